@@ -0,0 +1,207 @@
+// Package account bundles a signer, its address and a gRPC client into an
+// Account with the operations of a wallet: reading balances, sending coins
+// and objects, calling Move functions, and signing transactions and personal
+// messages. The signing methods return the same shapes as the Sui wallet
+// standard features sui:signTransaction and sui:signPersonalMessage.
+package account
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/open-move/sui-go-sdk/grpc"
+	"github.com/open-move/sui-go-sdk/keypair"
+	"github.com/open-move/sui-go-sdk/transaction"
+)
+
+// SUI is the coin type of the native token.
+const SUI = "0x2::sui::SUI"
+
+// Account is an address that can sign, backed by a client to reach the
+// network. It is safe for concurrent use if its signer is.
+type Account struct {
+	signer  keypair.Signer
+	address string
+	client  *grpc.Client
+}
+
+// SignedTransaction is a signed transaction in wallet standard form.
+type SignedTransaction struct {
+	// Bytes is the base64 encoded BCS TransactionData.
+	Bytes string `json:"bytes"`
+	// Signature is the base64 encoded serialized signature.
+	Signature string `json:"signature"`
+}
+
+// SignedMessage is a signed personal message in wallet standard form.
+type SignedMessage struct {
+	// Bytes is the base64 encoded message.
+	Bytes string `json:"bytes"`
+	// Signature is the base64 encoded serialized signature.
+	Signature string `json:"signature"`
+}
+
+// New returns the account controlled by signer. The client may be nil for
+// an account that only signs.
+func New(signer keypair.Signer, client *grpc.Client) (*Account, error) {
+	if signer == nil {
+		return nil, errors.New("nil signer")
+	}
+	address, err := signer.SuiAddress()
+	if err != nil {
+		return nil, fmt.Errorf("signer address: %w", err)
+	}
+	return &Account{signer: signer, address: address, client: client}, nil
+}
+
+// Address returns the account's Sui address.
+func (a *Account) Address() string {
+	return a.address
+}
+
+// Signer returns the signer that controls the account.
+func (a *Account) Signer() keypair.Signer {
+	return a.signer
+}
+
+// Client returns the client the account uses to reach the network.
+func (a *Account) Client() *grpc.Client {
+	return a.client
+}
+
+// Balance returns the account's total SUI balance in MIST.
+func (a *Account) Balance(ctx context.Context) (uint64, error) {
+	return a.CoinBalance(ctx, SUI)
+}
+
+// CoinBalance returns the account's total balance of coinType.
+func (a *Account) CoinBalance(ctx context.Context, coinType string) (uint64, error) {
+	if err := a.requireClient(); err != nil {
+		return 0, err
+	}
+	balance, err := a.client.GetBalance(ctx, a.address, coinType)
+	if err != nil {
+		return 0, err
+	}
+	return balance.GetBalance(), nil
+}
+
+// Transfer sends amount MIST to recipient and waits for the transaction to
+// be checkpointed.
+func (a *Account) Transfer(ctx context.Context, recipient string, amount uint64) (*grpc.ExecutionResult, error) {
+	if err := a.requireClient(); err != nil {
+		return nil, err
+	}
+	return a.client.SendSui(ctx, a.signer, recipient, amount, nil)
+}
+
+// TransferCoins sends amount of coinType to recipient and waits for the
+// transaction to be checkpointed.
+func (a *Account) TransferCoins(ctx context.Context, recipient string, coinType string, amount uint64) (*grpc.ExecutionResult, error) {
+	if err := a.requireClient(); err != nil {
+		return nil, err
+	}
+	return a.client.SendCoins(ctx, a.signer, recipient, coinType, amount, nil)
+}
+
+// TransferObject sends the object with objectID to recipient and waits for
+// the transaction to be checkpointed.
+func (a *Account) TransferObject(ctx context.Context, recipient string, objectID string) (*grpc.ExecutionResult, error) {
+	if err := a.requireClient(); err != nil {
+		return nil, err
+	}
+	return a.client.SendObject(ctx, a.signer, recipient, objectID, nil)
+}
+
+// Call executes a single Move call. Arguments are native Go values (or
+// transaction.Argument values), encoded against the function signature as
+// for transaction.MoveCall.Values.
+func (a *Account) Call(ctx context.Context, target string, args ...any) (*grpc.ExecutionResult, error) {
+	return a.CallGeneric(ctx, target, nil, args...)
+}
+
+// CallGeneric is Call for functions with type parameters.
+func (a *Account) CallGeneric(ctx context.Context, target string, typeArguments []string, args ...any) (*grpc.ExecutionResult, error) {
+	tx := transaction.New()
+	tx.MoveCall(transaction.MoveCall{
+		Target:        target,
+		TypeArguments: typeArguments,
+		Values:        args,
+	})
+	return a.Execute(ctx, tx)
+}
+
+// Execute builds tx with the account as sender, signs it, executes it and
+// waits for it to be checkpointed. This is the sui:signAndExecuteTransaction
+// feature of the wallet standard.
+func (a *Account) Execute(ctx context.Context, tx *transaction.Transaction) (*grpc.ExecutionResult, error) {
+	txBytes, signature, err := a.buildAndSign(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	return a.client.ExecuteTransactionBytes(ctx, txBytes, [][]byte{signature}, &grpc.ExecuteOptions{WaitForCheckpoint: true})
+}
+
+// SignTransaction builds tx with the account as sender and signs it without
+// executing it, e.g. to hand it to a sponsor or submit it later.
+func (a *Account) SignTransaction(ctx context.Context, tx *transaction.Transaction) (*SignedTransaction, error) {
+	txBytes, signature, err := a.buildAndSign(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	return &SignedTransaction{
+		Bytes:     base64.StdEncoding.EncodeToString(txBytes),
+		Signature: base64.StdEncoding.EncodeToString(signature),
+	}, nil
+}
+
+// SignMessage signs message as a personal message.
+func (a *Account) SignMessage(message []byte) (*SignedMessage, error) {
+	signature, err := a.signer.SignPersonalMessage(message)
+	if err != nil {
+		return nil, err
+	}
+	return &SignedMessage{
+		Bytes:     base64.StdEncoding.EncodeToString(message),
+		Signature: base64.StdEncoding.EncodeToString(signature),
+	}, nil
+}
+
+func (a *Account) buildAndSign(ctx context.Context, tx *transaction.Transaction) ([]byte, []byte, error) {
+	if err := a.requireClient(); err != nil {
+		return nil, nil, err
+	}
+	if tx == nil {
+		return nil, nil, transaction.ErrNilTransaction
+	}
+	if !tx.HasSender() {
+		tx.SetSender(a.address)
+	}
+	if err := tx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	resolver := grpc.NewResolver(a.client)
+	result, err := tx.Build(ctx, transaction.BuildOptions{Resolver: resolver, GasResolver: resolver})
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(result.TransactionBytes) == 0 {
+		return nil, nil, errors.New("built transaction missing data")
+	}
+
+	signature, err := a.signer.SignTransaction(result.TransactionBytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("sign transaction: %w", err)
+	}
+	return result.TransactionBytes, signature, nil
+}
+
+func (a *Account) requireClient() error {
+	if a.client == nil {
+		return errors.New("account has no client")
+	}
+	return nil
+}
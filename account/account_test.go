@@ -0,0 +1,86 @@
+package account
+
+import (
+	"context"
+	"encoding/base64"
+	"net"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/cryptography/ed25519"
+	"github.com/open-move/sui-go-sdk/grpc"
+	v2 "github.com/open-move/sui-go-sdk/proto/sui/rpc/v2"
+	"github.com/open-move/sui-go-sdk/utils"
+	googlegrpc "google.golang.org/grpc"
+)
+
+type stubStateServer struct {
+	v2.UnimplementedStateServiceServer
+	request *v2.GetBalanceRequest
+}
+
+func (s *stubStateServer) GetBalance(_ context.Context, req *v2.GetBalanceRequest) (*v2.GetBalanceResponse, error) {
+	s.request = req
+	return &v2.GetBalanceResponse{Balance: &v2.Balance{CoinType: req.CoinType, Balance: utils.Ptr(uint64(1_500))}}, nil
+}
+
+func TestAccount(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	server := googlegrpc.NewServer()
+	state := &stubStateServer{}
+	v2.RegisterStateServiceServer(server, state)
+	go server.Serve(lis)
+	defer server.Stop()
+
+	client, err := grpc.NewClient(context.Background(), lis.Addr().String())
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	defer client.Close()
+
+	kp, err := ed25519.Generate()
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	acct, err := New(kp, client)
+	if err != nil {
+		t.Fatalf("new account: %v", err)
+	}
+	want, _ := kp.SuiAddress()
+	if acct.Address() != want {
+		t.Fatalf("address %s, want %s", acct.Address(), want)
+	}
+
+	balance, err := acct.Balance(context.Background())
+	if err != nil {
+		t.Fatalf("balance: %v", err)
+	}
+	if balance != 1_500 || state.request.GetOwner() != want || state.request.GetCoinType() != SUI {
+		t.Fatalf("unexpected balance %d for %+v", balance, state.request)
+	}
+
+	signed, err := acct.SignMessage([]byte("hello"))
+	if err != nil {
+		t.Fatalf("sign message: %v", err)
+	}
+	signature, err := base64.StdEncoding.DecodeString(signed.Signature)
+	if err != nil {
+		t.Fatalf("decode signature: %v", err)
+	}
+	if err := kp.VerifyPersonalMessage([]byte("hello"), signature); err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if signed.Bytes != base64.StdEncoding.EncodeToString([]byte("hello")) {
+		t.Fatalf("unexpected message bytes %q", signed.Bytes)
+	}
+
+	offline, err := New(kp, nil)
+	if err != nil {
+		t.Fatalf("new offline account: %v", err)
+	}
+	if _, err := offline.Balance(context.Background()); err == nil {
+		t.Fatal("expected error without a client")
+	}
+}
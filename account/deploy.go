@@ -0,0 +1,246 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/open-move/sui-go-sdk/grpc"
+	"github.com/open-move/sui-go-sdk/transaction"
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/typetag"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+// UpgradeCapType is the type of the capability that authorizes upgrades of
+// a published package.
+const UpgradeCapType = "0x2::package::UpgradeCap"
+
+// PublishParams describes a package to publish, as printed by
+// `sui move build --dump-bytecode-as-base64` once decoded.
+type PublishParams struct {
+	Modules      [][]byte
+	Dependencies []string
+	// UpgradeCapRecipient receives the package's UpgradeCap. It defaults to
+	// the account.
+	UpgradeCapRecipient string
+}
+
+// InitSpec describes the transaction that initializes a freshly published
+// package. The zero value skips initialization.
+type InitSpec struct {
+	// Function is the function to call as "module::function" in the
+	// published package.
+	Function      string
+	TypeArguments []string
+	// Args are encoded as for Account.Call. A value returned by CreatedObject
+	// is replaced by the ID of the object the publish transaction created.
+	Args []any
+	// Build, when set, is used instead of Function to add the initialization
+	// commands to tx. It can read the package ID and the created objects
+	// from d.
+	Build func(tx *transaction.Transaction, d *Deployment) error
+}
+
+func (s InitSpec) empty() bool {
+	return s.Function == "" && s.Build == nil
+}
+
+// createdObject is a placeholder in InitSpec.Args.
+type createdObject string
+
+// CreatedObject refers, in InitSpec.Args, to the object of objectType that
+// the publish transaction created. objectType is matched as by
+// Deployment.Object.
+func CreatedObject(objectType string) any {
+	return createdObject(objectType)
+}
+
+// DeployedObject is an object created by a deployment.
+type DeployedObject struct {
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	Version uint64 `json:"version"`
+}
+
+// Deployment is the manifest of a published and initialized package.
+type Deployment struct {
+	Sender    string `json:"sender"`
+	PackageID string `json:"packageId"`
+	// UpgradeCap is the ID of the package's UpgradeCap.
+	UpgradeCap    string `json:"upgradeCap"`
+	PublishDigest string `json:"publishDigest"`
+	// Created are the objects the publish transaction created, such as the
+	// UpgradeCap and the objects made by the package's init functions.
+	Created []DeployedObject `json:"created"`
+	// InitDigest and InitCreated describe the initialization transaction.
+	// They are empty when there was none.
+	InitDigest  string           `json:"initDigest,omitempty"`
+	InitCreated []DeployedObject `json:"initCreated,omitempty"`
+
+	Publish *grpc.ExecutionResult `json:"-"`
+	Init    *grpc.ExecutionResult `json:"-"`
+}
+
+// Object returns the object of objectType that the deployment created,
+// searching the publish transaction first. objectType is a full Move type,
+// or "module::Struct" within the published package; type arguments are only
+// compared when objectType has them.
+func (d *Deployment) Object(objectType string) (DeployedObject, bool) {
+	want, err := d.structTag(objectType)
+	if err != nil {
+		return DeployedObject{}, false
+	}
+	for _, objects := range [][]DeployedObject{d.Created, d.InitCreated} {
+		for _, obj := range objects {
+			have, err := utils.ParseStructTag(obj.Type)
+			if err != nil {
+				continue
+			}
+			if len(want.TypeParams) == 0 {
+				have.TypeParams = nil
+			}
+			if typetag.TypeTagStruct(have).String() == typetag.TypeTagStruct(want).String() {
+				return obj, true
+			}
+		}
+	}
+	return DeployedObject{}, false
+}
+
+func (d *Deployment) structTag(objectType string) (typetag.StructTag, error) {
+	name, _, _ := strings.Cut(objectType, "<")
+	if strings.Count(name, "::") == 1 {
+		objectType = d.PackageID + "::" + objectType
+	}
+	return utils.ParseStructTag(objectType)
+}
+
+// DeployAndCall publishes a package, then calls its initialization function
+// with the new package ID and the objects the publish created, such as an
+// AdminCap, and returns the deployment manifest. Both transactions wait for
+// a checkpoint.
+//
+// If initialization fails after the package was published, the manifest of
+// the publish is returned together with the error.
+func (a *Account) DeployAndCall(ctx context.Context, params PublishParams, init InitSpec) (*Deployment, error) {
+	if len(params.Modules) == 0 {
+		return nil, errors.New("no modules to publish")
+	}
+	recipient := params.UpgradeCapRecipient
+	if recipient == "" {
+		recipient = a.address
+	}
+
+	tx := transaction.New()
+	upgradeCap := tx.Publish(transaction.PublishInput{Modules: params.Modules, Dependencies: params.Dependencies})
+	tx.TransferObjects(transaction.TransferObjects{
+		Objects: []transaction.Argument{upgradeCap.Arg()},
+		Address: tx.PureAddress(recipient),
+	})
+	published, err := a.Execute(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("publish: %w", err)
+	}
+	d, err := newDeployment(a.address, published)
+	if err != nil {
+		return nil, fmt.Errorf("publish: %w", err)
+	}
+	if init.empty() {
+		return d, nil
+	}
+
+	tx = transaction.New()
+	if init.Build != nil {
+		err = init.Build(tx, d)
+	} else {
+		err = d.addInitCall(tx, init)
+	}
+	if err != nil {
+		return d, fmt.Errorf("build init transaction: %w", err)
+	}
+	initialized, err := a.Execute(ctx, tx)
+	if err != nil {
+		return d, fmt.Errorf("init: %w", err)
+	}
+	d.Init = initialized
+	d.InitDigest = initialized.Digest
+	if initialized.Effects != nil {
+		if !initialized.Effects.Success {
+			return d, fmt.Errorf("init transaction %s failed: %s", initialized.Digest, initialized.Effects.Error)
+		}
+		d.InitCreated = createdObjects(initialized.Effects)
+	}
+	return d, nil
+}
+
+// newDeployment reads the package ID and created objects from the effects
+// of a publish transaction.
+func newDeployment(sender string, result *grpc.ExecutionResult) (*Deployment, error) {
+	effects := result.Effects
+	if effects == nil {
+		return nil, fmt.Errorf("transaction %s returned no effects", result.Digest)
+	}
+	if !effects.Success {
+		return nil, fmt.Errorf("transaction %s failed: %s", result.Digest, effects.Error)
+	}
+	d := &Deployment{
+		Sender:        sender,
+		PublishDigest: result.Digest,
+		Created:       createdObjects(effects),
+		Publish:       result,
+	}
+	for _, change := range effects.ChangesOfKind(types.PackagePublished) {
+		d.PackageID = change.ObjectID.String()
+	}
+	if d.PackageID == "" {
+		return nil, fmt.Errorf("transaction %s published no package", result.Digest)
+	}
+	if upgradeCap, ok := d.Object(UpgradeCapType); ok {
+		d.UpgradeCap = upgradeCap.ID
+	}
+	return d, nil
+}
+
+// addInitCall adds the call described by init, replacing CreatedObject
+// placeholders with object IDs.
+func (d *Deployment) addInitCall(tx *transaction.Transaction, init InitSpec) error {
+	module, function, ok := strings.Cut(init.Function, "::")
+	if !ok || module == "" || function == "" || strings.Contains(function, "::") {
+		return fmt.Errorf("init function %q is not module::function", init.Function)
+	}
+	args := make([]any, len(init.Args))
+	for i, arg := range init.Args {
+		placeholder, ok := arg.(createdObject)
+		if !ok {
+			args[i] = arg
+			continue
+		}
+		obj, found := d.Object(string(placeholder))
+		if !found {
+			return fmt.Errorf("argument %d: no %s was created", i, string(placeholder))
+		}
+		args[i] = obj.ID
+	}
+	tx.MoveCall(transaction.MoveCall{
+		Package:       d.PackageID,
+		Module:        module,
+		Function:      function,
+		TypeArguments: init.TypeArguments,
+		Values:        args,
+	})
+	return nil
+}
+
+func createdObjects(effects *types.TransactionEffects) []DeployedObject {
+	var objects []DeployedObject
+	for _, change := range effects.ChangesOfKind(types.ObjectCreated) {
+		obj := DeployedObject{ID: change.ObjectID.String(), Type: change.ObjectType}
+		if change.Output != nil {
+			obj.Version = change.Output.Version
+		}
+		objects = append(objects, obj)
+	}
+	return objects
+}
@@ -0,0 +1,81 @@
+package account
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/grpc"
+	"github.com/open-move/sui-go-sdk/transaction"
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+func TestNewDeployment(t *testing.T) {
+	pkg := utils.MustParseAddress("0xabc")
+	created := func(id, objectType string) types.ObjectChange {
+		return types.ObjectChange{
+			ObjectID:   utils.MustParseAddress(id),
+			Kind:       types.ObjectCreated,
+			ObjectType: objectType,
+			Output:     &types.ObjectRef{ObjectID: utils.MustParseAddress(id), Version: 4},
+		}
+	}
+	result := &grpc.ExecutionResult{Digest: "publish", Effects: &types.TransactionEffects{
+		Success: true,
+		Changes: []types.ObjectChange{
+			{ObjectID: utils.MustParseAddress("0x9"), Kind: types.ObjectMutated, ObjectType: "0x2::coin::Coin<0x2::sui::SUI>"},
+			{ObjectID: pkg, Kind: types.PackagePublished},
+			created("0x10", "0x0000000000000000000000000000000000000000000000000000000000000002::package::UpgradeCap"),
+			created("0x11", pkg.String()+"::admin::AdminCap"),
+			created("0x12", "0x2::coin::TreasuryCap<"+pkg.String()+"::token::TOKEN>"),
+		},
+	}}
+
+	d, err := newDeployment("0xa", result)
+	if err != nil {
+		t.Fatalf("new deployment: %v", err)
+	}
+	if d.PackageID != pkg.String() || d.PublishDigest != "publish" || len(d.Created) != 3 {
+		t.Fatalf("unexpected deployment %+v", d)
+	}
+	if d.UpgradeCap != utils.MustParseAddress("0x10").String() {
+		t.Fatalf("upgrade cap = %s", d.UpgradeCap)
+	}
+
+	for objectType, id := range map[string]string{
+		"admin::AdminCap":                             "0x11",
+		"0xabc::admin::AdminCap":                      "0x11",
+		"0x2::coin::TreasuryCap":                      "0x12",
+		"0x2::coin::TreasuryCap<0xabc::token::TOKEN>": "0x12",
+	} {
+		obj, ok := d.Object(objectType)
+		if !ok || obj.ID != utils.MustParseAddress(id).String() || obj.Version != 4 {
+			t.Errorf("Object(%q) = %+v, %v", objectType, obj, ok)
+		}
+	}
+	for _, objectType := range []string{"admin::OwnerCap", "0x2::coin::TreasuryCap<0x2::sui::SUI>", "not a type"} {
+		if obj, ok := d.Object(objectType); ok {
+			t.Errorf("Object(%q) = %+v, want none", objectType, obj)
+		}
+	}
+
+	tx := transaction.New()
+	if err := d.addInitCall(tx, InitSpec{Function: "admin::setup", Args: []any{CreatedObject("admin::AdminCap"), uint64(5)}}); err != nil {
+		t.Fatalf("add init call: %v", err)
+	}
+	if err := d.addInitCall(transaction.New(), InitSpec{Function: "admin::setup", Args: []any{CreatedObject("admin::OwnerCap")}}); err == nil || !strings.Contains(err.Error(), "OwnerCap") {
+		t.Fatalf("expected a missing object error, got %v", err)
+	}
+	if err := d.addInitCall(transaction.New(), InitSpec{Function: "setup"}); err == nil {
+		t.Fatal("expected an error for a function without a module")
+	}
+
+	result.Effects.Changes = result.Effects.Changes[:1]
+	if _, err := newDeployment("0xa", result); err == nil {
+		t.Fatal("expected an error without a published package")
+	}
+	result.Effects.Success = false
+	if _, err := newDeployment("0xa", result); err == nil {
+		t.Fatal("expected an error for a failed publish")
+	}
+}
@@ -0,0 +1,301 @@
+// Package analytics aggregates on-chain activity into reports, such as how
+// much gas an address spends and on which Move functions.
+package analytics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/open-move/sui-go-sdk/graphql"
+	"github.com/open-move/sui-go-sdk/transaction"
+	"github.com/open-move/sui-go-sdk/types"
+)
+
+// Targets for transactions that cannot be attributed to a Move function.
+const (
+	// NoMoveCall groups transactions without a MoveCall command, such as
+	// plain transfers and package publishes.
+	NoMoveCall = "(no move call)"
+	// UnknownTarget groups transactions whose data could not be decoded.
+	UnknownTarget = "(unknown)"
+)
+
+// gasReportPageSize is the number of transactions fetched per request.
+const gasReportPageSize = 50
+
+// Range bounds the transactions a report covers. Zero fields are unbounded.
+type Range struct {
+	// FromCheckpoint and ToCheckpoint bound the checkpoint sequence number,
+	// both inclusive.
+	FromCheckpoint *uint64
+	ToCheckpoint   *uint64
+	// Since and Until bound the transaction timestamp: Since is inclusive,
+	// Until exclusive.
+	Since time.Time
+	Until time.Time
+}
+
+// GasCost is an amount of gas in MIST, split into its components.
+type GasCost struct {
+	Computation uint64
+	Storage     uint64
+	Rebate      uint64
+	// NonRefundable is the part of Storage that will not be rebated when the
+	// objects are deleted.
+	NonRefundable uint64
+}
+
+// Net returns computation plus storage cost minus the rebate. It is
+// negative when deleting objects earned more than was spent.
+func (g GasCost) Net() int64 {
+	return int64(g.Computation) + int64(g.Storage) - int64(g.Rebate)
+}
+
+func (g *GasCost) add(summary *graphql.GasCostSummary) {
+	g.Computation += uint64(summary.ComputationCost)
+	g.Storage += uint64(summary.StorageCost)
+	g.Rebate += uint64(summary.StorageRebate)
+	g.NonRefundable += uint64(summary.NonRefundableStorageFee)
+}
+
+// TargetGas is the gas spent on transactions calling one Move function.
+type TargetGas struct {
+	// Target is the first function the transactions call, as
+	// "package::module::function", or NoMoveCall or UnknownTarget.
+	Target       string
+	Transactions int
+	Gas          GasCost
+}
+
+// Report is the gas an address paid over a range.
+type Report struct {
+	Address types.Address
+	// Transactions is the number of transactions whose gas the address paid.
+	Transactions int
+	// Failed is how many of them aborted; failed transactions still pay gas.
+	Failed int
+	// Sponsoring is how many of them other senders sent with the address as
+	// their gas sponsor.
+	Sponsoring int
+	// Sponsored counts transactions the address sent whose gas was paid by a
+	// sponsor. They are not included in the totals.
+	Sponsored int
+	Gas       GasCost
+	// ByTarget breaks Gas down by Move call target, most expensive first.
+	ByTarget []TargetGas
+	// FirstCheckpoint and LastCheckpoint are the checkpoints of the earliest
+	// and latest transactions counted, and First and Last their timestamps.
+	FirstCheckpoint uint64
+	LastCheckpoint  uint64
+	First           time.Time
+	Last            time.Time
+}
+
+// gasTransaction is the part of a transaction GasReport reads.
+type gasTransaction struct {
+	Digest         types.Digest     `json:"digest"`
+	TransactionBcs []byte           `json:"transactionBcs"`
+	Sender         *graphql.Address `json:"sender"`
+	GasInput       *struct {
+		GasSponsor *graphql.Address `json:"gasSponsor"`
+	} `json:"gasInput"`
+	Effects *struct {
+		Status     graphql.ExecutionStatus `json:"status"`
+		GasEffects *graphql.GasEffects     `json:"gasEffects"`
+		Checkpoint *struct {
+			SequenceNumber graphql.UInt53 `json:"sequenceNumber"`
+		} `json:"checkpoint"`
+		Timestamp *graphql.DateTime `json:"timestamp"`
+	} `json:"effects"`
+}
+
+// GasReport aggregates the gas address paid in r, both for the transactions
+// it sent and for those it sponsored, split into computation, storage and
+// rebates and grouped by the Move function each transaction calls first.
+func GasReport(ctx context.Context, client *graphql.Client, address types.Address, r Range) (*Report, error) {
+	if client == nil {
+		return nil, errors.New("nil client")
+	}
+	if r.FromCheckpoint != nil && r.ToCheckpoint != nil && *r.FromCheckpoint > *r.ToCheckpoint {
+		return nil, fmt.Errorf("invalid range: checkpoint %d is after %d", *r.FromCheckpoint, *r.ToCheckpoint)
+	}
+	if !r.Since.IsZero() && !r.Until.IsZero() && !r.Since.Before(r.Until) {
+		return nil, fmt.Errorf("invalid range: %s is not before %s", r.Since, r.Until)
+	}
+
+	// Sponsors have no filter of their own, but count as affected addresses.
+	filter := graphql.TransactionFilter{AffectedAddress: &address}
+	if r.FromCheckpoint != nil && *r.FromCheckpoint > 0 {
+		after := graphql.UInt53(*r.FromCheckpoint - 1)
+		filter.AfterCheckpoint = &after
+	}
+	if r.ToCheckpoint != nil {
+		before := graphql.UInt53(*r.ToCheckpoint + 1)
+		filter.BeforeCheckpoint = &before
+	}
+
+	report := &Report{Address: address}
+	targets := make(map[string]*TargetGas)
+	var after *string
+	for {
+		page, err := fetchGasTransactions(ctx, client, filter, after)
+		if err != nil {
+			return nil, err
+		}
+		for _, tx := range page.Nodes {
+			if tx.Effects == nil || tx.Effects.GasEffects == nil || tx.Effects.GasEffects.GasSummary == nil {
+				return nil, fmt.Errorf("transaction %s: missing gas summary", tx.Digest)
+			}
+			var timestamp time.Time
+			if tx.Effects.Timestamp != nil {
+				if timestamp, err = tx.Effects.Timestamp.Time(); err != nil {
+					return nil, fmt.Errorf("transaction %s: %w", tx.Digest, err)
+				}
+			}
+			// Transactions are listed oldest first, so nothing after Until
+			// can be in range.
+			if !r.Until.IsZero() && !timestamp.Before(r.Until) {
+				return report.finish(targets), nil
+			}
+			if !r.Since.IsZero() && timestamp.Before(r.Since) {
+				continue
+			}
+			report.add(targets, tx, timestamp)
+		}
+		if !page.PageInfo.HasNextPage || page.PageInfo.EndCursor == nil {
+			return report.finish(targets), nil
+		}
+		after = page.PageInfo.EndCursor
+	}
+}
+
+func fetchGasTransactions(ctx context.Context, client *graphql.Client, filter graphql.TransactionFilter, after *string) (*graphql.Connection[gasTransaction], error) {
+	query := `
+		query GasReport($filter: TransactionFilter, $first: Int, $after: String) {
+			transactions(filter: $filter, first: $first, after: $after) {
+				pageInfo { hasNextPage endCursor }
+				nodes {
+					digest
+					transactionBcs
+					sender { address }
+					gasInput { gasSponsor { address } }
+					effects {
+						status
+						gasEffects {
+							gasSummary {
+								computationCost
+								storageCost
+								storageRebate
+								nonRefundableStorageFee
+							}
+						}
+						checkpoint { sequenceNumber }
+						timestamp
+					}
+				}
+			}
+		}
+	`
+	vars := map[string]any{"filter": filter, "first": gasReportPageSize}
+	if after != nil {
+		vars["after"] = *after
+	}
+	var result struct {
+		Transactions *graphql.Connection[gasTransaction] `json:"transactions"`
+	}
+	if err := client.Execute(ctx, query, vars, &result); err != nil {
+		return nil, err
+	}
+	if result.Transactions == nil {
+		return nil, errors.New("transactions not returned")
+	}
+	return result.Transactions, nil
+}
+
+func (r *Report) add(targets map[string]*TargetGas, tx gasTransaction, timestamp time.Time) {
+	sent := tx.Sender != nil && tx.Sender.Address == r.Address
+	paid := sent
+	if tx.GasInput != nil && tx.GasInput.GasSponsor != nil {
+		paid = tx.GasInput.GasSponsor.Address == r.Address
+	}
+	switch {
+	case sent && !paid:
+		r.Sponsored++
+		return
+	case !paid:
+		// The address was only affected, such as a recipient.
+		return
+	}
+
+	summary := tx.Effects.GasEffects.GasSummary
+	r.Transactions++
+	if !sent {
+		r.Sponsoring++
+	}
+	if tx.Effects.Status == graphql.ExecutionStatusFailure {
+		r.Failed++
+	}
+	r.Gas.add(summary)
+
+	if tx.Effects.Checkpoint != nil {
+		checkpoint := uint64(tx.Effects.Checkpoint.SequenceNumber)
+		if r.Transactions == 1 || checkpoint < r.FirstCheckpoint {
+			r.FirstCheckpoint = checkpoint
+		}
+		r.LastCheckpoint = max(r.LastCheckpoint, checkpoint)
+	}
+	if !timestamp.IsZero() {
+		if r.First.IsZero() || timestamp.Before(r.First) {
+			r.First = timestamp
+		}
+		if timestamp.After(r.Last) {
+			r.Last = timestamp
+		}
+	}
+
+	target := moveCallTarget(tx.TransactionBcs)
+	entry, ok := targets[target]
+	if !ok {
+		entry = &TargetGas{Target: target}
+		targets[target] = entry
+	}
+	entry.Transactions++
+	entry.Gas.add(summary)
+}
+
+func (r *Report) finish(targets map[string]*TargetGas) *Report {
+	r.ByTarget = make([]TargetGas, 0, len(targets))
+	for _, entry := range targets {
+		r.ByTarget = append(r.ByTarget, *entry)
+	}
+	sort.Slice(r.ByTarget, func(i, j int) bool {
+		a, b := r.ByTarget[i], r.ByTarget[j]
+		if a.Gas.Net() != b.Gas.Net() {
+			return a.Gas.Net() > b.Gas.Net()
+		}
+		return a.Target < b.Target
+	})
+	return r
+}
+
+// moveCallTarget returns the first function a transaction calls.
+func moveCallTarget(txBytes []byte) string {
+	if len(txBytes) == 0 {
+		return UnknownTarget
+	}
+	data, err := transaction.DecodeTransactionData(txBytes)
+	if err != nil || data.V1 == nil {
+		return UnknownTarget
+	}
+	if ptb := data.V1.Kind.ProgrammableTransaction; ptb != nil {
+		for _, cmd := range ptb.Commands {
+			if call := cmd.MoveCall; call != nil {
+				return fmt.Sprintf("%s::%s::%s", call.Package, call.Module, call.Function)
+			}
+		}
+	}
+	return NoMoveCall
+}
@@ -0,0 +1,161 @@
+package analytics
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/open-move/sui-go-sdk/graphql"
+	"github.com/open-move/sui-go-sdk/transaction"
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+func buildTx(t *testing.T, target string) []byte {
+	t.Helper()
+	tx := transaction.New()
+	tx.SetSender("0xa").SetGasBudget(5000).SetGasPrice(1).SetGasPayment([]types.ObjectRef{
+		{ObjectID: utils.MustParseAddress("0x9"), Version: 3, Digest: types.Digest(bytes.Repeat([]byte{1}, 32))},
+	})
+	coin := tx.SplitCoins(transaction.SplitCoins{Coin: tx.Gas(), Amounts: []transaction.Argument{tx.PureU64(10)}})
+	if target != "" {
+		tx.MoveCall(transaction.MoveCall{Target: target, Arguments: []transaction.Argument{coin[0]}})
+	} else {
+		tx.TransferObjects(transaction.TransferObjects{Objects: []transaction.Argument{coin[0]}, Address: tx.PureAddress("0xb")})
+	}
+	txBytes, err := graphql.BuildTransaction(context.Background(), tx, transaction.BuildOptions{})
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	return txBytes
+}
+
+func gasNode(txBytes []byte, sender, sponsor string, status string, checkpoint int, timestamp time.Time, computation, storage, rebate int) map[string]any {
+	return map[string]any{
+		"digest":         "11111111111111111111111111111111",
+		"transactionBcs": txBytes,
+		"sender":         map[string]any{"address": sender},
+		"gasInput":       map[string]any{"gasSponsor": map[string]any{"address": sponsor}},
+		"effects": map[string]any{
+			"status": status,
+			"gasEffects": map[string]any{"gasSummary": map[string]any{
+				"computationCost":         computation,
+				"storageCost":             storage,
+				"storageRebate":           rebate,
+				"nonRefundableStorageFee": storage / 100,
+			}},
+			"checkpoint": map[string]any{"sequenceNumber": checkpoint},
+			"timestamp":  graphql.NewDateTime(timestamp),
+		},
+	}
+}
+
+func TestGasReport(t *testing.T) {
+	address := utils.MustParseAddress("0xa")
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	join := buildTx(t, "0x2::coin::join")
+	mint := buildTx(t, "0x3::nft::mint")
+	transfer := buildTx(t, "")
+
+	self, other := address.String(), utils.MustParseAddress("0x6").String()
+	pages := [][]map[string]any{
+		{
+			gasNode(nil, self, self, "SUCCESS", 9, start.Add(-time.Hour), 1, 1, 0),
+			gasNode(join, self, self, "SUCCESS", 10, start, 1000, 5000, 2000),
+			gasNode(transfer, self, self, "FAILURE", 11, start.Add(time.Minute), 800, 0, 0),
+			// Sponsored by the address for another sender.
+			gasNode(mint, other, self, "SUCCESS", 11, start.Add(time.Minute), 500, 500, 0),
+		},
+		{
+			gasNode(mint, self, "0x5", "SUCCESS", 12, start.Add(2*time.Minute), 9000, 9000, 0),
+			// Only received something from another sender.
+			gasNode(transfer, other, other, "SUCCESS", 12, start.Add(2*time.Minute), 7000, 7000, 0),
+			gasNode(join, self, self, "SUCCESS", 13, start.Add(3*time.Minute), 1000, 3000, 1000),
+			gasNode(mint, self, self, "SUCCESS", 14, start.Add(time.Hour), 1000, 1000, 0),
+		},
+	}
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables map[string]any `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		filter := req.Variables["filter"].(map[string]any)
+		if filter["affectedAddress"] != address.String() || filter["afterCheckpoint"] != float64(4) || filter["beforeCheckpoint"] != float64(21) {
+			t.Errorf("unexpected filter %v", filter)
+		}
+		page := 0
+		if req.Variables["after"] == "page1" {
+			page = 1
+		}
+		requests++
+		json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"transactions": map[string]any{
+			"pageInfo": map[string]any{"hasNextPage": page == 0, "endCursor": "page1"},
+			"nodes":    pages[page],
+		}}})
+	}))
+	defer server.Close()
+
+	client := graphql.NewClient(graphql.WithEndpoint(server.URL))
+	report, err := GasReport(context.Background(), client, address, Range{
+		FromCheckpoint: utils.Ptr(uint64(5)),
+		ToCheckpoint:   utils.Ptr(uint64(20)),
+		Since:          start,
+		Until:          start.Add(30 * time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("gas report: %v", err)
+	}
+
+	if requests != 2 {
+		t.Errorf("requests = %d, want 2", requests)
+	}
+	if report.Transactions != 4 || report.Failed != 1 || report.Sponsoring != 1 || report.Sponsored != 1 {
+		t.Errorf("counts = %d/%d/%d/%d, want 4/1/1/1", report.Transactions, report.Failed, report.Sponsoring, report.Sponsored)
+	}
+	wantTotal := GasCost{Computation: 3300, Storage: 8500, Rebate: 3000, NonRefundable: 85}
+	if report.Gas != wantTotal {
+		t.Errorf("total = %+v, want %+v", report.Gas, wantTotal)
+	}
+	if report.Gas.Net() != 8800 {
+		t.Errorf("net = %d, want 8800", report.Gas.Net())
+	}
+	if report.FirstCheckpoint != 10 || report.LastCheckpoint != 13 {
+		t.Errorf("checkpoints = %d..%d, want 10..13", report.FirstCheckpoint, report.LastCheckpoint)
+	}
+	if !report.First.Equal(start) || !report.Last.Equal(start.Add(3*time.Minute)) {
+		t.Errorf("times = %s..%s", report.First, report.Last)
+	}
+
+	joinTarget := fmt.Sprintf("%s::coin::join", utils.MustParseAddress("0x2"))
+	want := []TargetGas{
+		{Target: joinTarget, Transactions: 2, Gas: GasCost{Computation: 2000, Storage: 8000, Rebate: 3000, NonRefundable: 80}},
+		{Target: fmt.Sprintf("%s::nft::mint", utils.MustParseAddress("0x3")), Transactions: 1, Gas: GasCost{Computation: 500, Storage: 500, NonRefundable: 5}},
+		{Target: NoMoveCall, Transactions: 1, Gas: GasCost{Computation: 800}},
+	}
+	if len(report.ByTarget) != len(want) {
+		t.Fatalf("by target = %+v, want %+v", report.ByTarget, want)
+	}
+	for i := range want {
+		if report.ByTarget[i] != want[i] {
+			t.Errorf("by target[%d] = %+v, want %+v", i, report.ByTarget[i], want[i])
+		}
+	}
+}
+
+func TestGasReportInvalidRange(t *testing.T) {
+	client := graphql.NewClient(graphql.WithEndpoint("http://127.0.0.1:0"))
+	_, err := GasReport(context.Background(), client, utils.MustParseAddress("0xa"), Range{
+		FromCheckpoint: utils.Ptr(uint64(10)),
+		ToCheckpoint:   utils.Ptr(uint64(5)),
+	})
+	if err == nil {
+		t.Fatal("expected an error for an inverted range")
+	}
+}
@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestAPIKey(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodPost, "https://example.com", nil)
+	if err := APIKey("x-api-key", "secret").Apply(req); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if got := req.Header.Get("X-Api-Key"); got != "secret" {
+		t.Fatalf("unexpected header %q", got)
+	}
+}
+
+func TestJWTRefreshesBeforeExpiry(t *testing.T) {
+	now := time.Unix(1_000, 0)
+	fetches := 0
+	jwt := NewJWT(func(context.Context) (string, time.Time, error) {
+		fetches++
+		return "token-" + string(rune('0'+fetches)), now.Add(time.Minute), nil
+	})
+	jwt.now = func() time.Time { return now }
+
+	apply := func() string {
+		t.Helper()
+		req, _ := http.NewRequest(http.MethodPost, "https://example.com", nil)
+		if err := jwt.Apply(req); err != nil {
+			t.Fatalf("apply: %v", err)
+		}
+		return req.Header.Get("Authorization")
+	}
+
+	if got := apply(); got != "Bearer token-1" {
+		t.Fatalf("unexpected header %q", got)
+	}
+	now = now.Add(20 * time.Second)
+	if got := apply(); got != "Bearer token-1" || fetches != 1 {
+		t.Fatalf("expected the cached token, got %q after %d fetches", got, fetches)
+	}
+	// Within DefaultLeeway of the expiry the token is replaced.
+	now = now.Add(20 * time.Second)
+	if got := apply(); got != "Bearer token-2" {
+		t.Fatalf("expected a refreshed token, got %q", got)
+	}
+
+	if err := jwt.Refresh(context.Background()); err != nil || fetches != 3 {
+		t.Fatalf("refresh: %v after %d fetches", err, fetches)
+	}
+}
+
+func TestJWTSourceError(t *testing.T) {
+	jwt := NewJWT(func(context.Context) (string, time.Time, error) {
+		return "", time.Time{}, errors.New("offline")
+	})
+	req, _ := http.NewRequest(http.MethodPost, "https://example.com", nil)
+	if err := jwt.Apply(req); err == nil {
+		t.Fatal("expected the source error")
+	}
+}
+
+func TestReadBody(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodPost, "https://example.com", bytes.NewReader([]byte("payload")))
+	for range 2 {
+		body, err := ReadBody(req)
+		if err != nil || string(body) != "payload" {
+			t.Fatalf("unexpected body %q (%v)", body, err)
+		}
+	}
+}
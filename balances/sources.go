@@ -0,0 +1,78 @@
+package balances
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/open-move/sui-go-sdk/graphql"
+	"github.com/open-move/sui-go-sdk/grpc"
+	"github.com/open-move/sui-go-sdk/types"
+)
+
+var (
+	_ Source = (*GraphQLSource)(nil)
+	_ Source = (*GRPCSource)(nil)
+)
+
+// GraphQLSource reads balances through the Sui GraphQL API.
+type GraphQLSource struct {
+	client *graphql.Client
+}
+
+// NewGraphQLSource returns a source backed by client.
+func NewGraphQLSource(client *graphql.Client) *GraphQLSource {
+	return &GraphQLSource{client: client}
+}
+
+// AllBalances implements Source.
+func (s *GraphQLSource) AllBalances(ctx context.Context, owner types.Address) (map[string]*big.Int, error) {
+	if s == nil || s.client == nil {
+		return nil, errors.New("nil client")
+	}
+	balances, err := s.client.GetAllBalances(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]*big.Int, len(balances))
+	for _, balance := range balances {
+		if balance.CoinType == nil {
+			continue
+		}
+		amount, ok := balance.TotalBalance.ToBigInt()
+		if !ok {
+			return nil, fmt.Errorf("invalid balance %q for %s", balance.TotalBalance, balance.CoinType.Repr)
+		}
+		out[balance.CoinType.Repr] = amount
+	}
+	return out, nil
+}
+
+// GRPCSource reads balances through a fullnode's gRPC API.
+type GRPCSource struct {
+	client *grpc.Client
+}
+
+// NewGRPCSource returns a source backed by client.
+func NewGRPCSource(client *grpc.Client) *GRPCSource {
+	return &GRPCSource{client: client}
+}
+
+// AllBalances implements Source.
+func (s *GRPCSource) AllBalances(ctx context.Context, owner types.Address) (map[string]*big.Int, error) {
+	if s == nil || s.client == nil {
+		return nil, errors.New("nil client")
+	}
+	balances, err := s.client.GetAllBalances(ctx, owner.String())
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]*big.Int, len(balances))
+	for _, balance := range balances {
+		out[balance.GetCoinType()] = new(big.Int).SetUint64(balance.GetBalance())
+	}
+	return out, nil
+}
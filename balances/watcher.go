@@ -0,0 +1,276 @@
+// Package balances watches the coin balances of a set of addresses. A Watcher
+// polls every balance on an interval, reports what changed, and raises alerts
+// when a balance crosses a configured threshold, such as a relayer or gas
+// sponsor running low on SUI.
+package balances
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/open-move/sui-go-sdk/graphql"
+	"github.com/open-move/sui-go-sdk/types"
+)
+
+// DefaultInterval is how often balances are polled when no interval is set.
+const DefaultInterval = 30 * time.Second
+
+// SUI is the coin type of the native token.
+const SUI = "0x0000000000000000000000000000000000000000000000000000000000000002::sui::SUI"
+
+// Source reads every coin balance of an address.
+type Source interface {
+	// AllBalances returns owner's total balance per coin type.
+	AllBalances(ctx context.Context, owner types.Address) (map[string]*big.Int, error)
+}
+
+// Change reports a balance that differs from the previous poll.
+type Change struct {
+	Owner    types.Address
+	CoinType string
+	// Previous is zero when the coin type was not held at the previous poll.
+	Previous *big.Int
+	Current  *big.Int
+	// Delta is Current minus Previous.
+	Delta *big.Int
+}
+
+// Threshold raises an alert when a balance moves below Below or above Above.
+// Either bound may be nil.
+type Threshold struct {
+	// Name identifies the threshold in alerts, e.g. "gas runway".
+	Name     string
+	Owner    types.Address
+	CoinType string
+	Below    *big.Int
+	Above    *big.Int
+}
+
+// Alert reports a balance crossing a threshold. An alert fires once when the
+// balance enters the breached range and again, with Recovered set, when it
+// leaves it. A balance already breached at the first poll alerts immediately.
+type Alert struct {
+	Threshold Threshold
+	Balance   *big.Int
+	Recovered bool
+}
+
+// Option configures a Watcher.
+type Option func(*Watcher)
+
+// WithInterval sets how often balances are polled.
+func WithInterval(interval time.Duration) Option {
+	return func(w *Watcher) {
+		if interval > 0 {
+			w.interval = interval
+		}
+	}
+}
+
+// WithThreshold adds a threshold to check after every poll.
+func WithThreshold(threshold Threshold) Option {
+	return func(w *Watcher) {
+		w.thresholds = append(w.thresholds, threshold)
+	}
+}
+
+// OnChange sets the callback for balance changes. It is not called for the
+// balances read by the first poll.
+func OnChange(fn func(Change)) Option {
+	return func(w *Watcher) {
+		w.onChange = fn
+	}
+}
+
+// OnAlert sets the callback for threshold crossings.
+func OnAlert(fn func(Alert)) Option {
+	return func(w *Watcher) {
+		w.onAlert = fn
+	}
+}
+
+// OnError sets the callback for failed polls. Watching continues after
+// errors.
+func OnError(fn func(owner types.Address, err error)) Option {
+	return func(w *Watcher) {
+		w.onError = fn
+	}
+}
+
+// Watcher polls the balances of a fixed set of addresses. Callbacks run on
+// the polling goroutine, one at a time.
+type Watcher struct {
+	source     Source
+	owners     []types.Address
+	interval   time.Duration
+	thresholds []Threshold
+	onChange   func(Change)
+	onAlert    func(Alert)
+	onError    func(types.Address, error)
+
+	mu       sync.RWMutex
+	balances map[types.Address]map[string]*big.Int
+	breached []bool
+}
+
+// New returns a Watcher for owners that reads balances from source.
+func New(source Source, owners []types.Address, opts ...Option) (*Watcher, error) {
+	if source == nil {
+		return nil, errors.New("nil balance source")
+	}
+	if len(owners) == 0 {
+		return nil, errors.New("no addresses to watch")
+	}
+
+	w := &Watcher{
+		source:   source,
+		owners:   append([]types.Address(nil), owners...),
+		interval: DefaultInterval,
+		balances: make(map[types.Address]map[string]*big.Int),
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	for i, threshold := range w.thresholds {
+		coinType, err := graphql.NormalizeTypeTag(threshold.CoinType)
+		if err != nil {
+			return nil, err
+		}
+		w.thresholds[i].CoinType = coinType
+	}
+	w.breached = make([]bool, len(w.thresholds))
+	return w, nil
+}
+
+// Run polls immediately and then every interval until ctx is done. It
+// returns ctx's error.
+func (w *Watcher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.Poll(ctx)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll reads every watched balance once and fires the callbacks. Failures
+// are reported to the OnError callback and leave that owner's last known
+// balances in place.
+func (w *Watcher) Poll(ctx context.Context) {
+	for _, owner := range w.owners {
+		current, err := w.source.AllBalances(ctx, owner)
+		if err != nil {
+			if w.onError != nil && ctx.Err() == nil {
+				w.onError(owner, err)
+			}
+			continue
+		}
+		current = normalizeBalances(current)
+
+		w.mu.Lock()
+		previous, seen := w.balances[owner]
+		w.balances[owner] = current
+		w.mu.Unlock()
+
+		if seen && w.onChange != nil {
+			for _, change := range diff(owner, previous, current) {
+				w.onChange(change)
+			}
+		}
+		w.checkThresholds(owner, current)
+	}
+}
+
+// Balance returns the last polled balance of coinType for owner.
+func (w *Watcher) Balance(owner types.Address, coinType string) (*big.Int, bool) {
+	coinType, err := graphql.NormalizeTypeTag(coinType)
+	if err != nil {
+		return nil, false
+	}
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	balances, ok := w.balances[owner]
+	if !ok {
+		return nil, false
+	}
+	if balance, ok := balances[coinType]; ok {
+		return new(big.Int).Set(balance), true
+	}
+	return new(big.Int), true
+}
+
+func (w *Watcher) checkThresholds(owner types.Address, current map[string]*big.Int) {
+	for i, threshold := range w.thresholds {
+		if threshold.Owner != owner {
+			continue
+		}
+		balance := current[threshold.CoinType]
+		if balance == nil {
+			balance = new(big.Int)
+		}
+		breached := (threshold.Below != nil && balance.Cmp(threshold.Below) < 0) ||
+			(threshold.Above != nil && balance.Cmp(threshold.Above) > 0)
+		if breached == w.breached[i] {
+			continue
+		}
+		w.breached[i] = breached
+		if w.onAlert != nil {
+			w.onAlert(Alert{Threshold: threshold, Balance: new(big.Int).Set(balance), Recovered: !breached})
+		}
+	}
+}
+
+// diff returns the changes between two polls, treating missing coin types as
+// a zero balance.
+func diff(owner types.Address, previous, current map[string]*big.Int) []Change {
+	var changes []Change
+	report := func(coinType string, prev, cur *big.Int) {
+		if prev == nil {
+			prev = new(big.Int)
+		}
+		if cur == nil {
+			cur = new(big.Int)
+		}
+		if prev.Cmp(cur) == 0 {
+			return
+		}
+		changes = append(changes, Change{
+			Owner:    owner,
+			CoinType: coinType,
+			Previous: prev,
+			Current:  cur,
+			Delta:    new(big.Int).Sub(cur, prev),
+		})
+	}
+	for coinType, cur := range current {
+		report(coinType, previous[coinType], cur)
+	}
+	for coinType, prev := range previous {
+		if _, ok := current[coinType]; !ok {
+			report(coinType, prev, nil)
+		}
+	}
+	return changes
+}
+
+// normalizeBalances keys balances by normalized coin type so sources that
+// format types differently compare equal.
+func normalizeBalances(balances map[string]*big.Int) map[string]*big.Int {
+	out := make(map[string]*big.Int, len(balances))
+	for coinType, balance := range balances {
+		if normalized, err := graphql.NormalizeTypeTag(coinType); err == nil {
+			coinType = normalized
+		}
+		out[coinType] = balance
+	}
+	return out
+}
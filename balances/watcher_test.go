@@ -0,0 +1,91 @@
+package balances
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/graphql"
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+type scriptedSource struct {
+	polls []map[string]*big.Int
+	errs  []error
+	calls int
+}
+
+func (s *scriptedSource) AllBalances(context.Context, types.Address) (map[string]*big.Int, error) {
+	i := min(s.calls, len(s.polls)-1)
+	s.calls++
+	return s.polls[i], s.errs[i]
+}
+
+func TestSUIIsNormalized(t *testing.T) {
+	normalized, err := graphql.NormalizeTypeTag("0x2::sui::SUI")
+	if err != nil || normalized != SUI {
+		t.Fatalf("SUI = %q, normalized %q (%v)", SUI, normalized, err)
+	}
+}
+
+func TestWatcherChangesAndAlerts(t *testing.T) {
+	owner := utils.MustParseAddress("0xa")
+	source := &scriptedSource{
+		polls: []map[string]*big.Int{
+			{"0x2::sui::SUI": big.NewInt(500), "0x5::usdc::USDC": big.NewInt(7)},
+			{"0x2::sui::SUI": big.NewInt(80), "0x5::usdc::USDC": big.NewInt(7)},
+			nil,
+			{"0x2::sui::SUI": big.NewInt(300)},
+		},
+		errs: []error{nil, nil, errors.New("unavailable"), nil},
+	}
+
+	var changes []Change
+	var alerts []Alert
+	var failures int
+	watcher, err := New(source, []types.Address{owner},
+		WithThreshold(Threshold{Name: "gas runway", Owner: owner, CoinType: "0x2::sui::SUI", Below: big.NewInt(100)}),
+		OnChange(func(c Change) { changes = append(changes, c) }),
+		OnAlert(func(a Alert) { alerts = append(alerts, a) }),
+		OnError(func(types.Address, error) { failures++ }),
+	)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	ctx := context.Background()
+	watcher.Poll(ctx)
+	if len(changes) != 0 || len(alerts) != 0 {
+		t.Fatalf("first poll should only set the baseline: %v %v", changes, alerts)
+	}
+
+	watcher.Poll(ctx)
+	if len(changes) != 1 || changes[0].CoinType != SUI || changes[0].Delta.Int64() != -420 {
+		t.Fatalf("unexpected changes %+v", changes)
+	}
+	if len(alerts) != 1 || alerts[0].Recovered || alerts[0].Balance.Int64() != 80 {
+		t.Fatalf("expected low balance alert, got %+v", alerts)
+	}
+
+	watcher.Poll(ctx)
+	if failures != 1 {
+		t.Fatalf("expected one failure, got %d", failures)
+	}
+	if balance, ok := watcher.Balance(owner, "0x2::sui::SUI"); !ok || balance.Int64() != 80 {
+		t.Fatalf("failed poll should keep the last balance, got %v", balance)
+	}
+
+	changes = nil
+	watcher.Poll(ctx)
+	if len(changes) != 2 {
+		t.Fatalf("expected SUI and USDC changes, got %+v", changes)
+	}
+	if len(alerts) != 2 || !alerts[1].Recovered {
+		t.Fatalf("expected recovery alert, got %+v", alerts)
+	}
+	if balance, ok := watcher.Balance(owner, "0x5::usdc::USDC"); !ok || balance.Sign() != 0 {
+		t.Fatalf("expected zero USDC balance, got %v", balance)
+	}
+}
@@ -0,0 +1,215 @@
+// Package bcsreg decodes Move values and objects into Go structs. A Registry
+// maps Move struct types to Go types with the same BCS layout, so the BCS
+// returned by the GraphQL API (MoveValue.Bcs and Object.ObjectBcs) can be
+// decoded without fetching type layouts:
+//
+//	coin, err := bcsreg.DecodeObject[bcsreg.Coin](obj)
+//
+// The Default registry knows the framework types Coin, Balance, StakedSui,
+// Kiosk and Table; applications register their own with Register.
+package bcsreg
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	bcs "github.com/iotaledger/bcs-go"
+
+	"github.com/open-move/sui-go-sdk/graphql"
+)
+
+var (
+	// ErrUnregistered reports a Move type with no registered Go type.
+	ErrUnregistered = errors.New("bcsreg: type not registered")
+	// ErrNoContents reports an object or value selected without BCS or JSON
+	// contents.
+	ErrNoContents = errors.New("bcsreg: no contents to decode")
+)
+
+// Registry maps Move struct types to Go types. A type registered without
+// type arguments ("0x2::coin::Coin") matches every instantiation; one
+// registered with them matches only that instantiation and takes
+// precedence. It is safe for concurrent use.
+type Registry struct {
+	mu    sync.RWMutex
+	types map[string]reflect.Type
+}
+
+// New returns a registry holding the framework types.
+func New() *Registry {
+	r := &Registry{types: make(map[string]reflect.Type)}
+	registerFramework(r)
+	return r
+}
+
+// Default is the registry used by the package-level functions.
+var Default = New()
+
+// Register maps the Move type moveType to T, which must list the Move
+// struct's fields in declaration order with Go types of the same BCS
+// encoding. It replaces any earlier registration of moveType.
+func Register[T any](r *Registry, moveType string) error {
+	key, err := normalizeType(moveType)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	r.types[key] = reflect.TypeFor[T]()
+	r.mu.Unlock()
+	return nil
+}
+
+// Lookup returns the Go type registered for moveType, trying the exact
+// instantiation before the generic type.
+func (r *Registry) Lookup(moveType string) (reflect.Type, bool) {
+	key, err := normalizeType(moveType)
+	if err != nil {
+		return nil, false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if t, ok := r.types[key]; ok {
+		return t, true
+	}
+	base, _, generic := strings.Cut(key, "<")
+	if !generic {
+		return nil, false
+	}
+	t, ok := r.types[base]
+	return t, ok
+}
+
+// Decode decodes the BCS encoding of a value of moveType into a new value of
+// its registered Go type.
+func (r *Registry) Decode(moveType string, data []byte) (any, error) {
+	t, ok := r.Lookup(moveType)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnregistered, moveType)
+	}
+	v := reflect.New(t)
+	if err := unmarshal(data, v.Interface()); err != nil {
+		return nil, fmt.Errorf("bcsreg: decode %s: %w", moveType, err)
+	}
+	return v.Elem().Interface(), nil
+}
+
+// DecodeValue decodes a Move value into its registered Go type, using its
+// BCS encoding.
+func (r *Registry) DecodeValue(mv graphql.MoveValue) (any, error) {
+	if len(mv.Bcs) == 0 {
+		return nil, ErrNoContents
+	}
+	return r.Decode(mv.Type.Repr, mv.Bcs)
+}
+
+// DecodeObject decodes the contents of a Move object into its registered Go
+// type, from the contents' BCS or else from the object's BCS.
+func (r *Registry) DecodeObject(obj graphql.Object) (any, error) {
+	moveType, data, err := objectBcs(obj)
+	if err != nil {
+		return nil, err
+	}
+	return r.Decode(moveType, data)
+}
+
+// DecodeValue decodes mv into T with the Default registry. See DecodeObject.
+func DecodeValue[T any](mv graphql.MoveValue) (T, error) {
+	return DecodeValueWith[T](Default, mv)
+}
+
+// DecodeValueWith decodes mv into T with registry r. See DecodeObject.
+func DecodeValueWith[T any](r *Registry, mv graphql.MoveValue) (T, error) {
+	return decodeInto[T](r, mv.Type.Repr, mv.Bcs, mv)
+}
+
+// DecodeObject decodes the contents of a Move object into T with the Default
+// registry. BCS is used when T is registered for the object's type, and JSON
+// (see graphql.DecodeMoveValue) otherwise or when the object was selected
+// without BCS. As a last resort BCS is decoded into T unregistered, which
+// works for any T that mirrors the Move struct's layout.
+func DecodeObject[T any](obj graphql.Object) (T, error) {
+	return DecodeObjectWith[T](Default, obj)
+}
+
+// DecodeObjectWith decodes the contents of a Move object into T with
+// registry r. See DecodeObject.
+func DecodeObjectWith[T any](r *Registry, obj graphql.Object) (T, error) {
+	var contents graphql.MoveValue
+	if obj.AsMoveObject != nil && obj.AsMoveObject.Contents != nil {
+		contents = *obj.AsMoveObject.Contents
+	}
+	moveType, data, err := objectBcs(obj)
+	if err != nil && !errors.Is(err, ErrNoContents) {
+		var zero T
+		return zero, err
+	}
+	if moveType == "" {
+		moveType = contents.Type.Repr
+	}
+	return decodeInto[T](r, moveType, data, contents)
+}
+
+// decodeInto decodes data, the BCS of a value of moveType, into T, falling
+// back to the JSON of contents.
+func decodeInto[T any](r *Registry, moveType string, data []byte, contents graphql.MoveValue) (T, error) {
+	var out T
+	registered := false
+	if t, ok := r.Lookup(moveType); ok && t == reflect.TypeFor[T]() {
+		registered = true
+	}
+
+	if registered && len(data) > 0 {
+		if err := unmarshal(data, &out); err == nil {
+			return out, nil
+		} else if len(contents.Json) == 0 {
+			return out, fmt.Errorf("bcsreg: decode %s: %w", moveType, err)
+		}
+	}
+	if len(contents.Json) > 0 {
+		return graphql.DecodeMoveValue[T](contents)
+	}
+	if len(data) == 0 {
+		return out, ErrNoContents
+	}
+	if err := unmarshal(data, &out); err != nil {
+		return out, fmt.Errorf("bcsreg: decode %s: %w", moveType, err)
+	}
+	return out, nil
+}
+
+// objectBcs returns the type and BCS contents of a Move object.
+func objectBcs(obj graphql.Object) (string, []byte, error) {
+	if move := obj.AsMoveObject; move != nil && move.Contents != nil && len(move.Contents.Bcs) > 0 {
+		return move.Contents.Type.Repr, move.Contents.Bcs, nil
+	}
+	if obj.ObjectBcs != nil && len(*obj.ObjectBcs) > 0 {
+		return ParseObject(*obj.ObjectBcs)
+	}
+	return "", nil, ErrNoContents
+}
+
+// unmarshal decodes data into v and rejects trailing bytes.
+func unmarshal(data []byte, v any) error {
+	r := bytes.NewReader(data)
+	d := bcs.NewDecoder(r)
+	d.Decode(v)
+	if err := d.Err(); err != nil {
+		return err
+	}
+	if r.Len() > 0 {
+		return fmt.Errorf("%d excess bytes", r.Len())
+	}
+	return nil
+}
+
+func normalizeType(moveType string) (string, error) {
+	normalized, err := graphql.NormalizeTypeTag(moveType)
+	if err != nil {
+		return "", fmt.Errorf("bcsreg: %w", err)
+	}
+	return normalized, nil
+}
@@ -0,0 +1,138 @@
+package bcsreg
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	bcs "github.com/iotaledger/bcs-go"
+
+	"github.com/open-move/sui-go-sdk/graphql"
+	"github.com/open-move/sui-go-sdk/typetag"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+func coinBcs(t *testing.T, coin Coin) []byte {
+	t.Helper()
+	data, err := bcs.Marshal(&coin)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	return data
+}
+
+// objectBytes encodes a Move object holding contents: the Move data
+// variant, the object type, has_public_transfer, version and contents.
+func objectBytes(t *testing.T, typeVariant byte, tag *typetag.StructTag, contents []byte) []byte {
+	t.Helper()
+	out := []byte{0, typeVariant}
+	if tag != nil {
+		encoded, err := bcs.Marshal(tag)
+		if err != nil {
+			t.Fatalf("marshal tag: %v", err)
+		}
+		out = append(out, encoded...)
+	}
+	out = append(out, 1, 7, 0, 0, 0, 0, 0, 0, 0, byte(len(contents)))
+	return append(out, contents...)
+}
+
+func TestDecodeFrameworkTypes(t *testing.T) {
+	want := Coin{ID: utils.MustParseAddress("0xc0"), Balance: 1_500}
+	value := graphql.MoveValue{Type: graphql.MoveType{Repr: "0x2::coin::Coin<0x2::sui::SUI>"}, Bcs: coinBcs(t, want)}
+
+	decoded, err := Default.DecodeValue(value)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if decoded != want {
+		t.Fatalf("expected %+v, got %+v", want, decoded)
+	}
+
+	typed, err := DecodeValue[Coin](value)
+	if err != nil || typed != want {
+		t.Fatalf("expected %+v, got %+v (%v)", want, typed, err)
+	}
+
+	if _, err := Default.Decode("0x2::coin::Coin", []byte{1, 2}); err == nil {
+		t.Fatal("expected an error for truncated BCS")
+	}
+	if _, err := Default.Decode("0x9::pool::Pool", nil); !errors.Is(err, ErrUnregistered) {
+		t.Fatalf("expected ErrUnregistered, got %v", err)
+	}
+}
+
+func TestRegisterExactInstantiation(t *testing.T) {
+	type wrapped struct {
+		ID    [32]byte
+		Value uint64
+	}
+	r := New()
+	if err := Register[wrapped](r, "0x2::coin::Coin<0x5::usdc::USDC>"); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	if typ, _ := r.Lookup("0x2::coin::Coin<0x5::usdc::USDC>"); typ.Name() != "wrapped" {
+		t.Fatalf("expected the exact instantiation, got %v", typ)
+	}
+	if typ, _ := r.Lookup("0x2::coin::Coin<0x2::sui::SUI>"); typ.Name() != "Coin" {
+		t.Fatalf("expected the generic registration, got %v", typ)
+	}
+}
+
+func TestParseObject(t *testing.T) {
+	contents := coinBcs(t, Coin{ID: utils.MustParseAddress("0xc0"), Balance: 9})
+
+	moveType, data, err := ParseObject(objectBytes(t, 1, nil, contents))
+	if err != nil {
+		t.Fatalf("parse gas coin: %v", err)
+	}
+	if moveType != gasCoinType || string(data) != string(contents) {
+		t.Fatalf("unexpected gas coin %s %x", moveType, data)
+	}
+
+	tag := typetag.NewStructTag(utils.MustParseAddress("0x2"), "kiosk", "Kiosk", nil)
+	moveType, _, err = ParseObject(objectBytes(t, 0, &tag, contents))
+	if err != nil || moveType != "0x0000000000000000000000000000000000000000000000000000000000000002::kiosk::Kiosk" {
+		t.Fatalf("unexpected type %q (%v)", moveType, err)
+	}
+
+	if _, _, err := ParseObject([]byte{1}); err == nil {
+		t.Fatal("expected an error for a package")
+	}
+	if _, _, err := ParseObject(objectBytes(t, 1, nil, contents)[:20]); err == nil {
+		t.Fatal("expected an error for truncated contents")
+	}
+}
+
+func TestDecodeObjectFallbacks(t *testing.T) {
+	want := Coin{ID: utils.MustParseAddress("0xc0"), Balance: 42}
+	objectBcs := objectBytes(t, 1, nil, coinBcs(t, want))
+
+	fromObject, err := DecodeObject[Coin](graphql.Object{ObjectBcs: &objectBcs})
+	if err != nil || fromObject != want {
+		t.Fatalf("object BCS: expected %+v, got %+v (%v)", want, fromObject, err)
+	}
+
+	jsonOnly := graphql.Object{AsMoveObject: &graphql.MoveObject{Contents: &graphql.MoveValue{
+		Type: graphql.MoveType{Repr: "0x2::coin::Coin<0x2::sui::SUI>"},
+		Json: json.RawMessage(`{"id":"` + want.ID.String() + `","balance":"42"}`),
+	}}}
+	fromJSON, err := DecodeObject[Coin](jsonOnly)
+	if err != nil || fromJSON != want {
+		t.Fatalf("JSON: expected %+v, got %+v (%v)", want, fromJSON, err)
+	}
+
+	// An unregistered mirror of the layout decodes from BCS as a last resort.
+	type balanceOnly struct {
+		ID      [32]byte
+		Balance uint64
+	}
+	mirror, err := DecodeObject[balanceOnly](graphql.Object{ObjectBcs: &objectBcs})
+	if err != nil || mirror.Balance != 42 {
+		t.Fatalf("mirror: got %+v (%v)", mirror, err)
+	}
+
+	if _, err := DecodeObject[Coin](graphql.Object{}); !errors.Is(err, ErrNoContents) {
+		t.Fatalf("expected ErrNoContents, got %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+package bcsreg
+
+import "github.com/open-move/sui-go-sdk/types"
+
+// Framework types, in the BCS layout of their Move structs. A UID and an ID
+// both encode as the 32-byte address they wrap, and a Balance<T> as its u64
+// value, so fields of those types are flattened accordingly.
+
+// Balance is 0x2::balance::Balance<T>.
+type Balance struct {
+	Value uint64 `json:"value"`
+}
+
+// Coin is 0x2::coin::Coin<T>.
+type Coin struct {
+	ID      types.Address `json:"id"`
+	Balance uint64        `json:"balance"`
+}
+
+// StakedSui is 0x3::staking_pool::StakedSui.
+type StakedSui struct {
+	ID                   types.Address `json:"id"`
+	PoolID               types.Address `json:"pool_id"`
+	StakeActivationEpoch uint64        `json:"stake_activation_epoch"`
+	Principal            uint64        `json:"principal"`
+}
+
+// Kiosk is 0x2::kiosk::Kiosk.
+type Kiosk struct {
+	ID              types.Address `json:"id"`
+	Profits         uint64        `json:"profits"`
+	Owner           types.Address `json:"owner"`
+	ItemCount       uint32        `json:"item_count"`
+	AllowExtensions bool          `json:"allow_extensions"`
+}
+
+// Table is 0x2::table::Table<K, V>, and also has the layout of
+// 0x2::bag::Bag and 0x2::object_table::ObjectTable<K, V>.
+type Table struct {
+	ID   types.Address `json:"id"`
+	Size uint64        `json:"size"`
+}
+
+func registerFramework(r *Registry) {
+	must(Register[Balance](r, "0x2::balance::Balance"))
+	must(Register[Coin](r, "0x2::coin::Coin"))
+	must(Register[StakedSui](r, "0x3::staking_pool::StakedSui"))
+	must(Register[Kiosk](r, "0x2::kiosk::Kiosk"))
+	must(Register[Table](r, "0x2::table::Table"))
+	must(Register[Table](r, "0x2::bag::Bag"))
+	must(Register[Table](r, "0x2::object_table::ObjectTable"))
+}
+
+func must(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,69 @@
+package bcsreg
+
+import (
+	"bytes"
+	"fmt"
+
+	bcs "github.com/iotaledger/bcs-go"
+
+	"github.com/open-move/sui-go-sdk/typetag"
+)
+
+// Types that the object encoding abbreviates.
+const (
+	gasCoinType   = "0x0000000000000000000000000000000000000000000000000000000000000002::coin::Coin<0x0000000000000000000000000000000000000000000000000000000000000002::sui::SUI>"
+	stakedSuiType = "0x0000000000000000000000000000000000000000000000000000000000000003::staking_pool::StakedSui"
+	coinTypeBase  = "0x0000000000000000000000000000000000000000000000000000000000000002::coin::Coin"
+)
+
+// ParseObject reads the type and BCS contents of a Move object from the BCS
+// of the whole object, as selected by objectBcs. Packages are rejected.
+func ParseObject(objectBcs []byte) (string, []byte, error) {
+	r := bytes.NewReader(objectBcs)
+	d := bcs.NewDecoder(r)
+
+	if variant := d.ReadEnumIdx(); variant != 0 {
+		if err := d.Err(); err != nil {
+			return "", nil, fmt.Errorf("bcsreg: parse object: %w", err)
+		}
+		return "", nil, fmt.Errorf("bcsreg: parse object: not a Move object (data variant %d)", variant)
+	}
+
+	var moveType string
+	switch variant := d.ReadEnumIdx(); variant {
+	case 0:
+		var tag typetag.StructTag
+		d.Decode(&tag)
+		if d.Err() == nil {
+			moveType = typetag.TypeTagStruct(tag).String()
+		}
+	case 1:
+		moveType = gasCoinType
+	case 2:
+		moveType = stakedSuiType
+	case 3:
+		var tag typetag.TypeTag
+		d.Decode(&tag)
+		if d.Err() == nil {
+			moveType = coinTypeBase + "<" + tag.String() + ">"
+		}
+	default:
+		if err := d.Err(); err != nil {
+			return "", nil, fmt.Errorf("bcsreg: parse object: %w", err)
+		}
+		return "", nil, fmt.Errorf("bcsreg: parse object: unknown object type variant %d", variant)
+	}
+
+	d.ReadBool()   // has_public_transfer
+	d.ReadUint64() // version
+	size := d.ReadLen()
+	if err := d.Err(); err != nil {
+		return "", nil, fmt.Errorf("bcsreg: parse object: %w", err)
+	}
+	if r.Len() < size {
+		return "", nil, fmt.Errorf("bcsreg: parse object: contents truncated to %d of %d bytes", r.Len(), size)
+	}
+	contents := make([]byte, size)
+	r.Read(contents)
+	return moveType, contents, nil
+}
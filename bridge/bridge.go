@@ -0,0 +1,214 @@
+// Package bridge builds programmable transactions for the native Sui
+// Bridge and decodes its events. Deposits lock or burn a coin on Sui for
+// delivery on another chain; claims release a transfer from another chain
+// once the bridge committee has approved it. Relayers approve transfers by
+// submitting the committee's signatures over the message returned by
+// TokenTransferMessage.SigningBytes.
+//
+// Third-party bridges such as Wormhole use their own packages and are not
+// covered here.
+package bridge
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	bcs "github.com/iotaledger/bcs-go"
+	"github.com/open-move/sui-go-sdk/transaction"
+)
+
+const (
+	// PackageID is the address of the bridge package.
+	PackageID = "0xb"
+	// ObjectID is the address of the shared Bridge object.
+	ObjectID = "0x9"
+	// ClockObjectID is the address of the shared Clock object.
+	ClockObjectID = "0x6"
+
+	sendTokenTarget              = PackageID + "::bridge::send_token"
+	claimTokenTarget             = PackageID + "::bridge::claim_token"
+	claimAndTransferTokenTarget  = PackageID + "::bridge::claim_and_transfer_token"
+	approveTokenTransferTarget   = PackageID + "::bridge::approve_token_transfer"
+	createTokenBridgeMessageCall = PackageID + "::message::create_token_bridge_message"
+
+	// signingPrefix is prepended to a message before committee members
+	// sign it.
+	signingPrefix = "SUI_BRIDGE_MESSAGE"
+)
+
+// ChainID identifies a chain connected by the bridge.
+type ChainID uint8
+
+// Chains known to the bridge.
+const (
+	SuiMainnet ChainID = 0
+	SuiTestnet ChainID = 1
+	SuiCustom  ChainID = 2
+	EthMainnet ChainID = 10
+	EthSepolia ChainID = 11
+	EthCustom  ChainID = 12
+)
+
+func (c ChainID) String() string {
+	switch c {
+	case SuiMainnet:
+		return "sui-mainnet"
+	case SuiTestnet:
+		return "sui-testnet"
+	case SuiCustom:
+		return "sui-custom"
+	case EthMainnet:
+		return "eth-mainnet"
+	case EthSepolia:
+		return "eth-sepolia"
+	case EthCustom:
+		return "eth-custom"
+	}
+	return fmt.Sprintf("chain-%d", uint8(c))
+}
+
+// TokenID identifies a token supported by the bridge.
+type TokenID uint8
+
+// Tokens supported by the bridge.
+const (
+	TokenSUI  TokenID = 0
+	TokenBTC  TokenID = 1
+	TokenETH  TokenID = 2
+	TokenUSDC TokenID = 3
+	TokenUSDT TokenID = 4
+)
+
+// Message types of the bridge.
+const (
+	MessageTypeToken uint8 = 0
+
+	tokenMessageVersion uint8 = 1
+)
+
+// SendToken appends a bridge::send_token call that deposits coin, of type
+// coinType, for delivery to targetAddress on targetChain. For Ethereum
+// chains targetAddress is the 20-byte account; see ParseEVMAddress.
+func SendToken(tx *transaction.Transaction, coinType string, coin transaction.Argument, targetChain ChainID, targetAddress []byte) {
+	tx.MoveCall(transaction.MoveCall{
+		Target:        sendTokenTarget,
+		TypeArguments: []string{coinType},
+		Arguments: []transaction.Argument{
+			tx.Object(ObjectID),
+			tx.PureU8(uint8(targetChain)),
+			pureVector(tx, targetAddress),
+			coin,
+		},
+	})
+}
+
+// ClaimToken appends a bridge::claim_token call for the approved transfer
+// with sequence number seq from sourceChain and returns the claimed coin.
+// The transaction's sender must be the transfer's recipient.
+func ClaimToken(tx *transaction.Transaction, coinType string, sourceChain ChainID, seq uint64) transaction.Argument {
+	return tx.MoveCall(transaction.MoveCall{
+		Target:        claimTokenTarget,
+		TypeArguments: []string{coinType},
+		Arguments:     claimArguments(tx, sourceChain, seq),
+	}).Arg()
+}
+
+// ClaimAndTransferToken appends a bridge::claim_and_transfer_token call
+// that sends the approved transfer with sequence number seq from
+// sourceChain to its recipient. Anyone may submit it, e.g. a relayer.
+func ClaimAndTransferToken(tx *transaction.Transaction, coinType string, sourceChain ChainID, seq uint64) {
+	tx.MoveCall(transaction.MoveCall{
+		Target:        claimAndTransferTokenTarget,
+		TypeArguments: []string{coinType},
+		Arguments:     claimArguments(tx, sourceChain, seq),
+	})
+}
+
+func claimArguments(tx *transaction.Transaction, sourceChain ChainID, seq uint64) []transaction.Argument {
+	return []transaction.Argument{
+		tx.Object(ObjectID),
+		tx.Object(ClockObjectID),
+		tx.PureU8(uint8(sourceChain)),
+		tx.PureU64(seq),
+	}
+}
+
+// TokenTransferMessage is a token transfer as signed by the bridge
+// committee.
+type TokenTransferMessage struct {
+	SourceChain   ChainID
+	SeqNum        uint64
+	SenderAddress []byte
+	TargetChain   ChainID
+	TargetAddress []byte
+	TokenID       TokenID
+	Amount        uint64
+}
+
+// Bytes returns the message in the bridge's wire format: type, version,
+// big-endian sequence number and source chain, followed by the
+// length-prefixed sender, the target chain, the length-prefixed target,
+// the token and the big-endian amount.
+func (m TokenTransferMessage) Bytes() []byte {
+	out := []byte{MessageTypeToken, tokenMessageVersion}
+	out = binary.BigEndian.AppendUint64(out, m.SeqNum)
+	out = append(out, byte(m.SourceChain), byte(len(m.SenderAddress)))
+	out = append(out, m.SenderAddress...)
+	out = append(out, byte(m.TargetChain), byte(len(m.TargetAddress)))
+	out = append(out, m.TargetAddress...)
+	out = append(out, byte(m.TokenID))
+	return binary.BigEndian.AppendUint64(out, m.Amount)
+}
+
+// SigningBytes returns the bytes committee members sign with their
+// secp256k1 keys, hashed with Keccak-256: the message prefixed with
+// "SUI_BRIDGE_MESSAGE".
+func (m TokenTransferMessage) SigningBytes() []byte {
+	return append([]byte(signingPrefix), m.Bytes()...)
+}
+
+// Key returns the key the bridge records the transfer under.
+func (m TokenTransferMessage) Key() MessageKey {
+	return MessageKey{SourceChain: m.SourceChain, MessageType: MessageTypeToken, SeqNum: m.SeqNum}
+}
+
+// ApproveTokenTransfer appends the calls that rebuild msg on chain and
+// approve it with the committee's signatures, which must carry enough
+// stake to pass the bridge's threshold.
+func ApproveTokenTransfer(tx *transaction.Transaction, msg TokenTransferMessage, signatures [][]byte) {
+	message := tx.MoveCall(transaction.MoveCall{
+		Target: createTokenBridgeMessageCall,
+		Arguments: []transaction.Argument{
+			tx.PureU8(uint8(msg.SourceChain)),
+			tx.PureU64(msg.SeqNum),
+			pureVector(tx, msg.SenderAddress),
+			tx.PureU8(uint8(msg.TargetChain)),
+			pureVector(tx, msg.TargetAddress),
+			tx.PureU8(uint8(msg.TokenID)),
+			tx.PureU64(msg.Amount),
+		},
+	})
+	tx.MoveCall(transaction.MoveCall{
+		Target:    approveTokenTransferTarget,
+		Arguments: []transaction.Argument{tx.Object(ObjectID), message.Arg(), tx.PureBytes(bcs.MustMarshal(&signatures))},
+	})
+}
+
+// ParseEVMAddress decodes a 0x-prefixed, 20-byte Ethereum address.
+func ParseEVMAddress(address string) ([]byte, error) {
+	raw, err := hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(address, "0x"), "0X"))
+	if err != nil {
+		return nil, fmt.Errorf("bridge: invalid EVM address %q: %w", address, err)
+	}
+	if len(raw) != 20 {
+		return nil, fmt.Errorf("bridge: EVM address %q has %d bytes, want 20", address, len(raw))
+	}
+	return raw, nil
+}
+
+// pureVector adds value as a pure vector<u8> input.
+func pureVector(tx *transaction.Transaction, value []byte) transaction.Argument {
+	return tx.PureBytes(bcs.MustMarshal(&value))
+}
@@ -0,0 +1,139 @@
+package bridge
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	bcs "github.com/iotaledger/bcs-go"
+	"github.com/open-move/sui-go-sdk/graphql"
+	"github.com/open-move/sui-go-sdk/transaction"
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+// bridgeResolver resolves the Bridge and Clock as shared objects and the
+// bridge functions with their real parameter counts.
+type bridgeResolver struct{}
+
+func (bridgeResolver) ResolveObjects(_ context.Context, ids []string) ([]transaction.ObjectMetadata, error) {
+	out := make([]transaction.ObjectMetadata, len(ids))
+	for i, id := range ids {
+		version := uint64(1)
+		out[i] = transaction.ObjectMetadata{ID: utils.MustParseAddress(id), OwnerKind: transaction.OwnerShared, OwnerVersion: &version}
+	}
+	return out, nil
+}
+
+func (bridgeResolver) ResolveMoveFunction(_ context.Context, _, module, function string) (*transaction.MoveFunction, error) {
+	mutable := transaction.MoveParameter{Reference: transaction.ReferenceMutable}
+	value := transaction.MoveParameter{}
+	ctx := transaction.MoveParameter{Reference: transaction.ReferenceMutable, TypeName: "0x2::tx_context::TxContext"}
+	params := map[string][]transaction.MoveParameter{
+		"send_token":                  {mutable, value, value, value, ctx},
+		"claim_and_transfer_token":    {mutable, {Reference: transaction.ReferenceImmutable}, value, value, ctx},
+		"approve_token_transfer":      {mutable, value, value},
+		"create_token_bridge_message": {value, value, value, value, value, value, value},
+	}[function]
+	if params == nil {
+		return nil, errors.New("unknown function " + module + "::" + function)
+	}
+	return &transaction.MoveFunction{Parameters: params}, nil
+}
+
+func TestTransactions(t *testing.T) {
+	target, err := ParseEVMAddress("0x00000000000000000000000000000000000000aa")
+	if err != nil {
+		t.Fatalf("parse EVM address: %v", err)
+	}
+	tx := transaction.New()
+	coin := tx.ObjectRef(types.ObjectRef{ObjectID: utils.MustParseAddress("0xc0"), Version: 2, Digest: types.Digest(bytes.Repeat([]byte{1}, 32))})
+	SendToken(tx, "0x2::sui::SUI", coin, EthMainnet, target)
+	ClaimAndTransferToken(tx, "0x2::sui::SUI", EthMainnet, 42)
+	ApproveTokenTransfer(tx, TokenTransferMessage{SourceChain: EthMainnet, SeqNum: 42, SenderAddress: target, TargetChain: SuiMainnet, TargetAddress: make([]byte, 32), TokenID: TokenETH, Amount: 5}, [][]byte{{1, 2}, {3}})
+
+	result, err := tx.Build(context.Background(), transaction.BuildOptions{Resolver: bridgeResolver{}})
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	commands := result.ProgrammableKind.Commands
+	want := []string{"bridge::send_token", "bridge::claim_and_transfer_token", "message::create_token_bridge_message", "bridge::approve_token_transfer"}
+	if len(commands) != len(want) {
+		t.Fatalf("expected %d commands, got %d", len(want), len(commands))
+	}
+	for i, cmd := range commands {
+		if got := cmd.MoveCall.Module + "::" + cmd.MoveCall.Function; got != want[i] {
+			t.Fatalf("command %d is %s, want %s", i, got, want[i])
+		}
+	}
+
+	inputs := result.ResolvedInputArgs
+	// Input 0 is the coin.
+	bridgeObject := inputs[1].Object.SharedObject
+	if bridgeObject == nil || bridgeObject.ObjectID != utils.MustParseAddress(ObjectID) || !bridgeObject.Mutable {
+		t.Fatalf("the Bridge should be a mutable shared input, got %+v", inputs[1].Object)
+	}
+	if encoded := inputs[3].Pure.Bytes; !bytes.Equal(encoded, append([]byte{20}, target...)) {
+		t.Fatalf("target address encoded as %x", encoded)
+	}
+	signatures := inputs[len(inputs)-1].Pure.Bytes
+	if !bytes.Equal(signatures, []byte{2, 2, 1, 2, 1, 3}) {
+		t.Fatalf("signatures encoded as %x", signatures)
+	}
+}
+
+func TestTokenTransferMessage(t *testing.T) {
+	msg := TokenTransferMessage{
+		SourceChain:   SuiTestnet,
+		SeqNum:        10,
+		SenderAddress: []byte{0xaa, 0xbb},
+		TargetChain:   EthSepolia,
+		TargetAddress: []byte{0xcc},
+		TokenID:       TokenUSDC,
+		Amount:        12345,
+	}
+	want := "0001" + "000000000000000a" + "01" + "02aabb" + "0b" + "01cc" + "03" + "0000000000003039"
+	if got := hex.EncodeToString(msg.Bytes()); got != want {
+		t.Fatalf("message bytes %s, want %s", got, want)
+	}
+	if signing := msg.SigningBytes(); string(signing[:18]) != "SUI_BRIDGE_MESSAGE" || !bytes.Equal(signing[18:], msg.Bytes()) {
+		t.Fatalf("unexpected signing bytes %x", signing)
+	}
+	if key := msg.Key(); key != (MessageKey{SourceChain: SuiTestnet, MessageType: MessageTypeToken, SeqNum: 10}) {
+		t.Fatalf("unexpected key %+v", key)
+	}
+}
+
+func TestParseEvent(t *testing.T) {
+	deposited := TokenDeposited{SeqNum: 7, SourceChain: SuiMainnet, SenderAddress: make([]byte, 32), TargetChain: EthMainnet, TargetAddress: make([]byte, 20), TokenID: TokenSUI, Amount: 1_000}
+	event, err := ParseGraphQLEvent(graphql.Event{Contents: &graphql.MoveValue{
+		Type: graphql.MoveType{Repr: "0x000000000000000000000000000000000000000000000000000000000000000b::bridge::TokenDepositedEvent"},
+		Bcs:  bcs.MustMarshal(&deposited),
+	}})
+	if err != nil {
+		t.Fatalf("parse deposit: %v", err)
+	}
+	if got, ok := event.(*TokenDeposited); !ok || got.Amount != 1_000 || got.Message().Key().SeqNum != 7 || len(got.TargetAddress) != 20 {
+		t.Fatalf("unexpected deposit %#v", event)
+	}
+
+	key := MessageKey{SourceChain: EthMainnet, MessageType: MessageTypeToken, SeqNum: 3}
+	event, err = ParseEvent("0xb::bridge::TokenTransferLimitExceed", bcs.MustMarshal(&key))
+	if err != nil {
+		t.Fatalf("parse transfer event: %v", err)
+	}
+	if got, ok := event.(*TransferEvent); !ok || got.Status != TransferLimitExceeded || got.Key != key {
+		t.Fatalf("unexpected transfer event %#v", event)
+	}
+
+	for _, eventType := range []string{"0xb::committee::MemberRegistration", "0x2::bridge::TokenDepositedEvent", "0xb::bridge::UpdateRouteLimitEvent"} {
+		if _, err := ParseEvent(eventType, nil); !errors.Is(err, ErrNotBridgeEvent) {
+			t.Errorf("%s: expected ErrNotBridgeEvent, got %v", eventType, err)
+		}
+	}
+	if _, err := ParseEvent("0xb::bridge::TokenTransferClaimed", []byte{1}); err == nil || errors.Is(err, ErrNotBridgeEvent) {
+		t.Fatalf("expected a decode error, got %v", err)
+	}
+}
@@ -0,0 +1,138 @@
+package bridge
+
+import (
+	"errors"
+	"fmt"
+
+	bcs "github.com/iotaledger/bcs-go"
+	"github.com/open-move/sui-go-sdk/graphql"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+// ErrNotBridgeEvent is returned by ParseEvent for events the bridge
+// package does not emit, or that this package does not decode.
+var ErrNotBridgeEvent = errors.New("bridge: not a bridge event")
+
+// MessageKey identifies a bridge message.
+type MessageKey struct {
+	SourceChain ChainID
+	MessageType uint8
+	SeqNum      uint64
+}
+
+// TokenDeposited is emitted by send_token when a coin is deposited on Sui
+// for delivery to another chain.
+type TokenDeposited struct {
+	SeqNum        uint64
+	SourceChain   ChainID
+	SenderAddress []byte
+	TargetChain   ChainID
+	TargetAddress []byte
+	TokenID       TokenID
+	Amount        uint64
+}
+
+// Message returns the transfer message the committee signs for the
+// deposit.
+func (e TokenDeposited) Message() TokenTransferMessage {
+	return TokenTransferMessage{
+		SourceChain:   e.SourceChain,
+		SeqNum:        e.SeqNum,
+		SenderAddress: e.SenderAddress,
+		TargetChain:   e.TargetChain,
+		TargetAddress: e.TargetAddress,
+		TokenID:       e.TokenID,
+		Amount:        e.Amount,
+	}
+}
+
+// TransferStatus is the change reported by a TransferEvent.
+type TransferStatus string
+
+// Statuses of incoming transfers.
+const (
+	TransferApproved        TransferStatus = "approved"
+	TransferClaimed         TransferStatus = "claimed"
+	TransferAlreadyApproved TransferStatus = "already_approved"
+	TransferAlreadyClaimed  TransferStatus = "already_claimed"
+	// TransferLimitExceeded means the claim was deferred because the route's
+	// rate limit was reached; it can be retried later.
+	TransferLimitExceeded TransferStatus = "limit_exceeded"
+)
+
+// TransferEvent reports a change to an incoming transfer.
+type TransferEvent struct {
+	Status TransferStatus
+	Key    MessageKey
+}
+
+// EmergencyOp is emitted when the bridge is frozen or unfrozen.
+type EmergencyOp struct {
+	Frozen bool
+}
+
+var transferEvents = map[string]TransferStatus{
+	"TokenTransferApproved":        TransferApproved,
+	"TokenTransferClaimed":         TransferClaimed,
+	"TokenTransferAlreadyApproved": TransferAlreadyApproved,
+	"TokenTransferAlreadyClaimed":  TransferAlreadyClaimed,
+	"TokenTransferLimitExceed":     TransferLimitExceeded,
+}
+
+// ParseEvent decodes the BCS contents of a bridge::bridge event of type
+// eventType. It returns a *TokenDeposited, *TransferEvent or *EmergencyOp,
+// or an error wrapping ErrNotBridgeEvent for other events.
+func ParseEvent(eventType string, contents []byte) (any, error) {
+	tag, err := utils.ParseStructTag(eventType)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotBridgeEvent, err)
+	}
+	pkg, _ := utils.ParseAddress(PackageID)
+	if tag.Address != pkg || tag.Module != "bridge" {
+		return nil, fmt.Errorf("%w: %s", ErrNotBridgeEvent, eventType)
+	}
+
+	var event any
+	switch tag.Name {
+	case "TokenDepositedEvent":
+		event, err = decode[TokenDeposited](contents)
+	case "EmergencyOpEvent":
+		event, err = decode[EmergencyOp](contents)
+	default:
+		status, ok := transferEvents[tag.Name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrNotBridgeEvent, eventType)
+		}
+		var key *MessageKey
+		if key, err = decode[MessageKey](contents); err == nil {
+			event = &TransferEvent{Status: status, Key: *key}
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("bridge: decode %s: %w", tag.Name, err)
+	}
+	return event, nil
+}
+
+// ParseGraphQLEvent decodes a bridge event returned by the GraphQL API. The
+// event's contents must include their type and BCS.
+func ParseGraphQLEvent(ev graphql.Event) (any, error) {
+	if ev.Contents == nil || len(ev.Contents.Bcs) == 0 {
+		return nil, errors.New("bridge: event contents were not selected")
+	}
+	return ParseEvent(ev.Contents.Type.Repr, ev.Contents.Bcs)
+}
+
+func decode[T any](contents []byte) (*T, error) {
+	v, err := bcs.Unmarshal[T](contents)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// EventFilter selects every event emitted by the bridge module.
+func EventFilter() *graphql.EventFilter {
+	eventType := PackageID + "::bridge"
+	return &graphql.EventFilter{EventType: &eventType}
+}
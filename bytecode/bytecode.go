@@ -0,0 +1,657 @@
+// Package bytecode reads compiled Move modules. It decodes the module
+// header, handles, signatures and struct definitions of the Move binary
+// format, enough to list a module's functions and structs offline, e.g. to
+// check that a package about to be published or already on chain exposes
+// the expected API. Function bodies are not decoded.
+package bytecode
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/open-move/sui-go-sdk/types"
+)
+
+// Magic is the prefix of every compiled Move module.
+var Magic = []byte{0xA1, 0x1C, 0xEB, 0x0B}
+
+var (
+	ErrBadMagic  = errors.New("bytecode: not a Move module")
+	ErrTruncated = errors.New("bytecode: unexpected end of data")
+)
+
+// Table kinds of the Move binary format.
+const (
+	tableModuleHandles   = 0x1
+	tableDatatypeHandles = 0x2
+	tableFunctionHandles = 0x3
+	tableSignatures      = 0x5
+	tableIdentifiers     = 0x7
+	tableAddresses       = 0x8
+	tableStructDefs      = 0xA
+)
+
+// Signature token tags.
+const (
+	tokenBool           = 0x1
+	tokenU8             = 0x2
+	tokenU64            = 0x3
+	tokenU128           = 0x4
+	tokenAddress        = 0x5
+	tokenReference      = 0x6
+	tokenMutReference   = 0x7
+	tokenDatatype       = 0x8
+	tokenTypeParameter  = 0x9
+	tokenVector         = 0xA
+	tokenDatatypeInst   = 0xB
+	tokenSigner         = 0xC
+	tokenU16            = 0xD
+	tokenU32            = 0xE
+	tokenU256           = 0xF
+	maxSignatureDepth   = 256
+	fieldsNative        = 0x1
+	fieldsDeclared      = 0x2
+	abilityCopy         = 0x1
+	abilityDrop         = 0x2
+	abilityStore        = 0x4
+	abilityKey          = 0x8
+	maxAbilities        = abilityCopy | abilityDrop | abilityStore | abilityKey
+	moduleAddressLength = 32
+)
+
+// Abilities is the set of abilities of a type or required of a type
+// parameter.
+type Abilities uint8
+
+// Has reports whether every ability in other is in a.
+func (a Abilities) Has(other Abilities) bool {
+	return a&other == other
+}
+
+// String renders the abilities as in Move source, e.g. "copy, drop".
+func (a Abilities) String() string {
+	var names []string
+	for _, ability := range []struct {
+		bit  Abilities
+		name string
+	}{{abilityCopy, "copy"}, {abilityDrop, "drop"}, {abilityStore, "store"}, {abilityKey, "key"}} {
+		if a.Has(ability.bit) {
+			names = append(names, ability.name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// Ability values.
+const (
+	Copy  Abilities = abilityCopy
+	Drop  Abilities = abilityDrop
+	Store Abilities = abilityStore
+	Key   Abilities = abilityKey
+)
+
+// ModuleID names a module by address and name.
+type ModuleID struct {
+	Address types.Address
+	Name    string
+}
+
+// String renders the module as address::name.
+func (m ModuleID) String() string {
+	return m.Address.String() + "::" + m.Name
+}
+
+// TypeParameter is a type parameter of a struct.
+type TypeParameter struct {
+	Constraints Abilities
+	Phantom     bool
+}
+
+// FunctionHandle is a function used or defined by the module. Types are
+// rendered as in Move source, with type parameters written T0, T1, ...
+type FunctionHandle struct {
+	Module         ModuleID
+	Name           string
+	TypeParameters []Abilities
+	Parameters     []string
+	Returns        []string
+}
+
+// Field is a field of a struct definition.
+type Field struct {
+	Name string
+	Type string
+}
+
+// StructDefinition is a struct declared by the module.
+type StructDefinition struct {
+	Name           string
+	Abilities      Abilities
+	TypeParameters []TypeParameter
+	// Native structs have no declared fields.
+	Native bool
+	Fields []Field
+}
+
+// Module is the decoded part of a compiled Move module.
+type Module struct {
+	Version uint32
+	ID      ModuleID
+	// FunctionHandles lists every function the module calls or defines.
+	FunctionHandles []FunctionHandle
+	Structs         []StructDefinition
+}
+
+// Functions returns the handles of the functions defined by the module
+// itself, leaving out the ones it imports.
+func (m *Module) Functions() []FunctionHandle {
+	var own []FunctionHandle
+	for _, fn := range m.FunctionHandles {
+		if fn.Module == m.ID {
+			own = append(own, fn)
+		}
+	}
+	return own
+}
+
+// Function returns the module's own function called name.
+func (m *Module) Function(name string) (FunctionHandle, bool) {
+	for _, fn := range m.Functions() {
+		if fn.Name == name {
+			return fn, true
+		}
+	}
+	return FunctionHandle{}, false
+}
+
+// Struct returns the struct definition called name.
+func (m *Module) Struct(name string) (StructDefinition, bool) {
+	for _, def := range m.Structs {
+		if def.Name == name {
+			return def, true
+		}
+	}
+	return StructDefinition{}, false
+}
+
+type datatypeHandle struct {
+	module         int
+	name           string
+	abilities      Abilities
+	typeParameters []TypeParameter
+}
+
+type table struct {
+	offset, length int
+}
+
+// layout locates the tables of a compiled module.
+type layout struct {
+	version      uint32
+	tables       map[byte]table
+	contentStart int
+	contentEnd   int
+}
+
+func readLayout(data []byte) (*layout, error) {
+	if len(data) < 8 {
+		return nil, ErrTruncated
+	}
+	if string(data[:4]) != string(Magic) {
+		return nil, ErrBadMagic
+	}
+	// The high bits of the version carry a flavor tag on newer compilers.
+	l := &layout{version: binary.LittleEndian.Uint32(data[4:8]) & 0xFFFF, tables: make(map[byte]table)}
+
+	r := &reader{data: data, pos: 8}
+	count, err := r.uleb()
+	if err != nil {
+		return nil, err
+	}
+	for i := uint64(0); i < count; i++ {
+		kind, err := r.byte()
+		if err != nil {
+			return nil, err
+		}
+		offset, err := r.uleb()
+		if err != nil {
+			return nil, err
+		}
+		length, err := r.uleb()
+		if err != nil {
+			return nil, err
+		}
+		l.tables[kind] = table{offset: int(offset), length: int(length)}
+	}
+	l.contentStart = r.pos
+	l.contentEnd = r.pos
+	for kind, t := range l.tables {
+		// Compare against the space left so huge offsets cannot overflow.
+		if t.offset < 0 || t.length < 0 || t.offset > len(data)-l.contentStart || t.length > len(data)-l.contentStart-t.offset {
+			return nil, fmt.Errorf("bytecode: table 0x%x out of bounds", kind)
+		}
+		l.contentEnd = max(l.contentEnd, l.contentStart+t.offset+t.length)
+	}
+	return l, nil
+}
+
+// section returns a reader limited to one table. Missing tables are empty.
+func (l *layout) section(data []byte, kind byte) *reader {
+	t := l.tables[kind]
+	start := l.contentStart + t.offset
+	return &reader{data: data[:start+t.length], pos: start}
+}
+
+// Parse decodes a compiled Move module.
+func Parse(data []byte) (*Module, error) {
+	l, err := readLayout(data)
+	if err != nil {
+		return nil, err
+	}
+	section := func(kind byte) *reader { return l.section(data, kind) }
+
+	p := &parser{}
+	if p.identifiers, err = readAll(section(tableIdentifiers), func(r *reader) (string, error) {
+		b, err := r.bytes()
+		return string(b), err
+	}); err != nil {
+		return nil, fmt.Errorf("bytecode: identifiers: %w", err)
+	}
+	if p.addresses, err = readAll(section(tableAddresses), func(r *reader) (types.Address, error) {
+		var addr types.Address
+		b, err := r.take(moduleAddressLength)
+		copy(addr[:], b)
+		return addr, err
+	}); err != nil {
+		return nil, fmt.Errorf("bytecode: addresses: %w", err)
+	}
+	if p.modules, err = readAll(section(tableModuleHandles), p.moduleHandle); err != nil {
+		return nil, fmt.Errorf("bytecode: module handles: %w", err)
+	}
+	if p.datatypes, err = readAll(section(tableDatatypeHandles), p.datatypeHandle); err != nil {
+		return nil, fmt.Errorf("bytecode: datatype handles: %w", err)
+	}
+	if p.signatures, err = readAll(section(tableSignatures), p.signature); err != nil {
+		return nil, fmt.Errorf("bytecode: signatures: %w", err)
+	}
+
+	module := &Module{Version: l.version}
+	if module.FunctionHandles, err = readAll(section(tableFunctionHandles), p.functionHandle); err != nil {
+		return nil, fmt.Errorf("bytecode: function handles: %w", err)
+	}
+	if module.Structs, err = readAll(section(tableStructDefs), p.structDefinition); err != nil {
+		return nil, fmt.Errorf("bytecode: struct definitions: %w", err)
+	}
+
+	self := &reader{data: data, pos: l.contentEnd}
+	selfIndex, err := self.uleb()
+	if err != nil {
+		return nil, fmt.Errorf("bytecode: self module handle: %w", err)
+	}
+	if module.ID, err = p.module(selfIndex); err != nil {
+		return nil, err
+	}
+	return module, nil
+}
+
+// parser resolves table indices while decoding later tables.
+type parser struct {
+	identifiers []string
+	addresses   []types.Address
+	modules     []ModuleID
+	datatypes   []datatypeHandle
+	signatures  [][]typeToken
+}
+
+func (p *parser) identifier(index uint64) (string, error) {
+	if index >= uint64(len(p.identifiers)) {
+		return "", fmt.Errorf("identifier index %d out of range", index)
+	}
+	return p.identifiers[index], nil
+}
+
+func (p *parser) module(index uint64) (ModuleID, error) {
+	if index >= uint64(len(p.modules)) {
+		return ModuleID{}, fmt.Errorf("bytecode: module handle index %d out of range", index)
+	}
+	return p.modules[index], nil
+}
+
+func (p *parser) moduleHandle(r *reader) (ModuleID, error) {
+	addr, err := r.uleb()
+	if err != nil {
+		return ModuleID{}, err
+	}
+	name, err := r.uleb()
+	if err != nil {
+		return ModuleID{}, err
+	}
+	if addr >= uint64(len(p.addresses)) {
+		return ModuleID{}, fmt.Errorf("address index %d out of range", addr)
+	}
+	ident, err := p.identifier(name)
+	if err != nil {
+		return ModuleID{}, err
+	}
+	return ModuleID{Address: p.addresses[addr], Name: ident}, nil
+}
+
+func (p *parser) datatypeHandle(r *reader) (datatypeHandle, error) {
+	module, err := r.uleb()
+	if err != nil {
+		return datatypeHandle{}, err
+	}
+	name, err := r.uleb()
+	if err != nil {
+		return datatypeHandle{}, err
+	}
+	abilities, err := r.abilities()
+	if err != nil {
+		return datatypeHandle{}, err
+	}
+	params, err := readVector(r, func(r *reader) (TypeParameter, error) {
+		constraints, err := r.abilities()
+		if err != nil {
+			return TypeParameter{}, err
+		}
+		phantom, err := r.byte()
+		return TypeParameter{Constraints: constraints, Phantom: phantom != 0}, err
+	})
+	if err != nil {
+		return datatypeHandle{}, err
+	}
+	if module >= uint64(len(p.modules)) {
+		return datatypeHandle{}, fmt.Errorf("module handle index %d out of range", module)
+	}
+	ident, err := p.identifier(name)
+	if err != nil {
+		return datatypeHandle{}, err
+	}
+	return datatypeHandle{module: int(module), name: ident, abilities: abilities, typeParameters: params}, nil
+}
+
+func (p *parser) signature(r *reader) ([]typeToken, error) {
+	return readVector(r, func(r *reader) (typeToken, error) {
+		return r.typeToken(0)
+	})
+}
+
+func (p *parser) signatureTypes(index uint64) ([]string, error) {
+	if index >= uint64(len(p.signatures)) {
+		return nil, fmt.Errorf("signature index %d out of range", index)
+	}
+	rendered := make([]string, len(p.signatures[index]))
+	for i, token := range p.signatures[index] {
+		s, err := p.render(token)
+		if err != nil {
+			return nil, err
+		}
+		rendered[i] = s
+	}
+	return rendered, nil
+}
+
+func (p *parser) functionHandle(r *reader) (FunctionHandle, error) {
+	var indices [4]uint64
+	for i := range indices {
+		value, err := r.uleb()
+		if err != nil {
+			return FunctionHandle{}, err
+		}
+		indices[i] = value
+	}
+	typeParams, err := readVector(r, func(r *reader) (Abilities, error) { return r.abilities() })
+	if err != nil {
+		return FunctionHandle{}, err
+	}
+
+	module, err := p.module(indices[0])
+	if err != nil {
+		return FunctionHandle{}, err
+	}
+	name, err := p.identifier(indices[1])
+	if err != nil {
+		return FunctionHandle{}, err
+	}
+	params, err := p.signatureTypes(indices[2])
+	if err != nil {
+		return FunctionHandle{}, err
+	}
+	returns, err := p.signatureTypes(indices[3])
+	if err != nil {
+		return FunctionHandle{}, err
+	}
+	return FunctionHandle{Module: module, Name: name, TypeParameters: typeParams, Parameters: params, Returns: returns}, nil
+}
+
+func (p *parser) structDefinition(r *reader) (StructDefinition, error) {
+	index, err := r.uleb()
+	if err != nil {
+		return StructDefinition{}, err
+	}
+	if index >= uint64(len(p.datatypes)) {
+		return StructDefinition{}, fmt.Errorf("datatype handle index %d out of range", index)
+	}
+	handle := p.datatypes[index]
+	def := StructDefinition{Name: handle.name, Abilities: handle.abilities, TypeParameters: handle.typeParameters}
+
+	tag, err := r.byte()
+	if err != nil {
+		return StructDefinition{}, err
+	}
+	switch tag {
+	case fieldsNative:
+		def.Native = true
+		return def, nil
+	case fieldsDeclared:
+	default:
+		return StructDefinition{}, fmt.Errorf("unknown field information tag 0x%x", tag)
+	}
+
+	def.Fields, err = readVector(r, func(r *reader) (Field, error) {
+		name, err := r.uleb()
+		if err != nil {
+			return Field{}, err
+		}
+		token, err := r.typeToken(0)
+		if err != nil {
+			return Field{}, err
+		}
+		ident, err := p.identifier(name)
+		if err != nil {
+			return Field{}, err
+		}
+		rendered, err := p.render(token)
+		return Field{Name: ident, Type: rendered}, err
+	})
+	return def, err
+}
+
+// typeToken is a decoded signature token.
+type typeToken struct {
+	tag   byte
+	index uint64
+	inner []typeToken
+}
+
+func (p *parser) render(t typeToken) (string, error) {
+	switch t.tag {
+	case tokenBool:
+		return "bool", nil
+	case tokenU8:
+		return "u8", nil
+	case tokenU16:
+		return "u16", nil
+	case tokenU32:
+		return "u32", nil
+	case tokenU64:
+		return "u64", nil
+	case tokenU128:
+		return "u128", nil
+	case tokenU256:
+		return "u256", nil
+	case tokenAddress:
+		return "address", nil
+	case tokenSigner:
+		return "signer", nil
+	case tokenTypeParameter:
+		return fmt.Sprintf("T%d", t.index), nil
+	case tokenReference, tokenMutReference, tokenVector:
+		inner, err := p.render(t.inner[0])
+		if err != nil {
+			return "", err
+		}
+		switch t.tag {
+		case tokenReference:
+			return "&" + inner, nil
+		case tokenMutReference:
+			return "&mut " + inner, nil
+		}
+		return "vector<" + inner + ">", nil
+	case tokenDatatype, tokenDatatypeInst:
+		if t.index >= uint64(len(p.datatypes)) {
+			return "", fmt.Errorf("datatype handle index %d out of range", t.index)
+		}
+		handle := p.datatypes[t.index]
+		name := p.modules[handle.module].String() + "::" + handle.name
+		if t.tag == tokenDatatype {
+			return name, nil
+		}
+		args := make([]string, len(t.inner))
+		for i, arg := range t.inner {
+			s, err := p.render(arg)
+			if err != nil {
+				return "", err
+			}
+			args[i] = s
+		}
+		return name + "<" + strings.Join(args, ", ") + ">", nil
+	}
+	return "", fmt.Errorf("unknown signature token 0x%x", t.tag)
+}
+
+func readAll[T any](r *reader, read func(*reader) (T, error)) ([]T, error) {
+	var items []T
+	for r.pos < len(r.data) {
+		item, err := read(r)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func readVector[T any](r *reader, read func(*reader) (T, error)) ([]T, error) {
+	count, err := r.uleb()
+	if err != nil {
+		return nil, err
+	}
+	if count > uint64(len(r.data)-r.pos) {
+		return nil, ErrTruncated
+	}
+	items := make([]T, count)
+	for i := range items {
+		if items[i], err = read(r); err != nil {
+			return nil, err
+		}
+	}
+	return items, nil
+}
+
+type reader struct {
+	data []byte
+	pos  int
+}
+
+func (r *reader) byte() (byte, error) {
+	if r.pos >= len(r.data) {
+		return 0, ErrTruncated
+	}
+	b := r.data[r.pos]
+	r.pos++
+	return b, nil
+}
+
+func (r *reader) take(n int) ([]byte, error) {
+	if n < 0 || r.pos+n > len(r.data) {
+		return nil, ErrTruncated
+	}
+	b := r.data[r.pos : r.pos+n]
+	r.pos += n
+	return b, nil
+}
+
+func (r *reader) bytes() ([]byte, error) {
+	n, err := r.uleb()
+	if err != nil {
+		return nil, err
+	}
+	if n > uint64(len(r.data)-r.pos) {
+		return nil, ErrTruncated
+	}
+	return r.take(int(n))
+}
+
+func (r *reader) uleb() (uint64, error) {
+	var value uint64
+	for shift := uint(0); shift < 64; shift += 7 {
+		b, err := r.byte()
+		if err != nil {
+			return 0, err
+		}
+		value |= uint64(b&0x7F) << shift
+		if b&0x80 == 0 {
+			return value, nil
+		}
+	}
+	return 0, errors.New("bytecode: uleb128 overflow")
+}
+
+func (r *reader) abilities() (Abilities, error) {
+	b, err := r.uleb()
+	if err != nil {
+		return 0, err
+	}
+	if b > maxAbilities {
+		return 0, fmt.Errorf("invalid ability set 0x%x", b)
+	}
+	return Abilities(b), nil
+}
+
+func (r *reader) typeToken(depth int) (typeToken, error) {
+	if depth > maxSignatureDepth {
+		return typeToken{}, errors.New("signature too deep")
+	}
+	tag, err := r.byte()
+	if err != nil {
+		return typeToken{}, err
+	}
+	t := typeToken{tag: tag}
+	switch tag {
+	case tokenBool, tokenU8, tokenU16, tokenU32, tokenU64, tokenU128, tokenU256, tokenAddress, tokenSigner:
+	case tokenReference, tokenMutReference, tokenVector:
+		inner, err := r.typeToken(depth + 1)
+		if err != nil {
+			return typeToken{}, err
+		}
+		t.inner = []typeToken{inner}
+	case tokenTypeParameter, tokenDatatype:
+		if t.index, err = r.uleb(); err != nil {
+			return typeToken{}, err
+		}
+	case tokenDatatypeInst:
+		if t.index, err = r.uleb(); err != nil {
+			return typeToken{}, err
+		}
+		t.inner, err = readVector(r, func(r *reader) (typeToken, error) { return r.typeToken(depth + 1) })
+		if err != nil {
+			return typeToken{}, err
+		}
+	default:
+		return typeToken{}, fmt.Errorf("unknown signature token 0x%x", tag)
+	}
+	return t, nil
+}
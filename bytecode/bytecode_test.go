@@ -0,0 +1,126 @@
+package bytecode
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+func uleb(v uint64) []byte {
+	var out []byte
+	for {
+		b := byte(v & 0x7F)
+		v >>= 7
+		if v == 0 {
+			return append(out, b)
+		}
+		out = append(out, b|0x80)
+	}
+}
+
+func cat(parts ...[]byte) []byte {
+	var out []byte
+	for _, p := range parts {
+		out = append(out, p...)
+	}
+	return out
+}
+
+func ident(s string) []byte {
+	return cat(uleb(uint64(len(s))), []byte(s))
+}
+
+// coinModule assembles a reduced 0x2::coin module by hand: one struct
+// Coin<phantom T> { balance: Balance<T> }, a local function split and an
+// imported balance::join.
+func coinModule() []byte {
+	framework := utils.MustParseAddress("0x2")
+	sections := []struct {
+		kind byte
+		body []byte
+	}{
+		{tableModuleHandles, cat(uleb(0), uleb(0), uleb(0), uleb(3))},
+		{tableDatatypeHandles, cat(
+			uleb(0), uleb(1), uleb(uint64(Key|Store)), uleb(1), uleb(0), []byte{1},
+			uleb(1), uleb(4), uleb(uint64(Store)), uleb(1), uleb(0), []byte{1},
+		)},
+		{tableFunctionHandles, cat(
+			uleb(0), uleb(5), uleb(1), uleb(2), uleb(1), uleb(0),
+			uleb(1), uleb(6), uleb(3), uleb(0), uleb(0),
+		)},
+		{tableSignatures, cat(
+			uleb(0),
+			uleb(2), []byte{tokenMutReference, tokenDatatypeInst, 0, 1, tokenTypeParameter, 0, tokenU64},
+			uleb(1), []byte{tokenDatatypeInst, 0, 1, tokenTypeParameter, 0},
+			uleb(2), []byte{tokenMutReference, tokenDatatypeInst, 1, 1, tokenTypeParameter, 0, tokenVector, tokenU8},
+		)},
+		{tableIdentifiers, cat(ident("coin"), ident("Coin"), ident("value"), ident("balance"), ident("Balance"), ident("split"), ident("join"))},
+		{tableAddresses, framework[:]},
+		{tableStructDefs, cat(uleb(0), []byte{fieldsDeclared}, uleb(1), uleb(3), []byte{tokenDatatypeInst, 1, 1, tokenTypeParameter, 0})},
+	}
+
+	header := cat(Magic, []byte{6, 0, 0, 0}, uleb(uint64(len(sections))))
+	var contents []byte
+	for _, s := range sections {
+		header = cat(header, []byte{s.kind}, uleb(uint64(len(contents))), uleb(uint64(len(s.body))))
+		contents = append(contents, s.body...)
+	}
+	return cat(header, contents, uleb(0))
+}
+
+func TestParse(t *testing.T) {
+	m, err := Parse(coinModule())
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if m.Version != 6 || m.ID.String() != utils.MustParseAddress("0x2").String()+"::coin" {
+		t.Fatalf("unexpected header %d %s", m.Version, m.ID)
+	}
+	if len(m.FunctionHandles) != 2 {
+		t.Fatalf("expected 2 function handles, got %d", len(m.FunctionHandles))
+	}
+
+	local := m.Functions()
+	if len(local) != 1 || local[0].Name != "split" {
+		t.Fatalf("unexpected local functions %+v", local)
+	}
+	coin := m.ID.String() + "::Coin<T0>"
+	if want := []string{"&mut " + coin, "u64"}; !reflect.DeepEqual(local[0].Parameters, want) {
+		t.Fatalf("params %v, want %v", local[0].Parameters, want)
+	}
+	if want := []string{coin}; !reflect.DeepEqual(local[0].Returns, want) {
+		t.Fatalf("returns %v, want %v", local[0].Returns, want)
+	}
+	if _, ok := m.Function("join"); ok {
+		t.Fatal("imported function reported as local")
+	}
+
+	def, ok := m.Struct("Coin")
+	if !ok {
+		t.Fatal("Coin struct not found")
+	}
+	if def.Abilities.String() != "store, key" || !def.TypeParameters[0].Phantom || def.Native {
+		t.Fatalf("unexpected struct %+v", def)
+	}
+	balance := utils.MustParseAddress("0x2").String() + "::balance::Balance<T0>"
+	if want := []Field{{Name: "balance", Type: balance}}; !reflect.DeepEqual(def.Fields, want) {
+		t.Fatalf("fields %+v, want %+v", def.Fields, want)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	if _, err := Parse([]byte("not a module")); !errors.Is(err, ErrBadMagic) {
+		t.Fatalf("expected ErrBadMagic, got %v", err)
+	}
+	data := coinModule()
+	if _, err := Parse(data[:len(data)-10]); err == nil {
+		t.Fatal("expected truncated module to fail")
+	}
+	oversized := cat(Magic, []byte{6, 0, 0, 0}, uleb(1), []byte{tableIdentifiers}, uleb(1<<62), uleb(1<<62), uleb(0))
+	if _, err := Parse(oversized); err == nil || !strings.Contains(err.Error(), "out of bounds") {
+		t.Fatalf("expected oversized table to fail, got %v", err)
+	}
+}
@@ -0,0 +1,199 @@
+package bytecode
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/open-move/sui-go-sdk/types"
+)
+
+// VerificationStatus is the outcome of comparing one module.
+type VerificationStatus string
+
+const (
+	// StatusVerified means the local and on-chain bytecode are identical
+	// once the package address is substituted.
+	StatusVerified VerificationStatus = "verified"
+	// StatusMismatch means both sides have the module but the bytecode
+	// differs.
+	StatusMismatch VerificationStatus = "mismatch"
+	// StatusMissingOnChain means the module was built locally but is not
+	// part of the on-chain package.
+	StatusMissingOnChain VerificationStatus = "missing_on_chain"
+	// StatusMissingLocally means the on-chain package has a module the
+	// local build does not.
+	StatusMissingLocally VerificationStatus = "missing_locally"
+)
+
+// ModuleVerification is the result for a single module.
+type ModuleVerification struct {
+	Name   string
+	Status VerificationStatus
+	// Detail explains a mismatch or a module that could not be decoded.
+	Detail string
+}
+
+// VerificationReport compares every module of a package.
+type VerificationReport struct {
+	Modules []ModuleVerification
+}
+
+// Verified reports whether every module matched and none is missing on
+// either side.
+func (r *VerificationReport) Verified() bool {
+	if len(r.Modules) == 0 {
+		return false
+	}
+	for _, m := range r.Modules {
+		if m.Status != StatusVerified {
+			return false
+		}
+	}
+	return true
+}
+
+// Failed returns the modules that did not verify.
+func (r *VerificationReport) Failed() []ModuleVerification {
+	var failed []ModuleVerification
+	for _, m := range r.Modules {
+		if m.Status != StatusVerified {
+			failed = append(failed, m)
+		}
+	}
+	return failed
+}
+
+// LoadBuildOutput reads the modules produced by `sui move build`, keyed by
+// module name. dir may be the package's build directory
+// (build/<Package>) or its bytecode_modules directory. Dependency modules,
+// which the build writes to bytecode_modules/dependencies, are skipped.
+func LoadBuildOutput(dir string) (map[string][]byte, error) {
+	if sub := filepath.Join(dir, "bytecode_modules"); isDir(sub) {
+		dir = sub
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*.mv"))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("bytecode: no .mv files in %s", dir)
+	}
+	modules := make(map[string][]byte, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		modules[strings.TrimSuffix(filepath.Base(path), ".mv")] = data
+	}
+	return modules, nil
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// SubstituteAddress returns a copy of a module with every entry of its
+// address table equal to from replaced by to. Locally built packages carry
+// 0x0, or the address from Move.toml, where the published module carries
+// its package ID.
+func SubstituteAddress(data []byte, from, to types.Address) ([]byte, error) {
+	l, err := readLayout(data)
+	if err != nil {
+		return nil, err
+	}
+	out := bytes.Clone(data)
+	t := l.tables[tableAddresses]
+	if t.length%moduleAddressLength != 0 {
+		return nil, fmt.Errorf("bytecode: address table length %d is not a multiple of %d", t.length, moduleAddressLength)
+	}
+	start := l.contentStart + t.offset
+	for pos := start; pos < start+t.length; pos += moduleAddressLength {
+		if bytes.Equal(out[pos:pos+moduleAddressLength], from[:]) {
+			copy(out[pos:], to[:])
+		}
+	}
+	return out, nil
+}
+
+// VerifyModules compares locally built modules against the modules of a
+// published package, both keyed by module name. Each local module's own
+// address is replaced by the on-chain module's before comparing, so
+// unpublished builds (address 0x0) verify against the published package.
+func VerifyModules(local, onChain map[string][]byte) *VerificationReport {
+	names := make(map[string]struct{}, len(local)+len(onChain))
+	for name := range local {
+		names[name] = struct{}{}
+	}
+	for name := range onChain {
+		names[name] = struct{}{}
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	report := &VerificationReport{Modules: make([]ModuleVerification, 0, len(sorted))}
+	for _, name := range sorted {
+		report.Modules = append(report.Modules, verifyModule(name, local[name], onChain[name]))
+	}
+	return report
+}
+
+func verifyModule(name string, local, onChain []byte) ModuleVerification {
+	result := ModuleVerification{Name: name}
+	switch {
+	case onChain == nil:
+		result.Status = StatusMissingOnChain
+		return result
+	case local == nil:
+		result.Status = StatusMissingLocally
+		return result
+	}
+
+	result.Status = StatusMismatch
+	localModule, err := Parse(local)
+	if err != nil {
+		result.Detail = "local module: " + err.Error()
+		return result
+	}
+	chainModule, err := Parse(onChain)
+	if err != nil {
+		result.Detail = "on-chain module: " + err.Error()
+		return result
+	}
+	if localModule.ID.Name != chainModule.ID.Name {
+		result.Detail = fmt.Sprintf("module name %q does not match on-chain %q", localModule.ID.Name, chainModule.ID.Name)
+		return result
+	}
+
+	substituted, err := SubstituteAddress(local, localModule.ID.Address, chainModule.ID.Address)
+	if err != nil {
+		result.Detail = err.Error()
+		return result
+	}
+	if bytes.Equal(substituted, onChain) {
+		result.Status = StatusVerified
+		return result
+	}
+	result.Detail = describeMismatch(substituted, onChain)
+	return result
+}
+
+func describeMismatch(local, onChain []byte) string {
+	n := min(len(local), len(onChain))
+	offset := n
+	for i := 0; i < n; i++ {
+		if local[i] != onChain[i] {
+			offset = i
+			break
+		}
+	}
+	return fmt.Sprintf("bytecode differs at offset %d (local %d bytes, on-chain %d bytes)", offset, len(local), len(onChain))
+}
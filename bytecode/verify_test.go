@@ -0,0 +1,75 @@
+package bytecode
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+func TestVerifyModules(t *testing.T) {
+	published := coinModule()
+	// A local build of an unpublished package uses 0x0 for its own address.
+	local, err := SubstituteAddress(published, utils.MustParseAddress("0x2"), types.Address{})
+	if err != nil {
+		t.Fatalf("substitute: %v", err)
+	}
+	if m, err := Parse(local); err != nil || m.ID.Address != (types.Address{}) {
+		t.Fatalf("expected local module at 0x0, got %v %v", m, err)
+	}
+
+	tampered := append([]byte(nil), local...)
+	tampered[len(tampered)-2] ^= 0xFF
+
+	report := VerifyModules(
+		map[string][]byte{"coin": local, "extra": local, "pay": tampered},
+		map[string][]byte{"coin": published, "pay": published, "balance": published},
+	)
+	want := map[string]VerificationStatus{
+		"balance": StatusMissingLocally,
+		"coin":    StatusVerified,
+		"extra":   StatusMissingOnChain,
+		"pay":     StatusMismatch,
+	}
+	if len(report.Modules) != len(want) {
+		t.Fatalf("unexpected report %+v", report.Modules)
+	}
+	for _, m := range report.Modules {
+		if m.Status != want[m.Name] {
+			t.Fatalf("%s: got %s, want %s (%s)", m.Name, m.Status, want[m.Name], m.Detail)
+		}
+	}
+	if report.Verified() || len(report.Failed()) != 3 {
+		t.Fatalf("expected failed report, got %+v", report.Failed())
+	}
+
+	if !VerifyModules(map[string][]byte{"coin": local}, map[string][]byte{"coin": published}).Verified() {
+		t.Fatal("expected matching package to verify")
+	}
+}
+
+func TestLoadBuildOutput(t *testing.T) {
+	dir := t.TempDir()
+	modulesDir := filepath.Join(dir, "bytecode_modules")
+	if err := os.MkdirAll(filepath.Join(modulesDir, "dependencies", "Sui"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{
+		filepath.Join(modulesDir, "coin.mv"),
+		filepath.Join(modulesDir, "dependencies", "Sui", "object.mv"),
+	} {
+		if err := os.WriteFile(path, coinModule(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	modules, err := LoadBuildOutput(dir)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(modules) != 1 || modules["coin"] == nil {
+		t.Fatalf("unexpected modules %v", modules)
+	}
+}
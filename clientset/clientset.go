@@ -0,0 +1,157 @@
+// Package clientset keeps one gRPC client, GraphQL client and default
+// signer per Sui network, for applications that talk to several networks at
+// once:
+//
+//	clients, err := clientset.Load(ctx, "sui-networks.yaml")
+//	balance, err := clients.On("mainnet").GetBalance(ctx, owner, "0x2::sui::SUI")
+//
+// Sets are built in code with Add or loaded from a YAML or JSON file
+// describing endpoints, API keys and key references.
+package clientset
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/open-move/sui-go-sdk/account"
+	"github.com/open-move/sui-go-sdk/graphql"
+	"github.com/open-move/sui-go-sdk/grpc"
+	"github.com/open-move/sui-go-sdk/keypair"
+	"github.com/open-move/sui-go-sdk/network"
+)
+
+var (
+	// ErrUnknownNetwork reports a network name the set does not hold.
+	ErrUnknownNetwork = errors.New("clientset: unknown network")
+	// ErrNoSigner reports a network configured without a default signer.
+	ErrNoSigner = errors.New("clientset: network has no signer")
+)
+
+// Network is the clients and default signer for one network. It embeds the
+// gRPC client, so its methods can be called on the Network directly.
+type Network struct {
+	*grpc.Client
+	// Name is the name the network is registered under.
+	Name string
+	// Config is the network's profile: explorer links, faucet and chain ID.
+	Config network.Config
+	// GraphQL is the network's GraphQL client, or nil.
+	GraphQL *graphql.Client
+	// Signer is the network's default signer, or nil.
+	Signer keypair.Signer
+}
+
+// Account returns the default signer as an account on this network.
+func (n *Network) Account() (*account.Account, error) {
+	if n.Signer == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNoSigner, n.Name)
+	}
+	return account.New(n.Signer, n.Client)
+}
+
+// ClientSet maps network names to their clients. It is safe for concurrent
+// use.
+type ClientSet struct {
+	mu          sync.RWMutex
+	networks    map[string]*Network
+	defaultName string
+}
+
+// New returns an empty set.
+func New() *ClientSet {
+	return &ClientSet{networks: make(map[string]*Network)}
+}
+
+// Add registers n under n.Name, replacing any network of that name. The
+// first network added becomes the default.
+func (s *ClientSet) Add(n *Network) error {
+	if n == nil || n.Name == "" {
+		return errors.New("clientset: network name is empty")
+	}
+	if n.Client == nil && n.GraphQL == nil {
+		return fmt.Errorf("clientset: network %s has no client", n.Name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.networks[n.Name] = n
+	if s.defaultName == "" {
+		s.defaultName = n.Name
+	}
+	return nil
+}
+
+// Get returns the network registered under name.
+func (s *ClientSet) Get(name string) (*Network, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	n, ok := s.networks[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownNetwork, name)
+	}
+	return n, nil
+}
+
+// On returns the network registered under name and panics if there is none.
+// Use it where the names come from the application's own configuration;
+// use Get for names from user input.
+func (s *ClientSet) On(name string) *Network {
+	n, err := s.Get(name)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+// Default returns the default network: the one named by the config file's
+// default key or set with SetDefault, otherwise the first added.
+func (s *ClientSet) Default() (*Network, error) {
+	s.mu.RLock()
+	name := s.defaultName
+	s.mu.RUnlock()
+	if name == "" {
+		return nil, fmt.Errorf("%w: set is empty", ErrUnknownNetwork)
+	}
+	return s.Get(name)
+}
+
+// SetDefault makes name the default network.
+func (s *ClientSet) SetDefault(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.networks[name]; !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownNetwork, name)
+	}
+	s.defaultName = name
+	return nil
+}
+
+// Names returns the registered network names, sorted.
+func (s *ClientSet) Names() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	names := make([]string, 0, len(s.networks))
+	for name := range s.networks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Close closes every gRPC client in the set and returns the first error.
+func (s *ClientSet) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var firstErr error
+	for _, n := range s.networks {
+		if n.Client == nil {
+			continue
+		}
+		if err := n.Client.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,180 @@
+package clientset
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/cryptography/ed25519"
+	"github.com/open-move/sui-go-sdk/keypair"
+	"github.com/open-move/sui-go-sdk/keystore"
+	"github.com/open-move/sui-go-sdk/network"
+	v2 "github.com/open-move/sui-go-sdk/proto/sui/rpc/v2"
+	"github.com/open-move/sui-go-sdk/secrets"
+	"github.com/open-move/sui-go-sdk/utils"
+	googlegrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type balanceServer struct {
+	v2.UnimplementedStateServiceServer
+	apiKey string
+}
+
+func (s *balanceServer) GetBalance(ctx context.Context, req *v2.GetBalanceRequest) (*v2.GetBalanceResponse, error) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get("x-api-key")) > 0 {
+		s.apiKey = md.Get("x-api-key")[0]
+	}
+	return &v2.GetBalanceResponse{Balance: &v2.Balance{CoinType: req.CoinType, Balance: utils.Ptr(uint64(42))}}, nil
+}
+
+func TestLoadConfig(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	server := googlegrpc.NewServer()
+	balances := &balanceServer{}
+	v2.RegisterStateServiceServer(server, balances)
+	go server.Serve(lis)
+	defer server.Stop()
+
+	var graphqlKey string
+	gql := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		graphqlKey = r.Header.Get("Authorization")
+		w.Write([]byte(`{"data":{"chainIdentifier":"35834a8a"}}`))
+	}))
+	defer gql.Close()
+
+	envKey, _ := ed25519.Generate()
+	encoded, err := keypair.ToBech32FromKeypair(envKey)
+	if err != nil {
+		t.Fatalf("encode key: %v", err)
+	}
+	t.Setenv("CLIENTSET_TEST_KEY", encoded)
+	t.Setenv("CLIENTSET_TEST_API_KEY", "secret")
+
+	dir := t.TempDir()
+	storeKey, _ := ed25519.Generate()
+	store := keystore.New()
+	storeAddress, err := store.Import(storeKey)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	storePath := filepath.Join(dir, "sui.keystore")
+	if err := store.Save(storePath); err != nil {
+		t.Fatalf("save keystore: %v", err)
+	}
+
+	config := `
+default: testnet
+networks:
+  mainnet:
+    grpc: http://` + lis.Addr().String() + `
+    graphql: ` + gql.URL + `
+    apiKey: ${CLIENTSET_TEST_API_KEY}
+    key:
+      keystore: ` + storePath + `
+      address: "` + storeAddress + `"
+  testnet:
+    graphql: none
+    key:
+      env: CLIENTSET_TEST_KEY
+  local:
+    profile: localnet
+    apiKeyHeader: Authorization
+    graphql: ` + gql.URL + `
+    apiKey: Bearer ${CLIENTSET_TEST_API_KEY}
+`
+	path := filepath.Join(dir, "networks.yaml")
+	if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	ctx := context.Background()
+	clients, err := Load(ctx, path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	defer clients.Close()
+
+	if names := clients.Names(); len(names) != 3 || names[0] != "local" {
+		t.Fatalf("unexpected names %v", names)
+	}
+	def, err := clients.Default()
+	if err != nil || def.Name != "testnet" || def.GraphQL != nil || def.Config.ChainID != network.TestnetChainID {
+		t.Fatalf("unexpected default %+v (%v)", def, err)
+	}
+	if address, _ := def.Signer.SuiAddress(); address != mustAddress(t, envKey) {
+		t.Fatalf("testnet signer %s is not the env key", address)
+	}
+
+	mainnet := clients.On("mainnet")
+	balance, err := mainnet.GetBalance(ctx, storeAddress, "0x2::sui::SUI")
+	if err != nil || balance.GetBalance() != 42 {
+		t.Fatalf("balance: %v", err)
+	}
+	if balances.apiKey != "secret" {
+		t.Fatalf("grpc api key %q", balances.apiKey)
+	}
+	acct, err := mainnet.Account()
+	if err != nil || acct.Address() != storeAddress {
+		t.Fatalf("account: %v", err)
+	}
+
+	local := clients.On("local")
+	if local.Config.Name != network.Localnet {
+		t.Fatalf("unexpected profile %q", local.Config.Name)
+	}
+	if _, err := local.GraphQL.GetChainIdentifier(ctx); err != nil {
+		t.Fatalf("chain identifier: %v", err)
+	}
+	if graphqlKey != "Bearer secret" {
+		t.Fatalf("graphql api key %q", graphqlKey)
+	}
+	if _, err := local.Account(); !errors.Is(err, ErrNoSigner) {
+		t.Fatalf("expected ErrNoSigner, got %v", err)
+	}
+
+	if _, err := clients.Get("devnet"); !errors.Is(err, ErrUnknownNetwork) {
+		t.Fatalf("expected ErrUnknownNetwork, got %v", err)
+	}
+}
+
+func mustAddress(t *testing.T, signer keypair.Signer) string {
+	t.Helper()
+	address, err := signer.SuiAddress()
+	if err != nil {
+		t.Fatalf("address: %v", err)
+	}
+	return address
+}
+
+func TestKeyRefSealedEnv(t *testing.T) {
+	key, _ := ed25519.Generate()
+	sealed, err := secrets.Seal(key, "passphrase")
+	if err != nil {
+		t.Fatalf("seal: %v", err)
+	}
+	t.Setenv("CLIENTSET_SEALED_KEY", sealed)
+	t.Setenv("CLIENTSET_SEALED_PASSPHRASE", "passphrase")
+
+	signer, err := KeyRef{Env: "CLIENTSET_SEALED_KEY", PassphraseEnv: "CLIENTSET_SEALED_PASSPHRASE"}.Signer()
+	if err != nil {
+		t.Fatalf("signer: %v", err)
+	}
+	got, _ := signer.SuiAddress()
+	want, _ := key.SuiAddress()
+	if got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	if _, err := (KeyRef{Env: "CLIENTSET_SEALED_KEY"}).Signer(); !errors.Is(err, secrets.ErrPassphraseRequired) {
+		t.Fatalf("expected ErrPassphraseRequired, got %v", err)
+	}
+}
@@ -0,0 +1,249 @@
+package clientset
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/open-move/sui-go-sdk/auth"
+	"github.com/open-move/sui-go-sdk/graphql"
+	"github.com/open-move/sui-go-sdk/grpc"
+	"github.com/open-move/sui-go-sdk/keypair"
+	"github.com/open-move/sui-go-sdk/keystore"
+	"github.com/open-move/sui-go-sdk/network"
+	"github.com/open-move/sui-go-sdk/secrets"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultAPIKeyHeader is the header API keys are sent in unless a network
+// sets apiKeyHeader.
+const DefaultAPIKeyHeader = "x-api-key"
+
+// Config is the file format read by Load:
+//
+//	default: testnet
+//	networks:
+//	  mainnet:
+//	    grpc: https://fullnode.mainnet.sui.io
+//	    graphql: https://graphql.mainnet.sui.io/graphql
+//	    apiKey: ${MAINNET_API_KEY}
+//	    key:
+//	      keystore: ~/.sui/sui_config/sui.keystore
+//	      address: "0x..."
+//	  testnet:
+//	    key:
+//	      env: TESTNET_PRIVATE_KEY
+//
+// String values may reference environment variables as $VAR or ${VAR}, so
+// secrets can stay out of the file. Networks named after a known network
+// (or with a profile) default to its public endpoints.
+type Config struct {
+	Default  string                   `json:"default" yaml:"default"`
+	Networks map[string]NetworkConfig `json:"networks" yaml:"networks"`
+}
+
+// NetworkConfig configures one network of a Config.
+type NetworkConfig struct {
+	// Profile names the known network (mainnet, testnet, devnet or
+	// localnet) whose endpoints and explorer links are the defaults. It
+	// defaults to the network's name.
+	Profile string `json:"profile,omitempty" yaml:"profile,omitempty"`
+	// GRPC and GraphQL override the profile's endpoints. Set either to
+	// "none" to skip that client.
+	GRPC    string `json:"grpc,omitempty" yaml:"grpc,omitempty"`
+	GraphQL string `json:"graphql,omitempty" yaml:"graphql,omitempty"`
+	// APIKey is sent with every request to both endpoints, in
+	// APIKeyHeader.
+	APIKey       string `json:"apiKey,omitempty" yaml:"apiKey,omitempty"`
+	APIKeyHeader string `json:"apiKeyHeader,omitempty" yaml:"apiKeyHeader,omitempty"`
+	// Key references the network's default signer.
+	Key *KeyRef `json:"key,omitempty" yaml:"key,omitempty"`
+}
+
+// KeyRef references a private key without containing it. Exactly one of
+// Env and Address must be set.
+type KeyRef struct {
+	// Env names an environment variable holding a suiprivkey encoded key,
+	// or one sealed with secrets.Seal.
+	Env string `json:"env,omitempty" yaml:"env,omitempty"`
+	// Address selects a key from Keystore.
+	Address string `json:"address,omitempty" yaml:"address,omitempty"`
+	// Keystore is the sui.keystore file holding Address. It defaults to
+	// the Sui CLI keystore; a leading ~ is the home directory.
+	Keystore string `json:"keystore,omitempty" yaml:"keystore,omitempty"`
+	// PassphraseEnv names an environment variable holding the passphrase
+	// of an encrypted keystore, or of a sealed key in Env.
+	PassphraseEnv string `json:"passphraseEnv,omitempty" yaml:"passphraseEnv,omitempty"`
+}
+
+// LoadConfig reads a Config from a .yaml, .yml or .json file.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("clientset: read %s: %w", path, err)
+	}
+
+	var cfg Config
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &cfg)
+	case ".json":
+		err = json.Unmarshal(data, &cfg)
+	default:
+		return nil, fmt.Errorf("clientset: unsupported config format %q", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("clientset: parse %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// Load reads the config file at path and connects to every network in it.
+func Load(ctx context.Context, path string) (*ClientSet, error) {
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	return FromConfig(ctx, cfg)
+}
+
+// FromConfig connects to every network in cfg. On error, clients created
+// so far are closed.
+func FromConfig(ctx context.Context, cfg *Config) (*ClientSet, error) {
+	if cfg == nil || len(cfg.Networks) == 0 {
+		return nil, errors.New("clientset: config has no networks")
+	}
+
+	names := make([]string, 0, len(cfg.Networks))
+	for name := range cfg.Networks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	set := New()
+	for _, name := range names {
+		n, err := newNetwork(ctx, name, cfg.Networks[name])
+		if err == nil {
+			err = set.Add(n)
+		}
+		if err != nil {
+			set.Close()
+			return nil, fmt.Errorf("clientset: network %s: %w", name, err)
+		}
+	}
+	if cfg.Default != "" {
+		if err := set.SetDefault(os.ExpandEnv(cfg.Default)); err != nil {
+			set.Close()
+			return nil, err
+		}
+	}
+	return set, nil
+}
+
+func newNetwork(ctx context.Context, name string, cfg NetworkConfig) (*Network, error) {
+	profile := os.ExpandEnv(cfg.Profile)
+	if profile == "" {
+		profile = name
+	}
+	netCfg, known := network.Get(network.Name(profile))
+	if !known {
+		if cfg.Profile != "" {
+			return nil, fmt.Errorf("unknown profile %q", profile)
+		}
+		netCfg = network.Config{Name: network.Custom}
+	}
+
+	grpcURL := firstNonEmpty(os.ExpandEnv(cfg.GRPC), netCfg.GRPCURL)
+	graphqlURL := firstNonEmpty(os.ExpandEnv(cfg.GraphQL), netCfg.GraphQLURL)
+	if grpcURL == "" && graphqlURL == "" {
+		return nil, errors.New("no endpoints configured")
+	}
+	header := firstNonEmpty(os.ExpandEnv(cfg.APIKeyHeader), DefaultAPIKeyHeader)
+	apiKey := os.ExpandEnv(cfg.APIKey)
+
+	n := &Network{Name: name, Config: netCfg}
+	if cfg.Key != nil {
+		signer, err := cfg.Key.Signer()
+		if err != nil {
+			return nil, err
+		}
+		n.Signer = signer
+	}
+	if graphqlURL != "" && graphqlURL != "none" {
+		opts := []graphql.ClientOption{graphql.WithEndpoint(graphqlURL)}
+		if apiKey != "" {
+			opts = append(opts, graphql.WithAuthenticator(auth.APIKey(header, apiKey)))
+		}
+		n.GraphQL = graphql.NewClient(opts...)
+	}
+	if grpcURL != "" && grpcURL != "none" {
+		var opts []grpc.Option
+		if apiKey != "" {
+			opts = append(opts, grpc.WithAuthenticator(auth.APIKey(header, apiKey)))
+		}
+		client, err := grpc.NewClient(ctx, grpcURL, opts...)
+		if err != nil {
+			return nil, err
+		}
+		n.Client = client
+	}
+	return n, nil
+}
+
+// Signer loads the referenced key.
+func (r KeyRef) Signer() (keypair.Signer, error) {
+	env, address := os.ExpandEnv(r.Env), os.ExpandEnv(r.Address)
+	switch {
+	case env != "" && address != "":
+		return nil, errors.New("key sets both env and address")
+	case env != "":
+		encoded := os.Getenv(env)
+		if encoded == "" {
+			return nil, fmt.Errorf("key variable %s is not set", env)
+		}
+		return secrets.Open(context.Background(), encoded, secrets.Options{
+			Passphrase:     os.Getenv(r.PassphraseEnv),
+			AllowPlaintext: true,
+		})
+	case address != "":
+		store, err := r.loadKeystore()
+		if err != nil {
+			return nil, err
+		}
+		return store.Keypair(address)
+	default:
+		return nil, errors.New("key sets neither env nor address")
+	}
+}
+
+func (r KeyRef) loadKeystore() (*keystore.Keystore, error) {
+	path := os.ExpandEnv(r.Keystore)
+	if path == "" {
+		return keystore.LoadDefault()
+	}
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		path = filepath.Join(home, rest)
+	}
+	if r.PassphraseEnv != "" {
+		return keystore.LoadEncrypted(path, os.Getenv(r.PassphraseEnv))
+	}
+	return keystore.Load(path)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,100 @@
+// Command sui-movegen generates Go bindings for a published Move package:
+// structs with the BCS layout of its Move structs and enums, and typed
+// wrappers for its public and entry functions. It is meant to be run
+// through go:generate:
+//
+//	//go:generate go run github.com/open-move/sui-go-sdk/cmd/sui-movegen -package-id 0xabc -module pool -package pool -out pool_gen.go
+//
+// The package ABI is fetched from a GraphQL endpoint (-endpoint, mainnet by
+// default) or read from a file written with -save-abi, so later runs can
+// use a pinned ABI.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/open-move/sui-go-sdk/graphql"
+	"github.com/open-move/sui-go-sdk/movegen"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, "sui-movegen:", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	packageID := flag.String("package-id", "", "address of the Move package to fetch")
+	abiPath := flag.String("abi", "", "ABI JSON file to read instead of fetching the package")
+	endpoint := flag.String("endpoint", graphql.MainnetEndpoint, "GraphQL endpoint to fetch the package from")
+	saveABI := flag.String("save-abi", "", "write the fetched ABI to this file")
+	modules := flag.String("module", "", "comma-separated modules to generate (default all)")
+	pkg := flag.String("package", os.Getenv("GOPACKAGE"), "package name of the generated file (defaults to $GOPACKAGE)")
+	out := flag.String("out", "", "output file (default stdout)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: sui-movegen [flags]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if (*packageID == "") == (*abiPath == "") {
+		flag.Usage()
+		return fmt.Errorf("exactly one of -package-id and -abi is required")
+	}
+
+	var abi graphql.PackageABI
+	if *abiPath != "" {
+		data, err := os.ReadFile(*abiPath)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(data, &abi); err != nil {
+			return fmt.Errorf("read ABI %s: %w", *abiPath, err)
+		}
+	} else {
+		address, err := utils.ParseAddress(*packageID)
+		if err != nil {
+			return err
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+
+		client := graphql.NewClient(graphql.WithEndpoint(*endpoint))
+		fetched, err := client.GetPackageABI(ctx, address)
+		if err != nil {
+			return fmt.Errorf("fetch package %s: %w", *packageID, err)
+		}
+		abi = *fetched
+		if *saveABI != "" {
+			data, err := abi.JSON()
+			if err != nil {
+				return err
+			}
+			if err := os.WriteFile(*saveABI, data, 0o644); err != nil {
+				return err
+			}
+		}
+	}
+
+	opts := movegen.Options{Package: *pkg}
+	if *modules != "" {
+		opts.Modules = strings.Split(*modules, ",")
+	}
+	code, err := movegen.Generate(&abi, opts)
+	if err != nil {
+		return err
+	}
+	if *out == "" {
+		_, err = os.Stdout.Write(code)
+		return err
+	}
+	return os.WriteFile(*out, code, 0o644)
+}
@@ -0,0 +1,96 @@
+// Command suigql-gen generates typed Go code for GraphQL operations against
+// the Sui GraphQL schema. It is meant to be run through go:generate:
+//
+//	//go:generate go run github.com/open-move/sui-go-sdk/cmd/suigql-gen -schema schema.json -package queries -out queries_gen.go queries.graphql
+//
+// The schema is read from an introspection response (-schema) or fetched from
+// a live endpoint (-endpoint). With -save-schema the fetched introspection
+// response is written to disk so later runs can use a pinned schema.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/open-move/sui-go-sdk/graphql"
+)
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, "suigql-gen:", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	schemaPath := flag.String("schema", "", "introspection JSON file to read the schema from")
+	endpoint := flag.String("endpoint", "", "GraphQL endpoint to introspect when -schema is not set")
+	saveSchema := flag.String("save-schema", "", "write the fetched introspection response to this file")
+	pkg := flag.String("package", os.Getenv("GOPACKAGE"), "package name of the generated file (defaults to $GOPACKAGE)")
+	out := flag.String("out", "", "output file (default stdout)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: suigql-gen [flags] file.graphql...\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		return fmt.Errorf("no GraphQL documents given")
+	}
+	if (*schemaPath == "") == (*endpoint == "") {
+		return fmt.Errorf("exactly one of -schema and -endpoint is required")
+	}
+
+	var introspection []byte
+	if *schemaPath != "" {
+		data, err := os.ReadFile(*schemaPath)
+		if err != nil {
+			return err
+		}
+		introspection = data
+	} else {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		var raw json.RawMessage
+		client := graphql.NewClient(graphql.WithEndpoint(*endpoint))
+		if err := client.Execute(ctx, graphql.IntrospectionQuery, nil, &raw); err != nil {
+			return fmt.Errorf("introspect %s: %w", *endpoint, err)
+		}
+		introspection = raw
+		if *saveSchema != "" {
+			if err := os.WriteFile(*saveSchema, raw, 0o644); err != nil {
+				return err
+			}
+		}
+	}
+
+	schema, err := graphql.ParseIntrospection(introspection)
+	if err != nil {
+		return err
+	}
+
+	sources := make([]graphql.CodegenSource, 0, flag.NArg())
+	for _, path := range flag.Args() {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		sources = append(sources, graphql.CodegenSource{Name: path, Content: string(data)})
+	}
+
+	code, err := graphql.GenerateGo(schema, graphql.CodegenOptions{Package: *pkg}, sources...)
+	if err != nil {
+		return err
+	}
+	if *out == "" {
+		_, err = os.Stdout.Write(code)
+		return err
+	}
+	return os.WriteFile(*out, code, 0o644)
+}
@@ -0,0 +1,142 @@
+// Package coinmanager builds programmable transactions for issuing and
+// retiring coins through a TreasuryCap, and locates the TreasuryCap and
+// CoinMetadata objects for a coin type.
+package coinmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/open-move/sui-go-sdk/graphql"
+	"github.com/open-move/sui-go-sdk/transaction"
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+const (
+	coinMintTarget            = "0x2::coin::mint"
+	coinMintAndTransferTarget = "0x2::coin::mint_and_transfer"
+	coinBurnTarget            = "0x2::coin::burn"
+)
+
+var (
+	ErrTreasuryCapNotFound  = errors.New("treasury cap not found")
+	ErrCoinMetadataNotFound = graphql.ErrCoinMetadataNotFound
+)
+
+// TreasuryCapType returns the fully qualified TreasuryCap type for coinType.
+func TreasuryCapType(coinType string) string {
+	return fmt.Sprintf("0x2::coin::TreasuryCap<%s>", coinType)
+}
+
+// Mint appends a coin::mint call that mints amount into a new coin and
+// returns the coin argument for use in later commands.
+func Mint(tx *transaction.Transaction, coinType string, treasuryCap transaction.Argument, amount uint64) transaction.Argument {
+	return tx.MoveCall(transaction.MoveCall{
+		Target:        coinMintTarget,
+		TypeArguments: []string{coinType},
+		Arguments:     []transaction.Argument{treasuryCap, tx.PureU64(amount)},
+	}).Arg()
+}
+
+// MintAndTransfer appends a coin::mint_and_transfer call that mints amount
+// and sends it to recipient.
+func MintAndTransfer(tx *transaction.Transaction, coinType string, treasuryCap transaction.Argument, amount uint64, recipient string) {
+	tx.MoveCall(transaction.MoveCall{
+		Target:        coinMintAndTransferTarget,
+		TypeArguments: []string{coinType},
+		Arguments:     []transaction.Argument{treasuryCap, tx.PureU64(amount), tx.PureAddress(recipient)},
+	})
+}
+
+// Burn appends a coin::burn call that destroys coin and decreases the total
+// supply. The returned argument is the burned amount (u64).
+func Burn(tx *transaction.Transaction, coinType string, treasuryCap transaction.Argument, coin transaction.Argument) transaction.Argument {
+	return tx.MoveCall(transaction.MoveCall{
+		Target:        coinBurnTarget,
+		TypeArguments: []string{coinType},
+		Arguments:     []transaction.Argument{treasuryCap, coin},
+	}).Arg()
+}
+
+// Manager locates the on-chain objects needed to manage a coin.
+type Manager struct {
+	client *graphql.Client
+}
+
+// New returns a Manager backed by a GraphQL client.
+func New(client *graphql.Client) *Manager {
+	return &Manager{client: client}
+}
+
+// FindTreasuryCap returns the TreasuryCap for coinType, wherever it lives.
+func (m *Manager) FindTreasuryCap(ctx context.Context, coinType string) (*graphql.Object, error) {
+	query := `
+		query FindTreasuryCap($filter: ObjectFilter!) {
+			objects(filter: $filter, first: 1) {
+				nodes {
+					address
+					version
+					digest
+					owner {
+						__typename
+						... on AddressOwner { address { address } }
+						... on ObjectOwner { address { address } }
+						... on Shared { initialSharedVersion }
+					}
+				}
+			}
+		}
+	`
+
+	capType := TreasuryCapType(coinType)
+	var result struct {
+		Objects *graphql.Connection[graphql.Object] `json:"objects"`
+	}
+	err := m.client.Execute(ctx, query, map[string]any{"filter": graphql.ObjectFilter{Type: &capType}}, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Objects == nil || len(result.Objects.Nodes) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrTreasuryCapNotFound, coinType)
+	}
+	return &result.Objects.Nodes[0], nil
+}
+
+// FindOwnedTreasuryCap returns the TreasuryCap for coinType owned by owner.
+func (m *Manager) FindOwnedTreasuryCap(ctx context.Context, owner types.Address, coinType string) (*graphql.Object, error) {
+	capType := TreasuryCapType(coinType)
+	objects, err := m.client.GetOwnedObjects(ctx, owner, &graphql.ObjectFilter{Type: &capType}, &graphql.PaginationArgs{First: utils.Ptr(1)})
+	if err != nil {
+		return nil, err
+	}
+
+	if objects == nil || len(objects.Nodes) == 0 {
+		return nil, fmt.Errorf("%w: %s owned by %s", ErrTreasuryCapNotFound, coinType, owner)
+	}
+	return &objects.Nodes[0], nil
+}
+
+// GetCoinMetadata returns the CoinMetadata object for coinType.
+func (m *Manager) GetCoinMetadata(ctx context.Context, coinType string) (*graphql.CoinMetadata, error) {
+	metadata, err := m.client.GetCoinMetadata(ctx, coinType)
+	if err != nil {
+		return nil, err
+	}
+	if metadata == nil {
+		return nil, fmt.Errorf("%w: %s", ErrCoinMetadataNotFound, coinType)
+	}
+	return metadata, nil
+}
+
+// TreasuryCapRef returns the object reference of the TreasuryCap for
+// coinType owned by owner, ready to pass to tx.ObjectRef.
+func (m *Manager) TreasuryCapRef(ctx context.Context, owner types.Address, coinType string) (types.ObjectRef, error) {
+	obj, err := m.FindOwnedTreasuryCap(ctx, owner, coinType)
+	if err != nil {
+		return types.ObjectRef{}, err
+	}
+	return types.ObjectRef{ObjectID: obj.Address, Version: uint64(obj.Version), Digest: obj.Digest}, nil
+}
@@ -0,0 +1,95 @@
+package coinmanager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/graphql"
+	"github.com/open-move/sui-go-sdk/transaction"
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+const testCoinType = "0xabc::token::TOKEN"
+
+func TestMintBurnCommands(t *testing.T) {
+	capRef := types.ObjectRef{
+		ObjectID: utils.MustParseAddress("0xc4"),
+		Version:  4,
+		Digest:   types.Digest(bytes.Repeat([]byte{7}, 32)),
+	}
+
+	tx := transaction.New()
+	treasuryCap := tx.ObjectRef(capRef)
+	minted := Mint(tx, testCoinType, treasuryCap, 1_000)
+	Burn(tx, testCoinType, treasuryCap, minted)
+	MintAndTransfer(tx, testCoinType, treasuryCap, 5, "0xb0b")
+
+	result, err := tx.Build(context.Background(), transaction.BuildOptions{})
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+
+	commands := result.ProgrammableKind.Commands
+	want := []string{"mint", "burn", "mint_and_transfer"}
+	if len(commands) != len(want) {
+		t.Fatalf("expected %d commands, got %d", len(want), len(commands))
+	}
+	for i, cmd := range commands {
+		call := cmd.MoveCall
+		if call == nil || call.Module != "coin" || call.Function != want[i] {
+			t.Fatalf("command %d: expected coin::%s, got %+v", i, want[i], cmd)
+		}
+		if len(call.TypeArguments) != 1 || call.TypeArguments[0].Struct == nil || call.TypeArguments[0].Struct.Name != "TOKEN" {
+			t.Fatalf("command %d: unexpected type arguments", i)
+		}
+		if call.Arguments[0].Input == nil || *call.Arguments[0].Input != 0 {
+			t.Fatalf("command %d: treasury cap should be the first input", i)
+		}
+	}
+	if burned := commands[1].MoveCall.Arguments[1].Result; burned == nil || *burned != 0 {
+		t.Fatalf("burn should consume the minted coin")
+	}
+}
+
+func TestFindTreasuryCap(t *testing.T) {
+	capID := utils.MustParseAddress("0xc4")
+	var filterType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query     string         `json:"query"`
+			Variables map[string]any `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		var data any
+		switch {
+		case strings.Contains(req.Query, "FindTreasuryCap"):
+			filterType = req.Variables["filter"].(map[string]any)["type"].(string)
+			data = map[string]any{"objects": map[string]any{"nodes": []map[string]any{{"address": capID.String(), "version": 3}}}}
+		case strings.Contains(req.Query, "coinMetadata"):
+			data = map[string]any{"coinMetadata": nil}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+	defer server.Close()
+
+	manager := New(graphql.NewClient(graphql.WithEndpoint(server.URL)))
+	obj, err := manager.FindTreasuryCap(context.Background(), testCoinType)
+	if err != nil {
+		t.Fatalf("find treasury cap: %v", err)
+	}
+	if obj.Address != capID || filterType != "0x2::coin::TreasuryCap<"+testCoinType+">" {
+		t.Fatalf("unexpected lookup: %v %s", obj.Address, filterType)
+	}
+
+	if _, err := manager.GetCoinMetadata(context.Background(), testCoinType); !errors.Is(err, ErrCoinMetadataNotFound) {
+		t.Fatalf("expected ErrCoinMetadataNotFound, got %v", err)
+	}
+}
@@ -7,7 +7,9 @@ import (
 	"crypto/rand"
 	"encoding/binary"
 	"fmt"
+	"slices"
 
+	"github.com/open-move/sui-go-sdk/cryptography/intent"
 	"github.com/open-move/sui-go-sdk/cryptography/personalmsg"
 	"github.com/open-move/sui-go-sdk/cryptography/transaction"
 	"github.com/open-move/sui-go-sdk/keychain"
@@ -103,6 +105,18 @@ func (k Keypair) SignTransaction(txBytes []byte) ([]byte, error) {
 	)
 }
 
+// SignWithIntent signs a BCS-serialized payload under an arbitrary intent
+// scope, e.g. checkpoint summaries or proofs of possession.
+func (k Keypair) SignWithIntent(scope intent.IntentScope, payload []byte) ([]byte, error) {
+	return transaction.SignWithIntent(
+		keychain.SchemeEd25519,
+		scope,
+		payload,
+		k.PublicKey(),
+		k.signData,
+	)
+}
+
 // VerifyPersonalMessage verifies a personal message signature.
 func (k Keypair) VerifyPersonalMessage(message []byte, signature []byte) error {
 	return VerifyPersonalMessage(k.PublicKey(), message, signature)
@@ -147,12 +161,28 @@ func FromSecretKey(seed []byte) (*Keypair, error) {
 // supplied master seed. Ed25519 only supports hardened segments; any
 // non-hardened index results in an error.
 func Derive(seed []byte, path keychain.DerivationPath) (*Keypair, error) {
-	if err := path.ValidateForScheme(keychain.SchemeEd25519); err != nil {
-		return nil, err
-	}
+	return DeriveFrom(MasterKey(seed), path)
+}
+
+// ExtendedKey is a private key and chain code partway down a SLIP-0010
+// derivation path. Deriving many keys below a shared parent, such as every
+// address index of an account, only walks the segments after it.
+type ExtendedKey struct {
+	key      []byte
+	chain    []byte
+	segments []keychain.PathSegment
+}
 
+// MasterKey returns the root extended key of seed.
+func MasterKey(seed []byte) *ExtendedKey {
 	key, chain := slip10MasterKey(seed)
-	for _, segment := range path.Segments() {
+	return &ExtendedKey{key: key, chain: chain}
+}
+
+// Derive walks segments down from k and returns the extended key reached.
+func (k *ExtendedKey) Derive(segments ...keychain.PathSegment) (*ExtendedKey, error) {
+	key, chain := k.key, k.chain
+	for _, segment := range segments {
 		if !segment.Hardened {
 			return nil, fmt.Errorf("ed25519: slip-0010 only supports hardened segments")
 		}
@@ -169,13 +199,30 @@ func Derive(seed []byte, path keychain.DerivationPath) (*Keypair, error) {
 		key = digest[:seedSize]
 		chain = digest[seedSize:]
 	}
+	return &ExtendedKey{key: key, chain: chain, segments: append(slices.Clip(k.segments), segments...)}, nil
+}
+
+// DeriveFrom derives the keypair at path from parent, which must have been
+// derived along the start of path.
+func DeriveFrom(parent *ExtendedKey, path keychain.DerivationPath) (*Keypair, error) {
+	if err := path.ValidateForScheme(keychain.SchemeEd25519); err != nil {
+		return nil, err
+	}
+	segments := path.Segments()
+	if len(parent.segments) > len(segments) || !slices.Equal(parent.segments, segments[:len(parent.segments)]) {
+		return nil, fmt.Errorf("ed25519: path %s does not extend the parent key", path)
+	}
+	leaf, err := parent.Derive(segments[len(parent.segments):]...)
+	if err != nil {
+		return nil, err
+	}
 
-	privateKey := cryptoed25519.NewKeyFromSeed(key)
+	privateKey := cryptoed25519.NewKeyFromSeed(leaf.key)
 	publicKey := cryptoed25519.PublicKey(privateKey[32:])
 	return &Keypair{
 		privateKey: privateKey,
 		publicKey:  publicKey,
-		chainCode:  append([]byte{}, chain...),
+		chainCode:  append([]byte{}, leaf.chain...),
 		path:       path,
 	}, nil
 }
@@ -191,17 +191,28 @@ func HashIntentMessage[T any](message IntentMessage[T]) ([32]byte, error) {
 	return blake2b.Sum256(serialized), nil
 }
 
-// HashIntentBytes hashes a raw payload with an intent prefix without
-// re-encoding the payload as BCS.
-func HashIntentBytes(scope IntentScope, payload []byte) ([32]byte, error) {
+// BuildIntentMessage prefixes an already BCS-serialized payload with the
+// default intent for scope, without re-encoding the payload.
+func BuildIntentMessage(scope IntentScope, payload []byte) ([]byte, error) {
 	intent := DefaultIntent().WithScope(scope)
 	if err := intent.Validate(); err != nil {
-		return [32]byte{}, err
+		return nil, err
 	}
 
 	intentBytes := intent.Bytes()
 	combined := make([]byte, 0, len(intentBytes)+len(payload))
 	combined = append(combined, intentBytes[:]...)
 	combined = append(combined, payload...)
-	return blake2b.Sum256(combined), nil
+	return combined, nil
+}
+
+// HashIntentBytes hashes a raw payload with an intent prefix without
+// re-encoding the payload as BCS.
+func HashIntentBytes(scope IntentScope, payload []byte) ([32]byte, error) {
+	message, err := BuildIntentMessage(scope, payload)
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	return blake2b.Sum256(message), nil
 }
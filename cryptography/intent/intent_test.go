@@ -88,3 +88,28 @@ func TestIntentValidationErrors(t *testing.T) {
 		t.Fatalf("expected errInvalidIntentAppID, got %v", err)
 	}
 }
+
+func TestBuildIntentMessage(t *testing.T) {
+	payload := []byte{0xde, 0xad}
+	message, err := BuildIntentMessage(IntentScopeCheckpointSummary, payload)
+	if err != nil {
+		t.Fatalf("build intent message: %v", err)
+	}
+
+	expected := []byte{byte(IntentScopeCheckpointSummary), byte(IntentVersionV0), byte(AppIDSui), 0xde, 0xad}
+	if !bytes.Equal(message, expected) {
+		t.Fatalf("unexpected intent message: got %x want %x", message, expected)
+	}
+
+	digest, err := HashIntentBytes(IntentScopeCheckpointSummary, payload)
+	if err != nil {
+		t.Fatalf("hash intent bytes: %v", err)
+	}
+	if digest != blake2b.Sum256(expected) {
+		t.Fatalf("hash does not match built message")
+	}
+
+	if _, err := BuildIntentMessage(255, payload); !errors.Is(err, errInvalidIntentScope) {
+		t.Fatalf("expected errInvalidIntentScope, got %v", err)
+	}
+}
@@ -6,9 +6,11 @@ import (
 	"crypto/sha256"
 	"fmt"
 	"math/big"
+	"slices"
 
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
 	secp256k1ecdsa "github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/open-move/sui-go-sdk/cryptography/intent"
 	"github.com/open-move/sui-go-sdk/cryptography/personalmsg"
 	"github.com/open-move/sui-go-sdk/cryptography/transaction"
 	"github.com/open-move/sui-go-sdk/keychain"
@@ -130,6 +132,18 @@ func (k Keypair) SignTransaction(txBytes []byte) ([]byte, error) {
 	)
 }
 
+// SignWithIntent signs a BCS-serialized payload under an arbitrary intent
+// scope, e.g. checkpoint summaries or proofs of possession.
+func (k Keypair) SignWithIntent(scope intent.IntentScope, payload []byte) ([]byte, error) {
+	return transaction.SignWithIntent(
+		keychain.SchemeSecp256k1,
+		scope,
+		payload,
+		k.PublicKey(),
+		k.signData,
+	)
+}
+
 // VerifyPersonalMessage verifies a personal message signature.
 func (k Keypair) VerifyPersonalMessage(message []byte, signature []byte) error {
 	return VerifyPersonalMessage(k.PublicKey(), message, signature)
@@ -182,12 +196,27 @@ func FromSecretKey(secret []byte) (*Keypair, error) {
 // steps) starting from the provided seed and returns the resulting keypair and
 // chain code.
 func Derive(seed []byte, path keychain.DerivationPath) (*Keypair, error) {
-	if err := path.ValidateForScheme(keychain.SchemeSecp256k1); err != nil {
-		return nil, err
-	}
+	return DeriveFrom(MasterKey(seed), path)
+}
 
+// ExtendedKey is a private key and chain code partway down a BIP-32
+// derivation path. Deriving many keys below a shared parent, such as every
+// address index of an account, only walks the segments after it.
+type ExtendedKey struct {
+	key      []byte
+	chain    []byte
+	segments []keychain.PathSegment
+}
+
+// MasterKey returns the root extended key of seed.
+func MasterKey(seed []byte) *ExtendedKey {
 	key, chain := keychain.BIP32MasterPrivateKey(seed)
-	segments := path.Segments()
+	return &ExtendedKey{key: key, chain: chain}
+}
+
+// Derive walks segments down from k and returns the extended key reached.
+func (k *ExtendedKey) Derive(segments ...keychain.PathSegment) (*ExtendedKey, error) {
+	key, chain := k.key, k.chain
 	for _, segment := range segments {
 		nextKey, nextChain, err := keychain.DeriveChildPrivateKey(key, chain, segment, func(priv []byte) ([]byte, error) {
 			privKey := secp256k1.PrivKeyFromBytes(priv)
@@ -204,6 +233,24 @@ func Derive(seed []byte, path keychain.DerivationPath) (*Keypair, error) {
 		key = nextKey
 		chain = nextChain
 	}
+	return &ExtendedKey{key: key, chain: chain, segments: append(slices.Clip(k.segments), segments...)}, nil
+}
+
+// DeriveFrom derives the keypair at path from parent, which must have been
+// derived along the start of path.
+func DeriveFrom(parent *ExtendedKey, path keychain.DerivationPath) (*Keypair, error) {
+	if err := path.ValidateForScheme(keychain.SchemeSecp256k1); err != nil {
+		return nil, err
+	}
+	segments := path.Segments()
+	if len(parent.segments) > len(segments) || !slices.Equal(parent.segments, segments[:len(parent.segments)]) {
+		return nil, fmt.Errorf("secp256k1: path %s does not extend the parent key", path)
+	}
+	leaf, err := parent.Derive(segments[len(parent.segments):]...)
+	if err != nil {
+		return nil, err
+	}
+	key, chain := leaf.key, leaf.chain
 
 	privKey := secp256k1.PrivKeyFromBytes(key)
 	if privKey == nil || privKey.Key.IsZeroBit() == 1 {
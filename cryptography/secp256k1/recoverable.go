@@ -0,0 +1,118 @@
+package secp256k1
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	secp256k1ecdsa "github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"golang.org/x/crypto/sha3"
+)
+
+// RecoverableSignatureSize is the length of a recoverable signature:
+// 32-byte R, 32-byte S and a one-byte recovery id.
+const RecoverableSignatureSize = 65
+
+// compactRecoveryOffset is added to the recovery id in the decred compact
+// signature header (27, plus 4 for a compressed public key).
+const compactRecoveryOffset = 27 + 4
+
+// SignRecoverable signs the Keccak-256 hash of message and returns a 65-byte
+// `R || S || v` signature with v in {0, 1}, as used by Ethereum and the Sui
+// bridge. S is always in the lower half of the curve order.
+func (k Keypair) SignRecoverable(message []byte) ([]byte, error) {
+	return k.SignRecoverableDigest(Keccak256(message))
+}
+
+// SignRecoverableDigest signs a precomputed 32-byte digest and returns a
+// 65-byte `R || S || v` signature.
+func (k Keypair) SignRecoverableDigest(digest [32]byte) ([]byte, error) {
+	if k.privateKey == nil {
+		return nil, fmt.Errorf("secp256k1: private key is nil")
+	}
+
+	compact := secp256k1ecdsa.SignCompact(k.privateKey, digest[:], true)
+	out := make([]byte, RecoverableSignatureSize)
+	copy(out, compact[1:])
+	out[64] = compact[0] - compactRecoveryOffset
+	return out, nil
+}
+
+// RecoverPublicKey returns the compressed public key that produced a
+// recoverable signature over the Keccak-256 hash of message.
+func RecoverPublicKey(message, signature []byte) ([]byte, error) {
+	return RecoverPublicKeyFromDigest(Keccak256(message), signature)
+}
+
+// RecoverPublicKeyFromDigest returns the compressed public key that produced
+// a recoverable signature over digest. Recovery ids of 27 and 28 are accepted
+// as well as 0 and 1.
+func RecoverPublicKeyFromDigest(digest [32]byte, signature []byte) ([]byte, error) {
+	if len(signature) != RecoverableSignatureSize {
+		return nil, fmt.Errorf("secp256k1: invalid recoverable signature length %d", len(signature))
+	}
+	v := signature[64]
+	if v >= 27 {
+		v -= 27
+	}
+	if v > 1 {
+		return nil, fmt.Errorf("secp256k1: invalid recovery id %d", signature[64])
+	}
+
+	compact := make([]byte, RecoverableSignatureSize)
+	compact[0] = v + compactRecoveryOffset
+	copy(compact[1:], signature[:64])
+	pub, _, err := secp256k1ecdsa.RecoverCompact(compact, digest[:])
+	if err != nil {
+		return nil, fmt.Errorf("secp256k1: recover public key: %w", err)
+	}
+	return pub.SerializeCompressed(), nil
+}
+
+// EthereumAddress returns the EIP-55 checksummed Ethereum address of the
+// keypair's public key. It shares the key with SuiAddress, so the two
+// addresses can be linked by one signer.
+func (k Keypair) EthereumAddress() (string, error) {
+	return EthereumAddressFromPublicKey(k.PublicKey())
+}
+
+// EthereumAddressFromPublicKey derives the EIP-55 checksummed Ethereum
+// address of a compressed or uncompressed secp256k1 public key.
+func EthereumAddressFromPublicKey(publicKey []byte) (string, error) {
+	pub, err := secp256k1.ParsePubKey(publicKey)
+	if err != nil {
+		return "", fmt.Errorf("secp256k1: invalid public key: %w", err)
+	}
+	hash := Keccak256(pub.SerializeUncompressed()[1:])
+	return checksumAddress(hash[12:]), nil
+}
+
+// Keccak256 returns the legacy Keccak-256 hash used by Ethereum.
+func Keccak256(data []byte) [32]byte {
+	var out [32]byte
+	h := sha3.NewLegacyKeccak256()
+	h.Write(data)
+	h.Sum(out[:0])
+	return out
+}
+
+// checksumAddress applies EIP-55 mixed-case checksum encoding.
+func checksumAddress(address []byte) string {
+	lower := hex.EncodeToString(address)
+	hash := Keccak256([]byte(lower))
+
+	var b strings.Builder
+	b.WriteString("0x")
+	for i, c := range lower {
+		nibble := hash[i/2] >> 4
+		if i%2 == 1 {
+			nibble = hash[i/2] & 0x0f
+		}
+		if c >= 'a' && nibble >= 8 {
+			c -= 'a' - 'A'
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
@@ -0,0 +1,57 @@
+package secp256k1
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEthereumAddress(t *testing.T) {
+	secret := make([]byte, 32)
+	secret[31] = 1
+	kp, err := FromSecretKey(secret)
+	if err != nil {
+		t.Fatalf("FromSecretKey: %v", err)
+	}
+
+	address, err := kp.EthereumAddress()
+	if err != nil {
+		t.Fatalf("EthereumAddress: %v", err)
+	}
+	if want := "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf"; address != want {
+		t.Fatalf("got %s, want %s", address, want)
+	}
+}
+
+func TestSignRecoverable(t *testing.T) {
+	kp, err := Generate()
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	message := []byte("bridge message")
+
+	sig, err := kp.SignRecoverable(message)
+	if err != nil {
+		t.Fatalf("SignRecoverable: %v", err)
+	}
+	if len(sig) != RecoverableSignatureSize || sig[64] > 1 {
+		t.Fatalf("unexpected signature %x", sig)
+	}
+
+	recovered, err := RecoverPublicKey(message, sig)
+	if err != nil {
+		t.Fatalf("RecoverPublicKey: %v", err)
+	}
+	if !bytes.Equal(recovered, kp.PublicKey()) {
+		t.Fatal("recovered key does not match")
+	}
+
+	ethStyle := append([]byte(nil), sig...)
+	ethStyle[64] += 27
+	if recovered, err := RecoverPublicKey(message, ethStyle); err != nil || !bytes.Equal(recovered, kp.PublicKey()) {
+		t.Fatalf("recover with v=27/28: %v", err)
+	}
+
+	if recovered, err := RecoverPublicKey([]byte("other"), sig); err == nil && bytes.Equal(recovered, kp.PublicKey()) {
+		t.Fatal("signature recovered the signer for a different message")
+	}
+}
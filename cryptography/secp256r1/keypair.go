@@ -11,8 +11,10 @@ import (
 	"crypto/sha256"
 	"fmt"
 	"math/big"
+	"slices"
 
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/open-move/sui-go-sdk/cryptography/intent"
 	"github.com/open-move/sui-go-sdk/cryptography/personalmsg"
 	"github.com/open-move/sui-go-sdk/cryptography/transaction"
 	"github.com/open-move/sui-go-sdk/keychain"
@@ -123,6 +125,16 @@ func (k Keypair) SignTransaction(txBytes []byte) ([]byte, error) {
 	)
 }
 
+func (k Keypair) SignWithIntent(scope intent.IntentScope, payload []byte) ([]byte, error) {
+	return transaction.SignWithIntent(
+		keychain.SchemeSecp256r1,
+		scope,
+		payload,
+		k.PublicKey(),
+		k.signData,
+	)
+}
+
 func (k Keypair) VerifyPersonalMessage(message []byte, signature []byte) error {
 	return VerifyPersonalMessage(k.PublicKey(), message, signature)
 }
@@ -156,26 +168,59 @@ func FromSecretKey(secret []byte) (*Keypair, error) {
 // Mirrors Mysten's tooling by running BIP-32 derivation with secp256k1 group
 // operations before converting the resulting scalar into a P-256 keypair.
 func Derive(seed []byte, path keychain.DerivationPath) (*Keypair, error) {
-	if err := path.ValidateForScheme(keychain.SchemeSecp256r1); err != nil {
-		return nil, err
-	}
+	return DeriveFrom(MasterKey(seed), path)
+}
+
+// ExtendedKey is a private key and chain code partway down a BIP-32
+// derivation path, walked with secp256k1 group operations like Derive. Deriving many keys below a shared parent, such as every
+// address index of an account, only walks the segments after it.
+type ExtendedKey struct {
+	key      []byte
+	chain    []byte
+	segments []keychain.PathSegment
+}
 
+// MasterKey returns the root extended key of seed.
+func MasterKey(seed []byte) *ExtendedKey {
 	key, chain := keychain.BIP32MasterPrivateKey(seed)
-	secpOrder := secp256k1.S256().N
-	for _, segment := range path.Segments() {
+	return &ExtendedKey{key: key, chain: chain}
+}
+
+// Derive walks segments down from k and returns the extended key reached.
+func (k *ExtendedKey) Derive(segments ...keychain.PathSegment) (*ExtendedKey, error) {
+	key, chain := k.key, k.chain
+	for _, segment := range segments {
 		nextKey, nextChain, err := keychain.DeriveChildPrivateKey(key, chain, segment, func(s []byte) ([]byte, error) {
 			privKey := secp256k1.PrivKeyFromBytes(s)
 			if privKey == nil || privKey.Key.IsZeroBit() == 1 {
 				return nil, fmt.Errorf("secp256r1: invalid intermediate key")
 			}
 			return privKey.PubKey().SerializeCompressed(), nil
-		}, secpOrder)
+		}, secp256k1.S256().N)
 		if err != nil {
 			return nil, err
 		}
 		key = nextKey
 		chain = nextChain
 	}
+	return &ExtendedKey{key: key, chain: chain, segments: append(slices.Clip(k.segments), segments...)}, nil
+}
+
+// DeriveFrom derives the keypair at path from parent, which must have been
+// derived along the start of path.
+func DeriveFrom(parent *ExtendedKey, path keychain.DerivationPath) (*Keypair, error) {
+	if err := path.ValidateForScheme(keychain.SchemeSecp256r1); err != nil {
+		return nil, err
+	}
+	segments := path.Segments()
+	if len(parent.segments) > len(segments) || !slices.Equal(parent.segments, segments[:len(parent.segments)]) {
+		return nil, fmt.Errorf("secp256r1: path %s does not extend the parent key", path)
+	}
+	leaf, err := parent.Derive(segments[len(parent.segments):]...)
+	if err != nil {
+		return nil, err
+	}
+	key, chain := leaf.key, leaf.chain
 
 	priv, _, err := deriveECDSAKey(key)
 	if err != nil {
@@ -0,0 +1,65 @@
+package transaction
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/open-move/sui-go-sdk/keychain"
+	suitx "github.com/open-move/sui-go-sdk/transaction"
+)
+
+// SignatureLength is the length of a raw Ed25519, Secp256k1 or Secp256r1 signature.
+const SignatureLength = 64
+
+// ParsedSignature is a decoded `flag || signature || publicKey` signature.
+type ParsedSignature struct {
+	Scheme    keychain.Scheme
+	Signature []byte
+	PublicKey []byte
+}
+
+// SerializeSignature assembles a raw signature and public key into the
+// base64-encoded `flag || signature || publicKey` form Sui expects. Use it to
+// wrap signatures produced by external signers such as KMS or HSM devices,
+// which sign the digest returned by intent.HashIntentBytes.
+//
+// Secp256k1 and Secp256r1 signatures must be the 64-byte compact `r || s`
+// form with a low s value, over the SHA-256 hash of that digest, and the
+// public key must be compressed.
+func SerializeSignature(scheme keychain.Scheme, signature, publicKey []byte) (string, error) {
+	serialized, err := serializeSignature(scheme, signature, publicKey)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(serialized), nil
+}
+
+// ParseSerializedSignature decodes a base64 `flag || signature || publicKey`
+// signature as produced by SerializeSignature or a keypair's SignTransaction.
+func ParseSerializedSignature(serialized string) (*ParsedSignature, error) {
+	raw, err := base64.StdEncoding.DecodeString(serialized)
+	if err != nil {
+		return nil, fmt.Errorf("decode signature: %w", err)
+	}
+	scheme, signature, publicKey, err := suitx.ParseSerializedSignature(raw)
+	if err != nil {
+		return nil, err
+	}
+	return &ParsedSignature{Scheme: scheme, Signature: signature, PublicKey: publicKey}, nil
+}
+
+func serializeSignature(scheme keychain.Scheme, signature, publicKey []byte) ([]byte, error) {
+	if len(signature) != SignatureLength {
+		return nil, fmt.Errorf("%s: unexpected signature length %d", scheme.Label(), len(signature))
+	}
+
+	serialized := make([]byte, 0, 1+len(signature)+len(publicKey))
+	serialized = append(serialized, scheme.AddressFlag())
+	serialized = append(serialized, signature...)
+	serialized = append(serialized, publicKey...)
+	// Parsing checks the public key length for the scheme.
+	if _, _, _, err := suitx.ParseSerializedSignature(serialized); err != nil {
+		return nil, fmt.Errorf("%s: public key of %d bytes: %w", scheme.Label(), len(publicKey), err)
+	}
+	return serialized, nil
+}
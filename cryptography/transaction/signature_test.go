@@ -0,0 +1,67 @@
+package transaction_test
+
+import (
+	"bytes"
+	stded25519 "crypto/ed25519"
+	"encoding/base64"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/cryptography/ed25519"
+	"github.com/open-move/sui-go-sdk/cryptography/intent"
+	"github.com/open-move/sui-go-sdk/cryptography/transaction"
+	"github.com/open-move/sui-go-sdk/keychain"
+)
+
+func TestSerializeSignatureMatchesKeypair(t *testing.T) {
+	seed := bytes.Repeat([]byte{7}, stded25519.SeedSize)
+	kp, err := ed25519.FromSecretKey(seed)
+	if err != nil {
+		t.Fatalf("from secret key: %v", err)
+	}
+	txBytes := []byte("transaction data")
+
+	// Sign the intent digest the way an external signer would.
+	digest, err := intent.HashIntentBytes(intent.IntentScopeTransactionData, txBytes)
+	if err != nil {
+		t.Fatalf("hash intent: %v", err)
+	}
+	external := stded25519.Sign(stded25519.NewKeyFromSeed(seed), digest[:])
+
+	serialized, err := transaction.SerializeSignature(keychain.SchemeEd25519, external, kp.PublicKey())
+	if err != nil {
+		t.Fatalf("serialize: %v", err)
+	}
+	want, err := kp.SignTransaction(txBytes)
+	if err != nil {
+		t.Fatalf("sign transaction: %v", err)
+	}
+	if serialized != base64.StdEncoding.EncodeToString(want) {
+		t.Fatalf("serialized signature does not match keypair signature")
+	}
+
+	parsed, err := transaction.ParseSerializedSignature(serialized)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if parsed.Scheme != keychain.SchemeEd25519 || !bytes.Equal(parsed.Signature, external) || !bytes.Equal(parsed.PublicKey, kp.PublicKey()) {
+		t.Fatalf("unexpected parsed signature %+v", parsed)
+	}
+}
+
+func TestSerializeSignatureRejectsBadInput(t *testing.T) {
+	sig := make([]byte, transaction.SignatureLength)
+	if _, err := transaction.SerializeSignature(keychain.SchemeSecp256k1, sig, make([]byte, 32)); err == nil {
+		t.Fatal("expected error for uncompressed-length secp256k1 public key")
+	}
+	if _, err := transaction.SerializeSignature(keychain.SchemeEd25519, sig[:10], make([]byte, 32)); err == nil {
+		t.Fatal("expected error for short signature")
+	}
+
+	truncated := base64.StdEncoding.EncodeToString(append([]byte{0x00}, sig...))
+	if _, err := transaction.ParseSerializedSignature(truncated); err == nil {
+		t.Fatal("expected error for missing public key")
+	}
+	if _, err := transaction.ParseSerializedSignature("!!"); err == nil {
+		t.Fatal("expected error for invalid base64")
+	}
+}
@@ -16,7 +16,19 @@ func Sign(
 	publicKey []byte,
 	signFunc func([]byte) ([]byte, error),
 ) ([]byte, error) {
-	digest, err := intent.HashIntentBytes(intent.IntentScopeTransactionData, transactionBytes)
+	return SignWithIntent(scheme, intent.IntentScopeTransactionData, transactionBytes, publicKey, signFunc)
+}
+
+// SignWithIntent hashes a BCS-serialized payload under the given intent scope
+// and serializes the signature as `flag || signature || publicKey`.
+func SignWithIntent(
+	scheme keychain.Scheme,
+	scope intent.IntentScope,
+	payload []byte,
+	publicKey []byte,
+	signFunc func([]byte) ([]byte, error),
+) ([]byte, error) {
+	digest, err := intent.HashIntentBytes(scope, payload)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", scheme.Label(), err)
 	}
@@ -25,13 +37,5 @@ func Sign(
 	if err != nil {
 		return nil, err
 	}
-	if len(sig) != 64 {
-		return nil, fmt.Errorf("%s: unexpected signature length %d", scheme.Label(), len(sig))
-	}
-
-	serialized := make([]byte, 0, 1+len(sig)+len(publicKey))
-	serialized = append(serialized, scheme.AddressFlag())
-	serialized = append(serialized, sig...)
-	serialized = append(serialized, publicKey...)
-	return serialized, nil
+	return serializeSignature(scheme, sig, publicKey)
 }
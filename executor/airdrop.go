@@ -0,0 +1,234 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/open-move/sui-go-sdk/transaction"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+// Default per-transaction limits for Airdrop. They stay below the protocol
+// limits on inputs, commands and arguments per command, and keep a chunk of
+// object inputs well under the 128 KiB transaction size limit.
+const (
+	DefaultAirdropMaxInputs    = 500
+	DefaultAirdropMaxCommands  = 1024
+	DefaultAirdropMaxArguments = 511
+)
+
+// Transfer sends one object to a recipient.
+type Transfer struct {
+	ObjectID  string
+	Recipient string
+}
+
+// AirdropOptions configures Airdrop. Zero values use the defaults.
+type AirdropOptions struct {
+	// MaxInputs bounds the inputs (objects and recipient addresses) of one
+	// transaction.
+	MaxInputs int
+	// MaxCommands bounds the commands of one transaction.
+	MaxCommands int
+	// MaxArguments bounds the objects moved by one TransferObjects command.
+	MaxArguments int
+	// Concurrency bounds the transactions in flight. It defaults to the
+	// number of gas coins, which also bounds it from above.
+	Concurrency int
+	// OnChunk, if set, is called as each chunk completes, in completion
+	// order. It may be called from several goroutines at once.
+	OnChunk func(ChunkResult)
+}
+
+// ChunkResult is the outcome of one airdrop transaction.
+type ChunkResult struct {
+	// Index is the chunk's position in submission order.
+	Index     int
+	Transfers []Transfer
+	Effects   *Effects
+	Err       error
+}
+
+// AirdropReport lists every chunk in submission order.
+type AirdropReport struct {
+	Chunks []ChunkResult
+}
+
+// Failed returns the chunks that did not execute successfully.
+func (r *AirdropReport) Failed() []ChunkResult {
+	var failed []ChunkResult
+	for _, chunk := range r.Chunks {
+		if chunk.Err != nil {
+			failed = append(failed, chunk)
+		}
+	}
+	return failed
+}
+
+// FailedTransfers returns the transfers of failed chunks, ready to retry.
+func (r *AirdropReport) FailedTransfers() []Transfer {
+	var transfers []Transfer
+	for _, chunk := range r.Failed() {
+		transfers = append(transfers, chunk.Transfers...)
+	}
+	return transfers
+}
+
+// Err joins the errors of failed chunks, or returns nil.
+func (r *AirdropReport) Err() error {
+	var errs []error
+	for _, chunk := range r.Failed() {
+		errs = append(errs, fmt.Errorf("chunk %d: %w", chunk.Index, chunk.Err))
+	}
+	return errors.Join(errs...)
+}
+
+// Airdrop sends many objects, splitting the transfers into as few
+// transactions as the limits in opts allow and submitting them
+// concurrently. Transfers to the same recipient within a chunk share one
+// TransferObjects command. A failed chunk does not stop the others; the
+// report records each chunk's effects or error. The returned error is only
+// set for invalid input.
+func (e *Executor) Airdrop(ctx context.Context, transfers []Transfer, opts AirdropOptions) (*AirdropReport, error) {
+	if ctx == nil {
+		return nil, errors.New("nil context")
+	}
+	maxArguments := defaultLimit(opts.MaxArguments, DefaultAirdropMaxArguments)
+	chunks, err := chunkTransfers(transfers, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 || concurrency > cap(e.coins) {
+		concurrency = cap(e.coins)
+	}
+	report := &AirdropReport{Chunks: make([]ChunkResult, len(chunks))}
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, chunk := range chunks {
+		result := ChunkResult{Index: i, Transfers: chunk}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			result.Err = ctx.Err()
+			report.Chunks[i] = result
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			result.Effects, result.Err = e.Submit(ctx, buildTransfers(chunk, maxArguments))
+			report.Chunks[i] = result
+			if opts.OnChunk != nil {
+				opts.OnChunk(result)
+			}
+		}()
+	}
+	wg.Wait()
+	return report, nil
+}
+
+// chunkTransfers groups transfers into chunks that fit the limits in opts,
+// keeping the input order.
+func chunkTransfers(transfers []Transfer, opts AirdropOptions) ([][]Transfer, error) {
+	maxInputs := defaultLimit(opts.MaxInputs, DefaultAirdropMaxInputs)
+	maxCommands := defaultLimit(opts.MaxCommands, DefaultAirdropMaxCommands)
+	maxArguments := defaultLimit(opts.MaxArguments, DefaultAirdropMaxArguments)
+	if maxInputs < 2 {
+		return nil, errors.New("airdrop: max inputs must allow an object and a recipient")
+	}
+
+	seen := make(map[string]bool, len(transfers))
+	for i, t := range transfers {
+		id, err := utils.NormalizeAddress(t.ObjectID)
+		if err != nil {
+			return nil, fmt.Errorf("airdrop: transfer %d: object id: %w", i, err)
+		}
+		if _, err := utils.NormalizeAddress(t.Recipient); err != nil {
+			return nil, fmt.Errorf("airdrop: transfer %d: recipient: %w", i, err)
+		}
+		if seen[id] {
+			return nil, fmt.Errorf("airdrop: object %s is transferred more than once", t.ObjectID)
+		}
+		seen[id] = true
+	}
+
+	var (
+		chunks  [][]Transfer
+		current []Transfer
+		// group counts the objects in each recipient's open command.
+		group    map[string]int
+		inputs   int
+		commands int
+	)
+	reset := func() {
+		current, group, inputs, commands = nil, make(map[string]int), 0, 0
+	}
+	reset()
+	for _, t := range transfers {
+		recipient, _ := utils.NormalizeAddress(t.Recipient)
+		n, known := group[recipient]
+		needInputs, needCommands := 1, 0
+		if !known {
+			needInputs, needCommands = 2, 1
+		} else if n >= maxArguments {
+			needCommands = 1
+		}
+		if len(current) > 0 && (inputs+needInputs > maxInputs || commands+needCommands > maxCommands) {
+			chunks = append(chunks, current)
+			reset()
+			n, known = 0, false
+			needInputs, needCommands = 2, 1
+		}
+		if known && n >= maxArguments {
+			n = 0
+		}
+		group[recipient] = n + 1
+		inputs += needInputs
+		commands += needCommands
+		current = append(current, t)
+	}
+	if len(current) > 0 {
+		chunks = append(chunks, current)
+	}
+	return chunks, nil
+}
+
+func defaultLimit(value, fallback int) int {
+	if value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+// buildTransfers adds one TransferObjects command per recipient, in order
+// of first appearance, splitting a recipient's objects across commands of at
+// most maxArguments objects.
+func buildTransfers(chunk []Transfer, maxArguments int) BuildFunc {
+	return func(tx *transaction.Transaction) error {
+		var order []string
+		byRecipient := make(map[string][]transaction.Argument)
+		for _, t := range chunk {
+			recipient, _ := utils.NormalizeAddress(t.Recipient)
+			if _, ok := byRecipient[recipient]; !ok {
+				order = append(order, recipient)
+			}
+			byRecipient[recipient] = append(byRecipient[recipient], tx.Object(t.ObjectID))
+		}
+		for _, recipient := range order {
+			address := tx.PureAddress(recipient)
+			objects := byRecipient[recipient]
+			for len(objects) > 0 {
+				n := min(len(objects), maxArguments)
+				tx.TransferObjects(transaction.TransferObjects{Objects: objects[:n], Address: address})
+				objects = objects[n:]
+			}
+		}
+		return tx.Err()
+	}
+}
@@ -0,0 +1,108 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/transaction"
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+func TestChunkTransfers(t *testing.T) {
+	var transfers []Transfer
+	for i := range 7 {
+		transfers = append(transfers, Transfer{ObjectID: fmt.Sprintf("0x%x", 0x100+i), Recipient: fmt.Sprintf("0x%x", 1+i%2)})
+	}
+
+	// Each chunk holds at most 4 inputs: two recipients and two objects,
+	// or one recipient and three objects.
+	chunks, err := chunkTransfers(transfers, AirdropOptions{MaxInputs: 4})
+	if err != nil {
+		t.Fatalf("chunk: %v", err)
+	}
+	var sizes []int
+	for _, chunk := range chunks {
+		sizes = append(sizes, len(chunk))
+	}
+	if fmt.Sprint(sizes) != "[2 2 2 1]" {
+		t.Fatalf("chunk sizes %v", sizes)
+	}
+
+	// A single recipient with two objects per command needs a second
+	// command for the third object.
+	same := []Transfer{{"0x101", "0x1"}, {"0x102", "0x1"}, {"0x103", "0x1"}}
+	chunks, err = chunkTransfers(same, AirdropOptions{MaxCommands: 1, MaxArguments: 2})
+	if err != nil || len(chunks) != 2 || len(chunks[0]) != 2 {
+		t.Fatalf("unexpected chunks %v %v", chunks, err)
+	}
+
+	if _, err := chunkTransfers([]Transfer{{"0x101", "0x1"}, {"0x0101", "0x2"}}, AirdropOptions{}); err == nil {
+		t.Fatal("expected duplicate object to be rejected")
+	}
+	if _, err := chunkTransfers([]Transfer{{"0x101", "not an address"}}, AirdropOptions{}); err == nil {
+		t.Fatal("expected invalid recipient to be rejected")
+	}
+}
+
+// fixedGasPrice resolves only the gas price; the executor supplies the
+// budget and payment.
+type fixedGasPrice uint64
+
+func (p fixedGasPrice) ResolveGasPrice(context.Context) (uint64, error) { return uint64(p), nil }
+
+func (fixedGasPrice) ResolveGasBudget(context.Context, transaction.GasBudgetInput) (uint64, error) {
+	return 0, errors.New("not supported")
+}
+
+func (fixedGasPrice) ResolveGasPayment(context.Context, types.Address, uint64) ([]types.ObjectRef, error) {
+	return nil, errors.New("not supported")
+}
+
+func TestAirdrop(t *testing.T) {
+	gas1, gas2 := utils.MustParseAddress("0xa1"), utils.MustParseAddress("0xa2")
+	ids := []types.ObjectID{gas1, gas2}
+	var transfers []Transfer
+	for i := range 9 {
+		id := utils.MustParseAddress(fmt.Sprintf("0x%x", 0x100+i))
+		ids = append(ids, id)
+		transfers = append(transfers, Transfer{ObjectID: id.String(), Recipient: fmt.Sprintf("0x%x", 1+i%3)})
+	}
+	l := newLedger(ids...)
+
+	exec, err := New(l, l, stubSigner{}, []types.ObjectRef{coinRef(gas1), coinRef(gas2)}, WithGasBudget(5_000_000), WithGasResolver(fixedGasPrice(1000)))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	var completed atomic.Int32
+	report, err := exec.Airdrop(context.Background(), transfers, AirdropOptions{
+		MaxInputs: 6,
+		OnChunk:   func(ChunkResult) { completed.Add(1) },
+	})
+	if err != nil {
+		t.Fatalf("airdrop: %v", err)
+	}
+	if err := report.Err(); err != nil {
+		t.Fatalf("chunk failed: %v", err)
+	}
+	if len(report.Chunks) != 3 || int(completed.Load()) != 3 || l.executed != 3 {
+		t.Fatalf("chunks %d, completed %d, executed %d", len(report.Chunks), completed.Load(), l.executed)
+	}
+	for i, chunk := range report.Chunks {
+		if chunk.Index != i || len(chunk.Transfers) != 3 || chunk.Effects == nil || !chunk.Effects.Success {
+			t.Fatalf("unexpected chunk %d: %+v", i, chunk)
+		}
+	}
+	for _, id := range ids[2:] {
+		if l.versions[id] == 1 {
+			t.Fatalf("object %s was not transferred", id)
+		}
+	}
+	if len(report.FailedTransfers()) != 0 {
+		t.Fatalf("unexpected failed transfers %v", report.FailedTransfers())
+	}
+}
@@ -0,0 +1,123 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/open-move/sui-go-sdk/graphql"
+	"github.com/open-move/sui-go-sdk/grpc"
+	v2 "github.com/open-move/sui-go-sdk/proto/sui/rpc/v2"
+	"github.com/open-move/sui-go-sdk/transaction"
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+var (
+	_ Backend = (*GRPCBackend)(nil)
+	_ Backend = (*GraphQLBackend)(nil)
+)
+
+// GRPCBackend executes transactions through a fullnode's gRPC API.
+type GRPCBackend struct {
+	client *grpc.Client
+}
+
+// NewGRPCBackend returns a backend backed by client.
+func NewGRPCBackend(client *grpc.Client) *GRPCBackend {
+	return &GRPCBackend{client: client}
+}
+
+// ExecuteTransaction implements Backend.
+func (b *GRPCBackend) ExecuteTransaction(ctx context.Context, txBytes, signature []byte) (*Effects, error) {
+	if b == nil || b.client == nil {
+		return nil, errors.New("nil client")
+	}
+
+	userSig, err := transaction.UserSignatureFromSerialized(signature)
+	if err != nil {
+		return nil, err
+	}
+	executed, err := b.client.ExecuteSignedTransaction(ctx, &grpc.ExecuteRequest{
+		Transaction: &v2.Transaction{Bcs: &v2.Bcs{Name: utils.Ptr("TransactionData"), Value: txBytes}},
+		Signatures:  []*v2.UserSignature{userSig},
+		ReadMask:    grpc.NewFieldMask(grpc.TransactionDigest, grpc.EffectsStatus, grpc.EffectsChangedObjects).Build(),
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	effects := &Effects{Digest: executed.GetDigest()}
+	status := executed.GetEffects().GetStatus()
+	effects.Success = status.GetSuccess()
+	if !effects.Success {
+		effects.Error = status.GetError().GetDescription()
+	}
+	for _, changed := range executed.GetEffects().GetChangedObjects() {
+		id, err := utils.ParseAddress(changed.GetObjectId())
+		if err != nil {
+			return nil, fmt.Errorf("changed object id: %w", err)
+		}
+		change := ObjectChange{ObjectID: id}
+		if changed.GetOutputState() == v2.ChangedObject_OUTPUT_OBJECT_STATE_OBJECT_WRITE {
+			ref, err := utils.ParseObjectRef(changed.GetObjectId(), changed.GetOutputVersion(), changed.GetOutputDigest())
+			if err != nil {
+				return nil, err
+			}
+			change.Ref = &ref
+		}
+		effects.Changed = append(effects.Changed, change)
+	}
+	return effects, nil
+}
+
+// GraphQLBackend executes transactions through the Sui GraphQL API.
+type GraphQLBackend struct {
+	client *graphql.Client
+}
+
+// NewGraphQLBackend returns a backend backed by client.
+func NewGraphQLBackend(client *graphql.Client) *GraphQLBackend {
+	return &GraphQLBackend{client: client}
+}
+
+// ExecuteTransaction implements Backend.
+func (b *GraphQLBackend) ExecuteTransaction(ctx context.Context, txBytes, signature []byte) (*Effects, error) {
+	if b == nil || b.client == nil {
+		return nil, errors.New("nil client")
+	}
+
+	result, err := graphql.ExecuteTransactionWithOptions(b.client, ctx, txBytes, [][]byte{signature}, &graphql.ExecuteOptions{
+		WaitForEffects:    true,
+		ShowObjectChanges: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result == nil || result.Effects == nil {
+		if result != nil && len(result.Errors) > 0 {
+			return nil, errors.New(strings.Join(result.Errors, "; "))
+		}
+		return nil, errors.New("execute transaction response missing effects")
+	}
+
+	fx := result.Effects
+	effects := &Effects{
+		Digest:  fx.Digest.String(),
+		Success: fx.Status == graphql.ExecutionStatusSuccess,
+	}
+	if fx.ExecutionError != nil {
+		effects.Error = fx.ExecutionError.Message
+	}
+	if fx.ObjectChanges != nil {
+		for _, node := range fx.ObjectChanges.Nodes {
+			change := ObjectChange{ObjectID: node.Address}
+			if out := node.OutputState; out != nil {
+				change.Ref = &types.ObjectRef{ObjectID: out.Address, Version: uint64(out.Version), Digest: out.Digest}
+			}
+			effects.Changed = append(effects.Changed, change)
+		}
+	}
+	return effects, nil
+}
@@ -0,0 +1,415 @@
+// Package executor submits many transactions from one address without
+// equivocating on owned objects. An Executor rotates among a pool of gas
+// coins so each coin is used by one transaction at a time, tracks the object
+// versions produced by its own transactions so later builds never reuse a
+// consumed version, serializes transactions that touch the same owned object,
+// and rebuilds with fresh object references when the network reports a
+// version conflict.
+package executor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/open-move/sui-go-sdk/transaction"
+	"github.com/open-move/sui-go-sdk/types"
+)
+
+// DefaultMaxRetries is how many times a transaction is rebuilt after a
+// version conflict before Submit gives up.
+const DefaultMaxRetries = 3
+
+var (
+	// ErrVersionConflict reports that an input object version was already
+	// consumed or locked by another transaction.
+	ErrVersionConflict = errors.New("object version conflict")
+	// ErrExecutionFailed reports a transaction that executed but aborted.
+	ErrExecutionFailed = errors.New("transaction execution failed")
+)
+
+// Backend submits signed transactions.
+type Backend interface {
+	// ExecuteTransaction submits BCS transaction data with a serialized
+	// signature and waits for its effects.
+	ExecuteTransaction(ctx context.Context, txBytes, signature []byte) (*Effects, error)
+}
+
+// Effects is the part of a transaction's effects the executor needs.
+type Effects struct {
+	Digest  string
+	Success bool
+	// Error describes the abort when Success is false.
+	Error   string
+	Changed []ObjectChange
+}
+
+// ObjectChange is an object written, created or removed by a transaction.
+type ObjectChange struct {
+	ObjectID types.ObjectID
+	// Ref is the object's new reference, or nil if it was deleted or wrapped.
+	Ref *types.ObjectRef
+}
+
+// BuildFunc adds commands to a fresh transaction. It may be called several
+// times for one Submit when the transaction has to be rebuilt, so it must not
+// have side effects beyond tx.
+type BuildFunc func(tx *transaction.Transaction) error
+
+// Option configures an Executor.
+type Option func(*Executor)
+
+// WithMaxRetries sets how many times a conflicting transaction is rebuilt.
+func WithMaxRetries(retries int) Option {
+	return func(e *Executor) {
+		if retries >= 0 {
+			e.maxRetries = retries
+		}
+	}
+}
+
+// WithGasBudget fixes the gas budget of every transaction instead of
+// resolving it through the gas resolver.
+func WithGasBudget(budget uint64) Option {
+	return func(e *Executor) {
+		e.gasBudget = budget
+	}
+}
+
+// WithGasResolver sets the resolver used for gas prices and budgets. Gas
+// payment always comes from the executor's coin pool.
+func WithGasResolver(resolver transaction.GasResolver) Option {
+	return func(e *Executor) {
+		e.gasResolver = resolver
+	}
+}
+
+type gasCoin struct {
+	ref types.ObjectRef
+}
+
+// Executor submits transactions for a single sender.
+type Executor struct {
+	backend     Backend
+	resolver    transaction.Resolver
+	gasResolver transaction.GasResolver
+	signer      transaction.TransactionSigner
+	sender      string
+	gasBudget   uint64
+	maxRetries  int
+
+	coins chan *gasCoin
+
+	mu       sync.Mutex
+	versions map[types.ObjectID]types.ObjectRef
+	locks    map[types.ObjectID]chan struct{}
+}
+
+// New returns an Executor that signs with signer and pays gas from gasCoins,
+// which must be owned by the signer and not used elsewhere while the
+// executor is running. resolver resolves object inputs and refreshes
+// references after conflicts.
+func New(backend Backend, resolver transaction.Resolver, signer transaction.TransactionSigner, gasCoins []types.ObjectRef, opts ...Option) (*Executor, error) {
+	if backend == nil {
+		return nil, errors.New("nil backend")
+	}
+	if resolver == nil {
+		return nil, transaction.ErrResolverRequired
+	}
+	if signer == nil {
+		return nil, errors.New("nil signer")
+	}
+	if len(gasCoins) == 0 {
+		return nil, transaction.ErrNoCoins
+	}
+	sender, err := signer.SuiAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	e := &Executor{
+		backend:    backend,
+		resolver:   resolver,
+		signer:     signer,
+		sender:     sender,
+		maxRetries: DefaultMaxRetries,
+		coins:      make(chan *gasCoin, len(gasCoins)),
+		versions:   make(map[types.ObjectID]types.ObjectRef),
+		locks:      make(map[types.ObjectID]chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	if gr, ok := resolver.(transaction.GasResolver); ok && e.gasResolver == nil {
+		e.gasResolver = gr
+	}
+	for _, ref := range gasCoins {
+		e.coins <- &gasCoin{ref: ref}
+	}
+	return e, nil
+}
+
+// Submit builds, signs and executes a transaction. It waits for a free gas
+// coin, and retries with fresh object references when the network reports a
+// version conflict. A transaction that executes but aborts returns its
+// effects together with an error wrapping ErrExecutionFailed.
+func (e *Executor) Submit(ctx context.Context, build BuildFunc) (*Effects, error) {
+	if ctx == nil {
+		return nil, errors.New("nil context")
+	}
+	if build == nil {
+		return nil, errors.New("nil build function")
+	}
+
+	for attempt := 0; ; attempt++ {
+		effects, err := e.submit(ctx, build)
+		if err == nil || !IsVersionConflict(err) || attempt >= e.maxRetries {
+			return effects, err
+		}
+	}
+}
+
+func (e *Executor) submit(ctx context.Context, build BuildFunc) (*Effects, error) {
+	var coin *gasCoin
+	select {
+	case coin = <-e.coins:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	defer func() { e.coins <- coin }()
+
+	var (
+		result transaction.BuildResult
+		owned  []types.ObjectRef
+		unlock func()
+	)
+	for rebuilds := 0; ; rebuilds++ {
+		var err error
+		result, owned, err = e.build(ctx, coin, build)
+		if err != nil {
+			return nil, err
+		}
+		unlock, err = e.lockObjects(ctx, owned)
+		if err != nil {
+			return nil, err
+		}
+		// Another transaction may have consumed an input while we waited
+		// for its lock; rebuild against the versions it produced.
+		if !e.stale(owned) {
+			break
+		}
+		unlock()
+		if rebuilds >= e.maxRetries {
+			return nil, fmt.Errorf("%w: input references are older than versions produced by this executor", ErrVersionConflict)
+		}
+	}
+	defer unlock()
+
+	signature, err := e.signer.SignTransaction(result.TransactionBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	effects, err := e.backend.ExecuteTransaction(ctx, result.TransactionBytes, signature)
+	if err != nil {
+		if IsVersionConflict(err) {
+			e.forget(owned)
+			if refreshErr := e.refreshGas(ctx, coin); refreshErr != nil {
+				return nil, errors.Join(err, refreshErr)
+			}
+			if !errors.Is(err, ErrVersionConflict) {
+				err = fmt.Errorf("%w: %w", ErrVersionConflict, err)
+			}
+		}
+		return nil, err
+	}
+
+	e.apply(coin, owned, effects)
+	if !effects.Success {
+		return effects, fmt.Errorf("%w: %s", ErrExecutionFailed, effects.Error)
+	}
+	return effects, nil
+}
+
+func (e *Executor) build(ctx context.Context, coin *gasCoin, build BuildFunc) (transaction.BuildResult, []types.ObjectRef, error) {
+	tx := transaction.New()
+	if err := build(tx); err != nil {
+		return transaction.BuildResult{}, nil, err
+	}
+	tx.SetSender(e.sender)
+	tx.SetGasPayment([]types.ObjectRef{coin.ref})
+	if e.gasBudget > 0 {
+		tx.SetGasBudget(e.gasBudget)
+	}
+
+	result, err := tx.Build(ctx, transaction.BuildOptions{
+		Resolver:    &cachedResolver{Resolver: e.resolver, executor: e},
+		GasResolver: e.gasResolver,
+	})
+	if err != nil {
+		return transaction.BuildResult{}, nil, err
+	}
+
+	var owned []types.ObjectRef
+	for _, arg := range result.ResolvedInputArgs {
+		if arg.Object != nil && arg.Object.ImmOrOwnedObject != nil {
+			owned = append(owned, *arg.Object.ImmOrOwnedObject)
+		}
+	}
+	return result, owned, nil
+}
+
+// lockObjects acquires the per-object locks of refs in a fixed order so that
+// transactions sharing inputs cannot deadlock.
+func (e *Executor) lockObjects(ctx context.Context, refs []types.ObjectRef) (func(), error) {
+	ids := make([]types.ObjectID, 0, len(refs))
+	seen := make(map[types.ObjectID]bool, len(refs))
+	for _, ref := range refs {
+		if !seen[ref.ObjectID] {
+			seen[ref.ObjectID] = true
+			ids = append(ids, ref.ObjectID)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return bytes.Compare(ids[i][:], ids[j][:]) < 0 })
+
+	var held []chan struct{}
+	unlock := func() {
+		for _, lock := range held {
+			<-lock
+		}
+	}
+	for _, id := range ids {
+		e.mu.Lock()
+		lock, ok := e.locks[id]
+		if !ok {
+			lock = make(chan struct{}, 1)
+			e.locks[id] = lock
+		}
+		e.mu.Unlock()
+
+		select {
+		case lock <- struct{}{}:
+			held = append(held, lock)
+		case <-ctx.Done():
+			unlock()
+			return nil, ctx.Err()
+		}
+	}
+	return unlock, nil
+}
+
+func (e *Executor) stale(refs []types.ObjectRef) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, ref := range refs {
+		if known, ok := e.versions[ref.ObjectID]; ok && known.Version > ref.Version {
+			return true
+		}
+	}
+	return false
+}
+
+// apply records the versions produced by a transaction.
+func (e *Executor) apply(coin *gasCoin, owned []types.ObjectRef, effects *Effects) {
+	tracked := make(map[types.ObjectID]bool, len(owned))
+	for _, ref := range owned {
+		tracked[ref.ObjectID] = true
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, change := range effects.Changed {
+		if change.ObjectID == coin.ref.ObjectID && change.Ref != nil {
+			coin.ref = *change.Ref
+			continue
+		}
+		if !tracked[change.ObjectID] {
+			continue
+		}
+		if change.Ref == nil {
+			delete(e.versions, change.ObjectID)
+			continue
+		}
+		e.versions[change.ObjectID] = *change.Ref
+	}
+}
+
+func (e *Executor) forget(refs []types.ObjectRef) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, ref := range refs {
+		delete(e.versions, ref.ObjectID)
+	}
+}
+
+func (e *Executor) refreshGas(ctx context.Context, coin *gasCoin) error {
+	metas, err := e.resolver.ResolveObjects(ctx, []string{coin.ref.ObjectID.String()})
+	if err != nil {
+		return fmt.Errorf("refresh gas coin: %w", err)
+	}
+	if len(metas) != 1 {
+		return fmt.Errorf("refresh gas coin: resolver returned %d objects", len(metas))
+	}
+	coin.ref = types.ObjectRef{ObjectID: coin.ref.ObjectID, Version: metas[0].Version, Digest: metas[0].Digest}
+	return nil
+}
+
+// GasCoins returns the current references of the executor's idle gas coins.
+// Coins in use by a pending transaction are not included.
+func (e *Executor) GasCoins() []types.ObjectRef {
+	var idle []*gasCoin
+	defer func() {
+		for _, coin := range idle {
+			e.coins <- coin
+		}
+	}()
+
+	for {
+		select {
+		case coin := <-e.coins:
+			idle = append(idle, coin)
+		default:
+			refs := make([]types.ObjectRef, len(idle))
+			for i, coin := range idle {
+				refs[i] = coin.ref
+			}
+			return refs
+		}
+	}
+}
+
+// cachedResolver overlays the versions produced by the executor's own
+// transactions on the resolver's view, which may lag behind execution.
+type cachedResolver struct {
+	transaction.Resolver
+	executor *Executor
+}
+
+func (r *cachedResolver) ResolveObjects(ctx context.Context, objectIDs []string) ([]transaction.ObjectMetadata, error) {
+	metas, err := r.Resolver.ResolveObjects(ctx, objectIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	r.executor.mu.Lock()
+	defer r.executor.mu.Unlock()
+	for i := range metas {
+		if metas[i].OwnerKind != transaction.OwnerAddress {
+			continue
+		}
+		if known, ok := r.executor.versions[metas[i].ID]; ok && known.Version > metas[i].Version {
+			metas[i].Version = known.Version
+			metas[i].Digest = known.Digest
+		}
+	}
+	return metas, nil
+}
+
+// IsVersionConflict reports whether err means an input object reference was
+// out of date, so rebuilding the transaction may succeed.
+func IsVersionConflict(err error) bool {
+	return errors.Is(err, ErrVersionConflict) || transaction.IsStaleObjectError(err)
+}
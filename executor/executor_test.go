@@ -0,0 +1,199 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/transaction"
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+const testSender = "0x00000000000000000000000000000000000000000000000000000000000000aa"
+
+type stubSigner struct{}
+
+func (stubSigner) SignTransaction([]byte) ([]byte, error) { return []byte("sig"), nil }
+func (stubSigner) SuiAddress() (string, error)            { return testSender, nil }
+
+// ledger is a fake network that enforces owned object versions.
+type ledger struct {
+	mu       sync.Mutex
+	versions map[types.ObjectID]uint64
+	lamport  uint64
+	executed int
+	// stale makes the resolver report the initial versions, like a
+	// fullnode that has not caught up with execution.
+	stale   bool
+	initial map[types.ObjectID]uint64
+}
+
+func newLedger(ids ...types.ObjectID) *ledger {
+	l := &ledger{versions: make(map[types.ObjectID]uint64), initial: make(map[types.ObjectID]uint64), lamport: 10}
+	for _, id := range ids {
+		l.versions[id] = 1
+		l.initial[id] = 1
+	}
+	return l
+}
+
+func digestFor(version uint64) types.Digest {
+	d := make(types.Digest, 32)
+	d[0] = byte(version)
+	return d
+}
+
+func (l *ledger) ResolveObjects(_ context.Context, ids []string) ([]transaction.ObjectMetadata, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	out := make([]transaction.ObjectMetadata, len(ids))
+	for i, raw := range ids {
+		id := utils.MustParseAddress(raw)
+		version := l.versions[id]
+		if l.stale {
+			version = l.initial[id]
+		}
+		out[i] = transaction.ObjectMetadata{ID: id, Version: version, Digest: digestFor(version), OwnerKind: transaction.OwnerAddress}
+	}
+	return out, nil
+}
+
+func (l *ledger) ResolveMoveFunction(context.Context, string, string, string) (*transaction.MoveFunction, error) {
+	return nil, errors.New("not supported")
+}
+
+func (l *ledger) ExecuteTransaction(_ context.Context, txBytes, _ []byte) (*Effects, error) {
+	data, err := transaction.DecodeTransactionData(txBytes)
+	if err != nil {
+		return nil, err
+	}
+	v1 := data.V1
+	refs := append([]types.ObjectRef(nil), v1.GasData.Payment...)
+	for _, arg := range v1.Kind.ProgrammableTransaction.Inputs {
+		if arg.Object != nil && arg.Object.ImmOrOwnedObject != nil {
+			refs = append(refs, *arg.Object.ImmOrOwnedObject)
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, ref := range refs {
+		if l.versions[ref.ObjectID] != ref.Version {
+			return nil, fmt.Errorf("Transaction needs to be rebuilt because object %s version 0x%x is unavailable for consumption (ObjectVersionUnavailableForConsumption)", ref.ObjectID, ref.Version)
+		}
+	}
+
+	l.lamport++
+	l.executed++
+	effects := &Effects{Digest: fmt.Sprintf("tx-%d", l.executed), Success: true}
+	for _, ref := range refs {
+		l.versions[ref.ObjectID] = l.lamport
+		out := types.ObjectRef{ObjectID: ref.ObjectID, Version: l.lamport, Digest: digestFor(l.lamport)}
+		effects.Changed = append(effects.Changed, ObjectChange{ObjectID: ref.ObjectID, Ref: &out})
+	}
+	return effects, nil
+}
+
+func coinRef(id types.ObjectID) types.ObjectRef {
+	return types.ObjectRef{ObjectID: id, Version: 1, Digest: digestFor(1)}
+}
+
+func transferTo(object types.ObjectID) BuildFunc {
+	return func(tx *transaction.Transaction) error {
+		tx.SetGasPrice(1000)
+		tx.TransferObjects(transaction.TransferObjects{
+			Objects: []transaction.Argument{tx.Object(object.String())},
+			Address: tx.PureAddress("0x2"),
+		})
+		return tx.Err()
+	}
+}
+
+func TestExecutorConcurrentSubmissionsShareObject(t *testing.T) {
+	gas1, gas2 := utils.MustParseAddress("0xa1"), utils.MustParseAddress("0xa2")
+	nft := utils.MustParseAddress("0xb1")
+	l := newLedger(gas1, gas2, nft)
+	l.stale = true
+
+	exec, err := New(l, l, stubSigner{}, []types.ObjectRef{coinRef(gas1), coinRef(gas2)}, WithGasBudget(5_000_000))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for range 6 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := exec.Submit(context.Background(), transferTo(nft)); err != nil {
+				t.Errorf("Submit: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if l.executed != 6 {
+		t.Fatalf("executed %d transactions, want 6", l.executed)
+	}
+	coins := exec.GasCoins()
+	if len(coins) != 2 {
+		t.Fatalf("idle gas coins = %d, want 2", len(coins))
+	}
+	for _, coin := range coins {
+		if coin.Version != l.versions[coin.ObjectID] {
+			t.Errorf("gas coin %s at version %d, ledger has %d", coin.ObjectID, coin.Version, l.versions[coin.ObjectID])
+		}
+	}
+}
+
+func TestExecutorRetriesAfterExternalConflict(t *testing.T) {
+	gas, obj := utils.MustParseAddress("0xa1"), utils.MustParseAddress("0xb1")
+	l := newLedger(gas, obj)
+
+	exec, err := New(l, l, stubSigner{}, []types.ObjectRef{coinRef(gas)}, WithGasBudget(5_000_000))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	// Someone else spends the gas coin, so the pooled reference is stale.
+	l.versions[gas] = 7
+
+	effects, err := exec.Submit(context.Background(), transferTo(obj))
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if effects.Digest != "tx-1" {
+		t.Fatalf("digest = %q", effects.Digest)
+	}
+	if got := exec.GasCoins()[0].Version; got != l.versions[gas] {
+		t.Fatalf("gas coin version %d, want %d", got, l.versions[gas])
+	}
+}
+
+func TestExecutorGivesUpAfterMaxRetries(t *testing.T) {
+	gas, obj := utils.MustParseAddress("0xa1"), utils.MustParseAddress("0xb1")
+	l := newLedger(gas, obj)
+	l.stale = true
+	l.versions[obj] = 9 // the resolver never sees this version
+
+	exec, err := New(l, l, stubSigner{}, []types.ObjectRef{coinRef(gas)}, WithGasBudget(5_000_000), WithMaxRetries(2))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	_, err = exec.Submit(context.Background(), transferTo(obj))
+	if !errors.Is(err, ErrVersionConflict) || !IsVersionConflict(err) {
+		t.Fatalf("expected version conflict, got %v", err)
+	}
+}
+
+func TestIsVersionConflict(t *testing.T) {
+	if IsVersionConflict(nil) || IsVersionConflict(errors.New("insufficient gas")) {
+		t.Fatal("unexpected conflict")
+	}
+	if !IsVersionConflict(errors.New("Object (0x1, SequenceNumber(4)) already locked by a different transaction")) {
+		t.Fatal("lock conflict not detected")
+	}
+}
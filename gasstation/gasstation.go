@@ -0,0 +1,135 @@
+// Package gasstation executes transactions whose gas is paid by a
+// sponsorship service, a gas station, so users need no SUI of their own.
+//
+// A sponsored flow reserves gas coins owned by the sponsor, builds the
+// transaction with the sponsor as gas owner, has the user sign it, and hands
+// the user's signature to the station, which adds its own and executes.
+// SignAndExecute runs the whole flow in one call against any SponsorClient;
+// HTTPClient speaks the API of the open-source Sui gas pool server.
+package gasstation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/open-move/sui-go-sdk/transaction"
+	"github.com/open-move/sui-go-sdk/types"
+)
+
+const (
+	// DefaultGasBudget is the budget reserved when Options.Budget is zero.
+	DefaultGasBudget = 50_000_000
+	// DefaultReserveDuration is how long reserved coins are held when
+	// Options.ReserveDuration is zero. The transaction must be executed
+	// before the reservation expires.
+	DefaultReserveDuration = time.Minute
+)
+
+// ErrNoGasCoins is returned when a reservation holds no gas coins.
+var ErrNoGasCoins = errors.New("gasstation: reservation has no gas coins")
+
+// Reservation is a set of sponsor-owned gas coins held for one transaction.
+type Reservation struct {
+	ID             uint64
+	SponsorAddress types.Address
+	GasCoins       []types.ObjectRef
+	Budget         uint64
+	ExpiresAt      time.Time
+}
+
+// ExecutionResult is the outcome of a sponsored transaction.
+type ExecutionResult struct {
+	Digest string
+	// Success reports whether the transaction executed successfully; Error
+	// holds the execution error otherwise.
+	Success bool
+	Error   string
+	// Effects are the transaction effects as the station returned them.
+	Effects []byte
+}
+
+// SponsorClient is a gas sponsorship service.
+type SponsorClient interface {
+	// ReserveGas reserves sponsor-owned coins covering budget MIST for the
+	// given duration.
+	ReserveGas(ctx context.Context, budget uint64, duration time.Duration) (*Reservation, error)
+	// ExecuteSponsored co-signs txBytes, built against reservation, and
+	// executes it with the user's signature.
+	ExecuteSponsored(ctx context.Context, reservation *Reservation, txBytes []byte, userSignature []byte) (*ExecutionResult, error)
+}
+
+// Options configure SignAndExecute.
+type Options struct {
+	// Budget is the gas budget to reserve and set on the transaction.
+	Budget uint64
+	// ReserveDuration is how long the station holds the reserved coins.
+	ReserveDuration time.Duration
+	// BuildOptions resolve the transaction's inputs and, through
+	// GasResolver, its gas price. The gas owner, payment and budget come
+	// from the reservation.
+	BuildOptions transaction.BuildOptions
+}
+
+// Apply sets the reservation's sponsor, gas coins and budget as the gas
+// configuration of tx.
+func (r *Reservation) Apply(tx *transaction.Transaction) error {
+	if len(r.GasCoins) == 0 {
+		return ErrNoGasCoins
+	}
+	tx.SetGasOwner(r.SponsorAddress.String())
+	tx.SetGasPayment(r.GasCoins)
+	tx.SetGasBudget(r.Budget)
+	return nil
+}
+
+// SignAndExecute reserves gas from client, builds tx with the reservation
+// as its gas payment, signs it with signer and executes it through the
+// station. tx must have a sender, and a gas price either set directly or
+// resolvable through opts.BuildOptions.GasResolver.
+func SignAndExecute(ctx context.Context, client SponsorClient, tx *transaction.Transaction, signer transaction.TransactionSigner, opts Options) (*ExecutionResult, error) {
+	if client == nil {
+		return nil, errors.New("gasstation: nil sponsor client")
+	}
+	if signer == nil {
+		return nil, errors.New("gasstation: nil signer")
+	}
+	budget := opts.Budget
+	if budget == 0 {
+		budget = DefaultGasBudget
+	}
+	duration := opts.ReserveDuration
+	if duration == 0 {
+		duration = DefaultReserveDuration
+	}
+
+	reservation, err := client.ReserveGas(ctx, budget, duration)
+	if err != nil {
+		return nil, fmt.Errorf("gasstation: reserve gas: %w", err)
+	}
+	if reservation.Budget == 0 {
+		reservation.Budget = budget
+	}
+	if err := reservation.Apply(tx); err != nil {
+		return nil, err
+	}
+
+	built, err := tx.Build(ctx, opts.BuildOptions)
+	if err != nil {
+		return nil, fmt.Errorf("gasstation: build: %w", err)
+	}
+	if len(built.TransactionBytes) == 0 {
+		return nil, errors.New("gasstation: build: sender and gas price are required")
+	}
+
+	signature, err := signer.SignTransaction(built.TransactionBytes)
+	if err != nil {
+		return nil, fmt.Errorf("gasstation: sign: %w", err)
+	}
+	result, err := client.ExecuteSponsored(ctx, reservation, built.TransactionBytes, signature)
+	if err != nil {
+		return nil, fmt.Errorf("gasstation: execute: %w", err)
+	}
+	return result, nil
+}
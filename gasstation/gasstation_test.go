@@ -0,0 +1,93 @@
+package gasstation
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-move/sui-go-sdk/cryptography/ed25519"
+	"github.com/open-move/sui-go-sdk/transaction"
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+const sponsor = "0x0000000000000000000000000000000000000000000000000000000000005a5a"
+
+func TestSignAndExecute(t *testing.T) {
+	user, err := ed25519.Generate()
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	sender, _ := user.SuiAddress()
+	coinDigest := types.Digest(bytes.Repeat([]byte{7}, 32)).String()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, `{"error":"unauthorized"}`, http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/reserve_gas":
+			var req reserveGasRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.GasBudget != 20_000_000 || req.ReserveDurationSecs != 30 {
+				t.Errorf("unexpected reservation request %+v", req)
+			}
+			w.Write([]byte(`{"result":{"sponsor_address":"` + sponsor + `","reservation_id":42,"gas_coins":[
+				{"objectId":"0x77","version":"9","digest":"` + coinDigest + `"}
+			]},"error":null}`))
+		case "/v1/execute_tx":
+			var req executeTxRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			txBytes, _ := base64.StdEncoding.DecodeString(req.TxBytes)
+			data, err := transaction.DecodeTransactionData(txBytes)
+			if err != nil {
+				t.Errorf("decode transaction: %v", err)
+			} else {
+				gas := data.V1.GasData
+				if req.ReservationID != 42 || gas.Owner != utils.MustParseAddress(sponsor) || gas.Budget != 20_000_000 ||
+					len(gas.Payment) != 1 || gas.Payment[0].Version != 9 || data.V1.Sender != utils.MustParseAddress(sender) {
+					t.Errorf("unexpected sponsored transaction %+v", data.V1)
+				}
+			}
+			if sig, _ := base64.StdEncoding.DecodeString(req.UserSig); len(sig) == 0 {
+				t.Errorf("missing user signature")
+			}
+			w.Write([]byte(`{"effects":{"transactionDigest":"Digest1","status":{"status":"success"}},"error":null}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	tx := transaction.New()
+	tx.SetSender(sender)
+	tx.SetGasPrice(1000)
+	tx.MoveCall(transaction.MoveCall{Target: "0x2::counter::increment", Arguments: []transaction.Argument{tx.PureU64(1)}})
+
+	client := NewHTTPClient(server.URL+"/", WithBearerToken("secret"))
+	result, err := SignAndExecute(context.Background(), client, tx, user, Options{Budget: 20_000_000, ReserveDuration: 30 * time.Second})
+	if err != nil {
+		t.Fatalf("sign and execute: %v", err)
+	}
+	if result.Digest != "Digest1" || !result.Success {
+		t.Fatalf("unexpected result %+v", result)
+	}
+
+	_, err = NewHTTPClient(server.URL).ReserveGas(context.Background(), 1, time.Second)
+	if err == nil || !strings.Contains(err.Error(), "unauthorized") {
+		t.Fatalf("expected the station's error, got %v", err)
+	}
+}
+
+func TestReservationApplyRequiresCoins(t *testing.T) {
+	if err := (&Reservation{}).Apply(transaction.New()); err != ErrNoGasCoins {
+		t.Fatalf("expected ErrNoGasCoins, got %v", err)
+	}
+}
@@ -0,0 +1,218 @@
+package gasstation
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+// HTTPClient is a SponsorClient for a gas station speaking the API of the
+// Sui gas pool server: POST /v1/reserve_gas and POST /v1/execute_tx with a
+// bearer token. It is safe for concurrent use.
+type HTTPClient struct {
+	endpoint   string
+	httpClient *http.Client
+	token      string
+	now        func() time.Time
+}
+
+// Option configures an HTTPClient.
+type Option func(*HTTPClient)
+
+// WithHTTPClient sets the HTTP client used to reach the station.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *HTTPClient) {
+		if client != nil {
+			c.httpClient = client
+		}
+	}
+}
+
+// WithBearerToken authenticates requests with an Authorization header.
+func WithBearerToken(token string) Option {
+	return func(c *HTTPClient) {
+		c.token = token
+	}
+}
+
+// NewHTTPClient returns a client for the station at endpoint.
+func NewHTTPClient(endpoint string, opts ...Option) *HTTPClient {
+	c := &HTTPClient{
+		endpoint:   strings.TrimRight(endpoint, "/"),
+		httpClient: http.DefaultClient,
+		now:        time.Now,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+type reserveGasRequest struct {
+	GasBudget           uint64 `json:"gas_budget"`
+	ReserveDurationSecs uint64 `json:"reserve_duration_secs"`
+}
+
+type reserveGasResponse struct {
+	Result *struct {
+		SponsorAddress string       `json:"sponsor_address"`
+		ReservationID  flexUint64   `json:"reservation_id"`
+		GasCoins       []gasCoinRef `json:"gas_coins"`
+	} `json:"result"`
+	Error *string `json:"error"`
+}
+
+type gasCoinRef struct {
+	ObjectID string     `json:"objectId"`
+	Version  flexUint64 `json:"version"`
+	Digest   string     `json:"digest"`
+}
+
+type executeTxRequest struct {
+	ReservationID uint64 `json:"reservation_id"`
+	TxBytes       string `json:"tx_bytes"`
+	UserSig       string `json:"user_sig"`
+}
+
+type executeTxResponse struct {
+	Effects json.RawMessage `json:"effects"`
+	Error   *string         `json:"error"`
+}
+
+// ReserveGas implements SponsorClient.
+func (c *HTTPClient) ReserveGas(ctx context.Context, budget uint64, duration time.Duration) (*Reservation, error) {
+	secs := uint64((duration + time.Second - 1) / time.Second)
+	var resp reserveGasResponse
+	if err := c.post(ctx, "/v1/reserve_gas", reserveGasRequest{GasBudget: budget, ReserveDurationSecs: secs}, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Error != nil && *resp.Error != "" {
+		return nil, errors.New(*resp.Error)
+	}
+	if resp.Result == nil {
+		return nil, errors.New("empty reservation")
+	}
+
+	sponsor, err := utils.ParseAddress(resp.Result.SponsorAddress)
+	if err != nil {
+		return nil, fmt.Errorf("sponsor address: %w", err)
+	}
+	coins := make([]types.ObjectRef, len(resp.Result.GasCoins))
+	for i, coin := range resp.Result.GasCoins {
+		ref, err := utils.ParseObjectRef(coin.ObjectID, uint64(coin.Version), coin.Digest)
+		if err != nil {
+			return nil, fmt.Errorf("gas coin %d: %w", i, err)
+		}
+		coins[i] = ref
+	}
+	return &Reservation{
+		ID:             uint64(resp.Result.ReservationID),
+		SponsorAddress: sponsor,
+		GasCoins:       coins,
+		Budget:         budget,
+		ExpiresAt:      c.now().Add(time.Duration(secs) * time.Second),
+	}, nil
+}
+
+// ExecuteSponsored implements SponsorClient.
+func (c *HTTPClient) ExecuteSponsored(ctx context.Context, reservation *Reservation, txBytes []byte, userSignature []byte) (*ExecutionResult, error) {
+	if reservation == nil {
+		return nil, errors.New("nil reservation")
+	}
+	req := executeTxRequest{
+		ReservationID: reservation.ID,
+		TxBytes:       base64.StdEncoding.EncodeToString(txBytes),
+		UserSig:       base64.StdEncoding.EncodeToString(userSignature),
+	}
+	var resp executeTxResponse
+	if err := c.post(ctx, "/v1/execute_tx", req, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Error != nil && *resp.Error != "" {
+		return nil, errors.New(*resp.Error)
+	}
+	if len(resp.Effects) == 0 || string(resp.Effects) == "null" {
+		return nil, errors.New("empty effects")
+	}
+
+	var effects struct {
+		TransactionDigest string `json:"transactionDigest"`
+		Status            struct {
+			Status string `json:"status"`
+			Error  string `json:"error"`
+		} `json:"status"`
+	}
+	if err := json.Unmarshal(resp.Effects, &effects); err != nil {
+		return nil, fmt.Errorf("decode effects: %w", err)
+	}
+	return &ExecutionResult{
+		Digest:  effects.TransactionDigest,
+		Success: effects.Status.Status == "success",
+		Error:   effects.Status.Error,
+		Effects: resp.Effects,
+	}, nil
+}
+
+func (c *HTTPClient) post(ctx context.Context, path string, body, result any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+path, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 300 {
+		// The gas pool reports failures in the error field; fall back to the
+		// raw body for proxies and other servers.
+		var failure struct {
+			Error *string `json:"error"`
+		}
+		if json.Unmarshal(data, &failure) == nil && failure.Error != nil && *failure.Error != "" {
+			return fmt.Errorf("%s: status %d: %s", path, resp.StatusCode, *failure.Error)
+		}
+		return fmt.Errorf("%s: status %d: %s", path, resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	if err := json.Unmarshal(data, result); err != nil {
+		return fmt.Errorf("%s: decode response: %w", path, err)
+	}
+	return nil
+}
+
+// flexUint64 decodes a u64 sent either as a JSON number or as a string, as
+// Sui services do for versions and IDs.
+type flexUint64 uint64
+
+func (v *flexUint64) UnmarshalJSON(data []byte) error {
+	parsed, err := strconv.ParseUint(strings.Trim(string(data), `"`), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid u64 %s", data)
+	}
+	*v = flexUint64(parsed)
+	return nil
+}
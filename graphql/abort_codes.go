@@ -0,0 +1,248 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/open-move/sui-go-sdk/types"
+)
+
+// =============================================================================
+// Move Abort Codes
+// =============================================================================
+
+// AbortCodeRegistry maps Move abort codes to messages, per package and module.
+// It is safe for concurrent use.
+type AbortCodeRegistry struct {
+	mu       sync.RWMutex
+	messages map[string]map[uint64]string
+}
+
+// NewAbortCodeRegistry returns an empty registry.
+func NewAbortCodeRegistry() *AbortCodeRegistry {
+	return &AbortCodeRegistry{messages: make(map[string]map[uint64]string)}
+}
+
+// Register adds the messages for aborts raised by module in pkg. Later
+// registrations for the same code replace earlier ones.
+func (r *AbortCodeRegistry) Register(pkg types.Address, module string, messages map[uint64]string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := abortModuleKey(pkg, module)
+	existing := r.messages[key]
+	if existing == nil {
+		existing = make(map[uint64]string, len(messages))
+		r.messages[key] = existing
+	}
+	for code, message := range messages {
+		existing[code] = message
+	}
+}
+
+// Lookup returns the message registered for code in pkg::module.
+func (r *AbortCodeRegistry) Lookup(pkg types.Address, module string, code uint64) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	message, ok := r.messages[abortModuleKey(pkg, module)][code]
+	return message, ok
+}
+
+func abortModuleKey(pkg types.Address, module string) string {
+	return pkg.String() + "::" + module
+}
+
+// WithAbortCodes makes ResolveExecutionError consult registry for the
+// messages of non-clever abort codes.
+func WithAbortCodes(registry *AbortCodeRegistry) ClientOption {
+	return func(c *Client) {
+		c.abortCodes = registry
+	}
+}
+
+// CleverAbort is the decoded form of a Move 2024 clever error abort code.
+type CleverAbort struct {
+	// Line is the source line of the abort.
+	Line uint16
+	// IdentifierIndex is the constant pool index of the error constant's
+	// name, or 0xffff when the abort did not use a named constant.
+	IdentifierIndex uint16
+	// ConstantIndex is the constant pool index of the error constant's value,
+	// or 0xffff when the abort did not use a named constant.
+	ConstantIndex uint16
+}
+
+// cleverAbortTag marks abort codes produced by clever errors.
+const (
+	cleverAbortTag     = uint64(1) << 63
+	cleverAbortNoIndex = 0xffff
+)
+
+// DecodeCleverAbortCode decodes a clever error abort code. It reports false
+// for plain numeric abort codes.
+func DecodeCleverAbortCode(code uint64) (CleverAbort, bool) {
+	if code&cleverAbortTag == 0 {
+		return CleverAbort{}, false
+	}
+	return CleverAbort{
+		Line:            uint16(code >> 32),
+		IdentifierIndex: uint16(code >> 16),
+		ConstantIndex:   uint16(code),
+	}, true
+}
+
+// ResolveExecutionError fills in e.ResolvedMessage for a Move abort. Clever
+// errors are described from the identifier and constant reported by the
+// service, or else from the constant pool in the module's disassembly. Plain
+// abort codes are looked up in the registry set with WithAbortCodes. Errors
+// that are not aborts, or that cannot be resolved, are left unchanged.
+func (c *Client) ResolveExecutionError(ctx context.Context, e *ExecutionError) error {
+	if e == nil || e.AbortCode == nil {
+		return nil
+	}
+	code, err := strconv.ParseUint(string(*e.AbortCode), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid abort code %q: %w", *e.AbortCode, err)
+	}
+
+	var pkg types.Address
+	var module string
+	if e.Module != nil {
+		module = e.Module.Name
+		if e.Module.Package != nil {
+			pkg = e.Module.Package.Address
+		}
+	}
+
+	clever, ok := DecodeCleverAbortCode(code)
+	if !ok {
+		if c.abortCodes == nil || module == "" {
+			return nil
+		}
+		if message, ok := c.abortCodes.Lookup(pkg, module, code); ok {
+			e.ResolvedMessage = fmt.Sprintf("%s: %s (abort code %d)", module, message, code)
+		}
+		return nil
+	}
+
+	identifier, constant := e.Identifier, e.Constant
+	if identifier == nil && module != "" && clever.IdentifierIndex != cleverAbortNoIndex {
+		constants, err := c.moduleConstants(ctx, pkg, module)
+		if err != nil {
+			return err
+		}
+		identifier = constantAt(constants, clever.IdentifierIndex)
+		constant = constantAt(constants, clever.ConstantIndex)
+	}
+
+	var b strings.Builder
+	if module != "" {
+		b.WriteString(module)
+		b.WriteString(": ")
+	}
+	switch {
+	case identifier != nil && constant != nil:
+		fmt.Fprintf(&b, "%s: %s", *identifier, *constant)
+	case identifier != nil:
+		b.WriteString(*identifier)
+	default:
+		b.WriteString("assertion failed")
+	}
+	fmt.Fprintf(&b, " (line %d)", clever.Line)
+	e.ResolvedMessage = b.String()
+	return nil
+}
+
+// moduleConstants returns the constant pool of a module as rendered in its
+// disassembly, indexed by position. Results are cached per module.
+func (c *Client) moduleConstants(ctx context.Context, pkg types.Address, module string) ([]*string, error) {
+	key := abortModuleKey(pkg, module)
+	if cached, ok := c.constants.Load(key); ok {
+		return cached.([]*string), nil
+	}
+
+	disassembly, err := c.GetModuleDisassembly(ctx, pkg, module)
+	if errors.Is(err, ErrModuleNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	constants := parseDisassemblyConstants(disassembly)
+	c.constants.Store(key, constants)
+	return constants, nil
+}
+
+func constantAt(constants []*string, index uint16) *string {
+	if index == cleverAbortNoIndex || int(index) >= len(constants) {
+		return nil
+	}
+	return constants[index]
+}
+
+// disassemblyConstant matches an entry of the Constants section, such as
+// `1 => vector<u8>: "EInvalidInput" // interpreted as UTF8 string`.
+var disassemblyConstant = regexp.MustCompile(`^(\d+) => ([^:]+): (.*)$`)
+
+// parseDisassemblyConstants extracts the constant pool from module
+// disassembly. Byte vectors are rendered as strings when they are text.
+func parseDisassemblyConstants(disassembly string) []*string {
+	var constants []*string
+	inConstants := false
+	for _, line := range strings.Split(disassembly, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "Constants ["):
+			inConstants = true
+			continue
+		case !inConstants:
+			continue
+		case line == "]":
+			return constants
+		}
+
+		m := disassemblyConstant.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		index, err := strconv.Atoi(m[1])
+		if err != nil {
+			continue
+		}
+		value := renderConstant(strings.TrimSpace(m[2]), m[3])
+		for len(constants) <= index {
+			constants = append(constants, nil)
+		}
+		constants[index] = &value
+	}
+	return constants
+}
+
+func renderConstant(typ, value string) string {
+	if i := strings.Index(value, " //"); i >= 0 {
+		value = value[:i]
+	}
+	value = strings.TrimSpace(value)
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		return unquoted
+	}
+	if typ != "vector<u8>" || !strings.HasPrefix(value, "[") || !strings.HasSuffix(value, "]") {
+		return value
+	}
+
+	var text []byte
+	for _, part := range strings.Split(strings.Trim(value, "[]"), ",") {
+		n, err := strconv.ParseUint(strings.TrimSpace(part), 10, 8)
+		if err != nil || n < 0x20 || n > 0x7e {
+			return value
+		}
+		text = append(text, byte(n))
+	}
+	return string(text)
+}
@@ -0,0 +1,97 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+func TestDecodeCleverAbortCode(t *testing.T) {
+	code := uint64(1)<<63 | uint64(42)<<32 | uint64(1)<<16 | 2
+	clever, ok := DecodeCleverAbortCode(code)
+	if !ok || clever.Line != 42 || clever.IdentifierIndex != 1 || clever.ConstantIndex != 2 {
+		t.Fatalf("unexpected decode %+v %v", clever, ok)
+	}
+	if _, ok := DecodeCleverAbortCode(7); ok {
+		t.Fatal("plain abort code decoded as clever")
+	}
+}
+
+func TestResolveExecutionErrorRegistry(t *testing.T) {
+	pkg := utils.MustParseAddress("0xabc123")
+	registry := NewAbortCodeRegistry()
+	registry.Register(pkg, "pool", map[uint64]string{2: "insufficient liquidity"})
+	client := NewClient(WithEndpoint("http://127.0.0.1:0"), WithAbortCodes(registry))
+
+	code := BigInt("2")
+	execErr := &ExecutionError{
+		Message:   "MoveAbort(...) in command 0",
+		AbortCode: &code,
+		Module:    &MoveModule{Name: "pool", Package: &MovePackageRef{Address: pkg}},
+	}
+	if err := client.ResolveExecutionError(context.Background(), execErr); err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if execErr.ResolvedMessage != "pool: insufficient liquidity (abort code 2)" {
+		t.Fatalf("unexpected message %q", execErr.ResolvedMessage)
+	}
+
+	unknown := BigInt("3")
+	execErr = &ExecutionError{AbortCode: &unknown, Module: execErr.Module}
+	if err := client.ResolveExecutionError(context.Background(), execErr); err != nil || execErr.ResolvedMessage != "" {
+		t.Fatalf("expected unresolved error, got %q %v", execErr.ResolvedMessage, err)
+	}
+}
+
+func TestResolveExecutionErrorDisassembly(t *testing.T) {
+	disassembly := strings.Join([]string{
+		"module abc123.pool {",
+		"Constants [",
+		"\t0 => u64: 0",
+		"\t1 => vector<u8>: \"EInsufficientLiquidity\" // interpreted as UTF8 string",
+		"\t2 => vector<u8>: [78, 111, 116, 32, 101, 110, 111, 117, 103, 104]",
+		"]",
+		"}",
+	}, "\n")
+	body, _ := json.Marshal(disassembly)
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"object":{"asMovePackage":{"module":{"disassembly":` + string(body) + `}}}}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithEndpoint(server.URL), WithRetries(0))
+	code := BigInt("9223372217243467778") // line 42, identifier 1, constant 2
+	module := &MoveModule{Name: "pool", Package: &MovePackageRef{Address: utils.MustParseAddress("0xabc123")}}
+
+	for range 2 {
+		execErr := &ExecutionError{AbortCode: &code, Module: module}
+		if err := client.ResolveExecutionError(context.Background(), execErr); err != nil {
+			t.Fatalf("resolve: %v", err)
+		}
+		if want := "pool: EInsufficientLiquidity: Not enough (line 42)"; execErr.ResolvedMessage != want {
+			t.Fatalf("got %q, want %q", execErr.ResolvedMessage, want)
+		}
+	}
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("expected disassembly to be fetched once, got %d requests", n)
+	}
+
+	// Identifiers reported by the service take precedence.
+	identifier, constant := "EPaused", "1"
+	execErr := &ExecutionError{AbortCode: &code, Module: module, Identifier: &identifier, Constant: &constant}
+	if err := client.ResolveExecutionError(context.Background(), execErr); err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if want := "pool: EPaused: 1 (line 42)"; execErr.ResolvedMessage != want {
+		t.Fatalf("got %q, want %q", execErr.ResolvedMessage, want)
+	}
+}
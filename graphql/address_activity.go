@@ -0,0 +1,115 @@
+package graphql
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/open-move/sui-go-sdk/types"
+)
+
+// =============================================================================
+// Address Activity
+// =============================================================================
+
+// AddressRole is the part an address played in a transaction.
+type AddressRole string
+
+const (
+	// AddressRoleSender means the address sent the transaction.
+	AddressRoleSender AddressRole = "SENDER"
+	// AddressRoleSponsor means the address paid gas for another sender.
+	AddressRoleSponsor AddressRole = "SPONSOR"
+	// AddressRoleRecipient means the transaction affected the address
+	// without it sending or sponsoring it, e.g. by transferring it objects.
+	AddressRoleRecipient AddressRole = "RECIPIENT"
+)
+
+// AddressTransaction is a transaction in an address's activity history.
+type AddressTransaction struct {
+	Transaction
+	Role AddressRole
+	// BalanceChanges is the address's net balance change per coin type, as
+	// reported by the transaction's effects, including gas it paid. Coin
+	// types whose changes cancel out are omitted.
+	BalanceChanges map[string]*big.Int
+}
+
+// BalanceChange returns the address's net change in coinType, or zero.
+// coinType may use short addresses, e.g. "0x2::sui::SUI".
+func (t *AddressTransaction) BalanceChange(coinType string) *big.Int {
+	if normalized, err := NormalizeTypeTag(coinType); err == nil {
+		coinType = normalized
+	}
+	if amount, ok := t.BalanceChanges[coinType]; ok {
+		return new(big.Int).Set(amount)
+	}
+	return new(big.Int)
+}
+
+// GetAddressActivity returns the transactions that address sent or that
+// affected it, oldest first, each once, annotated with the address's role and
+// its net balance changes: the history tab of a wallet in one call. It merges
+// the sentAddress and affectedAddress filters; transactions are ordered by
+// checkpoint, and within a checkpoint sent transactions come first.
+//
+// Only forward pagination is supported: pass the returned EndCursor as After
+// to read the next page. Transactions select their inputs, effects summary
+// and balance changes.
+func (c *Client) GetAddressActivity(ctx context.Context, address types.Address, pagination *PaginationArgs) (*Connection[AddressTransaction], error) {
+	options := &TransactionBlockOptions{ShowInput: true, ShowEffects: true, ShowBalanceChanges: true}
+	paired, pageInfo, err := c.mergedTransactions(ctx, "address activity", TransactionFilter{SentAddress: &address}, TransactionFilter{AffectedAddress: &address}, pagination, options)
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([]AddressTransaction, len(paired))
+	for i, tx := range paired {
+		nodes[i] = AddressTransaction{
+			Transaction:    tx.Transaction,
+			Role:           addressRole(tx, address),
+			BalanceChanges: addressBalanceChanges(tx.Transaction, address),
+		}
+	}
+	return &Connection[AddressTransaction]{PageInfo: pageInfo, Nodes: nodes}, nil
+}
+
+func addressRole(tx pairedTransaction, address types.Address) AddressRole {
+	switch {
+	case tx.first || (tx.Sender != nil && tx.Sender.Address == address):
+		return AddressRoleSender
+	case tx.GasInput != nil && tx.GasInput.GasSponsor != nil && tx.GasInput.GasSponsor.Address == address:
+		return AddressRoleSponsor
+	default:
+		return AddressRoleRecipient
+	}
+}
+
+func addressBalanceChanges(tx Transaction, address types.Address) map[string]*big.Int {
+	changes := make(map[string]*big.Int)
+	if tx.Effects == nil || tx.Effects.BalanceChanges == nil {
+		return changes
+	}
+	for _, change := range tx.Effects.BalanceChanges.Nodes {
+		if change.Owner == nil || change.Owner.Address != address || change.CoinType == nil {
+			continue
+		}
+		amount, ok := change.Amount.ToBigInt()
+		if !ok {
+			continue
+		}
+		coinType := change.CoinType.Repr
+		if normalized, err := NormalizeTypeTag(coinType); err == nil {
+			coinType = normalized
+		}
+		if total, ok := changes[coinType]; ok {
+			total.Add(total, amount)
+		} else {
+			changes[coinType] = amount
+		}
+	}
+	for coinType, amount := range changes {
+		if amount.Sign() == 0 {
+			delete(changes, coinType)
+		}
+	}
+	return changes
+}
@@ -0,0 +1,97 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+func TestGetAddressActivity(t *testing.T) {
+	wallet := utils.MustParseAddress("0xa")
+	other := utils.MustParseAddress("0xb")
+	digest := func(n byte) string { return types.Digest(bytes.Repeat([]byte{n}, 32)).String() }
+	node := func(n byte, checkpoint int, sender, sponsor types.Address, changes ...any) map[string]any {
+		var nodes []any
+		for i := 0; i < len(changes); i += 3 {
+			nodes = append(nodes, map[string]any{
+				"owner":    map[string]any{"address": changes[i].(types.Address).String()},
+				"coinType": map[string]any{"repr": changes[i+1]},
+				"amount":   changes[i+2],
+			})
+		}
+		return map[string]any{
+			"digest":   digest(n),
+			"sender":   map[string]any{"address": sender.String()},
+			"gasInput": map[string]any{"gasSponsor": map[string]any{"address": sponsor.String()}},
+			"effects": map[string]any{
+				"checkpoint":     map[string]any{"sequenceNumber": checkpoint},
+				"balanceChanges": map[string]any{"nodes": nodes},
+			},
+		}
+	}
+	sui := "0x0000000000000000000000000000000000000000000000000000000000000002::sui::SUI"
+	sent := node(1, 10, wallet, wallet, wallet, sui, "-1500", other, sui, "1000")
+	received := node(2, 11, other, other, wallet, sui, "700", other, sui, "-900")
+	sponsored := node(3, 12, other, wallet, wallet, sui, "-200", wallet, sui, "0")
+	history := map[string][]map[string]any{
+		"sentAddress":     {sent},
+		"affectedAddress": {sent, received, sponsored},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables struct {
+				Filter map[string]any `json:"filter"`
+			} `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		var nodes []map[string]any
+		for key, value := range req.Variables.Filter {
+			if value != wallet.String() {
+				t.Errorf("unexpected filter %v", req.Variables.Filter)
+			}
+			nodes = history[key]
+		}
+		var edges []any
+		for i, n := range nodes {
+			edges = append(edges, map[string]any{"cursor": string(rune('0' + i)), "node": n})
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"transactions": map[string]any{
+			"pageInfo": map[string]any{"hasNextPage": false},
+			"edges":    edges,
+		}}})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithEndpoint(server.URL), WithRetries(0))
+	page, err := client.GetAddressActivity(context.Background(), wallet, nil)
+	if err != nil {
+		t.Fatalf("address activity: %v", err)
+	}
+	if len(page.Nodes) != 3 || page.PageInfo.HasNextPage {
+		t.Fatalf("expected 3 deduplicated transactions on one page, got %d", len(page.Nodes))
+	}
+
+	want := []struct {
+		role   AddressRole
+		change int64
+	}{{AddressRoleSender, -1500}, {AddressRoleRecipient, 700}, {AddressRoleSponsor, -200}}
+	for i, tx := range page.Nodes {
+		if tx.Digest.String() != digest(byte(i+1)) || tx.Role != want[i].role {
+			t.Fatalf("transaction %d: got %s as %s", i, tx.Digest, tx.Role)
+		}
+		if got := tx.BalanceChange("0x2::sui::SUI"); got.Int64() != want[i].change || len(tx.BalanceChanges) != 1 {
+			t.Fatalf("transaction %d: balance change %s, changes %v", i, got, tx.BalanceChanges)
+		}
+	}
+
+	if _, err := client.GetAddressActivity(context.Background(), wallet, &PaginationArgs{Last: utils.Ptr(1)}); err == nil {
+		t.Fatalf("expected backward pagination to be rejected")
+	}
+}
@@ -0,0 +1,96 @@
+package graphql
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// CallOption configures a single request, overriding the client-wide
+// settings for that call only.
+type CallOption func(*callConfig)
+
+type callConfig struct {
+	timeout  time.Duration
+	headers  map[string]string
+	endpoint string
+}
+
+// WithCallTimeout bounds a single call. It replaces the client timeout set
+// with WithTimeout, so long paginated scans can be given more time than
+// quick lookups.
+func WithCallTimeout(timeout time.Duration) CallOption {
+	return func(cfg *callConfig) {
+		cfg.timeout = timeout
+	}
+}
+
+// WithCallHeader sets an HTTP header on a single call. It takes precedence
+// over headers set with WithHeader.
+func WithCallHeader(key, value string) CallOption {
+	return func(cfg *callConfig) {
+		if cfg.headers == nil {
+			cfg.headers = make(map[string]string)
+		}
+		cfg.headers[key] = value
+	}
+}
+
+// WithEndpointOverride sends a single call to endpoint instead of the
+// client's endpoint, e.g. to route a historical query to an archive service.
+func WithEndpointOverride(endpoint string) CallOption {
+	return func(cfg *callConfig) {
+		cfg.endpoint = endpoint
+	}
+}
+
+type callConfigKey struct{}
+
+// WithCallOptions returns a context that applies opts to every request made
+// with it. This reaches the client's higher-level methods, which do not take
+// call options themselves:
+//
+//	ctx := graphql.WithCallOptions(ctx, graphql.WithCallTimeout(2*time.Minute))
+//	page, err := client.QueryEvents(ctx, filter, pagination)
+func WithCallOptions(ctx context.Context, opts ...CallOption) context.Context {
+	if len(opts) == 0 {
+		return ctx
+	}
+	cfg := callConfigFrom(ctx)
+	if cfg.headers != nil {
+		headers := make(map[string]string, len(cfg.headers))
+		for key, value := range cfg.headers {
+			headers[key] = value
+		}
+		cfg.headers = headers
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return context.WithValue(ctx, callConfigKey{}, cfg)
+}
+
+func callConfigFrom(ctx context.Context) callConfig {
+	cfg, _ := ctx.Value(callConfigKey{}).(callConfig)
+	return cfg
+}
+
+// endpointFor returns the endpoint requests made with ctx are sent to.
+func (c *Client) endpointFor(ctx context.Context) string {
+	if endpoint := callConfigFrom(ctx).endpoint; endpoint != "" {
+		return endpoint
+	}
+	return c.endpoint
+}
+
+// httpClientFor returns the HTTP client for requests made with ctx. A call
+// timeout is enforced through the context, so the client-wide timeout is
+// dropped rather than allowed to cut a longer call short.
+func (c *Client) httpClientFor(ctx context.Context) *http.Client {
+	if callConfigFrom(ctx).timeout <= 0 || c.httpClient.Timeout == 0 {
+		return c.httpClient
+	}
+	httpClient := *c.httpClient
+	httpClient.Timeout = 0
+	return &httpClient
+}
@@ -0,0 +1,77 @@
+package graphql
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func chainIdentifierServer(t *testing.T, chainID string, delay time.Duration, headers chan<- string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if headers != nil {
+			headers <- r.Header.Get("X-Request-Id")
+		}
+		time.Sleep(delay)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"chainIdentifier":"` + chainID + `"}}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestCallOptions(t *testing.T) {
+	headers := make(chan string, 4)
+	primary := chainIdentifierServer(t, "primary", 0, headers)
+	archive := chainIdentifierServer(t, "archive", 0, nil)
+
+	client := NewClient(WithEndpoint(primary.URL), WithRetries(0), WithHeader("X-Request-Id", "client"))
+
+	var result struct {
+		ChainIdentifier string `json:"chainIdentifier"`
+	}
+	if err := client.Execute(context.Background(), "query { chainIdentifier }", nil, &result, WithCallHeader("X-Request-Id", "call")); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if got := <-headers; got != "call" {
+		t.Fatalf("expected call header, got %q", got)
+	}
+
+	if _, err := client.GetChainIdentifier(context.Background()); err != nil {
+		t.Fatalf("get chain identifier: %v", err)
+	}
+	if got := <-headers; got != "client" {
+		t.Fatalf("expected client header, got %q", got)
+	}
+
+	ctx := WithCallOptions(context.Background(), WithEndpointOverride(archive.URL))
+	chainID, err := client.GetChainIdentifier(ctx)
+	if err != nil {
+		t.Fatalf("get chain identifier via override: %v", err)
+	}
+	if chainID != "archive" {
+		t.Fatalf("expected archive endpoint, got %q", chainID)
+	}
+}
+
+func TestCallTimeout(t *testing.T) {
+	server := chainIdentifierServer(t, "slow", 100*time.Millisecond, nil)
+	client := NewClient(WithEndpoint(server.URL), WithRetries(0), WithTimeout(20*time.Millisecond))
+
+	if _, err := client.GetChainIdentifier(context.Background()); err == nil {
+		t.Fatal("expected client timeout")
+	}
+
+	ctx := WithCallOptions(context.Background(), WithCallTimeout(time.Second))
+	if _, err := client.GetChainIdentifier(ctx); err != nil {
+		t.Fatalf("longer call timeout: %v", err)
+	}
+
+	fast := NewClient(WithEndpoint(server.URL), WithRetries(0))
+	err := fast.Execute(context.Background(), "query { chainIdentifier }", nil, nil, WithCallTimeout(20*time.Millisecond))
+	if err == nil {
+		t.Fatal("expected call timeout")
+	}
+}
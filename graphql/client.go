@@ -7,23 +7,73 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
 	"time"
+
+	"github.com/open-move/sui-go-sdk/auth"
+	"github.com/open-move/sui-go-sdk/network"
+	"github.com/open-move/sui-go-sdk/ratelimit"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Default endpoints for Sui networks
 const (
-	MainnetEndpoint = "https://graphql.mainnet.sui.io/graphql"
-	TestnetEndpoint = "https://graphql.testnet.sui.io/graphql"
-	DevnetEndpoint  = "https://graphql.devnet.sui.io/graphql"
+	MainnetEndpoint  = "https://graphql.mainnet.sui.io/graphql"
+	TestnetEndpoint  = "https://graphql.testnet.sui.io/graphql"
+	DevnetEndpoint   = "https://graphql.devnet.sui.io/graphql"
+	LocalnetEndpoint = "http://127.0.0.1:9125/graphql"
 )
 
 // Client is a GraphQL client for the Sui blockchain.
 type Client struct {
-	endpoint   string
-	httpClient *http.Client
-	headers    map[string]string
-	maxRetries int
+	endpoint    string
+	httpClient  *http.Client
+	headers     map[string]string
+	headerFunc  func(context.Context) (map[string]string, error)
+	auth        auth.Authenticator
+	proxy       string
+	optionErr   error
+	maxRetries  int
+	concurrency int
+	logger      *slog.Logger
+	tracer      trace.Tracer
+	metrics     Metrics
+	hooks       []RequestHook
+
+	validateQueries bool
+	schemaMu        sync.Mutex
+	schema          *Schema
+
+	sizeGuards    bool
+	costGuards    bool
+	limitsMu      sync.Mutex
+	serviceConfig *ServiceConfig
+	limitsFetch   chan struct{} // closed when the in-flight config fetch ends
+	limitsRetryAt time.Time
+
+	immutable ImmutableStore
+	layouts   sync.Map // normalized type -> *MoveTypeLayout
+	functions sync.Map // package::module::function -> *MoveFunction
+
+	persistedQueries     bool
+	persistedUnsupported atomic.Bool
+	persistedMu          sync.Mutex
+	persistedManifest    map[string]string // hash -> query
+
+	variableEncoder VariableEncoder
+	numbers         NumberMode
+	limiter         ratelimit.Limiter
+
+	protocolMu      sync.Mutex
+	protocolInfo    *ProtocolInfo
+	protocolFetched time.Time
+
+	abortCodes *AbortCodeRegistry
+	constants  sync.Map // package::module -> []*string
 }
 
 // ClientOption configures the Client.
@@ -57,6 +107,41 @@ func WithHeader(key, value string) ClientOption {
 	}
 }
 
+// WithHeaderFunc computes extra headers for every request, e.g. to attach a
+// short-lived access token for a paid RPC provider. Its headers take
+// precedence over those set with WithHeader.
+func WithHeaderFunc(fn func(ctx context.Context) (map[string]string, error)) ClientOption {
+	return func(c *Client) {
+		c.headerFunc = fn
+	}
+}
+
+// WithAuthenticator applies a to every request, after all other headers are
+// set. When the service answers 401 Unauthorized, a is refreshed and the
+// request is sent once more.
+func WithAuthenticator(a auth.Authenticator) ClientOption {
+	return func(c *Client) {
+		c.auth = a
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) {
+		c.headers["User-Agent"] = userAgent
+	}
+}
+
+// WithProxy routes requests through the proxy at proxyURL. The http, https
+// and socks5 schemes are supported. It applies to the client's own
+// transport, or to a copy of the one passed to WithHTTPClient. An invalid
+// URL makes every request fail.
+func WithProxy(proxyURL string) ClientOption {
+	return func(c *Client) {
+		c.proxy = proxyURL
+	}
+}
+
 // WithRetries sets the maximum number of retries for failed requests.
 func WithRetries(maxRetries int) ClientOption {
 	return func(c *Client) {
@@ -64,6 +149,25 @@ func WithRetries(maxRetries int) ClientOption {
 	}
 }
 
+// WithConcurrency sets how many requests batch helpers such as
+// GetMultipleTransactionBlocks keep in flight at once.
+func WithConcurrency(concurrency int) ClientOption {
+	return func(c *Client) {
+		if concurrency > 0 {
+			c.concurrency = concurrency
+		}
+	}
+}
+
+// WithSchemaValidation makes the client validate every query against the
+// endpoint's introspected schema before sending it. The schema is downloaded
+// on the first request and cached.
+func WithSchemaValidation() ClientOption {
+	return func(c *Client) {
+		c.validateQueries = true
+	}
+}
+
 // NewClient creates a new Sui GraphQL client.
 func NewClient(opts ...ClientOption) *Client {
 	c := &Client{
@@ -71,21 +175,75 @@ func NewClient(opts ...ClientOption) *Client {
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		headers:    make(map[string]string),
-		maxRetries: 3,
+		headers:     make(map[string]string),
+		maxRetries:  3,
+		concurrency: 8,
 	}
 
 	for _, opt := range opts {
 		opt(c)
 	}
+	if c.proxy != "" {
+		c.optionErr = c.applyProxy(c.proxy)
+	}
 
 	return c
 }
 
+// applyProxy swaps the HTTP client for a copy whose transport uses the proxy,
+// leaving a client passed to WithHTTPClient untouched.
+func (c *Client) applyProxy(proxyURL string) error {
+	parsed, err := url.Parse(proxyURL)
+	if err != nil {
+		return fmt.Errorf("invalid proxy url: %w", err)
+	}
+	switch parsed.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return fmt.Errorf("invalid proxy url %q: unsupported scheme", proxyURL)
+	}
+
+	base, ok := c.httpClient.Transport.(*http.Transport)
+	if c.httpClient.Transport == nil {
+		base, ok = http.DefaultTransport.(*http.Transport)
+	}
+	if !ok {
+		return fmt.Errorf("proxy requires an *http.Transport, got %T", c.httpClient.Transport)
+	}
+	transport := base.Clone()
+	transport.Proxy = http.ProxyURL(parsed)
+
+	httpClient := *c.httpClient
+	httpClient.Transport = transport
+	c.httpClient = &httpClient
+	return nil
+}
+
+// Endpoint reports the GraphQL endpoint the client sends requests to.
+func (c *Client) Endpoint() string {
+	return c.endpoint
+}
+
+// Network identifies the network the client is connected to from its chain
+// identifier, falling back to the endpoint for devnet and localnet.
+func (c *Client) Network(ctx context.Context) (network.Config, error) {
+	chainID, err := c.GetChainIdentifier(ctx)
+	if err != nil {
+		return network.Config{}, err
+	}
+
+	cfg := network.Detect(chainID, c.endpoint)
+	if cfg.GraphQLURL == "" {
+		cfg.GraphQLURL = c.endpoint
+	}
+	return cfg, nil
+}
+
 // graphqlRequest represents a GraphQL request payload.
 type graphqlRequest struct {
-	Query     string         `json:"query"`
-	Variables map[string]any `json:"variables,omitempty"`
+	Query      string         `json:"query,omitempty"`
+	Variables  map[string]any `json:"variables,omitempty"`
+	Extensions map[string]any `json:"extensions,omitempty"`
 }
 
 // GraphQLError represents a GraphQL error.
@@ -121,24 +279,90 @@ func (e GraphQLErrors) Error() string {
 	return fmt.Sprintf("%s (and %d more errors)", e[0].Message, len(e)-1)
 }
 
-// Execute sends a GraphQL query and unmarshals the response.
-func (c *Client) Execute(ctx context.Context, query string, variables map[string]any, result any) error {
-	return c.executeWithRetry(ctx, query, variables, result, 0)
+// httpStatusError reports a non-retryable HTTP error response.
+type httpStatusError struct {
+	StatusCode int
+	Body       string
 }
 
-// executeWithRetry executes a GraphQL query with exponential backoff retry logic.
-func (c *Client) executeWithRetry(ctx context.Context, query string, variables map[string]any, result any, attempt int) error {
-	reqBody := graphqlRequest{
-		Query:     query,
-		Variables: variables,
+func (e *httpStatusError) Error() string {
+	return fmt.Sprintf("HTTP error %d: %s", e.StatusCode, e.Body)
+}
+
+// MultiError aggregates the failures of a batch request in input order.
+type MultiError []error
+
+// Error implements the error interface.
+func (e MultiError) Error() string {
+	if len(e) == 0 {
+		return ""
+	}
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+	return fmt.Sprintf("%s (and %d more errors)", e[0].Error(), len(e)-1)
+}
+
+// Unwrap returns the individual errors for errors.Is and errors.As.
+func (e MultiError) Unwrap() []error {
+	return e
+}
+
+// Execute sends a GraphQL query and unmarshals the response. Call options
+// override the client settings for this request only.
+func (c *Client) Execute(ctx context.Context, query string, variables map[string]any, result any, opts ...CallOption) error {
+	ctx = WithCallOptions(ctx, opts...)
+	if timeout := callConfigFrom(ctx).timeout; timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	if c.validateQueries {
+		if err := c.ValidateQuery(ctx, query); err != nil {
+			return err
+		}
+	}
+	variables, err := c.encodeVariables(variables)
+	if err != nil {
+		return err
 	}
+	if c.costGuards && query != serviceConfigQuery {
+		if err := c.checkQueryCost(ctx, query, variables); err != nil {
+			return err
+		}
+	} else if c.sizeGuards && query != serviceConfigQuery {
+		if err := c.checkQuerySize(ctx, query); err != nil {
+			return err
+		}
+	}
+	return c.observe(ctx, query, variables, func(ctx context.Context) error {
+		if c.persistedQueries && !c.persistedUnsupported.Load() {
+			return c.executePersisted(ctx, query, variables, result)
+		}
+		return c.executeWithRetry(ctx, graphqlRequest{Query: query, Variables: variables}, result, 0)
+	})
+}
+
+// authRefreshedKey marks a context whose request has already been retried
+// with refreshed credentials.
+type authRefreshedKey struct{}
 
+// executeWithRetry executes a GraphQL query with exponential backoff retry logic.
+func (c *Client) executeWithRetry(ctx context.Context, reqBody graphqlRequest, result any, attempt int) error {
+	if c.optionErr != nil {
+		return c.optionErr
+	}
+	// Wait before building the request, so authenticators sign it after
+	// any throttling delay.
+	if err := c.acquire(ctx); err != nil {
+		return err
+	}
 	jsonBody, err := json.Marshal(reqBody)
 	if err != nil {
 		return fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(jsonBody))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpointFor(ctx), bytes.NewReader(jsonBody))
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
@@ -147,12 +371,33 @@ func (c *Client) executeWithRetry(ctx context.Context, query string, variables m
 	for key, value := range c.headers {
 		req.Header.Set(key, value)
 	}
+	if c.headerFunc != nil {
+		headers, err := c.headerFunc(ctx)
+		if err != nil {
+			return fmt.Errorf("request headers: %w", err)
+		}
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+	}
+	for key, value := range callConfigFrom(ctx).headers {
+		req.Header.Set(key, value)
+	}
+	if c.auth != nil {
+		if err := c.auth.Apply(req); err != nil {
+			return fmt.Errorf("authenticate request: %w", err)
+		}
+	}
 
-	resp, err := c.httpClient.Do(req)
+	start := time.Now()
+	resp, err := c.httpClientFor(ctx).Do(req)
 	if err != nil {
+		noteExchange(ctx, len(jsonBody), 0, 0)
+		c.runHooks(ctx, reqBody, jsonBody, attempt, nil, nil, time.Since(start), err)
 		if attempt < c.maxRetries {
+			noteRetry(ctx, attempt+1)
 			time.Sleep(time.Duration(1<<attempt) * 100 * time.Millisecond) // Exponential backoff
-			return c.executeWithRetry(ctx, query, variables, result, attempt+1)
+			return c.executeWithRetry(ctx, reqBody, result, attempt+1)
 		}
 		return fmt.Errorf("request failed: %w", err)
 	}
@@ -160,16 +405,33 @@ func (c *Client) executeWithRetry(ctx context.Context, query string, variables m
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
+		c.runHooks(ctx, reqBody, jsonBody, attempt, resp, nil, time.Since(start), err)
 		return fmt.Errorf("failed to read response: %w", err)
 	}
+	noteExchange(ctx, len(jsonBody), len(body), resp.StatusCode)
+	c.runHooks(ctx, reqBody, jsonBody, attempt, resp, body, time.Since(start), nil)
 
 	if resp.StatusCode >= 500 && attempt < c.maxRetries {
+		noteRetry(ctx, attempt+1)
 		time.Sleep(time.Duration(1<<attempt) * 100 * time.Millisecond)
-		return c.executeWithRetry(ctx, query, variables, result, attempt+1)
+		return c.executeWithRetry(ctx, reqBody, result, attempt+1)
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized && c.auth != nil && ctx.Value(authRefreshedKey{}) == nil {
+		if err := c.auth.Refresh(ctx); err != nil {
+			return fmt.Errorf("refresh credentials: %w", err)
+		}
+		return c.executeWithRetry(context.WithValue(ctx, authRefreshedKey{}, true), reqBody, result, attempt)
+	}
+
+	if raw, ok := result.(*RawResponse); ok {
+		raw.capture(resp, jsonBody, body, attempt)
+		raw.numbers = c.numbers
+		result = nil
 	}
 
 	if resp.StatusCode >= 400 {
-		return fmt.Errorf("HTTP error %d: %s", resp.StatusCode, string(body))
+		return &httpStatusError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	// Parse into a temporary structure to check for errors
@@ -187,7 +449,7 @@ func (c *Client) executeWithRetry(ctx context.Context, query string, variables m
 	}
 
 	if result != nil && len(rawResp.Data) > 0 {
-		if err := json.Unmarshal(rawResp.Data, result); err != nil {
+		if err := decodeJSON(rawResp.Data, result, c.numbers); err != nil {
 			return fmt.Errorf("failed to unmarshal data: %w", err)
 		}
 	}
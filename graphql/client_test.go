@@ -0,0 +1,123 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/auth"
+)
+
+func TestClientProxyAndHeaders(t *testing.T) {
+	var requested *http.Request
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r
+		w.Write([]byte(`{"data":{"chainIdentifier":"4c78adac"}}`))
+	}))
+	defer proxy.Close()
+
+	userClient := &http.Client{}
+	client := NewClient(
+		WithEndpoint("http://graphql.example.invalid/graphql"),
+		WithHTTPClient(userClient),
+		WithProxy(proxy.URL),
+		WithUserAgent("indexer/1.0"),
+		WithHeader("X-Api-Key", "static"),
+		WithHeaderFunc(func(context.Context) (map[string]string, error) {
+			return map[string]string{"Authorization": "Bearer token"}, nil
+		}),
+		WithRetries(0),
+	)
+
+	chainID, err := client.GetChainIdentifier(context.Background())
+	if err != nil {
+		t.Fatalf("get chain identifier: %v", err)
+	}
+	if chainID != "4c78adac" {
+		t.Fatalf("unexpected chain id %q", chainID)
+	}
+	if requested.Host != "graphql.example.invalid" {
+		t.Fatalf("request did not go through the proxy: host %q", requested.Host)
+	}
+	for header, want := range map[string]string{
+		"User-Agent":    "indexer/1.0",
+		"X-Api-Key":     "static",
+		"Authorization": "Bearer token",
+	} {
+		if got := requested.Header.Get(header); got != want {
+			t.Fatalf("%s: got %q, want %q", header, got, want)
+		}
+	}
+	if userClient.Transport != nil {
+		t.Fatal("WithProxy modified the caller's http.Client")
+	}
+}
+
+func TestClientInvalidProxy(t *testing.T) {
+	client := NewClient(WithProxy("ftp://proxy.example"), WithRetries(0))
+	if _, err := client.GetChainIdentifier(context.Background()); err == nil {
+		t.Fatal("expected invalid proxy error")
+	}
+
+	failing := NewClient(WithEndpoint("http://127.0.0.1:1"), WithRetries(0), WithHeaderFunc(func(context.Context) (map[string]string, error) {
+		return nil, errors.New("token expired")
+	}))
+	if _, err := failing.GetChainIdentifier(context.Background()); err == nil || err.Error() != "request headers: token expired" {
+		t.Fatalf("expected header error, got %v", err)
+	}
+}
+
+// signingAuth signs the request body and accepts a refresh once.
+type signingAuth struct {
+	key       string
+	refreshes int
+}
+
+func (a *signingAuth) Apply(req *http.Request) error {
+	body, err := auth.ReadBody(req)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Signature", fmt.Sprintf("%s:%d", a.key, len(body)))
+	return nil
+}
+
+func (a *signingAuth) Refresh(context.Context) error {
+	a.refreshes++
+	a.key = "rotated"
+	return nil
+}
+
+func TestClientAuthenticatorRefreshesOn401(t *testing.T) {
+	var signatures []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		signature := r.Header.Get("X-Signature")
+		signatures = append(signatures, signature)
+		if signature != fmt.Sprintf("rotated:%d", len(body)) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"data":{"chainIdentifier":"4c78adac"}}`))
+	}))
+	defer server.Close()
+
+	authenticator := &signingAuth{key: "expired"}
+	client := NewClient(WithEndpoint(server.URL), WithRetries(0), WithAuthenticator(authenticator))
+	if _, err := client.GetChainIdentifier(context.Background()); err != nil {
+		t.Fatalf("get chain identifier: %v", err)
+	}
+	if authenticator.refreshes != 1 || len(signatures) != 2 {
+		t.Fatalf("expected one refresh and a retry, got %d refreshes and signatures %v", authenticator.refreshes, signatures)
+	}
+
+	client = NewClient(WithEndpoint(server.URL), WithRetries(0), WithAuthenticator(auth.APIKey("X-Signature", "wrong")))
+	var status *httpStatusError
+	if _, err := client.GetChainIdentifier(context.Background()); !errors.As(err, &status) || status.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected 401 after one refresh, got %v", err)
+	}
+}
@@ -0,0 +1,459 @@
+package graphql
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// =============================================================================
+// Code Generation
+// =============================================================================
+
+// CodegenSource is a GraphQL document to generate code for.
+type CodegenSource struct {
+	// Name identifies the document in error messages, typically its file name.
+	Name    string
+	Content string
+}
+
+// CodegenOptions configures GenerateGo.
+type CodegenOptions struct {
+	// Package is the package clause of the generated file.
+	Package string
+}
+
+// codegenScalars maps GraphQL scalars to the Go types used for them. Scalars
+// not listed here decode into json.RawMessage.
+var codegenScalars = map[string]string{
+	"String":     "string",
+	"ID":         "string",
+	"Int":        "int",
+	"Float":      "float64",
+	"Boolean":    "bool",
+	"SuiAddress": "types.Address",
+	"UInt53":     "graphql.UInt53",
+	"BigInt":     "graphql.BigInt",
+	"DateTime":   "graphql.DateTime",
+	"Base64":     "string",
+	"JSON":       "json.RawMessage",
+}
+
+// GenerateGo generates Go source for every named operation in sources,
+// checked against schema. For an operation Op it emits the document as
+// OpQuery, an OpVariables struct when the operation declares variables, an
+// OpResult struct mirroring the selection set (with a named type per nested
+// object), and a function Op that executes the operation with a *Client.
+// Input object types used by variables are emitted once per file.
+//
+// Nullable fields become pointers, lists become slices, enums become strings
+// and fields selected through fragments on a narrower type are always
+// optional. Each operation's document only contains the fragments it uses.
+func GenerateGo(schema *Schema, opts CodegenOptions, sources ...CodegenSource) ([]byte, error) {
+	if opts.Package == "" {
+		return nil, fmt.Errorf("codegen: package name is empty")
+	}
+
+	g := &codegen{schema: schema, inputs: make(map[string]bool), names: make(map[string]string)}
+	for _, src := range sources {
+		if err := schema.Validate(src.Content); err != nil {
+			return nil, fmt.Errorf("codegen: %s: %w", src.Name, err)
+		}
+		doc, err := parseDocument(src.Content)
+		if err != nil {
+			return nil, fmt.Errorf("codegen: %s: %w", src.Name, err)
+		}
+		for _, op := range doc.operations {
+			if op.name == "" {
+				return nil, fmt.Errorf("codegen: %s:%d:%d: operations must be named", src.Name, op.pos.line, op.pos.column)
+			}
+			name := exportedName(op.name)
+			if prev, dup := g.names[name]; dup {
+				return nil, fmt.Errorf("codegen: %s: operation %s conflicts with %s", src.Name, op.name, prev)
+			}
+			g.names[name] = op.name
+			if err := g.operation(src.Content, doc, op); err != nil {
+				return nil, fmt.Errorf("codegen: %s: %w", src.Name, err)
+			}
+		}
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by suigql-gen. DO NOT EDIT.\n\npackage %s\n\n", opts.Package)
+	out.WriteString("import (\n\t\"context\"\n")
+	if g.usesJSON {
+		out.WriteString("\t\"encoding/json\"\n")
+	}
+	out.WriteString("\n\t\"github.com/open-move/sui-go-sdk/graphql\"\n")
+	if g.usesTypes {
+		out.WriteString("\t\"github.com/open-move/sui-go-sdk/types\"\n")
+	}
+	out.WriteString(")\n")
+	out.Write(g.body.Bytes())
+
+	inputs := make([]string, 0, len(g.inputs))
+	for name := range g.inputs {
+		inputs = append(inputs, name)
+	}
+	sort.Strings(inputs)
+	for _, name := range inputs {
+		g.inputObject(&out, name)
+	}
+
+	formatted, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("codegen: format output: %w", err)
+	}
+	return formatted, nil
+}
+
+type codegen struct {
+	schema    *Schema
+	body      bytes.Buffer
+	inputs    map[string]bool
+	names     map[string]string
+	usesJSON  bool
+	usesTypes bool
+}
+
+// genField is one response key of a generated struct, with the selections of
+// every occurrence of that key merged together.
+type genField struct {
+	key        string
+	typ        *typeRef
+	optional   bool
+	selections []genSelection
+}
+
+// genSelection is a selection together with the type it was written against.
+type genSelection struct {
+	parent string
+	sel    astSelection
+}
+
+func (g *codegen) operation(source string, doc *astDocument, op *astOperation) error {
+	name := exportedName(op.name)
+	root := g.schema.rootType(op.kind)
+	if root == "" {
+		return fmt.Errorf("schema does not support %s operations", op.kind)
+	}
+
+	text := source[op.start:op.end]
+	for _, frag := range usedFragments(doc, op.selections) {
+		text += "\n\n" + source[frag.start:frag.end]
+	}
+
+	b := &g.body
+	fmt.Fprintf(b, "\n// %sQuery is the GraphQL document for the %s operation.\n", name, op.name)
+	fmt.Fprintf(b, "const %sQuery = %s\n", name, goRawString(text))
+
+	if len(op.variables) > 0 {
+		g.variables(name, op)
+	}
+
+	sels := make([]genSelection, len(op.selections))
+	for i, sel := range op.selections {
+		sels[i] = genSelection{parent: root, sel: sel}
+	}
+	fmt.Fprintf(b, "\n// %sResult is the response to the %s operation.\n", name, op.name)
+	if err := g.object(name+"Result", name, root, sels, doc); err != nil {
+		return err
+	}
+
+	args, vars := "", "nil"
+	if len(op.variables) > 0 {
+		args, vars = ", vars "+name+"Variables", "vars.Vars()"
+	}
+	fmt.Fprintf(b, "\n// %s executes the %s operation.\n", name, op.name)
+	fmt.Fprintf(b, "func %s(ctx context.Context, client *graphql.Client%s) (*%sResult, error) {\n", name, args, name)
+	fmt.Fprintf(b, "\tvar result %sResult\n", name)
+	fmt.Fprintf(b, "\tif err := client.Execute(ctx, %sQuery, %s, &result); err != nil {\n\t\treturn nil, err\n\t}\n", name, vars)
+	b.WriteString("\treturn &result, nil\n}\n")
+	return nil
+}
+
+func (g *codegen) variables(name string, op *astOperation) {
+	b := &g.body
+	fmt.Fprintf(b, "\n// %sVariables are the variables of the %s operation.\n", name, op.name)
+	fmt.Fprintf(b, "type %sVariables struct {\n", name)
+	for _, v := range op.variables {
+		tag := v.name
+		if !v.typ.nonNull {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(b, "\t%s %s `json:%q`\n", exportedName(v.name), g.astGoType(v.typ), tag)
+	}
+	b.WriteString("}\n")
+
+	fmt.Fprintf(b, "\n// Vars returns the variables as a graphql.Vars map, leaving out unset\n// optional variables.\n")
+	fmt.Fprintf(b, "func (v %sVariables) Vars() graphql.Vars {\n\tvars := graphql.Vars{}\n", name)
+	for _, v := range op.variables {
+		field := exportedName(v.name)
+		if v.typ.nonNull {
+			fmt.Fprintf(b, "\tvars[%q] = v.%s\n", v.name, field)
+			continue
+		}
+		deref := ""
+		if strings.HasPrefix(g.astGoType(v.typ), "*") {
+			deref = "*"
+		}
+		fmt.Fprintf(b, "\tif v.%s != nil {\n\t\tvars[%q] = %sv.%s\n\t}\n", field, v.name, deref, field)
+	}
+	b.WriteString("\treturn vars\n}\n")
+}
+
+// object emits a struct named typeName for sels and, after it, the structs of
+// its nested objects. prefix is used to name nested types.
+func (g *codegen) object(typeName, prefix, parent string, sels []genSelection, doc *astDocument) error {
+	var fields []*genField
+	byKey := make(map[string]*genField)
+	if err := g.collect(parent, sels, false, doc, &fields, byKey); err != nil {
+		return err
+	}
+
+	type nested struct {
+		typeName string
+		parent   string
+		sels     []genSelection
+	}
+	var children []nested
+
+	b := &g.body
+	fmt.Fprintf(b, "type %s struct {\n", typeName)
+	for _, f := range fields {
+		goName := exportedName(f.key)
+		named := f.typ.namedType()
+		var goType string
+		if t := g.schema.types[named]; t != nil && isCompositeKind(t.Kind) {
+			childName := prefix + goName
+			children = append(children, nested{typeName: childName, parent: named, sels: f.selections})
+			goType = g.goType(f.typ, childName, f.optional)
+		} else {
+			goType = g.goType(f.typ, "", f.optional)
+		}
+		fmt.Fprintf(b, "\t%s %s `json:%q`\n", goName, goType, f.key)
+	}
+	b.WriteString("}\n")
+
+	for _, child := range children {
+		b.WriteString("\n")
+		if err := g.object(child.typeName, child.typeName, child.parent, child.sels, doc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// collect flattens sels into response keys, following fragment spreads and
+// inline fragments. Fields reached through a fragment on a type other than
+// parent are marked optional.
+func (g *codegen) collect(parent string, sels []genSelection, optional bool, doc *astDocument, fields *[]*genField, byKey map[string]*genField) error {
+	for _, s := range sels {
+		sel := s.sel
+		switch sel.kind {
+		case selectionField:
+			key := sel.alias
+			if key == "" {
+				key = sel.name
+			}
+			var typ *typeRef
+			if sel.name == "__typename" {
+				typ = &typeRef{Kind: typeKindNonNull, OfType: &typeRef{Kind: typeKindScalar, Name: "String"}}
+			} else {
+				field := g.schema.lookupField(g.schema.types[s.parent], sel.name)
+				if field == nil {
+					return fmt.Errorf("%d:%d: unknown field %s.%s", sel.pos.line, sel.pos.column, s.parent, sel.name)
+				}
+				typ = field.Type
+			}
+			child := make([]genSelection, len(sel.selections))
+			for i, c := range sel.selections {
+				child[i] = genSelection{parent: typ.namedType(), sel: c}
+			}
+			if f, ok := byKey[key]; ok {
+				f.selections = append(f.selections, child...)
+				f.optional = f.optional && optional
+				continue
+			}
+			f := &genField{key: key, typ: typ, optional: optional, selections: child}
+			byKey[key] = f
+			*fields = append(*fields, f)
+		case selectionFragmentSpread, selectionInlineFragment:
+			cond, body := sel.typeCondition, sel.selections
+			if sel.kind == selectionFragmentSpread {
+				frag := doc.fragments[sel.name]
+				if frag == nil {
+					return fmt.Errorf("%d:%d: unknown fragment %s", sel.pos.line, sel.pos.column, sel.name)
+				}
+				cond, body = frag.typeCondition, frag.selections
+			}
+			if cond == "" {
+				cond = s.parent
+			}
+			inner := make([]genSelection, len(body))
+			for i, c := range body {
+				inner[i] = genSelection{parent: cond, sel: c}
+			}
+			if err := g.collect(parent, inner, optional || cond != parent, doc, fields, byKey); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// goType returns the Go type for a response field. objectName is the
+// generated struct used for composite types.
+func (g *codegen) goType(t *typeRef, objectName string, optional bool) string {
+	nullable := true
+	if t.Kind == typeKindNonNull {
+		nullable, t = false, t.OfType
+	}
+	if t.Kind == typeKindList {
+		return "[]" + g.goType(t.OfType, objectName, false)
+	}
+
+	goType := objectName
+	if goType == "" {
+		goType = g.leafType(t.Name)
+	}
+	if (nullable || optional) && goType != "json.RawMessage" {
+		goType = "*" + goType
+	}
+	return goType
+}
+
+// astGoType returns the Go type for a variable of type t.
+func (g *codegen) astGoType(t *astType) string {
+	if t.list != nil {
+		return "[]" + g.astGoType(t.list)
+	}
+	goType := g.inputType(t.name)
+	if !t.nonNull && goType != "json.RawMessage" {
+		goType = "*" + goType
+	}
+	return goType
+}
+
+// inputType returns the Go type for a named input type, recording input
+// objects so that they are emitted.
+func (g *codegen) inputType(name string) string {
+	if t := g.schema.types[name]; t != nil && t.Kind == typeKindInputObject {
+		if !g.inputs[name] {
+			g.inputs[name] = true
+			for _, f := range t.InputFields {
+				g.inputType(f.Type.namedType())
+			}
+		}
+		return exportedName(name)
+	}
+	return g.leafType(name)
+}
+
+func (g *codegen) leafType(name string) string {
+	if t := g.schema.types[name]; t != nil && t.Kind == typeKindEnum {
+		return "string"
+	}
+	return g.goScalar(name)
+}
+
+func (g *codegen) goScalar(name string) string {
+	goType, ok := codegenScalars[name]
+	if !ok {
+		goType = "json.RawMessage"
+	}
+	switch {
+	case strings.HasPrefix(goType, "json."):
+		g.usesJSON = true
+	case strings.HasPrefix(goType, "types."):
+		g.usesTypes = true
+	}
+	return goType
+}
+
+func (g *codegen) inputObject(out *bytes.Buffer, name string) {
+	t := g.schema.types[name]
+	fmt.Fprintf(out, "\n// %s is the %s input type.\n", exportedName(name), name)
+	fmt.Fprintf(out, "type %s struct {\n", exportedName(name))
+	for _, f := range t.InputFields {
+		tag := f.Name
+		if f.Type.Kind != typeKindNonNull {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(out, "\t%s %s `json:%q`\n", exportedName(f.Name), g.inputRefType(f.Type), tag)
+	}
+	out.WriteString("}\n")
+}
+
+func (g *codegen) inputRefType(t *typeRef) string {
+	nullable := true
+	if t.Kind == typeKindNonNull {
+		nullable, t = false, t.OfType
+	}
+	if t.Kind == typeKindList {
+		return "[]" + g.inputRefType(t.OfType)
+	}
+	goType := g.inputType(t.Name)
+	if nullable && goType != "json.RawMessage" {
+		goType = "*" + goType
+	}
+	return goType
+}
+
+// usedFragments returns the fragments reachable from sels in first-use order.
+func usedFragments(doc *astDocument, sels []astSelection) []*astFragment {
+	var out []*astFragment
+	seen := make(map[string]bool)
+	var walk func([]astSelection)
+	walk = func(sels []astSelection) {
+		for _, sel := range sels {
+			if sel.kind == selectionFragmentSpread {
+				frag := doc.fragments[sel.name]
+				if frag == nil || seen[sel.name] {
+					continue
+				}
+				seen[sel.name] = true
+				out = append(out, frag)
+				walk(frag.selections)
+				continue
+			}
+			walk(sel.selections)
+		}
+	}
+	walk(sels)
+	return out
+}
+
+// exportedName converts a GraphQL name such as coinType or __typename into an
+// exported Go identifier.
+func exportedName(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if r == '_' {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	if b.Len() == 0 {
+		return "X"
+	}
+	return b.String()
+}
+
+// goRawString quotes s as a Go raw string literal, falling back to an
+// interpreted literal when s contains a backquote.
+func goRawString(s string) string {
+	if strings.Contains(s, "`") {
+		return fmt.Sprintf("%q", s)
+	}
+	return "`" + s + "`"
+}
@@ -0,0 +1,106 @@
+package graphql
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const codegenDocument = `
+# Transactions and their owners.
+query GetTransaction($digest: String!) {
+	transaction(digest: $digest) {
+		digest
+		kind
+		owner {
+			__typename
+			...OwnerAddress
+		}
+	}
+}
+
+query listObjects($first: Int, $filter: ObjectFilter) {
+	objects(first: $first, filter: $filter) { address }
+}
+
+fragment OwnerAddress on AddressOwner {
+	address { address }
+}
+
+fragment Unused on Object { address }
+`
+
+func TestParseIntrospection(t *testing.T) {
+	bare, _ := json.Marshal(testIntrospection())
+	wrapped, _ := json.Marshal(map[string]any{"data": testIntrospection()})
+
+	for name, data := range map[string][]byte{"bare": bare, "wrapped": wrapped} {
+		schema, err := ParseIntrospection(data)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if schema.queryType != "Query" || schema.types["Transaction"] == nil {
+			t.Fatalf("%s: unexpected schema %+v", name, schema)
+		}
+	}
+
+	if _, err := ParseIntrospection([]byte(`{"data":{}}`)); err == nil {
+		t.Fatal("expected error for missing query type")
+	}
+}
+
+func TestGenerateGo(t *testing.T) {
+	out, err := GenerateGo(testSchema(t), CodegenOptions{Package: "queries"}, CodegenSource{Name: "tx.graphql", Content: codegenDocument})
+	if err != nil {
+		t.Fatalf("GenerateGo: %v", err)
+	}
+	code := string(out)
+	compact := strings.Join(strings.Fields(code), " ")
+
+	for _, want := range []string{
+		"// Code generated by suigql-gen. DO NOT EDIT.",
+		"package queries",
+		`"github.com/open-move/sui-go-sdk/types"`,
+		"type GetTransactionVariables struct {\n\tDigest string `json:\"digest\"`\n}",
+		"type GetTransactionResult struct {\n\tTransaction *GetTransactionTransaction `json:\"transaction\"`\n}",
+		"Kind *string `json:\"kind\"`",
+		"Typename string `json:\"__typename\"`",
+		"Address *GetTransactionTransactionOwnerAddress `json:\"address\"`",
+		"Address types.Address `json:\"address\"`",
+		"func GetTransaction(ctx context.Context, client *graphql.Client, vars GetTransactionVariables) (*GetTransactionResult, error)",
+		"Filter *ObjectFilter `json:\"filter,omitempty\"`",
+		"ObjectIds []types.Address `json:\"objectIds,omitempty\"`",
+		"vars[\"first\"] = *v.First",
+		"vars[\"filter\"] = *v.Filter",
+		"func ListObjects(ctx context.Context, client *graphql.Client, vars ListObjectsVariables) (*ListObjectsResult, error)",
+	} {
+		if !strings.Contains(compact, strings.Join(strings.Fields(want), " ")) {
+			t.Errorf("generated code missing %q\n%s", want, code)
+		}
+	}
+
+	// Each document only carries the fragments its operation uses.
+	start := strings.Index(code, "const GetTransactionQuery")
+	end := strings.Index(code[start:], "`\n") + start
+	query := code[start:end]
+	if !strings.Contains(query, "fragment OwnerAddress on AddressOwner") || strings.Contains(query, "Unused") {
+		t.Errorf("unexpected GetTransaction document:\n%s", query)
+	}
+	if err := testSchema(t).Validate(query[strings.Index(query, "`")+1:]); err != nil {
+		t.Errorf("generated document does not validate: %v", err)
+	}
+}
+
+func TestGenerateGoErrors(t *testing.T) {
+	schema := testSchema(t)
+	cases := map[string]string{
+		"anonymous": `{ chainIdentifier }`,
+		"invalid":   `query Q { nope }`,
+		"duplicate": `query chain { chainIdentifier } query Chain { chainIdentifier }`,
+	}
+	for name, doc := range cases {
+		if _, err := GenerateGo(schema, CodegenOptions{Package: "p"}, CodegenSource{Name: name, Content: doc}); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
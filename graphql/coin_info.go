@@ -0,0 +1,156 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+// =============================================================================
+// Coin Info & Pricing
+// =============================================================================
+
+// CoinInfo is the subset of coin metadata needed to display balances.
+type CoinInfo struct {
+	CoinType string
+	Decimals int
+	Symbol   string
+	Name     string
+	IconURL  string
+}
+
+// CoinInfoProvider looks up display metadata for a coin type.
+type CoinInfoProvider interface {
+	CoinInfo(ctx context.Context, coinType string) (*CoinInfo, error)
+}
+
+// PriceFeed supplies the fiat price of one whole unit of a coin (e.g. 1 SUI).
+// Implementations typically wrap an exchange or oracle API.
+type PriceFeed interface {
+	Price(ctx context.Context, coinType string) (price *big.Float, currency string, err error)
+}
+
+// ErrCoinMetadataNotFound is returned when no metadata exists for a coin type.
+var ErrCoinMetadataNotFound = errors.New("coin metadata not found")
+
+// MetadataCoinInfoProvider resolves CoinInfo via GetCoinMetadata and caches
+// the results, since coin metadata rarely changes.
+type MetadataCoinInfoProvider struct {
+	client *Client
+	mu     sync.RWMutex
+	cache  map[string]*CoinInfo
+}
+
+// NewCoinInfoProvider returns a CoinInfoProvider backed by the client.
+func NewCoinInfoProvider(c *Client) *MetadataCoinInfoProvider {
+	return &MetadataCoinInfoProvider{client: c, cache: make(map[string]*CoinInfo)}
+}
+
+// CoinInfo returns cached metadata for coinType, fetching it on first use.
+func (p *MetadataCoinInfoProvider) CoinInfo(ctx context.Context, coinType string) (*CoinInfo, error) {
+	if p == nil || p.client == nil {
+		return nil, errors.New("nil client")
+	}
+
+	p.mu.RLock()
+	cached, ok := p.cache[coinType]
+	p.mu.RUnlock()
+	if ok {
+		return cached, nil
+	}
+
+	metadata, err := p.client.GetCoinMetadata(ctx, coinType)
+	if err != nil {
+		return nil, err
+	}
+	if metadata == nil || metadata.Decimals == nil {
+		return nil, fmt.Errorf("%w: %s", ErrCoinMetadataNotFound, coinType)
+	}
+
+	info := &CoinInfo{CoinType: coinType, Decimals: *metadata.Decimals}
+	if metadata.Symbol != nil {
+		info.Symbol = *metadata.Symbol
+	}
+	if metadata.Name != nil {
+		info.Name = *metadata.Name
+	}
+	if metadata.IconURL != nil {
+		info.IconURL = *metadata.IconURL
+	}
+
+	p.mu.Lock()
+	p.cache[coinType] = info
+	p.mu.Unlock()
+	return info, nil
+}
+
+// CoinFormatter formats raw balances using coin metadata and, optionally, a price feed.
+type CoinFormatter struct {
+	provider CoinInfoProvider
+	prices   PriceFeed
+}
+
+// NewCoinFormatter creates a formatter. prices may be nil when fiat values are not needed.
+func NewCoinFormatter(provider CoinInfoProvider, prices PriceFeed) *CoinFormatter {
+	return &CoinFormatter{provider: provider, prices: prices}
+}
+
+// FormatBalance renders a base-unit amount with decimals and symbol, e.g. "1.234 SUI".
+func (f *CoinFormatter) FormatBalance(ctx context.Context, amount *big.Int, coinType string) (string, error) {
+	info, err := f.coinInfo(ctx, coinType)
+	if err != nil {
+		return "", err
+	}
+
+	formatted := utils.FormatUnits(amount, info.Decimals)
+	if info.Symbol == "" {
+		return formatted, nil
+	}
+	return formatted + " " + info.Symbol, nil
+}
+
+// FiatValue converts a base-unit amount into its fiat value using the price feed.
+func (f *CoinFormatter) FiatValue(ctx context.Context, amount *big.Int, coinType string) (*big.Float, string, error) {
+	if f.prices == nil {
+		return nil, "", errors.New("no price feed configured")
+	}
+	if amount == nil {
+		return nil, "", errors.New("nil amount")
+	}
+
+	info, err := f.coinInfo(ctx, coinType)
+	if err != nil {
+		return nil, "", err
+	}
+	price, currency, err := f.prices.Price(ctx, coinType)
+	if err != nil {
+		return nil, "", err
+	}
+	if price == nil {
+		return nil, "", fmt.Errorf("no price for %s", coinType)
+	}
+
+	scale := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(info.Decimals)), nil))
+	units := new(big.Float).Quo(new(big.Float).SetInt(amount), scale)
+	return units.Mul(units, price), currency, nil
+}
+
+// FormatFiat renders the fiat value of an amount with two decimal places, e.g. "12.34 USD".
+func (f *CoinFormatter) FormatFiat(ctx context.Context, amount *big.Int, coinType string) (string, error) {
+	value, currency, err := f.FiatValue(ctx, amount, coinType)
+	if err != nil {
+		return "", err
+	}
+	return value.Text('f', 2) + " " + currency, nil
+}
+
+func (f *CoinFormatter) coinInfo(ctx context.Context, coinType string) (*CoinInfo, error) {
+	if f == nil || f.provider == nil {
+		return nil, errors.New("nil coin info provider")
+	}
+	return f.provider.CoinInfo(ctx, coinType)
+}
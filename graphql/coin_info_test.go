@@ -0,0 +1,39 @@
+package graphql
+
+import (
+	"context"
+	"math/big"
+	"testing"
+)
+
+type staticCoinInfo map[string]*CoinInfo
+
+func (s staticCoinInfo) CoinInfo(_ context.Context, coinType string) (*CoinInfo, error) {
+	return s[coinType], nil
+}
+
+type staticPrice struct{}
+
+func (staticPrice) Price(context.Context, string) (*big.Float, string, error) {
+	return big.NewFloat(2.5), "USD", nil
+}
+
+func TestCoinFormatter(t *testing.T) {
+	provider := staticCoinInfo{"0x2::sui::SUI": {CoinType: "0x2::sui::SUI", Decimals: 9, Symbol: "SUI"}}
+	formatter := NewCoinFormatter(provider, staticPrice{})
+	ctx := context.Background()
+
+	got, err := formatter.FormatBalance(ctx, big.NewInt(1_234_000_000), "0x2::sui::SUI")
+	if err != nil || got != "1.234 SUI" {
+		t.Fatalf("FormatBalance = %q, %v", got, err)
+	}
+
+	fiat, err := formatter.FormatFiat(ctx, big.NewInt(4_000_000_000), "0x2::sui::SUI")
+	if err != nil || fiat != "10.00 USD" {
+		t.Fatalf("FormatFiat = %q, %v", fiat, err)
+	}
+
+	if _, _, err := NewCoinFormatter(provider, nil).FiatValue(ctx, big.NewInt(1), "0x2::sui::SUI"); err == nil {
+		t.Fatalf("expected error without price feed")
+	}
+}
@@ -0,0 +1,243 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/open-move/sui-go-sdk/types"
+)
+
+// Defaults for SharedObjectCongestion.
+const (
+	DefaultCongestionTransactions = 50
+	DefaultCongestionCheckpoints  = 10
+	DefaultCongestionPercentile   = 0.75
+	DefaultMaxGasPriceMultiplier  = 10
+)
+
+// congestionCancelledBump is how far above the highest cancelled gas price a
+// suggestion lands, so it ranks ahead of the transactions that lost out.
+const congestionCancelledBump = 0.1
+
+// CongestionOptions configures SharedObjectCongestion. Zero values use the
+// package defaults.
+type CongestionOptions struct {
+	// Transactions is how many recent transactions using the object to inspect.
+	Transactions int
+	// Checkpoints is how many recent checkpoints to inspect for network load.
+	Checkpoints int
+	// Percentile of successful gas prices to match, between 0 and 1.
+	Percentile float64
+	// MaxMultiplier caps the suggested multiplier.
+	MaxMultiplier float64
+}
+
+// CongestionReport summarises recent demand for a shared object and the gas
+// price suggested to land a transaction on it.
+type CongestionReport struct {
+	ObjectID          types.Address
+	ReferenceGasPrice uint64
+	// Transactions is the number of recent transactions inspected and
+	// Cancelled how many of them were cancelled because the object was
+	// congested.
+	Transactions int
+	Cancelled    int
+	// PercentileGasPrice is the configured percentile of the gas prices paid
+	// by successful transactions.
+	PercentileGasPrice uint64
+	// MaxCancelledGasPrice is the highest gas price that was still cancelled.
+	MaxCancelledGasPrice uint64
+	// AverageCheckpointComputationCost is the mean computation cost per
+	// recent checkpoint, derived from their rolling gas summaries, as a
+	// measure of network-wide load.
+	AverageCheckpointComputationCost uint64
+	// Multiplier is the suggested multiple of the reference gas price, ready
+	// for transaction.GasPriceOracle.SetMultiplier.
+	Multiplier        float64
+	SuggestedGasPrice uint64
+}
+
+// CancellationRate returns the share of inspected transactions that were
+// cancelled for congestion.
+func (r *CongestionReport) CancellationRate() float64 {
+	if r.Transactions == 0 {
+		return 0
+	}
+	return float64(r.Cancelled) / float64(r.Transactions)
+}
+
+// SharedObjectCongestion inspects the latest transactions that used the
+// shared object objectID and suggests a gas price multiplier. Validators
+// order transactions on a congested object by gas price, so the suggestion
+// matches what recent successful transactions paid and, if any were
+// cancelled for congestion, outbids the highest cancelled price.
+func (c *Client) SharedObjectCongestion(ctx context.Context, objectID types.Address, opts *CongestionOptions) (*CongestionReport, error) {
+	cfg := CongestionOptions{}
+	if opts != nil {
+		cfg = *opts
+	}
+	if cfg.Transactions <= 0 {
+		cfg.Transactions = DefaultCongestionTransactions
+	}
+	if cfg.Checkpoints <= 0 {
+		cfg.Checkpoints = DefaultCongestionCheckpoints
+	}
+	if cfg.Percentile <= 0 || cfg.Percentile > 1 {
+		cfg.Percentile = DefaultCongestionPercentile
+	}
+	if cfg.MaxMultiplier < 1 {
+		cfg.MaxMultiplier = DefaultMaxGasPriceMultiplier
+	}
+
+	query := `
+		query SharedObjectCongestion($filter: TransactionFilter, $transactions: Int, $checkpoints: Int) {
+			epoch { referenceGasPrice }
+			transactions(filter: $filter, last: $transactions) {
+				nodes {
+					gasInput { gasPrice }
+					effects {
+						status
+						executionError { message }
+					}
+				}
+			}
+			checkpoints(last: $checkpoints) {
+				nodes {
+					rollingGasSummary { computationCost }
+					epoch { epochId }
+				}
+			}
+		}
+	`
+	vars := map[string]any{
+		"filter":       TransactionFilter{InputObject: &objectID},
+		"transactions": cfg.Transactions,
+		"checkpoints":  cfg.Checkpoints,
+	}
+
+	var result struct {
+		Epoch *struct {
+			ReferenceGasPrice *BigInt `json:"referenceGasPrice"`
+		} `json:"epoch"`
+		Transactions *struct {
+			Nodes []struct {
+				GasInput *struct {
+					GasPrice *BigInt `json:"gasPrice"`
+				} `json:"gasInput"`
+				Effects *struct {
+					Status         string `json:"status"`
+					ExecutionError *struct {
+						Message string `json:"message"`
+					} `json:"executionError"`
+				} `json:"effects"`
+			} `json:"nodes"`
+		} `json:"transactions"`
+		Checkpoints *struct {
+			Nodes []struct {
+				RollingGasSummary *struct {
+					ComputationCost *BigInt `json:"computationCost"`
+				} `json:"rollingGasSummary"`
+				Epoch *struct {
+					EpochID UInt53 `json:"epochId"`
+				} `json:"epoch"`
+			} `json:"nodes"`
+		} `json:"checkpoints"`
+	}
+	if err := c.Execute(ctx, query, vars, &result); err != nil {
+		return nil, err
+	}
+	if result.Epoch == nil || result.Epoch.ReferenceGasPrice == nil {
+		return nil, errors.New("reference gas price unavailable")
+	}
+	rgp, err := strconv.ParseUint(string(*result.Epoch.ReferenceGasPrice), 10, 64)
+	if err != nil || rgp == 0 {
+		return nil, errors.New("invalid reference gas price")
+	}
+
+	report := &CongestionReport{ObjectID: objectID, ReferenceGasPrice: rgp}
+
+	var succeeded []uint64
+	if result.Transactions != nil {
+		for _, node := range result.Transactions.Nodes {
+			if node.GasInput == nil || node.GasInput.GasPrice == nil || node.Effects == nil {
+				continue
+			}
+			price, err := strconv.ParseUint(string(*node.GasInput.GasPrice), 10, 64)
+			if err != nil {
+				continue
+			}
+			report.Transactions++
+			switch {
+			case node.Effects.Status == "SUCCESS":
+				succeeded = append(succeeded, price)
+			case node.Effects.ExecutionError != nil && isCongestionCancellation(node.Effects.ExecutionError.Message):
+				report.Cancelled++
+				report.MaxCancelledGasPrice = max(report.MaxCancelledGasPrice, price)
+			}
+		}
+	}
+
+	if result.Checkpoints != nil {
+		samples := make([]checkpointGasSample, 0, len(result.Checkpoints.Nodes))
+		for _, node := range result.Checkpoints.Nodes {
+			if node.RollingGasSummary == nil || node.RollingGasSummary.ComputationCost == nil || node.Epoch == nil {
+				continue
+			}
+			cost, err := strconv.ParseUint(string(*node.RollingGasSummary.ComputationCost), 10, 64)
+			if err != nil {
+				continue
+			}
+			samples = append(samples, checkpointGasSample{epoch: uint64(node.Epoch.EpochID), cost: cost})
+		}
+		report.AverageCheckpointComputationCost = averageCheckpointCost(samples)
+	}
+
+	multiplier := 1.0
+	if len(succeeded) > 0 {
+		sort.Slice(succeeded, func(i, j int) bool { return succeeded[i] < succeeded[j] })
+		index := int(math.Ceil(cfg.Percentile*float64(len(succeeded)))) - 1
+		report.PercentileGasPrice = succeeded[max(index, 0)]
+		multiplier = math.Max(multiplier, float64(report.PercentileGasPrice)/float64(rgp))
+	}
+	if report.Cancelled > 0 {
+		multiplier = math.Max(multiplier, float64(report.MaxCancelledGasPrice)/float64(rgp)*(1+congestionCancelledBump))
+	}
+	report.Multiplier = math.Min(multiplier, cfg.MaxMultiplier)
+	report.SuggestedGasPrice = uint64(math.Ceil(float64(rgp) * report.Multiplier))
+	return report, nil
+}
+
+// isCongestionCancellation reports whether an execution error is the
+// cancellation of a transaction whose shared objects were congested.
+func isCongestionCancellation(message string) bool {
+	return strings.Contains(message, "ExecutionCancelledDueToSharedObjectCongestion") ||
+		strings.Contains(strings.ToLower(message), "shared object congestion")
+}
+
+type checkpointGasSample struct {
+	epoch uint64
+	cost  uint64
+}
+
+// averageCheckpointCost averages the computation cost added per checkpoint.
+// Rolling summaries are cumulative within an epoch, so only consecutive
+// checkpoints of the same epoch are differenced.
+func averageCheckpointCost(samples []checkpointGasSample) uint64 {
+	var total, count uint64
+	for i := 1; i < len(samples); i++ {
+		prev, cur := samples[i-1], samples[i]
+		if cur.epoch != prev.epoch || cur.cost < prev.cost {
+			continue
+		}
+		total += cur.cost - prev.cost
+		count++
+	}
+	if count == 0 {
+		return 0
+	}
+	return total / count
+}
@@ -0,0 +1,82 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+func TestSharedObjectCongestion(t *testing.T) {
+	pool := utils.MustParseAddress("0xabc")
+	var filter map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables map[string]any `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		filter, _ = req.Variables["filter"].(map[string]any)
+
+		tx := func(price, status, message string) map[string]any {
+			effects := map[string]any{"status": status}
+			if message != "" {
+				effects["executionError"] = map[string]any{"message": message}
+			}
+			return map[string]any{"gasInput": map[string]any{"gasPrice": price}, "effects": effects}
+		}
+		checkpoint := func(epoch int, cost string) map[string]any {
+			return map[string]any{"rollingGasSummary": map[string]any{"computationCost": cost}, "epoch": map[string]any{"epochId": epoch}}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{
+			"epoch": map[string]any{"referenceGasPrice": "1000"},
+			"transactions": map[string]any{"nodes": []any{
+				tx("1000", "SUCCESS", ""),
+				tx("1500", "SUCCESS", ""),
+				tx("2000", "SUCCESS", ""),
+				tx("3000", "SUCCESS", ""),
+				tx("2500", "FAILURE", "ExecutionCancelledDueToSharedObjectCongestion { congested_objects: [0xabc] }"),
+				tx("9000", "FAILURE", "MoveAbort in 1st command"),
+			}},
+			"checkpoints": map[string]any{"nodes": []any{
+				checkpoint(7, "100"),
+				checkpoint(7, "300"),
+				checkpoint(7, "700"),
+				checkpoint(8, "50"),
+			}},
+		}})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithEndpoint(server.URL), WithRetries(0))
+	report, err := client.SharedObjectCongestion(context.Background(), pool, nil)
+	if err != nil {
+		t.Fatalf("congestion: %v", err)
+	}
+	if filter["inputObject"] != pool.String() {
+		t.Fatalf("unexpected filter %v", filter)
+	}
+	if report.Transactions != 6 || report.Cancelled != 1 || report.MaxCancelledGasPrice != 2500 {
+		t.Fatalf("unexpected counts %+v", report)
+	}
+	if report.PercentileGasPrice != 2000 {
+		t.Fatalf("p75 gas price %d, want 2000", report.PercentileGasPrice)
+	}
+	if report.AverageCheckpointComputationCost != 300 {
+		t.Fatalf("average checkpoint cost %d, want 300", report.AverageCheckpointComputationCost)
+	}
+	// The cancelled 2500 outranks the p75 of 2000, plus a 10% margin.
+	if report.SuggestedGasPrice != 2750 {
+		t.Fatalf("suggested %d (x%v), want 2750", report.SuggestedGasPrice, report.Multiplier)
+	}
+
+	capped, err := client.SharedObjectCongestion(context.Background(), pool, &CongestionOptions{MaxMultiplier: 1.5})
+	if err != nil {
+		t.Fatalf("congestion: %v", err)
+	}
+	if capped.Multiplier != 1.5 || capped.SuggestedGasPrice != 1500 {
+		t.Fatalf("expected capped multiplier, got %+v", capped)
+	}
+}
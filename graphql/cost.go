@@ -0,0 +1,313 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// =============================================================================
+// Query Cost Estimation
+// =============================================================================
+
+// defaultMaxQueryDepth is the maxQueryDepth of the public Sui GraphQL
+// services, assumed when the service config cannot be fetched.
+const defaultMaxQueryDepth = 20
+
+// maxCostSuggestions bounds the suggestions attached to a QueryLimitError.
+const maxCostSuggestions = 3
+
+// QueryCost estimates what a query costs the service, in the units of its
+// serviceConfig limits.
+type QueryCost struct {
+	// PayloadSize is the length of the query text.
+	PayloadSize int
+	// QueryNodes is the number of fields in the query, counted as for
+	// maxQueryNodes.
+	QueryNodes int
+	// Depth is the deepest field nesting, counted as for maxQueryDepth.
+	Depth int
+	// OutputNodes estimates the nodes a full response holds, counting each
+	// connection as a full page of first/last (or 20) items.
+	OutputNodes int
+	// Connections lists the paginated fields, most expensive first.
+	Connections []ConnectionCost
+}
+
+// ConnectionCost is the estimated output of one paginated field.
+type ConnectionCost struct {
+	// Path is the dotted path of the connection field, using aliases where
+	// the query sets them.
+	Path string
+	// PageSize is the page size the estimate assumes.
+	PageSize int
+	// DefaultPageSize reports that the query sets neither first nor last.
+	DefaultPageSize bool
+	// Parent is the path of the enclosing connection, if any.
+	Parent string
+	// OutputNodes is the estimated output of the connection's items,
+	// including nested connections.
+	OutputNodes int
+}
+
+// EstimateQueryCost estimates the cost of query. Page sizes passed as
+// variables are read from variables. For documents with several operations
+// each figure is the largest of any operation.
+func EstimateQueryCost(query string, variables map[string]any) (QueryCost, error) {
+	doc, err := parseDocument(query)
+	if err != nil {
+		return QueryCost{}, err
+	}
+
+	cost := QueryCost{PayloadSize: len(query)}
+	for _, op := range doc.operations {
+		w := costWalker{doc: doc, variables: variables}
+		output := w.walk(op.selections, defaultPageSize, 1, "", "")
+		cost.QueryNodes = max(cost.QueryNodes, w.nodes)
+		cost.Depth = max(cost.Depth, w.depth)
+		cost.OutputNodes = max(cost.OutputNodes, output)
+		cost.Connections = append(cost.Connections, w.connections...)
+	}
+	sort.SliceStable(cost.Connections, func(i, j int) bool {
+		return cost.Connections[i].OutputNodes > cost.Connections[j].OutputNodes
+	})
+	return cost, nil
+}
+
+// EstimateCost estimates the cost of the built query.
+func (qb *QueryBuilder) EstimateCost() (QueryCost, error) {
+	query, vars := qb.Build()
+	return EstimateQueryCost(query, vars)
+}
+
+// Check returns a QueryLimitError, with suggestions for reducing the cost,
+// for the first of limits the query exceeds. Zero limits are not checked.
+func (qc QueryCost) Check(limits ServiceConfig) error {
+	if limits.MaxQueryPayloadSize > 0 && qc.PayloadSize > limits.MaxQueryPayloadSize {
+		return &QueryLimitError{
+			Limit: "maxQueryPayloadSize", Value: qc.PayloadSize, Max: limits.MaxQueryPayloadSize,
+			Suggestions: []string{"pass long literals as variables", "split the query into several requests"},
+		}
+	}
+	if limits.MaxQueryNodes > 0 && qc.QueryNodes > limits.MaxQueryNodes {
+		return &QueryLimitError{
+			Limit: "maxQueryNodes", Value: qc.QueryNodes, Max: limits.MaxQueryNodes,
+			Suggestions: qc.nodeSuggestions(),
+		}
+	}
+	if limits.MaxQueryDepth > 0 && qc.Depth > limits.MaxQueryDepth {
+		return &QueryLimitError{
+			Limit: "maxQueryDepth", Value: qc.Depth, Max: limits.MaxQueryDepth,
+			Suggestions: qc.nodeSuggestions(),
+		}
+	}
+	if limits.MaxOutputNodes > 0 && qc.OutputNodes > limits.MaxOutputNodes {
+		return &QueryLimitError{
+			Limit: "maxOutputNodes", Value: qc.OutputNodes, Max: limits.MaxOutputNodes,
+			Suggestions: qc.outputSuggestions(limits.MaxOutputNodes),
+		}
+	}
+	return nil
+}
+
+// nodeSuggestions suggests ways to shrink the query itself.
+func (qc QueryCost) nodeSuggestions() []string {
+	var suggestions []string
+	for _, conn := range qc.Connections {
+		if conn.Parent != "" {
+			suggestions = append(suggestions, fmt.Sprintf("query %s separately instead of nesting it in %s", conn.Path, conn.Parent))
+		}
+		if len(suggestions) == maxCostSuggestions-1 {
+			break
+		}
+	}
+	return append(suggestions, "select fewer fields or split the query into several requests")
+}
+
+// outputSuggestions suggests page sizes and restructurings that bring the
+// response under limit, starting with the most expensive connections.
+func (qc QueryCost) outputSuggestions(limit int) []string {
+	var suggestions []string
+	for _, conn := range qc.Connections {
+		if len(suggestions) == maxCostSuggestions {
+			break
+		}
+		if conn.Parent != "" {
+			suggestions = append(suggestions, fmt.Sprintf("drop the nested connection %s and page it separately", conn.Path))
+			continue
+		}
+		// The connection's items scale linearly with its page size.
+		excess := qc.OutputNodes - limit
+		perItem := conn.OutputNodes / max(1, conn.PageSize)
+		page := conn.PageSize - (excess+perItem-1)/max(1, perItem)
+		switch {
+		case page >= 1 && conn.DefaultPageSize:
+			suggestions = append(suggestions, fmt.Sprintf("set first: %d on %s (the default page is %d)", page, conn.Path, conn.PageSize))
+		case page >= 1:
+			suggestions = append(suggestions, fmt.Sprintf("reduce first on %s from %d to %d", conn.Path, conn.PageSize, page))
+		}
+	}
+	if len(suggestions) == 0 {
+		suggestions = append(suggestions, "select fewer fields or split the query into several requests")
+	}
+	return suggestions
+}
+
+// WithCostGuards makes the client estimate the cost of every query before
+// sending it and reject queries over any of the service's maxQueryPayloadSize,
+// maxQueryNodes, maxQueryDepth and maxOutputNodes limits with a
+// QueryLimitError that suggests how to reduce the cost. It implies
+// WithSizeGuards.
+func WithCostGuards() ClientOption {
+	return func(c *Client) {
+		c.sizeGuards = true
+		c.costGuards = true
+	}
+}
+
+// CheckQueryCost estimates the cost of query and checks it against the
+// service's limits, fetching the service config on first use.
+func (c *Client) CheckQueryCost(ctx context.Context, query string, variables map[string]any) (QueryCost, error) {
+	cost, err := EstimateQueryCost(query, variables)
+	if err != nil {
+		return QueryCost{}, err
+	}
+	return cost, cost.Check(c.serviceLimits(ctx))
+}
+
+// checkQueryCost is the guard run by Execute. Queries that do not parse
+// are left for the service to report.
+func (c *Client) checkQueryCost(ctx context.Context, query string, variables map[string]any) error {
+	cost, err := EstimateQueryCost(query, variables)
+	if err != nil {
+		return nil
+	}
+	return cost.Check(c.serviceLimits(ctx))
+}
+
+// costWalker accumulates the cost of one operation. Its output estimate
+// matches estimateOutputNodes, with page sizes also read from variables.
+type costWalker struct {
+	doc         *astDocument
+	variables   map[string]any
+	nodes       int
+	depth       int
+	connections []ConnectionCost
+}
+
+func (w *costWalker) walk(sels []astSelection, page, depth int, path, parent string) int {
+	output := 0
+	for _, sel := range sels {
+		switch sel.kind {
+		case selectionField:
+			w.nodes++
+			w.depth = max(w.depth, depth)
+
+			name := sel.name
+			if sel.alias != "" {
+				name = sel.alias
+			}
+			fieldPath := joinPath(path, name)
+			childPage, explicit := w.pageSize(sel.arguments)
+
+			childParent := parent
+			if hasConnectionItems(sel.selections) {
+				childParent = fieldPath
+			}
+			n := 1 + w.walk(sel.selections, childPage, depth+1, fieldPath, childParent)
+			if sel.name == "nodes" || sel.name == "edges" {
+				n *= page
+			}
+			output += n
+
+			if childParent != parent {
+				w.connections = append(w.connections, ConnectionCost{
+					Path:            fieldPath,
+					PageSize:        childPage,
+					DefaultPageSize: !explicit,
+					Parent:          parent,
+					OutputNodes:     n - 1,
+				})
+			}
+		case selectionInlineFragment:
+			output += w.walk(sel.selections, page, depth, path, parent)
+		case selectionFragmentSpread:
+			if frag := w.doc.fragments[sel.name]; frag != nil {
+				output += w.walk(frag.selections, page, depth, path, parent)
+			}
+		}
+	}
+	return output
+}
+
+// pageSize returns the first or last argument and whether one was set.
+func (w *costWalker) pageSize(args []astArgument) (int, bool) {
+	for _, arg := range args {
+		if arg.name != "first" && arg.name != "last" {
+			continue
+		}
+		switch arg.value.kind {
+		case valueInt:
+			if n, err := strconv.Atoi(arg.value.raw); err == nil && n > 0 {
+				return n, true
+			}
+		case valueVariable:
+			if n, ok := intValue(w.variables[arg.value.raw]); ok && n > 0 {
+				return n, true
+			}
+		}
+	}
+	return defaultPageSize, false
+}
+
+// hasConnectionItems reports whether sels select the nodes or edges of a
+// connection.
+func hasConnectionItems(sels []astSelection) bool {
+	for _, sel := range sels {
+		if sel.kind == selectionField && (sel.name == "nodes" || sel.name == "edges") {
+			return true
+		}
+	}
+	return false
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// intValue reads an integer variable value, which may be any Go integer or
+// a float decoded from JSON.
+func intValue(v any) (int, bool) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return 0, false
+		}
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return int(rv.Float()), true
+	case reflect.String:
+		n, err := strconv.Atoi(rv.String())
+		return n, err == nil
+	}
+	return 0, false
+}
+
+func (e *QueryLimitError) suggestionText() string {
+	if len(e.Suggestions) == 0 {
+		return ""
+	}
+	return " (try: " + strings.Join(e.Suggestions, "; ") + ")"
+}
@@ -0,0 +1,113 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func nestedObjectsQuery(first int) *QueryBuilder {
+	qb := NewQueryBuilder()
+	ref := qb.Variable("first", "Int", first)
+	qb.Field("address").Arg("address", "0x1").
+		SubField("objects").ArgVar("first", ref).
+		SubField("nodes").Fields("address").
+		SubField("dynamicFields").Fields("nodes { name { json } }").End().
+		End().
+		End().
+		Done()
+	return qb
+}
+
+func TestQueryBuilderEstimateCost(t *testing.T) {
+	cost, err := nestedObjectsQuery(50).EstimateCost()
+	if err != nil {
+		t.Fatalf("estimate: %v", err)
+	}
+	if cost.QueryNodes != 8 || cost.Depth != 7 {
+		t.Fatalf("unexpected nodes %d depth %d", cost.QueryNodes, cost.Depth)
+	}
+	// 50 objects, each with 20 dynamic fields of 3 nodes.
+	if cost.OutputNodes != 3152 {
+		t.Fatalf("unexpected output nodes %d", cost.OutputNodes)
+	}
+	if len(cost.Connections) != 2 {
+		t.Fatalf("unexpected connections %+v", cost.Connections)
+	}
+	objects, fields := cost.Connections[0], cost.Connections[1]
+	if objects.Path != "address.objects" || objects.PageSize != 50 || objects.DefaultPageSize || objects.Parent != "" {
+		t.Fatalf("unexpected objects connection %+v", objects)
+	}
+	if fields.Path != "address.objects.nodes.dynamicFields" || !fields.DefaultPageSize || fields.Parent != "address.objects" {
+		t.Fatalf("unexpected dynamic fields connection %+v", fields)
+	}
+}
+
+func TestQueryCostCheck(t *testing.T) {
+	cost, err := nestedObjectsQuery(50).EstimateCost()
+	if err != nil {
+		t.Fatalf("estimate: %v", err)
+	}
+	if err := cost.Check(ServiceConfig{MaxOutputNodes: 5000}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	var limitErr *QueryLimitError
+	if err := cost.Check(ServiceConfig{MaxOutputNodes: 1000}); !errors.As(err, &limitErr) || limitErr.Limit != "maxOutputNodes" {
+		t.Fatalf("expected maxOutputNodes error, got %v", err)
+	}
+	want := []string{
+		"reduce first on address.objects from 50 to 15",
+		"drop the nested connection address.objects.nodes.dynamicFields and page it separately",
+	}
+	if strings.Join(limitErr.Suggestions, "|") != strings.Join(want, "|") {
+		t.Fatalf("unexpected suggestions %q", limitErr.Suggestions)
+	}
+	if !strings.Contains(limitErr.Error(), "try: reduce first") {
+		t.Fatalf("suggestions missing from %q", limitErr.Error())
+	}
+
+	// The suggested page size fits.
+	smaller, _ := nestedObjectsQuery(15).EstimateCost()
+	if err := smaller.Check(ServiceConfig{MaxOutputNodes: 1000}); err != nil {
+		t.Fatalf("suggested page size rejected: %v", err)
+	}
+
+	if err := cost.Check(ServiceConfig{MaxQueryDepth: 5}); !errors.As(err, &limitErr) || limitErr.Limit != "maxQueryDepth" {
+		t.Fatalf("expected maxQueryDepth error, got %v", err)
+	}
+}
+
+func TestCostGuards(t *testing.T) {
+	var sent atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req graphqlRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if strings.Contains(req.Query, "serviceConfig") {
+			w.Write([]byte(`{"data":{"serviceConfig":{"maxOutputNodes":1000}}}`))
+			return
+		}
+		sent.Add(1)
+		w.Write([]byte(`{"data":{"address":null}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithEndpoint(server.URL), WithCostGuards())
+	ctx := context.Background()
+
+	var limitErr *QueryLimitError
+	if err := nestedObjectsQuery(50).Execute(ctx, client, nil); !errors.As(err, &limitErr) || limitErr.Limit != "maxOutputNodes" {
+		t.Fatalf("expected maxOutputNodes error, got %v", err)
+	}
+	if err := nestedObjectsQuery(10).Execute(ctx, client, nil); err != nil {
+		t.Fatalf("small query rejected: %v", err)
+	}
+	if n := sent.Load(); n != 1 {
+		t.Fatalf("sent %d queries, want 1", n)
+	}
+}
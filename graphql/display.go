@@ -0,0 +1,203 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/open-move/sui-go-sdk/types"
+)
+
+// =============================================================================
+// Display Rendering
+// =============================================================================
+
+// ErrDisplayNotFound is returned when no Display object exists for a type.
+var ErrDisplayNotFound = errors.New("display not found")
+
+// GetObjectDisplay renders the Sui Display standard for an object: it fetches
+// the object's fields and the Display<T> template for its type, then
+// substitutes every {field} placeholder. Keys whose template cannot be
+// rendered are left out of the map and reported in a MultiError.
+func (c *Client) GetObjectDisplay(ctx context.Context, objectID types.Address) (map[string]string, error) {
+	obj, err := c.GetObject(ctx, objectID, &ObjectDataOptions{ShowContent: true})
+	if err != nil {
+		return nil, err
+	}
+	if obj == nil || obj.AsMoveObject == nil || obj.AsMoveObject.Contents == nil {
+		return nil, fmt.Errorf("object %s is not a Move object", objectID)
+	}
+
+	contents := obj.AsMoveObject.Contents
+	templates, err := c.GetDisplayTemplate(ctx, contents.Type.Repr)
+	if err != nil {
+		return nil, err
+	}
+
+	return RenderDisplay(templates, contents.Json)
+}
+
+// GetDisplayTemplate returns the key/template pairs of the Display<T> object
+// for objectType. When several Display objects exist, the one with the
+// highest display version wins.
+func (c *Client) GetDisplayTemplate(ctx context.Context, objectType string) (map[string]string, error) {
+	query := `
+		query GetDisplayTemplate($filter: ObjectFilter!) {
+			objects(filter: $filter, first: 50) {
+				nodes {
+					asMoveObject {
+						contents { json }
+					}
+				}
+			}
+		}
+	`
+
+	displayType := fmt.Sprintf("0x2::display::Display<%s>", objectType)
+	var result struct {
+		Objects *Connection[Object] `json:"objects"`
+	}
+	err := c.Execute(ctx, query, map[string]any{"filter": ObjectFilter{Type: &displayType}}, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	var best *displayObject
+	if result.Objects != nil {
+		for _, node := range result.Objects.Nodes {
+			if node.AsMoveObject == nil || node.AsMoveObject.Contents == nil {
+				continue
+			}
+			var display displayObject
+			if err := json.Unmarshal(node.AsMoveObject.Contents.Json, &display); err != nil {
+				return nil, fmt.Errorf("decode display object: %w", err)
+			}
+			if best == nil || display.Version > best.Version {
+				best = &display
+			}
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("%w: %s", ErrDisplayNotFound, objectType)
+	}
+
+	templates := make(map[string]string, len(best.Fields.Contents))
+	for _, entry := range best.Fields.Contents {
+		templates[entry.Key] = entry.Value
+	}
+	return templates, nil
+}
+
+// displayObject is the JSON form of a 0x2::display::Display<T> object.
+type displayObject struct {
+	Fields struct {
+		Contents []struct {
+			Key   string `json:"key"`
+			Value string `json:"value"`
+		} `json:"contents"`
+	} `json:"fields"`
+	Version UInt53 `json:"version"`
+}
+
+// RenderDisplay substitutes {field} placeholders in each template with values
+// from an object's JSON contents. Nested fields use dots ({meta.image_url}),
+// and \{ or \} produce literal braces.
+func RenderDisplay(templates map[string]string, fields json.RawMessage) (map[string]string, error) {
+	var values map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(fields))
+	decoder.UseNumber()
+	if err := decoder.Decode(&values); err != nil {
+		return nil, fmt.Errorf("decode object fields: %w", err)
+	}
+
+	keys := make([]string, 0, len(templates))
+	for key := range templates {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	rendered := make(map[string]string, len(templates))
+	var errs MultiError
+	for _, key := range keys {
+		value, err := renderTemplate(templates[key], values)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("display %q: %w", key, err))
+			continue
+		}
+		rendered[key] = value
+	}
+
+	if len(errs) > 0 {
+		return rendered, errs
+	}
+	return rendered, nil
+}
+
+func renderTemplate(template string, values map[string]any) (string, error) {
+	var out strings.Builder
+	for i := 0; i < len(template); i++ {
+		c := template[i]
+		switch c {
+		case '\\':
+			if i+1 < len(template) && (template[i+1] == '{' || template[i+1] == '}') {
+				out.WriteByte(template[i+1])
+				i++
+				continue
+			}
+			out.WriteByte(c)
+		case '{':
+			end := strings.IndexByte(template[i+1:], '}')
+			if end < 0 {
+				return "", fmt.Errorf("unterminated placeholder at offset %d", i)
+			}
+			path := strings.TrimSpace(template[i+1 : i+1+end])
+			value, err := lookupDisplayField(values, path)
+			if err != nil {
+				return "", err
+			}
+			out.WriteString(value)
+			i += end + 1
+		default:
+			out.WriteByte(c)
+		}
+	}
+	return out.String(), nil
+}
+
+func lookupDisplayField(values map[string]any, path string) (string, error) {
+	if path == "" {
+		return "", errors.New("empty placeholder")
+	}
+
+	var current any = values
+	for _, part := range strings.Split(path, ".") {
+		fields, ok := current.(map[string]any)
+		if !ok {
+			return "", fmt.Errorf("field %q not found", path)
+		}
+		if current, ok = fields[part]; !ok {
+			return "", fmt.Errorf("field %q not found", path)
+		}
+	}
+
+	switch v := current.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return fmt.Sprint(v), nil
+	case nil:
+		return "", nil
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return "", fmt.Errorf("field %q: %w", path, err)
+		}
+		return string(encoded), nil
+	}
+}
@@ -0,0 +1,91 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+func TestRenderDisplay(t *testing.T) {
+	fields := json.RawMessage(`{"name":"Capy #7","level":12,"meta":{"image":"ipfs://cid"},"id":"0x7"}`)
+	templates := map[string]string{
+		"name":        "{name}",
+		"image_url":   "https://img.example/{meta.image}",
+		"description": "Level {level} \\{not a field\\}",
+		"link":        "https://example.com/{missing}",
+	}
+
+	rendered, err := RenderDisplay(templates, fields)
+
+	var multiErr MultiError
+	if !errors.As(err, &multiErr) || len(multiErr) != 1 || !strings.Contains(multiErr[0].Error(), `"missing"`) {
+		t.Fatalf("expected one missing field error, got %v", err)
+	}
+	want := map[string]string{
+		"name":        "Capy #7",
+		"image_url":   "https://img.example/ipfs://cid",
+		"description": "Level 12 {not a field}",
+	}
+	if len(rendered) != len(want) {
+		t.Fatalf("unexpected rendered display %v", rendered)
+	}
+	for k, v := range want {
+		if rendered[k] != v {
+			t.Fatalf("%s: got %q, want %q", k, rendered[k], v)
+		}
+	}
+}
+
+func TestGetObjectDisplay(t *testing.T) {
+	objectID := utils.MustParseAddress("0x7")
+	var displayType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query     string         `json:"query"`
+			Variables map[string]any `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		var data any
+		switch {
+		case strings.Contains(req.Query, "GetDisplayTemplate"):
+			displayType = req.Variables["filter"].(map[string]any)["type"].(string)
+			display := func(version int, name string) map[string]any {
+				return map[string]any{"asMoveObject": map[string]any{"contents": map[string]any{"json": map[string]any{
+					"version": version,
+					"fields":  map[string]any{"contents": []map[string]any{{"key": "name", "value": name}}},
+				}}}}
+			}
+			data = map[string]any{"objects": map[string]any{"nodes": []any{display(1, "old {name}"), display(2, "NFT: {name}")}}}
+		default:
+			data = map[string]any{"object": map[string]any{
+				"address": objectID.String(),
+				"version": 3,
+				"asMoveObject": map[string]any{"contents": map[string]any{
+					"type": map[string]any{"repr": "0xabc::nft::NFT"},
+					"json": map[string]any{"name": "Capy"},
+				}},
+			}}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithEndpoint(server.URL))
+	rendered, err := client.GetObjectDisplay(context.Background(), objectID)
+	if err != nil {
+		t.Fatalf("get object display: %v", err)
+	}
+	if rendered["name"] != "NFT: Capy" {
+		t.Fatalf("expected latest display version to be used, got %v", rendered)
+	}
+	if displayType != "0x2::display::Display<0xabc::nft::NFT>" {
+		t.Fatalf("unexpected display type filter %s", displayType)
+	}
+}
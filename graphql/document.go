@@ -0,0 +1,768 @@
+package graphql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// =============================================================================
+// Executable Document Parsing
+// =============================================================================
+
+// This is a small parser for GraphQL executable documents (operations and
+// fragments). It keeps just enough structure to validate a query against an
+// introspected schema and does not evaluate directives.
+
+// sourcePos is a 1-based line and column in a query string.
+type sourcePos struct {
+	line   int
+	column int
+}
+
+type astDocument struct {
+	operations []*astOperation
+	fragments  map[string]*astFragment
+}
+
+type astOperation struct {
+	kind       string // "query", "mutation" or "subscription"
+	name       string
+	variables  []astVariableDef
+	selections []astSelection
+	pos        sourcePos
+	start, end int // byte offsets of the operation in the source
+}
+
+type astVariableDef struct {
+	name       string
+	typ        *astType
+	hasDefault bool
+	pos        sourcePos
+}
+
+// astType is a type reference written in a query, e.g. [SuiAddress!]!.
+type astType struct {
+	name    string
+	list    *astType
+	nonNull bool
+}
+
+func (t *astType) String() string {
+	var s string
+	if t.list != nil {
+		s = "[" + t.list.String() + "]"
+	} else {
+		s = t.name
+	}
+	if t.nonNull {
+		s += "!"
+	}
+	return s
+}
+
+func (t *astType) namedType() string {
+	for t.list != nil {
+		t = t.list
+	}
+	return t.name
+}
+
+type astFragment struct {
+	name          string
+	typeCondition string
+	selections    []astSelection
+	pos           sourcePos
+	start, end    int // byte offsets of the fragment in the source
+}
+
+type selectionKind int
+
+const (
+	selectionField selectionKind = iota
+	selectionFragmentSpread
+	selectionInlineFragment
+)
+
+type astSelection struct {
+	kind          selectionKind
+	alias         string
+	name          string // field name or spread fragment name
+	arguments     []astArgument
+	typeCondition string // inline fragments only; may be empty
+	selections    []astSelection
+	pos           sourcePos
+}
+
+type astArgument struct {
+	name  string
+	value astValue
+	pos   sourcePos
+}
+
+type valueKind int
+
+const (
+	valueVariable valueKind = iota
+	valueInt
+	valueFloat
+	valueString
+	valueBoolean
+	valueNull
+	valueEnum
+	valueList
+	valueObject
+)
+
+type astValue struct {
+	kind   valueKind
+	raw    string
+	list   []astValue
+	fields []astObjectField
+	pos    sourcePos
+}
+
+type astObjectField struct {
+	name  string
+	value astValue
+	pos   sourcePos
+}
+
+// parseDocument parses a GraphQL executable document.
+func parseDocument(source string) (*astDocument, error) {
+	p := &docParser{lexer: newLexer(source)}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	doc := &astDocument{fragments: make(map[string]*astFragment)}
+	for p.tok.kind != tokenEOF {
+		switch {
+		case p.tok.is(tokenPunct, "{"):
+			pos, start := p.tok.pos, p.tok.offset
+			sels, err := p.parseSelectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, &astOperation{kind: "query", selections: sels, pos: pos, start: start, end: p.prevEnd})
+		case p.tok.kind == tokenName && (p.tok.value == "query" || p.tok.value == "mutation" || p.tok.value == "subscription"):
+			op, err := p.parseOperation()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, op)
+		case p.tok.is(tokenName, "fragment"):
+			frag, err := p.parseFragment()
+			if err != nil {
+				return nil, err
+			}
+			if _, dup := doc.fragments[frag.name]; dup {
+				return nil, syntaxError(frag.pos, fmt.Sprintf("fragment %q is defined more than once", frag.name))
+			}
+			doc.fragments[frag.name] = frag
+		default:
+			return nil, p.unexpected()
+		}
+	}
+
+	if len(doc.operations) == 0 {
+		return nil, syntaxError(sourcePos{1, 1}, "document contains no operation")
+	}
+	return doc, nil
+}
+
+type docParser struct {
+	lexer   *lexer
+	tok     token
+	prevEnd int // byte offset just past the previous token
+}
+
+func (p *docParser) advance() error {
+	p.prevEnd = p.lexer.offset
+	tok, err := p.lexer.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *docParser) unexpected() error {
+	if p.tok.kind == tokenEOF {
+		return syntaxError(p.tok.pos, "unexpected end of document")
+	}
+	return syntaxError(p.tok.pos, fmt.Sprintf("unexpected %q", p.tok.value))
+}
+
+func (p *docParser) expectPunct(value string) error {
+	if !p.tok.is(tokenPunct, value) {
+		return syntaxError(p.tok.pos, fmt.Sprintf("expected %q, found %q", value, p.tok.value))
+	}
+	return p.advance()
+}
+
+func (p *docParser) expectName() (string, sourcePos, error) {
+	if p.tok.kind != tokenName {
+		return "", p.tok.pos, syntaxError(p.tok.pos, fmt.Sprintf("expected name, found %q", p.tok.value))
+	}
+	name, pos := p.tok.value, p.tok.pos
+	return name, pos, p.advance()
+}
+
+func (p *docParser) parseOperation() (*astOperation, error) {
+	op := &astOperation{kind: p.tok.value, pos: p.tok.pos, start: p.tok.offset}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	if p.tok.kind == tokenName {
+		op.name = p.tok.value
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.tok.is(tokenPunct, "(") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		for !p.tok.is(tokenPunct, ")") {
+			def, err := p.parseVariableDef()
+			if err != nil {
+				return nil, err
+			}
+			op.variables = append(op.variables, def)
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := p.skipDirectives(); err != nil {
+		return nil, err
+	}
+
+	sels, err := p.parseSelectionSet()
+	if err != nil {
+		return nil, err
+	}
+	op.selections = sels
+	op.end = p.prevEnd
+	return op, nil
+}
+
+func (p *docParser) parseVariableDef() (astVariableDef, error) {
+	pos := p.tok.pos
+	if err := p.expectPunct("$"); err != nil {
+		return astVariableDef{}, err
+	}
+	name, _, err := p.expectName()
+	if err != nil {
+		return astVariableDef{}, err
+	}
+	if err := p.expectPunct(":"); err != nil {
+		return astVariableDef{}, err
+	}
+	typ, err := p.parseType()
+	if err != nil {
+		return astVariableDef{}, err
+	}
+
+	def := astVariableDef{name: name, typ: typ, pos: pos}
+	if p.tok.is(tokenPunct, "=") {
+		if err := p.advance(); err != nil {
+			return astVariableDef{}, err
+		}
+		if _, err := p.parseValue(); err != nil {
+			return astVariableDef{}, err
+		}
+		def.hasDefault = true
+	}
+	if err := p.skipDirectives(); err != nil {
+		return astVariableDef{}, err
+	}
+	return def, nil
+}
+
+func (p *docParser) parseType() (*astType, error) {
+	var typ *astType
+	if p.tok.is(tokenPunct, "[") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		inner, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct("]"); err != nil {
+			return nil, err
+		}
+		typ = &astType{list: inner}
+	} else {
+		name, _, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		typ = &astType{name: name}
+	}
+
+	if p.tok.is(tokenPunct, "!") {
+		typ.nonNull = true
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	return typ, nil
+}
+
+func (p *docParser) parseFragment() (*astFragment, error) {
+	pos, start := p.tok.pos, p.tok.offset
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	name, _, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+	if !p.tok.is(tokenName, "on") {
+		return nil, syntaxError(p.tok.pos, fmt.Sprintf("expected \"on\", found %q", p.tok.value))
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	typeCondition, _, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.skipDirectives(); err != nil {
+		return nil, err
+	}
+	sels, err := p.parseSelectionSet()
+	if err != nil {
+		return nil, err
+	}
+	return &astFragment{name: name, typeCondition: typeCondition, selections: sels, pos: pos, start: start, end: p.prevEnd}, nil
+}
+
+func (p *docParser) parseSelectionSet() ([]astSelection, error) {
+	if err := p.expectPunct("{"); err != nil {
+		return nil, err
+	}
+
+	var sels []astSelection
+	for !p.tok.is(tokenPunct, "}") {
+		sel, err := p.parseSelection()
+		if err != nil {
+			return nil, err
+		}
+		sels = append(sels, sel)
+	}
+	if len(sels) == 0 {
+		return nil, syntaxError(p.tok.pos, "selection set must not be empty")
+	}
+	return sels, p.advance()
+}
+
+func (p *docParser) parseSelection() (astSelection, error) {
+	pos := p.tok.pos
+	if p.tok.is(tokenPunct, "...") {
+		if err := p.advance(); err != nil {
+			return astSelection{}, err
+		}
+
+		if p.tok.kind == tokenName && p.tok.value != "on" {
+			name := p.tok.value
+			if err := p.advance(); err != nil {
+				return astSelection{}, err
+			}
+			if err := p.skipDirectives(); err != nil {
+				return astSelection{}, err
+			}
+			return astSelection{kind: selectionFragmentSpread, name: name, pos: pos}, nil
+		}
+
+		sel := astSelection{kind: selectionInlineFragment, pos: pos}
+		if p.tok.is(tokenName, "on") {
+			if err := p.advance(); err != nil {
+				return astSelection{}, err
+			}
+			typeCondition, _, err := p.expectName()
+			if err != nil {
+				return astSelection{}, err
+			}
+			sel.typeCondition = typeCondition
+		}
+		if err := p.skipDirectives(); err != nil {
+			return astSelection{}, err
+		}
+		sels, err := p.parseSelectionSet()
+		if err != nil {
+			return astSelection{}, err
+		}
+		sel.selections = sels
+		return sel, nil
+	}
+
+	name, _, err := p.expectName()
+	if err != nil {
+		return astSelection{}, err
+	}
+	sel := astSelection{kind: selectionField, name: name, pos: pos}
+	if p.tok.is(tokenPunct, ":") {
+		if err := p.advance(); err != nil {
+			return astSelection{}, err
+		}
+		sel.alias = name
+		if sel.name, _, err = p.expectName(); err != nil {
+			return astSelection{}, err
+		}
+	}
+
+	if p.tok.is(tokenPunct, "(") {
+		args, err := p.parseArguments()
+		if err != nil {
+			return astSelection{}, err
+		}
+		sel.arguments = args
+	}
+	if err := p.skipDirectives(); err != nil {
+		return astSelection{}, err
+	}
+	if p.tok.is(tokenPunct, "{") {
+		sels, err := p.parseSelectionSet()
+		if err != nil {
+			return astSelection{}, err
+		}
+		sel.selections = sels
+	}
+	return sel, nil
+}
+
+func (p *docParser) parseArguments() ([]astArgument, error) {
+	if err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+
+	var args []astArgument
+	for !p.tok.is(tokenPunct, ")") {
+		name, pos, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct(":"); err != nil {
+			return nil, err
+		}
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, astArgument{name: name, value: value, pos: pos})
+	}
+	return args, p.advance()
+}
+
+// skipDirectives consumes directives; they do not affect validation here.
+func (p *docParser) skipDirectives() error {
+	for p.tok.is(tokenPunct, "@") {
+		if err := p.advance(); err != nil {
+			return err
+		}
+		if _, _, err := p.expectName(); err != nil {
+			return err
+		}
+		if p.tok.is(tokenPunct, "(") {
+			if _, err := p.parseArguments(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (p *docParser) parseValue() (astValue, error) {
+	tok := p.tok
+	value := astValue{raw: tok.value, pos: tok.pos}
+
+	switch {
+	case tok.is(tokenPunct, "$"):
+		if err := p.advance(); err != nil {
+			return astValue{}, err
+		}
+		name, _, err := p.expectName()
+		if err != nil {
+			return astValue{}, err
+		}
+		value.kind = valueVariable
+		value.raw = name
+		return value, nil
+	case tok.is(tokenPunct, "["):
+		if err := p.advance(); err != nil {
+			return astValue{}, err
+		}
+		value.kind = valueList
+		for !p.tok.is(tokenPunct, "]") {
+			item, err := p.parseValue()
+			if err != nil {
+				return astValue{}, err
+			}
+			value.list = append(value.list, item)
+		}
+		return value, p.advance()
+	case tok.is(tokenPunct, "{"):
+		if err := p.advance(); err != nil {
+			return astValue{}, err
+		}
+		value.kind = valueObject
+		for !p.tok.is(tokenPunct, "}") {
+			name, pos, err := p.expectName()
+			if err != nil {
+				return astValue{}, err
+			}
+			if err := p.expectPunct(":"); err != nil {
+				return astValue{}, err
+			}
+			fieldValue, err := p.parseValue()
+			if err != nil {
+				return astValue{}, err
+			}
+			value.fields = append(value.fields, astObjectField{name: name, value: fieldValue, pos: pos})
+		}
+		return value, p.advance()
+	case tok.kind == tokenInt:
+		value.kind = valueInt
+	case tok.kind == tokenFloat:
+		value.kind = valueFloat
+	case tok.kind == tokenString:
+		value.kind = valueString
+	case tok.kind == tokenName:
+		switch tok.value {
+		case "true", "false":
+			value.kind = valueBoolean
+		case "null":
+			value.kind = valueNull
+		default:
+			value.kind = valueEnum
+		}
+	default:
+		return astValue{}, p.unexpected()
+	}
+	return value, p.advance()
+}
+
+// =============================================================================
+// Lexer
+// =============================================================================
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunct
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+type token struct {
+	kind   tokenKind
+	value  string
+	pos    sourcePos
+	offset int
+}
+
+func (t token) is(kind tokenKind, value string) bool {
+	return t.kind == kind && t.value == value
+}
+
+type lexer struct {
+	src    string
+	offset int
+	line   int
+	column int
+}
+
+func newLexer(src string) *lexer {
+	return &lexer{src: strings.TrimPrefix(src, "\ufeff"), line: 1, column: 1}
+}
+
+func (l *lexer) pos() sourcePos {
+	return sourcePos{line: l.line, column: l.column}
+}
+
+func (l *lexer) peek(ahead int) byte {
+	if l.offset+ahead >= len(l.src) {
+		return 0
+	}
+	return l.src[l.offset+ahead]
+}
+
+func (l *lexer) bump() byte {
+	c := l.src[l.offset]
+	l.offset++
+	if c == '\n' {
+		l.line++
+		l.column = 1
+	} else {
+		l.column++
+	}
+	return c
+}
+
+func (l *lexer) skipIgnored() {
+	for l.offset < len(l.src) {
+		switch c := l.peek(0); c {
+		case ' ', '\t', '\n', '\r', ',':
+			l.bump()
+		case '#':
+			for l.offset < len(l.src) && l.peek(0) != '\n' {
+				l.bump()
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (l *lexer) next() (token, error) {
+	l.skipIgnored()
+	start := l.offset
+	tok, err := l.scan()
+	tok.offset = start
+	return tok, err
+}
+
+func (l *lexer) scan() (token, error) {
+	pos := l.pos()
+	if l.offset >= len(l.src) {
+		return token{kind: tokenEOF, pos: pos}, nil
+	}
+
+	c := l.peek(0)
+	switch {
+	case c == '.':
+		if l.peek(1) == '.' && l.peek(2) == '.' {
+			l.bump()
+			l.bump()
+			l.bump()
+			return token{kind: tokenPunct, value: "...", pos: pos}, nil
+		}
+		return token{}, syntaxError(pos, "unexpected \".\"")
+	case strings.IndexByte("!$&()/:=@[]{|}", c) >= 0:
+		l.bump()
+		return token{kind: tokenPunct, value: string(c), pos: pos}, nil
+	case isNameStart(c):
+		start := l.offset
+		for l.offset < len(l.src) && isNameContinue(l.peek(0)) {
+			l.bump()
+		}
+		return token{kind: tokenName, value: l.src[start:l.offset], pos: pos}, nil
+	case c == '-' || isDigit(c):
+		return l.readNumber(pos)
+	case c == '"':
+		return l.readString(pos)
+	default:
+		return token{}, syntaxError(pos, fmt.Sprintf("unexpected character %q", c))
+	}
+}
+
+func (l *lexer) readNumber(pos sourcePos) (token, error) {
+	start := l.offset
+	kind := tokenInt
+	if l.peek(0) == '-' {
+		l.bump()
+	}
+	if !isDigit(l.peek(0)) {
+		return token{}, syntaxError(pos, "invalid number")
+	}
+	for isDigit(l.peek(0)) {
+		l.bump()
+	}
+	if l.peek(0) == '.' {
+		kind = tokenFloat
+		l.bump()
+		if !isDigit(l.peek(0)) {
+			return token{}, syntaxError(pos, "invalid number")
+		}
+		for isDigit(l.peek(0)) {
+			l.bump()
+		}
+	}
+	if c := l.peek(0); c == 'e' || c == 'E' {
+		kind = tokenFloat
+		l.bump()
+		if c := l.peek(0); c == '+' || c == '-' {
+			l.bump()
+		}
+		if !isDigit(l.peek(0)) {
+			return token{}, syntaxError(pos, "invalid number")
+		}
+		for isDigit(l.peek(0)) {
+			l.bump()
+		}
+	}
+	return token{kind: kind, value: l.src[start:l.offset], pos: pos}, nil
+}
+
+func (l *lexer) readString(pos sourcePos) (token, error) {
+	if strings.HasPrefix(l.src[l.offset:], `"""`) {
+		l.bump()
+		l.bump()
+		l.bump()
+		start := l.offset
+		for l.offset < len(l.src) {
+			if strings.HasPrefix(l.src[l.offset:], `\"""`) {
+				l.bump()
+				l.bump()
+				l.bump()
+				l.bump()
+				continue
+			}
+			if strings.HasPrefix(l.src[l.offset:], `"""`) {
+				value := l.src[start:l.offset]
+				l.bump()
+				l.bump()
+				l.bump()
+				return token{kind: tokenString, value: value, pos: pos}, nil
+			}
+			l.bump()
+		}
+		return token{}, syntaxError(pos, "unterminated block string")
+	}
+
+	l.bump()
+	start := l.offset
+	for l.offset < len(l.src) {
+		switch l.peek(0) {
+		case '\\':
+			l.bump()
+			if l.offset < len(l.src) {
+				l.bump()
+			}
+		case '\n':
+			return token{}, syntaxError(pos, "unterminated string")
+		case '"':
+			value := l.src[start:l.offset]
+			l.bump()
+			return token{kind: tokenString, value: value, pos: pos}, nil
+		default:
+			l.bump()
+		}
+	}
+	return token{}, syntaxError(pos, "unterminated string")
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isNameContinue(c byte) bool {
+	return isNameStart(c) || isDigit(c)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func syntaxError(pos sourcePos, message string) error {
+	return QueryValidationErrors{{Message: "syntax error: " + message, Line: pos.line, Column: pos.column}}
+}
@@ -0,0 +1,507 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// =============================================================================
+// Cursor Stores
+// =============================================================================
+
+// CursorStore persists the position of an EventPoller so processing can resume
+// after a restart. Load returns nil when no cursor has been saved yet.
+type CursorStore interface {
+	Load(ctx context.Context) (*string, error)
+	Save(ctx context.Context, cursor string) error
+}
+
+// MemoryCursorStore keeps the cursor in memory. It is useful for tests and
+// short-lived processes that do not need to resume.
+type MemoryCursorStore struct {
+	mu     sync.Mutex
+	cursor *string
+}
+
+// NewMemoryCursorStore returns an in-memory cursor store, optionally seeded with a cursor.
+func NewMemoryCursorStore(initial string) *MemoryCursorStore {
+	store := &MemoryCursorStore{}
+	if initial != "" {
+		store.cursor = &initial
+	}
+	return store
+}
+
+// Load returns the current cursor.
+func (s *MemoryCursorStore) Load(_ context.Context) (*string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cursor == nil {
+		return nil, nil
+	}
+	cursor := *s.cursor
+	return &cursor, nil
+}
+
+// Save records the cursor.
+func (s *MemoryCursorStore) Save(_ context.Context, cursor string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.cursor = &cursor
+	return nil
+}
+
+// FileCursorStore persists the cursor to a file, replacing it atomically on each save.
+type FileCursorStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileCursorStore returns a cursor store backed by the file at path.
+func NewFileCursorStore(path string) *FileCursorStore {
+	return &FileCursorStore{path: path}
+}
+
+// Load reads the cursor from disk. A missing or empty file yields a nil cursor.
+func (s *FileCursorStore) Load(_ context.Context) (*string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read cursor: %w", err)
+	}
+
+	cursor := strings.TrimSpace(string(data))
+	if cursor == "" {
+		return nil, nil
+	}
+	return &cursor, nil
+}
+
+// Save writes the cursor to a temporary file and renames it into place.
+func (s *FileCursorStore) Save(_ context.Context, cursor string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("create cursor directory: %w", err)
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(cursor), 0o644); err != nil {
+		return fmt.Errorf("write cursor: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("replace cursor: %w", err)
+	}
+	return nil
+}
+
+// =============================================================================
+// Event Poller
+// =============================================================================
+
+const (
+	defaultPollInterval = 2 * time.Second
+	defaultPollPageSize = 50
+	defaultDedupWindow  = 10_000
+)
+
+// EventHandler processes a single event. Returning an error stops the current
+// poll without advancing the cursor, so the event is redelivered later.
+type EventHandler func(ctx context.Context, event Event) error
+
+// EventPollerOption customises an EventPoller.
+type EventPollerOption func(*EventPoller)
+
+// WithPollInterval sets the delay between polls once the poller has caught up.
+func WithPollInterval(interval time.Duration) EventPollerOption {
+	return func(p *EventPoller) {
+		if interval > 0 {
+			p.interval = interval
+		}
+	}
+}
+
+// WithPollPageSize sets the number of events requested per page.
+func WithPollPageSize(size int) EventPollerOption {
+	return func(p *EventPoller) {
+		if size > 0 {
+			p.pageSize = size
+		}
+	}
+}
+
+// WithDedupWindow sets how many recently delivered event keys are remembered.
+func WithDedupWindow(size int) EventPollerOption {
+	return func(p *EventPoller) {
+		if size > 0 {
+			p.dedupWindow = size
+		}
+	}
+}
+
+// WithEventTypePattern delivers only events whose type matches pattern,
+// e.g. one generic instantiation that the server filter cannot select on its
+// own. Other events are skipped, but the cursor still moves past them.
+func WithEventTypePattern(pattern *EventTypePattern) EventPollerOption {
+	return func(p *EventPoller) {
+		p.pattern = pattern
+	}
+}
+
+// WithCheckpointCursor makes the poller store the sequence number of the
+// last fully processed checkpoint instead of the service's endCursor. Each
+// poll reads events from overlap checkpoints before the stored one up to the
+// latest checkpoint the service reports, so events that a lagging or newly
+// rotated provider indexed late are still delivered; events seen within the
+// dedup window are not delivered twice. The stored checkpoint never moves
+// backwards, even when the service is behind it.
+//
+// The store must hold a checkpoint sequence number, or nothing; a store
+// written by a poller without this option cannot be reused.
+func WithCheckpointCursor(overlap uint64) EventPollerOption {
+	return func(p *EventPoller) {
+		p.checkpoints = true
+		p.overlap = overlap
+	}
+}
+
+// WithPollErrorHandler makes Run report poll errors to fn and keep polling
+// instead of returning the first error.
+func WithPollErrorHandler(fn func(error)) EventPollerOption {
+	return func(p *EventPoller) {
+		p.onError = fn
+	}
+}
+
+// EventPoller delivers events matching a filter to a handler with at-least-once
+// semantics. The cursor is saved after each handled event, and events already
+// delivered (keyed by transaction digest and event sequence number) are skipped
+// when a page is replayed.
+type EventPoller struct {
+	client  *Client
+	filter  *EventFilter
+	handler EventHandler
+	store   CursorStore
+
+	interval    time.Duration
+	pageSize    int
+	dedupWindow int
+	onError     func(error)
+	pattern     *EventTypePattern
+	checkpoints bool
+	overlap     uint64
+
+	seen      map[eventKey]struct{}
+	seenOrder []eventKey
+}
+
+type eventKey struct {
+	digest   string
+	sequence uint64
+}
+
+// NewEventPoller creates a poller. A nil store keeps the cursor in memory.
+func NewEventPoller(c *Client, filter *EventFilter, handler EventHandler, store CursorStore, opts ...EventPollerOption) (*EventPoller, error) {
+	if c == nil {
+		return nil, errors.New("nil client")
+	}
+	if handler == nil {
+		return nil, errors.New("nil event handler")
+	}
+	if store == nil {
+		store = NewMemoryCursorStore("")
+	}
+
+	p := &EventPoller{
+		client:      c,
+		filter:      filter,
+		handler:     handler,
+		store:       store,
+		interval:    defaultPollInterval,
+		pageSize:    defaultPollPageSize,
+		dedupWindow: defaultDedupWindow,
+		seen:        make(map[eventKey]struct{}),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(p)
+		}
+	}
+	return p, nil
+}
+
+// Run polls until ctx is cancelled, returning ctx.Err(). Poll errors are
+// returned immediately unless WithPollErrorHandler is set.
+func (p *EventPoller) Run(ctx context.Context) error {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := p.PollOnce(ctx); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if p.onError == nil {
+				return err
+			}
+			p.onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// PollOnce fetches pages from the stored cursor until caught up and returns the
+// number of events delivered to the handler.
+func (p *EventPoller) PollOnce(ctx context.Context) (int, error) {
+	if p.checkpoints {
+		return p.pollCheckpoints(ctx)
+	}
+
+	cursor, err := p.store.Load(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("load cursor: %w", err)
+	}
+
+	delivered := 0
+	for {
+		page, err := p.fetch(ctx, p.filter, cursor)
+		if err != nil {
+			return delivered, err
+		}
+		if page == nil {
+			return delivered, nil
+		}
+
+		for _, edge := range page.Edges {
+			ok, err := p.deliver(ctx, edge.Node)
+			if err != nil {
+				return delivered, err
+			}
+			if ok {
+				delivered++
+			}
+
+			if err := p.store.Save(ctx, edge.Cursor); err != nil {
+				return delivered, fmt.Errorf("save cursor: %w", err)
+			}
+			next := edge.Cursor
+			cursor = &next
+		}
+
+		if !page.PageInfo.HasNextPage || len(page.Edges) == 0 {
+			return delivered, nil
+		}
+	}
+}
+
+// pollCheckpoints is PollOnce for WithCheckpointCursor. Events arrive in
+// checkpoint order, so once an event from checkpoint c is seen every
+// checkpoint before c has been processed.
+func (p *EventPoller) pollCheckpoints(ctx context.Context) (int, error) {
+	stored, err := p.store.Load(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("load cursor: %w", err)
+	}
+	var done *uint64
+	if stored != nil {
+		sequence, err := strconv.ParseUint(*stored, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("cursor %q is not a checkpoint sequence number", *stored)
+		}
+		done = &sequence
+	}
+
+	latest, err := p.latestCheckpoint(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	filter := EventFilter{}
+	if p.filter != nil {
+		filter = *p.filter
+	}
+	if done != nil && *done >= p.overlap {
+		after := UInt53(*done - p.overlap)
+		filter.AfterCheckpoint = &after
+	}
+	before := UInt53(latest + 1)
+	filter.BeforeCheckpoint = &before
+
+	advance := func(sequence uint64) error {
+		if done != nil && sequence <= *done {
+			return nil
+		}
+		if err := p.store.Save(ctx, strconv.FormatUint(sequence, 10)); err != nil {
+			return fmt.Errorf("save cursor: %w", err)
+		}
+		done = &sequence
+		return nil
+	}
+
+	delivered := 0
+	var cursor *string
+	for {
+		page, err := p.fetch(ctx, &filter, cursor)
+		if err != nil {
+			return delivered, err
+		}
+		if page == nil {
+			break
+		}
+
+		for _, edge := range page.Edges {
+			if checkpoint, ok := edge.Node.Checkpoint(); ok && checkpoint > 0 {
+				if err := advance(checkpoint - 1); err != nil {
+					return delivered, err
+				}
+			}
+			ok, err := p.deliver(ctx, edge.Node)
+			if err != nil {
+				return delivered, err
+			}
+			if ok {
+				delivered++
+			}
+			next := edge.Cursor
+			cursor = &next
+		}
+
+		if !page.PageInfo.HasNextPage || len(page.Edges) == 0 {
+			break
+		}
+	}
+	return delivered, advance(latest)
+}
+
+// deliver hands event to the handler unless it does not match the pattern or
+// was already delivered, and reports whether it was handed over.
+func (p *EventPoller) deliver(ctx context.Context, event Event) (bool, error) {
+	if p.pattern != nil && !p.pattern.MatchEvent(event) {
+		return false, nil
+	}
+	key, ok := eventKeyOf(event)
+	if ok && p.markSeen(key, false) {
+		return false, nil
+	}
+	if err := p.handler(ctx, event); err != nil {
+		return false, err
+	}
+	if ok {
+		p.markSeen(key, true)
+	}
+	return true, nil
+}
+
+// latestCheckpoint returns the sequence number of the latest checkpoint the
+// service has indexed.
+func (p *EventPoller) latestCheckpoint(ctx context.Context) (uint64, error) {
+	var result struct {
+		Checkpoint *struct {
+			SequenceNumber UInt53 `json:"sequenceNumber"`
+		} `json:"checkpoint"`
+	}
+	if err := p.client.Execute(ctx, `query PollCheckpoint { checkpoint { sequenceNumber } }`, nil, &result); err != nil {
+		return 0, fmt.Errorf("latest checkpoint: %w", err)
+	}
+	if result.Checkpoint == nil {
+		return 0, errors.New("latest checkpoint: not returned")
+	}
+	return uint64(result.Checkpoint.SequenceNumber), nil
+}
+
+func (p *EventPoller) fetch(ctx context.Context, filter *EventFilter, after *string) (*Connection[Event], error) {
+	query := `
+		query PollEvents($filter: EventFilter, $first: Int, $after: String) {
+			events(filter: $filter, first: $first, after: $after) {
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+				edges {
+					cursor
+					node {
+						transactionModule { name package { address } }
+						sender { address }
+						timestamp
+						sequenceNumber
+						transaction { digest effects { checkpoint { sequenceNumber } } }
+						contents { type { repr } bcs json }
+						eventBcs
+					}
+				}
+			}
+		}
+	`
+
+	vars := map[string]any{"first": p.pageSize}
+	if filter != nil {
+		vars["filter"] = filter
+	}
+	if after != nil {
+		vars["after"] = *after
+	}
+
+	var result struct {
+		Events *Connection[Event] `json:"events"`
+	}
+	if err := p.client.Execute(ctx, query, vars, &result); err != nil {
+		return nil, err
+	}
+	return result.Events, nil
+}
+
+// markSeen reports whether key was already delivered; when record is true the
+// key is added to the bounded dedup window.
+func (p *EventPoller) markSeen(key eventKey, record bool) bool {
+	if _, ok := p.seen[key]; ok {
+		return true
+	}
+	if !record {
+		return false
+	}
+
+	p.seen[key] = struct{}{}
+	p.seenOrder = append(p.seenOrder, key)
+	if len(p.seenOrder) > p.dedupWindow {
+		evicted := p.seenOrder[0]
+		p.seenOrder = p.seenOrder[1:]
+		delete(p.seen, evicted)
+	}
+	return false
+}
+
+func eventKeyOf(event Event) (eventKey, bool) {
+	if event.Transaction == nil || len(event.Transaction.Digest) == 0 || event.SequenceNumber == nil {
+		return eventKey{}, false
+	}
+	return eventKey{digest: event.Transaction.Digest.String(), sequence: uint64(*event.SequenceNumber)}, true
+}
+
+// Checkpoint returns the sequence number of the checkpoint that includes the
+// event's transaction, when the query selected it.
+func (e Event) Checkpoint() (uint64, bool) {
+	if e.Transaction == nil || e.Transaction.Effects == nil || e.Transaction.Effects.Checkpoint == nil {
+		return 0, false
+	}
+	return uint64(e.Transaction.Effects.Checkpoint.SequenceNumber), true
+}
@@ -0,0 +1,303 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/types"
+)
+
+// newEventServer serves a fixed list of events, two per page, keyed by cursor index.
+func newEventServer(t *testing.T, total int) *httptest.Server {
+	t.Helper()
+	digest := types.Digest(bytes.Repeat([]byte{1}, 32)).String()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables map[string]any `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode request: %v", err)
+			return
+		}
+
+		start := 0
+		if after, ok := req.Variables["after"].(string); ok {
+			fmt.Sscanf(after, "c%d", &start)
+			start++
+		}
+		end := start + 2
+		if end > total {
+			end = total
+		}
+
+		edges := []map[string]any{}
+		for i := start; i < end; i++ {
+			edges = append(edges, map[string]any{
+				"cursor": fmt.Sprintf("c%d", i),
+				"node": map[string]any{
+					"sequenceNumber": i,
+					"transaction":    map[string]any{"digest": digest},
+				},
+			})
+		}
+
+		json.NewEncoder(w).Encode(map[string]any{
+			"data": map[string]any{
+				"events": map[string]any{
+					"pageInfo": map[string]any{"hasNextPage": end < total},
+					"edges":    edges,
+				},
+			},
+		})
+	}))
+}
+
+func TestEventPollerDeliversAllPages(t *testing.T) {
+	server := newEventServer(t, 5)
+	defer server.Close()
+
+	var got []uint64
+	store := NewFileCursorStore(filepath.Join(t.TempDir(), "cursor"))
+	poller, err := NewEventPoller(NewClient(WithEndpoint(server.URL)), nil, func(_ context.Context, ev Event) error {
+		got = append(got, uint64(*ev.SequenceNumber))
+		return nil
+	}, store)
+	if err != nil {
+		t.Fatalf("new poller: %v", err)
+	}
+
+	n, err := poller.PollOnce(context.Background())
+	if err != nil {
+		t.Fatalf("poll: %v", err)
+	}
+	if n != 5 || len(got) != 5 {
+		t.Fatalf("expected 5 events, got %d", n)
+	}
+
+	cursor, err := store.Load(context.Background())
+	if err != nil || cursor == nil || *cursor != "c4" {
+		t.Fatalf("expected cursor c4, got %v (%v)", cursor, err)
+	}
+
+	if n, err := poller.PollOnce(context.Background()); err != nil || n != 0 {
+		t.Fatalf("expected no new events, got %d (%v)", n, err)
+	}
+}
+
+func TestEventPollerRedeliversAfterHandlerError(t *testing.T) {
+	server := newEventServer(t, 3)
+	defer server.Close()
+
+	failOnce := true
+	var got []uint64
+	store := NewMemoryCursorStore("")
+	poller, err := NewEventPoller(NewClient(WithEndpoint(server.URL)), nil, func(_ context.Context, ev Event) error {
+		if *ev.SequenceNumber == 1 && failOnce {
+			failOnce = false
+			return errors.New("boom")
+		}
+		got = append(got, uint64(*ev.SequenceNumber))
+		return nil
+	}, store)
+	if err != nil {
+		t.Fatalf("new poller: %v", err)
+	}
+
+	if _, err := poller.PollOnce(context.Background()); err == nil {
+		t.Fatalf("expected handler error")
+	}
+	if cursor, _ := store.Load(context.Background()); cursor == nil || *cursor != "c0" {
+		t.Fatalf("cursor should not advance past failed event")
+	}
+
+	if _, err := poller.PollOnce(context.Background()); err != nil {
+		t.Fatalf("second poll: %v", err)
+	}
+	if len(got) != 3 || got[0] != 0 || got[1] != 1 || got[2] != 2 {
+		t.Fatalf("unexpected delivery order %v", got)
+	}
+}
+
+func TestEventPollerSkipsDuplicates(t *testing.T) {
+	server := newEventServer(t, 2)
+	defer server.Close()
+
+	count := 0
+	poller, err := NewEventPoller(NewClient(WithEndpoint(server.URL)), nil, func(context.Context, Event) error {
+		count++
+		return nil
+	}, nil)
+	if err != nil {
+		t.Fatalf("new poller: %v", err)
+	}
+
+	if _, err := poller.PollOnce(context.Background()); err != nil {
+		t.Fatalf("poll: %v", err)
+	}
+	// Rewind the cursor to simulate a replay; already delivered events are skipped.
+	poller.store = NewMemoryCursorStore("")
+	if _, err := poller.PollOnce(context.Background()); err != nil {
+		t.Fatalf("replay poll: %v", err)
+	}
+	if count != 2 {
+		t.Fatalf("expected 2 deliveries, got %d", count)
+	}
+}
+
+// checkpointEventServer serves events tagged with checkpoints, honouring the
+// afterCheckpoint and beforeCheckpoint filters, and reports latest as the
+// latest checkpoint. Events are listed in checkpoint order, two per page.
+type checkpointEventServer struct {
+	latest  uint64
+	events  []uint64 // checkpoint of the event with each sequence number
+	filters []map[string]any
+}
+
+func (s *checkpointEventServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Query     string         `json:"query"`
+		Variables map[string]any `json:"variables"`
+	}
+	json.NewDecoder(r.Body).Decode(&req)
+	if strings.Contains(req.Query, "PollCheckpoint") {
+		json.NewEncoder(w).Encode(map[string]any{
+			"data": map[string]any{"checkpoint": map[string]any{"sequenceNumber": s.latest}},
+		})
+		return
+	}
+
+	filter, _ := req.Variables["filter"].(map[string]any)
+	s.filters = append(s.filters, filter)
+	after, hasAfter := filter["afterCheckpoint"].(float64)
+	before, _ := filter["beforeCheckpoint"].(float64)
+	start := 0
+	if cursor, ok := req.Variables["after"].(string); ok {
+		fmt.Sscanf(cursor, "c%d", &start)
+		start++
+	}
+
+	digest := types.Digest(bytes.Repeat([]byte{1}, 32)).String()
+	edges := []map[string]any{}
+	next := len(s.events)
+	for i := start; i < len(s.events); i++ {
+		checkpoint := s.events[i]
+		if (hasAfter && checkpoint <= uint64(after)) || checkpoint >= uint64(before) {
+			continue
+		}
+		if len(edges) == 2 {
+			next = i
+			break
+		}
+		edges = append(edges, map[string]any{
+			"cursor": fmt.Sprintf("c%d", i),
+			"node": map[string]any{
+				"sequenceNumber": i,
+				"transaction": map[string]any{
+					"digest":  digest,
+					"effects": map[string]any{"checkpoint": map[string]any{"sequenceNumber": checkpoint}},
+				},
+			},
+		})
+	}
+	json.NewEncoder(w).Encode(map[string]any{
+		"data": map[string]any{
+			"events": map[string]any{
+				"pageInfo": map[string]any{"hasNextPage": next < len(s.events)},
+				"edges":    edges,
+			},
+		},
+	})
+}
+
+func TestEventPollerCheckpointCursor(t *testing.T) {
+	backend := &checkpointEventServer{latest: 3, events: []uint64{1, 1, 2, 3}}
+	server := httptest.NewServer(backend)
+	defer server.Close()
+
+	var got []uint64
+	store := NewMemoryCursorStore("")
+	poller, err := NewEventPoller(NewClient(WithEndpoint(server.URL)), nil, func(_ context.Context, ev Event) error {
+		got = append(got, uint64(*ev.SequenceNumber))
+		return nil
+	}, store, WithCheckpointCursor(1))
+	if err != nil {
+		t.Fatalf("new poller: %v", err)
+	}
+	checkpoint := func() string {
+		cursor, err := store.Load(context.Background())
+		if err != nil || cursor == nil {
+			t.Fatalf("load cursor: %v (%v)", cursor, err)
+		}
+		return *cursor
+	}
+
+	if n, err := poller.PollOnce(context.Background()); err != nil || n != 4 {
+		t.Fatalf("expected 4 events, got %d (%v)", n, err)
+	}
+	if checkpoint() != "3" {
+		t.Fatalf("expected checkpoint 3, got %s", checkpoint())
+	}
+
+	// Another provider indexed a late event of checkpoint 3 and checkpoint 4:
+	// the overlap re-scan picks up the late event, and delivered events are
+	// not repeated.
+	backend.latest = 4
+	backend.events = []uint64{1, 1, 2, 3, 3, 4}
+	if n, err := poller.PollOnce(context.Background()); err != nil || n != 2 {
+		t.Fatalf("expected 2 new events, got %d (%v)", n, err)
+	}
+	if fmt.Sprint(got) != "[0 1 2 3 4 5]" {
+		t.Fatalf("unexpected deliveries %v", got)
+	}
+	if after := backend.filters[len(backend.filters)-1]["afterCheckpoint"]; after != float64(2) {
+		t.Fatalf("expected re-scan after checkpoint 2, got %v", after)
+	}
+
+	// A lagging provider does not move the cursor backwards.
+	backend.latest = 2
+	if _, err := poller.PollOnce(context.Background()); err != nil {
+		t.Fatalf("lagging poll: %v", err)
+	}
+	if checkpoint() != "4" {
+		t.Fatalf("expected checkpoint to stay at 4, got %s", checkpoint())
+	}
+}
+
+func TestEventPollerCheckpointCursorStopsAtFailedCheckpoint(t *testing.T) {
+	backend := &checkpointEventServer{latest: 5, events: []uint64{1, 2, 3, 4}}
+	server := httptest.NewServer(backend)
+	defer server.Close()
+
+	store := NewMemoryCursorStore("")
+	poller, err := NewEventPoller(NewClient(WithEndpoint(server.URL)), nil, func(_ context.Context, ev Event) error {
+		if *ev.SequenceNumber == 2 {
+			return errors.New("boom")
+		}
+		return nil
+	}, store, WithCheckpointCursor(0))
+	if err != nil {
+		t.Fatalf("new poller: %v", err)
+	}
+
+	if _, err := poller.PollOnce(context.Background()); err == nil {
+		t.Fatal("expected handler error")
+	}
+	if cursor, _ := store.Load(context.Background()); cursor == nil || *cursor != "2" {
+		t.Fatalf("expected checkpoint 2 before the failed event, got %v", cursor)
+	}
+
+	store = NewMemoryCursorStore("c4")
+	poller.store = store
+	if _, err := poller.PollOnce(context.Background()); err == nil || !strings.Contains(err.Error(), "not a checkpoint") {
+		t.Fatalf("expected an invalid cursor error, got %v", err)
+	}
+}
@@ -0,0 +1,188 @@
+package graphql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+// =============================================================================
+// Event Type Patterns
+// =============================================================================
+
+// EventTypePattern matches event types, including generic events across
+// their instantiations.
+//
+// The server's EventFilter.EventType accepts a package ("0x2"), a module
+// ("0x2::coin"), a type without type arguments ("0xabc::pool::SwapEvent"),
+// which matches every instantiation of the type, or a fully instantiated
+// type, which matches only that instantiation. It has no way to fix some
+// type arguments and leave others open, so a pattern such as
+// "0xabc::pool::SwapEvent<0x2::sui::SUI, _>" is sent to the server as its
+// base type and the remaining arguments are checked client-side with
+// Matches.
+type EventTypePattern struct {
+	base string
+	// args are the normalized type arguments, with "" for a wildcard. They
+	// are nil when the pattern names the type without arguments.
+	args []string
+}
+
+// ParseEventTypePattern parses a Move event type in which any top-level
+// type argument may be "_" or "*" to match every type. Addresses may be in
+// short form; they are normalized.
+func ParseEventTypePattern(pattern string) (*EventTypePattern, error) {
+	trimmed := strings.TrimSpace(pattern)
+	base, rest, generic := strings.Cut(trimmed, "<")
+	normalized, err := NormalizeTypeTag(base)
+	if err != nil {
+		return nil, fmt.Errorf("event type pattern %q: %w", pattern, err)
+	}
+	p := &EventTypePattern{base: normalized}
+	if !generic {
+		return p, nil
+	}
+
+	if !strings.HasSuffix(rest, ">") {
+		return nil, fmt.Errorf("event type pattern %q: unbalanced type arguments", pattern)
+	}
+	args, err := splitTypeArguments(strings.TrimSuffix(rest, ">"))
+	if err != nil {
+		return nil, fmt.Errorf("event type pattern %q: %w", pattern, err)
+	}
+	p.args = make([]string, len(args))
+	for i, arg := range args {
+		if arg == "_" || arg == "*" {
+			continue
+		}
+		if p.args[i], err = NormalizeTypeTag(arg); err != nil {
+			return nil, fmt.Errorf("event type pattern %q: type argument %d: %w", pattern, i, err)
+		}
+	}
+	return p, nil
+}
+
+// MustParseEventTypePattern is like ParseEventTypePattern but panics on
+// error. It is meant for patterns known at compile time.
+func MustParseEventTypePattern(pattern string) *EventTypePattern {
+	p, err := ParseEventTypePattern(pattern)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// String returns the pattern in normalized form, with "_" for wildcards.
+func (p *EventTypePattern) String() string {
+	if p.args == nil {
+		return p.base
+	}
+	args := make([]string, len(p.args))
+	for i, arg := range p.args {
+		args[i] = arg
+		if arg == "" {
+			args[i] = "_"
+		}
+	}
+	return fmt.Sprintf("%s<%s>", p.base, strings.Join(args, ", "))
+}
+
+// Exact reports whether the pattern names a single instantiation, so the
+// server filter alone selects exactly the matching events.
+func (p *EventTypePattern) Exact() bool {
+	if p.args == nil {
+		return false
+	}
+	for _, arg := range p.args {
+		if arg == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter returns the narrowest server-side filter that includes every event
+// the pattern matches: the pattern itself when it is exact, or its base
+// type. Other fields, such as Sender, may be set on the result.
+func (p *EventTypePattern) Filter() *EventFilter {
+	eventType := p.base
+	if p.Exact() {
+		eventType = p.String()
+	}
+	return &EventFilter{EventType: &eventType}
+}
+
+// Matches reports whether eventType, such as an event's Contents.Type.Repr,
+// matches the pattern. Types that cannot be parsed do not match.
+func (p *EventTypePattern) Matches(eventType string) bool {
+	tag, err := utils.ParseTypeTag(eventType)
+	if err != nil || tag.Struct == nil {
+		return false
+	}
+	s := tag.Struct
+	if fmt.Sprintf("%s::%s::%s", s.Address.String(), s.Module, s.Name) != p.base {
+		return false
+	}
+	if p.args == nil {
+		return true
+	}
+	if len(s.TypeParams) != len(p.args) {
+		return false
+	}
+	for i, arg := range p.args {
+		if arg != "" && s.TypeParams[i].String() != arg {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchEvent reports whether the type of ev matches the pattern.
+func (p *EventTypePattern) MatchEvent(ev Event) bool {
+	return ev.Contents != nil && p.Matches(ev.Contents.Type.Repr)
+}
+
+// Select returns the events that match the pattern, in order.
+func (p *EventTypePattern) Select(events []Event) []Event {
+	var out []Event
+	for _, ev := range events {
+		if p.MatchEvent(ev) {
+			out = append(out, ev)
+		}
+	}
+	return out
+}
+
+// splitTypeArguments splits a comma-separated list of type arguments at the
+// top level, leaving nested type arguments intact.
+func splitTypeArguments(list string) ([]string, error) {
+	var args []string
+	depth, start := 0, 0
+	for i, r := range list {
+		switch r {
+		case '<':
+			depth++
+		case '>':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("unbalanced type arguments")
+			}
+		case ',':
+			if depth == 0 {
+				args = append(args, strings.TrimSpace(list[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced type arguments")
+	}
+	args = append(args, strings.TrimSpace(list[start:]))
+	for _, arg := range args {
+		if arg == "" {
+			return nil, fmt.Errorf("empty type argument")
+		}
+	}
+	return args, nil
+}
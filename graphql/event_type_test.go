@@ -0,0 +1,105 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const (
+	testSwapEvent = "0x0000000000000000000000000000000000000000000000000000000000000abc::pool::SwapEvent"
+	testSUI       = "0x0000000000000000000000000000000000000000000000000000000000000002::sui::SUI"
+	testUSDC      = "0x00000000000000000000000000000000000000000000000000000000000000dc::usdc::USDC"
+)
+
+func TestEventTypePattern(t *testing.T) {
+	suiUSDC := testSwapEvent + "<" + testSUI + ", " + testUSDC + ">"
+	usdcSUI := testSwapEvent + "<" + testUSDC + ", " + testSUI + ">"
+
+	cases := []struct {
+		pattern string
+		filter  string
+		exact   bool
+		matches map[string]bool
+	}{
+		{"0xabc::pool::SwapEvent", testSwapEvent, false, map[string]bool{suiUSDC: true, usdcSUI: true, testSwapEvent: true, "0xabc::pool::Other": false}},
+		{"0xabc::pool::SwapEvent<0x2::sui::SUI, _>", testSwapEvent, false, map[string]bool{suiUSDC: true, usdcSUI: false, testSwapEvent: false}},
+		{"0xabc::pool::SwapEvent<*, 0x2::sui::SUI>", testSwapEvent, false, map[string]bool{suiUSDC: false, usdcSUI: true}},
+		{"0xabc::pool::SwapEvent<0x2::sui::SUI, 0xdc::usdc::USDC>", suiUSDC, true, map[string]bool{suiUSDC: true, usdcSUI: false}},
+	}
+	for _, tc := range cases {
+		p, err := ParseEventTypePattern(tc.pattern)
+		if err != nil {
+			t.Fatalf("parse %s: %v", tc.pattern, err)
+		}
+		if got := *p.Filter().EventType; got != tc.filter || p.Exact() != tc.exact {
+			t.Errorf("%s: filter %s exact %v, want %s %v", tc.pattern, got, p.Exact(), tc.filter, tc.exact)
+		}
+		for eventType, want := range tc.matches {
+			if p.Matches(eventType) != want {
+				t.Errorf("%s matching %s = %v, want %v", tc.pattern, eventType, !want, want)
+			}
+		}
+	}
+
+	if got := MustParseEventTypePattern("0xabc::pool::SwapEvent<_, 0x2::sui::SUI>").String(); got != testSwapEvent+"<_, "+testSUI+">" {
+		t.Errorf("unexpected string %s", got)
+	}
+	for _, bad := range []string{"0xabc::pool", "0xabc::pool::SwapEvent<u64", "0xabc::pool::SwapEvent<u64,>", "0xabc::pool::SwapEvent<oops>"} {
+		if _, err := ParseEventTypePattern(bad); err == nil {
+			t.Errorf("expected %q to be rejected", bad)
+		}
+	}
+}
+
+func TestEventPollerTypePattern(t *testing.T) {
+	types := []string{
+		testSwapEvent + "<" + testSUI + ", " + testUSDC + ">",
+		testSwapEvent + "<" + testUSDC + ", " + testSUI + ">",
+		testSwapEvent + "<" + testSUI + ", " + testSUI + ">",
+	}
+	var filter map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables map[string]any `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		filter, _ = req.Variables["filter"].(map[string]any)
+
+		edges := []map[string]any{}
+		for i, typ := range types {
+			edges = append(edges, map[string]any{
+				"cursor": fmt.Sprintf("c%d", i),
+				"node":   map[string]any{"contents": map[string]any{"type": map[string]any{"repr": typ}}},
+			})
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"events": map[string]any{"edges": edges}}})
+	}))
+	defer server.Close()
+
+	pattern := MustParseEventTypePattern("0xabc::pool::SwapEvent<0x2::sui::SUI, _>")
+	var got []string
+	store := NewMemoryCursorStore("")
+	poller, err := NewEventPoller(NewClient(WithEndpoint(server.URL)), pattern.Filter(), func(_ context.Context, ev Event) error {
+		got = append(got, ev.Contents.Type.Repr)
+		return nil
+	}, store, WithEventTypePattern(pattern))
+	if err != nil {
+		t.Fatalf("new poller: %v", err)
+	}
+	if _, err := poller.PollOnce(context.Background()); err != nil {
+		t.Fatalf("poll: %v", err)
+	}
+	if filter["eventType"] != testSwapEvent {
+		t.Fatalf("unexpected server filter %v", filter)
+	}
+	if len(got) != 2 || got[0] != types[0] || got[1] != types[2] {
+		t.Fatalf("unexpected events %v", got)
+	}
+	if cursor, _ := store.Load(context.Background()); cursor == nil || *cursor != "c2" {
+		t.Fatalf("the cursor should move past skipped events, got %v", cursor)
+	}
+}
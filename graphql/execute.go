@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"fmt"
 
+	"github.com/open-move/sui-go-sdk/transaction"
 	"github.com/open-move/sui-go-sdk/types"
 )
 
@@ -20,7 +21,7 @@ func SimulateTransaction(c *Client, ctx context.Context, txBcs []byte, opts *Sim
 				effects {
 					digest
 					status
-					executionError { message }
+					executionError { message abortCode sourceLineNumber instructionOffset identifier constant module { name package { address } } }
 					lamportVersion
 					gasEffects {
 						gasSummary {
@@ -73,7 +74,7 @@ func ExecuteTransaction(c *Client, ctx context.Context, txBcs []byte, signatures
 				effects {
 					digest
 					status
-					executionError { message }
+					executionError { message abortCode sourceLineNumber instructionOffset identifier constant module { name package { address } } }
 					lamportVersion
 					gasEffects {
 						gasSummary {
@@ -158,7 +159,7 @@ func ExecuteTransactionWithOptions(c *Client, ctx context.Context, txBcs []byte,
 	effectsFields := `
 		digest
 		status
-		executionError { message }
+		executionError { message abortCode sourceLineNumber instructionOffset identifier constant module { name package { address } } }
 		lamportVersion
 		gasEffects {
 			gasSummary {
@@ -235,6 +236,69 @@ func ExecuteTransactionWithOptions(c *Client, ctx context.Context, txBcs []byte,
 	return result.ExecuteTransaction, nil
 }
 
+// SignAndExecuteTransaction signs BCS-encoded transaction data with signer and
+// submits it. Any signer works, including in-memory keypairs and hardware wallets.
+func SignAndExecuteTransaction(c *Client, ctx context.Context, txBcs []byte, signer transaction.TransactionSigner, opts *ExecuteOptions) (*ExecuteTransactionResult, error) {
+	if signer == nil {
+		return nil, fmt.Errorf("nil signer")
+	}
+
+	signature, err := signer.SignTransaction(txBcs)
+	if err != nil {
+		return nil, fmt.Errorf("sign transaction: %w", err)
+	}
+
+	return ExecuteTransactionWithOptions(c, ctx, txBcs, [][]byte{signature}, opts)
+}
+
+// =============================================================================
+// Transaction Builder Interop
+// =============================================================================
+
+// BuildTransaction builds tx into BCS-encoded TransactionData ready to be
+// simulated or executed through this package. The transaction must have a
+// sender and gas configuration, either set directly or via opts.GasResolver.
+func BuildTransaction(ctx context.Context, tx *transaction.Transaction, opts transaction.BuildOptions) ([]byte, error) {
+	result, err := tx.Build(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("build transaction: %w", err)
+	}
+	if len(result.TransactionBytes) == 0 {
+		return nil, fmt.Errorf("build transaction: sender and gas data are required")
+	}
+	return result.TransactionBytes, nil
+}
+
+// SimulateBuiltTransaction builds a transaction.Transaction and simulates it.
+func SimulateBuiltTransaction(c *Client, ctx context.Context, tx *transaction.Transaction, buildOpts transaction.BuildOptions, opts *SimulationOptions) (*SimulationResult, error) {
+	txBcs, err := BuildTransaction(ctx, tx, buildOpts)
+	if err != nil {
+		return nil, err
+	}
+	return SimulateTransaction(c, ctx, txBcs, opts)
+}
+
+// SignAndExecuteBuiltTransaction builds a transaction.Transaction, signs it
+// with signer, and submits it.
+func SignAndExecuteBuiltTransaction(c *Client, ctx context.Context, tx *transaction.Transaction, buildOpts transaction.BuildOptions, signer transaction.TransactionSigner, opts *ExecuteOptions) (*ExecuteTransactionResult, error) {
+	txBcs, err := BuildTransaction(ctx, tx, buildOpts)
+	if err != nil {
+		return nil, err
+	}
+	return SignAndExecuteTransaction(c, ctx, txBcs, signer, opts)
+}
+
+// TransactionFromBcs turns BCS-encoded TransactionData, such as the
+// transactionBcs returned by a query, back into a transaction.Transaction so
+// it can be modified and rebuilt.
+func TransactionFromBcs(txBcs []byte) (*transaction.Transaction, error) {
+	data, err := transaction.DecodeTransactionData(txBcs)
+	if err != nil {
+		return nil, err
+	}
+	return transaction.FromTransactionData(data)
+}
+
 // =============================================================================
 // ZkLogin Verification
 // =============================================================================
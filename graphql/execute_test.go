@@ -0,0 +1,65 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/transaction"
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+func TestSimulateBuiltTransaction(t *testing.T) {
+	tx := transaction.New()
+	tx.SetSender("0xa").SetGasBudget(5000).SetGasPrice(1).SetGasPayment([]types.ObjectRef{
+		{ObjectID: utils.MustParseAddress("0x9"), Version: 3, Digest: types.Digest(bytes.Repeat([]byte{1}, 32))},
+	})
+	tx.PayAllSui("0xb")
+
+	var simulated []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables map[string]any `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		simulated, _ = base64.StdEncoding.DecodeString(req.Variables["txBytes"].(string))
+		json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{
+			"simulateTransaction": map[string]any{"effects": map[string]any{"status": "SUCCESS"}},
+		}})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithEndpoint(server.URL))
+	result, err := SimulateBuiltTransaction(client, context.Background(), tx, transaction.BuildOptions{}, nil)
+	if err != nil {
+		t.Fatalf("simulate: %v", err)
+	}
+	if result.Effects.Status != ExecutionStatusSuccess {
+		t.Fatalf("unexpected simulation status")
+	}
+
+	rebuilt, err := TransactionFromBcs(simulated)
+	if err != nil {
+		t.Fatalf("from bcs: %v", err)
+	}
+	again, err := BuildTransaction(context.Background(), rebuilt, transaction.BuildOptions{})
+	if err != nil {
+		t.Fatalf("rebuild: %v", err)
+	}
+	if !bytes.Equal(again, simulated) {
+		t.Fatalf("rebuilt bytes differ from simulated bytes")
+	}
+}
+
+func TestBuildTransactionRequiresGasData(t *testing.T) {
+	tx := transaction.New()
+	tx.PayAllSui("0xb")
+	if _, err := BuildTransaction(context.Background(), tx, transaction.BuildOptions{}); err == nil {
+		t.Fatal("expected error for transaction without sender and gas")
+	}
+}
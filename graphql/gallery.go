@@ -0,0 +1,183 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/open-move/sui-go-sdk/types"
+)
+
+// =============================================================================
+// NFT Galleries
+// =============================================================================
+
+// Display keys defined by the Sui Display standard.
+const (
+	DisplayName        = "name"
+	DisplayDescription = "description"
+	DisplayImageURL    = "image_url"
+	DisplayLink        = "link"
+	DisplayProjectURL  = "project_url"
+	DisplayCreator     = "creator"
+)
+
+// GalleryItem is an object with its Display rendered, ready to show in a
+// gallery.
+type GalleryItem struct {
+	ObjectID types.Address
+	Version  UInt53
+	Digest   types.Digest
+	Owner    *ObjectOwner
+	// Type is the object's Move type, e.g. "0xabc::nft::Nft".
+	Type string
+	// Fields are the object's contents as JSON.
+	Fields json.RawMessage
+	// Display holds the rendered Display keys. It is empty when the type has
+	// no Display object.
+	Display map[string]string
+	// Err reports why the item is missing or incomplete: the object could not
+	// be fetched, is not a Move object, or some Display keys failed to render.
+	Err error
+}
+
+// Name returns the rendered "name" Display key.
+func (i *GalleryItem) Name() string { return i.Display[DisplayName] }
+
+// Description returns the rendered "description" Display key.
+func (i *GalleryItem) Description() string { return i.Display[DisplayDescription] }
+
+// ImageURL returns the rendered "image_url" Display key.
+func (i *GalleryItem) ImageURL() string { return i.Display[DisplayImageURL] }
+
+// GetObjectsWithDisplay fetches objects and renders their Display in as few
+// requests as the service limits allow: objects are read in batches, and the
+// Display template of each distinct type is fetched once. Requests run with
+// the client's concurrency.
+//
+// The result has one item per id, in order. Failures are reported per item
+// in GalleryItem.Err and, together, in the returned MultiError, so the items
+// that did load can be shown even when the error is non-nil.
+func (c *Client) GetObjectsWithDisplay(ctx context.Context, ids []types.Address) ([]GalleryItem, error) {
+	const selection = `
+		address
+		version
+		digest
+		owner {
+			__typename
+			... on AddressOwner { address { address } }
+			... on ObjectOwner { address { address } }
+			... on Shared { initialSharedVersion }
+		}
+		asMoveObject {
+			contents { type { repr } json }
+		}
+	`
+	query := fmt.Sprintf(`
+		query GalleryObjects($keys: [ObjectKey!]!) {
+			multiGetObjects(keys: $keys) {
+				%s
+			}
+		}
+	`, selection)
+
+	items := make([]GalleryItem, len(ids))
+	for i, id := range ids {
+		items[i].ObjectID = id
+	}
+	if len(ids) == 0 {
+		return items, nil
+	}
+
+	chunkSize := c.listBatchSize(ctx, selection)
+	c.runChunks(len(ids), chunkSize, func(start, end int) error {
+		keys := make([]map[string]any, 0, end-start)
+		for _, id := range ids[start:end] {
+			keys = append(keys, map[string]any{"address": id})
+		}
+		var result struct {
+			MultiGetObjects []*Object `json:"multiGetObjects"`
+		}
+		if err := c.Execute(ctx, query, map[string]any{"keys": keys}, &result); err != nil {
+			for i := start; i < end; i++ {
+				items[i].Err = fmt.Errorf("fetch object %s: %w", ids[i], err)
+			}
+			return nil
+		}
+		for i := start; i < end; i++ {
+			var obj *Object
+			if n := i - start; n < len(result.MultiGetObjects) {
+				obj = result.MultiGetObjects[n]
+			}
+			items[i].fill(obj)
+		}
+		return nil
+	})
+
+	// Fetch the Display template of each type once.
+	var objectTypes []string
+	seen := make(map[string]bool)
+	for _, item := range items {
+		if item.Err == nil && !seen[item.Type] {
+			seen[item.Type] = true
+			objectTypes = append(objectTypes, item.Type)
+		}
+	}
+	var mu sync.Mutex
+	templates := make(map[string]map[string]string, len(objectTypes))
+	templateErrs := make(map[string]error)
+	c.runChunks(len(objectTypes), 1, func(start, _ int) error {
+		objectType := objectTypes[start]
+		template, err := c.GetDisplayTemplate(ctx, objectType)
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil && !errors.Is(err, ErrDisplayNotFound) {
+			templateErrs[objectType] = fmt.Errorf("display template for %s: %w", objectType, err)
+			return nil
+		}
+		templates[objectType] = template
+		return nil
+	})
+
+	var errs MultiError
+	for i := range items {
+		item := &items[i]
+		if item.Err == nil {
+			if err := templateErrs[item.Type]; err != nil {
+				item.Err = err
+			} else if template := templates[item.Type]; len(template) > 0 {
+				display, err := RenderDisplay(template, item.Fields)
+				item.Display = display
+				if err != nil {
+					item.Err = fmt.Errorf("object %s: %w", item.ObjectID, err)
+				}
+			}
+		}
+		if item.Err != nil {
+			errs = append(errs, item.Err)
+		}
+	}
+	if len(errs) > 0 {
+		return items, errs
+	}
+	return items, nil
+}
+
+// fill copies the fetched object into the item, or records why it cannot.
+func (i *GalleryItem) fill(obj *Object) {
+	if obj == nil {
+		i.Err = fmt.Errorf("object %s not found", i.ObjectID)
+		return
+	}
+	i.Version = obj.Version
+	i.Digest = obj.Digest
+	i.Owner = obj.Owner
+	if obj.AsMoveObject == nil || obj.AsMoveObject.Contents == nil {
+		i.Err = fmt.Errorf("object %s is not a Move object", i.ObjectID)
+		return
+	}
+	i.Type = obj.AsMoveObject.Contents.Type.Repr
+	i.Fields = obj.AsMoveObject.Contents.Json
+}
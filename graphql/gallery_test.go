@@ -0,0 +1,159 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+func TestGetObjectsWithDisplay(t *testing.T) {
+	var templateFetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query     string         `json:"query"`
+			Variables map[string]any `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		var data any
+		switch {
+		case strings.Contains(req.Query, "GetDisplayTemplate"):
+			templateFetches.Add(1)
+			displayType := req.Variables["filter"].(map[string]any)["type"].(string)
+			nodes := []any{}
+			if strings.Contains(displayType, "::nft::Nft") {
+				nodes = append(nodes, map[string]any{"asMoveObject": map[string]any{"contents": map[string]any{"json": map[string]any{
+					"version": 1,
+					"fields": map[string]any{"contents": []map[string]any{
+						{"key": "name", "value": "{name}"},
+						{"key": "image_url", "value": "https://img.example/{id}.png"},
+					}},
+				}}}})
+			}
+			data = map[string]any{"objects": map[string]any{"nodes": nodes}}
+		case strings.Contains(req.Query, "GalleryObjects"):
+			objects := []any{}
+			for _, key := range req.Variables["keys"].([]any) {
+				address := key.(map[string]any)["address"].(string)
+				object := func(objectType string, fields map[string]any) map[string]any {
+					return map[string]any{
+						"address": address,
+						"version": 5,
+						"owner":   map[string]any{"__typename": "AddressOwner", "address": map[string]any{"address": "0xa11ce"}},
+						"asMoveObject": map[string]any{"contents": map[string]any{
+							"type": map[string]any{"repr": objectType},
+							"json": fields,
+						}},
+					}
+				}
+				switch address {
+				case utils.MustParseAddress("0x1").String(), utils.MustParseAddress("0x2").String():
+					objects = append(objects, object("0xabc::nft::Nft", map[string]any{"name": "Capy " + address[len(address)-1:], "id": address[len(address)-1:]}))
+				case utils.MustParseAddress("0x4").String():
+					objects = append(objects, object("0xabc::ticket::Ticket", map[string]any{"seat": "A1"}))
+				default:
+					objects = append(objects, nil)
+				}
+			}
+			data = map[string]any{"multiGetObjects": objects}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+	defer server.Close()
+
+	ids := []types.Address{
+		utils.MustParseAddress("0x1"),
+		utils.MustParseAddress("0x2"),
+		utils.MustParseAddress("0x3"),
+		utils.MustParseAddress("0x4"),
+	}
+	client := NewClient(WithEndpoint(server.URL))
+	items, err := client.GetObjectsWithDisplay(context.Background(), ids)
+
+	var multiErr MultiError
+	if !errors.As(err, &multiErr) || len(multiErr) != 1 || !strings.Contains(multiErr[0].Error(), "not found") {
+		t.Fatalf("expected one missing object error, got %v", err)
+	}
+	if len(items) != 4 {
+		t.Fatalf("expected 4 items, got %d", len(items))
+	}
+	if items[0].Name() != "Capy 1" || items[1].ImageURL() != "https://img.example/2.png" {
+		t.Fatalf("unexpected displays %v, %v", items[0].Display, items[1].Display)
+	}
+	if items[0].Type != "0xabc::nft::Nft" || items[0].Owner == nil || items[0].Version != 5 {
+		t.Fatalf("unexpected item %+v", items[0])
+	}
+	if items[2].ObjectID != ids[2] || items[2].Err == nil {
+		t.Fatalf("expected the missing object to be reported, got %+v", items[2])
+	}
+	if items[3].Err != nil || len(items[3].Display) != 0 || items[3].Type != "0xabc::ticket::Ticket" {
+		t.Fatalf("an object without display should load without error, got %+v", items[3])
+	}
+	if n := templateFetches.Load(); n != 2 {
+		t.Fatalf("expected one template fetch per type, got %d", n)
+	}
+}
+
+func TestGetObjectsWithDisplayChunks(t *testing.T) {
+	var requests, largest atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query     string         `json:"query"`
+			Variables map[string]any `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		var data any
+		switch {
+		case strings.Contains(req.Query, "serviceConfig"):
+			data = map[string]any{"serviceConfig": map[string]any{"maxOutputNodes": 100_000}}
+		case strings.Contains(req.Query, "GetDisplayTemplate"):
+			data = map[string]any{"objects": map[string]any{"nodes": []any{}}}
+		case strings.Contains(req.Query, "GalleryObjects"):
+			keys := req.Variables["keys"].([]any)
+			requests.Add(1)
+			if n := int32(len(keys)); n > largest.Load() {
+				largest.Store(n)
+			}
+			objects := make([]any, len(keys))
+			for i, key := range keys {
+				objects[i] = map[string]any{
+					"address":      key.(map[string]any)["address"],
+					"version":      1,
+					"asMoveObject": map[string]any{"contents": map[string]any{"type": map[string]any{"repr": "0xabc::nft::Nft"}, "json": map[string]any{}}},
+				}
+			}
+			data = map[string]any{"multiGetObjects": objects}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+	defer server.Close()
+
+	ids := make([]types.Address, 3*maxMultiGetKeys)
+	for i := range ids {
+		ids[i][30], ids[i][31] = byte(i>>8), byte(i)
+	}
+	items, err := NewClient(WithEndpoint(server.URL)).GetObjectsWithDisplay(context.Background(), ids)
+	if err != nil {
+		t.Fatalf("GetObjectsWithDisplay: %v", err)
+	}
+	for i, item := range items {
+		if item.ObjectID != ids[i] || item.Err != nil {
+			t.Fatalf("item %d = %+v", i, item)
+		}
+	}
+	if n := requests.Load(); n != 3 {
+		t.Fatalf("requests = %d, want 3", n)
+	}
+	if n := largest.Load(); n > maxMultiGetKeys {
+		t.Fatalf("a request asked for %d keys, more than %d", n, maxMultiGetKeys)
+	}
+}
@@ -0,0 +1,214 @@
+package graphql
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	bcs "github.com/iotaledger/bcs-go"
+
+	"github.com/open-move/sui-go-sdk/transaction"
+)
+
+// =============================================================================
+// Gas Profiling
+// =============================================================================
+
+// CommandGas is the gas attributed to one command of a programmable
+// transaction. Costs are in MIST and may be negative, e.g. when a command
+// deletes objects and earns a storage rebate.
+type CommandGas struct {
+	// Index is the command's position in the transaction.
+	Index int
+	// Kind is the command name, such as "MoveCall" or "SplitCoins".
+	Kind string
+	// Target is the function a MoveCall calls.
+	Target          string
+	ComputationCost int64
+	StorageCost     int64
+	StorageRebate   int64
+}
+
+// Net returns computation plus storage cost minus the rebate.
+func (g CommandGas) Net() int64 {
+	return g.ComputationCost + g.StorageCost - g.StorageRebate
+}
+
+// GasProfile attributes the gas of a transaction to its commands.
+type GasProfile struct {
+	// Total is the gas of the whole transaction.
+	Total GasCostSummary
+	// Base is the gas of the transaction with no commands: the minimum
+	// charge and the cost of its gas coin.
+	Base GasCostSummary
+	// Commands holds one entry per command that ran.
+	Commands []CommandGas
+	// FailedCommand is the index of the command that aborted the
+	// transaction, or -1. Later commands are not profiled.
+	FailedCommand int
+	// Error is the execution error of the failed command.
+	Error string
+}
+
+// Heaviest returns the command with the largest net cost, or false when no
+// command was profiled.
+func (p *GasProfile) Heaviest() (CommandGas, bool) {
+	if len(p.Commands) == 0 {
+		return CommandGas{}, false
+	}
+	heaviest := p.Commands[0]
+	for _, cmd := range p.Commands[1:] {
+		if cmd.Net() > heaviest.Net() {
+			heaviest = cmd
+		}
+	}
+	return heaviest, true
+}
+
+// ProfileGas estimates the gas each command of a programmable transaction
+// consumes. Neither RPC reports gas per command, so the transaction's
+// command prefixes (none, the first, the first two, ...) are simulated with
+// checks disabled, and each command is charged the difference between the
+// prefix that ends with it and the one before. Simulations run concurrently,
+// bounded by WithConcurrency.
+//
+// The figures are approximations: computation is charged in buckets, so
+// small commands may show zero while the command that crosses a bucket
+// boundary shows the whole step.
+func ProfileGas(c *Client, ctx context.Context, txBcs []byte) (*GasProfile, error) {
+	data, err := transaction.DecodeTransactionData(txBcs)
+	if err != nil {
+		return nil, err
+	}
+	ptb := data.V1.Kind.ProgrammableTransaction
+	if ptb == nil {
+		return nil, errors.New("profile gas: not a programmable transaction")
+	}
+
+	commands := ptb.Commands
+	prefixes := make([]*SimulationResult, len(commands)+1)
+	err = c.runChunks(len(prefixes), 1, func(n, _ int) error {
+		prefix := *data
+		v1 := *data.V1
+		v1.Kind = transaction.TransactionKind{ProgrammableTransaction: &transaction.ProgrammableTransaction{
+			Inputs:   ptb.Inputs,
+			Commands: commands[:n],
+		}}
+		prefix.V1 = &v1
+		encoded, err := bcs.Marshal(&prefix)
+		if err != nil {
+			return err
+		}
+		sim, err := simulateGas(c, ctx, encoded)
+		if err != nil {
+			return fmt.Errorf("profile gas: simulate %d commands: %w", n, err)
+		}
+		prefixes[n] = sim
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	base, ok := simulatedGas(prefixes[0])
+	if !ok {
+		return nil, fmt.Errorf("profile gas: transaction without commands failed: %s", simulationMessage(prefixes[0]))
+	}
+	profile := &GasProfile{Base: base, FailedCommand: -1}
+	previous := base
+	for i, cmd := range commands {
+		summary, ok := simulatedGas(prefixes[i+1])
+		if !ok {
+			profile.FailedCommand = i
+			profile.Error = simulationMessage(prefixes[i+1])
+			break
+		}
+		kind, target := commandKind(cmd)
+		profile.Commands = append(profile.Commands, CommandGas{
+			Index:           i,
+			Kind:            kind,
+			Target:          target,
+			ComputationCost: int64(summary.ComputationCost) - int64(previous.ComputationCost),
+			StorageCost:     int64(summary.StorageCost) - int64(previous.StorageCost),
+			StorageRebate:   int64(summary.StorageRebate) - int64(previous.StorageRebate),
+		})
+		previous = summary
+	}
+	profile.Total = previous
+	return profile, nil
+}
+
+func simulateGas(c *Client, ctx context.Context, txBcs []byte) (*SimulationResult, error) {
+	query := `
+		mutation ProfileGas($txBytes: String!) {
+			simulateTransaction(txBytes: $txBytes, skipChecks: true) {
+				effects {
+					status
+					executionError { message }
+					gasEffects {
+						gasSummary {
+							computationCost
+							storageCost
+							storageRebate
+							nonRefundableStorageFee
+						}
+					}
+				}
+				error
+			}
+		}
+	`
+	var result struct {
+		SimulateTransaction *SimulationResult `json:"simulateTransaction"`
+	}
+	if err := c.Execute(ctx, query, map[string]any{"txBytes": base64.StdEncoding.EncodeToString(txBcs)}, &result); err != nil {
+		return nil, err
+	}
+	if result.SimulateTransaction == nil {
+		return nil, errors.New("empty simulation result")
+	}
+	return result.SimulateTransaction, nil
+}
+
+// simulatedGas returns the gas summary of a successful simulation.
+func simulatedGas(sim *SimulationResult) (GasCostSummary, bool) {
+	if sim.Error != nil && *sim.Error != "" {
+		return GasCostSummary{}, false
+	}
+	effects := sim.Effects
+	if effects == nil || effects.Status != ExecutionStatusSuccess || effects.GasEffects == nil || effects.GasEffects.GasSummary == nil {
+		return GasCostSummary{}, false
+	}
+	return *effects.GasEffects.GasSummary, true
+}
+
+func simulationMessage(sim *SimulationResult) string {
+	if sim.Error != nil && *sim.Error != "" {
+		return *sim.Error
+	}
+	if sim.Effects != nil && sim.Effects.ExecutionError != nil {
+		return sim.Effects.ExecutionError.Message
+	}
+	return "unknown error"
+}
+
+func commandKind(cmd transaction.Command) (string, string) {
+	switch {
+	case cmd.MoveCall != nil:
+		return "MoveCall", fmt.Sprintf("%s::%s::%s", cmd.MoveCall.Package, cmd.MoveCall.Module, cmd.MoveCall.Function)
+	case cmd.TransferObjects != nil:
+		return "TransferObjects", ""
+	case cmd.SplitCoins != nil:
+		return "SplitCoins", ""
+	case cmd.MergeCoins != nil:
+		return "MergeCoins", ""
+	case cmd.Publish != nil:
+		return "Publish", ""
+	case cmd.MakeMoveVec != nil:
+		return "MakeMoveVec", ""
+	case cmd.Upgrade != nil:
+		return "Upgrade", ""
+	}
+	return "Unknown", ""
+}
@@ -0,0 +1,99 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/transaction"
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+func TestProfileGas(t *testing.T) {
+	tx := transaction.New()
+	tx.SetSender("0xa").SetGasBudget(5000).SetGasPrice(1).SetGasPayment([]types.ObjectRef{
+		{ObjectID: utils.MustParseAddress("0x9"), Version: 3, Digest: types.Digest(bytes.Repeat([]byte{1}, 32))},
+	})
+	coin := tx.SplitCoins(transaction.SplitCoins{Coin: tx.Gas(), Amounts: []transaction.Argument{tx.PureU64(10)}})
+	tx.MoveCall(transaction.MoveCall{Target: "0x2::coin::join", TypeArguments: []string{"0x2::sui::SUI"}, Arguments: []transaction.Argument{tx.Gas(), coin[0]}})
+	tx.TransferObjects(transaction.TransferObjects{Objects: []transaction.Argument{tx.Gas()}, Address: tx.PureAddress("0xb")})
+
+	txBcs, err := BuildTransaction(context.Background(), tx, transaction.BuildOptions{})
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables map[string]any `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		raw, _ := base64.StdEncoding.DecodeString(req.Variables["txBytes"].(string))
+		data, err := transaction.DecodeTransactionData(raw)
+		if err != nil {
+			t.Errorf("decode prefix: %v", err)
+			return
+		}
+		n := len(data.V1.Kind.ProgrammableTransaction.Commands)
+
+		var result map[string]any
+		switch {
+		case n == 3:
+			result = map[string]any{"effects": map[string]any{
+				"status":         "FAILURE",
+				"executionError": map[string]any{"message": "gas coin cannot be transferred"},
+			}}
+		default:
+			storage, rebate := 0, 0
+			if n >= 1 {
+				storage = 500
+			}
+			if n >= 2 {
+				rebate = 200
+			}
+			result = map[string]any{"effects": map[string]any{
+				"status": "SUCCESS",
+				"gasEffects": map[string]any{"gasSummary": map[string]any{
+					"computationCost": 1000 * (n + 1),
+					"storageCost":     storage,
+					"storageRebate":   rebate,
+				}},
+			}}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"simulateTransaction": result}})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithEndpoint(server.URL), WithRetries(0))
+	profile, err := ProfileGas(client, context.Background(), txBcs)
+	if err != nil {
+		t.Fatalf("profile: %v", err)
+	}
+
+	if profile.Base.ComputationCost != 1000 || profile.Total.ComputationCost != 3000 {
+		t.Fatalf("unexpected base %+v or total %+v", profile.Base, profile.Total)
+	}
+	if profile.FailedCommand != 2 || profile.Error != "gas coin cannot be transferred" {
+		t.Fatalf("unexpected failure %d %q", profile.FailedCommand, profile.Error)
+	}
+	if len(profile.Commands) != 2 {
+		t.Fatalf("expected 2 profiled commands, got %d", len(profile.Commands))
+	}
+
+	split, call := profile.Commands[0], profile.Commands[1]
+	if split.Kind != "SplitCoins" || split.ComputationCost != 1000 || split.StorageCost != 500 || split.Net() != 1500 {
+		t.Fatalf("unexpected split gas %+v", split)
+	}
+	if call.Kind != "MoveCall" || !strings.HasSuffix(call.Target, "::coin::join") || call.StorageRebate != 200 || call.Net() != 800 {
+		t.Fatalf("unexpected call gas %+v", call)
+	}
+	if heaviest, ok := profile.Heaviest(); !ok || heaviest.Index != 0 {
+		t.Fatalf("unexpected heaviest command %+v", heaviest)
+	}
+}
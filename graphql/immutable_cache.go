@@ -0,0 +1,201 @@
+package graphql
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/open-move/sui-go-sdk/types"
+)
+
+// =============================================================================
+// Immutable Data Cache
+// =============================================================================
+
+// ImmutableStore persists responses for data that never changes once it is
+// on chain, such as published packages and the configuration of a past
+// protocol version. Implementations must be safe for concurrent use.
+type ImmutableStore interface {
+	// Get returns the value stored under key and whether it was found.
+	Get(key string) ([]byte, bool, error)
+	// Set stores value under key.
+	Set(key string, value []byte) error
+}
+
+// WithImmutableCache caches the results of GetPackage, GetModule,
+// GetNormalizedMoveFunction and GetProtocolConfig (for an explicit version)
+// in store. Entries are scoped to the client's endpoint. System packages
+// such as 0x1 and 0x2 are upgraded in place and are never cached, nor are
+// lookups that found nothing.
+func WithImmutableCache(store ImmutableStore) ClientOption {
+	return func(c *Client) {
+		c.immutable = store
+	}
+}
+
+// MemoryStore is an ImmutableStore that keeps entries in memory.
+type MemoryStore struct {
+	mu      sync.RWMutex
+	entries map[string][]byte
+}
+
+// NewMemoryStore returns an empty in-memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: make(map[string][]byte)}
+}
+
+// Get implements ImmutableStore.
+func (s *MemoryStore) Get(key string) ([]byte, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	value, ok := s.entries[key]
+	return value, ok, nil
+}
+
+// Set implements ImmutableStore.
+func (s *MemoryStore) Set(key string, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[key] = append([]byte(nil), value...)
+	return nil
+}
+
+// DiskStore is an ImmutableStore that keeps one file per entry in a
+// directory, so cached metadata survives process restarts.
+type DiskStore struct {
+	dir string
+}
+
+// NewDiskStore returns a store rooted at dir, creating it if needed.
+func NewDiskStore(dir string) (*DiskStore, error) {
+	if dir == "" {
+		return nil, errors.New("empty cache directory")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create cache directory: %w", err)
+	}
+	return &DiskStore{dir: dir}, nil
+}
+
+// Get implements ImmutableStore.
+func (s *DiskStore) Get(key string) ([]byte, bool, error) {
+	value, err := os.ReadFile(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+// Set implements ImmutableStore. Entries are written to a temporary file and
+// renamed into place so concurrent readers never see a partial entry.
+func (s *DiskStore) Set(key string, value []byte) error {
+	tmp, err := os.CreateTemp(s.dir, ".entry-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(value); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), s.path(key)); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
+
+func (s *DiskStore) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// executeImmutable runs query like Execute, serving the response from the
+// immutable cache when possible. The response is stored only when found
+// reports that it holds a value. An empty key bypasses the cache.
+func (c *Client) executeImmutable(ctx context.Context, key, query string, variables map[string]any, result any, found func() bool) error {
+	if c.immutable == nil || key == "" {
+		return c.Execute(ctx, query, variables, result)
+	}
+
+	key = c.endpointFor(ctx) + "|" + key
+	cached, ok, err := c.immutable.Get(key)
+	if err != nil {
+		c.logCacheError(ctx, "read", key, err)
+	}
+	if ok && decodeJSON(cached, result, c.numbers) == nil {
+		return nil
+	}
+
+	var raw json.RawMessage
+	if err := c.Execute(ctx, query, variables, &raw); err != nil {
+		return err
+	}
+	if err := decodeJSON(raw, result, c.numbers); err != nil {
+		return fmt.Errorf("failed to unmarshal data: %w", err)
+	}
+	if found() {
+		if err := c.immutable.Set(key, raw); err != nil {
+			c.logCacheError(ctx, "write", key, err)
+		}
+	}
+	return nil
+}
+
+// logCacheError reports a store failure. The cache is an optimisation, so
+// failures never fail the request.
+func (c *Client) logCacheError(ctx context.Context, op, key string, err error) {
+	if c.logger != nil {
+		c.logger.LogAttrs(ctx, slog.LevelWarn, "graphql immutable cache "+op+" failed",
+			slog.String("key", key), slog.String("error", err.Error()))
+	}
+}
+
+// Kinds of package data in the immutable cache. Each kind starts its keys so
+// that, for example, a module and a function of the same name never share an
+// entry.
+const (
+	cachePackage      = "package"
+	cacheModule       = "module"
+	cacheModuleSource = "module-source"
+	cacheFunction     = "function"
+)
+
+// packageCacheKey returns the cache key for data of the given kind read from
+// a package, or "" when the package is mutable.
+func packageCacheKey(address types.Address, kind string, parts ...string) string {
+	if isSystemPackage(address) {
+		return ""
+	}
+	key := "package:" + address.String() + ":" + kind
+	for _, part := range parts {
+		key += ":" + part
+	}
+	return key
+}
+
+// isSystemPackage reports whether address is one of the framework packages
+// (0x1, 0x2, 0x3, 0xb, 0xdee9, ...) that are upgraded in place and so keep
+// their address across versions.
+func isSystemPackage(address types.Address) bool {
+	for _, b := range address[:len(address)-2] {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,90 @@
+package graphql
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+func TestImmutableCache(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"object":{"asMovePackage":{"address":"0xabc123","version":1}},"protocolConfigs":{"protocolVersion":70}}}`))
+	}))
+	defer server.Close()
+
+	store := NewMemoryStore()
+	client := NewClient(WithEndpoint(server.URL), WithImmutableCache(store))
+	ctx := context.Background()
+
+	pkg := utils.MustParseAddress("0xabc123")
+	for range 3 {
+		got, err := client.GetPackage(ctx, pkg)
+		if err != nil || got == nil || got.Version != 1 {
+			t.Fatalf("GetPackage = %+v, %v", got, err)
+		}
+	}
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("package fetched %d times, want 1", n)
+	}
+
+	// A second client sharing the store reuses the entry.
+	other := NewClient(WithEndpoint(server.URL), WithImmutableCache(store))
+	if _, err := other.GetPackage(ctx, pkg); err != nil || calls.Load() != 1 {
+		t.Fatalf("shared store not used: calls=%d err=%v", calls.Load(), err)
+	}
+
+	calls.Store(0)
+	for range 2 {
+		client.GetPackage(ctx, utils.MustParseAddress("0x2"))
+		client.GetProtocolConfig(ctx, nil)
+	}
+	if n := calls.Load(); n != 4 {
+		t.Fatalf("mutable data fetched %d times, want 4", n)
+	}
+
+	version := UInt53(70)
+	for range 2 {
+		if _, err := client.GetProtocolConfig(ctx, &version); err != nil {
+			t.Fatalf("GetProtocolConfig: %v", err)
+		}
+	}
+	if n := calls.Load(); n != 5 {
+		t.Fatalf("versioned protocol config fetched %d times, want 1", n-4)
+	}
+}
+
+func TestPackageCacheKeyKinds(t *testing.T) {
+	pkg := utils.MustParseAddress("0xabc123")
+	module := packageCacheKey(pkg, cacheModuleSource, "m", "bytes")
+	function := packageCacheKey(pkg, cacheFunction, "m", "bytes")
+	if module == function {
+		t.Fatalf("module and function share key %s", module)
+	}
+	if key := packageCacheKey(utils.MustParseAddress("0x2"), cacheFunction, "coin", "value"); key != "" {
+		t.Errorf("system package key = %q, want none", key)
+	}
+}
+
+func TestDiskStore(t *testing.T) {
+	store, err := NewDiskStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewDiskStore: %v", err)
+	}
+	if _, ok, err := store.Get("missing"); ok || err != nil {
+		t.Fatalf("Get(missing) = %v, %v", ok, err)
+	}
+	if err := store.Set("key", []byte(`{"a":1}`)); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	value, ok, err := store.Get("key")
+	if !ok || err != nil || string(value) != `{"a":1}` {
+		t.Fatalf("Get(key) = %s, %v, %v", value, ok, err)
+	}
+}
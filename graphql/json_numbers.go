@@ -0,0 +1,199 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+)
+
+// =============================================================================
+// JSON Numbers
+// =============================================================================
+
+// NumberMode selects how numbers are decoded into untyped (interface{})
+// values, such as TransactionEffects.EffectsJson, EventResult.ParsedJSON and
+// map[string]any results. Typed fields such as UInt53 and BigInt are decoded
+// the same way in every mode.
+type NumberMode int
+
+const (
+	// NumbersAsJSONNumber decodes numbers as json.Number, keeping their exact
+	// digits. It is the default. Use JSONBigInt or JSONUint64 to read them.
+	NumbersAsJSONNumber NumberMode = iota
+	// NumbersAsBigInt decodes integers as *big.Int and other numbers as
+	// json.Number.
+	NumbersAsBigInt
+	// NumbersAsFloat64 decodes numbers as float64, as encoding/json does.
+	// Integers above 2^53 lose precision.
+	NumbersAsFloat64
+)
+
+// WithNumberMode sets how numbers are decoded into untyped values.
+func WithNumberMode(mode NumberMode) ClientOption {
+	return func(c *Client) {
+		c.numbers = mode
+	}
+}
+
+// decodeJSON unmarshals data into result, decoding numbers in untyped values
+// according to mode.
+func decodeJSON(data []byte, result any, mode NumberMode) error {
+	if mode == NumbersAsFloat64 {
+		return json.Unmarshal(data, result)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(result); err != nil {
+		return err
+	}
+	if decoder.More() {
+		return errors.New("invalid character after top-level value")
+	}
+	if mode == NumbersAsBigInt {
+		bigNumbersIn(reflect.ValueOf(result))
+	}
+	return nil
+}
+
+// bigNumbersIn replaces the json.Number integers held in the interface
+// values reachable from v with *big.Int.
+func bigNumbersIn(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			bigNumbersIn(v.Elem())
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				bigNumbersIn(v.Field(i))
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			bigNumbersIn(v.Index(i))
+		}
+	case reflect.Map:
+		for iter := v.MapRange(); iter.Next(); {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			elem.Set(iter.Value())
+			bigNumbersIn(elem)
+			v.SetMapIndex(iter.Key(), elem)
+		}
+	case reflect.Interface:
+		// Only empty interfaces can hold the *big.Int replacing a number.
+		if !v.IsNil() && v.CanSet() && v.NumMethod() == 0 {
+			if converted := bigNumbers(v.Interface()); converted != nil {
+				v.Set(reflect.ValueOf(converted))
+			}
+		}
+	}
+}
+
+// bigNumbers returns v, decoded from JSON, with its json.Number integers
+// replaced by *big.Int.
+func bigNumbers(v any) any {
+	switch x := v.(type) {
+	case json.Number:
+		if n, ok := new(big.Int).SetString(x.String(), 10); ok {
+			return n
+		}
+		return x
+	case []any:
+		for i, elem := range x {
+			x[i] = bigNumbers(elem)
+		}
+		return x
+	case map[string]any:
+		for key, elem := range x {
+			x[key] = bigNumbers(elem)
+		}
+		return x
+	}
+	return v
+}
+
+// JSONBigInt converts an integer read from an untyped JSON value into a
+// *big.Int. It accepts json.Number, *big.Int, Go integers, decimal strings
+// (Sui encodes u64 and wider integers in JSON as strings) and float64 values
+// holding an integer; a float64 above 2^53 may already have lost precision.
+func JSONBigInt(v any) (*big.Int, error) {
+	switch x := v.(type) {
+	case json.Number:
+		return parseJSONInteger(x.String())
+	case string:
+		return parseJSONInteger(x)
+	case *big.Int:
+		if x == nil {
+			return nil, fmt.Errorf("nil integer")
+		}
+		return new(big.Int).Set(x), nil
+	case int:
+		return big.NewInt(int64(x)), nil
+	case int64:
+		return big.NewInt(x), nil
+	case uint64:
+		return new(big.Int).SetUint64(x), nil
+	case float64:
+		if x != math.Trunc(x) || math.IsInf(x, 0) {
+			return nil, fmt.Errorf("%v is not an integer", x)
+		}
+		n, _ := big.NewFloat(x).Int(nil)
+		return n, nil
+	}
+	return nil, fmt.Errorf("%T is not an integer", v)
+}
+
+// JSONUint64 converts an integer read from an untyped JSON value into a
+// uint64, accepting the same values as JSONBigInt.
+func JSONUint64(v any) (uint64, error) {
+	n, err := JSONBigInt(v)
+	if err != nil {
+		return 0, err
+	}
+	if !n.IsUint64() {
+		return 0, fmt.Errorf("%s does not fit in a uint64", n)
+	}
+	return n.Uint64(), nil
+}
+
+// JSONLookup returns the value at path within an untyped JSON value, such
+// as EventResult.ParsedJSON, following object keys and, for numeric path
+// elements, array indexes.
+func JSONLookup(v any, path ...string) (any, bool) {
+	for _, key := range path {
+		switch x := v.(type) {
+		case map[string]any:
+			elem, ok := x[key]
+			if !ok {
+				return nil, false
+			}
+			v = elem
+		case []any:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(x) {
+				return nil, false
+			}
+			v = x[i]
+		default:
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+func parseJSONInteger(s string) (*big.Int, error) {
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid integer %q", s)
+	}
+	return n, nil
+}
@@ -0,0 +1,94 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNumberModes(t *testing.T) {
+	const large = "18446744073709551615"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": {"transaction": {"effects": {
+			"lamportVersion": 12,
+			"effectsJson": {"amounts": [` + large + `, 2], "ratio": 1.5}
+		}}}}`))
+	}))
+	defer server.Close()
+
+	fetch := func(t *testing.T, opts ...ClientOption) any {
+		t.Helper()
+		client := NewClient(append([]ClientOption{WithEndpoint(server.URL)}, opts...)...)
+		var result struct {
+			Transaction struct {
+				Effects TransactionEffects `json:"effects"`
+			} `json:"transaction"`
+		}
+		if err := client.Execute(context.Background(), `query { transaction { effects { lamportVersion effectsJson } } }`, nil, &result); err != nil {
+			t.Fatalf("execute: %v", err)
+		}
+		if result.Transaction.Effects.Lamport != 12 {
+			t.Fatalf("lamport = %d, want 12", result.Transaction.Effects.Lamport)
+		}
+		return result.Transaction.Effects.EffectsJson
+	}
+
+	t.Run("json number", func(t *testing.T) {
+		effects := fetch(t)
+		amount, ok := JSONLookup(effects, "amounts", "0")
+		if !ok {
+			t.Fatal("amount not found")
+		}
+		if amount != json.Number(large) {
+			t.Fatalf("amount = %#v, want json.Number(%s)", amount, large)
+		}
+		n, err := JSONUint64(amount)
+		if err != nil || n != 18446744073709551615 {
+			t.Fatalf("JSONUint64 = %d, %v", n, err)
+		}
+	})
+
+	t.Run("big int", func(t *testing.T) {
+		effects := fetch(t, WithNumberMode(NumbersAsBigInt))
+		amount, _ := JSONLookup(effects, "amounts", "0")
+		n, ok := amount.(*big.Int)
+		if !ok || n.String() != large {
+			t.Fatalf("amount = %#v, want *big.Int %s", amount, large)
+		}
+		ratio, _ := JSONLookup(effects, "ratio")
+		if ratio != json.Number("1.5") {
+			t.Fatalf("ratio = %#v, want json.Number(1.5)", ratio)
+		}
+	})
+
+	t.Run("float64", func(t *testing.T) {
+		effects := fetch(t, WithNumberMode(NumbersAsFloat64))
+		amount, _ := JSONLookup(effects, "amounts", "1")
+		if amount != float64(2) {
+			t.Fatalf("amount = %#v, want float64 2", amount)
+		}
+	})
+}
+
+func TestJSONBigInt(t *testing.T) {
+	for _, v := range []any{json.Number("42"), "42", big.NewInt(42), 42, int64(42), uint64(42), float64(42)} {
+		n, err := JSONBigInt(v)
+		if err != nil || n.Int64() != 42 {
+			t.Errorf("JSONBigInt(%#v) = %v, %v", v, n, err)
+		}
+	}
+	for _, v := range []any{json.Number("1.5"), "0x2a", 1.5, true, nil} {
+		if _, err := JSONBigInt(v); err == nil {
+			t.Errorf("JSONBigInt(%#v) succeeded", v)
+		}
+	}
+	if _, err := JSONUint64(json.Number("-1")); err == nil {
+		t.Error("JSONUint64(-1) succeeded")
+	}
+	if _, ok := JSONLookup(map[string]any{"a": []any{1}}, "a", "3"); ok {
+		t.Error("JSONLookup found an out of range index")
+	}
+}
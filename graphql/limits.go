@@ -0,0 +1,269 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// =============================================================================
+// Service Limits
+// =============================================================================
+
+// Limits assumed when the service config cannot be fetched. They match the
+// public Sui GraphQL services.
+const (
+	defaultMaxQueryNodes       = 300
+	defaultMaxOutputNodes      = 100_000
+	defaultMaxQueryPayloadSize = 5_000
+	// defaultPageSize is the page size the service assumes for connections
+	// queried without first or last.
+	defaultPageSize = 20
+	// maxMultiGetKeys bounds the keys of one multiGetObjects-style lookup.
+	// The service config does not report the service's own limit on keys,
+	// so this stays well under it and keeps the variables payload small.
+	maxMultiGetKeys = 50
+)
+
+// serviceLimitsRetry is how long the default limits are used after the
+// service config could not be fetched.
+const serviceLimitsRetry = 30 * time.Second
+
+// Size of the text added to a batched query for each alias (the aliased field
+// and its variable definition) and for the operation itself.
+const (
+	aliasPayloadOverhead = 64
+	queryPayloadOverhead = 64
+)
+
+// QueryLimitError reports a query that exceeds one of the service's limits.
+type QueryLimitError struct {
+	Limit string // the serviceConfig field, such as "maxQueryNodes"
+	Value int
+	Max   int
+	// Suggestions are ways to bring the query under the limit, set by the
+	// cost guards.
+	Suggestions []string
+}
+
+func (e *QueryLimitError) Error() string {
+	return fmt.Sprintf("query exceeds %s: %d > %d", e.Limit, e.Value, e.Max) + e.suggestionText()
+}
+
+// WithSizeGuards makes the client check every query against the service's
+// maxQueryPayloadSize and maxQueryNodes limits before sending it, so an
+// oversized query fails with a QueryLimitError instead of an opaque server
+// error. The service config is fetched on the first request and cached.
+func WithSizeGuards() ClientOption {
+	return func(c *Client) {
+		c.sizeGuards = true
+	}
+}
+
+// serviceLimits returns the service config, with defaults for limits the
+// service did not report. The config is fetched once and cached. Concurrent
+// callers share a single fetch, made without holding limitsMu. A failed fetch
+// falls back to the defaults, which are used until serviceLimitsRetry passes.
+func (c *Client) serviceLimits(ctx context.Context) ServiceConfig {
+	c.limitsMu.Lock()
+	for c.serviceConfig == nil && c.limitsFetch != nil {
+		wait := c.limitsFetch
+		c.limitsMu.Unlock()
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			return withDefaultLimits(ServiceConfig{})
+		}
+		c.limitsMu.Lock()
+	}
+	if c.serviceConfig != nil {
+		cfg := *c.serviceConfig
+		c.limitsMu.Unlock()
+		return withDefaultLimits(cfg)
+	}
+	if time.Now().Before(c.limitsRetryAt) {
+		c.limitsMu.Unlock()
+		return withDefaultLimits(ServiceConfig{})
+	}
+	done := make(chan struct{})
+	c.limitsFetch = done
+	c.limitsMu.Unlock()
+
+	cfg, err := c.GetServiceConfig(ctx)
+
+	c.limitsMu.Lock()
+	defer c.limitsMu.Unlock()
+	c.limitsFetch = nil
+	close(done)
+	if err != nil || cfg == nil {
+		c.limitsRetryAt = time.Now().Add(serviceLimitsRetry)
+		return withDefaultLimits(ServiceConfig{})
+	}
+	c.serviceConfig = cfg
+	return withDefaultLimits(*cfg)
+}
+
+func withDefaultLimits(cfg ServiceConfig) ServiceConfig {
+	if cfg.MaxQueryNodes <= 0 {
+		cfg.MaxQueryNodes = defaultMaxQueryNodes
+	}
+	if cfg.MaxOutputNodes <= 0 {
+		cfg.MaxOutputNodes = defaultMaxOutputNodes
+	}
+	if cfg.MaxQueryPayloadSize <= 0 {
+		cfg.MaxQueryPayloadSize = defaultMaxQueryPayloadSize
+	}
+	if cfg.MaxQueryDepth <= 0 {
+		cfg.MaxQueryDepth = defaultMaxQueryDepth
+	}
+	return cfg
+}
+
+// checkQuerySize rejects queries the service would refuse for their size.
+// Queries that do not parse are left for the service to report.
+func (c *Client) checkQuerySize(ctx context.Context, query string) error {
+	limits := c.serviceLimits(ctx)
+	if n := len(query); n > limits.MaxQueryPayloadSize {
+		return &QueryLimitError{Limit: "maxQueryPayloadSize", Value: n, Max: limits.MaxQueryPayloadSize}
+	}
+
+	doc, err := parseDocument(query)
+	if err != nil {
+		return nil
+	}
+	for _, op := range doc.operations {
+		if n := countSelectionNodes(doc, op.selections); n > limits.MaxQueryNodes {
+			return &QueryLimitError{Limit: "maxQueryNodes", Value: n, Max: limits.MaxQueryNodes}
+		}
+	}
+	return nil
+}
+
+// batchSize returns how many aliased copies of a root field with the given
+// selection fit in one query under the service's limits.
+func (c *Client) batchSize(ctx context.Context, selection string) int {
+	limits := c.serviceLimits(ctx)
+
+	nodes, outputs := 1, 1 // the aliased root field itself
+	if doc, err := parseDocument("{" + selection + "}"); err == nil {
+		sels := doc.operations[0].selections
+		nodes += countSelectionNodes(doc, sels)
+		outputs += estimateOutputNodes(doc, sels, defaultPageSize)
+	}
+	payload := len(compactSelection(selection)) + aliasPayloadOverhead
+
+	size := min(
+		limits.MaxQueryNodes/nodes,
+		limits.MaxOutputNodes/outputs,
+		(limits.MaxQueryPayloadSize-queryPayloadOverhead)/payload,
+	)
+	return max(1, size)
+}
+
+// listBatchSize returns how many keys a list root field such as
+// multiGetObjects can fetch in one query with the given selection before its
+// response exceeds the service's maxOutputNodes limit, and at most
+// maxMultiGetKeys.
+func (c *Client) listBatchSize(ctx context.Context, selection string) int {
+	limits := c.serviceLimits(ctx)
+
+	outputs := 1
+	if doc, err := parseDocument("{" + selection + "}"); err == nil {
+		outputs += estimateOutputNodes(doc, doc.operations[0].selections, defaultPageSize)
+	}
+	return max(1, min(maxMultiGetKeys, limits.MaxOutputNodes/outputs))
+}
+
+// runChunks calls fn for consecutive [start, end) chunks of n items,
+// running up to the client's concurrency at once. It returns the first error.
+func (c *Client) runChunks(n, size int, fn func(start, end int) error) error {
+	workers := c.concurrency
+	if workers <= 0 {
+		workers = 1
+	}
+	sem := make(chan struct{}, workers)
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	for start := 0; start < n; start += size {
+		end := min(start+size, n)
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := fn(start, end); err != nil {
+				errOnce.Do(func() { firstErr = err })
+			}
+		}()
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// countSelectionNodes counts the fields in sels, including those inside
+// fragments, the way the service counts query nodes.
+func countSelectionNodes(doc *astDocument, sels []astSelection) int {
+	count := 0
+	for _, sel := range sels {
+		switch sel.kind {
+		case selectionField:
+			count += 1 + countSelectionNodes(doc, sel.selections)
+		case selectionInlineFragment:
+			count += countSelectionNodes(doc, sel.selections)
+		case selectionFragmentSpread:
+			if frag := doc.fragments[sel.name]; frag != nil {
+				count += countSelectionNodes(doc, frag.selections)
+			}
+		}
+	}
+	return count
+}
+
+// estimateOutputNodes estimates how many nodes a response to sels can hold.
+// The nodes or edges of a connection are counted page times, where page is
+// the connection's first or last argument, or defaultPageSize.
+func estimateOutputNodes(doc *astDocument, sels []astSelection, page int) int {
+	count := 0
+	for _, sel := range sels {
+		switch sel.kind {
+		case selectionField:
+			n := 1 + estimateOutputNodes(doc, sel.selections, pageSize(sel.arguments))
+			if sel.name == "nodes" || sel.name == "edges" {
+				n *= page
+			}
+			count += n
+		case selectionInlineFragment:
+			count += estimateOutputNodes(doc, sel.selections, page)
+		case selectionFragmentSpread:
+			if frag := doc.fragments[sel.name]; frag != nil {
+				count += estimateOutputNodes(doc, frag.selections, page)
+			}
+		}
+	}
+	return count
+}
+
+func pageSize(args []astArgument) int {
+	for _, arg := range args {
+		if (arg.name == "first" || arg.name == "last") && arg.value.kind == valueInt {
+			if n, err := strconv.Atoi(arg.value.raw); err == nil && n > 0 {
+				return n
+			}
+		}
+	}
+	return defaultPageSize
+}
+
+// compactSelection collapses the whitespace in a selection set, which the
+// service counts against maxQueryPayloadSize. Selections built by this
+// package contain no string literals, so this does not change their meaning.
+func compactSelection(selection string) string {
+	return strings.Join(strings.Fields(selection), " ")
+}
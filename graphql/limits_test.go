@@ -0,0 +1,182 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/types"
+)
+
+func TestSizeGuards(t *testing.T) {
+	var sent atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req graphqlRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if strings.Contains(req.Query, "serviceConfig") {
+			w.Write([]byte(`{"data":{"serviceConfig":{"maxQueryNodes":3,"maxQueryPayloadSize":200}}}`))
+			return
+		}
+		sent.Add(1)
+		w.Write([]byte(`{"data":{"chainIdentifier":"35834a8a"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithEndpoint(server.URL), WithSizeGuards())
+	ctx := context.Background()
+
+	if _, err := client.GetChainIdentifier(ctx); err != nil {
+		t.Fatalf("small query rejected: %v", err)
+	}
+
+	var limitErr *QueryLimitError
+	err := client.Execute(ctx, `{ epoch { epochId referenceGasPrice startTimestamp } }`, nil, nil)
+	if !errors.As(err, &limitErr) || limitErr.Limit != "maxQueryNodes" || limitErr.Value != 4 {
+		t.Fatalf("expected maxQueryNodes error, got %v", err)
+	}
+	err = client.Execute(ctx, "{ chainIdentifier }"+strings.Repeat(" ", 200), nil, nil)
+	if !errors.As(err, &limitErr) || limitErr.Limit != "maxQueryPayloadSize" {
+		t.Fatalf("expected maxQueryPayloadSize error, got %v", err)
+	}
+	if n := sent.Load(); n != 1 {
+		t.Fatalf("sent %d queries, want 1", n)
+	}
+}
+
+func TestSizeGuardsCacheConfigFailure(t *testing.T) {
+	var configFetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req graphqlRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if strings.Contains(req.Query, "serviceConfig") {
+			configFetches.Add(1)
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"data":{"chainIdentifier":"35834a8a"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithEndpoint(server.URL), WithSizeGuards(), WithRetries(0))
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetChainIdentifier(context.Background()); err != nil {
+				t.Errorf("query with default limits: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if _, err := client.GetChainIdentifier(context.Background()); err != nil {
+		t.Fatalf("query with default limits: %v", err)
+	}
+	if n := configFetches.Load(); n != 1 {
+		t.Fatalf("service config fetched %d times, want 1", n)
+	}
+}
+
+func TestGetMultipleObjectsChunked(t *testing.T) {
+	var chunks atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query     string `json:"query"`
+			Variables struct {
+				Keys []struct {
+					Address string `json:"address"`
+				} `json:"keys"`
+			} `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if strings.Contains(req.Query, "serviceConfig") {
+			// Each object's selection is estimated at well over 20 output
+			// nodes, so only a few fit per request.
+			w.Write([]byte(`{"data":{"serviceConfig":{"maxOutputNodes":100}}}`))
+			return
+		}
+		chunks.Add(1)
+		objects := make([]map[string]any, len(req.Variables.Keys))
+		for i, key := range req.Variables.Keys {
+			objects[i] = map[string]any{"address": key.Address, "version": 1}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"multiGetObjects": objects}})
+	}))
+	defer server.Close()
+
+	ids := make([]types.Address, 10)
+	for i := range ids {
+		ids[i][31] = byte(i + 1)
+	}
+	client := NewClient(WithEndpoint(server.URL))
+	objects, err := client.GetMultipleObjects(context.Background(), ids, nil)
+	if err != nil {
+		t.Fatalf("GetMultipleObjects: %v", err)
+	}
+	if len(objects) != len(ids) {
+		t.Fatalf("got %d objects, want %d", len(objects), len(ids))
+	}
+	for i, obj := range objects {
+		if obj.Address != ids[i] {
+			t.Fatalf("object %d = %s, want %s", i, obj.Address, ids[i])
+		}
+	}
+	if n := chunks.Load(); n < 2 {
+		t.Fatalf("expected the request to be split, got %d chunk(s)", n)
+	}
+}
+
+func TestGetMultipleObjectsCapsKeys(t *testing.T) {
+	var requests, largest atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query     string `json:"query"`
+			Variables struct {
+				Keys []struct {
+					Address string `json:"address"`
+				} `json:"keys"`
+			} `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if strings.Contains(req.Query, "serviceConfig") {
+			// Output nodes alone would allow thousands of keys per request.
+			w.Write([]byte(`{"data":{"serviceConfig":{"maxOutputNodes":100000}}}`))
+			return
+		}
+		requests.Add(1)
+		if n := int32(len(req.Variables.Keys)); n > largest.Load() {
+			largest.Store(n)
+		}
+		objects := make([]map[string]any, len(req.Variables.Keys))
+		for i, key := range req.Variables.Keys {
+			objects[i] = map[string]any{"address": key.Address, "version": 1}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"multiGetObjects": objects}})
+	}))
+	defer server.Close()
+
+	ids := make([]types.Address, 4*maxMultiGetKeys+1)
+	for i := range ids {
+		ids[i][30], ids[i][31] = byte(i>>8), byte(i)
+	}
+	client := NewClient(WithEndpoint(server.URL))
+	objects, err := client.GetMultipleObjects(context.Background(), ids, nil)
+	if err != nil {
+		t.Fatalf("GetMultipleObjects: %v", err)
+	}
+	if len(objects) != len(ids) || objects[len(ids)-1].Address != ids[len(ids)-1] {
+		t.Fatalf("got %d objects, want %d in order", len(objects), len(ids))
+	}
+	if n := requests.Load(); n != 5 {
+		t.Fatalf("requests = %d, want 5", n)
+	}
+	if n := largest.Load(); n > maxMultiGetKeys {
+		t.Fatalf("a request asked for %d keys, more than %d", n, maxMultiGetKeys)
+	}
+}
@@ -0,0 +1,256 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// =============================================================================
+// Metrics
+// =============================================================================
+
+// Metrics receives one record per operation executed by the client.
+// Implementations must be safe for concurrent use; ObserveOperation is
+// called synchronously, so it should not block.
+type Metrics interface {
+	ObserveOperation(OperationMetrics)
+}
+
+// OperationMetrics describes a completed operation.
+type OperationMetrics struct {
+	// Name is the GraphQL operation name, or "anonymous".
+	Name string
+	// Kind is "query", "mutation" or "subscription".
+	Kind     string
+	Duration time.Duration
+	// RequestBytes and ResponseBytes are the body sizes of the last attempt.
+	RequestBytes  int
+	ResponseBytes int
+	Retries       int
+	// RateLimited counts responses with status 429 Too Many Requests.
+	RateLimited int
+	// ThrottleWait is the time spent waiting for the client-side rate
+	// limiter, summed over attempts.
+	ThrottleWait time.Duration
+	// Error is ErrorClassNone when the operation succeeded.
+	Error ErrorClass
+}
+
+// ErrorClass groups operation failures for alerting.
+type ErrorClass string
+
+const (
+	ErrorClassNone ErrorClass = ""
+	// ErrorClassCanceled covers context cancellation by the caller.
+	ErrorClassCanceled ErrorClass = "canceled"
+	// ErrorClassTimeout covers deadlines and network timeouts.
+	ErrorClassTimeout ErrorClass = "timeout"
+	// ErrorClassTransport covers connection failures.
+	ErrorClassTransport ErrorClass = "transport"
+	// ErrorClassRateLimited covers 429 responses.
+	ErrorClassRateLimited ErrorClass = "rate_limited"
+	// ErrorClassServer covers 5xx responses.
+	ErrorClassServer ErrorClass = "server"
+	// ErrorClassHTTP covers other non-2xx responses.
+	ErrorClassHTTP ErrorClass = "http"
+	// ErrorClassGraphQL covers errors reported in the response body.
+	ErrorClassGraphQL ErrorClass = "graphql"
+	// ErrorClassRejected covers queries rejected before sending, by local
+	// validation or service limit checks.
+	ErrorClassRejected ErrorClass = "rejected"
+	// ErrorClassOther covers everything else, such as decoding failures.
+	ErrorClassOther ErrorClass = "other"
+)
+
+// ClassifyError returns the class of an error returned by Execute.
+func ClassifyError(err error) ErrorClass {
+	if err == nil {
+		return ErrorClassNone
+	}
+	var (
+		status     *httpStatusError
+		gqlErrors  GraphQLErrors
+		limitErr   *QueryLimitError
+		invalidErr *QueryValidationError
+		netErr     net.Error
+	)
+	switch {
+	case errors.Is(err, context.Canceled):
+		return ErrorClassCanceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrorClassTimeout
+	case errors.As(err, &status):
+		switch {
+		case status.StatusCode == http.StatusTooManyRequests:
+			return ErrorClassRateLimited
+		case status.StatusCode >= 500:
+			return ErrorClassServer
+		}
+		return ErrorClassHTTP
+	case errors.As(err, &gqlErrors):
+		return ErrorClassGraphQL
+	case errors.As(err, &limitErr), errors.As(err, &invalidErr):
+		return ErrorClassRejected
+	case errors.As(err, &netErr):
+		if netErr.Timeout() {
+			return ErrorClassTimeout
+		}
+		return ErrorClassTransport
+	}
+	return ErrorClassOther
+}
+
+// WithMetrics reports every operation to m, e.g. a MetricsCollector or an
+// adapter for a Prometheus or OpenTelemetry registry.
+func WithMetrics(m Metrics) ClientOption {
+	return func(c *Client) {
+		c.metrics = m
+	}
+}
+
+// noteExchange records the sizes and status of an HTTP attempt on the
+// operation carried by ctx, if any.
+func noteExchange(ctx context.Context, requestBytes, responseBytes, status int) {
+	op, ok := ctx.Value(operationKey{}).(*operation)
+	if !ok {
+		return
+	}
+	op.mu.Lock()
+	defer op.mu.Unlock()
+	op.requestBytes = requestBytes
+	op.responseBytes = responseBytes
+	if status == http.StatusTooManyRequests {
+		op.rateLimited++
+	}
+}
+
+// DefaultLatencyBuckets are the histogram bounds used by
+// NewMetricsCollector when none are given.
+var DefaultLatencyBuckets = []time.Duration{
+	10 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// MetricsCollector aggregates operation metrics in memory. It implements
+// expvar.Var, so it can be published with expvar.Publish and scraped from
+// /debug/vars.
+type MetricsCollector struct {
+	buckets []time.Duration
+
+	mu         sync.Mutex
+	operations map[string]*OperationStats
+}
+
+// OperationStats are the totals for one operation name.
+type OperationStats struct {
+	Count         uint64                `json:"count"`
+	Errors        map[ErrorClass]uint64 `json:"errors,omitempty"`
+	Retries       uint64                `json:"retries"`
+	RateLimited   uint64                `json:"rate_limited"`
+	ThrottleWait  time.Duration         `json:"throttle_wait_ns"`
+	RequestBytes  uint64                `json:"request_bytes"`
+	ResponseBytes uint64                `json:"response_bytes"`
+	TotalDuration time.Duration         `json:"total_duration_ns"`
+	// Latency holds cumulative counts of operations at or under each bound.
+	Latency []LatencyBucket `json:"latency"`
+}
+
+// LatencyBucket is one cumulative histogram bucket.
+type LatencyBucket struct {
+	UpperBound time.Duration `json:"le_ns"`
+	Count      uint64        `json:"count"`
+}
+
+// NewMetricsCollector returns an empty collector. buckets are the latency
+// histogram upper bounds; DefaultLatencyBuckets are used when none are
+// given.
+func NewMetricsCollector(buckets ...time.Duration) *MetricsCollector {
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+	sorted := append([]time.Duration(nil), buckets...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return &MetricsCollector{buckets: sorted, operations: make(map[string]*OperationStats)}
+}
+
+// ObserveOperation implements Metrics.
+func (m *MetricsCollector) ObserveOperation(op OperationMetrics) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats, ok := m.operations[op.Name]
+	if !ok {
+		stats = &OperationStats{Latency: make([]LatencyBucket, len(m.buckets))}
+		for i, bound := range m.buckets {
+			stats.Latency[i].UpperBound = bound
+		}
+		m.operations[op.Name] = stats
+	}
+	stats.Count++
+	stats.Retries += uint64(op.Retries)
+	stats.RateLimited += uint64(op.RateLimited)
+	stats.ThrottleWait += op.ThrottleWait
+	stats.RequestBytes += uint64(op.RequestBytes)
+	stats.ResponseBytes += uint64(op.ResponseBytes)
+	stats.TotalDuration += op.Duration
+	if op.Error != ErrorClassNone {
+		if stats.Errors == nil {
+			stats.Errors = make(map[ErrorClass]uint64)
+		}
+		stats.Errors[op.Error]++
+	}
+	for i := range stats.Latency {
+		if op.Duration <= stats.Latency[i].UpperBound {
+			stats.Latency[i].Count++
+		}
+	}
+}
+
+// Snapshot returns a copy of the totals keyed by operation name.
+func (m *MetricsCollector) Snapshot() map[string]OperationStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := make(map[string]OperationStats, len(m.operations))
+	for name, stats := range m.operations {
+		copied := *stats
+		copied.Latency = append([]LatencyBucket(nil), stats.Latency...)
+		if stats.Errors != nil {
+			copied.Errors = make(map[ErrorClass]uint64, len(stats.Errors))
+			for class, n := range stats.Errors {
+				copied.Errors[class] = n
+			}
+		}
+		snapshot[name] = copied
+	}
+	return snapshot
+}
+
+// Reset discards all recorded totals.
+func (m *MetricsCollector) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.operations = make(map[string]*OperationStats)
+}
+
+// String implements expvar.Var by rendering the snapshot as JSON.
+func (m *MetricsCollector) String() string {
+	encoded, err := json.Marshal(m.Snapshot())
+	if err != nil {
+		return "{}"
+	}
+	return string(encoded)
+}
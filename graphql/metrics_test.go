@@ -0,0 +1,85 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMetricsCollector(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch calls.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusBadGateway)
+		case 3:
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"data":{"chainIdentifier":"4c78adac"}}`))
+		}
+	}))
+	defer server.Close()
+
+	collector := NewMetricsCollector(time.Millisecond, time.Minute)
+	client := NewClient(WithEndpoint(server.URL), WithRetries(1), WithMetrics(collector))
+	query := `query GetChainIdentifier { chainIdentifier }`
+
+	if err := client.Execute(context.Background(), query, nil, nil); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if err := client.Execute(context.Background(), query, nil, nil); err == nil {
+		t.Fatal("expected rate limit error")
+	}
+
+	stats, ok := collector.Snapshot()["GetChainIdentifier"]
+	if !ok {
+		t.Fatalf("no stats recorded: %v", collector.Snapshot())
+	}
+	if stats.Count != 2 || stats.Retries != 1 || stats.RateLimited != 1 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+	if stats.Errors[ErrorClassRateLimited] != 1 || len(stats.Errors) != 1 {
+		t.Fatalf("unexpected errors %v", stats.Errors)
+	}
+	if stats.RequestBytes == 0 || stats.ResponseBytes == 0 {
+		t.Fatalf("expected payload sizes, got %+v", stats)
+	}
+	if last := stats.Latency[len(stats.Latency)-1]; last.UpperBound != time.Minute || last.Count != 2 {
+		t.Fatalf("unexpected latency buckets %+v", stats.Latency)
+	}
+
+	var _ expvar.Var = collector
+	if !strings.Contains(collector.String(), `"GetChainIdentifier"`) {
+		t.Fatalf("unexpected expvar output %s", collector.String())
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	cases := []struct {
+		err  error
+		want ErrorClass
+	}{
+		{nil, ErrorClassNone},
+		{fmt.Errorf("request failed: %w", context.Canceled), ErrorClassCanceled},
+		{context.DeadlineExceeded, ErrorClassTimeout},
+		{&httpStatusError{StatusCode: 429}, ErrorClassRateLimited},
+		{&httpStatusError{StatusCode: 503}, ErrorClassServer},
+		{&httpStatusError{StatusCode: 403}, ErrorClassHTTP},
+		{GraphQLErrors{{Message: "bad"}}, ErrorClassGraphQL},
+		{&QueryLimitError{}, ErrorClassRejected},
+		{errors.New("boom"), ErrorClassOther},
+	}
+	for _, tc := range cases {
+		if got := ClassifyError(tc.err); got != tc.want {
+			t.Errorf("ClassifyError(%v) = %q, want %q", tc.err, got, tc.want)
+		}
+	}
+}
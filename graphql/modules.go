@@ -0,0 +1,142 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/open-move/sui-go-sdk/bytecode"
+	"github.com/open-move/sui-go-sdk/types"
+)
+
+// ErrModuleNotFound is returned when a package has no module with the
+// requested name, or the address is not a package.
+var ErrModuleNotFound = errors.New("module not found")
+
+// GetModuleBytecode returns the compiled bytecode of a published module.
+// Results are cached, since published modules never change.
+func (c *Client) GetModuleBytecode(ctx context.Context, pkg types.Address, module string) ([]byte, error) {
+	m, err := c.moduleSource(ctx, pkg, module, "bytes")
+	if err != nil {
+		return nil, err
+	}
+	return *m.Bytes, nil
+}
+
+// GetModuleDisassembly returns the disassembly of a published module as
+// rendered by the node.
+func (c *Client) GetModuleDisassembly(ctx context.Context, pkg types.Address, module string) (string, error) {
+	m, err := c.moduleSource(ctx, pkg, module, "disassembly")
+	if err != nil {
+		return "", err
+	}
+	return *m.Disassembly, nil
+}
+
+// InspectModule fetches a module's bytecode and decodes it locally, so its
+// function handles and struct definitions can be checked without trusting
+// the node's normalized view.
+func (c *Client) InspectModule(ctx context.Context, pkg types.Address, module string) (*bytecode.Module, error) {
+	data, err := c.GetModuleBytecode(ctx, pkg, module)
+	if err != nil {
+		return nil, err
+	}
+	return bytecode.Parse(data)
+}
+
+// moduleSource reads a single representation of a module: "bytes" or
+// "disassembly".
+func (c *Client) moduleSource(ctx context.Context, pkg types.Address, module, field string) (*MoveModule, error) {
+	query := `
+		query GetModuleSource($address: SuiAddress!, $module: String!) {
+			object(address: $address) {
+				asMovePackage {
+					module(name: $module) { ` + field + ` }
+				}
+			}
+		}
+	`
+	var result struct {
+		Object *struct {
+			AsMovePackage *struct {
+				Module *MoveModule `json:"module"`
+			} `json:"asMovePackage"`
+		} `json:"object"`
+	}
+	found := func() bool {
+		if result.Object == nil || result.Object.AsMovePackage == nil || result.Object.AsMovePackage.Module == nil {
+			return false
+		}
+		m := result.Object.AsMovePackage.Module
+		if field == "bytes" {
+			return m.Bytes != nil
+		}
+		return m.Disassembly != nil
+	}
+	err := c.executeImmutable(ctx, packageCacheKey(pkg, cacheModuleSource, module, field), query, map[string]any{"address": pkg, "module": module}, &result, found)
+	if err != nil {
+		return nil, err
+	}
+	if !found() {
+		return nil, ErrModuleNotFound
+	}
+	return result.Object.AsMovePackage.Module, nil
+}
+
+// ErrPackageNotFound is returned when an address does not hold a Move
+// package.
+var ErrPackageNotFound = errors.New("package not found")
+
+// GetPackageBytecode returns the compiled bytecode of every module in a
+// published package, keyed by module name.
+func (c *Client) GetPackageBytecode(ctx context.Context, pkg types.Address) (map[string][]byte, error) {
+	query := `
+		query GetPackageBytecode($address: SuiAddress!, $after: String) {
+			object(address: $address) {
+				asMovePackage {
+					modules(first: 50, after: $after) {
+						pageInfo { hasNextPage endCursor }
+						nodes { name bytes }
+					}
+				}
+			}
+		}
+	`
+	modules := make(map[string][]byte)
+	var after *string
+	for {
+		var result struct {
+			Object *struct {
+				AsMovePackage *struct {
+					Modules *Connection[MoveModule] `json:"modules"`
+				} `json:"asMovePackage"`
+			} `json:"object"`
+		}
+		if err := c.Execute(ctx, query, map[string]any{"address": pkg, "after": after}, &result); err != nil {
+			return nil, err
+		}
+		if result.Object == nil || result.Object.AsMovePackage == nil || result.Object.AsMovePackage.Modules == nil {
+			return nil, ErrPackageNotFound
+		}
+		page := result.Object.AsMovePackage.Modules
+		for _, m := range page.Nodes {
+			if m.Bytes != nil {
+				modules[m.Name] = *m.Bytes
+			}
+		}
+		if !page.PageInfo.HasNextPage || page.PageInfo.EndCursor == nil {
+			return modules, nil
+		}
+		after = page.PageInfo.EndCursor
+	}
+}
+
+// VerifyPackage compares locally built modules, such as those returned by
+// bytecode.LoadBuildOutput, against the published package at pkg. The
+// report lists each module as verified, mismatched, or missing on one side.
+func (c *Client) VerifyPackage(ctx context.Context, pkg types.Address, local map[string][]byte) (*bytecode.VerificationReport, error) {
+	onChain, err := c.GetPackageBytecode(ctx, pkg)
+	if err != nil {
+		return nil, err
+	}
+	return bytecode.VerifyModules(local, onChain), nil
+}
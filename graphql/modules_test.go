@@ -0,0 +1,103 @@
+package graphql
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/bytecode"
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+func TestGetModuleSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		body := string(raw)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(body, `"missing"`):
+			w.Write([]byte(`{"data":{"object":{"asMovePackage":{"module":null}}}}`))
+		case strings.Contains(body, "bytes"):
+			w.Write([]byte(`{"data":{"object":{"asMovePackage":{"module":{"bytes":"oRzrCwYAAAA="}}}}}`))
+		default:
+			w.Write([]byte(`{"data":{"object":{"asMovePackage":{"module":{"disassembly":"module 2.coin {}"}}}}}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithEndpoint(server.URL), WithRetries(0))
+	pkg := utils.MustParseAddress("0x2")
+
+	code, err := client.GetModuleBytecode(context.Background(), pkg, "coin")
+	if err != nil {
+		t.Fatalf("bytecode: %v", err)
+	}
+	if want := []byte{0xA1, 0x1C, 0xEB, 0x0B, 6, 0, 0, 0}; string(code) != string(want) {
+		t.Fatalf("got %x, want %x", code, want)
+	}
+
+	disassembly, err := client.GetModuleDisassembly(context.Background(), pkg, "coin")
+	if err != nil || disassembly != "module 2.coin {}" {
+		t.Fatalf("disassembly %q %v", disassembly, err)
+	}
+
+	if _, err := client.GetModuleBytecode(context.Background(), pkg, "missing"); !errors.Is(err, ErrModuleNotFound) {
+		t.Fatalf("expected ErrModuleNotFound, got %v", err)
+	}
+}
+
+// minimalModule assembles a module with no functions or structs.
+func minimalModule(addr types.Address, name string) []byte {
+	data := []byte{0xA1, 0x1C, 0xEB, 0x0B, 6, 0, 0, 0, 3}
+	n := byte(len(name))
+	data = append(data, 7, 0, n+1, 8, n+1, 32, 1, n+33, 2)
+	data = append(data, n)
+	data = append(data, name...)
+	data = append(data, addr[:]...)
+	return append(data, 0, 0, 0)
+}
+
+func TestVerifyPackage(t *testing.T) {
+	pkg := utils.MustParseAddress("0xabc")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		module, cursor := "alpha", `"c1"`
+		next := true
+		if strings.Contains(string(raw), `"after":"c1"`) {
+			module, cursor, next = "beta", "null", false
+		}
+		encoded := base64.StdEncoding.EncodeToString(minimalModule(pkg, module))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"data":{"object":{"asMovePackage":{"modules":{"pageInfo":{"hasNextPage":%t,"endCursor":%s},"nodes":[{"name":%q,"bytes":%q}]}}}}}`, next, cursor, module, encoded)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithEndpoint(server.URL), WithRetries(0))
+	local := map[string][]byte{
+		"alpha": minimalModule(types.Address{}, "alpha"),
+		"beta":  minimalModule(types.Address{}, "beta"),
+	}
+	report, err := client.VerifyPackage(context.Background(), pkg, local)
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if !report.Verified() || len(report.Modules) != 2 {
+		t.Fatalf("expected both modules to verify, got %+v", report.Modules)
+	}
+
+	local["beta"] = minimalModule(types.Address{}, "gamma")
+	report, err = client.VerifyPackage(context.Background(), pkg, local)
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if failed := report.Failed(); len(failed) != 1 || failed[0].Name != "beta" || failed[0].Status != bytecode.StatusMismatch {
+		t.Fatalf("expected beta mismatch, got %+v", failed)
+	}
+}
@@ -0,0 +1,620 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+// =============================================================================
+// Move Value Decoding
+// =============================================================================
+
+// ErrMissingLayout is returned when a Move value has no type layout to decode
+// it with.
+var ErrMissingLayout = errors.New("move value has no type layout")
+
+// DecodeMoveValue decodes the JSON form of mv into T, using the type layout
+// selected with the value (contents { type { layout } json }) to interpret
+// it:
+//
+//   - u64, u128 and u256 strings decode into Go integers, *big.Int or big.Int;
+//   - addresses, IDs and UIDs decode into types.Address, or canonical
+//     0x-prefixed 64-digit strings;
+//   - vectors decode into slices, and vector<u8> into []byte as well;
+//   - structs decode into Go structs, matching fields by the `move` struct
+//     tag, then the `json` tag, then the field name ignoring case and
+//     underscores (so balance_value matches BalanceValue);
+//   - Option values decode into pointers, nil when empty.
+//
+// Decoding into interface{} yields uint64 for u8 to u64, *big.Int for u128 and
+// u256, and map[string]any for structs. Without a layout the JSON is decoded
+// with the same Go-side conversions but addresses are left as sent.
+func DecodeMoveValue[T any](mv MoveValue) (T, error) {
+	return DecodeMoveValueWithLayout[T](mv.Json, mv.Type.Layout)
+}
+
+// DecodeMoveValueWithLayout decodes the JSON form of a Move value into T
+// using layout, which may be nil. See DecodeMoveValue.
+func DecodeMoveValueWithLayout[T any](data json.RawMessage, layout *MoveTypeLayout) (T, error) {
+	var out T
+	if len(data) == 0 {
+		return out, errors.New("move value has no JSON contents")
+	}
+
+	raw, err := decodeJSONValue(data)
+	if err != nil {
+		return out, err
+	}
+
+	normalized := raw
+	if layout != nil && len(layout.RawMessage) > 0 {
+		parsed, err := parseMoveLayout(layout.RawMessage)
+		if err != nil {
+			return out, err
+		}
+		if normalized, err = normalizeMoveValue(raw, parsed); err != nil {
+			return out, err
+		}
+	}
+
+	if err := assignMoveValue(reflect.ValueOf(&out).Elem(), normalized); err != nil {
+		return out, err
+	}
+	return out, nil
+}
+
+// FetchLayoutAndDecode is DecodeMoveValue for values selected without their
+// layout: the layout of mv's type is fetched with GetMoveTypeLayout first.
+func FetchLayoutAndDecode[T any](c *Client, ctx context.Context, mv MoveValue) (T, error) {
+	if mv.Type.Layout == nil {
+		layout, err := c.GetMoveTypeLayout(ctx, mv.Type.Repr)
+		if err != nil {
+			var zero T
+			return zero, err
+		}
+		mv.Type.Layout = layout
+	}
+	return DecodeMoveValue[T](mv)
+}
+
+// GetMoveTypeLayout returns the layout of a fully instantiated Move type.
+// Layouts are cached by the client.
+func (c *Client) GetMoveTypeLayout(ctx context.Context, typeRepr string) (*MoveTypeLayout, error) {
+	normalized, err := NormalizeTypeTag(typeRepr)
+	if err != nil {
+		return nil, fmt.Errorf("normalize type %q: %w", typeRepr, err)
+	}
+	if cached, ok := c.layouts.Load(normalized); ok {
+		return cached.(*MoveTypeLayout), nil
+	}
+
+	query := `
+		query GetMoveTypeLayout($type: String!) {
+			type(type: $type) {
+				layout
+			}
+		}
+	`
+
+	var result struct {
+		Type *struct {
+			Layout *MoveTypeLayout `json:"layout"`
+		} `json:"type"`
+	}
+	if err := c.Execute(ctx, query, map[string]any{"type": normalized}, &result); err != nil {
+		return nil, err
+	}
+	if result.Type == nil || result.Type.Layout == nil {
+		return nil, fmt.Errorf("%w: %s", ErrMissingLayout, normalized)
+	}
+
+	c.layouts.Store(normalized, result.Type.Layout)
+	return result.Type.Layout, nil
+}
+
+// moveLayout is a parsed MoveTypeLayout. Exactly one of primitive, vector,
+// fields (structs) or variants (enums) is set.
+type moveLayout struct {
+	primitive string
+	vector    *moveLayout
+	typ       string
+	fields    []moveFieldLayout
+	variants  map[string][]moveFieldLayout
+}
+
+type moveFieldLayout struct {
+	name   string
+	layout *moveLayout
+}
+
+func parseMoveLayout(data json.RawMessage) (*moveLayout, error) {
+	var primitive string
+	if err := json.Unmarshal(data, &primitive); err == nil {
+		return &moveLayout{primitive: primitive}, nil
+	}
+
+	var composite struct {
+		Vector json.RawMessage `json:"vector"`
+		Struct *struct {
+			Type   string          `json:"type"`
+			Fields json.RawMessage `json:"fields"`
+		} `json:"struct"`
+		Enum *struct {
+			Type     string `json:"type"`
+			Variants []struct {
+				Name   string          `json:"name"`
+				Fields json.RawMessage `json:"fields"`
+			} `json:"variants"`
+		} `json:"enum"`
+	}
+	if err := json.Unmarshal(data, &composite); err != nil {
+		return nil, fmt.Errorf("parse move layout: %w", err)
+	}
+
+	switch {
+	case len(composite.Vector) > 0:
+		elem, err := parseMoveLayout(composite.Vector)
+		if err != nil {
+			return nil, err
+		}
+		return &moveLayout{vector: elem}, nil
+	case composite.Struct != nil:
+		fields, err := parseMoveFieldLayouts(composite.Struct.Fields)
+		if err != nil {
+			return nil, err
+		}
+		return &moveLayout{typ: composite.Struct.Type, fields: fields}, nil
+	case composite.Enum != nil:
+		layout := &moveLayout{typ: composite.Enum.Type, variants: make(map[string][]moveFieldLayout)}
+		for _, variant := range composite.Enum.Variants {
+			fields, err := parseMoveFieldLayouts(variant.Fields)
+			if err != nil {
+				return nil, err
+			}
+			layout.variants[variant.Name] = fields
+		}
+		return layout, nil
+	}
+	return nil, fmt.Errorf("parse move layout: unrecognized layout %s", data)
+}
+
+func parseMoveFieldLayouts(data json.RawMessage) ([]moveFieldLayout, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var raw []struct {
+		Name   string          `json:"name"`
+		Layout json.RawMessage `json:"layout"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parse move layout fields: %w", err)
+	}
+	fields := make([]moveFieldLayout, len(raw))
+	for i, field := range raw {
+		layout, err := parseMoveLayout(field.Layout)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		fields[i] = moveFieldLayout{name: field.Name, layout: layout}
+	}
+	return fields, nil
+}
+
+// decodeJSONValue decodes data keeping numbers as json.Number.
+func decodeJSONValue(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var v any
+	if err := decoder.Decode(&v); err != nil {
+		return nil, fmt.Errorf("decode move value json: %w", err)
+	}
+	return v, nil
+}
+
+// normalizeMoveValue converts v, decoded from JSON, into uint64, *big.Int,
+// canonical address strings, []any and map[string]any according to layout.
+func normalizeMoveValue(v any, layout *moveLayout) (any, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	switch {
+	case layout.primitive != "":
+		return normalizeMovePrimitive(v, layout.primitive)
+
+	case layout.vector != nil:
+		if s, ok := v.(string); ok && layout.vector.primitive == "u8" {
+			return s, nil // byte strings such as String's bytes are sent as text
+		}
+		elems, ok := v.([]any)
+		if !ok {
+			return nil, fmt.Errorf("expected array for vector, got %T", v)
+		}
+		out := make([]any, len(elems))
+		for i, elem := range elems {
+			n, err := normalizeMoveValue(elem, layout.vector)
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+			out[i] = n
+		}
+		return out, nil
+
+	case layout.variants != nil:
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("expected object for enum %s, got %T", layout.typ, v)
+		}
+		name, _ := obj["@variant"].(string)
+		fields, ok := layout.variants[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown variant %q of enum %s", name, layout.typ)
+		}
+		return normalizeMoveFields(obj, fields)
+	}
+
+	obj, ok := v.(map[string]any)
+	if ok {
+		return normalizeMoveFields(obj, layout.fields)
+	}
+	// Some structs are flattened into their single field's value: Option
+	// into the value or null, UID and ID into an address, String into text,
+	// Balance into its amount.
+	if isOptionType(layout.typ) && len(layout.fields) == 1 && layout.fields[0].layout.vector != nil {
+		return normalizeMoveValue(v, layout.fields[0].layout.vector)
+	}
+	if len(layout.fields) == 1 {
+		return normalizeMoveValue(v, layout.fields[0].layout)
+	}
+	return nil, fmt.Errorf("expected object for struct %s, got %T", layout.typ, v)
+}
+
+func normalizeMoveFields(obj map[string]any, fields []moveFieldLayout) (map[string]any, error) {
+	out := make(map[string]any, len(obj))
+	for key, value := range obj {
+		out[key] = value
+	}
+	for _, field := range fields {
+		value, ok := obj[field.name]
+		if !ok {
+			continue
+		}
+		n, err := normalizeMoveValue(value, field.layout)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", field.name, err)
+		}
+		out[field.name] = n
+	}
+	return out, nil
+}
+
+func normalizeMovePrimitive(v any, primitive string) (any, error) {
+	switch primitive {
+	case "bool":
+		if b, ok := v.(bool); ok {
+			return b, nil
+		}
+		return nil, fmt.Errorf("expected bool, got %T", v)
+	case "address", "signer":
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected address string, got %T", v)
+		}
+		return utils.NormalizeAddress(s)
+	case "u8", "u16", "u32", "u64":
+		n, err := moveInteger(v)
+		if err != nil {
+			return nil, err
+		}
+		if !n.IsUint64() {
+			return nil, fmt.Errorf("%s value %s out of range", primitive, n)
+		}
+		return n.Uint64(), nil
+	case "u128", "u256":
+		return moveInteger(v)
+	}
+	return v, nil
+}
+
+// moveInteger parses an integer sent as a JSON number or string.
+func moveInteger(v any) (*big.Int, error) {
+	var s string
+	switch x := v.(type) {
+	case json.Number:
+		s = x.String()
+	case string:
+		s = x
+	case uint64:
+		return new(big.Int).SetUint64(x), nil
+	case *big.Int:
+		return x, nil
+	default:
+		return nil, fmt.Errorf("expected integer, got %T", v)
+	}
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid integer %q", s)
+	}
+	return n, nil
+}
+
+func isOptionType(typ string) bool {
+	base, _, _ := strings.Cut(typ, "<")
+	return strings.HasSuffix(base, "::option::Option")
+}
+
+var (
+	addressType     = reflect.TypeOf(types.Address{})
+	bigIntType      = reflect.TypeOf(big.Int{})
+	rawMessageType  = reflect.TypeOf(json.RawMessage{})
+	unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+// assignMoveValue stores a normalized value in dst.
+func assignMoveValue(dst reflect.Value, v any) error {
+	if v == nil {
+		dst.SetZero()
+		return nil
+	}
+
+	switch dst.Type() {
+	case addressType:
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("cannot decode %T into address", v)
+		}
+		addr, err := utils.ParseAddress(s)
+		if err != nil {
+			return err
+		}
+		dst.Set(reflect.ValueOf(addr))
+		return nil
+	case bigIntType:
+		n, err := moveInteger(v)
+		if err != nil {
+			return err
+		}
+		dst.Set(reflect.ValueOf(*n))
+		return nil
+	case rawMessageType:
+		data, err := json.Marshal(jsonCompatible(v))
+		if err != nil {
+			return err
+		}
+		dst.SetBytes(data)
+		return nil
+	}
+
+	if dst.Kind() != reflect.Pointer && dst.Kind() != reflect.Interface && dst.Addr().Type().Implements(unmarshalerType) {
+		data, err := json.Marshal(jsonCompatible(v))
+		if err != nil {
+			return err
+		}
+		return dst.Addr().Interface().(json.Unmarshaler).UnmarshalJSON(data)
+	}
+
+	switch dst.Kind() {
+	case reflect.Pointer:
+		elem := reflect.New(dst.Type().Elem())
+		if err := assignMoveValue(elem.Elem(), v); err != nil {
+			return err
+		}
+		dst.Set(elem)
+		return nil
+
+	case reflect.Interface:
+		if dst.NumMethod() != 0 {
+			return fmt.Errorf("cannot decode into %s", dst.Type())
+		}
+		dst.Set(reflect.ValueOf(plainMoveValue(v)))
+		return nil
+
+	case reflect.Bool:
+		b, ok := v.(bool)
+		if !ok {
+			return fmt.Errorf("cannot decode %T into bool", v)
+		}
+		dst.SetBool(b)
+		return nil
+
+	case reflect.String:
+		switch x := v.(type) {
+		case string:
+			dst.SetString(x)
+		case json.Number:
+			dst.SetString(x.String())
+		case uint64:
+			dst.SetString(strconv.FormatUint(x, 10))
+		case *big.Int:
+			dst.SetString(x.String())
+		default:
+			return fmt.Errorf("cannot decode %T into string", v)
+		}
+		return nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := moveInteger(v)
+		if err != nil {
+			return err
+		}
+		if !n.IsUint64() || dst.OverflowUint(n.Uint64()) {
+			return fmt.Errorf("value %s overflows %s", n, dst.Type())
+		}
+		dst.SetUint(n.Uint64())
+		return nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := moveInteger(v)
+		if err != nil {
+			return err
+		}
+		if !n.IsInt64() || dst.OverflowInt(n.Int64()) {
+			return fmt.Errorf("value %s overflows %s", n, dst.Type())
+		}
+		dst.SetInt(n.Int64())
+		return nil
+
+	case reflect.Float32, reflect.Float64:
+		n, err := moveInteger(v)
+		if err != nil {
+			return err
+		}
+		f, _ := new(big.Float).SetInt(n).Float64()
+		if dst.Kind() == reflect.Float32 && f > math.MaxFloat32 {
+			return fmt.Errorf("value %s overflows %s", n, dst.Type())
+		}
+		dst.SetFloat(f)
+		return nil
+
+	case reflect.Slice:
+		if s, ok := v.(string); ok && dst.Type().Elem().Kind() == reflect.Uint8 {
+			dst.SetBytes([]byte(s))
+			return nil
+		}
+		elems, ok := v.([]any)
+		if !ok {
+			return fmt.Errorf("cannot decode %T into %s", v, dst.Type())
+		}
+		slice := reflect.MakeSlice(dst.Type(), len(elems), len(elems))
+		for i, elem := range elems {
+			if err := assignMoveValue(slice.Index(i), elem); err != nil {
+				return fmt.Errorf("[%d]: %w", i, err)
+			}
+		}
+		dst.Set(slice)
+		return nil
+
+	case reflect.Map:
+		obj, ok := v.(map[string]any)
+		if !ok || dst.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("cannot decode %T into %s", v, dst.Type())
+		}
+		m := reflect.MakeMapWithSize(dst.Type(), len(obj))
+		for key, value := range obj {
+			elem := reflect.New(dst.Type().Elem()).Elem()
+			if err := assignMoveValue(elem, value); err != nil {
+				return fmt.Errorf("%s: %w", key, err)
+			}
+			m.SetMapIndex(reflect.ValueOf(key).Convert(dst.Type().Key()), elem)
+		}
+		dst.Set(m)
+		return nil
+
+	case reflect.Struct:
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return fmt.Errorf("cannot decode %T into %s", v, dst.Type())
+		}
+		return assignMoveStruct(dst, obj)
+	}
+	return fmt.Errorf("cannot decode into %s", dst.Type())
+}
+
+func assignMoveStruct(dst reflect.Value, obj map[string]any) error {
+	byKey := make(map[string]string, len(obj))
+	for key := range obj {
+		byKey[foldMoveName(key)] = key
+	}
+
+	t := dst.Type()
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		key, ok := moveFieldKey(field, obj, byKey)
+		if !ok {
+			continue
+		}
+		if err := assignMoveValue(dst.Field(i), obj[key]); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// moveFieldKey finds the JSON key that feeds a Go struct field.
+func moveFieldKey(field reflect.StructField, obj map[string]any, byKey map[string]string) (string, bool) {
+	for _, tagName := range []string{"move", "json"} {
+		tag, ok := field.Tag.Lookup(tagName)
+		if !ok {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			return "", false
+		}
+		if name != "" {
+			_, found := obj[name]
+			return name, found
+		}
+	}
+	key, ok := byKey[foldMoveName(field.Name)]
+	return key, ok
+}
+
+func foldMoveName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
+
+// plainMoveValue converts json.Number left by layout-less decoding into
+// uint64 or *big.Int for interface{} targets.
+func plainMoveValue(v any) any {
+	switch x := v.(type) {
+	case json.Number:
+		if n, err := moveInteger(x); err == nil {
+			if n.IsUint64() {
+				return n.Uint64()
+			}
+			return n
+		}
+		return x.String()
+	case []any:
+		out := make([]any, len(x))
+		for i, elem := range x {
+			out[i] = plainMoveValue(elem)
+		}
+		return out
+	case map[string]any:
+		out := make(map[string]any, len(x))
+		for key, elem := range x {
+			out[key] = plainMoveValue(elem)
+		}
+		return out
+	}
+	return v
+}
+
+// jsonCompatible prepares a normalized value for json.Marshal, rendering
+// big integers as strings like the service does.
+func jsonCompatible(v any) any {
+	switch x := v.(type) {
+	case *big.Int:
+		return x.String()
+	case []any:
+		out := make([]any, len(x))
+		for i, elem := range x {
+			out[i] = jsonCompatible(elem)
+		}
+		return out
+	case map[string]any:
+		out := make(map[string]any, len(x))
+		for key, elem := range x {
+			out[key] = jsonCompatible(elem)
+		}
+		return out
+	}
+	return v
+}
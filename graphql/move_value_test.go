@@ -0,0 +1,122 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+const poolLayout = `{"struct":{"type":"0x5::pool::Pool","fields":[
+	{"name":"id","layout":{"struct":{"type":"0x2::object::UID","fields":[{"name":"id","layout":{"struct":{"type":"0x2::object::ID","fields":[{"name":"bytes","layout":"address"}]}}}]}}},
+	{"name":"admin","layout":"address"},
+	{"name":"fee_bps","layout":"u16"},
+	{"name":"reserve","layout":"u64"},
+	{"name":"liquidity","layout":"u128"},
+	{"name":"paused","layout":"bool"},
+	{"name":"name","layout":{"struct":{"type":"0x1::string::String","fields":[{"name":"bytes","layout":{"vector":"u8"}}]}}},
+	{"name":"tag","layout":{"vector":"u8"}},
+	{"name":"holders","layout":{"vector":"address"}},
+	{"name":"referrer","layout":{"struct":{"type":"0x1::option::Option<address>","fields":[{"name":"vec","layout":{"vector":"address"}}]}}},
+	{"name":"owner_cap","layout":{"struct":{"type":"0x1::option::Option<u64>","fields":[{"name":"vec","layout":{"vector":"u64"}}]}}}
+]}}`
+
+const poolJSON = `{
+	"id": "0x7",
+	"admin": "0xa",
+	"fee_bps": 30,
+	"reserve": "18446744073709551615",
+	"liquidity": "340282366920938463463374607431768211455",
+	"paused": false,
+	"name": "SUI/USDC",
+	"tag": [1, 2, 3],
+	"holders": ["0xb", "0xc"],
+	"referrer": "0xd",
+	"owner_cap": null
+}`
+
+type pool struct {
+	ID        types.Address
+	Admin     string
+	FeeBps    uint16 `move:"fee_bps"`
+	Reserve   uint64
+	Liquidity *big.Int
+	Paused    bool
+	Name      string
+	Tag       []byte
+	Holders   []types.Address
+	Referrer  *types.Address
+	OwnerCap  *uint64
+}
+
+func TestDecodeMoveValue(t *testing.T) {
+	mv := MoveValue{
+		Type: MoveType{Repr: "0x5::pool::Pool", Layout: &MoveTypeLayout{RawMessage: json.RawMessage(poolLayout)}},
+		Json: json.RawMessage(poolJSON),
+	}
+
+	got, err := DecodeMoveValue[pool](mv)
+	if err != nil {
+		t.Fatalf("DecodeMoveValue: %v", err)
+	}
+	if got.ID != utils.MustParseAddress("0x7") || got.Admin != utils.MustParseAddress("0xa").String() {
+		t.Fatalf("unexpected addresses %s %s", got.ID, got.Admin)
+	}
+	if got.FeeBps != 30 || got.Reserve != 18446744073709551615 || got.Liquidity.String() != "340282366920938463463374607431768211455" {
+		t.Fatalf("unexpected numbers %+v", got)
+	}
+	if got.Name != "SUI/USDC" || string(got.Tag) != "\x01\x02\x03" || len(got.Holders) != 2 || got.Holders[1] != utils.MustParseAddress("0xc") {
+		t.Fatalf("unexpected values %+v", got)
+	}
+	if got.Referrer == nil || *got.Referrer != utils.MustParseAddress("0xd") || got.OwnerCap != nil {
+		t.Fatalf("unexpected options %+v", got)
+	}
+
+	generic, err := DecodeMoveValue[map[string]any](mv)
+	if err != nil {
+		t.Fatalf("DecodeMoveValue into map: %v", err)
+	}
+	if generic["reserve"] != uint64(18446744073709551615) || generic["fee_bps"] != uint64(30) {
+		t.Fatalf("unexpected generic numbers %v %v", generic["reserve"], generic["fee_bps"])
+	}
+	if _, ok := generic["liquidity"].(*big.Int); !ok {
+		t.Fatalf("u128 decoded as %T", generic["liquidity"])
+	}
+
+	if _, err := DecodeMoveValue[struct{ FeeBps uint8 }](mv); err != nil {
+		t.Fatalf("decode fee into uint8: %v", err)
+	}
+	if _, err := DecodeMoveValue[struct{ Reserve uint32 }](mv); err == nil {
+		t.Fatal("expected overflow error")
+	}
+}
+
+func TestFetchLayoutAndDecode(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"type": map[string]any{"layout": json.RawMessage(poolLayout)}}})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithEndpoint(server.URL))
+	mv := MoveValue{Type: MoveType{Repr: "0x5::pool::Pool"}, Json: json.RawMessage(poolJSON)}
+	for range 2 {
+		got, err := FetchLayoutAndDecode[pool](client, context.Background(), mv)
+		if err != nil {
+			t.Fatalf("FetchLayoutAndDecode: %v", err)
+		}
+		if got.Reserve != 18446744073709551615 {
+			t.Fatalf("unexpected reserve %d", got.Reserve)
+		}
+	}
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("layout fetched %d times, want 1", n)
+	}
+}
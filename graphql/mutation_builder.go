@@ -112,7 +112,7 @@ func (smb *SimulateMutationBuilder) buildEffectsBlock() string {
 		return `
 			digest
 			status
-			executionError { message }
+			executionError { message abortCode sourceLineNumber instructionOffset identifier constant module { name package { address } } }
 			gasEffects {
 				gasSummary {
 					computationCost
@@ -164,7 +164,7 @@ func (smb *SimulateMutationBuilder) buildEffectsBlock() string {
 				}
 			`
 		case "executionError":
-			result += "executionError { message }\n"
+			result += "executionError { message abortCode sourceLineNumber instructionOffset identifier constant module { name package { address } } }\n"
 		}
 	}
 	return result
@@ -269,7 +269,7 @@ func (emb *ExecuteMutationBuilder) buildEffectsBlock() string {
 		return `
 			digest
 			status
-			executionError { message }
+			executionError { message abortCode sourceLineNumber instructionOffset identifier constant module { name package { address } } }
 			lamportVersion
 			gasEffects {
 				gasSummary {
@@ -331,7 +331,7 @@ func (emb *ExecuteMutationBuilder) buildEffectsBlock() string {
 				}
 			`
 		case "executionError":
-			result += "executionError { message }\n"
+			result += "executionError { message abortCode sourceLineNumber instructionOffset identifier constant module { name package { address } } }\n"
 		}
 	}
 	return result
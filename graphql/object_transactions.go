@@ -0,0 +1,245 @@
+package graphql
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/open-move/sui-go-sdk/types"
+)
+
+// =============================================================================
+// Object Transaction History
+// =============================================================================
+
+// ObjectTransaction is a transaction that used or changed an object.
+type ObjectTransaction struct {
+	Transaction
+	// Input reports that the object was an input of the transaction.
+	Input bool
+	// Changed reports that the transaction created, mutated, wrapped or
+	// deleted the object.
+	Changed bool
+}
+
+// GetObjectTransactions returns the transactions that took objectID as an
+// input or changed it, oldest first, each once with both relationships
+// marked. It merges the inputObject and changedObject filters; transactions
+// are ordered by checkpoint, and within a checkpoint by the order of the
+// filter that returned them.
+//
+// Only forward pagination is supported: pass the returned EndCursor as After
+// to read the next page. Transactions select their inputs and effects
+// summary.
+func (c *Client) GetObjectTransactions(ctx context.Context, objectID types.Address, pagination *PaginationArgs) (*Connection[ObjectTransaction], error) {
+	options := &TransactionBlockOptions{ShowInput: true, ShowEffects: true}
+	paired, pageInfo, err := c.mergedTransactions(ctx, "object transactions", TransactionFilter{InputObject: &objectID}, TransactionFilter{ChangedObject: &objectID}, pagination, options)
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([]ObjectTransaction, len(paired))
+	for i, tx := range paired {
+		nodes[i] = ObjectTransaction{Transaction: tx.Transaction, Input: tx.first, Changed: tx.second}
+	}
+	return &Connection[ObjectTransaction]{PageInfo: pageInfo, Nodes: nodes}, nil
+}
+
+// pairedTransaction is a transaction returned by one or both of the filters
+// merged by mergedTransactions.
+type pairedTransaction struct {
+	Transaction
+	first, second bool
+}
+
+// pairCursor is the position in both underlying connections of a merged
+// history, encoded as its opaque cursor.
+type pairCursor struct {
+	First      *string `json:"i,omitempty"`
+	Second     *string `json:"c,omitempty"`
+	FirstDone  bool    `json:"id,omitempty"`
+	SecondDone bool    `json:"cd,omitempty"`
+}
+
+// mergedTransactions reads a page of the transactions matching either filter,
+// ordered by checkpoint and deduplicated by digest. label prefixes errors.
+func (c *Client) mergedTransactions(ctx context.Context, label string, first, second TransactionFilter, pagination *PaginationArgs, options *TransactionBlockOptions) ([]pairedTransaction, PageInfo, error) {
+	limit := defaultPageSize
+	var cursor pairCursor
+	if pagination != nil {
+		if pagination.Last != nil || pagination.Before != nil {
+			return nil, PageInfo{}, fmt.Errorf("%s: only forward pagination is supported", label)
+		}
+		if pagination.First != nil && *pagination.First > 0 {
+			limit = *pagination.First
+		}
+		if pagination.After != nil {
+			decoded, err := decodePairCursor(*pagination.After)
+			if err != nil {
+				return nil, PageInfo{}, fmt.Errorf("%s: invalid cursor: %w", label, err)
+			}
+			cursor = decoded
+		}
+	}
+
+	selection := transactionSelection(options)
+	var firsts, seconds *Connection[Transaction]
+	var err error
+	if !cursor.FirstDone {
+		if firsts, err = c.transactionEdges(ctx, selection, first, limit, cursor.First); err != nil {
+			return nil, PageInfo{}, err
+		}
+	}
+	if !cursor.SecondDone {
+		if seconds, err = c.transactionEdges(ctx, selection, second, limit, cursor.Second); err != nil {
+			return nil, PageInfo{}, err
+		}
+	}
+
+	nodes, next := mergeTransactionPages(firsts, seconds, limit, cursor)
+	encoded, err := encodePairCursor(next)
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	return nodes, PageInfo{
+		HasNextPage:     !next.FirstDone || !next.SecondDone,
+		HasPreviousPage: pagination != nil && pagination.After != nil,
+		EndCursor:       &encoded,
+	}, nil
+}
+
+func (c *Client) transactionEdges(ctx context.Context, selection string, filter TransactionFilter, first int, after *string) (*Connection[Transaction], error) {
+	query := fmt.Sprintf(`
+		query GetTransactionEdges($filter: TransactionFilter, $first: Int, $after: String) {
+			transactions(filter: $filter, first: $first, after: $after) {
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+				edges {
+					cursor
+					node {
+						%s
+					}
+				}
+			}
+		}
+	`, selection)
+
+	vars := map[string]any{"filter": filter, "first": first}
+	if after != nil {
+		vars["after"] = *after
+	}
+
+	var result struct {
+		Transactions *Connection[Transaction] `json:"transactions"`
+	}
+	if err := c.Execute(ctx, query, vars, &result); err != nil {
+		return nil, err
+	}
+	if result.Transactions == nil {
+		return &Connection[Transaction]{}, nil
+	}
+	return result.Transactions, nil
+}
+
+// mergeTransactionPages merges the two pages by checkpoint, up to limit
+// transactions. A connection with more pages bounds the merge: only
+// transactions before its last checkpoint are certain to precede its next
+// page, so later ones are left for the next call. It returns the merged
+// transactions and the cursor after them.
+func mergeTransactionPages(firsts, seconds *Connection[Transaction], limit int, cursor pairCursor) ([]pairedTransaction, pairCursor) {
+	firstEdges, firstMore := pageEdges(firsts)
+	secondEdges, secondMore := pageEdges(seconds)
+
+	bound := uint64(math.MaxUint64)
+	if firstMore && len(firstEdges) > 0 {
+		bound = min(bound, transactionCheckpoint(firstEdges[len(firstEdges)-1].Node))
+	}
+	if secondMore && len(secondEdges) > 0 {
+		bound = min(bound, transactionCheckpoint(secondEdges[len(secondEdges)-1].Node))
+	}
+
+	merge := func(strict bool) ([]pairedTransaction, pairCursor) {
+		next := cursor
+		var nodes []pairedTransaction
+		seen := make(map[string]int)
+		i, j := 0, 0
+		for len(nodes) < limit && (i < len(firstEdges) || j < len(secondEdges)) {
+			fromFirst := j >= len(secondEdges) ||
+				(i < len(firstEdges) && transactionCheckpoint(firstEdges[i].Node) <= transactionCheckpoint(secondEdges[j].Node))
+			edge := secondEdges
+			k := j
+			if fromFirst {
+				edge, k = firstEdges, i
+			}
+			checkpoint := transactionCheckpoint(edge[k].Node)
+			if checkpoint > bound || (strict && checkpoint == bound) {
+				break
+			}
+
+			key := edge[k].Node.Digest.String()
+			idx, ok := seen[key]
+			if !ok {
+				idx = len(nodes)
+				seen[key] = idx
+				nodes = append(nodes, pairedTransaction{Transaction: edge[k].Node})
+			}
+			cursorValue := edge[k].Cursor
+			if fromFirst {
+				nodes[idx].first = true
+				next.First = &cursorValue
+				i++
+			} else {
+				nodes[idx].second = true
+				next.Second = &cursorValue
+				j++
+			}
+		}
+		next.FirstDone = cursor.FirstDone || (!firstMore && i == len(firstEdges))
+		next.SecondDone = cursor.SecondDone || (!secondMore && j == len(secondEdges))
+		return nodes, next
+	}
+
+	// Stopping short of the bound keeps a transaction returned by both
+	// filters on one page; fall back to including it when nothing else fits.
+	nodes, next := merge(bound != math.MaxUint64)
+	if len(nodes) == 0 {
+		nodes, next = merge(false)
+	}
+	return nodes, next
+}
+
+func pageEdges(page *Connection[Transaction]) ([]Edge[Transaction], bool) {
+	if page == nil {
+		return nil, false
+	}
+	return page.Edges, page.PageInfo.HasNextPage
+}
+
+// transactionCheckpoint returns the transaction's checkpoint, ordering
+// transactions without one last.
+func transactionCheckpoint(tx Transaction) uint64 {
+	if tx.Effects == nil || tx.Effects.Checkpoint == nil {
+		return math.MaxUint64
+	}
+	return uint64(tx.Effects.Checkpoint.SequenceNumber)
+}
+
+func encodePairCursor(cursor pairCursor) (string, error) {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodePairCursor(encoded string) (pairCursor, error) {
+	var cursor pairCursor
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err == nil {
+		err = json.Unmarshal(data, &cursor)
+	}
+	return cursor, err
+}
@@ -0,0 +1,108 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+func TestGetObjectTransactionsMergesFilters(t *testing.T) {
+	digest := func(n byte) string { return types.Digest(bytes.Repeat([]byte{n}, 32)).String() }
+	type entry struct {
+		digest     byte
+		checkpoint int
+	}
+	// Transaction 2 both read and changed the object.
+	history := map[string][]entry{
+		"inputObject":   {{1, 10}, {2, 11}, {4, 13}},
+		"changedObject": {{2, 11}, {3, 12}, {5, 14}},
+	}
+
+	object := utils.MustParseAddress("0xabc")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables struct {
+				Filter map[string]any `json:"filter"`
+				First  int            `json:"first"`
+				After  *string        `json:"after"`
+			} `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		var entries []entry
+		for key, value := range req.Variables.Filter {
+			if value != object.String() {
+				t.Errorf("unexpected filter %v", req.Variables.Filter)
+			}
+			entries = history[key]
+		}
+		start := 0
+		if req.Variables.After != nil {
+			start = int((*req.Variables.After)[0]-'0') + 1
+		}
+		end := min(start+req.Variables.First, len(entries))
+		var edges []any
+		for i := start; i < end; i++ {
+			edges = append(edges, map[string]any{
+				"cursor": string(rune('0' + i)),
+				"node": map[string]any{
+					"digest":  digest(entries[i].digest),
+					"effects": map[string]any{"checkpoint": map[string]any{"sequenceNumber": entries[i].checkpoint}},
+				},
+			})
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"transactions": map[string]any{
+			"pageInfo": map[string]any{"hasNextPage": end < len(entries)},
+			"edges":    edges,
+		}}})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithEndpoint(server.URL), WithRetries(0))
+	var got []string
+	var flags []bool
+	pagination := &PaginationArgs{First: utils.Ptr(2)}
+	for pages := 0; ; pages++ {
+		if pages > 10 {
+			t.Fatal("pagination did not terminate")
+		}
+		page, err := client.GetObjectTransactions(context.Background(), object, pagination)
+		if err != nil {
+			t.Fatalf("page %d: %v", pages, err)
+		}
+		for _, node := range page.Nodes {
+			got = append(got, node.Digest.String())
+			flags = append(flags, node.Input, node.Changed)
+		}
+		if !page.PageInfo.HasNextPage {
+			break
+		}
+		pagination = &PaginationArgs{First: utils.Ptr(2), After: page.PageInfo.EndCursor}
+	}
+
+	want := []string{digest(1), digest(2), digest(3), digest(4), digest(5)}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d transactions, got %d", len(want), len(got))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("transaction %d: expected %s, got %s", i, want[i], got[i])
+		}
+	}
+	wantFlags := []bool{true, false, true, true, false, true, true, false, false, true}
+	for i := range wantFlags {
+		if flags[i] != wantFlags[i] {
+			t.Fatalf("unexpected relationships %v", flags)
+		}
+	}
+
+	if _, err := client.GetObjectTransactions(context.Background(), object, &PaginationArgs{Last: utils.Ptr(1)}); err == nil {
+		t.Fatal("expected backward pagination to be rejected")
+	}
+}
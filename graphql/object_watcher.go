@@ -0,0 +1,142 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/open-move/sui-go-sdk/types"
+)
+
+// =============================================================================
+// Object Watcher
+// =============================================================================
+
+// ObjectUpdate reports a change to a watched object.
+type ObjectUpdate struct {
+	ObjectID types.Address
+	// PreviousVersion is the version seen at the previous poll. Polling can
+	// skip versions when the object changes several times per interval.
+	PreviousVersion UInt53
+	Version         UInt53
+	Digest          types.Digest
+	// TransactionDigest is the transaction that produced Version.
+	TransactionDigest types.Digest
+	// Deleted is set when the object no longer exists (deleted or wrapped).
+	Deleted bool
+	// Object is the object's current state; nil when Deleted.
+	Object *Object
+	// Err is set when a poll failed. Watching continues after errors.
+	Err error
+}
+
+type watchedObject struct {
+	Object
+	PreviousTransaction *TransactionRef `json:"previousTransaction"`
+}
+
+// WatchObject polls an object every interval and sends an update each time
+// its version or digest changes, or when it is deleted. The object's state at
+// the time of the call is the baseline and is not sent. The channel is closed
+// when ctx is cancelled. It is useful for watching shared objects such as
+// pools or price oracles.
+func (c *Client) WatchObject(ctx context.Context, objectID types.Address, interval time.Duration) (<-chan ObjectUpdate, error) {
+	if ctx == nil {
+		return nil, errors.New("nil context")
+	}
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	current, err := c.fetchWatchedObject(ctx, objectID)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make(chan ObjectUpdate)
+	go func() {
+		defer close(updates)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		send := func(update ObjectUpdate) bool {
+			select {
+			case updates <- update:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			next, err := c.fetchWatchedObject(ctx, objectID)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				if !send(ObjectUpdate{ObjectID: objectID, Err: err}) {
+					return
+				}
+				continue
+			}
+
+			update, changed := objectUpdate(objectID, current, next)
+			current = next
+			if changed && !send(update) {
+				return
+			}
+		}
+	}()
+	return updates, nil
+}
+
+func (c *Client) fetchWatchedObject(ctx context.Context, objectID types.Address) (*watchedObject, error) {
+	query := fmt.Sprintf(`
+		query WatchObject($objectId: SuiAddress!) {
+			object(address: $objectId) {
+				%s
+			}
+		}
+	`, objectSelection(nil))
+
+	var result struct {
+		Object *watchedObject `json:"object"`
+	}
+	if err := c.Execute(ctx, query, map[string]any{"objectId": objectID}, &result); err != nil {
+		return nil, err
+	}
+	return result.Object, nil
+}
+
+// objectUpdate compares two polls of the same object.
+func objectUpdate(objectID types.Address, prev, next *watchedObject) (ObjectUpdate, bool) {
+	update := ObjectUpdate{ObjectID: objectID}
+	if prev != nil {
+		update.PreviousVersion = prev.Version
+	}
+
+	switch {
+	case next == nil:
+		update.Deleted = true
+		return update, prev != nil
+	case prev != nil && prev.Version == next.Version && prev.Digest.String() == next.Digest.String():
+		return update, false
+	}
+
+	update.Version = next.Version
+	update.Digest = next.Digest
+	if next.PreviousTransaction != nil {
+		update.TransactionDigest = next.PreviousTransaction.Digest
+	}
+	object := next.Object
+	update.Object = &object
+	return update, true
+}
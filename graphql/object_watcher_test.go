@@ -0,0 +1,76 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+func testDigest(b byte) string {
+	return types.Digest(bytes.Repeat([]byte{b}, 32)).String()
+}
+
+func TestWatchObject(t *testing.T) {
+	objectJSON := func(version int, digest byte) string {
+		return fmt.Sprintf(`{"data":{"object":{"address":"0x5","version":%d,"digest":%q,"previousTransaction":{"digest":%q}}}}`,
+			version, testDigest(digest), testDigest(digest+100))
+	}
+	responses := []string{
+		objectJSON(1, 1),
+		objectJSON(1, 1), // unchanged, no update
+		objectJSON(4, 2),
+		`{"errors":[{"message":"overloaded"}]}`,
+		`{"data":{"object":null}}`,
+	}
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		i := int(calls.Add(1)) - 1
+		if i >= len(responses) {
+			i = len(responses) - 1
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(responses[i]))
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	client := NewClient(WithEndpoint(server.URL), WithRetries(0))
+	updates, err := client.WatchObject(ctx, utils.MustParseAddress("0x5"), time.Millisecond)
+	if err != nil {
+		t.Fatalf("WatchObject: %v", err)
+	}
+
+	changed := <-updates
+	if changed.Err != nil || changed.Deleted {
+		t.Fatalf("unexpected update %+v", changed)
+	}
+	if changed.PreviousVersion != 1 || changed.Version != 4 || changed.Digest.String() != testDigest(2) {
+		t.Fatalf("unexpected versions %+v", changed)
+	}
+	if changed.TransactionDigest.String() != testDigest(102) || changed.Object == nil {
+		t.Fatalf("unexpected transaction digest %s", changed.TransactionDigest)
+	}
+
+	if failed := <-updates; failed.Err == nil {
+		t.Fatalf("expected poll error, got %+v", failed)
+	}
+
+	deleted := <-updates
+	if !deleted.Deleted || deleted.PreviousVersion != 4 || deleted.Object != nil {
+		t.Fatalf("expected deletion, got %+v", deleted)
+	}
+
+	cancel()
+	for range updates {
+	}
+}
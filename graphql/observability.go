@@ -0,0 +1,165 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"regexp"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// =============================================================================
+// Logging and Tracing
+// =============================================================================
+
+// tracerName identifies spans emitted by this package.
+const tracerName = "github.com/open-move/sui-go-sdk/graphql"
+
+// Attribute keys recorded on spans and log records. Variable values and
+// request headers are never recorded, since they may carry transaction bytes,
+// signatures or credentials.
+const (
+	attrOperationName = "graphql.operation.name"
+	attrOperationType = "graphql.operation.type"
+	attrVariablesSize = "graphql.variables.size"
+	attrRetryCount    = "sui.retry.count"
+	attrLatency       = "sui.latency"
+)
+
+// WithLogger makes the client log one record per operation with its name,
+// variables size, latency and retry count. Successful operations are logged
+// at debug level and failures at warn level.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithTracer makes the client emit an OpenTelemetry span per operation.
+func WithTracer(provider trace.TracerProvider) ClientOption {
+	return func(c *Client) {
+		if provider == nil {
+			c.tracer = nil
+			return
+		}
+		c.tracer = provider.Tracer(tracerName)
+	}
+}
+
+var operationPattern = regexp.MustCompile(`^\s*(query|mutation|subscription)\b\s*([_A-Za-z][_0-9A-Za-z]*)?`)
+
+// operation tracks the observable properties of a single Execute call.
+type operation struct {
+	name          string
+	kind          string
+	variablesSize int
+
+	// Retries and HTTP exchanges are noted from the request path.
+	mu            sync.Mutex
+	retries       int
+	requestBytes  int
+	responseBytes int
+	rateLimited   int
+	throttled     time.Duration
+}
+
+type operationKey struct{}
+
+func newOperation(query string, variables map[string]any) *operation {
+	op := &operation{name: "anonymous", kind: "query"}
+	if m := operationPattern.FindStringSubmatch(query); m != nil {
+		op.kind = m[1]
+		if m[2] != "" {
+			op.name = m[2]
+		}
+	}
+	if len(variables) > 0 {
+		if encoded, err := json.Marshal(variables); err == nil {
+			op.variablesSize = len(encoded)
+		}
+	}
+	return op
+}
+
+// noteRetry records a retry on the operation carried by ctx, if any.
+func noteRetry(ctx context.Context, attempt int) {
+	if op, ok := ctx.Value(operationKey{}).(*operation); ok {
+		op.mu.Lock()
+		op.retries = attempt
+		op.mu.Unlock()
+	}
+	trace.SpanFromContext(ctx).AddEvent("retry", trace.WithAttributes(attribute.Int(attrRetryCount, attempt)))
+}
+
+// observe runs fn as a single instrumented operation.
+func (c *Client) observe(ctx context.Context, query string, variables map[string]any, fn func(context.Context) error) error {
+	if c.logger == nil && c.tracer == nil && c.metrics == nil {
+		return fn(ctx)
+	}
+
+	op := newOperation(query, variables)
+	ctx = context.WithValue(ctx, operationKey{}, op)
+
+	var span trace.Span
+	if c.tracer != nil {
+		ctx, span = c.tracer.Start(ctx, op.kind+" "+op.name, trace.WithSpanKind(trace.SpanKindClient))
+	}
+
+	start := time.Now()
+	err := fn(ctx)
+	latency := time.Since(start)
+
+	op.mu.Lock()
+	defer op.mu.Unlock()
+
+	if span != nil {
+		span.SetAttributes(
+			attribute.String(attrOperationName, op.name),
+			attribute.String(attrOperationType, op.kind),
+			attribute.Int(attrVariablesSize, op.variablesSize),
+			attribute.Int(attrRetryCount, op.retries),
+		)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+
+	if c.logger != nil {
+		level := slog.LevelDebug
+		attrs := []slog.Attr{
+			slog.String(attrOperationName, op.name),
+			slog.String(attrOperationType, op.kind),
+			slog.Int(attrVariablesSize, op.variablesSize),
+			slog.Int(attrRetryCount, op.retries),
+			slog.Duration(attrLatency, latency),
+		}
+		if err != nil {
+			level = slog.LevelWarn
+			attrs = append(attrs, slog.String("error", err.Error()))
+		}
+		c.logger.LogAttrs(ctx, level, "graphql operation", attrs...)
+	}
+
+	if c.metrics != nil {
+		c.metrics.ObserveOperation(OperationMetrics{
+			Name:          op.name,
+			Kind:          op.kind,
+			Duration:      latency,
+			RequestBytes:  op.requestBytes,
+			ResponseBytes: op.responseBytes,
+			Retries:       op.retries,
+			RateLimited:   op.rateLimited,
+			ThrottleWait:  op.throttled,
+			Error:         ClassifyError(err),
+		})
+	}
+
+	return err
+}
@@ -0,0 +1,81 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestClientObservability(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"chainIdentifier":"35834a8a"}}`))
+	}))
+	defer server.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	client := NewClient(WithEndpoint(server.URL), WithTracer(provider), WithLogger(logger))
+	query := `query GetChainIdentifier($secret: String) { chainIdentifier }`
+	var out struct {
+		ChainIdentifier string `json:"chainIdentifier"`
+	}
+	if err := client.Execute(context.Background(), query, map[string]any{"secret": "do-not-log"}, &out); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != "query GetChainIdentifier" {
+		t.Fatalf("unexpected span name %q", span.Name())
+	}
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	if attrs[attrOperationName].AsString() != "GetChainIdentifier" || attrs[attrRetryCount].AsInt64() != 1 {
+		t.Fatalf("unexpected span attributes %v", span.Attributes())
+	}
+	if attrs[attrVariablesSize].AsInt64() == 0 {
+		t.Fatal("expected variables size to be recorded")
+	}
+
+	output := logs.String()
+	if !strings.Contains(output, "graphql.operation.name=GetChainIdentifier") || !strings.Contains(output, "sui.retry.count=1") {
+		t.Fatalf("unexpected log output %q", output)
+	}
+	if strings.Contains(output, "do-not-log") {
+		t.Fatalf("variable values leaked into logs: %q", output)
+	}
+}
+
+func TestNewOperationAnonymous(t *testing.T) {
+	op := newOperation(`{ chainIdentifier }`, nil)
+	if op.name != "anonymous" || op.kind != "query" || op.variablesSize != 0 {
+		t.Fatalf("unexpected operation %+v", op)
+	}
+	op = newOperation(`mutation { executeTransaction }`, nil)
+	if op.kind != "mutation" || op.name != "anonymous" {
+		t.Fatalf("unexpected operation %+v", op)
+	}
+}
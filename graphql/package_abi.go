@@ -0,0 +1,225 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/open-move/sui-go-sdk/types"
+)
+
+// PackageABI is the public interface of a published Move package: every
+// module's functions, structs, enums and constants. It marshals to JSON as
+// a machine-readable ABI for code generators and frontends.
+type PackageABI struct {
+	Address types.Address `json:"address"`
+	Version UInt53        `json:"version"`
+	Modules []ModuleABI   `json:"modules"`
+}
+
+// ModuleABI is the interface of one module. Entries are sorted by name.
+type ModuleABI struct {
+	Name      string           `json:"name"`
+	Functions []MoveFunction   `json:"functions"`
+	Structs   []MoveStruct     `json:"structs"`
+	Enums     []MoveEnum       `json:"enums"`
+	Constants []ModuleConstant `json:"constants"`
+}
+
+// ModuleConstant is an entry of a module's constant pool as rendered in its
+// disassembly. Byte vectors holding text are rendered as strings.
+type ModuleConstant struct {
+	Index int    `json:"index"`
+	Value string `json:"value"`
+}
+
+// Module returns the module with the given name, or nil.
+func (a *PackageABI) Module(name string) *ModuleABI {
+	for i := range a.Modules {
+		if a.Modules[i].Name == name {
+			return &a.Modules[i]
+		}
+	}
+	return nil
+}
+
+// Function returns the function with the given name, or nil.
+func (m *ModuleABI) Function(name string) *MoveFunction {
+	for i := range m.Functions {
+		if m.Functions[i].Name == name {
+			return &m.Functions[i]
+		}
+	}
+	return nil
+}
+
+// JSON returns the ABI as indented JSON.
+func (a *PackageABI) JSON() ([]byte, error) {
+	return json.MarshalIndent(a, "", "  ")
+}
+
+// GetPackageABI walks every module of a published package and collects its
+// functions (parameters, returns, visibility and entry flag), structs and
+// enums (fields, abilities and type parameters) and constant pool into a
+// single PackageABI. It returns ErrPackageNotFound when pkg is not a
+// package.
+func (c *Client) GetPackageABI(ctx context.Context, pkg types.Address) (*PackageABI, error) {
+	query := `
+		query GetPackageModules($address: SuiAddress!, $after: String) {
+			object(address: $address) {
+				asMovePackage {
+					address
+					version
+					modules(first: 50, after: $after) {
+						pageInfo { hasNextPage endCursor }
+						nodes { name }
+					}
+				}
+			}
+		}
+	`
+	abi := &PackageABI{Address: pkg}
+	var after *string
+	for {
+		var result struct {
+			Object *struct {
+				AsMovePackage *MovePackage `json:"asMovePackage"`
+			} `json:"object"`
+		}
+		if err := c.Execute(ctx, query, map[string]any{"address": pkg, "after": after}, &result); err != nil {
+			return nil, err
+		}
+		if result.Object == nil || result.Object.AsMovePackage == nil || result.Object.AsMovePackage.Modules == nil {
+			return nil, ErrPackageNotFound
+		}
+		p := result.Object.AsMovePackage
+		abi.Version = p.Version
+		for _, m := range p.Modules.Nodes {
+			module, err := c.moduleABI(ctx, pkg, m.Name)
+			if err != nil {
+				return nil, err
+			}
+			abi.Modules = append(abi.Modules, *module)
+		}
+		if !p.Modules.PageInfo.HasNextPage || p.Modules.PageInfo.EndCursor == nil {
+			break
+		}
+		after = p.Modules.PageInfo.EndCursor
+	}
+	sort.Slice(abi.Modules, func(i, j int) bool { return abi.Modules[i].Name < abi.Modules[j].Name })
+	return abi, nil
+}
+
+// moduleABI pages through the functions, structs and enums of one module.
+// Connections that are exhausted keep their last cursor, so later pages
+// return no further nodes for them.
+func (c *Client) moduleABI(ctx context.Context, pkg types.Address, name string) (*ModuleABI, error) {
+	query := `
+		query GetModuleABI($address: SuiAddress!, $module: String!, $afterFunctions: String, $afterStructs: String, $afterEnums: String) {
+			object(address: $address) {
+				asMovePackage {
+					module(name: $module) {
+						functions(first: 50, after: $afterFunctions) {
+							pageInfo { hasNextPage endCursor }
+							nodes {
+								name
+								visibility
+								isEntry
+								typeParameters { constraints }
+								parameters { repr signature }
+								return { repr signature }
+							}
+						}
+						structs(first: 50, after: $afterStructs) {
+							pageInfo { hasNextPage endCursor }
+							nodes {
+								name
+								abilities
+								typeParameters { constraints isPhantom }
+								fields { name type { repr signature } }
+							}
+						}
+						enums(first: 50, after: $afterEnums) {
+							pageInfo { hasNextPage endCursor }
+							nodes {
+								name
+								abilities
+								typeParameters { constraints isPhantom }
+								variants { name fields { name type { repr signature } } }
+							}
+						}
+					}
+				}
+			}
+		}
+	`
+	module := &ModuleABI{
+		Name:      name,
+		Functions: []MoveFunction{},
+		Structs:   []MoveStruct{},
+		Enums:     []MoveEnum{},
+		Constants: []ModuleConstant{},
+	}
+	var afterFunctions, afterStructs, afterEnums *string
+	for {
+		var result struct {
+			Object *struct {
+				AsMovePackage *struct {
+					Module *MoveModule `json:"module"`
+				} `json:"asMovePackage"`
+			} `json:"object"`
+		}
+		vars := map[string]any{
+			"address":        pkg,
+			"module":         name,
+			"afterFunctions": afterFunctions,
+			"afterStructs":   afterStructs,
+			"afterEnums":     afterEnums,
+		}
+		if err := c.Execute(ctx, query, vars, &result); err != nil {
+			return nil, err
+		}
+		if result.Object == nil || result.Object.AsMovePackage == nil || result.Object.AsMovePackage.Module == nil {
+			return nil, ErrModuleNotFound
+		}
+		m := result.Object.AsMovePackage.Module
+		more := false
+		if m.Functions != nil {
+			module.Functions = append(module.Functions, m.Functions.Nodes...)
+			more = nextABIPage(m.Functions.PageInfo, &afterFunctions) || more
+		}
+		if m.Structs != nil {
+			module.Structs = append(module.Structs, m.Structs.Nodes...)
+			more = nextABIPage(m.Structs.PageInfo, &afterStructs) || more
+		}
+		if m.Enums != nil {
+			module.Enums = append(module.Enums, m.Enums.Nodes...)
+			more = nextABIPage(m.Enums.PageInfo, &afterEnums) || more
+		}
+		if !more {
+			break
+		}
+	}
+	sort.Slice(module.Functions, func(i, j int) bool { return module.Functions[i].Name < module.Functions[j].Name })
+	sort.Slice(module.Structs, func(i, j int) bool { return module.Structs[i].Name < module.Structs[j].Name })
+	sort.Slice(module.Enums, func(i, j int) bool { return module.Enums[i].Name < module.Enums[j].Name })
+
+	constants, err := c.moduleConstants(ctx, pkg, name)
+	if err != nil {
+		return nil, err
+	}
+	for i, value := range constants {
+		if value != nil {
+			module.Constants = append(module.Constants, ModuleConstant{Index: i, Value: *value})
+		}
+	}
+	return module, nil
+}
+
+// nextABIPage advances cursor when info has another page.
+func nextABIPage(info PageInfo, cursor **string) bool {
+	if info.EndCursor != nil {
+		*cursor = info.EndCursor
+	}
+	return info.HasNextPage && info.EndCursor != nil
+}
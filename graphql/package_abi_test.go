@@ -0,0 +1,112 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+func TestGetPackageABI(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query     string         `json:"query"`
+			Variables map[string]any `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(req.Query, "GetPackageModules") && req.Variables["after"] == nil:
+			w.Write([]byte(`{"data":{"object":{"asMovePackage":{"address":"0xabc","version":3,"modules":{
+				"pageInfo":{"hasNextPage":true,"endCursor":"m1"},"nodes":[{"name":"pool"}]}}}}}`))
+		case strings.Contains(req.Query, "GetPackageModules"):
+			w.Write([]byte(`{"data":{"object":{"asMovePackage":{"address":"0xabc","version":3,"modules":{
+				"pageInfo":{"hasNextPage":false,"endCursor":"m2"},"nodes":[{"name":"math"}]}}}}}`))
+		case strings.Contains(req.Query, "GetModuleABI") && req.Variables["module"] == "math":
+			w.Write([]byte(`{"data":{"object":{"asMovePackage":{"module":{
+				"functions":{"pageInfo":{"hasNextPage":false},"nodes":[]},
+				"structs":{"pageInfo":{"hasNextPage":false},"nodes":[]},
+				"enums":{"pageInfo":{"hasNextPage":false},"nodes":[]}}}}}}`))
+		case strings.Contains(req.Query, "GetModuleABI") && req.Variables["afterFunctions"] == nil:
+			w.Write([]byte(`{"data":{"object":{"asMovePackage":{"module":{
+				"functions":{"pageInfo":{"hasNextPage":true,"endCursor":"f1"},"nodes":[
+					{"name":"swap","visibility":"PUBLIC","isEntry":true,"typeParameters":[{"constraints":["DROP"]}],
+					 "parameters":[{"repr":"&mut 0xabc::pool::Pool<$0>"},{"repr":"u64"}],"return":[]}]},
+				"structs":{"pageInfo":{"hasNextPage":false,"endCursor":"s1"},"nodes":[
+					{"name":"Pool","abilities":["KEY"],"typeParameters":[{"constraints":[],"isPhantom":true}],
+					 "fields":[{"name":"id","type":{"repr":"0x2::object::UID"}}]}]},
+				"enums":{"pageInfo":{"hasNextPage":false},"nodes":[
+					{"name":"Side","abilities":["COPY","DROP"],"typeParameters":[],"variants":[{"name":"Bid","fields":[]},{"name":"Ask","fields":[]}]}]}}}}}}`))
+		case strings.Contains(req.Query, "GetModuleABI"):
+			if req.Variables["afterStructs"] != "s1" {
+				t.Errorf("exhausted structs should keep their cursor, got %v", req.Variables["afterStructs"])
+			}
+			w.Write([]byte(`{"data":{"object":{"asMovePackage":{"module":{
+				"functions":{"pageInfo":{"hasNextPage":false,"endCursor":"f2"},"nodes":[
+					{"name":"fee","visibility":"PRIVATE","isEntry":false,"typeParameters":[],"parameters":[],"return":[{"repr":"u64"}]}]},
+				"structs":{"pageInfo":{"hasNextPage":false},"nodes":[]},
+				"enums":{"pageInfo":{"hasNextPage":false},"nodes":[]}}}}}}`))
+		case strings.Contains(req.Query, "GetModuleSource"):
+			disassembly := "module abc.pool {\nConstants [\n\t0 => u64: 7\n\t1 => vector<u8>: \"EPaused\" // interpreted as UTF8 string\n]\n}"
+			payload, _ := json.Marshal(map[string]any{"data": map[string]any{"object": map[string]any{"asMovePackage": map[string]any{"module": map[string]any{"disassembly": disassembly}}}}})
+			w.Write(payload)
+		default:
+			t.Errorf("unexpected query %s", req.Query)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithEndpoint(server.URL), WithRetries(0))
+	abi, err := client.GetPackageABI(context.Background(), utils.MustParseAddress("0xabc"))
+	if err != nil {
+		t.Fatalf("GetPackageABI: %v", err)
+	}
+	if abi.Version != 3 || len(abi.Modules) != 2 || abi.Modules[0].Name != "math" || abi.Modules[1].Name != "pool" {
+		t.Fatalf("unexpected modules %+v", abi)
+	}
+
+	pool := abi.Module("pool")
+	if len(pool.Functions) != 2 || pool.Functions[0].Name != "fee" || pool.Functions[1].Name != "swap" {
+		t.Fatalf("unexpected functions %+v", pool.Functions)
+	}
+	swap := pool.Function("swap")
+	if !swap.IsEntry || len(swap.Parameters) != 2 || swap.TypeParameters[0].Constraints[0] != MoveAbilityDrop {
+		t.Fatalf("unexpected swap %+v", swap)
+	}
+	if len(pool.Structs) != 1 || !pool.Structs[0].TypeParameters[0].IsPhantom || len(pool.Enums) != 1 || len(pool.Enums[0].Variants) != 2 {
+		t.Fatalf("unexpected datatypes %+v %+v", pool.Structs, pool.Enums)
+	}
+	if len(pool.Constants) != 2 || pool.Constants[1] != (ModuleConstant{Index: 1, Value: "EPaused"}) {
+		t.Fatalf("unexpected constants %+v", pool.Constants)
+	}
+
+	data, err := abi.JSON()
+	if err != nil {
+		t.Fatalf("JSON: %v", err)
+	}
+	var decoded PackageABI
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded.Module("pool").Function("swap") == nil {
+		t.Fatalf("round trip: %v", err)
+	}
+	if !strings.Contains(string(data), `"functions": []`) {
+		t.Fatalf("empty modules should export empty lists:\n%s", data)
+	}
+}
+
+func TestGetPackageABINotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"object":null}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithEndpoint(server.URL), WithRetries(0))
+	if _, err := client.GetPackageABI(context.Background(), utils.MustParseAddress("0xabc")); !errors.Is(err, ErrPackageNotFound) {
+		t.Fatalf("expected ErrPackageNotFound, got %v", err)
+	}
+}
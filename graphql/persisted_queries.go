@@ -0,0 +1,128 @@
+package graphql
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// =============================================================================
+// Automatic Persisted Queries
+// =============================================================================
+
+// WithPersistedQueries enables automatic persisted queries (APQ). The client
+// first sends only the SHA-256 hash of each query; when the server does not
+// know the hash yet, it resends the full query so the server can store it.
+// Servers that report they do not support persisted queries get full
+// queries from then on.
+func WithPersistedQueries() ClientOption {
+	return func(c *Client) {
+		c.persistedQueries = true
+	}
+}
+
+// maxPersistedManifest bounds the queries a client records for
+// PersistedQueryManifest. Queries built at run time, such as batched
+// lookups, would otherwise grow it without limit.
+const maxPersistedManifest = 1000
+
+// PersistedQueryHash returns the hash that identifies query in the automatic
+// persisted query protocol.
+func PersistedQueryHash(query string) string {
+	sum := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(sum[:])
+}
+
+// PersistedQueryManifest returns, keyed by hash, IntrospectionQuery, the
+// service config query and the first queries this client sent as persisted
+// queries, up to 1000. The queries of other client methods are only included
+// once they have been sent, so to seed a server that only accepts
+// allow-listed queries, build the manifest after exercising the methods the
+// application uses.
+func (c *Client) PersistedQueryManifest() map[string]string {
+	manifest := make(map[string]string)
+	for _, query := range []string{IntrospectionQuery, serviceConfigQuery} {
+		manifest[PersistedQueryHash(query)] = query
+	}
+	c.persistedMu.Lock()
+	defer c.persistedMu.Unlock()
+	for hash, query := range c.persistedManifest {
+		manifest[hash] = query
+	}
+	return manifest
+}
+
+// persistedQueryExtension returns the APQ extension for query and records
+// the query in the client's manifest while it has room.
+func (c *Client) persistedQueryExtension(query string) map[string]any {
+	hash := PersistedQueryHash(query)
+	c.persistedMu.Lock()
+	if _, ok := c.persistedManifest[hash]; !ok && len(c.persistedManifest) < maxPersistedManifest {
+		if c.persistedManifest == nil {
+			c.persistedManifest = make(map[string]string)
+		}
+		c.persistedManifest[hash] = query
+	}
+	c.persistedMu.Unlock()
+	return map[string]any{
+		"persistedQuery": map[string]any{
+			"version":    1,
+			"sha256Hash": hash,
+		},
+	}
+}
+
+// executePersisted runs the APQ exchange for query.
+func (c *Client) executePersisted(ctx context.Context, query string, variables map[string]any, result any) error {
+	extensions := c.persistedQueryExtension(query)
+	err := c.executeWithRetry(ctx, graphqlRequest{Variables: variables, Extensions: extensions}, result, 0)
+	switch persistedQueryFailure(err) {
+	case persistedQueryNotFound:
+		return c.executeWithRetry(ctx, graphqlRequest{Query: query, Variables: variables, Extensions: extensions}, result, 0)
+	case persistedQueryNotSupported:
+		c.persistedUnsupported.Store(true)
+		return c.executeWithRetry(ctx, graphqlRequest{Query: query, Variables: variables}, result, 0)
+	}
+	return err
+}
+
+type persistedQueryResult int
+
+const (
+	persistedQueryOK persistedQueryResult = iota
+	persistedQueryNotFound
+	persistedQueryNotSupported
+)
+
+// persistedQueryFailure classifies the error from a hash-only request. A
+// server without APQ support may reject the request as missing its query
+// instead of saying so; a 400 response is treated the same way.
+func persistedQueryFailure(err error) persistedQueryResult {
+	var gqlErrs GraphQLErrors
+	if errors.As(err, &gqlErrs) {
+		for _, e := range gqlErrs {
+			code, _ := e.Extensions["code"].(string)
+			switch {
+			case e.Message == "PersistedQueryNotFound" || code == "PERSISTED_QUERY_NOT_FOUND":
+				return persistedQueryNotFound
+			case e.Message == "PersistedQueryNotSupported" || code == "PERSISTED_QUERY_NOT_SUPPORTED":
+				return persistedQueryNotSupported
+			}
+		}
+		return persistedQueryOK
+	}
+
+	var httpErr *httpStatusError
+	if errors.As(err, &httpErr) {
+		switch {
+		case strings.Contains(httpErr.Body, "PersistedQueryNotFound") || strings.Contains(httpErr.Body, "PERSISTED_QUERY_NOT_FOUND"):
+			return persistedQueryNotFound
+		case httpErr.StatusCode == http.StatusBadRequest:
+			return persistedQueryNotSupported
+		}
+	}
+	return persistedQueryOK
+}
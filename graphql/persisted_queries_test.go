@@ -0,0 +1,89 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestPersistedQueries(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		stored   = map[string]string{}
+		requests []graphqlRequest
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req graphqlRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, req)
+
+		hash := req.Extensions["persistedQuery"].(map[string]any)["sha256Hash"].(string)
+		if req.Query != "" {
+			if PersistedQueryHash(req.Query) != hash {
+				w.Write([]byte(`{"errors":[{"message":"provided sha does not match query"}]}`))
+				return
+			}
+			stored[hash] = req.Query
+		}
+		if _, ok := stored[hash]; !ok {
+			w.Write([]byte(`{"errors":[{"message":"PersistedQueryNotFound","extensions":{"code":"PERSISTED_QUERY_NOT_FOUND"}}]}`))
+			return
+		}
+		w.Write([]byte(`{"data":{"chainIdentifier":"35834a8a"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithEndpoint(server.URL), WithPersistedQueries())
+	for range 2 {
+		id, err := client.GetChainIdentifier(context.Background())
+		if err != nil || id != "35834a8a" {
+			t.Fatalf("GetChainIdentifier = %q, %v", id, err)
+		}
+	}
+
+	if len(requests) != 3 {
+		t.Fatalf("sent %d requests, want 3", len(requests))
+	}
+	if requests[0].Query != "" || requests[1].Query == "" || requests[2].Query != "" {
+		t.Fatalf("unexpected request sequence %+v", requests)
+	}
+	if _, ok := client.PersistedQueryManifest()[PersistedQueryHash(requests[1].Query)]; !ok {
+		t.Fatal("query missing from manifest")
+	}
+	if _, ok := client.PersistedQueryManifest()[PersistedQueryHash(IntrospectionQuery)]; !ok {
+		t.Fatal("introspection query missing from manifest")
+	}
+}
+
+func TestPersistedQueriesNotSupported(t *testing.T) {
+	var requests []graphqlRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req graphqlRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		requests = append(requests, req)
+		if req.Query == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`query is required`))
+			return
+		}
+		w.Write([]byte(`{"data":{"chainIdentifier":"35834a8a"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithEndpoint(server.URL), WithPersistedQueries())
+	for range 2 {
+		if _, err := client.GetChainIdentifier(context.Background()); err != nil {
+			t.Fatalf("GetChainIdentifier: %v", err)
+		}
+	}
+
+	// After the first rejection the client stops sending bare hashes.
+	if len(requests) != 3 || requests[2].Query == "" || requests[2].Extensions != nil {
+		t.Fatalf("unexpected request sequence %+v", requests)
+	}
+}
@@ -0,0 +1,124 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// =============================================================================
+// Protocol Features
+// =============================================================================
+
+// protocolInfoTTL bounds how long the latest protocol config is cached. The
+// protocol version only changes at epoch boundaries, so a few minutes of
+// staleness is harmless.
+const protocolInfoTTL = 5 * time.Minute
+
+// ErrProtocolConfigUnset is returned by the typed ProtocolInfo accessors when
+// the network does not define the requested config, or defines it without a
+// value at the current protocol version.
+var ErrProtocolConfigUnset = errors.New("protocol config not set")
+
+// ProtocolInfo is an indexed view of a network's protocol configuration.
+type ProtocolInfo struct {
+	Version UInt53
+	// Flags maps feature flag names to whether they are enabled.
+	Flags   map[string]bool
+	configs map[string]*string
+}
+
+// NewProtocolInfo indexes the flags and configs returned by GetProtocolConfig.
+func NewProtocolInfo(cfg *ProtocolConfigs) *ProtocolInfo {
+	info := &ProtocolInfo{
+		Flags:   make(map[string]bool),
+		configs: make(map[string]*string),
+	}
+	if cfg == nil {
+		return info
+	}
+	info.Version = cfg.ProtocolVersion
+	for _, flag := range cfg.FeatureFlags {
+		info.Flags[flag.Key] = flag.Value
+	}
+	for _, config := range cfg.Configs {
+		info.configs[config.Key] = config.Value
+	}
+	return info
+}
+
+// FeatureEnabled reports whether the named feature flag is enabled. Flags the
+// network does not know about are reported as disabled.
+func (p *ProtocolInfo) FeatureEnabled(flag string) bool {
+	return p.Flags[flag]
+}
+
+// Config returns the raw value of a protocol config and whether it is set.
+func (p *ProtocolInfo) Config(key string) (string, bool) {
+	value := p.configs[key]
+	if value == nil {
+		return "", false
+	}
+	return *value, true
+}
+
+// ConfigUint64 returns a numeric protocol config, such as
+// max_tx_size_bytes or max_move_object_size.
+func (p *ProtocolInfo) ConfigUint64(key string) (uint64, error) {
+	value, ok := p.Config(key)
+	if !ok {
+		return 0, fmt.Errorf("%s: %w", key, ErrProtocolConfigUnset)
+	}
+	n, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s: invalid unsigned value %q", key, value)
+	}
+	return n, nil
+}
+
+// ConfigBool returns a boolean protocol config.
+func (p *ProtocolInfo) ConfigBool(key string) (bool, error) {
+	value, ok := p.Config(key)
+	if !ok {
+		return false, fmt.Errorf("%s: %w", key, ErrProtocolConfigUnset)
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%s: invalid boolean value %q", key, value)
+	}
+	return b, nil
+}
+
+// ProtocolInfo returns the network's latest protocol configuration. The
+// result is cached for a few minutes so feature checks can be made freely.
+func (c *Client) ProtocolInfo(ctx context.Context) (*ProtocolInfo, error) {
+	c.protocolMu.Lock()
+	defer c.protocolMu.Unlock()
+
+	if c.protocolInfo != nil && time.Since(c.protocolFetched) < protocolInfoTTL {
+		return c.protocolInfo, nil
+	}
+	cfg, err := c.GetProtocolConfig(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		return nil, errors.New("protocol config not available")
+	}
+	c.protocolInfo = NewProtocolInfo(cfg)
+	c.protocolFetched = time.Now()
+	return c.protocolInfo, nil
+}
+
+// IsFeatureEnabled reports whether a protocol feature flag, such as
+// "receive_objects" or "package_upgrades", is enabled on the connected
+// network.
+func (c *Client) IsFeatureEnabled(ctx context.Context, flag string) (bool, error) {
+	info, err := c.ProtocolInfo(ctx)
+	if err != nil {
+		return false, err
+	}
+	return info.FeatureEnabled(flag), nil
+}
@@ -0,0 +1,64 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestProtocolInfo(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"protocolConfigs":{
+			"protocolVersion":70,
+			"featureFlags":[{"key":"receive_objects","value":true},{"key":"zklogin_auth","value":false}],
+			"configs":[
+				{"key":"max_tx_size_bytes","value":"131072"},
+				{"key":"random_beacon_reduction_allowed","value":"true"},
+				{"key":"max_age_of_jwk_in_epochs","value":null}
+			]}}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithEndpoint(server.URL), WithRetries(0))
+	ctx := context.Background()
+
+	enabled, err := client.IsFeatureEnabled(ctx, "receive_objects")
+	if err != nil || !enabled {
+		t.Fatalf("receive_objects: %v %v", enabled, err)
+	}
+	for _, flag := range []string{"zklogin_auth", "not_a_flag"} {
+		if enabled, err := client.IsFeatureEnabled(ctx, flag); err != nil || enabled {
+			t.Fatalf("%s: expected disabled, got %v %v", flag, enabled, err)
+		}
+	}
+
+	info, err := client.ProtocolInfo(ctx)
+	if err != nil {
+		t.Fatalf("ProtocolInfo: %v", err)
+	}
+	if info.Version != 70 {
+		t.Fatalf("version = %d", info.Version)
+	}
+	if size, err := info.ConfigUint64("max_tx_size_bytes"); err != nil || size != 131072 {
+		t.Fatalf("max_tx_size_bytes = %d, %v", size, err)
+	}
+	if allowed, err := info.ConfigBool("random_beacon_reduction_allowed"); err != nil || !allowed {
+		t.Fatalf("random_beacon_reduction_allowed = %v, %v", allowed, err)
+	}
+	if _, err := info.ConfigUint64("max_age_of_jwk_in_epochs"); !errors.Is(err, ErrProtocolConfigUnset) {
+		t.Fatalf("expected ErrProtocolConfigUnset, got %v", err)
+	}
+	if _, err := info.ConfigBool("max_tx_size_bytes"); err == nil {
+		t.Fatal("expected parse error")
+	}
+
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("expected one request, got %d", n)
+	}
+}
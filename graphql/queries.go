@@ -2,7 +2,13 @@ package graphql
 
 import (
 	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
 
 	"github.com/open-move/sui-go-sdk/types"
 )
@@ -86,6 +92,47 @@ func (c *Client) GetBalance(ctx context.Context, owner types.Address, coinType s
 	return result.Address.Balance, nil
 }
 
+// HasActivity reports whether address has sent a transaction or currently
+// owns any objects or balances. Wallet restore flows use it to tell used
+// accounts from fresh ones.
+func (c *Client) HasActivity(ctx context.Context, address types.Address) (bool, error) {
+	query := `
+		query HasActivity($address: SuiAddress!, $filter: TransactionFilter) {
+			address(address: $address) {
+				balances(first: 1) { nodes { totalBalance } }
+				objects(first: 1) { nodes { address } }
+			}
+			transactions(first: 1, filter: $filter) { nodes { digest } }
+		}
+	`
+
+	vars := map[string]any{
+		"address": address,
+		"filter":  TransactionFilter{SentAddress: &address},
+	}
+
+	var result struct {
+		Address *struct {
+			Balances *Connection[Balance]    `json:"balances"`
+			Objects  *Connection[MoveObject] `json:"objects"`
+		} `json:"address"`
+		Transactions *Connection[TransactionRef] `json:"transactions"`
+	}
+
+	if err := c.Execute(ctx, query, vars, &result); err != nil {
+		return false, err
+	}
+
+	if result.Transactions != nil && len(result.Transactions.Nodes) > 0 {
+		return true, nil
+	}
+	if result.Address == nil {
+		return false, nil
+	}
+	return (result.Address.Balances != nil && len(result.Address.Balances.Nodes) > 0) ||
+		(result.Address.Objects != nil && len(result.Address.Objects.Nodes) > 0), nil
+}
+
 // GetCoins returns coins of a specific type owned by an address.
 // Uses objects query with type filter to get coin objects.
 func (c *Client) GetCoins(ctx context.Context, owner types.Address, coinType *string, pagination *PaginationArgs) (*Connection[Coin], error) {
@@ -159,10 +206,11 @@ func (c *Client) GetCoins(ctx context.Context, owner types.Address, coinType *st
 	coins := make([]Coin, 0, len(result.Address.Objects.Nodes))
 	for _, obj := range result.Address.Objects.Nodes {
 		coin := Coin{
-			Address:  obj.Address,
-			Version:  obj.Version,
-			Digest:   obj.Digest,
-			Contents: obj.Contents,
+			CoinBalance: coinBalanceFromContents(obj.Contents),
+			Address:     obj.Address,
+			Version:     obj.Version,
+			Digest:      obj.Digest,
+			Contents:    obj.Contents,
 		}
 		coins = append(coins, coin)
 	}
@@ -173,6 +221,71 @@ func (c *Client) GetCoins(ctx context.Context, owner types.Address, coinType *st
 	}, nil
 }
 
+// ErrInsufficientCoins is returned by GetCoinsOfTotalValue when the owner's
+// coins do not add up to the requested total.
+var ErrInsufficientCoins = errors.New("insufficient coin balance")
+
+// GetCoinsOfTotalValue pages through owner's coins of coinType (SUI when
+// nil) and returns as soon as their balances add up to at least minTotal,
+// without fetching the remaining pages.
+func (c *Client) GetCoinsOfTotalValue(ctx context.Context, owner types.Address, coinType *string, minTotal uint64) ([]Coin, error) {
+	target := new(big.Int).SetUint64(minTotal)
+	total := new(big.Int)
+	var selected []Coin
+
+	pagination := &PaginationArgs{}
+	for {
+		page, err := c.GetCoins(ctx, owner, coinType, pagination)
+		if err != nil {
+			return nil, err
+		}
+		if page == nil {
+			break
+		}
+		for _, coin := range page.Nodes {
+			balance, ok := coin.CoinBalance.ToBigInt()
+			if !ok || balance.Sign() == 0 {
+				continue
+			}
+			selected = append(selected, coin)
+			total.Add(total, balance)
+			if total.Cmp(target) >= 0 {
+				return selected, nil
+			}
+		}
+		if !page.PageInfo.HasNextPage || page.PageInfo.EndCursor == nil {
+			break
+		}
+		pagination = &PaginationArgs{After: page.PageInfo.EndCursor}
+	}
+
+	return nil, fmt.Errorf("%w: required %d, available %s", ErrInsufficientCoins, minTotal, total)
+}
+
+// coinBalanceFromContents reads the balance of a Coin<T> from its contents,
+// preferring the BCS encoding (a 32-byte UID followed by a u64) and falling
+// back to the JSON rendering.
+func coinBalanceFromContents(contents *MoveValue) BigInt {
+	if contents == nil {
+		return ""
+	}
+	if len(contents.Bcs) == 40 {
+		return BigInt(strconv.FormatUint(binary.LittleEndian.Uint64(contents.Bcs[32:]), 10))
+	}
+
+	var fields struct {
+		Balance json.RawMessage `json:"balance"`
+	}
+	if json.Unmarshal(contents.Json, &fields) != nil {
+		return ""
+	}
+	balance := strings.Trim(string(fields.Balance), `"`)
+	if _, ok := new(big.Int).SetString(balance, 10); !ok {
+		return ""
+	}
+	return BigInt(balance)
+}
+
 // GetCoinMetadata returns metadata for a coin type.
 // Equivalent to Blockvision's SuiXGetCoinMetadata.
 func (c *Client) GetCoinMetadata(ctx context.Context, coinType string) (*CoinMetadata, error) {
@@ -249,8 +362,30 @@ type ObjectDataOptions struct {
 	ShowDisplay             bool
 }
 
+// RefOnlyObjectOptions selects only an object's address, version and digest,
+// which is all a transaction input needs.
+func RefOnlyObjectOptions() *ObjectDataOptions {
+	return &ObjectDataOptions{}
+}
+
+// ContentObjectOptions selects an object's type, owner and contents.
+func ContentObjectOptions() *ObjectDataOptions {
+	return &ObjectDataOptions{ShowType: true, ShowContent: true, ShowOwner: true}
+}
+
 // buildObjectQuery constructs the GraphQL query for fetching an object.
 func (c *Client) buildObjectQuery(options *ObjectDataOptions) string {
+	return fmt.Sprintf(`
+		query GetObject($objectId: SuiAddress!) {
+			object(address: $objectId) {
+				%s
+			}
+		}
+	`, objectSelection(options))
+}
+
+// objectSelection returns the object fields selected for the given options.
+func objectSelection(options *ObjectDataOptions) string {
 	if options == nil {
 		options = &ObjectDataOptions{
 			ShowType:                true,
@@ -288,60 +423,157 @@ func (c *Client) buildObjectQuery(options *ObjectDataOptions) string {
 		}`
 	}
 
-	return fmt.Sprintf(`
-		query GetObject($objectId: SuiAddress!) {
-			object(address: $objectId) {
+	return fields
+}
+
+// GetObjectAtVersion returns the object as it was at a specific version.
+func (c *Client) GetObjectAtVersion(ctx context.Context, objectID types.Address, version UInt53, options *ObjectDataOptions) (*Object, error) {
+	query := fmt.Sprintf(`
+		query GetObjectAtVersion($objectId: SuiAddress!, $version: UInt53) {
+			object(address: $objectId, version: $version) {
 				%s
 			}
 		}
-	`, fields)
+	`, objectSelection(options))
+
+	var result struct {
+		Object *Object `json:"object"`
+	}
+
+	err := c.Execute(ctx, query, map[string]any{"objectId": objectID, "version": version}, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Object, nil
 }
 
-// GetMultipleObjects returns details for multiple objects.
-// Equivalent to Blockvision's SuiMultiGetObjects.
-func (c *Client) GetMultipleObjects(ctx context.Context, objectIDs []types.Address, options *ObjectDataOptions) ([]Object, error) {
-	query := `
-		query MultiGetObjects($keys: [ObjectKey!]!) {
-			multiGetObjects(keys: $keys) {
-				address
-				version
-				digest
-				storageRebate
-				owner {
-					__typename
-					... on AddressOwner { address { address } }
-					... on ObjectOwner { address { address } }
-					... on Shared { initialSharedVersion }
+// GetObjectAtCheckpoint returns the latest version of the object as of a checkpoint.
+func (c *Client) GetObjectAtCheckpoint(ctx context.Context, objectID types.Address, checkpoint UInt53, options *ObjectDataOptions) (*Object, error) {
+	query := fmt.Sprintf(`
+		query GetObjectAtCheckpoint($objectId: SuiAddress!, $checkpoint: UInt53) {
+			object(address: $objectId, atCheckpoint: $checkpoint) {
+				%s
+			}
+		}
+	`, objectSelection(options))
+
+	var result struct {
+		Object *Object `json:"object"`
+	}
+
+	err := c.Execute(ctx, query, map[string]any{"objectId": objectID, "checkpoint": checkpoint}, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Object, nil
+}
+
+// GetObjectVersionHistory lists the versions of an object, oldest first,
+// optionally bounded by a version filter.
+func (c *Client) GetObjectVersionHistory(ctx context.Context, objectID types.Address, filter *VersionFilter, pagination *PaginationArgs, options *ObjectDataOptions) (*Connection[Object], error) {
+	query := fmt.Sprintf(`
+		query GetObjectVersionHistory($objectId: SuiAddress!, $filter: VersionFilter, $first: Int, $after: String, $last: Int, $before: String) {
+			objectVersions(address: $objectId, filter: $filter, first: $first, after: $after, last: $last, before: $before) {
+				pageInfo {
+					hasNextPage
+					hasPreviousPage
+					startCursor
+					endCursor
 				}
-				previousTransaction { digest }
-				asMoveObject {
-					address version digest hasPublicTransfer
-					contents { type { repr } bcs json }
+				nodes {
+					%s
 				}
 			}
 		}
-	`
+	`, objectSelection(options))
 
-	// Build object keys
-	keys := make([]map[string]any, len(objectIDs))
-	for i, id := range objectIDs {
-		keys[i] = map[string]any{"address": id}
+	vars := map[string]any{"objectId": objectID}
+	if filter != nil {
+		vars["filter"] = filter
+	}
+	if pagination != nil {
+		for k, v := range pagination.ToVariables() {
+			vars[k] = v
+		}
 	}
 
 	var result struct {
-		MultiGetObjects []Object `json:"multiGetObjects"`
+		ObjectVersions *Connection[Object] `json:"objectVersions"`
 	}
 
-	err := c.Execute(ctx, query, map[string]any{"keys": keys}, &result)
+	err := c.Execute(ctx, query, vars, &result)
 	if err != nil {
 		return nil, err
 	}
 
-	if result.MultiGetObjects == nil {
+	return result.ObjectVersions, nil
+}
+
+// GetMultipleObjects returns details for multiple objects.
+// Equivalent to Blockvision's SuiMultiGetObjects.
+//
+// Large requests are split into chunks whose responses fit the service's
+// maxOutputNodes limit; chunks are sent concurrently, bounded by
+// WithConcurrency, and results keep the input order.
+func (c *Client) GetMultipleObjects(ctx context.Context, objectIDs []types.Address, options *ObjectDataOptions) ([]Object, error) {
+	const selection = `
+		address
+		version
+		digest
+		storageRebate
+		owner {
+			__typename
+			... on AddressOwner { address { address } }
+			... on ObjectOwner { address { address } }
+			... on Shared { initialSharedVersion }
+		}
+		previousTransaction { digest }
+		asMoveObject {
+			address version digest hasPublicTransfer
+			contents { type { repr } bcs json }
+		}
+	`
+	query := fmt.Sprintf(`
+		query MultiGetObjects($keys: [ObjectKey!]!) {
+			multiGetObjects(keys: $keys) {
+				%s
+			}
+		}
+	`, selection)
+
+	if len(objectIDs) == 0 {
 		return []Object{}, nil
 	}
 
-	return result.MultiGetObjects, nil
+	chunkSize := c.listBatchSize(ctx, selection)
+	chunks := make([][]Object, (len(objectIDs)+chunkSize-1)/chunkSize)
+
+	err := c.runChunks(len(objectIDs), chunkSize, func(start, end int) error {
+		keys := make([]map[string]any, 0, end-start)
+		for _, id := range objectIDs[start:end] {
+			keys = append(keys, map[string]any{"address": id})
+		}
+
+		var result struct {
+			MultiGetObjects []Object `json:"multiGetObjects"`
+		}
+		if err := c.Execute(ctx, query, map[string]any{"keys": keys}, &result); err != nil {
+			return err
+		}
+		chunks[start/chunkSize] = result.MultiGetObjects
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	objects := []Object{}
+	for _, chunk := range chunks {
+		objects = append(objects, chunk...)
+	}
+	return objects, nil
 }
 
 // GetOwnedObjects returns objects owned by an address.
@@ -520,6 +752,9 @@ func (c *Client) GetDynamicFieldObject(ctx context.Context, parentID types.Addre
 // =============================================================================
 
 // TransactionBlockOptions controls what data is returned for transactions.
+// Large selections dominate latency on public endpoints, so request only the
+// fields you read; see the presets below. A nil options selects everything
+// except the raw transaction BCS, events included.
 type TransactionBlockOptions struct {
 	ShowInput          bool
 	ShowRawInput       bool
@@ -529,6 +764,30 @@ type TransactionBlockOptions struct {
 	ShowBalanceChanges bool
 }
 
+// DigestOnlyTransactionOptions selects only transaction digests, e.g. to
+// page through a filter's matches.
+func DigestOnlyTransactionOptions() *TransactionBlockOptions {
+	return &TransactionBlockOptions{}
+}
+
+// StatusTransactionOptions selects the effects summary: status, gas used,
+// epoch, checkpoint and timestamp.
+func StatusTransactionOptions() *TransactionBlockOptions {
+	return &TransactionBlockOptions{ShowEffects: true}
+}
+
+// FullTransactionOptions selects every field a transaction query supports.
+func FullTransactionOptions() *TransactionBlockOptions {
+	return &TransactionBlockOptions{
+		ShowInput:          true,
+		ShowRawInput:       true,
+		ShowEffects:        true,
+		ShowEvents:         true,
+		ShowObjectChanges:  true,
+		ShowBalanceChanges: true,
+	}
+}
+
 // GetTransactionBlock returns details for a transaction.
 // Equivalent to Blockvision's SuiGetTransactionBlock.
 func (c *Client) GetTransactionBlock(ctx context.Context, digest string, options *TransactionBlockOptions) (*Transaction, error) {
@@ -548,6 +807,17 @@ func (c *Client) GetTransactionBlock(ctx context.Context, digest string, options
 
 // buildTransactionQuery constructs the GraphQL query for fetching a transaction block.
 func (c *Client) buildTransactionQuery(options *TransactionBlockOptions) string {
+	return fmt.Sprintf(`
+		query GetTransaction($digest: String!) {
+			transaction(digest: $digest) {
+				%s
+			}
+		}
+	`, transactionSelection(options))
+}
+
+// transactionSelection returns the transaction fields selected for the given options.
+func transactionSelection(options *TransactionBlockOptions) string {
 	if options == nil {
 		options = &TransactionBlockOptions{
 			ShowInput:          true,
@@ -567,6 +837,9 @@ func (c *Client) buildTransactionQuery(options *TransactionBlockOptions) string
 				gasSponsor { address }
 				gasPrice
 				gasBudget
+				gasPayment {
+					nodes { address version digest }
+				}
 			}
 			expiration { epochId }
 			signatures { signatureBytes }
@@ -619,7 +892,7 @@ func (c *Client) buildTransactionQuery(options *TransactionBlockOptions) string
 			effects {
 				digest
 				status
-				executionError { message }
+				executionError { message abortCode sourceLineNumber instructionOffset identifier constant module { name package { address } } }
 				lamportVersion
 				gasEffects {
 					gasSummary {
@@ -636,6 +909,22 @@ func (c *Client) buildTransactionQuery(options *TransactionBlockOptions) string
 		`
 	}
 
+	if options.ShowEvents {
+		fields += `
+			effects {
+				events {
+					nodes {
+						transactionModule { name package { address } }
+						sender { address }
+						timestamp
+						contents { type { repr } bcs json }
+						eventBcs
+					}
+				}
+			}
+		`
+	}
+
 	if options.ShowObjectChanges {
 		fields += `
 			effects {
@@ -668,36 +957,108 @@ func (c *Client) buildTransactionQuery(options *TransactionBlockOptions) string
 		`
 	}
 
-	return fmt.Sprintf(`
-		query GetTransaction($digest: String!) {
-			transaction(digest: $digest) {
-				%s
-			}
-		}
-	`, fields)
+	return fields
 }
 
 // GetMultipleTransactionBlocks returns details for multiple transactions.
 // Equivalent to Blockvision's SuiMultiGetTransactionBlocks.
+//
+// Digests are fetched with aliased transaction(digest:) fields in as few
+// requests as the service's maxQueryNodes limit allows; chunks are sent
+// concurrently, bounded by WithConcurrency. Results keep the input order and
+// digests that are not found are omitted. If a chunk fails, its digests are
+// retried one by one, and the transactions that were fetched are returned
+// together with a MultiError describing each failure.
 func (c *Client) GetMultipleTransactionBlocks(ctx context.Context, digests []string, options *TransactionBlockOptions) ([]Transaction, error) {
-	// Query each transaction individually since there's no batch query by digest
+	if len(digests) == 0 {
+		return []Transaction{}, nil
+	}
+
+	selection := transactionSelection(options)
+	chunkSize := c.batchSize(ctx, selection)
+
+	results := make([]*Transaction, len(digests))
+	errs := make([]error, len(digests))
+
+	c.runChunks(len(digests), chunkSize, func(start, end int) error {
+		if err := c.fetchTransactionChunk(ctx, selection, digests[start:end], results[start:end]); err == nil {
+			return nil
+		}
+		for i := start; i < end; i++ {
+			tx, err := c.GetTransactionBlock(ctx, digests[i], options)
+			if err != nil {
+				errs[i] = fmt.Errorf("transaction %s: %w", digests[i], err)
+				continue
+			}
+			results[i] = tx
+		}
+		return nil
+	})
+
 	transactions := make([]Transaction, 0, len(digests))
-	for _, digest := range digests {
-		tx, err := c.GetTransactionBlock(ctx, digest, options)
-		if err != nil {
-			return nil, err
+	var multiErr MultiError
+	for i, tx := range results {
+		if errs[i] != nil {
+			multiErr = append(multiErr, errs[i])
+			continue
 		}
 		if tx != nil {
 			transactions = append(transactions, *tx)
 		}
 	}
+	if len(multiErr) > 0 {
+		return transactions, multiErr
+	}
 	return transactions, nil
 }
 
-// QueryTransactionBlocks queries transactions with filters.
+// fetchTransactionChunk fetches digests in a single request, storing each
+// transaction at the same index of out.
+func (c *Client) fetchTransactionChunk(ctx context.Context, selection string, digests []string, out []*Transaction) error {
+	var query strings.Builder
+	query.WriteString("query GetMultipleTransactions(")
+	vars := make(map[string]any, len(digests))
+	for i, digest := range digests {
+		if i > 0 {
+			query.WriteString(", ")
+		}
+		fmt.Fprintf(&query, "$d%d: String!", i)
+		vars[fmt.Sprintf("d%d", i)] = digest
+	}
+	query.WriteString(") {\n")
+	selection = compactSelection(selection)
+	for i := range digests {
+		fmt.Fprintf(&query, "t%d: transaction(digest: $d%d) { %s }\n", i, i, selection)
+	}
+	query.WriteString("}")
+
+	var result map[string]*Transaction
+	if err := c.Execute(ctx, query.String(), vars, &result); err != nil {
+		return err
+	}
+	for i := range digests {
+		out[i] = result[fmt.Sprintf("t%d", i)]
+	}
+	return nil
+}
+
+// QueryTransactionBlocks queries transactions with filters, selecting their
+// inputs, raw BCS and effects. Use QueryTransactionBlocksWithOptions to fetch
+// less.
 // Equivalent to Blockvision's SuiXQueryTransactionBlocks.
 func (c *Client) QueryTransactionBlocks(ctx context.Context, filter *TransactionFilter, pagination *PaginationArgs) (*Connection[Transaction], error) {
-	query := `
+	return c.QueryTransactionBlocksWithOptions(ctx, filter, pagination, &TransactionBlockOptions{
+		ShowInput:    true,
+		ShowRawInput: true,
+		ShowEffects:  true,
+	})
+}
+
+// QueryTransactionBlocksWithOptions queries transactions with filters,
+// selecting only the fields enabled in options. A nil options selects the
+// same fields as GetTransactionBlock.
+func (c *Client) QueryTransactionBlocksWithOptions(ctx context.Context, filter *TransactionFilter, pagination *PaginationArgs, options *TransactionBlockOptions) (*Connection[Transaction], error) {
+	query := fmt.Sprintf(`
 		query QueryTransactions($filter: TransactionFilter, $first: Int, $after: String, $last: Int, $before: String) {
 			transactions(filter: $filter, first: $first, after: $after, last: $last, before: $before) {
 				pageInfo {
@@ -707,83 +1068,11 @@ func (c *Client) QueryTransactionBlocks(ctx context.Context, filter *Transaction
 					endCursor
 				}
 				nodes {
-					digest
-					kind {
-						__typename
-						... on ProgrammableTransaction {
-							inputs {
-								nodes {
-									__typename
-									... on Pure {
-										bytes
-									}
-									... on OwnedOrImmutable {
-										object {
-											address
-											version
-											digest
-										}
-									}
-									... on SharedInput {
-										address
-										initialSharedVersion
-										mutable
-									}
-									... on Receiving {
-										object {
-											address
-											version
-											digest
-										}
-									}
-								}
-							}
-							commands {
-								nodes {
-									__typename
-								}
-							}
-						}
-					}
-					signatures {
-						signatureBytes
-					}
-					expiration { epochId }
-					transactionBcs
-					sender { address }
-					gasInput {
-						gasSponsor { address }
-						gasPrice
-						gasBudget
-						gasPayment {
-							nodes {
-								address
-								version
-								digest
-							}
-						}
-					}
-					effects {
-						digest
-						status
-						executionError { message }
-						lamportVersion
-						gasEffects {
-							gasSummary {
-								computationCost
-								storageCost
-								storageRebate
-								nonRefundableStorageFee
-							}
-						}
-						epoch { epochId }
-						checkpoint { sequenceNumber }
-						timestamp
-					}
+					%s
 				}
 			}
 		}
-	`
+	`, transactionSelection(options))
 
 	vars := make(map[string]any)
 	if filter != nil {
@@ -951,7 +1240,12 @@ func (c *Client) GetProtocolConfig(ctx context.Context, protocolVersion *UInt53)
 		ProtocolConfigs *ProtocolConfigs `json:"protocolConfigs"`
 	}
 
-	err := c.Execute(ctx, query, vars, &result)
+	// The latest config changes with each upgrade; past versions never do.
+	var key string
+	if protocolVersion != nil {
+		key = fmt.Sprintf("protocolConfig:%d", *protocolVersion)
+	}
+	err := c.executeImmutable(ctx, key, query, vars, &result, func() bool { return result.ProtocolConfigs != nil })
 	if err != nil {
 		return nil, err
 	}
@@ -1008,28 +1302,73 @@ func (c *Client) GetReferenceGasPrice(ctx context.Context) (*BigInt, error) {
 	return result.Epoch.ReferenceGasPrice, nil
 }
 
-// GetServiceConfig returns the GraphQL service configuration.
-func (c *Client) GetServiceConfig(ctx context.Context) (*ServiceConfig, error) {
+// ResolveGasPrice returns the reference gas price of the current epoch. With
+// ResolveCurrentEpoch it lets the client act as a transaction.GasPriceSource.
+func (c *Client) ResolveGasPrice(ctx context.Context) (uint64, error) {
+	price, err := c.GetReferenceGasPrice(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if price == nil {
+		return 0, fmt.Errorf("reference gas price not available")
+	}
+	value, err := strconv.ParseUint(string(*price), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parse reference gas price %q: %w", *price, err)
+	}
+	return value, nil
+}
+
+// ResolveCurrentEpoch returns the current epoch ID. It lets the client act as
+// a transaction.EpochResolver for SetExpirationInEpochs and Build.
+func (c *Client) ResolveCurrentEpoch(ctx context.Context) (uint64, error) {
 	query := `
-		query GetServiceConfig {
-			serviceConfig {
-				maxQueryDepth
-				maxQueryNodes
-				maxOutputNodes
-				queryTimeoutMs
-				maxQueryPayloadSize
-				maxTypeArgumentDepth
-				maxTypeNodes
-				maxMoveValueDepth
+		query GetCurrentEpochId {
+			epoch {
+				epochId
 			}
 		}
 	`
 
 	var result struct {
-		ServiceConfig *ServiceConfig `json:"serviceConfig"`
+		Epoch *struct {
+			EpochID UInt53 `json:"epochId"`
+		} `json:"epoch"`
 	}
 
 	err := c.Execute(ctx, query, nil, &result)
+	if err != nil {
+		return 0, err
+	}
+
+	if result.Epoch == nil {
+		return 0, fmt.Errorf("current epoch not available")
+	}
+	return uint64(result.Epoch.EpochID), nil
+}
+
+const serviceConfigQuery = `
+	query GetServiceConfig {
+		serviceConfig {
+			maxQueryDepth
+			maxQueryNodes
+			maxOutputNodes
+			queryTimeoutMs
+			maxQueryPayloadSize
+			maxTypeArgumentDepth
+			maxTypeNodes
+			maxMoveValueDepth
+		}
+	}
+`
+
+// GetServiceConfig returns the GraphQL service configuration.
+func (c *Client) GetServiceConfig(ctx context.Context) (*ServiceConfig, error) {
+	var result struct {
+		ServiceConfig *ServiceConfig `json:"serviceConfig"`
+	}
+
+	err := c.Execute(ctx, serviceConfigQuery, nil, &result)
 	if err != nil {
 		return nil, err
 	}
@@ -1213,7 +1552,9 @@ func (c *Client) GetPackage(ctx context.Context, address types.Address) (*MovePa
 		} `json:"object"`
 	}
 
-	err := c.Execute(ctx, query, map[string]any{"address": address}, &result)
+	err := c.executeImmutable(ctx, packageCacheKey(address, cachePackage), query, map[string]any{"address": address}, &result, func() bool {
+		return result.Object != nil && result.Object.AsMovePackage != nil
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -1299,7 +1640,9 @@ func (c *Client) GetModule(ctx context.Context, packageAddress types.Address, mo
 		} `json:"object"`
 	}
 
-	err := c.Execute(ctx, query, map[string]any{"address": packageAddress, "module": moduleName}, &result)
+	err := c.executeImmutable(ctx, packageCacheKey(packageAddress, cacheModule, moduleName), query, map[string]any{"address": packageAddress, "module": moduleName}, &result, func() bool {
+		return result.Object != nil && result.Object.AsMovePackage != nil && result.Object.AsMovePackage.Module != nil
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -1350,11 +1693,15 @@ func (c *Client) GetNormalizedMoveFunction(ctx context.Context, packageAddress t
 		} `json:"object"`
 	}
 
-	err := c.Execute(ctx, query, map[string]any{
+	found := func() bool {
+		return result.Object != nil && result.Object.AsMovePackage != nil && result.Object.AsMovePackage.Module != nil &&
+			result.Object.AsMovePackage.Module.Function != nil
+	}
+	err := c.executeImmutable(ctx, packageCacheKey(packageAddress, cacheFunction, moduleName, functionName), query, map[string]any{
 		"address":  packageAddress,
 		"module":   moduleName,
 		"function": functionName,
-	}, &result)
+	}, &result, found)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,360 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/open-move/sui-go-sdk/keypair"
+	"github.com/open-move/sui-go-sdk/transaction"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+func TestObjectHistoryQueries(t *testing.T) {
+	objectID := utils.MustParseAddress("0x5")
+
+	var lastVars map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query     string         `json:"query"`
+			Variables map[string]any `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		lastVars = req.Variables
+
+		var data any
+		switch {
+		case strings.Contains(req.Query, "objectVersions("):
+			data = map[string]any{"objectVersions": map[string]any{
+				"pageInfo": map[string]any{"hasNextPage": false},
+				"nodes": []map[string]any{
+					{"address": objectID.String(), "version": 3},
+					{"address": objectID.String(), "version": 7},
+				},
+			}}
+		case strings.Contains(req.Query, "version: $version"):
+			data = map[string]any{"object": map[string]any{"address": objectID.String(), "version": req.Variables["version"]}}
+		case strings.Contains(req.Query, "atCheckpoint: $checkpoint"):
+			data = map[string]any{"object": map[string]any{"address": objectID.String(), "version": 9}}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithEndpoint(server.URL))
+	ctx := context.Background()
+
+	obj, err := client.GetObjectAtVersion(ctx, objectID, 4, nil)
+	if err != nil {
+		t.Fatalf("at version: %v", err)
+	}
+	if obj.Version != 4 {
+		t.Fatalf("unexpected version %d", obj.Version)
+	}
+
+	obj, err = client.GetObjectAtCheckpoint(ctx, objectID, 100, nil)
+	if err != nil {
+		t.Fatalf("at checkpoint: %v", err)
+	}
+	if obj.Version != 9 || lastVars["checkpoint"].(float64) != 100 {
+		t.Fatalf("unexpected checkpoint lookup %+v %v", obj, lastVars)
+	}
+
+	history, err := client.GetObjectVersionHistory(ctx, objectID, &VersionFilter{AfterVersion: utils.Ptr(UInt53(2))}, &PaginationArgs{First: utils.Ptr(10)}, nil)
+	if err != nil {
+		t.Fatalf("history: %v", err)
+	}
+	if len(history.Nodes) != 2 || history.Nodes[1].Version != 7 {
+		t.Fatalf("unexpected history %+v", history.Nodes)
+	}
+	filter, ok := lastVars["filter"].(map[string]any)
+	if !ok || filter["afterVersion"].(float64) != 2 || lastVars["first"].(float64) != 10 {
+		t.Fatalf("unexpected history variables %v", lastVars)
+	}
+}
+
+func TestGetMultipleTransactionBlocksBatched(t *testing.T) {
+	var inFlight, peak, batches, singles int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		var req struct {
+			Query     string         `json:"query"`
+			Variables map[string]any `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		lookup := func(digest string) any {
+			if digest == "missing" {
+				return nil
+			}
+			return map[string]any{"digest": digest}
+		}
+
+		switch {
+		case strings.Contains(req.Query, "serviceConfig"):
+			// Each aliased transaction { digest } costs two nodes, so three fit.
+			json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"serviceConfig": map[string]any{"maxQueryNodes": 6}}})
+		case strings.Contains(req.Query, "GetMultipleTransactions"):
+			atomic.AddInt32(&batches, 1)
+			data := map[string]any{}
+			for name, value := range req.Variables {
+				if value == "bad" {
+					json.NewEncoder(w).Encode(map[string]any{"errors": []map[string]any{{"message": "boom", "path": []string{"t" + name[1:]}}}})
+					return
+				}
+				data["t"+name[1:]] = lookup(value.(string))
+			}
+			json.NewEncoder(w).Encode(map[string]any{"data": data})
+		default:
+			atomic.AddInt32(&singles, 1)
+			digest := req.Variables["digest"].(string)
+			if digest == "bad" {
+				json.NewEncoder(w).Encode(map[string]any{"errors": []map[string]any{{"message": "boom"}}})
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"transaction": lookup(digest)}})
+		}
+	}))
+	defer server.Close()
+
+	var digests, want []string
+	for i := 0; i < 8; i++ {
+		switch i {
+		case 2:
+			digests = append(digests, "bad")
+		case 4:
+			digests = append(digests, "missing")
+		default:
+			d := testDigest(byte(i + 1))
+			digests = append(digests, d)
+			want = append(want, d)
+		}
+	}
+	client := NewClient(WithEndpoint(server.URL), WithConcurrency(2), WithRetries(0))
+	txs, err := client.GetMultipleTransactionBlocks(context.Background(), digests, &TransactionBlockOptions{})
+
+	var multiErr MultiError
+	if !errors.As(err, &multiErr) || len(multiErr) != 1 || !strings.Contains(multiErr[0].Error(), "bad") {
+		t.Fatalf("expected a single aggregated failure, got %v", err)
+	}
+	if len(txs) != len(want) {
+		t.Fatalf("expected %d transactions, got %d", len(want), len(txs))
+	}
+	for i, tx := range txs {
+		if tx.Digest.String() != want[i] {
+			t.Fatalf("result %d: got %s, want %s", i, tx.Digest, want[i])
+		}
+	}
+	if batches != 3 {
+		t.Fatalf("expected 3 batched requests, got %d", batches)
+	}
+	// Only the chunk containing the failing digest falls back to single lookups.
+	if singles != 3 {
+		t.Fatalf("expected 3 fallback requests, got %d", singles)
+	}
+	if peak > 2 {
+		t.Fatalf("concurrency limit exceeded: %d", peak)
+	}
+}
+
+func TestResolveCurrentEpoch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"epoch":{"epochId":812}}}`))
+	}))
+	defer server.Close()
+
+	var resolver transaction.EpochResolver = NewClient(WithEndpoint(server.URL))
+	epoch, err := resolver.ResolveCurrentEpoch(context.Background())
+	if err != nil {
+		t.Fatalf("ResolveCurrentEpoch: %v", err)
+	}
+	if epoch != 812 {
+		t.Fatalf("got epoch %d, want 812", epoch)
+	}
+}
+
+func TestResolveGasPrice(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"epoch":{"referenceGasPrice":"750"}}}`))
+	}))
+	defer server.Close()
+
+	var source transaction.GasPriceSource = NewClient(WithEndpoint(server.URL))
+	price, err := source.ResolveGasPrice(context.Background())
+	if err != nil {
+		t.Fatalf("ResolveGasPrice: %v", err)
+	}
+	if price != 750 {
+		t.Fatalf("got price %d, want 750", price)
+	}
+}
+
+func TestHasActivity(t *testing.T) {
+	responses := map[string]string{
+		"0x1": `{"data":{"address":{"balances":{"nodes":[]},"objects":{"nodes":[]}},"transactions":{"nodes":[{"digest":"` + testDigest(1) + `"}]}}}`,
+		"0x2": `{"data":{"address":{"balances":{"nodes":[{"totalBalance":"5"}]},"objects":{"nodes":[]}},"transactions":{"nodes":[]}}}`,
+		"0x3": `{"data":{"address":{"balances":{"nodes":[]},"objects":{"nodes":[]}},"transactions":{"nodes":[]}}}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables struct {
+				Address string `json:"address"`
+				Filter  struct {
+					SentAddress string `json:"sentAddress"`
+				} `json:"filter"`
+			} `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Variables.Filter.SentAddress != req.Variables.Address {
+			t.Errorf("filter address %q, want %q", req.Variables.Filter.SentAddress, req.Variables.Address)
+		}
+		key := "0x" + strings.TrimLeft(strings.TrimPrefix(req.Variables.Address, "0x"), "0")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(responses[key]))
+	}))
+	defer server.Close()
+
+	var checker keypair.ActivityChecker = NewClient(WithEndpoint(server.URL))
+	for address, want := range map[string]bool{"0x1": true, "0x2": true, "0x3": false} {
+		got, err := checker.HasActivity(context.Background(), utils.MustParseAddress(address))
+		if err != nil {
+			t.Fatalf("HasActivity(%s): %v", address, err)
+		}
+		if got != want {
+			t.Fatalf("HasActivity(%s) = %v, want %v", address, got, want)
+		}
+	}
+}
+
+func TestQueryTransactionBlocksWithOptions(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req graphqlRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		query = req.Query
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"transactions":{"pageInfo":{"hasNextPage":false},"nodes":[{"digest":"` + testDigest(1) + `"}]}}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithEndpoint(server.URL))
+	conn, err := client.QueryTransactionBlocksWithOptions(context.Background(), nil, nil, DigestOnlyTransactionOptions())
+	if err != nil {
+		t.Fatalf("QueryTransactionBlocksWithOptions: %v", err)
+	}
+	if len(conn.Nodes) != 1 || conn.Nodes[0].Digest.String() != testDigest(1) {
+		t.Fatalf("unexpected nodes %+v", conn.Nodes)
+	}
+	for _, field := range []string{"effects", "signatures", "transactionBcs", "kind"} {
+		if strings.Contains(query, field) {
+			t.Fatalf("digest-only query selects %s:\n%s", field, query)
+		}
+	}
+
+	if _, err := client.QueryTransactionBlocks(context.Background(), nil, nil); err != nil {
+		t.Fatalf("QueryTransactionBlocks: %v", err)
+	}
+	for _, field := range []string{"effects", "signatures", "transactionBcs", "gasPayment"} {
+		if !strings.Contains(query, field) {
+			t.Fatalf("default query is missing %s", field)
+		}
+	}
+
+	if !strings.Contains(transactionSelection(nil), "events") {
+		t.Fatal("default selection is missing events")
+	}
+	if strings.Contains(transactionSelection(StatusTransactionOptions()), "events") {
+		t.Fatal("status selection requests events")
+	}
+	full := transactionSelection(FullTransactionOptions())
+	if !strings.Contains(full, "events") {
+		t.Fatal("full selection is missing events")
+	}
+	if minimal := transactionSelection(StatusTransactionOptions()); len(minimal) >= len(full)/2 {
+		t.Fatalf("status selection is not much smaller than full: %d vs %d bytes", len(minimal), len(full))
+	}
+}
+
+func TestGetCoinsOfTotalValue(t *testing.T) {
+	owner := utils.MustParseAddress("0x5")
+	coinBcs := func(balance byte) []byte {
+		bcs := make([]byte, 40)
+		bcs[32] = balance
+		return bcs
+	}
+
+	var pages atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables map[string]any `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		pages.Add(1)
+
+		var objects map[string]any
+		if req.Variables["after"] == nil {
+			objects = map[string]any{
+				"pageInfo": map[string]any{"hasNextPage": true, "endCursor": "c1"},
+				"nodes": []map[string]any{
+					{"address": "0x11", "version": 1, "contents": map[string]any{"bcs": coinBcs(30)}},
+					{"address": "0x12", "version": 1, "contents": map[string]any{"json": map[string]any{"id": "0x12", "balance": "0"}}},
+				},
+			}
+		} else {
+			objects = map[string]any{
+				"pageInfo": map[string]any{"hasNextPage": false, "endCursor": "c2"},
+				"nodes": []map[string]any{
+					{"address": "0x13", "version": 1, "contents": map[string]any{"json": map[string]any{"id": "0x13", "balance": "50"}}},
+					{"address": "0x14", "version": 1, "contents": map[string]any{"bcs": coinBcs(70)}},
+				},
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"address": map[string]any{"objects": objects}}})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithEndpoint(server.URL), WithRetries(0))
+	ctx := context.Background()
+
+	page, err := client.GetCoins(ctx, owner, nil, nil)
+	if err != nil {
+		t.Fatalf("get coins: %v", err)
+	}
+	if page.Nodes[0].CoinBalance != "30" || page.Nodes[1].CoinBalance != "0" {
+		t.Fatalf("unexpected balances %q %q", page.Nodes[0].CoinBalance, page.Nodes[1].CoinBalance)
+	}
+
+	pages.Store(0)
+	coins, err := client.GetCoinsOfTotalValue(ctx, owner, nil, 60)
+	if err != nil {
+		t.Fatalf("coins of total value: %v", err)
+	}
+	if len(coins) != 2 || coins[1].CoinBalance != "50" {
+		t.Fatalf("unexpected selection %+v", coins)
+	}
+	if pages.Load() != 2 {
+		t.Fatalf("expected 2 pages, fetched %d", pages.Load())
+	}
+
+	_, err = client.GetCoinsOfTotalValue(ctx, owner, nil, 1000)
+	if !errors.Is(err, ErrInsufficientCoins) {
+		t.Fatalf("expected ErrInsufficientCoins, got %v", err)
+	}
+}
@@ -2,8 +2,11 @@ package graphql
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/open-move/sui-go-sdk/types"
 )
@@ -20,6 +23,7 @@ type QueryBuilder struct {
 	operationName string
 	variables     []variableDef
 	selections    []selectionBuilder
+	fragments     []fragmentDef
 	variableIndex int
 }
 
@@ -38,6 +42,7 @@ type selectionBuilder struct {
 	selections []selectionBuilder
 	inline     bool   // for inline fragments
 	typeName   string // for inline fragments (...on Type)
+	spread     string // for named fragment spreads (...Name)
 }
 
 // argumentBuilder represents a GraphQL field argument.
@@ -165,6 +170,12 @@ func (fb *FieldBuilder) InlineFragment(typeName string) *SubFieldBuilder {
 	}
 }
 
+// UseFragment spreads a named fragment into the field's selection set.
+func (fb *FieldBuilder) UseFragment(name string) *FieldBuilder {
+	fb.selection.selections = append(fb.selection.selections, selectionBuilder{spread: name})
+	return fb
+}
+
 // Done finalizes the field and adds it to the query.
 func (fb *FieldBuilder) Done() *QueryBuilder {
 	fb.parent.selections = append(fb.parent.selections, fb.selection)
@@ -229,6 +240,12 @@ func (sfb *SubFieldBuilder) InlineFragment(typeName string) *NestedSubFieldBuild
 	}
 }
 
+// UseFragment spreads a named fragment into the sub-field's selection set.
+func (sfb *SubFieldBuilder) UseFragment(name string) *SubFieldBuilder {
+	sfb.selection.selections = append(sfb.selection.selections, selectionBuilder{spread: name})
+	return sfb
+}
+
 // End finalizes the sub-field and returns to the parent.
 func (sfb *SubFieldBuilder) End() *FieldBuilder {
 	sfb.parent.selection.selections = append(sfb.parent.selection.selections, sfb.selection)
@@ -293,6 +310,12 @@ func (nsfb *NestedSubFieldBuilder) InlineFragment(typeName string) *DeepNestedSu
 	}
 }
 
+// UseFragment spreads a named fragment.
+func (nsfb *NestedSubFieldBuilder) UseFragment(name string) *NestedSubFieldBuilder {
+	nsfb.selection.selections = append(nsfb.selection.selections, selectionBuilder{spread: name})
+	return nsfb
+}
+
 // End finalizes and returns to parent.
 func (nsfb *NestedSubFieldBuilder) End() *SubFieldBuilder {
 	nsfb.parent.selection.selections = append(nsfb.parent.selection.selections, nsfb.selection)
@@ -336,6 +359,12 @@ func (dnsfb *DeepNestedSubFieldBuilder) SubField(name string) *DeepNestedSubFiel
 	return dnsfb
 }
 
+// UseFragment spreads a named fragment.
+func (dnsfb *DeepNestedSubFieldBuilder) UseFragment(name string) *DeepNestedSubFieldBuilder {
+	dnsfb.selection.selections = append(dnsfb.selection.selections, selectionBuilder{spread: name})
+	return dnsfb
+}
+
 // End finalizes and returns to parent.
 func (dnsfb *DeepNestedSubFieldBuilder) End() *NestedSubFieldBuilder {
 	dnsfb.parent.selection.selections = append(dnsfb.parent.selection.selections, dnsfb.selection)
@@ -377,6 +406,19 @@ func (qb *QueryBuilder) Build() (string, map[string]any) {
 
 	sb.WriteString("}")
 
+	// Fragment definitions referenced by the operation
+	for _, frag := range qb.usedFragments() {
+		sb.WriteString("\n\nfragment ")
+		sb.WriteString(frag.name)
+		sb.WriteString(" on ")
+		sb.WriteString(frag.typeCondition)
+		sb.WriteString(" {\n")
+		for _, sel := range frag.selections {
+			qb.writeSelection(&sb, sel, 1)
+		}
+		sb.WriteString("}")
+	}
+
 	// Build variables map
 	vars := make(map[string]any)
 	for _, v := range qb.variables {
@@ -390,6 +432,14 @@ func (qb *QueryBuilder) Build() (string, map[string]any) {
 func (qb *QueryBuilder) writeSelection(sb *strings.Builder, sel selectionBuilder, indent int) {
 	indentStr := strings.Repeat("  ", indent)
 
+	if sel.spread != "" {
+		sb.WriteString(indentStr)
+		sb.WriteString("...")
+		sb.WriteString(sel.spread)
+		sb.WriteString("\n")
+		return
+	}
+
 	if sel.inline {
 		sb.WriteString(indentStr)
 		sb.WriteString("... on ")
@@ -467,6 +517,128 @@ func (qb *QueryBuilder) Execute(ctx context.Context, client *Client, result any)
 	return client.Execute(ctx, query, vars, result)
 }
 
+// =============================================================================
+// Fragments
+// =============================================================================
+
+// Built-in fragment names. These can be passed to UseFragment without being
+// defined on the builder first.
+const (
+	FragmentGasCostSummary = "GasCostSummaryFields"
+	FragmentOwner          = "OwnerFields"
+	FragmentObjectRef      = "ObjectRefFields"
+	FragmentPageInfo       = "PageInfoFields"
+	FragmentMoveValue      = "MoveValueFields"
+)
+
+// fragmentDef represents a named GraphQL fragment definition.
+type fragmentDef struct {
+	name          string
+	typeCondition string
+	selections    []selectionBuilder
+}
+
+// builtinFragments holds the fragments for common Sui types.
+var builtinFragments = map[string]fragmentDef{
+	FragmentGasCostSummary: newFragmentDef(FragmentGasCostSummary, "GasCostSummary",
+		"computationCost", "storageCost", "storageRebate", "nonRefundableStorageFee"),
+	FragmentOwner: newFragmentDef(FragmentOwner, "Owner",
+		"__typename",
+		"... on AddressOwner { address { address } }",
+		"... on ObjectOwner { address { address } }",
+		"... on Shared { initialSharedVersion }",
+		"... on ConsensusAddressOwner { startVersion address { address } }"),
+	FragmentObjectRef: newFragmentDef(FragmentObjectRef, "Object",
+		"address", "version", "digest"),
+	FragmentPageInfo: newFragmentDef(FragmentPageInfo, "PageInfo",
+		"hasNextPage", "hasPreviousPage", "startCursor", "endCursor"),
+	FragmentMoveValue: newFragmentDef(FragmentMoveValue, "MoveValue",
+		"type { repr }", "bcs", "json"),
+}
+
+// fragmentSpreadPattern matches named fragment spreads inside raw field strings.
+var fragmentSpreadPattern = regexp.MustCompile(`\.\.\.\s*([A-Za-z_][A-Za-z0-9_]*)`)
+
+func newFragmentDef(name, typeCondition string, fields ...string) fragmentDef {
+	frag := fragmentDef{name: name, typeCondition: typeCondition}
+	for _, f := range fields {
+		frag.selections = append(frag.selections, selectionBuilder{name: f})
+	}
+	return frag
+}
+
+// Fragment defines a named fragment on typeCondition. Fields are written
+// verbatim, so they may contain nested selections or spreads of other
+// fragments. Defining a fragment with the name of a built-in overrides it.
+func (qb *QueryBuilder) Fragment(name, typeCondition string, fields ...string) *QueryBuilder {
+	frag := newFragmentDef(name, typeCondition, fields...)
+	for i := range qb.fragments {
+		if qb.fragments[i].name == name {
+			qb.fragments[i] = frag
+			return qb
+		}
+	}
+	qb.fragments = append(qb.fragments, frag)
+	return qb
+}
+
+// UseFragment spreads a named fragment at the root of the operation.
+func (qb *QueryBuilder) UseFragment(name string) *QueryBuilder {
+	qb.selections = append(qb.selections, selectionBuilder{spread: name})
+	return qb
+}
+
+// lookupFragment returns a user-defined fragment, falling back to built-ins.
+func (qb *QueryBuilder) lookupFragment(name string) (fragmentDef, bool) {
+	for _, frag := range qb.fragments {
+		if frag.name == name {
+			return frag, true
+		}
+	}
+	frag, ok := builtinFragments[name]
+	return frag, ok
+}
+
+// usedFragments returns the fragments reachable from the operation's
+// selections, in the order they are first referenced. Unknown names are
+// left for the server to report.
+func (qb *QueryBuilder) usedFragments() []fragmentDef {
+	var used []fragmentDef
+	seen := make(map[string]bool)
+
+	var visit func(sels []selectionBuilder)
+	var use func(name string)
+	use = func(name string) {
+		if seen[name] {
+			return
+		}
+		seen[name] = true
+		frag, ok := qb.lookupFragment(name)
+		if !ok {
+			return
+		}
+		used = append(used, frag)
+		visit(frag.selections)
+	}
+	visit = func(sels []selectionBuilder) {
+		for _, sel := range sels {
+			if sel.spread != "" {
+				use(sel.spread)
+				continue
+			}
+			for _, m := range fragmentSpreadPattern.FindAllStringSubmatch(sel.name, -1) {
+				if m[1] != "on" {
+					use(m[1])
+				}
+			}
+			visit(sel.selections)
+		}
+	}
+
+	visit(qb.selections)
+	return used
+}
+
 // =============================================================================
 // Pre-built Query Templates (using raw queries for complex nested structures)
 // =============================================================================
@@ -687,35 +859,150 @@ func (pq *PagedQuery[T]) FetchPage(ctx context.Context, cursor *string) (*Connec
 	return pq.resultPath(rawResult)
 }
 
-// FetchAll fetches all pages and returns combined results.
+// FetchAll fetches all pages and returns combined results. If a page fails,
+// the nodes fetched so far are returned together with a *PaginationError
+// holding the cursor to resume from.
 func (pq *PagedQuery[T]) FetchAll(ctx context.Context, maxPages int) ([]T, error) {
+	return pq.FetchAllResumable(ctx, FetchOptions{MaxPages: maxPages})
+}
+
+// FetchOptions configures FetchAllResumable and ForEachPage.
+type FetchOptions struct {
+	// MaxPages stops after this many pages. Zero means no limit.
+	MaxPages int
+	// StartCursor resumes from a cursor saved by OnProgress or returned in a
+	// PaginationError.
+	StartCursor *string
+	// Retry controls how a failed page is retried before giving up.
+	Retry PageRetryPolicy
+	// OnProgress is called after each page. Returning an error stops
+	// pagination with that error.
+	OnProgress func(PageProgress) error
+}
+
+// PageRetryPolicy controls the retries of a single page. Retries start from
+// the page's cursor, so nodes are never fetched twice.
+type PageRetryPolicy struct {
+	// MaxAttempts is the number of attempts per page; values below 2
+	// disable retries.
+	MaxAttempts int
+	// Backoff is the delay before the first retry, doubled on each retry.
+	Backoff time.Duration
+	// Retryable reports whether err is worth retrying. When nil, every error
+	// except context cancellation is retried.
+	Retryable func(err error) bool
+}
+
+// PageProgress reports the state of a pagination after a page completes.
+type PageProgress struct {
+	// Pages is the number of pages fetched so far.
+	Pages int
+	// Nodes is the number of nodes fetched so far.
+	Nodes int
+	// Cursor is the cursor to resume from after this page.
+	Cursor *string
+	// HasNextPage reports whether more pages remain.
+	HasNextPage bool
+}
+
+// PaginationError is returned when a page fails after its retries. Cursor is
+// the start cursor of the failed page; pass it as FetchOptions.StartCursor
+// to resume.
+type PaginationError struct {
+	Cursor *string
+	Pages  int
+	Err    error
+}
+
+func (e *PaginationError) Error() string {
+	cursor := "<start>"
+	if e.Cursor != nil {
+		cursor = *e.Cursor
+	}
+	return fmt.Sprintf("pagination stopped after %d pages (resume from %s): %v", e.Pages, cursor, e.Err)
+}
+
+func (e *PaginationError) Unwrap() error {
+	return e.Err
+}
+
+// FetchAllResumable fetches pages as configured by opts and returns the
+// combined nodes. On failure it returns the nodes fetched so far together
+// with a *PaginationError.
+func (pq *PagedQuery[T]) FetchAllResumable(ctx context.Context, opts FetchOptions) ([]T, error) {
 	var allNodes []T
-	var cursor *string
-	pages := 0
+	err := pq.ForEachPage(ctx, opts, func(nodes []T) error {
+		allNodes = append(allNodes, nodes...)
+		return nil
+	})
+	return allNodes, err
+}
+
+// ForEachPage fetches pages as configured by opts and hands each page's
+// nodes to fn without accumulating them, which suits long backfills. An
+// error from fn stops pagination and is returned as is.
+func (pq *PagedQuery[T]) ForEachPage(ctx context.Context, opts FetchOptions, fn func(nodes []T) error) error {
+	cursor := opts.StartCursor
+	pages, count := 0, 0
 
 	for {
-		conn, err := pq.FetchPage(ctx, cursor)
+		conn, err := pq.fetchPageWithRetry(ctx, cursor, opts.Retry)
 		if err != nil {
-			return nil, err
+			return &PaginationError{Cursor: cursor, Pages: pages, Err: err}
 		}
-
 		if conn == nil {
-			break
+			return nil
 		}
 
-		allNodes = append(allNodes, conn.Nodes...)
+		if err := fn(conn.Nodes); err != nil {
+			return err
+		}
+		pages++
+		count += len(conn.Nodes)
 
-		if !conn.PageInfo.HasNextPage {
-			break
+		hasNext := conn.PageInfo.HasNextPage && conn.PageInfo.EndCursor != nil
+		if conn.PageInfo.EndCursor != nil {
+			cursor = conn.PageInfo.EndCursor
+		}
+		if opts.OnProgress != nil {
+			if err := opts.OnProgress(PageProgress{Pages: pages, Nodes: count, Cursor: cursor, HasNextPage: hasNext}); err != nil {
+				return err
+			}
 		}
 
-		cursor = conn.PageInfo.EndCursor
-		pages++
+		if !hasNext || (opts.MaxPages > 0 && pages >= opts.MaxPages) {
+			return nil
+		}
+	}
+}
 
-		if maxPages > 0 && pages >= maxPages {
-			break
+func (pq *PagedQuery[T]) fetchPageWithRetry(ctx context.Context, cursor *string, policy PageRetryPolicy) (*Connection[T], error) {
+	delay := policy.Backoff
+	for attempt := 1; ; attempt++ {
+		conn, err := pq.FetchPage(ctx, cursor)
+		if err == nil || attempt >= policy.MaxAttempts || !policy.retryable(err) {
+			return conn, err
+		}
+
+		if delay > 0 {
+			timer := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, err
+			case <-timer.C:
+			}
+			delay *= 2
 		}
 	}
+}
 
-	return allNodes, nil
+func (p PageRetryPolicy) retryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if p.Retryable != nil {
+		return p.Retryable(err)
+	}
+	return true
 }
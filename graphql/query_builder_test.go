@@ -0,0 +1,122 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestQueryBuilderFragments(t *testing.T) {
+	qb := NewQueryBuilder().Name("Tx")
+	qb.Fragment("EffectsFields", "TransactionEffects", "status", "gasEffects { gasSummary { ...GasCostSummaryFields } }").
+		Fragment("Unused", "Object", "address")
+	qb.Field("transaction").
+		Arg("digest", "abc").
+		Fields("digest").
+		SubField("effects").UseFragment("EffectsFields").End().
+		Done()
+
+	query, _ := qb.Build()
+
+	if !strings.Contains(query, "effects {\n      ...EffectsFields\n") {
+		t.Fatalf("missing spread:\n%s", query)
+	}
+	if !strings.Contains(query, "fragment EffectsFields on TransactionEffects {") {
+		t.Fatalf("missing user fragment:\n%s", query)
+	}
+	if !strings.Contains(query, "fragment GasCostSummaryFields on GasCostSummary {") {
+		t.Fatalf("missing nested built-in fragment:\n%s", query)
+	}
+	if strings.Contains(query, "fragment Unused") {
+		t.Fatalf("unreferenced fragment emitted:\n%s", query)
+	}
+	if strings.Count(query, "fragment ") != 2 {
+		t.Fatalf("unexpected fragment count:\n%s", query)
+	}
+}
+
+func TestQueryBuilderFragmentOverride(t *testing.T) {
+	qb := NewQueryBuilder().Fragment(FragmentPageInfo, "PageInfo", "endCursor")
+	qb.Field("events").SubField("pageInfo").UseFragment(FragmentPageInfo).End().Done()
+
+	query, _ := qb.Build()
+	if strings.Contains(query, "hasNextPage") || !strings.Contains(query, "fragment PageInfoFields on PageInfo {\n  endCursor\n}") {
+		t.Fatalf("built-in fragment not overridden:\n%s", query)
+	}
+}
+
+func TestPagedQueryResumable(t *testing.T) {
+	var failures atomic.Int32
+	failures.Store(2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables map[string]any `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		page := 0
+		if after, ok := req.Variables["after"].(string); ok {
+			page, _ = strconv.Atoi(after)
+		}
+		if page == 2 && failures.Add(-1) >= 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"items": map[string]any{
+			"pageInfo": map[string]any{"hasNextPage": page < 3, "endCursor": strconv.Itoa(page + 1)},
+			"nodes":    []int{page * 10, page*10 + 1},
+		}}})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithEndpoint(server.URL), WithRetries(0))
+	pq := NewPagedQuery(client, func(cursor *string) *QueryBuilder {
+		qb := NewQueryBuilder()
+		after := qb.Variable("after", "String", cursor)
+		qb.Field("items").Arg("after", after).Fields("nodes").Done()
+		return qb
+	}, func(raw any) (*Connection[int], error) {
+		data, err := json.Marshal(raw.(map[string]any)["items"])
+		if err != nil {
+			return nil, err
+		}
+		var conn Connection[int]
+		return &conn, json.Unmarshal(data, &conn)
+	})
+	ctx := context.Background()
+
+	nodes, err := pq.FetchAll(ctx, 0)
+	var pageErr *PaginationError
+	if !errors.As(err, &pageErr) {
+		t.Fatalf("expected PaginationError, got %v", err)
+	}
+	if len(nodes) != 4 || pageErr.Pages != 2 || pageErr.Cursor == nil || *pageErr.Cursor != "2" {
+		t.Fatalf("unexpected partial result %v %+v", nodes, pageErr)
+	}
+
+	var progress []PageProgress
+	rest, err := pq.FetchAllResumable(ctx, FetchOptions{
+		StartCursor: pageErr.Cursor,
+		Retry:       PageRetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond},
+		OnProgress: func(p PageProgress) error {
+			progress = append(progress, p)
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("resume: %v", err)
+	}
+	if len(rest) != 4 || rest[0] != 20 || rest[3] != 31 {
+		t.Fatalf("unexpected resumed nodes %v", rest)
+	}
+	if len(progress) != 2 || progress[1].Nodes != 4 || progress[1].HasNextPage || *progress[1].Cursor != "4" {
+		t.Fatalf("unexpected progress %+v", progress)
+	}
+}
@@ -0,0 +1,55 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/open-move/sui-go-sdk/ratelimit"
+)
+
+// WithRateLimit throttles the client to rps requests per second, with
+// bursts of up to burst requests. Every HTTP attempt, including retries,
+// takes a token. Use WithRateLimiter to share one quota between clients.
+func WithRateLimit(rps float64, burst int) ClientOption {
+	return func(c *Client) {
+		if bucket := ratelimit.NewTokenBucket(rps, burst); bucket != nil {
+			c.limiter = bucket
+		}
+	}
+}
+
+// WithRateLimiter throttles the client with l, which may be shared with
+// other clients so that together they respect a provider's global quota.
+// Any ratelimit.Limiter works, including a *rate.Limiter from
+// golang.org/x/time/rate.
+func WithRateLimiter(l ratelimit.Limiter) ClientOption {
+	return func(c *Client) {
+		c.limiter = l
+	}
+}
+
+// acquire waits for the rate limiter before an HTTP attempt and records the
+// wait on the operation carried by ctx.
+func (c *Client) acquire(ctx context.Context) error {
+	if c.limiter == nil {
+		return nil
+	}
+	start := time.Now()
+	err := c.limiter.Wait(ctx)
+	noteThrottle(ctx, time.Since(start))
+	if err != nil {
+		return fmt.Errorf("rate limit: %w", err)
+	}
+	return nil
+}
+
+// noteThrottle records time spent waiting for the rate limiter on the
+// operation carried by ctx, if any.
+func noteThrottle(ctx context.Context, waited time.Duration) {
+	if op, ok := ctx.Value(operationKey{}).(*operation); ok {
+		op.mu.Lock()
+		op.throttled += waited
+		op.mu.Unlock()
+	}
+}
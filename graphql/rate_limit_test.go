@@ -0,0 +1,60 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/open-move/sui-go-sdk/ratelimit"
+)
+
+func TestRateLimit(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"chainIdentifier":"4c78adac"}}`))
+	}))
+	defer server.Close()
+
+	// Two clients share one bucket holding a single token.
+	quota := ratelimit.NewTokenBucket(40, 1)
+	collector := NewMetricsCollector()
+	first := NewClient(WithEndpoint(server.URL), WithRateLimiter(quota), WithMetrics(collector))
+	second := NewClient(WithEndpoint(server.URL), WithRateLimiter(quota))
+	query := `query GetChainIdentifier { chainIdentifier }`
+
+	start := time.Now()
+	for _, client := range []*Client{first, second, first} {
+		if err := client.Execute(context.Background(), query, nil, nil); err != nil {
+			t.Fatalf("execute: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Fatalf("requests were not throttled: %v", elapsed)
+	}
+	if stats := quota.Stats(); stats.Acquired != 3 || stats.Throttled != 2 {
+		t.Fatalf("unexpected bucket stats %+v", stats)
+	}
+	if stats := collector.Snapshot()["GetChainIdentifier"]; stats.Count != 2 || stats.ThrottleWait < 10*time.Millisecond {
+		t.Fatalf("expected the throttle wait in the metrics, got %+v", stats)
+	}
+
+	slow := NewClient(WithEndpoint(server.URL), WithRateLimit(0.001, 1), WithRetries(0))
+	if err := slow.Execute(context.Background(), query, nil, nil); err != nil {
+		t.Fatalf("the first request should not wait: %v", err)
+	}
+	before := calls.Load()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	if err := slow.Execute(ctx, query, nil, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the wait to end with the context, got %v", err)
+	}
+	if calls.Load() != before {
+		t.Fatalf("a throttled request was sent")
+	}
+}
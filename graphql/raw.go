@@ -0,0 +1,147 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// =============================================================================
+// Raw Responses and Request Hooks
+// =============================================================================
+
+// RawResponse is a GraphQL response envelope as the server sent it.
+type RawResponse struct {
+	// Data is the undecoded data field; it may be partial when Errors is set.
+	Data json.RawMessage `json:"data,omitempty"`
+	// Errors are the errors with their paths, locations and extensions.
+	Errors []GraphQLError `json:"errors,omitempty"`
+	// Extensions holds response extensions, such as usage or cost reports.
+	Extensions map[string]any `json:"extensions,omitempty"`
+
+	// StatusCode and Header are those of the HTTP response.
+	StatusCode int         `json:"-"`
+	Header     http.Header `json:"-"`
+	// Body is the response body; for non-JSON error responses it is the only
+	// record of what the server said.
+	Body []byte `json:"-"`
+	// RequestBody is the exact JSON request body that produced the response.
+	RequestBody []byte `json:"-"`
+	// Attempts counts the requests sent, including retries.
+	Attempts int `json:"-"`
+	// Duration is the time ExecuteRaw took, including retries.
+	Duration time.Duration `json:"-"`
+
+	numbers NumberMode
+}
+
+// Err returns the response's GraphQL errors, or an error for an HTTP error
+// status, or nil.
+func (r *RawResponse) Err() error {
+	if len(r.Errors) > 0 {
+		return GraphQLErrors(r.Errors)
+	}
+	if r.StatusCode >= 400 {
+		return &httpStatusError{StatusCode: r.StatusCode, Body: string(r.Body)}
+	}
+	return nil
+}
+
+// Decode unmarshals Data into result, decoding numbers with the client's
+// NumberMode.
+func (r *RawResponse) Decode(result any) error {
+	if len(r.Data) == 0 {
+		return nil
+	}
+	return decodeJSON(r.Data, result, r.numbers)
+}
+
+func (r *RawResponse) capture(resp *http.Response, requestBody, body []byte, attempt int) {
+	*r = RawResponse{
+		StatusCode:  resp.StatusCode,
+		Header:      resp.Header,
+		Body:        body,
+		RequestBody: requestBody,
+		Attempts:    attempt + 1,
+	}
+	// Error responses need not be JSON; Body keeps them either way.
+	_ = json.Unmarshal(body, r)
+}
+
+// ExecuteRaw sends a GraphQL query like Execute but returns the whole
+// response envelope instead of decoding its data: errors with their paths
+// and extensions, response extensions, the HTTP status and headers, and the
+// exact request body sent. Responses carrying GraphQL errors or an HTTP
+// error status are returned without error; check RawResponse.Err. An error
+// is returned only when no response was received.
+func (c *Client) ExecuteRaw(ctx context.Context, query string, variables map[string]any, opts ...CallOption) (*RawResponse, error) {
+	raw := &RawResponse{}
+	start := time.Now()
+	err := c.Execute(ctx, query, variables, raw, opts...)
+	raw.Duration = time.Since(start)
+	if raw.StatusCode == 0 {
+		return nil, err
+	}
+	return raw, nil
+}
+
+// RequestInfo describes one HTTP request the client sent, including each
+// retry, and its outcome.
+type RequestInfo struct {
+	Endpoint string
+	// Body is the exact JSON body sent. Query is empty for persisted
+	// queries sent by hash, which are identified in Extensions.
+	Body       []byte
+	Query      string
+	Variables  map[string]any
+	Extensions map[string]any
+	// Attempt is 1 for the first request and increases with each retry.
+	Attempt int
+	// StatusCode is zero when the request failed before a response.
+	StatusCode int
+	Response   []byte
+	Duration   time.Duration
+	// Err is the transport error, if any.
+	Err error
+}
+
+// RequestHook is called after every HTTP request the client sends. Hooks
+// run synchronously on the request path and must not modify Body, Response
+// or Variables.
+type RequestHook func(ctx context.Context, info RequestInfo)
+
+// WithRequestHook calls hook after every request, with the exact query and
+// variables sent and the raw response, which is useful when debugging
+// server-side rejections. Unlike WithLogger, hooks see variable values.
+// Several hooks run in the order they were added.
+func WithRequestHook(hook RequestHook) ClientOption {
+	return func(c *Client) {
+		if hook != nil {
+			c.hooks = append(c.hooks, hook)
+		}
+	}
+}
+
+func (c *Client) runHooks(ctx context.Context, req graphqlRequest, body []byte, attempt int, resp *http.Response, respBody []byte, duration time.Duration, err error) {
+	if len(c.hooks) == 0 {
+		return
+	}
+	info := RequestInfo{
+		Endpoint:   c.endpointFor(ctx),
+		Body:       body,
+		Query:      req.Query,
+		Variables:  req.Variables,
+		Extensions: req.Extensions,
+		Attempt:    attempt + 1,
+		Response:   respBody,
+		Duration:   duration,
+		Err:        err,
+	}
+	if resp != nil {
+		info.StatusCode = resp.StatusCode
+	}
+	for _, hook := range c.hooks {
+		hook(ctx, info)
+	}
+}
@@ -0,0 +1,99 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestExecuteRaw(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("X-Request-Cost", "7")
+		w.Write([]byte(`{
+			"data": {"epoch": {"epochId": 5}, "object": null},
+			"errors": [{"message": "object not found", "path": ["object"], "extensions": {"code": "NOT_FOUND"}}],
+			"extensions": {"usage": {"inputNodes": 3}}
+		}`))
+	}))
+	defer server.Close()
+
+	var infos []RequestInfo
+	client := NewClient(WithEndpoint(server.URL), WithRetries(1), WithRequestHook(func(_ context.Context, info RequestInfo) {
+		infos = append(infos, info)
+	}))
+
+	query := `query Q($id: SuiAddress!) { epoch { epochId } object(address: $id) { version } }`
+	raw, err := client.ExecuteRaw(context.Background(), query, map[string]any{"id": "0x1"})
+	if err != nil {
+		t.Fatalf("execute raw: %v", err)
+	}
+
+	if raw.StatusCode != http.StatusOK || raw.Header.Get("X-Request-Cost") != "7" || raw.Attempts != 2 || raw.Duration <= 0 {
+		t.Fatalf("unexpected envelope %+v", raw)
+	}
+	if len(raw.Errors) != 1 || raw.Errors[0].Path[0] != "object" || raw.Errors[0].Extensions["code"] != "NOT_FOUND" {
+		t.Fatalf("unexpected errors %+v", raw.Errors)
+	}
+	var gqlErrs GraphQLErrors
+	if !errors.As(raw.Err(), &gqlErrs) {
+		t.Fatalf("expected GraphQLErrors, got %v", raw.Err())
+	}
+	if usage, _ := raw.Extensions["usage"].(map[string]any); usage["inputNodes"] != float64(3) {
+		t.Fatalf("unexpected extensions %v", raw.Extensions)
+	}
+
+	var partial struct {
+		Epoch struct {
+			EpochID int `json:"epochId"`
+		} `json:"epoch"`
+	}
+	if err := raw.Decode(&partial); err != nil || partial.Epoch.EpochID != 5 {
+		t.Fatalf("decode partial data: %v %+v", err, partial)
+	}
+
+	var sent graphqlRequest
+	if err := json.Unmarshal(raw.RequestBody, &sent); err != nil || sent.Query != query {
+		t.Fatalf("unexpected request body %s", raw.RequestBody)
+	}
+
+	if len(infos) != 2 {
+		t.Fatalf("expected a hook call per attempt, got %d", len(infos))
+	}
+	if infos[0].Attempt != 1 || infos[0].StatusCode != http.StatusBadGateway || infos[1].Attempt != 2 || infos[1].StatusCode != http.StatusOK {
+		t.Fatalf("unexpected hook calls %+v", infos)
+	}
+	if infos[1].Query != query || infos[1].Variables["id"] != "0x1" || !strings.Contains(string(infos[1].Response), "NOT_FOUND") {
+		t.Fatalf("hook did not see the exchange: %+v", infos[1])
+	}
+}
+
+func TestExecuteRawHTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "query too deep", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithEndpoint(server.URL), WithRetries(0))
+	raw, err := client.ExecuteRaw(context.Background(), `query { epoch { epochId } }`, nil)
+	if err != nil {
+		t.Fatalf("execute raw: %v", err)
+	}
+	if raw.StatusCode != http.StatusBadRequest || !strings.Contains(string(raw.Body), "query too deep") || raw.Err() == nil {
+		t.Fatalf("unexpected response %+v", raw)
+	}
+
+	client = NewClient(WithEndpoint("http://127.0.0.1:1"), WithRetries(0))
+	if _, err := client.ExecuteRaw(context.Background(), `query { epoch { epochId } }`, nil); err == nil {
+		t.Fatalf("expected an error without a response")
+	}
+}
@@ -0,0 +1,278 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	bcs "github.com/iotaledger/bcs-go"
+	"github.com/open-move/sui-go-sdk/transaction"
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+// readCallGasBudget is the budget of ReadMoveCall's transactions. Nothing is
+// charged; the simulation supplies its own gas coin.
+const readCallGasBudget = 50_000_000_000
+
+// ReadMoveCall calls a Move function without a sender, gas coins or a
+// resolver and returns its first return value, ready for DecodeMoveValue:
+// the devInspect pattern for view functions. Arguments are native Go values
+// (or object IDs for object parameters), encoded against the function
+// signature; signatures are fetched once per client and cached. The call
+// runs as the zero address with checks disabled.
+//
+// Use SimulateMoveCall for functions with several return values or to run
+// as a particular sender.
+func (c *Client) ReadMoveCall(ctx context.Context, target string, typeArgs []string, args []any) (*MoveValue, error) {
+	pkg, module, function, err := utils.ParseMoveCallTarget(target)
+	if err != nil {
+		return nil, err
+	}
+	pkgAddress, err := utils.ParseAddress(pkg)
+	if err != nil {
+		return nil, fmt.Errorf("read move call: package: %w", err)
+	}
+
+	tx := transaction.New()
+	tx.MoveCall(transaction.MoveCall{Target: target, TypeArguments: typeArgs, Values: args})
+	built, err := tx.Build(ctx, transaction.BuildOptions{Resolver: readResolver{c}})
+	if err != nil {
+		return nil, fmt.Errorf("read move call: %w", err)
+	}
+
+	price, err := c.GetReferenceGasPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var gasPrice uint64
+	if price != nil {
+		if amount, ok := price.ToBigInt(); ok && amount.IsUint64() {
+			gasPrice = amount.Uint64()
+		}
+	}
+	if gasPrice == 0 {
+		return nil, errors.New("read move call: reference gas price unavailable")
+	}
+
+	data := transaction.TransactionData{V1: &transaction.TransactionDataV1{
+		Kind:       transaction.TransactionKind{ProgrammableTransaction: built.ProgrammableKind},
+		GasData:    transaction.GasData{Price: gasPrice, Budget: readCallGasBudget},
+		Expiration: transaction.ExpirationNone(),
+	}}
+	txBcs, err := bcs.Marshal(&data)
+	if err != nil {
+		return nil, fmt.Errorf("read move call: %w", err)
+	}
+
+	result, err := c.moveCallResult(ctx, txBcs, target, pkgAddress, module, function, typeArgs)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.ReturnValues) == 0 {
+		return nil, fmt.Errorf("read move call: %s returns no values", target)
+	}
+	return &result.ReturnValues[0], nil
+}
+
+// ReadMoveValue calls a Move function as ReadMoveCall does and decodes its
+// first return value into T.
+func ReadMoveValue[T any](c *Client, ctx context.Context, target string, typeArgs []string, args []any) (T, error) {
+	var zero T
+	value, err := c.ReadMoveCall(ctx, target, typeArgs, args)
+	if err != nil {
+		return zero, err
+	}
+	return DecodeMoveValue[T](*value)
+}
+
+// moveFunction returns a Move function's signature, cached for the life of
+// the client. Published functions never change signature, and upgrades of
+// system packages keep existing public signatures.
+func (c *Client) moveFunction(ctx context.Context, pkg types.Address, module, function string) (*MoveFunction, error) {
+	key := fmt.Sprintf("%s::%s::%s", pkg, module, function)
+	if cached, ok := c.functions.Load(key); ok {
+		return cached.(*MoveFunction), nil
+	}
+	fn, err := c.GetNormalizedMoveFunction(ctx, pkg, module, function)
+	if err != nil {
+		return nil, err
+	}
+	if fn == nil {
+		return nil, fmt.Errorf("move function %s not found", key)
+	}
+	c.functions.Store(key, fn)
+	return fn, nil
+}
+
+// readResolver resolves Move call inputs through the client, so ReadMoveCall
+// needs no resolver from the caller.
+type readResolver struct {
+	client *Client
+}
+
+func (r readResolver) ResolveMoveFunction(ctx context.Context, packageID, module, function string) (*transaction.MoveFunction, error) {
+	pkg, err := utils.ParseAddress(packageID)
+	if err != nil {
+		return nil, err
+	}
+	fn, err := r.client.moveFunction(ctx, pkg, module, function)
+	if err != nil {
+		return nil, err
+	}
+	params := make([]transaction.MoveParameter, len(fn.Parameters))
+	for i, param := range fn.Parameters {
+		if param.Signature == nil {
+			return nil, fmt.Errorf("move function %s::%s::%s: parameter %d has no signature", packageID, module, function, i)
+		}
+		if params[i], err = moveParameter(param.Signature.RawMessage); err != nil {
+			return nil, fmt.Errorf("move function %s::%s::%s: parameter %d: %w", packageID, module, function, i, err)
+		}
+	}
+	return &transaction.MoveFunction{Parameters: params}, nil
+}
+
+func (r readResolver) ResolveObjects(ctx context.Context, objectIDs []string) ([]transaction.ObjectMetadata, error) {
+	ids := make([]types.Address, len(objectIDs))
+	for i, id := range objectIDs {
+		address, err := utils.ParseAddress(id)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = address
+	}
+	objects, err := r.client.GetMultipleObjects(ctx, ids, nil)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[types.Address]Object, len(objects))
+	for _, obj := range objects {
+		byID[obj.Address] = obj
+	}
+
+	metas := make([]transaction.ObjectMetadata, len(ids))
+	for i, id := range ids {
+		obj, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("object %s not found", id)
+		}
+		metas[i] = transaction.ObjectMetadata{ID: obj.Address, Version: uint64(obj.Version), Digest: obj.Digest, OwnerKind: transaction.OwnerUnknown}
+		if obj.Owner != nil {
+			switch obj.Owner.Typename {
+			case "AddressOwner":
+				metas[i].OwnerKind = transaction.OwnerAddress
+			case "ObjectOwner":
+				metas[i].OwnerKind = transaction.OwnerObject
+			case "Immutable":
+				metas[i].OwnerKind = transaction.OwnerImmutable
+			case "Shared":
+				metas[i].OwnerKind = transaction.OwnerShared
+				if obj.Owner.InitialSharedVersion != nil {
+					version := uint64(*obj.Owner.InitialSharedVersion)
+					metas[i].OwnerVersion = &version
+				}
+			}
+		}
+	}
+	return metas, nil
+}
+
+// openSignature is the JSON form of a GraphQL OpenMoveTypeSignature.
+type openSignature struct {
+	Ref  *string         `json:"ref"`
+	Body json.RawMessage `json:"body"`
+}
+
+// MoveParameter decodes the type's signature into the form the transaction
+// builder uses for Move function parameters. The type must have been
+// selected with its signature.
+func (t OpenMoveType) MoveParameter() (transaction.MoveParameter, error) {
+	if t.Signature == nil {
+		return transaction.MoveParameter{}, fmt.Errorf("type %s has no signature", t.Repr)
+	}
+	return moveParameter(t.Signature.RawMessage)
+}
+
+func moveParameter(raw json.RawMessage) (transaction.MoveParameter, error) {
+	var sig openSignature
+	if err := json.Unmarshal(raw, &sig); err != nil {
+		return transaction.MoveParameter{}, err
+	}
+	body, err := signatureBody(sig.Body)
+	if err != nil {
+		return transaction.MoveParameter{}, err
+	}
+	param := transaction.MoveParameter{Body: &body}
+	if body.Kind == transaction.MoveTypeDatatype {
+		param.TypeName = body.TypeName
+	}
+	if sig.Ref != nil {
+		switch *sig.Ref {
+		case "&":
+			param.Reference = transaction.ReferenceImmutable
+		case "&mut":
+			param.Reference = transaction.ReferenceMutable
+		}
+	}
+	return param, nil
+}
+
+func signatureBody(raw json.RawMessage) (transaction.MoveTypeSignature, error) {
+	var primitive string
+	if json.Unmarshal(raw, &primitive) == nil {
+		kind, ok := map[string]transaction.MoveTypeKind{
+			"address": transaction.MoveTypeAddress,
+			"bool":    transaction.MoveTypeBool,
+			"u8":      transaction.MoveTypeU8,
+			"u16":     transaction.MoveTypeU16,
+			"u32":     transaction.MoveTypeU32,
+			"u64":     transaction.MoveTypeU64,
+			"u128":    transaction.MoveTypeU128,
+			"u256":    transaction.MoveTypeU256,
+		}[primitive]
+		if !ok {
+			return transaction.MoveTypeSignature{}, fmt.Errorf("unknown type %q", primitive)
+		}
+		return transaction.MoveTypeSignature{Kind: kind}, nil
+	}
+
+	var composite struct {
+		Vector   json.RawMessage `json:"vector"`
+		Datatype *struct {
+			Package        string            `json:"package"`
+			Module         string            `json:"module"`
+			Type           string            `json:"type"`
+			TypeParameters []json.RawMessage `json:"typeParameters"`
+		} `json:"datatype"`
+		TypeParameter *uint32 `json:"typeParameter"`
+	}
+	if err := json.Unmarshal(raw, &composite); err != nil {
+		return transaction.MoveTypeSignature{}, err
+	}
+	switch {
+	case composite.Vector != nil:
+		elem, err := signatureBody(composite.Vector)
+		if err != nil {
+			return transaction.MoveTypeSignature{}, err
+		}
+		return transaction.MoveTypeSignature{Kind: transaction.MoveTypeVector, TypeArguments: []transaction.MoveTypeSignature{elem}}, nil
+	case composite.Datatype != nil:
+		dt := composite.Datatype
+		out := transaction.MoveTypeSignature{
+			Kind:     transaction.MoveTypeDatatype,
+			TypeName: fmt.Sprintf("%s::%s::%s", dt.Package, dt.Module, dt.Type),
+		}
+		for _, param := range dt.TypeParameters {
+			arg, err := signatureBody(param)
+			if err != nil {
+				return transaction.MoveTypeSignature{}, err
+			}
+			out.TypeArguments = append(out.TypeArguments, arg)
+		}
+		return out, nil
+	case composite.TypeParameter != nil:
+		return transaction.MoveTypeSignature{Kind: transaction.MoveTypeParameter, TypeParameter: *composite.TypeParameter}, nil
+	}
+	return transaction.MoveTypeSignature{}, fmt.Errorf("unknown type signature %s", raw)
+}
@@ -0,0 +1,94 @@
+package graphql
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/transaction"
+	"github.com/open-move/sui-go-sdk/types"
+)
+
+func TestReadMoveCall(t *testing.T) {
+	var functionRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query     string         `json:"query"`
+			Variables map[string]any `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(req.Query, "query GetReferenceGasPrice"):
+			w.Write([]byte(`{"data":{"epoch":{"referenceGasPrice":"750"}}}`))
+		case strings.Contains(req.Query, "query GetNormalizedMoveFunction"):
+			functionRequests.Add(1)
+			w.Write([]byte(`{"data":{"object":{"asMovePackage":{"module":{"function":{"name":"price","parameters":[
+				{"repr":"&0xabc::pool::Pool<$0>","signature":{"ref":"&","body":{"datatype":{"package":"0x0000000000000000000000000000000000000000000000000000000000000abc","module":"pool","type":"Pool","typeParameters":[{"typeParameter":0}]}}}},
+				{"repr":"u64","signature":{"ref":null,"body":"u64"}},
+				{"repr":"&0x2::tx_context::TxContext","signature":{"ref":"&","body":{"datatype":{"package":"0x0000000000000000000000000000000000000000000000000000000000000002","module":"tx_context","type":"TxContext","typeParameters":[]}}}}
+			],"return":[{"repr":"u64"}]}}}}}}`))
+		case strings.Contains(req.Query, "serviceConfig"):
+			w.Write([]byte(`{"data":{"serviceConfig":{"maxOutputNodes":1000}}}`))
+		case strings.Contains(req.Query, "query MultiGetObjects"):
+			w.Write([]byte(`{"data":{"multiGetObjects":[{"address":"0x0000000000000000000000000000000000000000000000000000000000000123","version":9,"digest":"11111111111111111111111111111111","owner":{"__typename":"Shared","initialSharedVersion":4}}]}}`))
+		case strings.Contains(req.Query, "mutation SimulateMoveCall"):
+			txBcs, _ := base64.StdEncoding.DecodeString(req.Variables["txBytes"].(string))
+			data, err := transaction.DecodeTransactionData(txBcs)
+			if err != nil {
+				t.Errorf("decode transaction: %v", err)
+			} else {
+				v1 := data.V1
+				if v1.Sender != (types.Address{}) || len(v1.GasData.Payment) != 0 || v1.GasData.Price != 750 {
+					t.Errorf("unexpected transaction data %+v", v1)
+				}
+				inputs := v1.Kind.ProgrammableTransaction.Inputs
+				if len(inputs) != 2 || inputs[0].Object == nil || inputs[0].Object.SharedObject == nil ||
+					inputs[0].Object.SharedObject.InitialSharedVersion != 4 || inputs[0].Object.SharedObject.Mutable {
+					t.Errorf("unexpected inputs %+v", inputs)
+				}
+			}
+			w.Write([]byte(`{"data":{"simulateTransaction":{"effects":{"status":"SUCCESS"},"outputs":[{"returnValues":[
+				{"value":{"type":{"repr":"u64"},"json":"1250"}}
+			]}]}}}`))
+		case strings.Contains(req.Query, "query GetMoveTypeLayout"):
+			w.Write([]byte(`{"data":{"type":{"layout":"u64"}}}`))
+		default:
+			t.Errorf("unexpected query %s", req.Query)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithEndpoint(server.URL), WithRetries(0))
+	for range 2 {
+		price, err := ReadMoveValue[uint64](client, context.Background(), "0xabc::pool::price", []string{"0x2::sui::SUI"}, []any{"0x123", 10})
+		if err != nil {
+			t.Fatalf("read move value: %v", err)
+		}
+		if price != 1250 {
+			t.Fatalf("price = %d, want 1250", price)
+		}
+	}
+	if n := functionRequests.Load(); n != 1 {
+		t.Fatalf("function signature fetched %d times, want 1", n)
+	}
+}
+
+func TestSignatureBodyKeepsFullPackageAddress(t *testing.T) {
+	const framework = "0x0000000000000000000000000000000000000000000000000000000000000002"
+	sig, err := signatureBody(json.RawMessage(`{"datatype":{"package":"` + framework + `","module":"transfer","type":"Receiving","typeParameters":[{"typeParameter":0}]}}`))
+	if err != nil {
+		t.Fatalf("signature body: %v", err)
+	}
+	if sig.Kind != transaction.MoveTypeDatatype || sig.TypeName != framework+"::transfer::Receiving" {
+		t.Fatalf("unexpected signature %+v", sig)
+	}
+	if len(sig.TypeArguments) != 1 || sig.TypeArguments[0].Kind != transaction.MoveTypeParameter {
+		t.Fatalf("unexpected type arguments %+v", sig.TypeArguments)
+	}
+}
@@ -0,0 +1,165 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+
+	bcs "github.com/iotaledger/bcs-go"
+	"github.com/open-move/sui-go-sdk/transaction"
+	"github.com/open-move/sui-go-sdk/types"
+)
+
+// =============================================================================
+// Transaction Replay
+// =============================================================================
+
+// ReplayOptions controls how a fetched transaction is patched before re-simulation.
+type ReplayOptions struct {
+	// RefreshObjectVersions rewrites owned, receiving and gas payment object
+	// references to their latest versions so the transaction can run against current state.
+	RefreshObjectVersions bool
+	// GasBudget overrides the original gas budget when set.
+	GasBudget *uint64
+	// GasPrice overrides the original gas price when set.
+	GasPrice *uint64
+	// SkipChecks disables transaction checks during simulation.
+	SkipChecks bool
+}
+
+// ReplayResult holds the original transaction data, the patched data that was
+// simulated, and the simulation outcome.
+type ReplayResult struct {
+	Digest           string
+	Original         *transaction.TransactionData
+	Replayed         *transaction.TransactionData
+	TransactionBytes []byte
+	Simulation       *SimulationResult
+}
+
+// GetTransactionData fetches the BCS-encoded TransactionData for digest and decodes it.
+func (c *Client) GetTransactionData(ctx context.Context, digest string) (*transaction.TransactionData, error) {
+	query := `
+		query GetTransactionData($digest: String!) {
+			transaction(digest: $digest) {
+				transactionBcs
+			}
+		}
+	`
+
+	var result struct {
+		Transaction *struct {
+			TransactionBcs []byte `json:"transactionBcs"`
+		} `json:"transaction"`
+	}
+
+	if err := c.Execute(ctx, query, map[string]any{"digest": digest}, &result); err != nil {
+		return nil, err
+	}
+	if result.Transaction == nil || len(result.Transaction.TransactionBcs) == 0 {
+		return nil, fmt.Errorf("transaction %s not found", digest)
+	}
+
+	return transaction.DecodeTransactionData(result.Transaction.TransactionBcs)
+}
+
+// ReplayTransaction fetches a transaction by digest, optionally patches gas and
+// object versions, and re-simulates it. It is intended for debugging failed
+// transactions against current chain state.
+func (c *Client) ReplayTransaction(ctx context.Context, digest string, opts *ReplayOptions) (*ReplayResult, error) {
+	if opts == nil {
+		opts = &ReplayOptions{}
+	}
+
+	original, err := c.GetTransactionData(ctx, digest)
+	if err != nil {
+		return nil, err
+	}
+
+	// Re-decode to obtain an independent copy to patch.
+	encoded, err := bcs.Marshal(original)
+	if err != nil {
+		return nil, fmt.Errorf("encode transaction data: %w", err)
+	}
+	replayed, err := transaction.DecodeTransactionData(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.GasBudget != nil {
+		replayed.V1.GasData.Budget = *opts.GasBudget
+	}
+	if opts.GasPrice != nil {
+		replayed.V1.GasData.Price = *opts.GasPrice
+	}
+	if opts.RefreshObjectVersions {
+		if err := c.refreshObjectRefs(ctx, replayed.V1); err != nil {
+			return nil, err
+		}
+	}
+
+	txBytes, err := bcs.Marshal(replayed)
+	if err != nil {
+		return nil, fmt.Errorf("encode replayed transaction: %w", err)
+	}
+
+	checks := !opts.SkipChecks
+	simulation, err := SimulateTransaction(c, ctx, txBytes, &SimulationOptions{ChecksEnabled: &checks})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReplayResult{
+		Digest:           digest,
+		Original:         original,
+		Replayed:         replayed,
+		TransactionBytes: txBytes,
+		Simulation:       simulation,
+	}, nil
+}
+
+// refreshObjectRefs replaces owned, receiving and gas payment references with the latest versions.
+func (c *Client) refreshObjectRefs(ctx context.Context, data *transaction.TransactionDataV1) error {
+	var refs []*types.ObjectRef
+	if programmable := data.Kind.ProgrammableTransaction; programmable != nil {
+		for _, arg := range programmable.Inputs {
+			if arg.Object == nil {
+				continue
+			}
+			switch {
+			case arg.Object.ImmOrOwnedObject != nil:
+				refs = append(refs, arg.Object.ImmOrOwnedObject)
+			case arg.Object.Receiving != nil:
+				refs = append(refs, arg.Object.Receiving)
+			}
+		}
+	}
+	for i := range data.GasData.Payment {
+		refs = append(refs, &data.GasData.Payment[i])
+	}
+	if len(refs) == 0 {
+		return nil
+	}
+
+	ids := make([]types.Address, len(refs))
+	for i, ref := range refs {
+		ids[i] = ref.ObjectID
+	}
+	objects, err := c.GetMultipleObjects(ctx, ids, nil)
+	if err != nil {
+		return err
+	}
+
+	latest := make(map[types.Address]Object, len(objects))
+	for _, obj := range objects {
+		latest[obj.Address] = obj
+	}
+	for _, ref := range refs {
+		obj, ok := latest[ref.ObjectID]
+		if !ok {
+			return fmt.Errorf("object %s no longer exists", ref.ObjectID.String())
+		}
+		ref.Version = uint64(obj.Version)
+		ref.Digest = obj.Digest
+	}
+	return nil
+}
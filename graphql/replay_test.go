@@ -0,0 +1,80 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/transaction"
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+func TestReplayTransactionRefreshesVersions(t *testing.T) {
+	oldDigest := types.Digest(bytes.Repeat([]byte{1}, 32))
+	newDigest := types.Digest(bytes.Repeat([]byte{2}, 32))
+	gasID := utils.MustParseAddress("0x9")
+
+	tx := transaction.New()
+	tx.SetSender("0xa").SetGasBudget(5000).SetGasPrice(1).SetGasPayment([]types.ObjectRef{
+		{ObjectID: gasID, Version: 3, Digest: oldDigest},
+	})
+	tx.PayAllSui("0xb")
+	built, err := tx.Build(context.Background(), transaction.BuildOptions{})
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+
+	var simulated []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query     string         `json:"query"`
+			Variables map[string]any `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		var data any
+		switch {
+		case strings.Contains(req.Query, "transactionBcs"):
+			data = map[string]any{"transaction": map[string]any{"transactionBcs": built.TransactionBytes}}
+		case strings.Contains(req.Query, "multiGetObjects"):
+			data = map[string]any{"multiGetObjects": []map[string]any{{
+				"address": gasID.String(), "version": 8, "digest": newDigest.String(),
+			}}}
+		case strings.Contains(req.Query, "simulateTransaction"):
+			simulated, _ = base64.StdEncoding.DecodeString(req.Variables["txBytes"].(string))
+			data = map[string]any{"simulateTransaction": map[string]any{"effects": map[string]any{"status": "SUCCESS"}}}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+	defer server.Close()
+
+	budget := uint64(900)
+	client := NewClient(WithEndpoint(server.URL))
+	result, err := client.ReplayTransaction(context.Background(), "digest", &ReplayOptions{
+		RefreshObjectVersions: true,
+		GasBudget:             &budget,
+	})
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+
+	if result.Original.V1.GasData.Payment[0].Version != 3 {
+		t.Fatalf("original data should be untouched")
+	}
+	gas := result.Replayed.V1.GasData
+	if gas.Payment[0].Version != 8 || !bytes.Equal(gas.Payment[0].Digest, newDigest) || gas.Budget != 900 {
+		t.Fatalf("unexpected patched gas data %+v", gas)
+	}
+	if !bytes.Equal(simulated, result.TransactionBytes) {
+		t.Fatalf("simulated bytes do not match replayed transaction")
+	}
+	if result.Simulation.Effects.Status != ExecutionStatusSuccess {
+		t.Fatalf("unexpected simulation status")
+	}
+}
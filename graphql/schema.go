@@ -0,0 +1,223 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// =============================================================================
+// Schema Introspection
+// =============================================================================
+
+// IntrospectionQuery fetches the parts of the schema needed for validation and
+// code generation. Its response can be loaded with ParseIntrospection.
+const IntrospectionQuery = `
+	query IntrospectSchema {
+		__schema {
+			queryType { name }
+			mutationType { name }
+			subscriptionType { name }
+			types {
+				kind
+				name
+				fields(includeDeprecated: true) {
+					name
+					args { name defaultValue type { ...TypeRef } }
+					type { ...TypeRef }
+				}
+				inputFields { name defaultValue type { ...TypeRef } }
+				enumValues(includeDeprecated: true) { name }
+				possibleTypes { name }
+			}
+		}
+	}
+
+	fragment TypeRef on __Type {
+		kind
+		name
+		ofType {
+			kind
+			name
+			ofType {
+				kind
+				name
+				ofType {
+					kind
+					name
+					ofType {
+						kind
+						name
+						ofType { kind name ofType { kind name } }
+					}
+				}
+			}
+		}
+	}
+`
+
+// Type kinds reported by introspection.
+const (
+	typeKindScalar      = "SCALAR"
+	typeKindObject      = "OBJECT"
+	typeKindInterface   = "INTERFACE"
+	typeKindUnion       = "UNION"
+	typeKindEnum        = "ENUM"
+	typeKindInputObject = "INPUT_OBJECT"
+	typeKindList        = "LIST"
+	typeKindNonNull     = "NON_NULL"
+)
+
+// Schema is an introspected GraphQL schema used to validate queries locally.
+type Schema struct {
+	queryType        string
+	mutationType     string
+	subscriptionType string
+	types            map[string]*schemaType
+}
+
+type schemaType struct {
+	Kind          string                  `json:"kind"`
+	Name          string                  `json:"name"`
+	Fields        []schemaField           `json:"fields"`
+	InputFields   []schemaInputValue      `json:"inputFields"`
+	EnumValues    []struct{ Name string } `json:"enumValues"`
+	PossibleTypes []struct{ Name string } `json:"possibleTypes"`
+}
+
+type schemaField struct {
+	Name string             `json:"name"`
+	Args []schemaInputValue `json:"args"`
+	Type *typeRef           `json:"type"`
+}
+
+type schemaInputValue struct {
+	Name         string   `json:"name"`
+	DefaultValue *string  `json:"defaultValue"`
+	Type         *typeRef `json:"type"`
+}
+
+// typeRef is a possibly wrapped (list/non-null) reference to a named type.
+type typeRef struct {
+	Kind   string   `json:"kind"`
+	Name   string   `json:"name"`
+	OfType *typeRef `json:"ofType"`
+}
+
+func (t *typeRef) String() string {
+	switch t.Kind {
+	case typeKindNonNull:
+		return t.OfType.String() + "!"
+	case typeKindList:
+		return "[" + t.OfType.String() + "]"
+	default:
+		return t.Name
+	}
+}
+
+func (t *typeRef) namedType() string {
+	for t.OfType != nil {
+		t = t.OfType
+	}
+	return t.Name
+}
+
+type introspectionResult struct {
+	Schema struct {
+		QueryType        *struct{ Name string } `json:"queryType"`
+		MutationType     *struct{ Name string } `json:"mutationType"`
+		SubscriptionType *struct{ Name string } `json:"subscriptionType"`
+		Types            []*schemaType          `json:"types"`
+	} `json:"__schema"`
+}
+
+func newSchema(result *introspectionResult) (*Schema, error) {
+	if result.Schema.QueryType == nil {
+		return nil, fmt.Errorf("introspection result has no query type")
+	}
+
+	s := &Schema{
+		queryType: result.Schema.QueryType.Name,
+		types:     make(map[string]*schemaType, len(result.Schema.Types)),
+	}
+	if result.Schema.MutationType != nil {
+		s.mutationType = result.Schema.MutationType.Name
+	}
+	if result.Schema.SubscriptionType != nil {
+		s.subscriptionType = result.Schema.SubscriptionType.Name
+	}
+	for _, t := range result.Schema.Types {
+		s.types[t.Name] = t
+	}
+	return s, nil
+}
+
+// ParseIntrospection loads a schema from the JSON response to
+// IntrospectionQuery. Both the bare {"__schema": ...} object and the full
+// {"data": {"__schema": ...}} response body are accepted.
+func ParseIntrospection(data []byte) (*Schema, error) {
+	var envelope struct {
+		Data *introspectionResult `json:"data"`
+		introspectionResult
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, fmt.Errorf("parse introspection: %w", err)
+	}
+	if envelope.Data != nil {
+		return newSchema(envelope.Data)
+	}
+	return newSchema(&envelope.introspectionResult)
+}
+
+// Schema returns the endpoint's schema, downloading it through introspection
+// on first use and caching it for the lifetime of the client.
+func (c *Client) Schema(ctx context.Context) (*Schema, error) {
+	c.schemaMu.Lock()
+	defer c.schemaMu.Unlock()
+
+	if c.schema != nil {
+		return c.schema, nil
+	}
+
+	var result introspectionResult
+	if err := c.executeWithRetry(ctx, graphqlRequest{Query: IntrospectionQuery}, &result, 0); err != nil {
+		return nil, fmt.Errorf("introspect schema: %w", err)
+	}
+
+	schema, err := newSchema(&result)
+	if err != nil {
+		return nil, err
+	}
+	c.schema = schema
+	return schema, nil
+}
+
+// ValidateQuery checks a query against the endpoint's schema without sending
+// it. It returns QueryValidationErrors describing every problem found.
+func (c *Client) ValidateQuery(ctx context.Context, query string) error {
+	schema, err := c.Schema(ctx)
+	if err != nil {
+		return err
+	}
+	return schema.Validate(query)
+}
+
+func (s *Schema) lookupField(t *schemaType, name string) *schemaField {
+	for i := range t.Fields {
+		if t.Fields[i].Name == name {
+			return &t.Fields[i]
+		}
+	}
+	return nil
+}
+
+func (s *Schema) rootType(operation string) string {
+	switch operation {
+	case "mutation":
+		return s.mutationType
+	case "subscription":
+		return s.subscriptionType
+	default:
+		return s.queryType
+	}
+}
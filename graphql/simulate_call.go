@@ -0,0 +1,213 @@
+package graphql
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/open-move/sui-go-sdk/transaction"
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+// =============================================================================
+// Read-only Move Calls
+// =============================================================================
+
+// MoveCallRequest describes a single Move call to simulate.
+type MoveCallRequest struct {
+	// Sender is the address the call runs as.
+	Sender        string
+	Target        string
+	TypeArguments []string
+	// Arguments are native Go values (or transaction.Argument values),
+	// encoded against the function signature as for
+	// transaction.MoveCall.Values.
+	Arguments []any
+	// BuildOptions resolve object inputs, the function signature and gas
+	// payment, e.g. with grpc.NewResolver. Transaction data needs a gas
+	// payment even though nothing is charged.
+	BuildOptions transaction.BuildOptions
+}
+
+// MoveCallResult is the outcome of SimulateMoveCall.
+type MoveCallResult struct {
+	Simulation *SimulationResult
+	// ReturnValues are the call's return values with their type and layout
+	// set, ready for DecodeMoveValue.
+	ReturnValues []MoveValue
+}
+
+// SimulateMoveCall runs a single Move call without executing it, the
+// devInspect pattern for reading on-chain state through view functions.
+// Checks are disabled so the function need not be an entry function and may
+// take any sender. Return values are paired with the layouts of the
+// function's return types; function signatures are cached by the client.
+func SimulateMoveCall(c *Client, ctx context.Context, req MoveCallRequest) (*MoveCallResult, error) {
+	if req.Sender == "" {
+		return nil, errors.New("simulate move call: sender is empty")
+	}
+	target, err := transaction.ResolveMoveCallTarget(ctx, req.BuildOptions, req.Target)
+	if err != nil {
+		return nil, fmt.Errorf("simulate move call: %w", err)
+	}
+	pkg, module, function, err := utils.ParseMoveCallTarget(target)
+	if err != nil {
+		return nil, err
+	}
+	pkgAddress, err := utils.ParseAddress(pkg)
+	if err != nil {
+		return nil, fmt.Errorf("simulate move call: package: %w", err)
+	}
+
+	tx := transaction.New()
+	tx.SetSender(req.Sender)
+	tx.MoveCall(transaction.MoveCall{Target: target, TypeArguments: req.TypeArguments, Values: req.Arguments})
+	txBcs, err := BuildTransaction(ctx, tx, req.BuildOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.moveCallResult(ctx, txBcs, req.Target, pkgAddress, module, function, req.TypeArguments)
+}
+
+// moveCallResult simulates txBcs, a single Move call, and pairs its return
+// values with the layouts of the function's return types.
+func (c *Client) moveCallResult(ctx context.Context, txBcs []byte, target string, pkgAddress types.Address, module, function string, typeArguments []string) (*MoveCallResult, error) {
+	simulation, err := simulateWithOutputs(c, ctx, txBcs)
+	if err != nil {
+		return nil, err
+	}
+	if err := simulationFailure(simulation); err != nil {
+		return &MoveCallResult{Simulation: simulation}, err
+	}
+
+	fn, err := c.moveFunction(ctx, pkgAddress, module, function)
+	if err != nil {
+		return nil, fmt.Errorf("simulate move call: %w", err)
+	}
+
+	var outputs []CommandOutput
+	if n := len(simulation.Outputs); n > 0 {
+		outputs = simulation.Outputs[n-1].ReturnValues
+	}
+	if len(outputs) != len(fn.Return) {
+		return nil, fmt.Errorf("simulate move call: %s returns %d values, simulation produced %d", target, len(fn.Return), len(outputs))
+	}
+
+	result := &MoveCallResult{Simulation: simulation, ReturnValues: make([]MoveValue, len(outputs))}
+	for i, output := range outputs {
+		if output.Value == nil {
+			return nil, fmt.Errorf("simulate move call: return value %d is missing", i)
+		}
+		repr, err := instantiateReturnType(fn.Return[i].Repr, typeArguments)
+		if err != nil {
+			return nil, fmt.Errorf("simulate move call: return value %d: %w", i, err)
+		}
+		layout, err := c.GetMoveTypeLayout(ctx, repr)
+		if err != nil {
+			return nil, fmt.Errorf("simulate move call: return value %d: %w", i, err)
+		}
+		value := *output.Value
+		value.Type.Repr = repr
+		value.Type.Layout = layout
+		result.ReturnValues[i] = value
+	}
+	return result, nil
+}
+
+// SimulateMoveCallTyped simulates a Move call that returns a single value
+// and decodes it into T, as DecodeMoveValue does.
+func SimulateMoveCallTyped[T any](c *Client, ctx context.Context, req MoveCallRequest) (T, error) {
+	var zero T
+	result, err := SimulateMoveCall(c, ctx, req)
+	if err != nil {
+		return zero, err
+	}
+	if len(result.ReturnValues) != 1 {
+		return zero, fmt.Errorf("simulate move call: %s returns %d values, want 1", req.Target, len(result.ReturnValues))
+	}
+	return DecodeReturnValue[T](result, 0)
+}
+
+// DecodeReturnValue decodes the index-th return value of a simulated call
+// into T.
+func DecodeReturnValue[T any](result *MoveCallResult, index int) (T, error) {
+	var zero T
+	if result == nil || index < 0 || index >= len(result.ReturnValues) {
+		return zero, fmt.Errorf("return value %d out of range", index)
+	}
+	return DecodeMoveValue[T](result.ReturnValues[index])
+}
+
+// simulateWithOutputs simulates txBcs as a dev inspect, selecting each
+// command's return values.
+func simulateWithOutputs(c *Client, ctx context.Context, txBcs []byte) (*SimulationResult, error) {
+	query := `
+		mutation SimulateMoveCall($txBytes: String!) {
+			simulateTransaction(txBytes: $txBytes, skipChecks: true) {
+				effects {
+					status
+					executionError { message abortCode sourceLineNumber instructionOffset identifier constant module { name package { address } } }
+					gasEffects {
+						gasSummary {
+							computationCost
+							storageCost
+							storageRebate
+							nonRefundableStorageFee
+						}
+					}
+				}
+				outputs {
+					returnValues {
+						value { type { repr } json bcs }
+					}
+				}
+				error
+			}
+		}
+	`
+	var result struct {
+		SimulateTransaction *SimulationResult `json:"simulateTransaction"`
+	}
+	if err := c.Execute(ctx, query, map[string]any{"txBytes": base64.StdEncoding.EncodeToString(txBcs)}, &result); err != nil {
+		return nil, err
+	}
+	if result.SimulateTransaction == nil {
+		return nil, errors.New("simulate move call: empty simulation result")
+	}
+	return result.SimulateTransaction, nil
+}
+
+func simulationFailure(sim *SimulationResult) error {
+	if sim.Error != nil && *sim.Error != "" {
+		return fmt.Errorf("simulate move call: %s", *sim.Error)
+	}
+	if sim.Effects != nil && sim.Effects.ExecutionError != nil {
+		return fmt.Errorf("simulate move call: %s", sim.Effects.ExecutionError.Message)
+	}
+	return nil
+}
+
+var typeParameterRef = regexp.MustCompile(`\$(\d+)`)
+
+// instantiateReturnType substitutes typeArguments for the $N type
+// parameters of a function's return type and drops a leading reference,
+// since returned references are reported by value.
+func instantiateReturnType(repr string, typeArguments []string) (string, error) {
+	repr = strings.TrimPrefix(strings.TrimPrefix(repr, "&mut "), "&")
+	var err error
+	repr = typeParameterRef.ReplaceAllStringFunc(repr, func(m string) string {
+		index, _ := strconv.Atoi(m[1:])
+		if index >= len(typeArguments) {
+			err = fmt.Errorf("type parameter %s has no type argument", m)
+			return m
+		}
+		return typeArguments[index]
+	})
+	return repr, err
+}
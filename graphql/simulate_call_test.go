@@ -0,0 +1,113 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/transaction"
+	"github.com/open-move/sui-go-sdk/types"
+)
+
+// viewResolver resolves the signature of a view function and supplies gas
+// data so transactions can be built without a network.
+type viewResolver struct{}
+
+func (viewResolver) ResolveObjects(context.Context, []string) ([]transaction.ObjectMetadata, error) {
+	return nil, nil
+}
+
+func (viewResolver) ResolveMoveFunction(context.Context, string, string, string) (*transaction.MoveFunction, error) {
+	return &transaction.MoveFunction{Parameters: []transaction.MoveParameter{
+		{Body: &transaction.MoveTypeSignature{Kind: transaction.MoveTypeU64}},
+	}}, nil
+}
+
+func (viewResolver) ResolveGasPrice(context.Context) (uint64, error) { return 1000, nil }
+
+func (viewResolver) ResolveGasBudget(context.Context, transaction.GasBudgetInput) (uint64, error) {
+	return 50_000_000, nil
+}
+
+func (viewResolver) ResolveGasPayment(context.Context, types.Address, uint64) ([]types.ObjectRef, error) {
+	return []types.ObjectRef{{ObjectID: types.Address{1}, Version: 1, Digest: types.Digest(bytes.Repeat([]byte{1}, 32))}}, nil
+}
+
+func TestSimulateMoveCall(t *testing.T) {
+	var layoutRequests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query     string         `json:"query"`
+			Variables map[string]any `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(req.Query, "mutation SimulateMoveCall"):
+			if !strings.Contains(req.Query, "skipChecks: true") {
+				t.Errorf("expected checks to be skipped")
+			}
+			w.Write([]byte(`{"data":{"simulateTransaction":{"effects":{"status":"SUCCESS"},"outputs":[{"returnValues":[
+				{"value":{"type":{"repr":"u64"},"json":"42"}},
+				{"value":{"type":{"repr":"vector<u64>"},"json":["1","2"]}}
+			]}]}}}`))
+		case strings.Contains(req.Query, "query GetNormalizedMoveFunction"):
+			w.Write([]byte(`{"data":{"object":{"asMovePackage":{"module":{"function":{"name":"stats","return":[{"repr":"u64"},{"repr":"vector<$0>"}]}}}}}}`))
+		case strings.Contains(req.Query, "query GetMoveTypeLayout"):
+			typ, _ := req.Variables["type"].(string)
+			layoutRequests = append(layoutRequests, typ)
+			layout := `"u64"`
+			if strings.HasPrefix(typ, "vector") {
+				layout = `{"vector":"u64"}`
+			}
+			w.Write([]byte(`{"data":{"type":{"layout":` + layout + `}}}`))
+		default:
+			t.Errorf("unexpected query %s", req.Query)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithEndpoint(server.URL), WithRetries(0))
+	req := MoveCallRequest{
+		Sender:        "0x1",
+		Target:        "0xabc::vault::stats",
+		TypeArguments: []string{"u64"},
+		Arguments:     []any{7},
+		BuildOptions:  transaction.BuildOptions{Resolver: viewResolver{}, GasResolver: viewResolver{}},
+	}
+
+	result, err := SimulateMoveCall(client, context.Background(), req)
+	if err != nil {
+		t.Fatalf("simulate: %v", err)
+	}
+	total, err := DecodeReturnValue[uint64](result, 0)
+	if err != nil || total != 42 {
+		t.Fatalf("return 0: %d %v", total, err)
+	}
+	history, err := DecodeReturnValue[[]uint64](result, 1)
+	if err != nil || !reflect.DeepEqual(history, []uint64{1, 2}) {
+		t.Fatalf("return 1: %v %v", history, err)
+	}
+	if !reflect.DeepEqual(layoutRequests, []string{"u64", "vector<u64>"}) {
+		t.Fatalf("unexpected layout requests %v", layoutRequests)
+	}
+
+	if _, err := SimulateMoveCallTyped[uint64](client, context.Background(), req); err == nil {
+		t.Fatal("expected typed call to reject multiple return values")
+	}
+}
+
+func TestInstantiateReturnType(t *testing.T) {
+	got, err := instantiateReturnType("&0x2::coin::Coin<$1>", []string{"u8", "0x2::sui::SUI"})
+	if err != nil || got != "0x2::coin::Coin<0x2::sui::SUI>" {
+		t.Fatalf("got %q %v", got, err)
+	}
+	if _, err := instantiateReturnType("vector<$2>", []string{"u8"}); err == nil {
+		t.Fatal("expected missing type argument error")
+	}
+}
@@ -0,0 +1,193 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+)
+
+// =============================================================================
+// Sui System State
+// =============================================================================
+
+// validatorPageSize is the page size used to fetch the active validators.
+const validatorPageSize = 50
+
+// SuiSystemState is the state of the Sui system object for an epoch, the
+// GraphQL counterpart of JSON-RPC's suix_getLatestSuiSystemState.
+type SuiSystemState struct {
+	Epoch              UInt53
+	ProtocolVersion    UInt53
+	SystemStateVersion *UInt53
+	ReferenceGasPrice  *BigInt
+	StartTimestamp     *DateTime
+	SafeMode           *SafeMode
+	StorageFund        *StorageFund
+	Parameters         *SystemParameters
+	StakeSubsidy       *StakeSubsidy
+
+	TotalStake                  BigInt
+	PendingActiveValidatorsSize *UInt53
+	StakingPoolMappingsSize     *UInt53
+	InactivePoolsSize           *UInt53
+	ValidatorCandidatesSize     *UInt53
+	// ActiveValidators is the full active set, fetched across pages.
+	ActiveValidators []Validator
+}
+
+const systemStateFields = `
+	epochId
+	referenceGasPrice
+	startTimestamp
+	systemStateVersion
+	protocolConfigs { protocolVersion }
+	safeMode {
+		enabled
+		gasSummary { computationCost storageCost storageRebate nonRefundableStorageFee }
+	}
+	storageFund { totalObjectStorageRebates nonRefundableBalance }
+	systemParameters {
+		durationMs
+		stakeSubsidyStartEpoch
+		minValidatorCount
+		maxValidatorCount
+		minValidatorJoiningStake
+		validatorLowStakeThreshold
+		validatorVeryLowStakeThreshold
+		validatorLowStakeGracePeriod
+	}
+	stakeSubsidy { balance distributionCounter currentDistributionAmount periodLength decreaseRate }
+`
+
+const validatorFields = `
+	pageInfo { hasNextPage endCursor }
+	nodes {
+		address
+		name
+		description
+		imageUrl
+		projectUrl
+		stakingPoolActivationEpoch
+		stakingPoolSuiBalance
+		rewardsPool
+		poolTokenBalance
+		pendingStake
+		pendingTotalSuiWithdraw
+		pendingPoolTokenWithdraw
+		votingPower
+		gasPrice
+		commissionRate
+		nextEpochStake
+		nextEpochGasPrice
+		nextEpochCommissionRate
+		atRisk
+		reportRecords
+	}
+`
+
+// GetLatestSuiSystemState returns the system state of the current epoch,
+// with every active validator.
+func (c *Client) GetLatestSuiSystemState(ctx context.Context) (*SuiSystemState, error) {
+	return c.GetSuiSystemState(ctx, nil)
+}
+
+// GetSuiSystemState returns the system state of an epoch, or of the current
+// epoch when epochID is nil. The active validators are paged internally; all
+// pages are read from the epoch of the first response, so the set is
+// consistent even if the epoch changes meanwhile.
+func (c *Client) GetSuiSystemState(ctx context.Context, epochID *UInt53) (*SuiSystemState, error) {
+	query := `
+		query GetSuiSystemState($epochId: UInt53, $first: Int) {
+			epoch(epochId: $epochId) {` + systemStateFields + `
+				validatorSet {
+					totalStake
+					pendingActiveValidatorsSize
+					stakingPoolMappingsSize
+					inactivePoolsSize
+					validatorCandidatesSize
+					activeValidators(first: $first) {` + validatorFields + `}
+				}
+			}
+		}
+	`
+
+	vars := map[string]any{"first": validatorPageSize}
+	if epochID != nil {
+		vars["epochId"] = *epochID
+	}
+
+	var result struct {
+		Epoch *Epoch `json:"epoch"`
+	}
+	if err := c.Execute(ctx, query, vars, &result); err != nil {
+		return nil, err
+	}
+	if result.Epoch == nil {
+		return nil, errors.New("system state not available")
+	}
+
+	epoch := result.Epoch
+	state := &SuiSystemState{
+		Epoch:              epoch.EpochID,
+		SystemStateVersion: epoch.SystemStateVersion,
+		ReferenceGasPrice:  epoch.ReferenceGasPrice,
+		StartTimestamp:     epoch.StartTimestamp,
+		SafeMode:           epoch.SafeMode,
+		StorageFund:        epoch.StorageFund,
+		Parameters:         epoch.SystemParameters,
+		StakeSubsidy:       epoch.StakeSubsidy,
+	}
+	if epoch.ProtocolConfigs != nil {
+		state.ProtocolVersion = epoch.ProtocolConfigs.ProtocolVersion
+	}
+	set := epoch.ValidatorSet
+	if set == nil {
+		return state, nil
+	}
+	state.TotalStake = set.TotalStake
+	state.PendingActiveValidatorsSize = set.PendingActiveValidatorsSize
+	state.StakingPoolMappingsSize = set.StakingPoolMappingsSize
+	state.InactivePoolsSize = set.InactivePoolsSize
+	state.ValidatorCandidatesSize = set.ValidatorCandidatesSize
+
+	page := set.ActiveValidators
+	for page != nil {
+		state.ActiveValidators = append(state.ActiveValidators, page.Nodes...)
+		if !page.PageInfo.HasNextPage || page.PageInfo.EndCursor == nil {
+			break
+		}
+		next, err := c.activeValidatorsPage(ctx, state.Epoch, *page.PageInfo.EndCursor)
+		if err != nil {
+			return nil, err
+		}
+		page = next
+	}
+	return state, nil
+}
+
+func (c *Client) activeValidatorsPage(ctx context.Context, epochID UInt53, after string) (*Connection[Validator], error) {
+	query := `
+		query GetActiveValidators($epochId: UInt53, $first: Int, $after: String) {
+			epoch(epochId: $epochId) {
+				validatorSet {
+					activeValidators(first: $first, after: $after) {` + validatorFields + `}
+				}
+			}
+		}
+	`
+
+	vars := map[string]any{"epochId": epochID, "first": validatorPageSize, "after": after}
+	var result struct {
+		Epoch *struct {
+			ValidatorSet *struct {
+				ActiveValidators *Connection[Validator] `json:"activeValidators"`
+			} `json:"validatorSet"`
+		} `json:"epoch"`
+	}
+	if err := c.Execute(ctx, query, vars, &result); err != nil {
+		return nil, err
+	}
+	if result.Epoch == nil || result.Epoch.ValidatorSet == nil {
+		return nil, nil
+	}
+	return result.Epoch.ValidatorSet.ActiveValidators, nil
+}
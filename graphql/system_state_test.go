@@ -0,0 +1,72 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetLatestSuiSystemState(t *testing.T) {
+	var afters []any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables map[string]any `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		validators := func(hasNext bool, cursor string, addresses ...string) map[string]any {
+			nodes := make([]any, len(addresses))
+			for i, address := range addresses {
+				nodes[i] = map[string]any{"address": address, "votingPower": 100}
+			}
+			return map[string]any{"pageInfo": map[string]any{"hasNextPage": hasNext, "endCursor": cursor}, "nodes": nodes}
+		}
+
+		after, paged := req.Variables["after"]
+		if !paged {
+			json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"epoch": map[string]any{
+				"epochId":           42,
+				"referenceGasPrice": "750",
+				"protocolConfigs":   map[string]any{"protocolVersion": 70},
+				"storageFund":       map[string]any{"totalObjectStorageRebates": "10", "nonRefundableBalance": "5"},
+				"systemParameters":  map[string]any{"durationMs": "86400000", "maxValidatorCount": 150},
+				"validatorSet": map[string]any{
+					"totalStake":       "1000",
+					"activeValidators": validators(true, "c1", "0x1", "0x2"),
+				},
+			}}})
+			return
+		}
+		afters = append(afters, after)
+		if req.Variables["epochId"] != float64(42) {
+			t.Errorf("expected later pages to pin epoch 42, got %v", req.Variables["epochId"])
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"epoch": map[string]any{
+			"validatorSet": map[string]any{"activeValidators": validators(false, "c2", "0x3")},
+		}}})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithEndpoint(server.URL), WithRetries(0))
+	state, err := client.GetLatestSuiSystemState(context.Background())
+	if err != nil {
+		t.Fatalf("system state: %v", err)
+	}
+	if state.Epoch != 42 || state.ProtocolVersion != 70 || state.TotalStake != "1000" {
+		t.Fatalf("unexpected state %+v", state)
+	}
+	if state.ReferenceGasPrice == nil || *state.ReferenceGasPrice != "750" {
+		t.Fatalf("unexpected gas price %v", state.ReferenceGasPrice)
+	}
+	if state.StorageFund == nil || state.StorageFund.NonRefundableBalance != "5" {
+		t.Fatalf("unexpected storage fund %+v", state.StorageFund)
+	}
+	if state.Parameters == nil || state.Parameters.MaxValidatorCount == nil || *state.Parameters.MaxValidatorCount != 150 {
+		t.Fatalf("unexpected parameters %+v", state.Parameters)
+	}
+	if len(state.ActiveValidators) != 3 || len(afters) != 1 || afters[0] != "c1" {
+		t.Fatalf("expected 3 validators over 2 pages, got %d (afters %v)", len(state.ActiveValidators), afters)
+	}
+}
@@ -0,0 +1,109 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+// =============================================================================
+// Typed Owned Object Queries
+// =============================================================================
+
+// ownedObjectsPageSize is the page size used when walking all owned objects.
+const ownedObjectsPageSize = 50
+
+// OwnedObject is an owned Move object with its contents decoded into T.
+type OwnedObject[T any] struct {
+	MoveObject
+	Data T
+}
+
+// NormalizeTypeTag parses a Move type and renders it with full-length
+// addresses, including inside generic type arguments, so it matches the
+// server's canonical form (e.g. "0x2::coin::Coin<0x2::sui::SUI>").
+func NormalizeTypeTag(typeTag string) (string, error) {
+	tag, err := utils.ParseTypeTag(typeTag)
+	if err != nil {
+		return "", err
+	}
+	return tag.String(), nil
+}
+
+// GetOwnedObjectsOfType returns every object owned by owner whose type
+// matches typeTag, following pagination to the end and decoding each
+// object's JSON contents into T.
+//
+// A type without type arguments (e.g. "0x2::coin::Coin") matches every
+// instantiation; a fully instantiated type (e.g. "0x2::coin::Coin<0x2::sui::SUI>")
+// matches only that one.
+func GetOwnedObjectsOfType[T any](c *Client, ctx context.Context, owner types.Address, typeTag string) ([]OwnedObject[T], error) {
+	normalized, err := NormalizeTypeTag(typeTag)
+	if err != nil {
+		return nil, fmt.Errorf("normalize type %q: %w", typeTag, err)
+	}
+
+	query := `
+		query GetOwnedObjectsOfType($address: SuiAddress!, $filter: ObjectFilter, $first: Int, $after: String) {
+			address(address: $address) {
+				objects(filter: $filter, first: $first, after: $after) {
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+					nodes {
+						address
+						version
+						digest
+						hasPublicTransfer
+						contents { type { repr } json }
+					}
+				}
+			}
+		}
+	`
+
+	var objects []OwnedObject[T]
+	var cursor *string
+	for {
+		vars := map[string]any{
+			"address": owner,
+			"filter":  ObjectFilter{Type: &normalized},
+			"first":   ownedObjectsPageSize,
+		}
+		if cursor != nil {
+			vars["after"] = *cursor
+		}
+
+		var result struct {
+			Address *struct {
+				Objects *Connection[MoveObject] `json:"objects"`
+			} `json:"address"`
+		}
+		if err := c.Execute(ctx, query, vars, &result); err != nil {
+			return nil, err
+		}
+		if result.Address == nil || result.Address.Objects == nil {
+			return objects, nil
+		}
+
+		page := result.Address.Objects
+		for _, node := range page.Nodes {
+			owned := OwnedObject[T]{MoveObject: node}
+			if node.Contents != nil && len(node.Contents.Json) > 0 {
+				if err := json.Unmarshal(node.Contents.Json, &owned.Data); err != nil {
+					return nil, fmt.Errorf("decode object %s: %w", node.Address, err)
+				}
+			}
+			objects = append(objects, owned)
+		}
+
+		if !page.PageInfo.HasNextPage || page.PageInfo.EndCursor == nil {
+			return objects, nil
+		}
+		cursor = page.PageInfo.EndCursor
+	}
+}
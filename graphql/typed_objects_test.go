@@ -0,0 +1,80 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+func TestNormalizeTypeTag(t *testing.T) {
+	got, err := NormalizeTypeTag("0x2::coin::Coin<0x2::sui::SUI>")
+	if err != nil {
+		t.Fatalf("NormalizeTypeTag: %v", err)
+	}
+	want := "0x0000000000000000000000000000000000000000000000000000000000000002::coin::Coin<0x0000000000000000000000000000000000000000000000000000000000000002::sui::SUI>"
+	if got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	if _, err := NormalizeTypeTag("not a type"); err == nil {
+		t.Fatal("expected error for invalid type tag")
+	}
+}
+
+func TestGetOwnedObjectsOfType(t *testing.T) {
+	wantType := "0x0000000000000000000000000000000000000000000000000000000000000002::coin::Coin<0x0000000000000000000000000000000000000000000000000000000000000002::sui::SUI>"
+	pages := []string{
+		`{"data":{"address":{"objects":{"pageInfo":{"hasNextPage":true,"endCursor":"c1"},"nodes":[
+			{"address":"0x1","version":3,"contents":{"type":{"repr":"` + wantType + `"},"json":{"id":"0x1","balance":"100"}}}
+		]}}}}`,
+		`{"data":{"address":{"objects":{"pageInfo":{"hasNextPage":false,"endCursor":"c2"},"nodes":[
+			{"address":"0x2","version":4,"contents":{"type":{"repr":"` + wantType + `"},"json":{"id":"0x2","balance":"250"}}}
+		]}}}}`,
+	}
+
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables map[string]any `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		filter, _ := req.Variables["filter"].(map[string]any)
+		if filter["type"] != wantType {
+			t.Errorf("unexpected type filter %v", filter["type"])
+		}
+		if calls == 1 && req.Variables["after"] != "c1" {
+			t.Errorf("expected cursor c1, got %v", req.Variables["after"])
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(pages[calls]))
+		calls++
+	}))
+	defer server.Close()
+
+	type coin struct {
+		ID      string `json:"id"`
+		Balance string `json:"balance"`
+	}
+
+	client := NewClient(WithEndpoint(server.URL))
+	owner := utils.MustParseAddress("0xabc")
+	coins, err := GetOwnedObjectsOfType[coin](client, context.Background(), owner, "0x2::coin::Coin<0x2::sui::SUI>")
+	if err != nil {
+		t.Fatalf("GetOwnedObjectsOfType: %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 requests, got %d", calls)
+	}
+	if len(coins) != 2 || coins[0].Data.Balance != "100" || coins[1].Data.Balance != "250" {
+		t.Fatalf("unexpected objects %+v", coins)
+	}
+	if coins[1].Version != 4 || coins[1].Contents.Type.Repr != wantType {
+		t.Fatalf("unexpected object metadata %+v", coins[1].MoveObject)
+	}
+}
@@ -2,8 +2,10 @@ package graphql
 
 import (
 	"encoding/json"
+	"fmt"
 	"math/big"
 	"strconv"
+	"time"
 
 	"github.com/open-move/sui-go-sdk/types"
 )
@@ -11,18 +13,92 @@ import (
 // DateTime represents an ISO-8601 formatted date-time string.
 type DateTime string
 
+// dateTimeLayout is the format the service uses for DateTime values.
+const dateTimeLayout = "2006-01-02T15:04:05.000Z"
+
+// NewDateTime formats t in UTC with millisecond precision, as the DateTime
+// scalar expects.
+func NewDateTime(t time.Time) DateTime {
+	return DateTime(t.UTC().Format(dateTimeLayout))
+}
+
+// Time parses the DateTime.
+func (d DateTime) Time() (time.Time, error) {
+	return time.Parse(time.RFC3339Nano, string(d))
+}
+
+// MarshalJSON encodes d as a JSON string, rejecting values that are not
+// ISO-8601 date-times. An empty DateTime encodes as null.
+func (d DateTime) MarshalJSON() ([]byte, error) {
+	if d == "" {
+		return []byte("null"), nil
+	}
+	if _, err := d.Time(); err != nil {
+		return nil, fmt.Errorf("invalid DateTime %q: %w", string(d), err)
+	}
+	return json.Marshal(string(d))
+}
+
 // BigInt represents an arbitrarily large integer as a string.
 type BigInt string
 
+// NewBigInt returns n as a BigInt.
+func NewBigInt(n *big.Int) BigInt {
+	return BigInt(n.String())
+}
+
 // ToBigInt converts the BigInt string to a *big.Int.
 func (b BigInt) ToBigInt() (*big.Int, bool) {
 	n := new(big.Int)
 	return n.SetString(string(b), 10)
 }
 
+// MarshalJSON encodes b as a JSON string of decimal digits, as the BigInt
+// scalar expects; a JSON number would lose precision in many clients. An
+// empty BigInt encodes as null.
+func (b BigInt) MarshalJSON() ([]byte, error) {
+	if b == "" {
+		return []byte("null"), nil
+	}
+	if _, ok := b.ToBigInt(); !ok {
+		return nil, fmt.Errorf("invalid BigInt %q", string(b))
+	}
+	return json.Marshal(string(b))
+}
+
+// UnmarshalJSON handles both string and number representations.
+func (b *BigInt) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		*b = BigInt(str)
+		return nil
+	}
+	var num json.Number
+	if err := json.Unmarshal(data, &num); err != nil {
+		return err
+	}
+	*b = BigInt(num.String())
+	return nil
+}
+
+// MaxUInt53 is the largest value of the UInt53 scalar.
+const MaxUInt53 = 1<<53 - 1
+
 // UInt53 represents a 53-bit unsigned integer (safe for JavaScript).
 type UInt53 uint64
 
+// MarshalJSON encodes u as a JSON number, as the UInt53 scalar expects,
+// rejecting values above MaxUInt53.
+func (u UInt53) MarshalJSON() ([]byte, error) {
+	if u > MaxUInt53 {
+		return nil, fmt.Errorf("UInt53 %d exceeds %d", uint64(u), uint64(MaxUInt53))
+	}
+	return strconv.AppendUint(nil, uint64(u), 10), nil
+}
+
 // UnmarshalJSON handles both number and string representations.
 func (u *UInt53) UnmarshalJSON(data []byte) error {
 	var num uint64
@@ -164,11 +240,13 @@ func DefaultFieldSelector() *FieldSelector {
 
 // Event represents a Sui event.
 type Event struct {
-	TransactionModule *MoveModule `json:"transactionModule"`
-	Sender            *Address    `json:"sender"`
-	Timestamp         *DateTime   `json:"timestamp"`
-	Contents          *MoveValue  `json:"contents"`
-	EventBcs          []byte      `json:"eventBcs"`
+	TransactionModule *MoveModule     `json:"transactionModule"`
+	Sender            *Address        `json:"sender"`
+	Timestamp         *DateTime       `json:"timestamp"`
+	Contents          *MoveValue      `json:"contents"`
+	EventBcs          []byte          `json:"eventBcs"`
+	SequenceNumber    *UInt53         `json:"sequenceNumber,omitempty"`
+	Transaction       *TransactionRef `json:"transaction,omitempty"`
 }
 
 // EventFilter contains filters for event queries.
@@ -177,6 +255,9 @@ type EventFilter struct {
 	TransactionDigest *types.Digest  `json:"transactionDigest,omitempty"`
 	EmittingModule    *string        `json:"emittingModule,omitempty"`
 	EventType         *string        `json:"eventType,omitempty"`
+	AfterCheckpoint   *UInt53        `json:"afterCheckpoint,omitempty"`
+	BeforeCheckpoint  *UInt53        `json:"beforeCheckpoint,omitempty"`
+	AtCheckpoint      *UInt53        `json:"atCheckpoint,omitempty"`
 }
 
 // CoinMetadata represents coin metadata.
@@ -233,6 +314,16 @@ type SimulationResult struct {
 type CommandResult struct {
 	// Each element is a JSON representation of the returned value
 	Results []MoveValueResult `json:"results"`
+	// ReturnValues are the values returned by the command.
+	ReturnValues []CommandOutput `json:"returnValues,omitempty"`
+	// MutatedReferences are the values of arguments passed by mutable
+	// reference, after the command ran.
+	MutatedReferences []CommandOutput `json:"mutatedReferences,omitempty"`
+}
+
+// CommandOutput is a value produced by a command.
+type CommandOutput struct {
+	Value *MoveValue `json:"value"`
 }
 
 // MoveValueResult represents a Move value returned from simulation.
@@ -264,9 +355,11 @@ type Object struct {
 	AsMovePackage            *MovePackage    `json:"asMovePackage,omitempty"`
 }
 
-// TransactionRef is a minimal transaction reference.
+// TransactionRef is a minimal transaction reference. Effects is set only
+// when a query selects it, e.g. for the transaction's checkpoint.
 type TransactionRef struct {
-	Digest types.Digest `json:"digest"`
+	Digest  types.Digest        `json:"digest"`
+	Effects *TransactionEffects `json:"effects,omitempty"`
 }
 
 // DisplayEntry represents a single display field.
@@ -591,6 +684,7 @@ type TransactionEffects struct {
 	Dependencies   *Connection[Transaction]   `json:"dependencies,omitempty"`
 	BalanceChanges *Connection[BalanceChange] `json:"balanceChanges,omitempty"`
 	ObjectChanges  *Connection[ObjectChange]  `json:"objectChanges,omitempty"`
+	Events         *Connection[Event]         `json:"events,omitempty"`
 	GasEffects     *GasEffects                `json:"gasEffects,omitempty"`
 	Epoch          *Epoch                     `json:"epoch,omitempty"`
 	Checkpoint     *Checkpoint                `json:"checkpoint,omitempty"`
@@ -609,6 +703,9 @@ type ExecutionError struct {
 	Identifier        *string     `json:"identifier"`
 	Constant          *string     `json:"constant"`
 	Module            *MoveModule `json:"module"`
+	// ResolvedMessage describes a Move abort in readable terms. It is set by
+	// Client.ResolveExecutionError.
+	ResolvedMessage string `json:"-"`
 }
 
 // ExecutionResult represents the execution status.
@@ -659,6 +756,7 @@ type TransactionFilter struct {
 	SentAddress      *types.Address `json:"sentAddress,omitempty"`
 	RecvAddress      *types.Address `json:"recvAddress,omitempty"`
 	PaidAddress      *types.Address `json:"paidAddress,omitempty"`
+	AffectedAddress  *types.Address `json:"affectedAddress,omitempty"`
 	InputObject      *types.Address `json:"inputObject,omitempty"`
 	ChangedObject    *types.Address `json:"changedObject,omitempty"`
 	TransactionIDs   []string       `json:"transactionIds,omitempty"`
@@ -0,0 +1,483 @@
+package graphql
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// =============================================================================
+// Query Validation
+// =============================================================================
+
+// QueryValidationError describes a problem found while validating a query
+// against the schema.
+type QueryValidationError struct {
+	Message string
+	Line    int
+	Column  int
+}
+
+// Error implements the error interface.
+func (e QueryValidationError) Error() string {
+	if e.Line == 0 {
+		return e.Message
+	}
+	return fmt.Sprintf("%d:%d: %s", e.Line, e.Column, e.Message)
+}
+
+// QueryValidationErrors is a collection of query validation errors.
+type QueryValidationErrors []QueryValidationError
+
+// Error implements the error interface.
+func (e QueryValidationErrors) Error() string {
+	if len(e) == 0 {
+		return ""
+	}
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+	return fmt.Sprintf("%s (and %d more errors)", e[0].Error(), len(e)-1)
+}
+
+// builtinScalars are the scalar types defined by the GraphQL specification.
+// Custom scalars (SuiAddress, UInt53, BigInt, ...) accept any literal.
+var builtinScalars = map[string]bool{"Int": true, "Float": true, "String": true, "Boolean": true, "ID": true}
+
+// Validate parses query and checks it against the schema: unknown types,
+// fields and arguments, missing required arguments, leaf/composite
+// selections, undefined variables and fragments, and argument types.
+func (s *Schema) Validate(query string) error {
+	doc, err := parseDocument(query)
+	if err != nil {
+		return err
+	}
+
+	v := &validator{schema: s, doc: doc}
+	for _, op := range doc.operations {
+		v.validateOperation(op)
+	}
+	if len(v.errs) > 0 {
+		return v.errs
+	}
+	return nil
+}
+
+type validator struct {
+	schema *Schema
+	doc    *astDocument
+	errs   QueryValidationErrors
+
+	variables map[string]astVariableDef
+	visited   map[string]bool
+}
+
+func (v *validator) report(pos sourcePos, format string, args ...any) {
+	v.errs = append(v.errs, QueryValidationError{
+		Message: fmt.Sprintf(format, args...),
+		Line:    pos.line,
+		Column:  pos.column,
+	})
+}
+
+func (v *validator) validateOperation(op *astOperation) {
+	root := v.schema.rootType(op.kind)
+	if root == "" {
+		v.report(op.pos, "schema does not support %s operations", op.kind)
+		return
+	}
+
+	v.variables = make(map[string]astVariableDef, len(op.variables))
+	v.visited = make(map[string]bool)
+	for _, def := range op.variables {
+		if _, dup := v.variables[def.name]; dup {
+			v.report(def.pos, "variable $%s is defined more than once", def.name)
+		}
+		v.variables[def.name] = def
+
+		named := def.typ.namedType()
+		t, ok := v.schema.types[named]
+		switch {
+		case !ok:
+			v.report(def.pos, "unknown type %q for variable $%s%s", named, def.name, suggest(named, v.typeNames()))
+		case t.Kind != typeKindScalar && t.Kind != typeKindEnum && t.Kind != typeKindInputObject:
+			v.report(def.pos, "variable $%s cannot be of non-input type %q", def.name, def.typ)
+		}
+	}
+
+	v.validateSelections(root, op.selections, true)
+}
+
+func (v *validator) validateSelections(parent string, sels []astSelection, isRoot bool) {
+	parentType := v.schema.types[parent]
+	if parentType == nil {
+		return
+	}
+
+	for _, sel := range sels {
+		switch sel.kind {
+		case selectionFragmentSpread:
+			frag, ok := v.doc.fragments[sel.name]
+			if !ok {
+				v.report(sel.pos, "unknown fragment %q", sel.name)
+				continue
+			}
+			if v.visited[sel.name] {
+				continue
+			}
+			v.visited[sel.name] = true
+			if v.checkTypeCondition(frag.typeCondition, frag.pos) {
+				v.validateSelections(frag.typeCondition, frag.selections, false)
+			}
+		case selectionInlineFragment:
+			target := parent
+			if sel.typeCondition != "" {
+				if !v.checkTypeCondition(sel.typeCondition, sel.pos) {
+					continue
+				}
+				target = sel.typeCondition
+			}
+			v.validateSelections(target, sel.selections, false)
+		default:
+			v.validateField(parentType, sel, isRoot)
+		}
+	}
+}
+
+func (v *validator) checkTypeCondition(name string, pos sourcePos) bool {
+	t, ok := v.schema.types[name]
+	if !ok {
+		v.report(pos, "unknown type %q in type condition%s", name, suggest(name, v.typeNames()))
+		return false
+	}
+	if !isCompositeKind(t.Kind) {
+		v.report(pos, "fragment cannot condition on non-composite type %q", name)
+		return false
+	}
+	return true
+}
+
+func (v *validator) validateField(parent *schemaType, sel astSelection, isRoot bool) {
+	if sel.name == "__typename" {
+		if len(sel.selections) > 0 {
+			v.report(sel.pos, "field \"__typename\" must not have a selection since type \"String!\" has no subfields")
+		}
+		return
+	}
+	if isRoot && (sel.name == "__schema" || sel.name == "__type") {
+		return
+	}
+
+	if parent.Kind == typeKindUnion {
+		v.report(sel.pos, "cannot query field %q on union type %q; use an inline fragment on one of its members", sel.name, parent.Name)
+		return
+	}
+
+	field := v.schema.lookupField(parent, sel.name)
+	if field == nil {
+		names := make([]string, 0, len(parent.Fields))
+		for _, f := range parent.Fields {
+			names = append(names, f.Name)
+		}
+		v.report(sel.pos, "cannot query field %q on type %q%s", sel.name, parent.Name, suggest(sel.name, names))
+		return
+	}
+
+	v.validateArguments(parent, field, sel)
+
+	named := field.Type.namedType()
+	fieldType := v.schema.types[named]
+	if fieldType == nil {
+		return
+	}
+	if isCompositeKind(fieldType.Kind) {
+		if len(sel.selections) == 0 {
+			v.report(sel.pos, "field %q of type %q must have a selection of subfields", sel.name, field.Type)
+			return
+		}
+		v.validateSelections(named, sel.selections, false)
+		return
+	}
+	if len(sel.selections) > 0 {
+		v.report(sel.pos, "field %q must not have a selection since type %q has no subfields", sel.name, field.Type)
+	}
+}
+
+func (v *validator) validateArguments(parent *schemaType, field *schemaField, sel astSelection) {
+	provided := make(map[string]bool, len(sel.arguments))
+	for _, arg := range sel.arguments {
+		provided[arg.name] = true
+
+		var def *schemaInputValue
+		for i := range field.Args {
+			if field.Args[i].Name == arg.name {
+				def = &field.Args[i]
+				break
+			}
+		}
+		if def == nil {
+			names := make([]string, 0, len(field.Args))
+			for _, a := range field.Args {
+				names = append(names, a.Name)
+			}
+			v.report(arg.pos, "unknown argument %q on field \"%s.%s\"%s", arg.name, parent.Name, field.Name, suggest(arg.name, names))
+			continue
+		}
+
+		where := fmt.Sprintf("argument %q on field \"%s.%s\"", arg.name, parent.Name, field.Name)
+		v.validateValue(arg.value, def.Type, def.DefaultValue != nil, where)
+	}
+
+	for _, arg := range field.Args {
+		if arg.Type.Kind == typeKindNonNull && arg.DefaultValue == nil && !provided[arg.Name] {
+			v.report(sel.pos, "field \"%s.%s\" argument %q of type %q is required but not provided", parent.Name, field.Name, arg.Name, arg.Type)
+		}
+	}
+}
+
+// validateValue checks a literal or variable against an input type.
+func (v *validator) validateValue(value astValue, expected *typeRef, hasLocationDefault bool, where string) {
+	if value.kind == valueVariable {
+		v.validateVariableUsage(value, expected, hasLocationDefault, where)
+		return
+	}
+
+	if expected.Kind == typeKindNonNull {
+		if value.kind == valueNull {
+			v.report(value.pos, "%s expects type %q, found null", where, expected)
+			return
+		}
+		expected = expected.OfType
+	}
+	if value.kind == valueNull {
+		return
+	}
+
+	if expected.Kind == typeKindList {
+		if value.kind == valueList {
+			for _, item := range value.list {
+				v.validateValue(item, expected.OfType, false, where)
+			}
+			return
+		}
+		// A single value is coerced to a one-element list.
+		v.validateValue(value, expected.OfType, false, where)
+		return
+	}
+	if value.kind == valueList {
+		v.report(value.pos, "%s expects type %q, found a list", where, expected)
+		return
+	}
+
+	t := v.schema.types[expected.Name]
+	if t == nil {
+		return
+	}
+	switch t.Kind {
+	case typeKindInputObject:
+		v.validateInputObject(value, t, where)
+	case typeKindEnum:
+		if value.kind != valueEnum {
+			v.report(value.pos, "%s expects enum %q, found %s", where, t.Name, describeValue(value))
+			return
+		}
+		for _, ev := range t.EnumValues {
+			if ev.Name == value.raw {
+				return
+			}
+		}
+		names := make([]string, 0, len(t.EnumValues))
+		for _, ev := range t.EnumValues {
+			names = append(names, ev.Name)
+		}
+		v.report(value.pos, "%s: value %q does not exist in enum %q%s", where, value.raw, t.Name, suggest(value.raw, names))
+	case typeKindScalar:
+		if value.kind == valueObject && builtinScalars[t.Name] {
+			v.report(value.pos, "%s expects type %q, found an object", where, t.Name)
+			return
+		}
+		if !scalarAccepts(t.Name, value.kind) {
+			v.report(value.pos, "%s expects type %q, found %s", where, t.Name, describeValue(value))
+		}
+	}
+}
+
+func (v *validator) validateInputObject(value astValue, t *schemaType, where string) {
+	if value.kind != valueObject {
+		v.report(value.pos, "%s expects input object %q, found %s", where, t.Name, describeValue(value))
+		return
+	}
+
+	provided := make(map[string]bool, len(value.fields))
+	for _, field := range value.fields {
+		provided[field.name] = true
+
+		var def *schemaInputValue
+		for i := range t.InputFields {
+			if t.InputFields[i].Name == field.name {
+				def = &t.InputFields[i]
+				break
+			}
+		}
+		if def == nil {
+			names := make([]string, 0, len(t.InputFields))
+			for _, f := range t.InputFields {
+				names = append(names, f.Name)
+			}
+			v.report(field.pos, "%s: field %q is not defined by input type %q%s", where, field.name, t.Name, suggest(field.name, names))
+			continue
+		}
+		v.validateValue(field.value, def.Type, def.DefaultValue != nil, fmt.Sprintf("%s field \"%s.%s\"", where, t.Name, field.name))
+	}
+
+	for _, f := range t.InputFields {
+		if f.Type.Kind == typeKindNonNull && f.DefaultValue == nil && !provided[f.Name] {
+			v.report(value.pos, "%s: field \"%s.%s\" of required type %q was not provided", where, t.Name, f.Name, f.Type)
+		}
+	}
+}
+
+func (v *validator) validateVariableUsage(value astValue, expected *typeRef, hasLocationDefault bool, where string) {
+	def, ok := v.variables[value.raw]
+	if !ok {
+		v.report(value.pos, "variable $%s is not defined", value.raw)
+		return
+	}
+
+	if !variableTypeCompatible(def.typ, expected, def.hasDefault || hasLocationDefault) {
+		v.report(value.pos, "variable $%s of type %q used in position expecting type %q (%s)", value.raw, def.typ, expected, where)
+	}
+}
+
+// variableTypeCompatible reports whether a variable of type varType may be
+// used where locationType is expected. A nullable variable may flow into a
+// non-null position when either side provides a default value.
+func variableTypeCompatible(varType *astType, locationType *typeRef, hasDefault bool) bool {
+	if locationType.Kind == typeKindNonNull {
+		if !varType.nonNull {
+			if !hasDefault {
+				return false
+			}
+			return variableTypeCompatible(varType, locationType.OfType, false)
+		}
+		inner := *varType
+		inner.nonNull = false
+		return variableTypeCompatible(&inner, locationType.OfType, false)
+	}
+
+	if varType.nonNull {
+		inner := *varType
+		inner.nonNull = false
+		return variableTypeCompatible(&inner, locationType, false)
+	}
+
+	if locationType.Kind == typeKindList {
+		if varType.list == nil {
+			return false
+		}
+		return variableTypeCompatible(varType.list, locationType.OfType, false)
+	}
+	if varType.list != nil {
+		return false
+	}
+	return varType.name == locationType.Name
+}
+
+func scalarAccepts(name string, kind valueKind) bool {
+	switch name {
+	case "Int":
+		return kind == valueInt
+	case "Float":
+		return kind == valueInt || kind == valueFloat
+	case "String":
+		return kind == valueString
+	case "Boolean":
+		return kind == valueBoolean
+	case "ID":
+		return kind == valueString || kind == valueInt
+	default:
+		return true
+	}
+}
+
+func describeValue(value astValue) string {
+	switch value.kind {
+	case valueString:
+		return fmt.Sprintf("string %q", value.raw)
+	case valueObject:
+		return "an object"
+	case valueList:
+		return "a list"
+	default:
+		return value.raw
+	}
+}
+
+func isCompositeKind(kind string) bool {
+	return kind == typeKindObject || kind == typeKindInterface || kind == typeKindUnion
+}
+
+func (v *validator) typeNames() []string {
+	names := make([]string, 0, len(v.schema.types))
+	for name := range v.schema.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// suggest returns a "did you mean" hint for the closest candidates to input.
+func suggest(input string, candidates []string) string {
+	type scored struct {
+		name     string
+		distance int
+	}
+
+	threshold := len(input)*2/5 + 1
+	lower := strings.ToLower(input)
+	var matches []scored
+	for _, c := range candidates {
+		d := levenshtein(lower, strings.ToLower(c))
+		if d <= threshold || (len(c) > 2 && strings.HasPrefix(lower, strings.ToLower(c))) {
+			matches = append(matches, scored{c, d})
+		}
+	}
+	if len(matches) == 0 {
+		return ""
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].distance != matches[j].distance {
+			return matches[i].distance < matches[j].distance
+		}
+		return matches[i].name < matches[j].name
+	})
+	if len(matches) > 3 {
+		matches = matches[:3]
+	}
+	quoted := make([]string, len(matches))
+	for i, m := range matches {
+		quoted[i] = fmt.Sprintf("%q", m.name)
+	}
+	return "; did you mean " + strings.Join(quoted, " or ") + "?"
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,181 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func named(kind, name string) map[string]any {
+	return map[string]any{"kind": kind, "name": name}
+}
+
+func nonNull(of map[string]any) map[string]any {
+	return map[string]any{"kind": "NON_NULL", "ofType": of}
+}
+
+func listOf(of map[string]any) map[string]any {
+	return map[string]any{"kind": "LIST", "ofType": of}
+}
+
+func testIntrospection() map[string]any {
+	field := func(name string, typ map[string]any, args ...map[string]any) map[string]any {
+		return map[string]any{"name": name, "type": typ, "args": args}
+	}
+	arg := func(name string, typ map[string]any) map[string]any {
+		return map[string]any{"name": name, "type": typ}
+	}
+
+	return map[string]any{"__schema": map[string]any{
+		"queryType":    map[string]any{"name": "Query"},
+		"mutationType": nil,
+		"types": []map[string]any{
+			{"kind": "OBJECT", "name": "Query", "fields": []map[string]any{
+				field("chainIdentifier", nonNull(named("SCALAR", "String"))),
+				field("transaction", named("OBJECT", "Transaction"), arg("digest", nonNull(named("SCALAR", "String")))),
+				field("objects", named("OBJECT", "Object"),
+					arg("first", named("SCALAR", "Int")),
+					arg("filter", named("INPUT_OBJECT", "ObjectFilter"))),
+			}},
+			{"kind": "OBJECT", "name": "Transaction", "fields": []map[string]any{
+				field("digest", nonNull(named("SCALAR", "String"))),
+				field("kind", named("ENUM", "TransactionKind")),
+				field("owner", named("UNION", "Owner")),
+			}},
+			{"kind": "OBJECT", "name": "Object", "fields": []map[string]any{
+				field("address", nonNull(named("SCALAR", "SuiAddress"))),
+			}},
+			{"kind": "UNION", "name": "Owner", "possibleTypes": []map[string]any{{"name": "AddressOwner"}}},
+			{"kind": "OBJECT", "name": "AddressOwner", "fields": []map[string]any{
+				field("address", named("OBJECT", "Object")),
+			}},
+			{"kind": "INPUT_OBJECT", "name": "ObjectFilter", "inputFields": []map[string]any{
+				arg("type", named("SCALAR", "String")),
+				arg("objectIds", listOf(nonNull(named("SCALAR", "SuiAddress")))),
+			}},
+			{"kind": "ENUM", "name": "TransactionKind", "enumValues": []map[string]any{{"name": "PROGRAMMABLE"}}},
+			{"kind": "SCALAR", "name": "String"},
+			{"kind": "SCALAR", "name": "Int"},
+			{"kind": "SCALAR", "name": "SuiAddress"},
+		},
+	}}
+}
+
+func testSchema(t *testing.T) *Schema {
+	t.Helper()
+	raw, _ := json.Marshal(testIntrospection())
+	var result introspectionResult
+	if err := json.Unmarshal(raw, &result); err != nil {
+		t.Fatalf("decode introspection: %v", err)
+	}
+	schema, err := newSchema(&result)
+	if err != nil {
+		t.Fatalf("new schema: %v", err)
+	}
+	return schema
+}
+
+func TestSchemaValidateAcceptsValidQueries(t *testing.T) {
+	schema := testSchema(t)
+
+	queries := []string{
+		`{ chainIdentifier }`,
+		`query Tx($digest: String!) {
+			tx: transaction(digest: $digest) {
+				digest
+				kind
+				owner { __typename ... on AddressOwner { address { ...Addr } } }
+			}
+		}
+		fragment Addr on Object { address }`,
+		`query($ids: [SuiAddress!]) {
+			objects(first: 10, filter: { type: "0x2::coin::Coin", objectIds: $ids }) { address }
+		}`,
+	}
+	for _, q := range queries {
+		if err := schema.Validate(q); err != nil {
+			t.Fatalf("unexpected validation error for %s: %v", q, err)
+		}
+	}
+}
+
+func TestSchemaValidateReportsErrors(t *testing.T) {
+	schema := testSchema(t)
+
+	cases := []struct {
+		query string
+		want  string
+	}{
+		{`{ transactionBlock(digest: "x") { digest } }`, `did you mean "transaction"`},
+		{`{ transaction { digest } }`, `argument "digest" of type "String!" is required`},
+		{`{ transaction(digest: 5) { digest } }`, `expects type "String", found 5`},
+		{`{ transaction(digest: "x") }`, `must have a selection of subfields`},
+		{`{ chainIdentifier { value } }`, `must not have a selection`},
+		{`{ objects(filter: { typ: "x" }) { address } }`, `did you mean "type"`},
+		{`query($first: String) { objects(first: $first) { address } }`, `variable $first of type "String" used in position expecting type "Int"`},
+		{`{ objects(first: $n) { address } }`, `variable $n is not defined`},
+		{`{ transaction(digest: "x") { owner { address } } }`, `union type "Owner"`},
+		{`{ transaction(digest: "x") { ...Missing } }`, `unknown fragment "Missing"`},
+		{`mutation { chainIdentifier }`, `does not support mutation`},
+		{`{ transaction(digest: "x") { digest `, `syntax error`},
+	}
+	for _, tc := range cases {
+		err := schema.Validate(tc.query)
+		var verrs QueryValidationErrors
+		if !errors.As(err, &verrs) {
+			t.Fatalf("%s: expected QueryValidationErrors, got %v", tc.query, err)
+		}
+		if !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("%s: expected error containing %q, got %q", tc.query, tc.want, err)
+		}
+	}
+}
+
+func TestIntrospectionQueryParses(t *testing.T) {
+	if _, err := parseDocument(IntrospectionQuery); err != nil {
+		t.Fatalf("parse introspection query: %v", err)
+	}
+}
+
+func TestClientSchemaValidation(t *testing.T) {
+	var introspections, queries int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query string `json:"query"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		if strings.Contains(req.Query, "__schema") {
+			atomic.AddInt32(&introspections, 1)
+			json.NewEncoder(w).Encode(map[string]any{"data": testIntrospection()})
+			return
+		}
+		atomic.AddInt32(&queries, 1)
+		json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"chainIdentifier": "35834a8a"}})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithEndpoint(server.URL), WithSchemaValidation())
+	ctx := context.Background()
+
+	var out struct{ ChainIdentifier string }
+	if err := client.Execute(ctx, `{ chainIdentifier }`, nil, &out); err != nil {
+		t.Fatalf("valid query: %v", err)
+	}
+
+	qb := NewQueryBuilder()
+	qb.Field("chainIdentifer").Done()
+	err := qb.Execute(ctx, client, &out)
+	if err == nil || !strings.Contains(err.Error(), `did you mean "chainIdentifier"`) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+
+	if introspections != 1 || queries != 1 {
+		t.Fatalf("expected 1 introspection and 1 query, got %d and %d", introspections, queries)
+	}
+}
@@ -0,0 +1,77 @@
+package graphql
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// VariableEncoder rewrites the value of a top-level query variable before the
+// request is serialized, e.g. to map an application type onto one of the
+// custom scalars. Returning value unchanged keeps the default encoding.
+type VariableEncoder func(name string, value any) (any, error)
+
+// WithVariableEncoder installs enc for every request. It runs before the
+// built-in conversions, which encode *big.Int and big.Int as BigInt strings
+// and time.Time as DateTime strings.
+func WithVariableEncoder(enc VariableEncoder) ClientOption {
+	return func(c *Client) {
+		c.variableEncoder = enc
+	}
+}
+
+// encodeVariables applies the variable encoder and the built-in scalar
+// conversions. The caller's map is never modified; a copy is returned when
+// any variable changes.
+func (c *Client) encodeVariables(variables map[string]any) (map[string]any, error) {
+	var out map[string]any
+	for name, value := range variables {
+		encoded, changed := value, false
+		if c.variableEncoder != nil {
+			var err error
+			if encoded, err = c.variableEncoder(name, value); err != nil {
+				return nil, fmt.Errorf("encode variable $%s: %w", name, err)
+			}
+			changed = true
+		}
+		if scalar, ok := encodeScalar(encoded); ok {
+			encoded, changed = scalar, true
+		}
+		if !changed {
+			continue
+		}
+		if out == nil {
+			out = make(map[string]any, len(variables))
+			for k, v := range variables {
+				out[k] = v
+			}
+		}
+		out[name] = encoded
+	}
+	if out == nil {
+		return variables, nil
+	}
+	return out, nil
+}
+
+// encodeScalar converts Go values whose default JSON encoding does not
+// match the corresponding scalar.
+func encodeScalar(value any) (any, bool) {
+	switch v := value.(type) {
+	case *big.Int:
+		if v == nil {
+			return nil, true
+		}
+		return NewBigInt(v), true
+	case big.Int:
+		return NewBigInt(&v), true
+	case time.Time:
+		return NewDateTime(v), true
+	case *time.Time:
+		if v == nil {
+			return nil, true
+		}
+		return NewDateTime(*v), true
+	}
+	return value, false
+}
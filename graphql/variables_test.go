@@ -0,0 +1,156 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScalarVariableEncoding(t *testing.T) {
+	var variables string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables json.RawMessage `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		variables = string(req.Variables)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{}}`))
+	}))
+	defer server.Close()
+
+	ts := time.Date(2024, 5, 1, 14, 30, 0, 123_000_000, time.FixedZone("CEST", 2*60*60))
+	amount, _ := new(big.Int).SetString("340282366920938463463374607431768211455", 10)
+	client := NewClient(WithEndpoint(server.URL), WithRetries(0))
+	vars := map[string]any{
+		"checkpoint": UInt53(MaxUInt53),
+		"balance":    BigInt("18446744073709551616"),
+		"at":         DateTime("2024-05-01T12:30:00.123Z"),
+		"amount":     amount,
+		"after":      ts,
+		"first":      5,
+	}
+	if err := client.Execute(context.Background(), `query Q { chainIdentifier }`, vars, &struct{}{}); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	var got map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(variables), &got); err != nil {
+		t.Fatalf("decode variables %s: %v", variables, err)
+	}
+	for name, want := range map[string]string{
+		"checkpoint": "9007199254740991",
+		"balance":    `"18446744073709551616"`,
+		"at":         `"2024-05-01T12:30:00.123Z"`,
+		"amount":     `"340282366920938463463374607431768211455"`,
+		"after":      `"2024-05-01T12:30:00.123Z"`,
+		"first":      "5",
+	} {
+		if string(got[name]) != want {
+			t.Errorf("$%s encoded as %s, want %s", name, got[name], want)
+		}
+	}
+	if _, ok := vars["amount"].(*big.Int); !ok {
+		t.Fatalf("the caller's variables were modified")
+	}
+
+	for name, value := range map[string]any{
+		"overflow": UInt53(MaxUInt53 + 1),
+		"digits":   BigInt("12a"),
+		"date":     DateTime("yesterday"),
+	} {
+		err := client.Execute(context.Background(), `query Q { chainIdentifier }`, map[string]any{name: value}, &struct{}{})
+		if err == nil {
+			t.Errorf("expected %v to be rejected", value)
+		}
+	}
+}
+
+func TestVariableEncoder(t *testing.T) {
+	var variables string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables json.RawMessage `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		variables = string(req.Variables)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{}}`))
+	}))
+	defer server.Close()
+
+	type epochNumber struct{ n uint64 }
+	errBad := errors.New("bad variable")
+	client := NewClient(WithEndpoint(server.URL), WithRetries(0), WithVariableEncoder(func(name string, value any) (any, error) {
+		switch v := value.(type) {
+		case epochNumber:
+			return UInt53(v.n), nil
+		case chan int:
+			return nil, errBad
+		}
+		return value, nil
+	}))
+
+	if err := client.Execute(context.Background(), `query Q { chainIdentifier }`, map[string]any{"epoch": epochNumber{7}}, &struct{}{}); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if variables != `{"epoch":7}` {
+		t.Fatalf("unexpected variables %s", variables)
+	}
+
+	err := client.Execute(context.Background(), `query Q { chainIdentifier }`, map[string]any{"x": make(chan int)}, &struct{}{})
+	if !errors.Is(err, errBad) || !strings.Contains(err.Error(), "$x") {
+		t.Fatalf("expected the encoder error for $x, got %v", err)
+	}
+}
+
+// TestScalarVariablesLiveSchema checks the scalars against the testnet
+// schema. It is skipped when the endpoint cannot be reached.
+func TestScalarVariablesLiveSchema(t *testing.T) {
+	client := newTestClient()
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	schema, err := client.Schema(ctx)
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		t.Skipf("testnet unreachable: %v", err)
+	}
+	if err != nil {
+		t.Fatalf("schema: %v", err)
+	}
+	for _, name := range []string{"UInt53", "BigInt", "DateTime"} {
+		if typ := schema.types[name]; typ == nil || typ.Kind != typeKindScalar {
+			t.Errorf("%s is not a scalar of the live schema", name)
+		}
+	}
+
+	const query = `query Checkpoint($seq: UInt53) { checkpoint(sequenceNumber: $seq) { sequenceNumber timestamp } }`
+	if err := schema.Validate(query); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	var resp struct {
+		Checkpoint *struct {
+			SequenceNumber UInt53   `json:"sequenceNumber"`
+			Timestamp      DateTime `json:"timestamp"`
+		} `json:"checkpoint"`
+	}
+	if err := client.Execute(ctx, query, map[string]any{"seq": UInt53(1)}, &resp); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if resp.Checkpoint == nil || resp.Checkpoint.SequenceNumber != 1 {
+		t.Fatalf("unexpected checkpoint %+v", resp.Checkpoint)
+	}
+	if _, err := resp.Checkpoint.Timestamp.Time(); err != nil {
+		t.Fatalf("timestamp: %v", err)
+	}
+	if _, err := json.Marshal(resp.Checkpoint.Timestamp); err != nil {
+		t.Fatalf("timestamp does not round-trip: %v", err)
+	}
+}
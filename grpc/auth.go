@@ -0,0 +1,103 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/open-move/sui-go-sdk/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// WithAuthenticator applies a to every RPC, sending the headers it sets as
+// call metadata. A unary call rejected with Unauthenticated is retried once
+// after a.Refresh. Streams are authenticated when opened, without a body,
+// and are not retried.
+func WithAuthenticator(a auth.Authenticator) Option {
+	return func(cfg *config) {
+		if a == nil {
+			return
+		}
+		cfg.dialOptions = append(cfg.dialOptions,
+			grpc.WithChainUnaryInterceptor(authUnaryInterceptor(a)),
+			grpc.WithChainStreamInterceptor(authStreamInterceptor(a)),
+		)
+	}
+}
+
+func authUnaryInterceptor(a auth.Authenticator) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		var body []byte
+		if msg, ok := req.(proto.Message); ok {
+			encoded, err := proto.Marshal(msg)
+			if err != nil {
+				return err
+			}
+			body = encoded
+		}
+
+		authCtx, err := authenticate(ctx, a, cc.Target(), method, body)
+		if err != nil {
+			return err
+		}
+		err = invoker(authCtx, method, req, reply, cc, opts...)
+		if status.Code(err) != codes.Unauthenticated {
+			return err
+		}
+
+		if refreshErr := a.Refresh(ctx); refreshErr != nil {
+			return fmt.Errorf("refresh credentials: %w (after %w)", refreshErr, err)
+		}
+		if authCtx, err = authenticate(ctx, a, cc.Target(), method, body); err != nil {
+			return err
+		}
+		return invoker(authCtx, method, req, reply, cc, opts...)
+	}
+}
+
+func authStreamInterceptor(a auth.Authenticator) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		authCtx, err := authenticate(ctx, a, cc.Target(), method, nil)
+		if err != nil {
+			return nil, err
+		}
+		return streamer(authCtx, desc, cc, method, opts...)
+	}
+}
+
+// authenticate presents the call to a as an HTTP request and returns ctx
+// with the headers it set appended to the outgoing metadata.
+func authenticate(ctx context.Context, a auth.Authenticator, target, method string, body []byte) (context.Context, error) {
+	// Targets may carry a resolver scheme, as in dns:///host:443.
+	host := target[strings.LastIndex(target, "/")+1:]
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://"+host+method, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("authenticate request: %w", err)
+	}
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	req.Header.Set("Content-Type", "application/grpc")
+	if err := a.Apply(req); err != nil {
+		return nil, fmt.Errorf("authenticate request: %w", err)
+	}
+
+	pairs := make([]string, 0, 2*len(req.Header))
+	for key, values := range req.Header {
+		key = strings.ToLower(key)
+		if key == "content-type" {
+			continue
+		}
+		for _, value := range values {
+			pairs = append(pairs, key, value)
+		}
+	}
+	return metadata.AppendToOutgoingContext(ctx, pairs...), nil
+}
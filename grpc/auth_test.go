@@ -0,0 +1,58 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/open-move/sui-go-sdk/auth"
+	v2 "github.com/open-move/sui-go-sdk/proto/sui/rpc/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// tokenLedgerServer accepts only the bearer token "fresh".
+type tokenLedgerServer struct {
+	v2.UnimplementedLedgerServiceServer
+}
+
+func (tokenLedgerServer) GetServiceInfo(ctx context.Context, _ *v2.GetServiceInfoRequest) (*v2.GetServiceInfoResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get("authorization"); len(values) != 1 || values[0] != "Bearer fresh" {
+		return nil, status.Error(codes.Unauthenticated, "bad token")
+	}
+	chainID := "chain"
+	return &v2.GetServiceInfoResponse{ChainId: &chainID}, nil
+}
+
+func TestWithAuthenticatorRefreshesOnUnauthenticated(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	requireNoError(t, err, "listen")
+	server := grpc.NewServer()
+	v2.RegisterLedgerServiceServer(server, tokenLedgerServer{})
+	go server.Serve(lis)
+	defer server.Stop()
+
+	tokens := []string{"stale", "fresh"}
+	fetches := 0
+	jwt := auth.NewJWT(func(context.Context) (string, time.Time, error) {
+		token := tokens[min(fetches, len(tokens)-1)]
+		fetches++
+		return token, time.Time{}, nil
+	})
+
+	client, err := NewClient(context.Background(), lis.Addr().String(), WithAuthenticator(jwt))
+	requireNoError(t, err, "new client")
+	defer client.Close()
+
+	_, err = client.LedgerClient().GetServiceInfo(context.Background(), &v2.GetServiceInfoRequest{})
+	requireNoError(t, err, "get service info")
+	requireEqual(t, fetches, 2, "token fetches")
+
+	_, err = client.LedgerClient().GetServiceInfo(context.Background(), &v2.GetServiceInfoRequest{})
+	requireNoError(t, err, "get service info with cached token")
+	requireEqual(t, fetches, 2, "token fetches after reuse")
+}
@@ -0,0 +1,183 @@
+package grpc
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// callSettings collects the per-call options of one RPC.
+type callSettings struct {
+	timeout  time.Duration
+	headers  []string // alternating keys and values
+	endpoint string
+}
+
+// callOption is a grpc.CallOption understood by the client's interceptor.
+// It is ignored by grpc itself.
+type callOption struct {
+	grpc.EmptyCallOption
+	apply func(*callSettings)
+}
+
+// WithCallTimeout bounds a single call, independently of the deadline of
+// the context it was made with. Pass it to any client method that accepts
+// grpc.CallOption values, or attach it to a context with WithCallOptions.
+func WithCallTimeout(timeout time.Duration) grpc.CallOption {
+	return callOption{apply: func(s *callSettings) { s.timeout = timeout }}
+}
+
+// WithCallHeader sends an extra metadata header with a single call.
+func WithCallHeader(key, value string) grpc.CallOption {
+	return callOption{apply: func(s *callSettings) { s.headers = append(s.headers, key, value) }}
+}
+
+// WithEndpointOverride sends a single call to endpoint instead of the
+// client's endpoint, e.g. to route a long historical scan to an archive node.
+// The connection is dialed with the client's options on first use and
+// reused until the client is closed.
+func WithEndpointOverride(endpoint string) grpc.CallOption {
+	return callOption{apply: func(s *callSettings) { s.endpoint = endpoint }}
+}
+
+type callOptionsKey struct{}
+
+// WithCallOptions returns a context that applies opts to every call made
+// with it. Options passed to a method directly are applied after these.
+func WithCallOptions(ctx context.Context, opts ...grpc.CallOption) context.Context {
+	existing, _ := ctx.Value(callOptionsKey{}).([]grpc.CallOption)
+	merged := append(append([]grpc.CallOption(nil), existing...), opts...)
+	return context.WithValue(ctx, callOptionsKey{}, merged)
+}
+
+// splitCallOptions separates the client's call options from grpc's own and
+// resolves them against any options attached to ctx.
+func splitCallOptions(ctx context.Context, opts []grpc.CallOption) (callSettings, []grpc.CallOption) {
+	var settings callSettings
+	fromCtx, _ := ctx.Value(callOptionsKey{}).([]grpc.CallOption)
+	for _, opt := range fromCtx {
+		if o, ok := opt.(callOption); ok {
+			o.apply(&settings)
+		}
+	}
+	rest := make([]grpc.CallOption, 0, len(opts))
+	for _, opt := range opts {
+		if o, ok := opt.(callOption); ok {
+			o.apply(&settings)
+			continue
+		}
+		rest = append(rest, opt)
+	}
+	return settings, rest
+}
+
+// apply adds the timeout and headers to ctx.
+func (s callSettings) apply(ctx context.Context) (context.Context, context.CancelFunc) {
+	if len(s.headers) > 0 {
+		ctx = metadata.AppendToOutgoingContext(ctx, s.headers...)
+	}
+	if s.timeout > 0 {
+		return context.WithTimeout(ctx, s.timeout)
+	}
+	return ctx, func() {}
+}
+
+// callRouter implements the per-call options for a client.
+type callRouter struct {
+	dial func(endpoint string) (*grpc.ClientConn, error)
+
+	mu    sync.Mutex
+	conns map[string]*grpc.ClientConn
+}
+
+func (r *callRouter) dialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(r.unary),
+		grpc.WithChainStreamInterceptor(r.stream),
+	}
+}
+
+func (r *callRouter) unary(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	settings, rest := splitCallOptions(ctx, opts)
+	ctx, cancel := settings.apply(context.WithValue(ctx, callOptionsKey{}, nil))
+	defer cancel()
+
+	if settings.endpoint == "" {
+		return invoker(ctx, method, req, reply, cc, rest...)
+	}
+	conn, err := r.conn(settings.endpoint)
+	if err != nil {
+		return err
+	}
+	return conn.Invoke(ctx, method, req, reply, rest...)
+}
+
+func (r *callRouter) stream(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	settings, rest := splitCallOptions(ctx, opts)
+	ctx, cancel := settings.apply(context.WithValue(ctx, callOptionsKey{}, nil))
+
+	var stream grpc.ClientStream
+	var err error
+	if settings.endpoint == "" {
+		stream, err = streamer(ctx, desc, cc, method, rest...)
+	} else {
+		var conn *grpc.ClientConn
+		if conn, err = r.conn(settings.endpoint); err == nil {
+			stream, err = conn.NewStream(ctx, desc, method, rest...)
+		}
+	}
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &cancelStream{ClientStream: stream, cancel: cancel}, nil
+}
+
+func (r *callRouter) conn(endpoint string) (*grpc.ClientConn, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if conn, ok := r.conns[endpoint]; ok {
+		return conn, nil
+	}
+	conn, err := r.dial(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if r.conns == nil {
+		r.conns = make(map[string]*grpc.ClientConn)
+	}
+	r.conns[endpoint] = conn
+	return conn, nil
+}
+
+func (r *callRouter) close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var firstErr error
+	for endpoint, conn := range r.conns {
+		if err := conn.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		delete(r.conns, endpoint)
+	}
+	return firstErr
+}
+
+// cancelStream releases the call's timeout once the stream ends.
+type cancelStream struct {
+	grpc.ClientStream
+	cancel context.CancelFunc
+}
+
+func (s *cancelStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil {
+		s.cancel()
+	}
+	return err
+}
@@ -0,0 +1,73 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	v2 "github.com/open-move/sui-go-sdk/proto/sui/rpc/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type recordingLedgerServer struct {
+	v2.UnimplementedLedgerServiceServer
+	chainID  string
+	header   string
+	deadline time.Duration
+}
+
+func (s *recordingLedgerServer) GetServiceInfo(ctx context.Context, _ *v2.GetServiceInfoRequest) (*v2.GetServiceInfoResponse, error) {
+	s.header, s.deadline = "", 0
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("x-request-id"); len(values) > 0 {
+			s.header = values[0]
+		}
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		s.deadline = time.Until(deadline)
+	}
+	return &v2.GetServiceInfoResponse{ChainId: &s.chainID}, nil
+}
+
+func startRecordingLedger(t *testing.T, chainID string) (*recordingLedgerServer, string) {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	requireNoError(t, err, "listen")
+	ledger := &recordingLedgerServer{chainID: chainID}
+	server := grpc.NewServer()
+	v2.RegisterLedgerServiceServer(server, ledger)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+	return ledger, lis.Addr().String()
+}
+
+func TestCallOptions(t *testing.T) {
+	primary, primaryAddr := startRecordingLedger(t, "primary")
+	_, archiveAddr := startRecordingLedger(t, "archive")
+
+	client, err := NewClient(context.Background(), primaryAddr)
+	requireNoError(t, err, "new client")
+	defer client.Close()
+
+	resp, err := client.LedgerClient().GetServiceInfo(context.Background(), &v2.GetServiceInfoRequest{},
+		WithCallTimeout(time.Minute), WithCallHeader("x-request-id", "abc"))
+	requireNoError(t, err, "get service info")
+	requireEqual(t, resp.GetChainId(), "primary", "chain id")
+	requireEqual(t, primary.header, "abc", "header")
+	if primary.deadline <= 0 || primary.deadline > time.Minute {
+		t.Fatalf("unexpected deadline %v", primary.deadline)
+	}
+
+	ctx := WithCallOptions(context.Background(), WithEndpointOverride(archiveAddr))
+	resp, err = client.LedgerClient().GetServiceInfo(ctx, &v2.GetServiceInfoRequest{})
+	requireNoError(t, err, "get service info via override")
+	requireEqual(t, resp.GetChainId(), "archive", "override chain id")
+
+	resp, err = client.LedgerClient().GetServiceInfo(context.Background(), &v2.GetServiceInfoRequest{})
+	requireNoError(t, err, "get service info")
+	requireEqual(t, resp.GetChainId(), "primary", "chain id after override")
+	requireEqual(t, primary.header, "", "header is per call")
+	requireEqual(t, primary.deadline, time.Duration(0), "timeout is per call")
+}
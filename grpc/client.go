@@ -10,6 +10,7 @@ import (
 	"net/url"
 	"strings"
 
+	"github.com/open-move/sui-go-sdk/network"
 	v2 "github.com/open-move/sui-go-sdk/proto/sui/rpc/v2"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -20,6 +21,8 @@ import (
 type Client struct {
 	endpoint string
 	conn     *grpc.ClientConn
+	pool     *connPool
+	router   *callRouter
 
 	ledgerClient                v2.LedgerServiceClient
 	movePackageClient           v2.MovePackageServiceClient
@@ -37,42 +40,64 @@ func NewClient(ctx context.Context, endpoint string, opts ...Option) (*Client, e
 		return nil, errors.New("nil context")
 	}
 
-	target, serverName, secure, err := normalizeEndpoint(endpoint)
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	router := &callRouter{}
+	router.dial = func(override string) (*grpc.ClientConn, error) {
+		return dial(override, cfg, nil)
+	}
+	endpoints := append([]string{endpoint}, cfg.extraEndpoints...)
+	pool, err := newConnPool(endpoints, cfg.poolSize, func(endpoint string) (*grpc.ClientConn, error) {
+		return dial(endpoint, cfg, router.dialOptions())
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	cfg := defaultConfig()
-	for _, opt := range opts {
-		opt(cfg)
+	c := &Client{
+		conn:                        pool.members[0].conn,
+		pool:                        pool,
+		router:                      router,
+		endpoint:                    endpoint,
+		ledgerClient:                v2.NewLedgerServiceClient(pool),
+		movePackageClient:           v2.NewMovePackageServiceClient(pool),
+		nameServiceClient:           v2.NewNameServiceClient(pool),
+		signatureVerificationClient: v2.NewSignatureVerificationServiceClient(pool),
+		stateClient:                 v2.NewStateServiceClient(pool),
+		subscriptionClient:          v2.NewSubscriptionServiceClient(pool),
+		transactionExecutionClient:  v2.NewTransactionExecutionServiceClient(pool),
 	}
 
-	dialOpts := make([]grpc.DialOption, 0, len(cfg.dialOptions)+2)
+	return c, nil
+}
+
+// dial opens a connection to endpoint using the client configuration.
+// extra dial options run ahead of the observability interceptors.
+func dial(endpoint string, cfg *config, extra []grpc.DialOption) (*grpc.ClientConn, error) {
+	target, serverName, secure, err := normalizeEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	dialOpts := make([]grpc.DialOption, 0, len(cfg.dialOptions)+len(extra)+5)
 	creds, err := selectCredentials(cfg, secure, serverName)
 	if err != nil {
 		return nil, err
 	}
 	dialOpts = append(dialOpts, grpc.WithTransportCredentials(creds))
+	dialOpts = append(dialOpts, extra...)
+	dialOpts = append(dialOpts, cfg.observabilityDialOptions()...)
+	dialOpts = append(dialOpts, cfg.rateLimitDialOptions()...)
 	dialOpts = append(dialOpts, cfg.dialOptions...)
 
 	conn, err := grpc.NewClient(target, dialOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("dial %q: %w", endpoint, err)
 	}
-
-	c := &Client{
-		conn:                        conn,
-		endpoint:                    endpoint,
-		ledgerClient:                v2.NewLedgerServiceClient(conn),
-		movePackageClient:           v2.NewMovePackageServiceClient(conn),
-		nameServiceClient:           v2.NewNameServiceClient(conn),
-		signatureVerificationClient: v2.NewSignatureVerificationServiceClient(conn),
-		stateClient:                 v2.NewStateServiceClient(conn),
-		subscriptionClient:          v2.NewSubscriptionServiceClient(conn),
-		transactionExecutionClient:  v2.NewTransactionExecutionServiceClient(conn),
-	}
-
-	return c, nil
+	return conn, nil
 }
 
 // NewMainnetClient constructs a Client that targets the public Sui mainnet fullnode.
@@ -90,6 +115,38 @@ func NewDevnetClient(ctx context.Context, opts ...Option) (*Client, error) {
 	return NewClient(ctx, DevnetFullnodeURL, opts...)
 }
 
+// NewLocalnetClient constructs a Client that targets a local `sui start` node.
+func NewLocalnetClient(ctx context.Context, opts ...Option) (*Client, error) {
+	return NewClient(ctx, LocalnetFullnodeURL, opts...)
+}
+
+// Network identifies the network the client is connected to from the chain
+// ID reported by GetServiceInfo, falling back to the endpoint for devnet and
+// localnet.
+func (c *Client) Network(ctx context.Context, opts ...grpc.CallOption) (network.Config, error) {
+	if c == nil {
+		return network.Config{}, errors.New("nil client")
+	}
+	if ctx == nil {
+		return network.Config{}, errors.New("nil context")
+	}
+
+	resp, err := c.ledgerClient.GetServiceInfo(ctx, &v2.GetServiceInfoRequest{}, opts...)
+	if err != nil {
+		return network.Config{}, err
+	}
+	chainID, err := network.ChainIdentifierFromDigest(resp.GetChainId())
+	if err != nil {
+		return network.Config{}, err
+	}
+
+	cfg := network.Detect(chainID, c.endpoint)
+	if cfg.GRPCURL == "" {
+		cfg.GRPCURL = c.endpoint
+	}
+	return cfg, nil
+}
+
 // Endpoint reports the remote endpoint the client was created for.
 func (c *Client) Endpoint() string {
 	if c == nil {
@@ -98,7 +155,8 @@ func (c *Client) Endpoint() string {
 	return c.endpoint
 }
 
-// Conn exposes the underlying grpc.ClientConn for advanced use cases.
+// Conn exposes the underlying grpc.ClientConn for advanced use cases. With a
+// connection pool it is the first connection to the primary endpoint.
 func (c *Client) Conn() *grpc.ClientConn {
 	if c == nil {
 		return nil
@@ -106,12 +164,18 @@ func (c *Client) Conn() *grpc.ClientConn {
 	return c.conn
 }
 
-// Close shuts down the underlying gRPC connection.
+// Close shuts down the underlying gRPC connections.
 func (c *Client) Close() error {
-	if c == nil || c.conn == nil {
+	if c == nil || c.pool == nil {
 		return nil
 	}
-	return c.conn.Close()
+	err := c.pool.close()
+	if c.router != nil {
+		if routerErr := c.router.close(); err == nil {
+			err = routerErr
+		}
+	}
+	return err
 }
 
 // LedgerClient returns the generated LedgerService client for advanced RPC access.
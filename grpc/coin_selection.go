@@ -105,7 +105,7 @@ func (c *Client) SelectCoins(ctx context.Context, owner string, coinType string,
 		req.PageSize = &size
 	}
 	req.ReadMask = ensureFieldMaskPaths(cfg.readMask,
-		"object_id", "version", "digest", "balance", "owner",
+		ObjectID, ObjectVersion, ObjectDigest, ObjectBalance, ObjectOwner,
 	)
 
 	pager, err := c.OwnedObjectsPager(req)
@@ -183,7 +183,7 @@ func (c *Client) SelectUpToNLargestCoins(ctx context.Context, owner string, coin
 		req.PageSize = &size
 	}
 	req.ReadMask = ensureFieldMaskPaths(cfg.readMask,
-		"object_id", "version", "digest", "balance", "owner",
+		ObjectID, ObjectVersion, ObjectDigest, ObjectBalance, ObjectOwner,
 	)
 
 	pager, err := c.OwnedObjectsPager(req)
@@ -227,5 +227,8 @@ func shouldExclude(exclusions map[string]struct{}, id string) bool {
 }
 
 func normalizeObjectID(id string) string {
+	if normalized, err := utils.NormalizeAddress(id); err == nil {
+		return normalized
+	}
 	return strings.ToLower(strings.TrimSpace(id))
 }
@@ -7,8 +7,18 @@ const (
 	TestnetFullnodeURL = "https://fullnode.testnet.sui.io"
 	// DevnetFullnodeURL is the public-good devnet fullnode endpoint.
 	DevnetFullnodeURL = "https://fullnode.devnet.sui.io"
+	// LocalnetFullnodeURL is the default endpoint of a local `sui start` node.
+	LocalnetFullnodeURL = "http://127.0.0.1:9000"
 	// MainnetArchiveURL is the public-good mainnet archive endpoint.
 	MainnetArchiveURL = "https://archive.mainnet.sui.io"
 	// TestnetArchiveURL is the public-good testnet archive endpoint.
 	TestnetArchiveURL = "https://archive.testnet.sui.io"
 )
+
+// Network endpoint aliases, named consistently with the graphql package.
+const (
+	MainnetEndpoint  = MainnetFullnodeURL
+	TestnetEndpoint  = TestnetFullnodeURL
+	DevnetEndpoint   = DevnetFullnodeURL
+	LocalnetEndpoint = LocalnetFullnodeURL
+)
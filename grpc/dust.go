@@ -0,0 +1,182 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	v2 "github.com/open-move/sui-go-sdk/proto/sui/rpc/v2"
+	"github.com/open-move/sui-go-sdk/transaction"
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+// DustSweepOptions configures SweepDustCoins.
+type DustSweepOptions struct {
+	// Threshold is the balance, in MIST, below which a coin is dust.
+	// Required.
+	Threshold uint64
+	// BatchSize bounds the dust coins merged per transaction. The default
+	// and maximum is the gas payment limit minus the primary coin.
+	BatchSize int
+	// MaxTransactions bounds the transactions sent in one sweep, so a
+	// periodic job spreads a large backlog over several runs. Zero sends as
+	// many as needed.
+	MaxTransactions int
+	// Execute configures each execution. The sweep always waits for
+	// checkpoint inclusion.
+	Execute *ExecuteOptions
+}
+
+// DustSweepReport summarises a sweep.
+type DustSweepReport struct {
+	// Primary is the coin the dust was merged into: the owner's largest
+	// SUI coin.
+	Primary string
+	// Scanned is the number of SUI coins the owner held.
+	Scanned int
+	// Dust is the number of coins below the threshold, and Merged how many
+	// of them this sweep merged.
+	Dust   int
+	Merged int
+	// Transactions are the sweep transactions, in execution order.
+	Transactions []*ExecutionResult
+	// GasUsed sums the gas charged by all sweep transactions.
+	GasUsed types.GasCostSummary
+}
+
+// Reclaimed returns the storage rebate minus the gas spent, in MIST: what
+// the sweep earned the owner. It can be negative when few coins were
+// merged.
+func (r *DustSweepReport) Reclaimed() int64 {
+	return -r.GasUsed.NetCost()
+}
+
+// Remaining returns the number of dust coins left for a later sweep.
+func (r *DustSweepReport) Remaining() int {
+	return r.Dust - r.Merged
+}
+
+// SweepDustCoins merges the signer's SUI coins below opts.Threshold into
+// its largest SUI coin. Each transaction pays gas with the primary coin and
+// a batch of dust coins, which the network merges into the primary coin;
+// deleting the dust returns its storage rebate. Long-running senders can
+// call it periodically to keep their coin count, and so coin selection
+// cost, bounded.
+func (c *Client) SweepDustCoins(ctx context.Context, signer transaction.TransactionSigner, opts DustSweepOptions) (*DustSweepReport, error) {
+	tx, err := sendPreconditions(c, ctx, signer)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Threshold == 0 {
+		return nil, errors.New("dust threshold must be greater than zero")
+	}
+	batchSize := opts.BatchSize
+	if batchSize <= 0 || batchSize > maxGasPaymentObjects-1 {
+		batchSize = maxGasPaymentObjects - 1
+	}
+	owner := tx.Sender()
+
+	coins, err := c.listSuiCoins(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+	report := &DustSweepReport{Scanned: len(coins)}
+	if len(coins) < 2 {
+		return report, nil
+	}
+
+	sort.SliceStable(coins, func(i, j int) bool {
+		return coins[i].GetBalance() > coins[j].GetBalance()
+	})
+	primary, err := objectRefsFromObjects(coins[:1])
+	if err != nil {
+		return nil, err
+	}
+	report.Primary = coins[0].GetObjectId()
+
+	var dust []types.ObjectRef
+	for _, coin := range coins[1:] {
+		if coin.GetBalance() >= opts.Threshold {
+			continue
+		}
+		ref, err := utils.ParseObjectRef(coin.GetObjectId(), coin.GetVersion(), coin.GetDigest())
+		if err != nil {
+			return nil, err
+		}
+		dust = append(dust, ref)
+	}
+	report.Dust = len(dust)
+
+	gasRef := primary[0]
+	for start := 0; start < len(dust); start += batchSize {
+		if opts.MaxTransactions > 0 && len(report.Transactions) >= opts.MaxTransactions {
+			break
+		}
+		batch := dust[start:min(start+batchSize, len(dust))]
+
+		tx := transaction.New()
+		tx.SetSender(owner)
+		tx.PayAllSui(owner)
+		payment := append([]types.ObjectRef{gasRef}, batch...)
+		result, err := c.sendTransaction(ctx, tx, signer, &fixedGasResolver{Resolver: NewResolver(c), payment: payment}, opts.Execute)
+		if err != nil {
+			return report, fmt.Errorf("sweep batch %d: %w", len(report.Transactions), err)
+		}
+
+		report.Transactions = append(report.Transactions, result)
+		if effects := result.Effects; effects != nil {
+			report.GasUsed.ComputationCost += effects.GasUsed.ComputationCost
+			report.GasUsed.StorageCost += effects.GasUsed.StorageCost
+			report.GasUsed.StorageRebate += effects.GasUsed.StorageRebate
+			report.GasUsed.NonRefundableStorageFee += effects.GasUsed.NonRefundableStorageFee
+			if !effects.Success {
+				return report, fmt.Errorf("sweep batch %d failed: %s", len(report.Transactions)-1, effects.Error)
+			}
+		}
+		report.Merged += len(batch)
+
+		if gasRef, err = c.nextGasRef(ctx, result, report.Primary); err != nil {
+			return report, err
+		}
+	}
+	return report, nil
+}
+
+func (c *Client) listSuiCoins(ctx context.Context, owner string) ([]*v2.Object, error) {
+	size := defaultCoinPageSize
+	pager, err := c.OwnedObjectsPager(&v2.ListOwnedObjectsRequest{
+		Owner:      utils.Ptr(owner),
+		ObjectType: utils.Ptr("0x2::coin::Coin<" + defaultGasCoinType + ">"),
+		PageSize:   &size,
+		ReadMask:   NewFieldMask(ObjectID, ObjectVersion, ObjectDigest, ObjectBalance).Build(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pager.Collect(ctx)
+}
+
+// nextGasRef returns the primary coin's reference after result, from the
+// effects when they carry it and from the network otherwise.
+func (c *Client) nextGasRef(ctx context.Context, result *ExecutionResult, primary string) (types.ObjectRef, error) {
+	if result.Effects != nil && result.Effects.GasObject != nil {
+		return *result.Effects.GasObject, nil
+	}
+	obj, err := c.GetObject(ctx, primary, nil)
+	if err != nil {
+		return types.ObjectRef{}, fmt.Errorf("refresh primary coin: %w", err)
+	}
+	return utils.ParseObjectRef(obj.GetObjectId(), obj.GetVersion(), obj.GetDigest())
+}
+
+// fixedGasResolver pays gas with a fixed set of coins.
+type fixedGasResolver struct {
+	*Resolver
+	payment []types.ObjectRef
+}
+
+func (r *fixedGasResolver) ResolveGasPayment(context.Context, types.Address, uint64) ([]types.ObjectRef, error) {
+	return r.payment, nil
+}
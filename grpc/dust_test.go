@@ -0,0 +1,93 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/cryptography/ed25519"
+	v2 "github.com/open-move/sui-go-sdk/proto/sui/rpc/v2"
+	"github.com/open-move/sui-go-sdk/transaction"
+	"github.com/open-move/sui-go-sdk/utils"
+	"google.golang.org/grpc"
+)
+
+type dustStateServer struct {
+	v2.UnimplementedStateServiceServer
+}
+
+func (dustStateServer) ListOwnedObjects(context.Context, *v2.ListOwnedObjectsRequest) (*v2.ListOwnedObjectsResponse, error) {
+	coin := func(id string, balance uint64) *v2.Object {
+		return &v2.Object{
+			ObjectId: utils.Ptr(id),
+			Version:  utils.Ptr(uint64(3)),
+			Digest:   utils.Ptr(testDigest(3)),
+			Balance:  utils.Ptr(balance),
+		}
+	}
+	return &v2.ListOwnedObjectsResponse{Objects: []*v2.Object{
+		coin("0x22", 100),
+		coin("0x21", 10_000_000),
+		coin("0x23", 200),
+		coin("0x24", 5_000_000),
+		coin("0x25", 300),
+	}}, nil
+}
+
+type dustExecutionServer struct {
+	sendExecutionServer
+	executed []*v2.ExecuteTransactionRequest
+}
+
+func (s *dustExecutionServer) ExecuteTransaction(ctx context.Context, req *v2.ExecuteTransactionRequest) (*v2.ExecuteTransactionResponse, error) {
+	s.executed = append(s.executed, req)
+	return s.sendExecutionServer.ExecuteTransaction(ctx, req)
+}
+
+func TestSweepDustCoins(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	requireNoError(t, err, "listen")
+	server := grpc.NewServer()
+	execution := &dustExecutionServer{}
+	v2.RegisterLedgerServiceServer(server, sendLedgerServer{})
+	v2.RegisterStateServiceServer(server, dustStateServer{})
+	v2.RegisterTransactionExecutionServiceServer(server, execution)
+	go server.Serve(lis)
+	defer server.Stop()
+
+	client, err := NewClient(context.Background(), lis.Addr().String())
+	requireNoError(t, err, "new client")
+	defer client.Close()
+
+	signer, err := ed25519.Generate()
+	requireNoError(t, err, "generate keypair")
+
+	report, err := client.SweepDustCoins(context.Background(), signer, DustSweepOptions{Threshold: 1_000_000, BatchSize: 2})
+	requireNoError(t, err, "sweep")
+	requireEqual(t, report.Primary, "0x21", "primary")
+	requireEqual(t, report.Scanned, 5, "scanned")
+	requireEqual(t, report.Dust, 3, "dust")
+	requireEqual(t, report.Merged, 3, "merged")
+	requireEqual(t, report.Remaining(), 0, "remaining")
+	requireEqual(t, len(report.Transactions), 2, "transactions")
+	requireEqual(t, report.GasUsed.StorageRebate, uint64(1000), "storage rebate")
+	requireEqual(t, report.Reclaimed(), int64(-5000), "reclaimed")
+
+	first, err := transaction.DecodeTransactionData(execution.executed[0].GetTransaction().GetBcs().GetValue())
+	requireNoError(t, err, "decode first")
+	payment := first.V1.GasData.Payment
+	requireEqual(t, len(payment), 3, "first payment")
+	requireEqual(t, payment[0].ObjectID, utils.MustParseAddress("0x21"), "first gas coin")
+
+	// The second batch pays with the primary coin's version from the
+	// first batch's effects.
+	second, err := transaction.DecodeTransactionData(execution.executed[1].GetTransaction().GetBcs().GetValue())
+	requireNoError(t, err, "decode second")
+	payment = second.V1.GasData.Payment
+	requireEqual(t, len(payment), 2, "second payment")
+	requireEqual(t, payment[0].Version, uint64(12), "refreshed gas version")
+
+	report, err = client.SweepDustCoins(context.Background(), signer, DustSweepOptions{Threshold: 1_000_000, BatchSize: 2, MaxTransactions: 1})
+	requireNoError(t, err, "limited sweep")
+	requireEqual(t, report.Remaining(), 1, "remaining after limited sweep")
+}
@@ -0,0 +1,106 @@
+package grpc
+
+import (
+	"fmt"
+
+	v2 "github.com/open-move/sui-go-sdk/proto/sui/rpc/v2"
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+// EffectsFromProto converts gRPC transaction effects into the shared
+// types.TransactionEffects. Fields absent from the read mask are left zero.
+func EffectsFromProto(effects *v2.TransactionEffects) (*types.TransactionEffects, error) {
+	if effects == nil {
+		return nil, nil
+	}
+
+	out := &types.TransactionEffects{
+		Success:        effects.GetStatus().GetSuccess(),
+		Epoch:          effects.GetEpoch(),
+		LamportVersion: effects.GetLamportVersion(),
+	}
+	if !out.Success {
+		out.Error = effects.GetStatus().GetError().GetDescription()
+	}
+	if digest := effects.GetTransactionDigest(); digest != "" {
+		parsed, err := utils.ParseDigest(digest)
+		if err != nil {
+			return nil, fmt.Errorf("transaction digest: %w", err)
+		}
+		out.TransactionDigest = parsed
+	}
+	if gas := effects.GetGasUsed(); gas != nil {
+		out.GasUsed = types.GasCostSummary{
+			ComputationCost:         gas.GetComputationCost(),
+			StorageCost:             gas.GetStorageCost(),
+			StorageRebate:           gas.GetStorageRebate(),
+			NonRefundableStorageFee: gas.GetNonRefundableStorageFee(),
+		}
+	}
+	if gas := effects.GetGasObject(); gas != nil && gas.GetOutputDigest() != "" {
+		ref, err := utils.ParseObjectRef(gas.GetObjectId(), gas.GetOutputVersion(), gas.GetOutputDigest())
+		if err != nil {
+			return nil, fmt.Errorf("gas object: %w", err)
+		}
+		out.GasObject = &ref
+	}
+	for _, dep := range effects.GetDependencies() {
+		parsed, err := utils.ParseDigest(dep)
+		if err != nil {
+			return nil, fmt.Errorf("dependency: %w", err)
+		}
+		out.Dependencies = append(out.Dependencies, parsed)
+	}
+	for _, changed := range effects.GetChangedObjects() {
+		change, err := objectChangeFromProto(changed)
+		if err != nil {
+			return nil, err
+		}
+		out.Changes = append(out.Changes, change)
+	}
+	return out, nil
+}
+
+func objectChangeFromProto(changed *v2.ChangedObject) (types.ObjectChange, error) {
+	id, err := utils.ParseAddress(changed.GetObjectId())
+	if err != nil {
+		return types.ObjectChange{}, fmt.Errorf("changed object id: %w", err)
+	}
+	change := types.ObjectChange{ObjectID: id, ObjectType: changed.GetObjectType()}
+
+	inputExists := changed.GetInputState() == v2.ChangedObject_INPUT_OBJECT_STATE_EXISTS
+	if inputExists {
+		ref, err := utils.ParseObjectRef(changed.GetObjectId(), changed.GetInputVersion(), changed.GetInputDigest())
+		if err != nil {
+			return types.ObjectChange{}, fmt.Errorf("object %s input: %w", id, err)
+		}
+		change.Input = &ref
+	}
+
+	output := changed.GetOutputState()
+	outputExists := output == v2.ChangedObject_OUTPUT_OBJECT_STATE_OBJECT_WRITE || output == v2.ChangedObject_OUTPUT_OBJECT_STATE_PACKAGE_WRITE
+	if outputExists {
+		ref, err := utils.ParseObjectRef(changed.GetObjectId(), changed.GetOutputVersion(), changed.GetOutputDigest())
+		if err != nil {
+			return types.ObjectChange{}, fmt.Errorf("object %s output: %w", id, err)
+		}
+		change.Output = &ref
+	}
+
+	switch {
+	case output == v2.ChangedObject_OUTPUT_OBJECT_STATE_PACKAGE_WRITE && !inputExists:
+		change.Kind = types.PackagePublished
+	case changed.GetIdOperation() == v2.ChangedObject_CREATED:
+		change.Kind = types.ObjectCreated
+	case changed.GetIdOperation() == v2.ChangedObject_DELETED:
+		change.Kind = types.ObjectDeleted
+	case inputExists && outputExists:
+		change.Kind = types.ObjectMutated
+	case inputExists:
+		change.Kind = types.ObjectWrapped
+	case outputExists:
+		change.Kind = types.ObjectUnwrapped
+	}
+	return change, nil
+}
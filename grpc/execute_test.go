@@ -0,0 +1,201 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	v2 "github.com/open-move/sui-go-sdk/proto/sui/rpc/v2"
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func testDigest(b byte) string {
+	return types.Digest(bytes.Repeat([]byte{b}, 32)).String()
+}
+
+func testEffects() *v2.TransactionEffects {
+	return &v2.TransactionEffects{
+		Status:            &v2.ExecutionStatus{Success: utils.Ptr(true)},
+		Epoch:             utils.Ptr(uint64(7)),
+		TransactionDigest: utils.Ptr(testDigest(1)),
+		LamportVersion:    utils.Ptr(uint64(12)),
+		Dependencies:      []string{testDigest(9)},
+		GasUsed: &v2.GasCostSummary{
+			ComputationCost: utils.Ptr(uint64(1000)),
+			StorageCost:     utils.Ptr(uint64(2000)),
+			StorageRebate:   utils.Ptr(uint64(500)),
+		},
+		GasObject: &v2.ChangedObject{
+			ObjectId:      utils.Ptr("0x10"),
+			OutputVersion: utils.Ptr(uint64(12)),
+			OutputDigest:  utils.Ptr(testDigest(2)),
+		},
+		ChangedObjects: []*v2.ChangedObject{
+			{
+				ObjectId:      utils.Ptr("0x10"),
+				InputState:    v2.ChangedObject_INPUT_OBJECT_STATE_EXISTS.Enum(),
+				InputVersion:  utils.Ptr(uint64(3)),
+				InputDigest:   utils.Ptr(testDigest(3)),
+				OutputState:   v2.ChangedObject_OUTPUT_OBJECT_STATE_OBJECT_WRITE.Enum(),
+				OutputVersion: utils.Ptr(uint64(12)),
+				OutputDigest:  utils.Ptr(testDigest(2)),
+				IdOperation:   v2.ChangedObject_NONE.Enum(),
+				ObjectType:    utils.Ptr("0x2::coin::Coin<0x2::sui::SUI>"),
+			},
+			{
+				ObjectId:      utils.Ptr("0x11"),
+				InputState:    v2.ChangedObject_INPUT_OBJECT_STATE_DOES_NOT_EXIST.Enum(),
+				OutputState:   v2.ChangedObject_OUTPUT_OBJECT_STATE_OBJECT_WRITE.Enum(),
+				OutputVersion: utils.Ptr(uint64(12)),
+				OutputDigest:  utils.Ptr(testDigest(4)),
+				IdOperation:   v2.ChangedObject_CREATED.Enum(),
+			},
+			{
+				ObjectId:     utils.Ptr("0x12"),
+				InputState:   v2.ChangedObject_INPUT_OBJECT_STATE_EXISTS.Enum(),
+				InputVersion: utils.Ptr(uint64(5)),
+				InputDigest:  utils.Ptr(testDigest(5)),
+				OutputState:  v2.ChangedObject_OUTPUT_OBJECT_STATE_DOES_NOT_EXIST.Enum(),
+				IdOperation:  v2.ChangedObject_NONE.Enum(),
+			},
+		},
+	}
+}
+
+func TestEffectsFromProto(t *testing.T) {
+	effects, err := EffectsFromProto(testEffects())
+	requireNoError(t, err, "convert effects")
+
+	requireEqual(t, effects.Success, true, "success")
+	requireEqual(t, effects.TransactionDigest.String(), testDigest(1), "transaction digest")
+	requireEqual(t, effects.Epoch, uint64(7), "epoch")
+	requireEqual(t, effects.GasUsed.NetCost(), int64(2500), "net gas")
+	requireNotNil(t, effects.GasObject, "gas object")
+	requireEqual(t, effects.GasObject.Version, uint64(12), "gas version")
+	requireEqual(t, len(effects.Dependencies), 1, "dependencies")
+
+	requireEqual(t, len(effects.Changes), 3, "changes")
+	requireEqual(t, effects.Changes[0].Kind, types.ObjectMutated, "mutated kind")
+	requireEqual(t, effects.Changes[0].Input.Version, uint64(3), "input version")
+	requireEqual(t, effects.Changes[1].Kind, types.ObjectCreated, "created kind")
+	if effects.Changes[1].Input != nil {
+		t.Fatal("created object should have no input")
+	}
+	requireEqual(t, effects.Changes[2].Kind, types.ObjectWrapped, "wrapped kind")
+	requireEqual(t, len(effects.ChangesOfKind(types.ObjectCreated)), 1, "created filter")
+}
+
+type stubExecutionServer struct {
+	v2.UnimplementedTransactionExecutionServiceServer
+	request *v2.ExecuteTransactionRequest
+}
+
+func (s *stubExecutionServer) ExecuteTransaction(_ context.Context, req *v2.ExecuteTransactionRequest) (*v2.ExecuteTransactionResponse, error) {
+	s.request = req
+	return &v2.ExecuteTransactionResponse{Transaction: &v2.ExecutedTransaction{
+		Digest:  utils.Ptr(testDigest(1)),
+		Effects: testEffects(),
+	}}, nil
+}
+
+type checkpointLedgerServer struct {
+	v2.UnimplementedLedgerServiceServer
+	calls atomic.Int32
+}
+
+func (s *checkpointLedgerServer) GetTransaction(context.Context, *v2.GetTransactionRequest) (*v2.GetTransactionResponse, error) {
+	switch s.calls.Add(1) {
+	case 1:
+		return nil, status.Error(codes.NotFound, "not indexed yet")
+	case 2:
+		return &v2.GetTransactionResponse{Transaction: &v2.ExecutedTransaction{Digest: utils.Ptr(testDigest(1))}}, nil
+	}
+	return &v2.GetTransactionResponse{Transaction: &v2.ExecutedTransaction{
+		Digest:     utils.Ptr(testDigest(1)),
+		Checkpoint: utils.Ptr(uint64(42)),
+	}}, nil
+}
+
+func TestExecuteTransactionBytes(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	requireNoError(t, err, "listen")
+	server := grpc.NewServer()
+	execution := &stubExecutionServer{}
+	ledger := &checkpointLedgerServer{}
+	v2.RegisterTransactionExecutionServiceServer(server, execution)
+	v2.RegisterLedgerServiceServer(server, ledger)
+	go server.Serve(lis)
+	defer server.Stop()
+
+	client, err := NewClient(context.Background(), lis.Addr().String())
+	requireNoError(t, err, "new client")
+	defer client.Close()
+
+	signature := append([]byte{0x00}, make([]byte, 96)...)
+	result, err := client.ExecuteTransactionBytes(context.Background(), []byte{1, 2, 3}, [][]byte{signature}, &ExecuteOptions{
+		WaitForCheckpoint: true,
+		PollInterval:      time.Millisecond,
+	})
+	requireNoError(t, err, "execute")
+
+	requireEqual(t, result.Digest, testDigest(1), "digest")
+	requireNotNil(t, result.Effects, "effects")
+	requireEqual(t, result.Effects.Success, true, "success")
+	requireNotNil(t, result.Checkpoint, "checkpoint")
+	requireEqual(t, *result.Checkpoint, uint64(42), "checkpoint")
+	requireEqual(t, ledger.calls.Load(), int32(3), "checkpoint polls")
+
+	sent := execution.request
+	requireEqual(t, string(sent.GetTransaction().GetBcs().GetValue()), string([]byte{1, 2, 3}), "transaction bytes")
+	requireEqual(t, len(sent.GetSignatures()), 1, "signatures")
+	requested := map[string]bool{}
+	for _, path := range sent.GetReadMask().GetPaths() {
+		requested[path] = true
+	}
+	if !requested["digest"] || !requested["effects"] || !requested["checkpoint"] {
+		t.Fatalf("unexpected read mask %v", sent.GetReadMask().GetPaths())
+	}
+}
+
+func TestExecuteSignedTransactionAppliesOptions(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	requireNoError(t, err, "listen")
+	server := grpc.NewServer()
+	execution := &stubExecutionServer{}
+	ledger := &checkpointLedgerServer{}
+	v2.RegisterTransactionExecutionServiceServer(server, execution)
+	v2.RegisterLedgerServiceServer(server, ledger)
+	go server.Serve(lis)
+	defer server.Stop()
+
+	client, err := NewClient(context.Background(), lis.Addr().String())
+	requireNoError(t, err, "new client")
+	defer client.Close()
+
+	tx, err := client.ExecuteSignedTransaction(context.Background(), &ExecuteRequest{
+		Transaction: &v2.Transaction{Bcs: &v2.Bcs{Value: []byte{1, 2, 3}}},
+	}, &ExecuteOptions{
+		ReadMask:          &fieldmaskpb.FieldMask{Paths: []string{TransactionEvents}},
+		WaitForCheckpoint: true,
+		PollInterval:      time.Millisecond,
+	})
+	requireNoError(t, err, "execute")
+
+	requireEqual(t, tx.GetCheckpoint(), uint64(42), "checkpoint")
+	requireEqual(t, ledger.calls.Load(), int32(3), "checkpoint polls")
+	requested := map[string]bool{}
+	for _, path := range execution.request.GetReadMask().GetPaths() {
+		requested[path] = true
+	}
+	if !requested[TransactionEvents] || !requested[TransactionDigest] {
+		t.Fatalf("unexpected read mask %v", execution.request.GetReadMask().GetPaths())
+	}
+}
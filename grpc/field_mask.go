@@ -0,0 +1,140 @@
+package grpc
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// Field mask paths of ExecutedTransaction, for read masks of GetTransaction,
+// ExecuteTransaction and WaitForTransaction. SimulateTransaction returns the
+// transaction under "transaction"; use FieldMaskBuilder.Under to prefix them.
+const (
+	TransactionDigest         = "digest"
+	TransactionData           = "transaction"
+	TransactionSignatures     = "signatures"
+	TransactionEffects        = "effects"
+	TransactionEvents         = "events"
+	TransactionCheckpoint     = "checkpoint"
+	TransactionTimestamp      = "timestamp"
+	TransactionBalanceChanges = "balance_changes"
+	TransactionObjects        = "objects"
+
+	EffectsStatus         = "effects.status"
+	EffectsGasUsed        = "effects.gas_used"
+	EffectsChangedObjects = "effects.changed_objects"
+	EffectsGasObject      = "effects.gas_object"
+)
+
+// Field mask paths of Object, for read masks of GetObject, BatchGetObjects and
+// ListOwnedObjects.
+const (
+	ObjectID                  = "object_id"
+	ObjectVersion             = "version"
+	ObjectDigest              = "digest"
+	ObjectOwner               = "owner"
+	ObjectType                = "object_type"
+	ObjectHasPublicTransfer   = "has_public_transfer"
+	ObjectContents            = "contents"
+	ObjectJSON                = "json"
+	ObjectBCS                 = "bcs"
+	ObjectBalance             = "balance"
+	ObjectPreviousTransaction = "previous_transaction"
+	ObjectStorageRebate       = "storage_rebate"
+)
+
+// Field mask paths of Epoch, for read masks of GetEpoch.
+const (
+	EpochNumber            = "epoch"
+	EpochReferenceGasPrice = "reference_gas_price"
+)
+
+// FieldMaskBuilder assembles a read mask from paths, dropping duplicates and
+// keeping the order paths were first added. The zero value is ready to use.
+type FieldMaskBuilder struct {
+	paths []string
+	seen  map[string]struct{}
+}
+
+// NewFieldMask returns a builder holding paths.
+func NewFieldMask(paths ...string) *FieldMaskBuilder {
+	return new(FieldMaskBuilder).Add(paths...)
+}
+
+// Add appends paths, skipping empty and repeated ones.
+func (b *FieldMaskBuilder) Add(paths ...string) *FieldMaskBuilder {
+	if b.seen == nil {
+		b.seen = make(map[string]struct{}, len(paths))
+	}
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		if _, ok := b.seen[path]; ok {
+			continue
+		}
+		b.seen[path] = struct{}{}
+		b.paths = append(b.paths, path)
+	}
+	return b
+}
+
+// Under appends paths nested under prefix, e.g. Under("transaction",
+// EffectsStatus) adds "transaction.effects.status".
+func (b *FieldMaskBuilder) Under(prefix string, paths ...string) *FieldMaskBuilder {
+	prefix = strings.TrimSuffix(prefix, ".")
+	for _, path := range paths {
+		if path != "" {
+			b.Add(prefix + "." + path)
+		}
+	}
+	return b
+}
+
+// Merge appends the paths of mask, which may be nil.
+func (b *FieldMaskBuilder) Merge(mask *fieldmaskpb.FieldMask) *FieldMaskBuilder {
+	return b.Add(mask.GetPaths()...)
+}
+
+// Paths returns a copy of the paths added so far.
+func (b *FieldMaskBuilder) Paths() []string {
+	return append([]string(nil), b.paths...)
+}
+
+// Build returns a new FieldMask holding the paths added so far. Later changes
+// to the builder do not affect it.
+func (b *FieldMaskBuilder) Build() *fieldmaskpb.FieldMask {
+	return &fieldmaskpb.FieldMask{Paths: b.Paths()}
+}
+
+// MinimalObject returns a read mask for what is needed to use an object as a
+// transaction input: its reference and owner.
+func MinimalObject() *fieldmaskpb.FieldMask {
+	return NewFieldMask(ObjectID, ObjectVersion, ObjectDigest, ObjectOwner).Build()
+}
+
+// FullObject returns a read mask for every field of an object, including its
+// BCS and JSON contents.
+func FullObject() *fieldmaskpb.FieldMask {
+	return NewFieldMask(
+		ObjectID, ObjectVersion, ObjectDigest, ObjectOwner, ObjectType,
+		ObjectHasPublicTransfer, ObjectContents, ObjectJSON, ObjectBCS, ObjectBalance,
+		ObjectPreviousTransaction, ObjectStorageRebate,
+	).Build()
+}
+
+// MinimalTransaction returns a read mask for a transaction's digest, effects
+// and checkpoint, the fields ExecutionResult is built from.
+func MinimalTransaction() *fieldmaskpb.FieldMask {
+	return NewFieldMask(TransactionDigest, TransactionEffects, TransactionCheckpoint).Build()
+}
+
+// FullTransaction returns a read mask for every field of an executed
+// transaction.
+func FullTransaction() *fieldmaskpb.FieldMask {
+	return NewFieldMask(
+		TransactionDigest, TransactionData, TransactionSignatures, TransactionEffects,
+		TransactionEvents, TransactionCheckpoint, TransactionTimestamp,
+		TransactionBalanceChanges, TransactionObjects,
+	).Build()
+}
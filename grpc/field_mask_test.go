@@ -0,0 +1,33 @@
+package grpc
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestFieldMaskBuilder(t *testing.T) {
+	b := NewFieldMask(TransactionDigest, "", TransactionDigest).
+		Under("transaction.", EffectsStatus, EffectsGasUsed).
+		Merge(&fieldmaskpb.FieldMask{Paths: []string{TransactionDigest, TransactionEvents}}).
+		Merge(nil)
+	mask := b.Build()
+	got := strings.Join(mask.GetPaths(), ",")
+	requireEqual(t, got, "digest,transaction.effects.status,transaction.effects.gas_used,events", "paths")
+
+	b.Add(TransactionCheckpoint)
+	requireEqual(t, len(mask.GetPaths()), 4, "built mask is independent of the builder")
+
+	var zero FieldMaskBuilder
+	requireEqual(t, len(zero.Add(ObjectOwner).Build().GetPaths()), 1, "zero value builder")
+}
+
+func TestFieldMaskPresets(t *testing.T) {
+	requireEqual(t, strings.Join(MinimalObject().GetPaths(), ","), "object_id,version,digest,owner", "minimal object")
+
+	full := FullTransaction()
+	requireEqual(t, len(full.GetPaths()), 9, "full transaction paths")
+	full.Paths = full.Paths[:1]
+	requireEqual(t, len(FullTransaction().GetPaths()), 9, "presets return fresh masks")
+}
@@ -182,7 +182,7 @@ func (c *Client) GetCurrentEpoch(ctx context.Context, readMask *fieldmaskpb.Fiel
 
 // ReferenceGasPrice returns the reference gas price from the current epoch.
 func (c *Client) ReferenceGasPrice(ctx context.Context, opts ...grpc.CallOption) (uint64, error) {
-	mask := &fieldmaskpb.FieldMask{Paths: []string{"reference_gas_price"}}
+	mask := NewFieldMask(EpochReferenceGasPrice).Build()
 	epoch, err := c.GetCurrentEpoch(ctx, mask, opts...)
 	if err != nil {
 		return 0, err
@@ -250,6 +250,86 @@ func (c *Client) BatchGetObjects(ctx context.Context, requests []ObjectRequest,
 	return results, nil
 }
 
+// ListOwnedObjects returns every object owned by owner, following page tokens until the listing is exhausted.
+// typeFilter optionally restricts results to a Move type; a type without type parameters (e.g. "0x2::coin::Coin")
+// matches every instantiation. A pageSize of zero uses the server default.
+func (c *Client) ListOwnedObjects(ctx context.Context, owner string, typeFilter string, pageSize uint32, opts ...grpc.CallOption) ([]*v2.Object, error) {
+	if c == nil {
+		return nil, errors.New("nil client")
+	}
+	if ctx == nil {
+		return nil, errors.New("nil context")
+	}
+	if owner == "" {
+		return nil, errors.New("owner address is empty")
+	}
+
+	req := &v2.ListOwnedObjectsRequest{
+		Owner:    utils.Ptr(owner),
+		ReadMask: NewFieldMask(ObjectID, ObjectVersion, ObjectDigest, ObjectType, ObjectOwner).Build(),
+	}
+	if typeFilter != "" {
+		req.ObjectType = utils.Ptr(typeFilter)
+	}
+	if pageSize > 0 {
+		req.PageSize = &pageSize
+	}
+
+	pager, err := c.OwnedObjectsPager(req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return pager.Collect(ctx)
+}
+
+// GetBalance returns owner's balance of a single coin type.
+func (c *Client) GetBalance(ctx context.Context, owner string, coinType string, opts ...grpc.CallOption) (*v2.Balance, error) {
+	if c == nil {
+		return nil, errors.New("nil client")
+	}
+	if ctx == nil {
+		return nil, errors.New("nil context")
+	}
+	if owner == "" {
+		return nil, errors.New("owner address is empty")
+	}
+	if coinType == "" {
+		return nil, errors.New("coin type is empty")
+	}
+
+	resp, err := c.stateClient.GetBalance(ctx, &v2.GetBalanceRequest{
+		Owner:    utils.Ptr(owner),
+		CoinType: utils.Ptr(coinType),
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	balance := resp.GetBalance()
+	if balance == nil {
+		return nil, fmt.Errorf("balance of %q for %q not found", coinType, owner)
+	}
+	return balance, nil
+}
+
+// GetAllBalances returns owner's balance of every coin type it holds, following page tokens until the listing is exhausted.
+func (c *Client) GetAllBalances(ctx context.Context, owner string, opts ...grpc.CallOption) ([]*v2.Balance, error) {
+	if c == nil {
+		return nil, errors.New("nil client")
+	}
+	if ctx == nil {
+		return nil, errors.New("nil context")
+	}
+	if owner == "" {
+		return nil, errors.New("owner address is empty")
+	}
+
+	pager, err := c.BalancesPager(&v2.ListBalancesRequest{Owner: utils.Ptr(owner)}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return pager.Collect(ctx)
+}
+
 func cloneFieldMask(mask *fieldmaskpb.FieldMask) *fieldmaskpb.FieldMask {
 	if mask == nil {
 		return nil
@@ -0,0 +1,85 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/open-move/sui-go-sdk/transaction"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ExecuteTransactionBytesOnce is like ExecuteTransactionBytes but safe to
+// call again after a failure whose outcome is unknown, such as a dropped
+// connection or a timeout. The transaction digest, computed from txBytes,
+// serves as its idempotency key: the ledger is checked for the digest
+// before the transaction is submitted, and if it has already been executed
+// its existing result is returned with AlreadyExecuted set instead.
+//
+// When submission fails with a transport error that leaves the outcome
+// unknown, the ledger is checked once more and the existing result is
+// returned if the transaction landed. A transaction that is executing but
+// not yet indexed may still be submitted twice; the network executes a
+// digest only once, so the second submission has no further effect.
+func (c *Client) ExecuteTransactionBytesOnce(ctx context.Context, txBytes []byte, signatures [][]byte, options *ExecuteOptions) (*ExecutionResult, error) {
+	if c == nil {
+		return nil, errors.New("nil client")
+	}
+	if ctx == nil {
+		return nil, errors.New("nil context")
+	}
+	if len(txBytes) == 0 {
+		return nil, ErrMissingTransaction
+	}
+
+	cfg := options.clone()
+	digest := transaction.TransactionDigest(txBytes).String()
+	existing, err := c.executedTransaction(ctx, digest, cfg)
+	if err != nil || existing != nil {
+		return existing, err
+	}
+
+	result, err := c.ExecuteTransactionBytes(ctx, txBytes, signatures, cfg)
+	if err == nil || !ambiguousExecutionError(err) || ctx.Err() != nil {
+		return result, err
+	}
+	existing, lookupErr := c.executedTransaction(ctx, digest, cfg)
+	if lookupErr != nil {
+		return nil, errors.Join(err, lookupErr)
+	}
+	if existing != nil {
+		return existing, nil
+	}
+	return nil, err
+}
+
+// executedTransaction returns the result of the transaction with the given
+// digest, or nil when the ledger does not know it.
+func (c *Client) executedTransaction(ctx context.Context, digest string, cfg *ExecuteOptions) (*ExecutionResult, error) {
+	tx, err := c.GetTransaction(ctx, digest, &GetTransactionOptions{
+		ReadMask: ensureFieldMaskPaths(cfg.ReadMask, TransactionDigest, TransactionEffects, TransactionCheckpoint),
+	})
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("look up transaction %s: %w", digest, err)
+	}
+	result, err := c.executionResult(ctx, tx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	result.AlreadyExecuted = true
+	return result, nil
+}
+
+// ambiguousExecutionError reports whether a failed submission may still
+// have reached the network.
+func ambiguousExecutionError(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Unknown, codes.Internal, codes.Aborted:
+		return true
+	}
+	return false
+}
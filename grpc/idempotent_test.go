@@ -0,0 +1,154 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	v2 "github.com/open-move/sui-go-sdk/proto/sui/rpc/v2"
+	"github.com/open-move/sui-go-sdk/transaction"
+	"github.com/open-move/sui-go-sdk/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// scriptedExecutionServer answers ExecuteTransaction with err, or with an
+// executed transaction when err is nil.
+type scriptedExecutionServer struct {
+	v2.UnimplementedTransactionExecutionServiceServer
+	err   error
+	calls atomic.Int32
+}
+
+func (s *scriptedExecutionServer) ExecuteTransaction(context.Context, *v2.ExecuteTransactionRequest) (*v2.ExecuteTransactionResponse, error) {
+	s.calls.Add(1)
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &v2.ExecuteTransactionResponse{Transaction: &v2.ExecutedTransaction{
+		Digest:  utils.Ptr(testDigest(1)),
+		Effects: testEffects(),
+	}}, nil
+}
+
+// digestLedgerServer records the digests looked up and answers them from
+// responses in turn, repeating the last one.
+type digestLedgerServer struct {
+	v2.UnimplementedLedgerServiceServer
+	responses []func() (*v2.GetTransactionResponse, error)
+
+	mu      sync.Mutex
+	digests []string
+}
+
+func (s *digestLedgerServer) GetTransaction(_ context.Context, req *v2.GetTransactionRequest) (*v2.GetTransactionResponse, error) {
+	s.mu.Lock()
+	s.digests = append(s.digests, req.GetDigest())
+	n := len(s.digests) - 1
+	s.mu.Unlock()
+	return s.responses[min(n, len(s.responses)-1)]()
+}
+
+func (s *digestLedgerServer) lookups() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.digests...)
+}
+
+func TestExecuteTransactionBytesOnce(t *testing.T) {
+	txBytes := []byte{1, 2, 3}
+	digest := transaction.TransactionDigest(txBytes).String()
+	signature := append([]byte{0x00}, make([]byte, 96)...)
+
+	notFound := func() (*v2.GetTransactionResponse, error) { return nil, status.Error(codes.NotFound, "unknown digest") }
+	found := func() (*v2.GetTransactionResponse, error) {
+		return &v2.GetTransactionResponse{Transaction: &v2.ExecutedTransaction{
+			Digest:     utils.Ptr(digest),
+			Effects:    testEffects(),
+			Checkpoint: utils.Ptr(uint64(7)),
+		}}, nil
+	}
+
+	tests := []struct {
+		name       string
+		ledger     []func() (*v2.GetTransactionResponse, error)
+		executeErr error
+		wantErr    codes.Code
+		executes   int32
+		lookups    int
+		existing   bool
+	}{
+		{name: "already executed", ledger: []func() (*v2.GetTransactionResponse, error){found}, lookups: 1, existing: true},
+		{name: "not yet executed", ledger: []func() (*v2.GetTransactionResponse, error){notFound}, executes: 1, lookups: 1},
+		{
+			name:       "landed despite a dropped connection",
+			ledger:     []func() (*v2.GetTransactionResponse, error){notFound, found},
+			executeErr: status.Error(codes.Unavailable, "connection reset"),
+			executes:   1,
+			lookups:    2,
+			existing:   true,
+		},
+		{
+			name:       "lost with a dropped connection",
+			ledger:     []func() (*v2.GetTransactionResponse, error){notFound},
+			executeErr: status.Error(codes.Unavailable, "connection reset"),
+			wantErr:    codes.Unavailable,
+			executes:   1,
+			lookups:    2,
+		},
+		{
+			name:       "rejected",
+			ledger:     []func() (*v2.GetTransactionResponse, error){notFound},
+			executeErr: status.Error(codes.InvalidArgument, "invalid signature"),
+			wantErr:    codes.InvalidArgument,
+			executes:   1,
+			lookups:    1,
+		},
+		{
+			name: "lookup failed",
+			ledger: []func() (*v2.GetTransactionResponse, error){func() (*v2.GetTransactionResponse, error) {
+				return nil, status.Error(codes.PermissionDenied, "denied")
+			}},
+			wantErr: codes.PermissionDenied,
+			lookups: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lis, err := net.Listen("tcp", "127.0.0.1:0")
+			requireNoError(t, err, "listen")
+			server := grpc.NewServer()
+			execution := &scriptedExecutionServer{err: tt.executeErr}
+			ledger := &digestLedgerServer{responses: tt.ledger}
+			v2.RegisterTransactionExecutionServiceServer(server, execution)
+			v2.RegisterLedgerServiceServer(server, ledger)
+			go server.Serve(lis)
+			defer server.Stop()
+
+			client, err := NewClient(context.Background(), lis.Addr().String())
+			requireNoError(t, err, "new client")
+			defer client.Close()
+
+			result, err := client.ExecuteTransactionBytesOnce(context.Background(), txBytes, [][]byte{signature}, nil)
+			if tt.wantErr != codes.OK {
+				if err == nil || status.Code(err) != tt.wantErr {
+					t.Fatalf("expected %v error, got %v", tt.wantErr, err)
+				}
+			} else {
+				requireNoError(t, err, "execute once")
+				requireEqual(t, result.AlreadyExecuted, tt.existing, "already executed")
+				requireNotNil(t, result.Effects, "effects")
+			}
+			requireEqual(t, execution.calls.Load(), tt.executes, "executions")
+			lookups := ledger.lookups()
+			requireEqual(t, len(lookups), tt.lookups, "lookups")
+			for _, looked := range lookups {
+				requireEqual(t, looked, digest, "looked up digest")
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package grpc
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// tracerName identifies spans emitted by this package.
+const tracerName = "github.com/open-move/sui-go-sdk/grpc"
+
+// Attribute keys recorded on spans and log records. Request and response
+// payloads and metadata are never recorded; only the request size is. The
+// client does not retry RPCs itself, so no retry count is reported.
+const (
+	attrRPCMethod   = "rpc.method"
+	attrRPCCode     = "rpc.grpc.status_code"
+	attrRequestSize = "rpc.request.size"
+	attrLatency     = "sui.latency"
+)
+
+// WithLogger makes the client log one record per RPC with its method, request
+// size, status code and latency. Successful calls are logged at debug level
+// and failures at warn level.
+func WithLogger(logger *slog.Logger) Option {
+	return func(cfg *config) {
+		cfg.logger = logger
+	}
+}
+
+// WithTracer makes the client emit an OpenTelemetry span per RPC.
+func WithTracer(provider trace.TracerProvider) Option {
+	return func(cfg *config) {
+		cfg.tracerProvider = provider
+	}
+}
+
+// observabilityDialOptions returns the interceptors that implement WithLogger
+// and WithTracer, or nil when neither is configured.
+func (cfg *config) observabilityDialOptions() []grpc.DialOption {
+	if cfg.logger == nil && cfg.tracerProvider == nil {
+		return nil
+	}
+
+	o := &observer{logger: cfg.logger}
+	if cfg.tracerProvider != nil {
+		o.tracer = cfg.tracerProvider.Tracer(tracerName)
+	}
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(o.unary),
+		grpc.WithChainStreamInterceptor(o.stream),
+	}
+}
+
+type observer struct {
+	logger *slog.Logger
+	tracer trace.Tracer
+}
+
+func (o *observer) unary(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	ctx, finish := o.start(ctx, method, req)
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	finish(err)
+	return err
+}
+
+// stream instruments the establishment of a stream; the span ends once the
+// stream has been opened, not when it is closed.
+func (o *observer) stream(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	ctx, finish := o.start(ctx, method, nil)
+	stream, err := streamer(ctx, desc, cc, method, opts...)
+	finish(err)
+	return stream, err
+}
+
+func (o *observer) start(ctx context.Context, method string, req any) (context.Context, func(error)) {
+	size := 0
+	if msg, ok := req.(proto.Message); ok {
+		size = proto.Size(msg)
+	}
+
+	var span trace.Span
+	if o.tracer != nil {
+		ctx, span = o.tracer.Start(ctx, method, trace.WithSpanKind(trace.SpanKindClient))
+	}
+	start := time.Now()
+
+	return ctx, func(err error) {
+		latency := time.Since(start)
+		code := status.Code(err)
+
+		if span != nil {
+			span.SetAttributes(
+				attribute.String(attrRPCMethod, method),
+				attribute.Int(attrRPCCode, int(code)),
+				attribute.Int(attrRequestSize, size),
+			)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, code.String())
+			}
+			span.End()
+		}
+
+		if o.logger != nil {
+			level := slog.LevelDebug
+			attrs := []slog.Attr{
+				slog.String(attrRPCMethod, method),
+				slog.String(attrRPCCode, code.String()),
+				slog.Int(attrRequestSize, size),
+				slog.Duration(attrLatency, latency),
+			}
+			if err != nil {
+				level = slog.LevelWarn
+				attrs = append(attrs, slog.String("error", err.Error()))
+			}
+			o.logger.LogAttrs(ctx, level, "grpc call", attrs...)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net"
+	"strings"
+	"testing"
+
+	v2 "github.com/open-move/sui-go-sdk/proto/sui/rpc/v2"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+)
+
+type stubLedgerServer struct {
+	v2.UnimplementedLedgerServiceServer
+}
+
+func (stubLedgerServer) GetServiceInfo(context.Context, *v2.GetServiceInfoRequest) (*v2.GetServiceInfoResponse, error) {
+	chainID := "chain"
+	return &v2.GetServiceInfoResponse{ChainId: &chainID}, nil
+}
+
+func TestClientObservability(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	requireNoError(t, err, "listen")
+	server := grpc.NewServer()
+	v2.RegisterLedgerServiceServer(server, stubLedgerServer{})
+	go server.Serve(lis)
+	defer server.Stop()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	client, err := NewClient(context.Background(), lis.Addr().String(), WithTracer(provider), WithLogger(logger))
+	requireNoError(t, err, "new client")
+	defer client.Close()
+
+	_, err = client.LedgerClient().GetServiceInfo(context.Background(), &v2.GetServiceInfoRequest{})
+	requireNoError(t, err, "get service info")
+	_, err = client.LedgerClient().GetEpoch(context.Background(), &v2.GetEpochRequest{})
+	if err == nil {
+		t.Fatal("expected unimplemented error")
+	}
+
+	spans := recorder.Ended()
+	requireEqual(t, len(spans), 2, "span count")
+	requireEqual(t, spans[0].Name(), "/sui.rpc.v2.LedgerService/GetServiceInfo", "span name")
+	requireEqual(t, spans[0].Status().Code, codes.Unset, "success status")
+	requireEqual(t, spans[1].Status().Code, codes.Error, "failure status")
+
+	output := logs.String()
+	if !strings.Contains(output, "level=DEBUG") || !strings.Contains(output, "level=WARN") {
+		t.Fatalf("expected debug and warn records, got %q", output)
+	}
+	if !strings.Contains(output, "rpc.grpc.status_code=Unimplemented") {
+		t.Fatalf("expected status code in logs, got %q", output)
+	}
+}
@@ -2,7 +2,10 @@ package grpc
 
 import (
 	"crypto/tls"
+	"log/slog"
 
+	"github.com/open-move/sui-go-sdk/ratelimit"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
@@ -15,6 +18,11 @@ type config struct {
 	dialOptions          []grpc.DialOption
 	transportCredentials credentials.TransportCredentials
 	tlsConfig            *tls.Config
+	logger               *slog.Logger
+	tracerProvider       trace.TracerProvider
+	poolSize             int
+	extraEndpoints       []string
+	limiter              ratelimit.Limiter
 }
 
 func defaultConfig() *config {
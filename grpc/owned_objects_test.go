@@ -0,0 +1,87 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	v2 "github.com/open-move/sui-go-sdk/proto/sui/rpc/v2"
+	"github.com/open-move/sui-go-sdk/utils"
+	"google.golang.org/grpc"
+)
+
+type stubStateServer struct {
+	v2.UnimplementedStateServiceServer
+	objectRequests []*v2.ListOwnedObjectsRequest
+}
+
+func (s *stubStateServer) ListOwnedObjects(_ context.Context, req *v2.ListOwnedObjectsRequest) (*v2.ListOwnedObjectsResponse, error) {
+	s.objectRequests = append(s.objectRequests, req)
+	if len(req.GetPageToken()) == 0 {
+		return &v2.ListOwnedObjectsResponse{
+			Objects:       []*v2.Object{{ObjectId: utils.Ptr("0x1")}},
+			NextPageToken: []byte("next"),
+		}, nil
+	}
+	return &v2.ListOwnedObjectsResponse{Objects: []*v2.Object{{ObjectId: utils.Ptr("0x2")}}}, nil
+}
+
+func (s *stubStateServer) ListBalances(_ context.Context, req *v2.ListBalancesRequest) (*v2.ListBalancesResponse, error) {
+	if len(req.GetPageToken()) == 0 {
+		return &v2.ListBalancesResponse{
+			Balances:      []*v2.Balance{{CoinType: utils.Ptr("0x2::sui::SUI"), Balance: utils.Ptr(uint64(10))}},
+			NextPageToken: []byte("next"),
+		}, nil
+	}
+	return &v2.ListBalancesResponse{
+		Balances: []*v2.Balance{{CoinType: utils.Ptr("0xa::usdc::USDC"), Balance: utils.Ptr(uint64(5))}},
+	}, nil
+}
+
+func (s *stubStateServer) GetBalance(_ context.Context, req *v2.GetBalanceRequest) (*v2.GetBalanceResponse, error) {
+	return &v2.GetBalanceResponse{Balance: &v2.Balance{CoinType: req.CoinType, Balance: utils.Ptr(uint64(10))}}, nil
+}
+
+func newStubStateClient(t *testing.T, state *stubStateServer) *Client {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	requireNoError(t, err, "listen")
+	server := grpc.NewServer()
+	v2.RegisterStateServiceServer(server, state)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	client, err := NewClient(context.Background(), lis.Addr().String())
+	requireNoError(t, err, "new client")
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestListOwnedObjects(t *testing.T) {
+	state := &stubStateServer{}
+	client := newStubStateClient(t, state)
+
+	objects, err := client.ListOwnedObjects(context.Background(), "0xabc", "0x2::coin::Coin", 1)
+	requireNoError(t, err, "list owned objects")
+	requireEqual(t, len(objects), 2, "object count")
+	requireEqual(t, objects[1].GetObjectId(), "0x2", "second object")
+
+	requireEqual(t, len(state.objectRequests), 2, "request count")
+	first := state.objectRequests[0]
+	requireEqual(t, first.GetObjectType(), "0x2::coin::Coin", "type filter")
+	requireEqual(t, first.GetPageSize(), uint32(1), "page size")
+	requireEqual(t, string(state.objectRequests[1].GetPageToken()), "next", "page token")
+}
+
+func TestGetAllBalances(t *testing.T) {
+	client := newStubStateClient(t, &stubStateServer{})
+
+	balances, err := client.GetAllBalances(context.Background(), "0xabc")
+	requireNoError(t, err, "get all balances")
+	requireEqual(t, len(balances), 2, "balance count")
+	requireEqual(t, balances[1].GetCoinType(), "0xa::usdc::USDC", "second coin type")
+
+	balance, err := client.GetBalance(context.Background(), "0xabc", "0x2::sui::SUI")
+	requireNoError(t, err, "get balance")
+	requireEqual(t, balance.GetBalance(), uint64(10), "balance")
+}
@@ -0,0 +1,141 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	v2 "github.com/open-move/sui-go-sdk/proto/sui/rpc/v2"
+	"github.com/open-move/sui-go-sdk/transaction"
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+// maxGasPaymentObjects mirrors the protocol limit on gas payment coins.
+const maxGasPaymentObjects = 256
+
+// maxConsolidateCoins bounds the number of coins merged in a single transaction.
+const maxConsolidateCoins = 500
+
+// PaySui appends commands that split each amount off the gas coin and
+// transfer it to its recipient, and returns the gas resolver to build tx
+// with. The resolver selects enough of the sender's SUI coins to cover the
+// amounts plus the gas budget, which Build estimates over the whole
+// transaction when it is unset, so commands added after PaySui are covered
+// too. Gas payment already set on tx is left alone. The transaction sender
+// must be set.
+func (c *Client) PaySui(ctx context.Context, tx *transaction.Transaction, recipients []string, amounts []uint64, opts ...CoinSelectionOption) (transaction.GasResolver, error) {
+	if _, err := payPreconditions(c, ctx, tx); err != nil {
+		return nil, err
+	}
+	if len(recipients) != len(amounts) {
+		return nil, transaction.ErrPayLengthMismatch
+	}
+
+	var total uint64
+	for _, amount := range amounts {
+		if total+amount < total {
+			return nil, errors.New("pay amounts overflow uint64")
+		}
+		total += amount
+	}
+
+	tx.PaySui(recipients, amounts)
+	if err := tx.Err(); err != nil {
+		return nil, err
+	}
+	return &sendGasResolver{Resolver: NewResolver(c), amount: total, options: opts}, nil
+}
+
+// PayAllSui uses the sender's SUI coins (up to the gas payment limit) as gas
+// payment and transfers the merged gas coin to recipient. The transaction sender must be set.
+func (c *Client) PayAllSui(ctx context.Context, tx *transaction.Transaction, recipient string, opts ...CoinSelectionOption) error {
+	owner, err := payPreconditions(c, ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	refs, err := c.ownedCoinRefs(ctx, owner, defaultGasCoinType, maxGasPaymentObjects, opts...)
+	if err != nil {
+		return err
+	}
+
+	tx.SetGasPayment(refs)
+	tx.PayAllSui(recipient)
+	return tx.Err()
+}
+
+// ConsolidateCoins merges the sender's coins of coinType into a single coin.
+// SUI coins are merged through the gas payment and returned to the sender;
+// other coin types are merged with a MergeCoins command. The transaction sender must be set.
+func (c *Client) ConsolidateCoins(ctx context.Context, tx *transaction.Transaction, coinType string, opts ...CoinSelectionOption) error {
+	owner, err := payPreconditions(c, ctx, tx)
+	if err != nil {
+		return err
+	}
+	if coinType == "" {
+		return errors.New("coin type is empty")
+	}
+
+	if isSuiCoinType(coinType) {
+		return c.PayAllSui(ctx, tx, owner, opts...)
+	}
+
+	refs, err := c.ownedCoinRefs(ctx, owner, coinType, maxConsolidateCoins, opts...)
+	if err != nil {
+		return err
+	}
+
+	tx.ConsolidateCoins(refs)
+	return tx.Err()
+}
+
+func (c *Client) ownedCoinRefs(ctx context.Context, owner string, coinType string, limit int, opts ...CoinSelectionOption) ([]types.ObjectRef, error) {
+	coins, err := c.SelectUpToNLargestCoins(ctx, owner, "0x2::coin::Coin<"+coinType+">", limit, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if len(coins) == 0 {
+		return nil, transaction.ErrNoCoins
+	}
+
+	return objectRefsFromObjects(coins)
+}
+
+func payPreconditions(c *Client, ctx context.Context, tx *transaction.Transaction) (string, error) {
+	if c == nil {
+		return "", errors.New("nil client")
+	}
+	if ctx == nil {
+		return "", errors.New("nil context")
+	}
+	if err := tx.Err(); err != nil {
+		return "", err
+	}
+	if !tx.HasSender() {
+		return "", errors.New("transaction sender is required")
+	}
+
+	return tx.Sender(), nil
+}
+
+func objectRefsFromObjects(objects []*v2.Object) ([]types.ObjectRef, error) {
+	refs := make([]types.ObjectRef, len(objects))
+	for i, obj := range objects {
+		ref, err := utils.ParseObjectRef(obj.GetObjectId(), obj.GetVersion(), obj.GetDigest())
+		if err != nil {
+			return nil, err
+		}
+		refs[i] = ref
+	}
+
+	return refs, nil
+}
+
+func isSuiCoinType(coinType string) bool {
+	tag, err := utils.ParseStructTag(coinType)
+	if err != nil {
+		return false
+	}
+
+	return tag.Address == utils.MustParseAddress("0x2") && tag.Module == "sui" && tag.Name == "SUI"
+}
@@ -0,0 +1,79 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	v2 "github.com/open-move/sui-go-sdk/proto/sui/rpc/v2"
+	"github.com/open-move/sui-go-sdk/transaction"
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/utils"
+	"google.golang.org/grpc"
+)
+
+func TestPaySuiCoversBudget(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	requireNoError(t, err, "listen")
+	server := grpc.NewServer()
+	v2.RegisterLedgerServiceServer(server, sendLedgerServer{})
+	v2.RegisterStateServiceServer(server, sendStateServer{})
+	v2.RegisterTransactionExecutionServiceServer(server, &sendExecutionServer{})
+	go server.Serve(lis)
+	defer server.Stop()
+
+	client, err := NewClient(context.Background(), lis.Addr().String())
+	requireNoError(t, err, "new client")
+	defer client.Close()
+
+	build := func(budget uint64, amount uint64, payment ...types.ObjectRef) (transaction.GasData, error) {
+		tx := transaction.New().SetSender("0xa")
+		if budget > 0 {
+			tx.SetGasBudget(budget)
+		}
+		if len(payment) > 0 {
+			tx.SetGasPayment(payment)
+		}
+		gas, err := client.PaySui(context.Background(), tx, []string{"0x5"}, []uint64{amount})
+		if err != nil {
+			return transaction.GasData{}, err
+		}
+		// Commands added after PaySui are built with its gas resolver too.
+		tx.TransferObjects(transaction.TransferObjects{Objects: []transaction.Argument{tx.Gas()}, Address: tx.PureAddress("0x6")})
+		result, err := tx.Build(context.Background(), transaction.BuildOptions{Resolver: NewResolver(client), GasResolver: gas})
+		if err != nil {
+			return transaction.GasData{}, err
+		}
+		data, err := transaction.DecodeTransactionData(result.TransactionBytes)
+		if err != nil {
+			return transaction.GasData{}, err
+		}
+		return data.V1.GasData, nil
+	}
+
+	// 4_000_000 fits in one coin, but not together with a 2_000_000 budget.
+	gas, err := build(2_000_000, 4_000_000)
+	requireNoError(t, err, "pay with budget")
+	requireEqual(t, gas.Budget, uint64(2_000_000), "gas budget")
+	requireEqual(t, len(gas.Payment), 2, "gas payment coins")
+
+	// Without a budget one is estimated from the simulation.
+	gas, err = build(0, 4_000_000)
+	requireNoError(t, err, "pay without budget")
+	requireEqual(t, gas.Budget > 0, true, "estimated budget")
+	requireEqual(t, len(gas.Payment), 2, "gas payment coins")
+
+	// Gas payment the caller set is kept.
+	preset := types.ObjectRef{ObjectID: utils.MustParseAddress("0x99"), Version: 1, Digest: types.Digest(bytes.Repeat([]byte{1}, 32))}
+	gas, err = build(2_000_000, 4_000_000, preset)
+	requireNoError(t, err, "pay with preset gas")
+	requireEqual(t, len(gas.Payment), 1, "preset gas payment coins")
+	requireEqual(t, gas.Payment[0].ObjectID, preset.ObjectID, "preset gas coin")
+
+	// The coins hold 11_000_000: enough for the amount, not for its gas.
+	if _, err := build(2_000_000, 10_000_000); !errors.Is(err, ErrInsufficientBalance) {
+		t.Fatalf("expected ErrInsufficientBalance, got %v", err)
+	}
+}
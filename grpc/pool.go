@@ -0,0 +1,161 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+// WithPoolSize opens size connections to each endpoint and spreads calls
+// across them, for workloads that saturate a single HTTP/2 channel.
+func WithPoolSize(size int) Option {
+	return func(cfg *config) {
+		if size > 0 {
+			cfg.poolSize = size
+		}
+	}
+}
+
+// WithEndpoints adds endpoints that share the client's load with the
+// endpoint passed to NewClient. Calls are distributed round-robin across
+// all of them.
+func WithEndpoints(endpoints ...string) Option {
+	return func(cfg *config) {
+		cfg.extraEndpoints = append(cfg.extraEndpoints, endpoints...)
+	}
+}
+
+// PoolStats reports the state of a client's connections.
+type PoolStats struct {
+	Connections []ConnectionStats
+}
+
+// Ready returns the number of connections that are ready to serve calls.
+func (s PoolStats) Ready() int {
+	ready := 0
+	for _, conn := range s.Connections {
+		if conn.State == connectivity.Ready {
+			ready++
+		}
+	}
+	return ready
+}
+
+// ConnectionStats describes one pooled connection.
+type ConnectionStats struct {
+	Endpoint string
+	State    connectivity.State
+	// Calls counts the unary calls and streams started on the connection.
+	Calls uint64
+	// Failures counts the calls that returned an error.
+	Failures uint64
+}
+
+// PoolStats returns a snapshot of the client's connections for monitoring.
+func (c *Client) PoolStats() PoolStats {
+	if c == nil || c.pool == nil {
+		return PoolStats{}
+	}
+	return c.pool.stats()
+}
+
+// connPool is a grpc.ClientConnInterface that picks a healthy connection
+// round-robin for every call. Connections reconnect on their own; a
+// connection in TRANSIENT_FAILURE is skipped until it recovers.
+type connPool struct {
+	members []*poolMember
+	next    atomic.Uint64
+}
+
+type poolMember struct {
+	endpoint string
+	conn     *grpc.ClientConn
+	calls    atomic.Uint64
+	failures atomic.Uint64
+}
+
+func newConnPool(endpoints []string, size int, dial func(endpoint string) (*grpc.ClientConn, error)) (*connPool, error) {
+	if size < 1 {
+		size = 1
+	}
+	pool := &connPool{}
+	for _, endpoint := range endpoints {
+		for i := 0; i < size; i++ {
+			conn, err := dial(endpoint)
+			if err != nil {
+				pool.close()
+				return nil, err
+			}
+			pool.members = append(pool.members, &poolMember{endpoint: endpoint, conn: conn})
+		}
+	}
+	if len(pool.members) == 0 {
+		return nil, errors.New("no endpoints")
+	}
+	return pool, nil
+}
+
+func (p *connPool) Invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error {
+	member := p.pick()
+	member.calls.Add(1)
+	err := member.conn.Invoke(ctx, method, args, reply, opts...)
+	if err != nil {
+		member.failures.Add(1)
+	}
+	return err
+}
+
+func (p *connPool) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	member := p.pick()
+	member.calls.Add(1)
+	stream, err := member.conn.NewStream(ctx, desc, method, opts...)
+	if err != nil {
+		member.failures.Add(1)
+	}
+	return stream, err
+}
+
+// pick returns the next connection that is not failing, waking idle ones.
+// When every connection is failing it falls back to plain round-robin and
+// lets grpc wait for a reconnect.
+func (p *connPool) pick() *poolMember {
+	n := uint64(len(p.members))
+	start := p.next.Add(1) - 1
+	for i := uint64(0); i < n; i++ {
+		member := p.members[(start+i)%n]
+		switch member.conn.GetState() {
+		case connectivity.TransientFailure, connectivity.Shutdown:
+			continue
+		case connectivity.Idle:
+			member.conn.Connect()
+		}
+		return member
+	}
+	return p.members[start%n]
+}
+
+func (p *connPool) stats() PoolStats {
+	stats := PoolStats{Connections: make([]ConnectionStats, len(p.members))}
+	for i, member := range p.members {
+		stats.Connections[i] = ConnectionStats{
+			Endpoint: member.endpoint,
+			State:    member.conn.GetState(),
+			Calls:    member.calls.Load(),
+			Failures: member.failures.Load(),
+		}
+	}
+	return stats
+}
+
+func (p *connPool) close() error {
+	var errs []error
+	for _, member := range p.members {
+		if err := member.conn.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,34 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	v2 "github.com/open-move/sui-go-sdk/proto/sui/rpc/v2"
+)
+
+func TestConnectionPool(t *testing.T) {
+	_, primaryAddr := startRecordingLedger(t, "primary")
+	_, secondaryAddr := startRecordingLedger(t, "secondary")
+
+	client, err := NewClient(context.Background(), primaryAddr, WithEndpoints(secondaryAddr), WithPoolSize(2))
+	requireNoError(t, err, "new client")
+	defer client.Close()
+
+	seen := make(map[string]int)
+	for i := 0; i < 8; i++ {
+		resp, err := client.LedgerClient().GetServiceInfo(context.Background(), &v2.GetServiceInfoRequest{})
+		requireNoError(t, err, "get service info")
+		seen[resp.GetChainId()]++
+	}
+	requireEqual(t, seen["primary"], 4, "primary calls")
+	requireEqual(t, seen["secondary"], 4, "secondary calls")
+
+	stats := client.PoolStats()
+	requireEqual(t, len(stats.Connections), 4, "connections")
+	requireEqual(t, stats.Ready(), 4, "ready connections")
+	for _, conn := range stats.Connections {
+		requireEqual(t, conn.Calls, uint64(2), "calls on "+conn.Endpoint)
+		requireEqual(t, conn.Failures, uint64(0), "failures on "+conn.Endpoint)
+	}
+}
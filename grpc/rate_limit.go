@@ -0,0 +1,73 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"github.com/open-move/sui-go-sdk/ratelimit"
+)
+
+// attrThrottleWait records time spent waiting for the rate limiter.
+const attrThrottleWait = "sui.throttle_wait"
+
+// WithRateLimit throttles the client to rps calls per second, with bursts of
+// up to burst calls. Every unary call and every stream opened takes a
+// token; pooled connections share the same bucket. Use WithRateLimiter to
+// share one quota between clients.
+func WithRateLimit(rps float64, burst int) Option {
+	return func(cfg *config) {
+		if bucket := ratelimit.NewTokenBucket(rps, burst); bucket != nil {
+			cfg.limiter = bucket
+		}
+	}
+}
+
+// WithRateLimiter throttles the client with l, which may be shared with
+// other clients so that together they respect a provider's global quota.
+func WithRateLimiter(l ratelimit.Limiter) Option {
+	return func(cfg *config) {
+		cfg.limiter = l
+	}
+}
+
+// rateLimitDialOptions returns the interceptors that implement
+// WithRateLimit, or nil when no limiter is configured.
+func (cfg *config) rateLimitDialOptions() []grpc.DialOption {
+	if cfg.limiter == nil {
+		return nil
+	}
+	l := cfg.limiter
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			if err := acquire(ctx, l); err != nil {
+				return err
+			}
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}),
+		grpc.WithChainStreamInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			if err := acquire(ctx, l); err != nil {
+				return nil, err
+			}
+			return streamer(ctx, desc, cc, method, opts...)
+		}),
+	}
+}
+
+// acquire waits on l, recording the wait on the call's span. A wait cut
+// short by ctx fails with the matching Canceled or DeadlineExceeded status.
+func acquire(ctx context.Context, l ratelimit.Limiter) error {
+	start := time.Now()
+	err := l.Wait(ctx)
+	if waited := time.Since(start); waited > time.Millisecond {
+		trace.SpanFromContext(ctx).SetAttributes(attribute.Int64(attrThrottleWait, waited.Milliseconds()))
+	}
+	if err != nil {
+		return status.FromContextError(err).Err()
+	}
+	return nil
+}
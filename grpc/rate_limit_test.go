@@ -0,0 +1,58 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	v2 "github.com/open-move/sui-go-sdk/proto/sui/rpc/v2"
+	"github.com/open-move/sui-go-sdk/ratelimit"
+	"github.com/open-move/sui-go-sdk/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRateLimit(t *testing.T) {
+	ledger := &scriptedLedgerServer{responses: []func() (*v2.GetTransactionResponse, error){
+		func() (*v2.GetTransactionResponse, error) {
+			return &v2.GetTransactionResponse{Transaction: &v2.ExecutedTransaction{Digest: utils.Ptr(testDigest(1))}}, nil
+		},
+	}}
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	requireNoError(t, err, "listen")
+	server := grpc.NewServer()
+	v2.RegisterLedgerServiceServer(server, ledger)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	quota := ratelimit.NewTokenBucket(40, 1)
+	client, err := NewClient(context.Background(), lis.Addr().String(), WithRateLimiter(quota))
+	requireNoError(t, err, "new client")
+	t.Cleanup(func() { client.Close() })
+
+	start := time.Now()
+	for range 3 {
+		_, err := client.LedgerClient().GetTransaction(context.Background(), &v2.GetTransactionRequest{Digest: utils.Ptr(testDigest(1))})
+		requireNoError(t, err, "get transaction")
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Fatalf("calls were not throttled: %v", elapsed)
+	}
+	stats := quota.Stats()
+	requireEqual(t, stats.Acquired, uint64(3), "acquired")
+	requireEqual(t, stats.Throttled, uint64(2), "throttled")
+
+	slow := ratelimit.NewTokenBucket(0.001, 1)
+	slow.TryAcquire()
+	client, err = NewClient(context.Background(), lis.Addr().String(), WithRateLimiter(slow))
+	requireNoError(t, err, "new client")
+	t.Cleanup(func() { client.Close() })
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	calls := ledger.calls.Load()
+	_, err = client.LedgerClient().GetTransaction(ctx, &v2.GetTransactionRequest{Digest: utils.Ptr(testDigest(1))})
+	requireEqual(t, status.Code(err), codes.DeadlineExceeded, "status")
+	requireEqual(t, ledger.calls.Load(), calls, "calls sent")
+}
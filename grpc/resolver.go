@@ -10,7 +10,6 @@ import (
 	"github.com/open-move/sui-go-sdk/transaction"
 	"github.com/open-move/sui-go-sdk/types"
 	"github.com/open-move/sui-go-sdk/utils"
-	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 const (
@@ -81,7 +80,7 @@ func (r *Resolver) ResolveObjects(ctx context.Context, objectIDs []string) ([]tr
 		for i, id := range pending {
 			requests[i] = ObjectRequest{ObjectID: id}
 		}
-		mask := &fieldmaskpb.FieldMask{Paths: []string{"object_id", "version", "digest", "owner"}}
+		mask := MinimalObject()
 		responses, err := r.client.BatchGetObjects(ctx, requests, mask)
 		if err != nil {
 			return nil, err
@@ -197,6 +196,22 @@ func (r *Resolver) ResolveGasPrice(ctx context.Context) (uint64, error) {
 	return r.client.ReferenceGasPrice(ctx)
 }
 
+// ResolveCurrentEpoch returns the current epoch, letting Build reject expired transactions.
+func (r *Resolver) ResolveCurrentEpoch(ctx context.Context) (uint64, error) {
+	if r == nil || r.client == nil {
+		return 0, fmt.Errorf("nil client")
+	}
+	if ctx == nil {
+		return 0, fmt.Errorf("nil context")
+	}
+
+	epoch, err := r.client.GetCurrentEpoch(ctx, NewFieldMask(EpochNumber).Build())
+	if err != nil {
+		return 0, err
+	}
+	return epoch.GetEpoch(), nil
+}
+
 // ResolveGasBudget estimates a gas budget using simulation.
 func (r *Resolver) ResolveGasBudget(ctx context.Context, input transaction.GasBudgetInput) (uint64, error) {
 	if r == nil || r.client == nil {
@@ -214,10 +229,7 @@ func (r *Resolver) ResolveGasBudget(ctx context.Context, input transaction.GasBu
 	checks := v2.SimulateTransactionRequest_ENABLED
 	doSelect := true
 	resp, err := r.client.SimulateTransaction(ctx, tx, &SimulateTransactionOptions{
-		ReadMask: &fieldmaskpb.FieldMask{Paths: []string{
-			"transaction.effects.status",
-			"transaction.effects.gas_used",
-		}},
+		ReadMask:       new(FieldMaskBuilder).Under("transaction", EffectsStatus, EffectsGasUsed).Build(),
 		Checks:         &checks,
 		DoGasSelection: &doSelect,
 	})
@@ -291,16 +303,7 @@ func (r *Resolver) ResolveGasPayment(ctx context.Context, owner types.Address, b
 		return nil, err
 	}
 
-	refs := make([]types.ObjectRef, len(coins))
-	for i, coin := range coins {
-		ref, err := utils.ParseObjectRef(coin.GetObjectId(), coin.GetVersion(), coin.GetDigest())
-		if err != nil {
-			return nil, err
-		}
-		refs[i] = ref
-	}
-
-	return refs, nil
+	return objectRefsFromObjects(coins)
 }
 
 func addGasBudgetBuffer(base uint64) uint64 {
@@ -723,8 +726,54 @@ func convertMoveParameter(param *v2.OpenSignature) transaction.MoveParameter {
 		typeName = param.Body.GetTypeName()
 	}
 
+	var body *transaction.MoveTypeSignature
+	if param != nil && param.Body != nil {
+		converted := convertSignatureBody(param.Body)
+		body = &converted
+	}
+
 	return transaction.MoveParameter{
 		Reference: ref,
 		TypeName:  typeName,
+		Body:      body,
+	}
+}
+
+func convertSignatureBody(body *v2.OpenSignatureBody) transaction.MoveTypeSignature {
+	out := transaction.MoveTypeSignature{
+		TypeName:      body.GetTypeName(),
+		TypeParameter: body.GetTypeParameter(),
+	}
+
+	switch body.GetType() {
+	case v2.OpenSignatureBody_ADDRESS:
+		out.Kind = transaction.MoveTypeAddress
+	case v2.OpenSignatureBody_BOOL:
+		out.Kind = transaction.MoveTypeBool
+	case v2.OpenSignatureBody_U8:
+		out.Kind = transaction.MoveTypeU8
+	case v2.OpenSignatureBody_U16:
+		out.Kind = transaction.MoveTypeU16
+	case v2.OpenSignatureBody_U32:
+		out.Kind = transaction.MoveTypeU32
+	case v2.OpenSignatureBody_U64:
+		out.Kind = transaction.MoveTypeU64
+	case v2.OpenSignatureBody_U128:
+		out.Kind = transaction.MoveTypeU128
+	case v2.OpenSignatureBody_U256:
+		out.Kind = transaction.MoveTypeU256
+	case v2.OpenSignatureBody_VECTOR:
+		out.Kind = transaction.MoveTypeVector
+	case v2.OpenSignatureBody_DATATYPE:
+		out.Kind = transaction.MoveTypeDatatype
+	case v2.OpenSignatureBody_TYPE_PARAMETER:
+		out.Kind = transaction.MoveTypeParameter
+	default:
+		out.Kind = transaction.MoveTypeUnknown
+	}
+
+	for _, arg := range body.GetTypeParameterInstantiation() {
+		out.TypeArguments = append(out.TypeArguments, convertSignatureBody(arg))
 	}
+	return out
 }
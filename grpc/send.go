@@ -0,0 +1,157 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/open-move/sui-go-sdk/transaction"
+	"github.com/open-move/sui-go-sdk/types"
+)
+
+// SendSui transfers amount MIST from the signer to recipient. It selects
+// enough SUI coins to cover the amount and the gas budget, signs the
+// transaction, executes it, and waits for checkpoint inclusion.
+func (c *Client) SendSui(ctx context.Context, signer transaction.TransactionSigner, recipient string, amount uint64, options *ExecuteOptions) (*ExecutionResult, error) {
+	tx, err := sendPreconditions(c, ctx, signer)
+	if err != nil {
+		return nil, err
+	}
+
+	tx.PaySui([]string{recipient}, []uint64{amount})
+	return c.sendTransaction(ctx, tx, signer, &sendGasResolver{Resolver: NewResolver(c), amount: amount}, options)
+}
+
+// SendObject transfers the object with objectID from the signer to
+// recipient, then waits for checkpoint inclusion. The object is never
+// selected as gas payment.
+func (c *Client) SendObject(ctx context.Context, signer transaction.TransactionSigner, recipient string, objectID string, options *ExecuteOptions) (*ExecutionResult, error) {
+	tx, err := sendPreconditions(c, ctx, signer)
+	if err != nil {
+		return nil, err
+	}
+	if objectID == "" {
+		return nil, errors.New("object id is empty")
+	}
+
+	tx.TransferObjects(transaction.TransferObjects{
+		Objects: []transaction.Argument{tx.Object(objectID)},
+		Address: tx.PureAddress(recipient),
+	})
+	return c.sendTransaction(ctx, tx, signer, &sendGasResolver{Resolver: NewResolver(c), exclude: []string{objectID}}, options)
+}
+
+// SendCoins transfers amount of coinType from the signer to recipient. SUI
+// is sent as in SendSui; other coin types are merged into one coin, split,
+// and transferred, with gas paid from the signer's SUI coins.
+func (c *Client) SendCoins(ctx context.Context, signer transaction.TransactionSigner, recipient string, coinType string, amount uint64, options *ExecuteOptions) (*ExecutionResult, error) {
+	if coinType == "" {
+		return nil, errors.New("coin type is empty")
+	}
+	if isSuiCoinType(coinType) {
+		return c.SendSui(ctx, signer, recipient, amount, options)
+	}
+
+	tx, err := sendPreconditions(c, ctx, signer)
+	if err != nil {
+		return nil, err
+	}
+	coins, err := c.SelectCoins(ctx, tx.Sender(), coinType, amount)
+	if err != nil {
+		return nil, err
+	}
+	refs, err := objectRefsFromObjects(coins)
+	if err != nil {
+		return nil, err
+	}
+
+	coin := tx.ConsolidateCoins(refs)
+	split := tx.SplitCoins(transaction.SplitCoins{Coin: coin, Amounts: []transaction.Argument{tx.PureU64(amount)}})
+	if err := tx.Err(); err != nil {
+		return nil, err
+	}
+	tx.TransferObjects(transaction.TransferObjects{
+		Objects: split,
+		Address: tx.PureAddress(recipient),
+	})
+	return c.sendTransaction(ctx, tx, signer, NewResolver(c), options)
+}
+
+// sendTransaction builds tx with gas resolved by gas, signs it and executes
+// it, always waiting for checkpoint inclusion.
+func (c *Client) sendTransaction(ctx context.Context, tx *transaction.Transaction, signer transaction.TransactionSigner, gas transaction.GasResolver, options *ExecuteOptions) (*ExecutionResult, error) {
+	if err := tx.Err(); err != nil {
+		return nil, err
+	}
+
+	result, err := tx.Build(ctx, transaction.BuildOptions{Resolver: NewResolver(c), GasResolver: gas})
+	if err != nil {
+		return nil, err
+	}
+	if len(result.TransactionBytes) == 0 {
+		return nil, errors.New("built transaction missing data")
+	}
+
+	signature, err := signer.SignTransaction(result.TransactionBytes)
+	if err != nil {
+		return nil, fmt.Errorf("sign transaction: %w", err)
+	}
+
+	cfg := options.clone()
+	cfg.WaitForCheckpoint = true
+	return c.ExecuteTransactionBytes(ctx, result.TransactionBytes, [][]byte{signature}, cfg)
+}
+
+func sendPreconditions(c *Client, ctx context.Context, signer transaction.TransactionSigner) (*transaction.Transaction, error) {
+	if c == nil {
+		return nil, errors.New("nil client")
+	}
+	if ctx == nil {
+		return nil, errors.New("nil context")
+	}
+	if signer == nil {
+		return nil, errors.New("nil signer")
+	}
+
+	sender, err := signer.SuiAddress()
+	if err != nil {
+		return nil, err
+	}
+	tx := transaction.New()
+	tx.SetSender(sender)
+	return tx, nil
+}
+
+// sendGasResolver selects gas payment that also covers amount, which the
+// transaction splits off the gas coin, and never selects excluded objects.
+// options further restrict the selection.
+type sendGasResolver struct {
+	*Resolver
+	amount  uint64
+	exclude []string
+	options []CoinSelectionOption
+}
+
+func (r *sendGasResolver) ResolveGasPayment(ctx context.Context, owner types.Address, budget uint64) ([]types.ObjectRef, error) {
+	if budget == 0 {
+		return nil, fmt.Errorf("gas budget must be greater than zero")
+	}
+	if r.amount > math.MaxUint64-budget {
+		return nil, errors.New("amount plus gas budget overflows uint64")
+	}
+
+	options := append([]CoinSelectionOption{WithCoinExclusions(r.exclude...)}, r.options...)
+	coins, err := r.client.SelectCoins(ctx, owner.String(), defaultGasCoinType, budget+r.amount, options...)
+	if err != nil {
+		return nil, err
+	}
+	refs, err := objectRefsFromObjects(coins)
+	if err != nil {
+		return nil, err
+	}
+	if len(refs) > maxGasPaymentObjects {
+		return nil, fmt.Errorf("%w: amount needs more than %d gas coins", ErrInsufficientBalance, maxGasPaymentObjects)
+	}
+	return refs, nil
+}
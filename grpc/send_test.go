@@ -0,0 +1,100 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/cryptography/ed25519"
+	v2 "github.com/open-move/sui-go-sdk/proto/sui/rpc/v2"
+	"github.com/open-move/sui-go-sdk/transaction"
+	"github.com/open-move/sui-go-sdk/utils"
+	"google.golang.org/grpc"
+)
+
+type sendLedgerServer struct {
+	v2.UnimplementedLedgerServiceServer
+}
+
+func (sendLedgerServer) GetEpoch(context.Context, *v2.GetEpochRequest) (*v2.GetEpochResponse, error) {
+	return &v2.GetEpochResponse{Epoch: &v2.Epoch{Epoch: utils.Ptr(uint64(7)), ReferenceGasPrice: utils.Ptr(uint64(1000))}}, nil
+}
+
+type sendStateServer struct {
+	v2.UnimplementedStateServiceServer
+}
+
+func (sendStateServer) ListOwnedObjects(context.Context, *v2.ListOwnedObjectsRequest) (*v2.ListOwnedObjectsResponse, error) {
+	coin := func(id string, balance uint64) *v2.Object {
+		return &v2.Object{
+			ObjectId: utils.Ptr(id),
+			Version:  utils.Ptr(uint64(3)),
+			Digest:   utils.Ptr(testDigest(3)),
+			Balance:  utils.Ptr(balance),
+		}
+	}
+	return &v2.ListOwnedObjectsResponse{Objects: []*v2.Object{
+		coin("0x21", 1_000_000),
+		coin("0x22", 5_000_000),
+		coin("0x23", 5_000_000),
+	}}, nil
+}
+
+type sendExecutionServer struct {
+	v2.UnimplementedTransactionExecutionServiceServer
+	executed *v2.ExecuteTransactionRequest
+}
+
+func (*sendExecutionServer) SimulateTransaction(context.Context, *v2.SimulateTransactionRequest) (*v2.SimulateTransactionResponse, error) {
+	return &v2.SimulateTransactionResponse{Transaction: &v2.ExecutedTransaction{Effects: &v2.TransactionEffects{
+		Status:  &v2.ExecutionStatus{Success: utils.Ptr(true)},
+		GasUsed: &v2.GasCostSummary{ComputationCost: utils.Ptr(uint64(1_000_000))},
+	}}}, nil
+}
+
+func (s *sendExecutionServer) ExecuteTransaction(_ context.Context, req *v2.ExecuteTransactionRequest) (*v2.ExecuteTransactionResponse, error) {
+	s.executed = req
+	return &v2.ExecuteTransactionResponse{Transaction: &v2.ExecutedTransaction{
+		Digest:     utils.Ptr(testDigest(1)),
+		Effects:    testEffects(),
+		Checkpoint: utils.Ptr(uint64(42)),
+	}}, nil
+}
+
+func TestSendSui(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	requireNoError(t, err, "listen")
+	server := grpc.NewServer()
+	execution := &sendExecutionServer{}
+	v2.RegisterLedgerServiceServer(server, sendLedgerServer{})
+	v2.RegisterStateServiceServer(server, sendStateServer{})
+	v2.RegisterTransactionExecutionServiceServer(server, execution)
+	go server.Serve(lis)
+	defer server.Stop()
+
+	client, err := NewClient(context.Background(), lis.Addr().String())
+	requireNoError(t, err, "new client")
+	defer client.Close()
+
+	signer, err := ed25519.Generate()
+	requireNoError(t, err, "generate keypair")
+
+	result, err := client.SendSui(context.Background(), signer, "0x5", 4_000_000, nil)
+	requireNoError(t, err, "send sui")
+	requireEqual(t, result.Digest, testDigest(1), "digest")
+	requireNotNil(t, result.Checkpoint, "checkpoint")
+	requireEqual(t, *result.Checkpoint, uint64(42), "checkpoint")
+	requireNotNil(t, execution.executed, "executed request")
+	requireEqual(t, len(execution.executed.GetSignatures()), 1, "signatures")
+
+	data, err := transaction.DecodeTransactionData(execution.executed.GetTransaction().GetBcs().GetValue())
+	requireNoError(t, err, "decode transaction")
+	gas := data.V1.GasData
+	requireEqual(t, gas.Price, uint64(1000), "gas price")
+	// 4_000_000 plus a ~1_100_000 budget needs the first two coins.
+	requireEqual(t, len(gas.Payment), 2, "gas payment coins")
+
+	sender, err := signer.SuiAddress()
+	requireNoError(t, err, "sender")
+	requireEqual(t, data.V1.Sender, utils.MustParseAddress(sender), "sender")
+}
@@ -3,14 +3,22 @@ package grpc
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	v2 "github.com/open-move/sui-go-sdk/proto/sui/rpc/v2"
 	"github.com/open-move/sui-go-sdk/transaction"
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/utils"
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
+// defaultCheckpointPollInterval is how often execution polls for
+// checkpoint inclusion when ExecuteOptions.PollInterval is unset.
+const defaultCheckpointPollInterval = 500 * time.Millisecond
+
 var (
 	// ErrMissingTransaction indicates the request lacked the required transaction payload.
 	ErrMissingTransaction = errors.New("execute transaction request missing transaction")
@@ -20,9 +28,34 @@ var (
 	ErrResponseMissingDigest = errors.New("execute transaction response missing digest")
 )
 
-// ExecuteOptions configures ExecuteTransaction semantics.
+// ExecuteOptions configures every Execute* method of the client.
 type ExecuteOptions struct {
 	ExecuteCallOptions []grpc.CallOption
+	// ReadMask selects additional fields of the executed transaction. The
+	// digest, effects status and checkpoint are always requested.
+	ReadMask *fieldmaskpb.FieldMask
+	// WaitForCheckpoint makes execution wait until the transaction is
+	// included in a checkpoint, so that reads issued afterwards observe its
+	// effects.
+	WaitForCheckpoint bool
+	// PollInterval is how often checkpoint inclusion is polled. Defaults to
+	// 500ms.
+	PollInterval time.Duration
+}
+
+// ExecutionResult is the outcome of ExecuteTransactionBytes.
+type ExecutionResult struct {
+	Digest string
+	// Effects are the parsed effects of the transaction.
+	Effects *types.TransactionEffects
+	// Checkpoint is the checkpoint that includes the transaction, when known.
+	Checkpoint *uint64
+	// Transaction is the raw response, including any fields requested via
+	// ExecuteOptions.ReadMask.
+	Transaction *v2.ExecutedTransaction
+	// AlreadyExecuted is set by ExecuteTransactionBytesOnce when the
+	// transaction was found on chain and not submitted again.
+	AlreadyExecuted bool
 }
 
 // ExecuteRequest describes a signed transaction to submit via ExecuteSignedTransaction.
@@ -38,6 +71,9 @@ func (o *ExecuteOptions) clone() *ExecuteOptions {
 	}
 	return &ExecuteOptions{
 		ExecuteCallOptions: append([]grpc.CallOption(nil), o.ExecuteCallOptions...),
+		ReadMask:           cloneFieldMask(o.ReadMask),
+		WaitForCheckpoint:  o.WaitForCheckpoint,
+		PollInterval:       o.PollInterval,
 	}
 }
 
@@ -116,7 +152,36 @@ func (c *Client) SignAndExecuteTransaction(ctx context.Context, tx *transaction.
 	}, options)
 }
 
-// ExecuteTransaction submits an ExecuteTransactionRequest and returns its immediate response.
+// ExecuteWithAutoRefresh refreshes the versions of tx's owned object inputs
+// and gas coins, then signs and submits it like SignAndExecuteTransaction.
+// If the fullnode still rejects an object version as stale, the references
+// are refreshed and the transaction is re-signed and submitted once more.
+func (c *Client) ExecuteWithAutoRefresh(ctx context.Context, tx *transaction.Transaction, signer transaction.TransactionSigner, options *ExecuteOptions) (*v2.ExecutedTransaction, error) {
+	if c == nil {
+		return nil, errors.New("nil client")
+	}
+	if tx == nil {
+		return nil, errors.New("nil transaction")
+	}
+
+	// A new resolver per attempt, since resolvers cache object versions.
+	if err := tx.RefreshObjectRefs(ctx, NewResolver(c)); err != nil {
+		return nil, err
+	}
+	executed, err := c.SignAndExecuteTransaction(ctx, tx, signer, options)
+	if err == nil || !transaction.IsStaleObjectError(err) {
+		return executed, err
+	}
+
+	if refreshErr := tx.RefreshObjectRefs(ctx, NewResolver(c)); refreshErr != nil {
+		return nil, errors.Join(err, refreshErr)
+	}
+	return c.SignAndExecuteTransaction(ctx, tx, signer, options)
+}
+
+// ExecuteTransaction submits an ExecuteTransactionRequest and returns its
+// response. When options.WaitForCheckpoint is set it blocks until the
+// transaction is checkpointed or ctx is done.
 func (c *Client) ExecuteTransaction(ctx context.Context, request *v2.ExecuteTransactionRequest, options *ExecuteOptions) (*v2.ExecuteTransactionResponse, error) {
 	if c == nil {
 		return nil, errors.New("nil client")
@@ -136,10 +201,111 @@ func (c *Client) ExecuteTransaction(ctx context.Context, request *v2.ExecuteTran
 	if execReq.GetTransaction() == nil {
 		return nil, ErrMissingTransaction
 	}
-	execReq.ReadMask = ensureFieldMaskPaths(execReq.GetReadMask(), "digest", "effects.status", "checkpoint")
 
 	cfg := options.clone()
-	return c.transactionExecutionClient.ExecuteTransaction(ctx, execReq, cfg.ExecuteCallOptions...)
+	paths := append([]string{TransactionDigest, EffectsStatus, TransactionCheckpoint}, cfg.ReadMask.GetPaths()...)
+	execReq.ReadMask = ensureFieldMaskPaths(execReq.GetReadMask(), paths...)
+
+	resp, err := c.transactionExecutionClient.ExecuteTransaction(ctx, execReq, cfg.ExecuteCallOptions...)
+	if err != nil {
+		return nil, err
+	}
+	if tx := resp.GetTransaction(); cfg.WaitForCheckpoint && tx != nil && tx.Checkpoint == nil && tx.GetDigest() != "" {
+		checkpoint, err := c.waitForCheckpoint(ctx, tx.GetDigest(), cfg.PollInterval)
+		if err != nil {
+			return nil, err
+		}
+		tx.Checkpoint = &checkpoint
+	}
+	return resp, nil
+}
+
+// ExecuteTransactionBytes submits BCS-encoded TransactionData with its
+// serialized signatures and returns the digest and parsed effects. When
+// options.WaitForCheckpoint is set it blocks until the transaction is
+// checkpointed or ctx is done.
+func (c *Client) ExecuteTransactionBytes(ctx context.Context, txBytes []byte, signatures [][]byte, options *ExecuteOptions) (*ExecutionResult, error) {
+	if c == nil {
+		return nil, errors.New("nil client")
+	}
+	if ctx == nil {
+		return nil, errors.New("nil context")
+	}
+	if len(txBytes) == 0 {
+		return nil, ErrMissingTransaction
+	}
+	if len(signatures) == 0 {
+		return nil, errors.New("no signatures provided")
+	}
+
+	userSigs := make([]*v2.UserSignature, len(signatures))
+	for i, sig := range signatures {
+		parsed, err := transaction.UserSignatureFromSerialized(sig)
+		if err != nil {
+			return nil, fmt.Errorf("signature %d: %w", i, err)
+		}
+		userSigs[i] = parsed
+	}
+
+	cfg := options.clone()
+	req := &v2.ExecuteTransactionRequest{
+		Transaction: &v2.Transaction{Bcs: &v2.Bcs{Name: utils.Ptr("TransactionData"), Value: txBytes}},
+		Signatures:  userSigs,
+		ReadMask:    ensureFieldMaskPaths(cfg.ReadMask, TransactionDigest, TransactionEffects, TransactionCheckpoint),
+	}
+	resp, err := c.ExecuteTransaction(ctx, req, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return c.executionResult(ctx, resp.GetTransaction(), cfg)
+}
+
+// executionResult parses an executed transaction into an ExecutionResult,
+// waiting for its checkpoint when cfg asks for it.
+func (c *Client) executionResult(ctx context.Context, tx *v2.ExecutedTransaction, cfg *ExecuteOptions) (*ExecutionResult, error) {
+	if tx == nil {
+		return nil, ErrResponseMissingTransaction
+	}
+	if tx.GetDigest() == "" {
+		return nil, ErrResponseMissingDigest
+	}
+
+	effects, err := EffectsFromProto(tx.GetEffects())
+	if err != nil {
+		return nil, fmt.Errorf("parse effects: %w", err)
+	}
+	result := &ExecutionResult{
+		Digest:      tx.GetDigest(),
+		Effects:     effects,
+		Checkpoint:  tx.Checkpoint,
+		Transaction: tx,
+	}
+	if cfg.WaitForCheckpoint && result.Checkpoint == nil {
+		checkpoint, err := c.waitForCheckpoint(ctx, result.Digest, cfg.PollInterval)
+		if err != nil {
+			return nil, err
+		}
+		result.Checkpoint = &checkpoint
+		tx.Checkpoint = &checkpoint
+	}
+	return result, nil
+}
+
+// waitForCheckpoint polls at a fixed interval until the transaction reports
+// a checkpoint.
+func (c *Client) waitForCheckpoint(ctx context.Context, digest string, interval time.Duration) (uint64, error) {
+	if interval <= 0 {
+		interval = defaultCheckpointPollInterval
+	}
+	tx, err := c.WaitForTransaction(ctx, digest, &WaitOptions{
+		Finality:        WaitForCheckpointInclusion,
+		InitialInterval: interval,
+		MaxInterval:     interval,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("wait for checkpoint: %w", err)
+	}
+	return tx.GetCheckpoint(), nil
 }
 
 // SimulateTransactionOptions customises behaviour of SimulateTransaction.
@@ -0,0 +1,179 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	v2 "github.com/open-move/sui-go-sdk/proto/sui/rpc/v2"
+	"github.com/open-move/sui-go-sdk/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// Default backoff of WaitForTransaction.
+const (
+	DefaultWaitInitialInterval = 200 * time.Millisecond
+	DefaultWaitMaxInterval     = 5 * time.Second
+	defaultWaitMultiplier      = 2
+)
+
+// Finality selects how far a transaction must progress before
+// WaitForTransaction returns.
+type Finality int
+
+const (
+	// WaitForExecution returns once the fullnode knows the transaction and
+	// its effects.
+	WaitForExecution Finality = iota
+	// WaitForCheckpointInclusion returns once the transaction is included in
+	// a checkpoint, after which every read observes its effects.
+	WaitForCheckpointInclusion
+)
+
+func (f Finality) String() string {
+	switch f {
+	case WaitForExecution:
+		return "execution"
+	case WaitForCheckpointInclusion:
+		return "checkpoint inclusion"
+	default:
+		return fmt.Sprintf("Finality(%d)", int(f))
+	}
+}
+
+// WaitOptions configure WaitForTransaction.
+type WaitOptions struct {
+	Finality Finality
+	// ReadMask selects extra fields of the returned transaction. The digest,
+	// effects and checkpoint are always requested.
+	ReadMask *fieldmaskpb.FieldMask
+	// InitialInterval is the delay before the first retry; it doubles after
+	// every attempt up to MaxInterval. Default 200ms and 5s.
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	// MaxAttempts bounds the number of queries; zero polls until Timeout or
+	// the context ends.
+	MaxAttempts int
+	// Timeout bounds the whole wait when positive.
+	Timeout time.Duration
+}
+
+// NotFoundAfterRetriesError reports a transaction that did not reach the
+// requested finality before the attempts, timeout or context ran out.
+type NotFoundAfterRetriesError struct {
+	Digest   string
+	Finality Finality
+	Attempts int
+	Elapsed  time.Duration
+	// Found reports that the transaction was executed but not yet
+	// checkpointed.
+	Found bool
+	// Err is the context error or the last lookup error, if any.
+	Err error
+}
+
+func (e *NotFoundAfterRetriesError) Error() string {
+	msg := fmt.Sprintf("transaction %s did not reach %s after %d attempts in %s", e.Digest, e.Finality, e.Attempts, e.Elapsed.Round(time.Millisecond))
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *NotFoundAfterRetriesError) Unwrap() error {
+	return e.Err
+}
+
+// WaitForTransaction polls for a transaction with bounded exponential
+// backoff until it reaches the requested finality. NotFound and Unavailable
+// responses are retried, since a fullnode may not have indexed a freshly
+// submitted transaction yet; other errors are returned immediately. When
+// the wait gives up it returns a *NotFoundAfterRetriesError, which wraps
+// the context error when ctx ended or Timeout elapsed.
+func (c *Client) WaitForTransaction(ctx context.Context, digest string, options *WaitOptions) (*v2.ExecutedTransaction, error) {
+	if c == nil {
+		return nil, errors.New("nil client")
+	}
+	if ctx == nil {
+		return nil, errors.New("nil context")
+	}
+	if digest == "" {
+		return nil, errors.New("transaction digest is empty")
+	}
+	opts := WaitOptions{}
+	if options != nil {
+		opts = *options
+	}
+	interval := opts.InitialInterval
+	if interval <= 0 {
+		interval = DefaultWaitInitialInterval
+	}
+	maxInterval := opts.MaxInterval
+	if maxInterval <= 0 {
+		maxInterval = DefaultWaitMaxInterval
+	}
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	req := &v2.GetTransactionRequest{
+		Digest:   utils.Ptr(digest),
+		ReadMask: ensureFieldMaskPaths(opts.ReadMask, TransactionDigest, TransactionEffects, TransactionCheckpoint),
+	}
+	start := time.Now()
+	giveUp := &NotFoundAfterRetriesError{Digest: digest, Finality: opts.Finality}
+	for {
+		giveUp.Attempts++
+		resp, err := c.ledgerClient.GetTransaction(ctx, req)
+		tx := resp.GetTransaction()
+		switch {
+		case err == nil && tx != nil:
+			giveUp.Found, giveUp.Err = true, nil
+			if opts.Finality != WaitForCheckpointInclusion || tx.Checkpoint != nil {
+				return tx, nil
+			}
+		case err != nil && (ctx.Err() != nil || waitExpired(ctx, err)):
+			// The lookup failed because the wait ran out; report that below.
+		case err != nil && !retryableWaitError(err):
+			return nil, fmt.Errorf("wait for transaction %s: %w", digest, err)
+		case err != nil:
+			giveUp.Err = err
+		}
+
+		if opts.MaxAttempts > 0 && giveUp.Attempts >= opts.MaxAttempts {
+			giveUp.Elapsed = time.Since(start)
+			return nil, giveUp
+		}
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			giveUp.Elapsed = time.Since(start)
+			giveUp.Err = ctx.Err()
+			return nil, giveUp
+		case <-timer.C:
+		}
+		interval = min(interval*defaultWaitMultiplier, maxInterval)
+	}
+}
+
+// waitExpired reports whether err is the deadline of ctx passing during a
+// lookup. gRPC can report it a moment before ctx.Done is closed.
+func waitExpired(ctx context.Context, err error) bool {
+	deadline, ok := ctx.Deadline()
+	return ok && status.Code(err) == codes.DeadlineExceeded && !time.Now().Before(deadline)
+}
+
+func retryableWaitError(err error) bool {
+	switch status.Code(err) {
+	case codes.NotFound, codes.Unavailable:
+		return true
+	default:
+		return false
+	}
+}
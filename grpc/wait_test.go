@@ -0,0 +1,96 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	v2 "github.com/open-move/sui-go-sdk/proto/sui/rpc/v2"
+	"github.com/open-move/sui-go-sdk/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// scriptedLedgerServer answers GetTransaction with its responses in turn,
+// repeating the last one.
+type scriptedLedgerServer struct {
+	v2.UnimplementedLedgerServiceServer
+	responses []func() (*v2.GetTransactionResponse, error)
+	calls     atomic.Int32
+}
+
+func (s *scriptedLedgerServer) GetTransaction(context.Context, *v2.GetTransactionRequest) (*v2.GetTransactionResponse, error) {
+	n := int(s.calls.Add(1)) - 1
+	return s.responses[min(n, len(s.responses)-1)]()
+}
+
+func newWaitTestClient(t *testing.T, ledger *scriptedLedgerServer) *Client {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	requireNoError(t, err, "listen")
+	server := grpc.NewServer()
+	v2.RegisterLedgerServiceServer(server, ledger)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	client, err := NewClient(context.Background(), lis.Addr().String())
+	requireNoError(t, err, "new client")
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestWaitForTransactionFinality(t *testing.T) {
+	notFound := func() (*v2.GetTransactionResponse, error) { return nil, status.Error(codes.NotFound, "not indexed") }
+	executed := func() (*v2.GetTransactionResponse, error) {
+		return &v2.GetTransactionResponse{Transaction: &v2.ExecutedTransaction{Digest: utils.Ptr(testDigest(1))}}, nil
+	}
+	checkpointed := func() (*v2.GetTransactionResponse, error) {
+		return &v2.GetTransactionResponse{Transaction: &v2.ExecutedTransaction{Digest: utils.Ptr(testDigest(1)), Checkpoint: utils.Ptr(uint64(9))}}, nil
+	}
+	opts := func(finality Finality) *WaitOptions {
+		return &WaitOptions{Finality: finality, InitialInterval: time.Millisecond, MaxInterval: 2 * time.Millisecond}
+	}
+
+	ledger := &scriptedLedgerServer{responses: []func() (*v2.GetTransactionResponse, error){notFound, notFound, executed, checkpointed}}
+	client := newWaitTestClient(t, ledger)
+	tx, err := client.WaitForTransaction(context.Background(), testDigest(1), opts(WaitForExecution))
+	requireNoError(t, err, "wait for execution")
+	requireEqual(t, tx.GetDigest(), testDigest(1), "digest")
+	requireEqual(t, ledger.calls.Load(), int32(3), "execution polls")
+
+	ledger.calls.Store(0)
+	tx, err = client.WaitForTransaction(context.Background(), testDigest(1), opts(WaitForCheckpointInclusion))
+	requireNoError(t, err, "wait for checkpoint")
+	requireEqual(t, tx.GetCheckpoint(), uint64(9), "checkpoint")
+	requireEqual(t, ledger.calls.Load(), int32(4), "checkpoint polls")
+}
+
+func TestWaitForTransactionGivesUp(t *testing.T) {
+	ledger := &scriptedLedgerServer{responses: []func() (*v2.GetTransactionResponse, error){
+		func() (*v2.GetTransactionResponse, error) { return nil, status.Error(codes.NotFound, "not indexed") },
+	}}
+	client := newWaitTestClient(t, ledger)
+
+	_, err := client.WaitForTransaction(context.Background(), testDigest(1), &WaitOptions{InitialInterval: time.Millisecond, MaxAttempts: 3})
+	var notFound *NotFoundAfterRetriesError
+	if !errors.As(err, &notFound) || notFound.Attempts != 3 || status.Code(notFound.Err) != codes.NotFound {
+		t.Fatalf("expected NotFoundAfterRetriesError after 3 attempts, got %v", err)
+	}
+
+	_, err = client.WaitForTransaction(context.Background(), testDigest(1), &WaitOptions{InitialInterval: time.Millisecond, Timeout: 20 * time.Millisecond})
+	if !errors.As(err, &notFound) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected a timeout, got %v", err)
+	}
+
+	ledger.responses[0] = func() (*v2.GetTransactionResponse, error) {
+		return nil, status.Error(codes.InvalidArgument, "bad digest")
+	}
+	_, err = client.WaitForTransaction(context.Background(), testDigest(1), &WaitOptions{InitialInterval: time.Millisecond})
+	if err == nil || errors.As(err, &notFound) || status.Code(errors.Unwrap(err)) != codes.InvalidArgument {
+		t.Fatalf("expected the lookup error to be returned, got %v", err)
+	}
+}
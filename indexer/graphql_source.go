@@ -0,0 +1,293 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/open-move/sui-go-sdk/graphql"
+	"github.com/open-move/sui-go-sdk/types"
+)
+
+const graphqlTransactionPageSize = 50
+
+// Selections of the events and object changes connections of transaction
+// effects. Both are paginated: a transaction can have more of either than
+// fit in one page.
+const (
+	graphqlEventSelection = `
+		pageInfo { hasNextPage endCursor }
+		nodes {
+			sequenceNumber
+			transactionModule { name package { address } }
+			sender { address }
+			contents { type { repr } bcs json }
+		}
+	`
+	graphqlObjectChangeSelection = `
+		pageInfo { hasNextPage endCursor }
+		nodes {
+			address
+			idCreated
+			idDeleted
+			inputState { version }
+			outputState { version asMoveObject { contents { type { repr } } } }
+		}
+	`
+)
+
+// GraphQLSource reads checkpoints by polling the GraphQL API.
+type GraphQLSource struct {
+	client *graphql.Client
+}
+
+var _ Source = (*GraphQLSource)(nil)
+
+// NewGraphQLSource returns a source backed by client.
+func NewGraphQLSource(client *graphql.Client) *GraphQLSource {
+	return &GraphQLSource{client: client}
+}
+
+// LatestCheckpoint returns the sequence number of the latest indexed checkpoint.
+func (s *GraphQLSource) LatestCheckpoint(ctx context.Context) (uint64, error) {
+	if s == nil || s.client == nil {
+		return 0, errors.New("nil client")
+	}
+
+	query := `query LatestCheckpoint { checkpoint { sequenceNumber } }`
+
+	var result struct {
+		Checkpoint *struct {
+			SequenceNumber graphql.UInt53 `json:"sequenceNumber"`
+		} `json:"checkpoint"`
+	}
+	if err := s.client.Execute(ctx, query, nil, &result); err != nil {
+		return 0, err
+	}
+	if result.Checkpoint == nil {
+		return 0, errors.New("latest checkpoint missing from response")
+	}
+	return uint64(result.Checkpoint.SequenceNumber), nil
+}
+
+// Checkpoint fetches a checkpoint and pages through all of its transactions.
+func (s *GraphQLSource) Checkpoint(ctx context.Context, sequence uint64) (*Checkpoint, error) {
+	if s == nil || s.client == nil {
+		return nil, errors.New("nil client")
+	}
+
+	query := `
+		query IndexCheckpoint($seq: UInt53!, $filter: TransactionFilter, $first: Int, $after: String) {
+			checkpoint(sequenceNumber: $seq) {
+				sequenceNumber
+				digest
+				timestamp
+			}
+			transactions(filter: $filter, first: $first, after: $after) {
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+				nodes {
+					digest
+					sender { address }
+					effects {
+						status
+						events { ` + graphqlEventSelection + ` }
+						objectChanges { ` + graphqlObjectChangeSelection + ` }
+					}
+				}
+			}
+		}
+	`
+
+	seq := graphql.UInt53(sequence)
+	vars := map[string]any{
+		"seq":    seq,
+		"filter": graphql.TransactionFilter{AtCheckpoint: &seq},
+		"first":  graphqlTransactionPageSize,
+	}
+
+	var out *Checkpoint
+	for {
+		var result struct {
+			Checkpoint   *graphql.Checkpoint                      `json:"checkpoint"`
+			Transactions *graphql.Connection[graphql.Transaction] `json:"transactions"`
+		}
+		if err := s.client.Execute(ctx, query, vars, &result); err != nil {
+			return nil, err
+		}
+		if result.Checkpoint == nil {
+			return nil, fmt.Errorf("checkpoint %d not found", sequence)
+		}
+
+		if out == nil {
+			out = &Checkpoint{
+				SequenceNumber: uint64(result.Checkpoint.SequenceNumber),
+				Digest:         result.Checkpoint.Digest.String(),
+			}
+			if result.Checkpoint.Timestamp != nil {
+				if ts, err := time.Parse(time.RFC3339Nano, string(*result.Checkpoint.Timestamp)); err == nil {
+					out.Timestamp = ts
+				}
+			}
+		}
+
+		if result.Transactions == nil {
+			break
+		}
+		for _, tx := range result.Transactions.Nodes {
+			if err := s.completeEffects(ctx, &tx); err != nil {
+				return nil, err
+			}
+			converted, err := convertGraphQLTransaction(out.SequenceNumber, tx)
+			if err != nil {
+				return nil, err
+			}
+			out.Transactions = append(out.Transactions, converted)
+		}
+
+		page := result.Transactions.PageInfo
+		if !page.HasNextPage || page.EndCursor == nil {
+			break
+		}
+		vars["after"] = *page.EndCursor
+	}
+
+	return out, nil
+}
+
+// completeEffects fetches the events and object changes of tx that did not
+// fit in the first page.
+func (s *GraphQLSource) completeEffects(ctx context.Context, tx *graphql.Transaction) error {
+	effects := tx.Effects
+	if effects == nil {
+		return nil
+	}
+	if events := effects.Events; events != nil {
+		for events.PageInfo.HasNextPage {
+			page, err := s.effectsPage(ctx, tx.Digest, "events", graphqlEventSelection, events.PageInfo.EndCursor)
+			if err != nil {
+				return err
+			}
+			if page.Events == nil {
+				return fmt.Errorf("transaction %s: events missing from response", tx.Digest)
+			}
+			events.Nodes = append(events.Nodes, page.Events.Nodes...)
+			events.PageInfo = page.Events.PageInfo
+		}
+	}
+	if changes := effects.ObjectChanges; changes != nil {
+		for changes.PageInfo.HasNextPage {
+			page, err := s.effectsPage(ctx, tx.Digest, "objectChanges", graphqlObjectChangeSelection, changes.PageInfo.EndCursor)
+			if err != nil {
+				return err
+			}
+			if page.ObjectChanges == nil {
+				return fmt.Errorf("transaction %s: object changes missing from response", tx.Digest)
+			}
+			changes.Nodes = append(changes.Nodes, page.ObjectChanges.Nodes...)
+			changes.PageInfo = page.ObjectChanges.PageInfo
+		}
+	}
+	return nil
+}
+
+// effectsPage fetches the page after cursor of one connection of a
+// transaction's effects.
+func (s *GraphQLSource) effectsPage(ctx context.Context, digest types.Digest, field, selection string, after *string) (*graphql.TransactionEffects, error) {
+	if after == nil {
+		return nil, fmt.Errorf("transaction %s: %s has a next page but no cursor", digest, field)
+	}
+	query := `
+		query IndexTransactionEffects($digest: String!, $after: String) {
+			transaction(digest: $digest) {
+				effects {
+					` + field + `(after: $after) { ` + selection + ` }
+				}
+			}
+		}
+	`
+
+	var result struct {
+		Transaction *struct {
+			Effects *graphql.TransactionEffects `json:"effects"`
+		} `json:"transaction"`
+	}
+	if err := s.client.Execute(ctx, query, map[string]any{"digest": digest.String(), "after": *after}, &result); err != nil {
+		return nil, err
+	}
+	if result.Transaction == nil || result.Transaction.Effects == nil {
+		return nil, fmt.Errorf("transaction %s not found", digest)
+	}
+	return result.Transaction.Effects, nil
+}
+
+func convertGraphQLTransaction(checkpoint uint64, tx graphql.Transaction) (*Transaction, error) {
+	out := &Transaction{
+		Digest:     tx.Digest.String(),
+		Checkpoint: checkpoint,
+	}
+	if tx.Sender != nil {
+		out.Sender = tx.Sender.Address.String()
+	}
+
+	effects := tx.Effects
+	if effects == nil {
+		return out, nil
+	}
+	out.Success = effects.Status == graphql.ExecutionStatusSuccess
+
+	if effects.Events != nil {
+		for i, ev := range effects.Events.Nodes {
+			if ev.SequenceNumber == nil {
+				return nil, fmt.Errorf("transaction %s: event %d has no sequence number", out.Digest, i)
+			}
+			event := &Event{
+				TransactionDigest: out.Digest,
+				Checkpoint:        checkpoint,
+				Sequence:          uint64(*ev.SequenceNumber),
+			}
+			if ev.TransactionModule != nil {
+				event.Module = ev.TransactionModule.Name
+				if ev.TransactionModule.Package != nil {
+					event.PackageID = ev.TransactionModule.Package.Address.String()
+				}
+			}
+			if ev.Sender != nil {
+				event.Sender = ev.Sender.Address.String()
+			}
+			if ev.Contents != nil {
+				event.Type = ev.Contents.Type.Repr
+				event.Bcs = ev.Contents.Bcs
+				event.JSON = ev.Contents.Json
+			}
+			out.Events = append(out.Events, event)
+		}
+	}
+
+	if effects.ObjectChanges != nil {
+		for _, change := range effects.ObjectChanges.Nodes {
+			converted := &ObjectChange{
+				TransactionDigest: out.Digest,
+				Checkpoint:        checkpoint,
+				ObjectID:          change.Address.String(),
+				Created:           change.IDCreated != nil && *change.IDCreated,
+				Deleted:           change.IDDeleted != nil && *change.IDDeleted,
+			}
+			if change.InputState != nil {
+				converted.InputVersion = uint64(change.InputState.Version)
+			}
+			if change.OutputState != nil {
+				converted.OutputVersion = uint64(change.OutputState.Version)
+				if obj := change.OutputState.AsMoveObject; obj != nil && obj.Contents != nil {
+					converted.ObjectType = obj.Contents.Type.Repr
+				}
+			}
+			out.ObjectChanges = append(out.ObjectChanges, converted)
+		}
+	}
+
+	return out, nil
+}
@@ -0,0 +1,83 @@
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/graphql"
+)
+
+func TestGraphQLSourcePaginatesEffects(t *testing.T) {
+	const digest = "11111111111111111111111111111111"
+	event := func(seq int) string {
+		return `{"sequenceNumber":` + strconv.Itoa(seq) + `,"contents":{"type":{"repr":"0x2::example::Event"},"json":{}}}`
+	}
+	change := func(id string) string {
+		return `{"address":"` + id + `","idCreated":true,"outputState":{"version":5}}`
+	}
+
+	var effectsQueries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query     string         `json:"query"`
+			Variables map[string]any `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(req.Query, "query IndexCheckpoint"):
+			w.Write([]byte(`{"data":{
+				"checkpoint":{"sequenceNumber":7,"digest":"` + digest + `"},
+				"transactions":{"pageInfo":{"hasNextPage":false},"nodes":[{"digest":"` + digest + `","effects":{"status":"SUCCESS",
+					"events":{"pageInfo":{"hasNextPage":true,"endCursor":"e1"},"nodes":[` + event(0) + `,` + event(1) + `]},
+					"objectChanges":{"pageInfo":{"hasNextPage":true,"endCursor":"o1"},"nodes":[` + change("0x1") + `]}
+				}}]}
+			}}`))
+		case strings.Contains(req.Query, "query IndexTransactionEffects"):
+			after := req.Variables["after"].(string)
+			effectsQueries = append(effectsQueries, after)
+			switch {
+			case strings.Contains(req.Query, "events(after"):
+				if after == "e1" {
+					w.Write([]byte(`{"data":{"transaction":{"effects":{"events":{"pageInfo":{"hasNextPage":true,"endCursor":"e2"},"nodes":[` + event(2) + `]}}}}}`))
+				} else {
+					w.Write([]byte(`{"data":{"transaction":{"effects":{"events":{"pageInfo":{"hasNextPage":false},"nodes":[` + event(3) + `]}}}}}`))
+				}
+			case strings.Contains(req.Query, "objectChanges(after"):
+				w.Write([]byte(`{"data":{"transaction":{"effects":{"objectChanges":{"pageInfo":{"hasNextPage":false},"nodes":[` + change("0x2") + `]}}}}}`))
+			}
+		default:
+			t.Errorf("unexpected query %s", req.Query)
+		}
+	}))
+	defer server.Close()
+
+	source := NewGraphQLSource(graphql.NewClient(graphql.WithEndpoint(server.URL), graphql.WithRetries(0)))
+	cp, err := source.Checkpoint(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("checkpoint: %v", err)
+	}
+	if len(cp.Transactions) != 1 {
+		t.Fatalf("transactions = %d, want 1", len(cp.Transactions))
+	}
+	tx := cp.Transactions[0]
+	if len(tx.Events) != 4 {
+		t.Fatalf("events = %d, want 4", len(tx.Events))
+	}
+	for i, ev := range tx.Events {
+		if ev.Sequence != uint64(i) {
+			t.Errorf("event %d sequence = %d", i, ev.Sequence)
+		}
+	}
+	if len(tx.ObjectChanges) != 2 || !tx.ObjectChanges[1].Created {
+		t.Fatalf("object changes = %+v", tx.ObjectChanges)
+	}
+	if strings.Join(effectsQueries, ",") != "e1,e2,o1" {
+		t.Errorf("effects pages = %v", effectsQueries)
+	}
+}
@@ -0,0 +1,167 @@
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/open-move/sui-go-sdk/grpc"
+	v2 "github.com/open-move/sui-go-sdk/proto/sui/rpc/v2"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+var checkpointReadPaths = []string{
+	"sequence_number",
+	"digest",
+	"summary.timestamp",
+	"transactions.digest",
+	"transactions.transaction.sender",
+	"transactions.effects.status",
+	"transactions.effects.changed_objects",
+	"transactions.events.events",
+}
+
+// GRPCSource reads checkpoints from a fullnode over gRPC. It supports both
+// fetching by sequence number and the checkpoint subscription stream.
+type GRPCSource struct {
+	client *grpc.Client
+}
+
+var (
+	_ Source     = (*GRPCSource)(nil)
+	_ Subscriber = (*GRPCSource)(nil)
+)
+
+// NewGRPCSource returns a source backed by client.
+func NewGRPCSource(client *grpc.Client) *GRPCSource {
+	return &GRPCSource{client: client}
+}
+
+// LatestCheckpoint returns the sequence number of the latest executed checkpoint.
+func (s *GRPCSource) LatestCheckpoint(ctx context.Context) (uint64, error) {
+	if s == nil || s.client == nil {
+		return 0, errors.New("nil client")
+	}
+
+	resp, err := s.client.LedgerClient().GetCheckpoint(ctx, &v2.GetCheckpointRequest{
+		ReadMask: &fieldmaskpb.FieldMask{Paths: []string{"sequence_number"}},
+	})
+	if err != nil {
+		return 0, err
+	}
+	if resp.GetCheckpoint() == nil {
+		return 0, errors.New("latest checkpoint missing from response")
+	}
+	return resp.GetCheckpoint().GetSequenceNumber(), nil
+}
+
+// Checkpoint fetches a single checkpoint with its transactions, events and object changes.
+func (s *GRPCSource) Checkpoint(ctx context.Context, sequence uint64) (*Checkpoint, error) {
+	if s == nil || s.client == nil {
+		return nil, errors.New("nil client")
+	}
+
+	cp, err := s.client.GetCheckpointBySequence(ctx, sequence, &fieldmaskpb.FieldMask{Paths: checkpointReadPaths})
+	if err != nil {
+		return nil, err
+	}
+	return convertProtoCheckpoint(cp), nil
+}
+
+// Subscribe opens the fullnode checkpoint subscription.
+func (s *GRPCSource) Subscribe(ctx context.Context) (Stream, error) {
+	if s == nil || s.client == nil {
+		return nil, errors.New("nil client")
+	}
+
+	paths := make([]string, len(checkpointReadPaths))
+	for i, path := range checkpointReadPaths {
+		paths[i] = "checkpoint." + path
+	}
+	stream, err := s.client.SubscriptionClient().SubscribeCheckpoints(ctx, &v2.SubscribeCheckpointsRequest{
+		ReadMask: &fieldmaskpb.FieldMask{Paths: append(paths, "cursor")},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &grpcStream{stream: stream}, nil
+}
+
+type grpcStream struct {
+	stream interface {
+		Recv() (*v2.SubscribeCheckpointsResponse, error)
+	}
+}
+
+func (s *grpcStream) Recv() (*Checkpoint, error) {
+	resp, err := s.stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	if resp.GetCheckpoint() == nil {
+		return nil, fmt.Errorf("subscription response for cursor %d missing checkpoint", resp.GetCursor())
+	}
+
+	cp := convertProtoCheckpoint(resp.GetCheckpoint())
+	if resp.Cursor != nil {
+		cp.SequenceNumber = resp.GetCursor()
+	}
+	return cp, nil
+}
+
+func convertProtoCheckpoint(cp *v2.Checkpoint) *Checkpoint {
+	out := &Checkpoint{
+		SequenceNumber: cp.GetSequenceNumber(),
+		Digest:         cp.GetDigest(),
+	}
+	if ts := cp.GetSummary().GetTimestamp(); ts != nil {
+		out.Timestamp = ts.AsTime()
+	}
+
+	for _, tx := range cp.GetTransactions() {
+		converted := &Transaction{
+			Digest:     tx.GetDigest(),
+			Checkpoint: out.SequenceNumber,
+			Sender:     tx.GetTransaction().GetSender(),
+			Success:    tx.GetEffects().GetStatus().GetSuccess(),
+		}
+
+		for i, ev := range tx.GetEvents().GetEvents() {
+			event := &Event{
+				TransactionDigest: converted.Digest,
+				Checkpoint:        out.SequenceNumber,
+				Sequence:          uint64(i),
+				PackageID:         ev.GetPackageId(),
+				Module:            ev.GetModule(),
+				Sender:            ev.GetSender(),
+				Type:              ev.GetEventType(),
+				Bcs:               ev.GetContents().GetValue(),
+			}
+			if ev.GetJson() != nil {
+				if raw, err := protojson.Marshal(ev.GetJson()); err == nil {
+					event.JSON = json.RawMessage(raw)
+				}
+			}
+			converted.Events = append(converted.Events, event)
+		}
+
+		for _, change := range tx.GetEffects().GetChangedObjects() {
+			converted.ObjectChanges = append(converted.ObjectChanges, &ObjectChange{
+				TransactionDigest: converted.Digest,
+				Checkpoint:        out.SequenceNumber,
+				ObjectID:          change.GetObjectId(),
+				ObjectType:        change.GetObjectType(),
+				InputVersion:      change.GetInputVersion(),
+				OutputVersion:     change.GetOutputVersion(),
+				Created:           change.GetIdOperation() == v2.ChangedObject_CREATED,
+				Deleted:           change.GetIdOperation() == v2.ChangedObject_DELETED,
+			})
+		}
+
+		out.Transactions = append(out.Transactions, converted)
+	}
+
+	return out
+}
@@ -0,0 +1,99 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ProgressStore records the last fully processed checkpoint. Load reports
+// ok=false when nothing has been processed yet.
+type ProgressStore interface {
+	Load(ctx context.Context) (sequence uint64, ok bool, err error)
+	Save(ctx context.Context, sequence uint64) error
+}
+
+// MemoryProgressStore keeps progress in memory.
+type MemoryProgressStore struct {
+	mu       sync.Mutex
+	sequence uint64
+	ok       bool
+}
+
+// NewMemoryProgressStore returns an empty in-memory progress store.
+func NewMemoryProgressStore() *MemoryProgressStore {
+	return &MemoryProgressStore{}
+}
+
+// Load returns the last saved checkpoint.
+func (s *MemoryProgressStore) Load(_ context.Context) (uint64, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sequence, s.ok, nil
+}
+
+// Save records the last processed checkpoint.
+func (s *MemoryProgressStore) Save(_ context.Context, sequence uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sequence, s.ok = sequence, true
+	return nil
+}
+
+// FileProgressStore persists progress as a decimal sequence number in a file.
+type FileProgressStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileProgressStore returns a progress store backed by the file at path.
+func NewFileProgressStore(path string) *FileProgressStore {
+	return &FileProgressStore{path: path}
+}
+
+// Load reads the last processed checkpoint from disk.
+func (s *FileProgressStore) Load(_ context.Context) (uint64, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, false, nil
+		}
+		return 0, false, fmt.Errorf("read progress: %w", err)
+	}
+
+	raw := strings.TrimSpace(string(data))
+	if raw == "" {
+		return 0, false, nil
+	}
+	sequence, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("parse progress: %w", err)
+	}
+	return sequence, true, nil
+}
+
+// Save atomically replaces the stored checkpoint.
+func (s *FileProgressStore) Save(_ context.Context, sequence uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("create progress directory: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(strconv.FormatUint(sequence, 10)), 0o644); err != nil {
+		return fmt.Errorf("write progress: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("replace progress: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package indexer
+
+import "context"
+
+// Source fetches checkpoints by sequence number. It is used for backfill and,
+// when the source cannot stream, for polling the chain tip.
+type Source interface {
+	LatestCheckpoint(ctx context.Context) (uint64, error)
+	Checkpoint(ctx context.Context, sequence uint64) (*Checkpoint, error)
+}
+
+// Subscriber is implemented by sources that can push new checkpoints as they
+// are produced. The Worker fills any gap between its progress and the stream
+// using Source.Checkpoint.
+type Subscriber interface {
+	Subscribe(ctx context.Context) (Stream, error)
+}
+
+// Stream yields checkpoints from a subscription in increasing order.
+type Stream interface {
+	Recv() (*Checkpoint, error)
+}
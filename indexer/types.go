@@ -0,0 +1,53 @@
+// Package indexer streams checkpoints in order and fans their contents out to
+// user-registered handlers. It works against either the gRPC or the GraphQL API
+// and records progress so indexing resumes where it stopped.
+package indexer
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Checkpoint is the source-independent view of a checkpoint handed to handlers.
+type Checkpoint struct {
+	SequenceNumber uint64
+	Digest         string
+	Timestamp      time.Time
+	Transactions   []*Transaction
+}
+
+// Transaction is a transaction executed in a checkpoint.
+type Transaction struct {
+	Digest        string
+	Checkpoint    uint64
+	Sender        string
+	Success       bool
+	Events        []*Event
+	ObjectChanges []*ObjectChange
+}
+
+// Event is an event emitted by a transaction. Sequence is the event's index
+// within its transaction.
+type Event struct {
+	TransactionDigest string
+	Checkpoint        uint64
+	Sequence          uint64
+	PackageID         string
+	Module            string
+	Sender            string
+	Type              string
+	Bcs               []byte
+	JSON              json.RawMessage
+}
+
+// ObjectChange describes how a transaction changed an object.
+type ObjectChange struct {
+	TransactionDigest string
+	Checkpoint        uint64
+	ObjectID          string
+	ObjectType        string
+	InputVersion      uint64
+	OutputVersion     uint64
+	Created           bool
+	Deleted           bool
+}
@@ -0,0 +1,322 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const defaultPollInterval = time.Second
+
+// CheckpointHandler is invoked once per checkpoint, after its transaction, event and object change handlers.
+type CheckpointHandler func(ctx context.Context, checkpoint *Checkpoint) error
+
+// TransactionHandler is invoked for every transaction in a checkpoint.
+type TransactionHandler func(ctx context.Context, tx *Transaction) error
+
+// EventHandler is invoked for every event in a checkpoint.
+type EventHandler func(ctx context.Context, event *Event) error
+
+// ObjectChangeHandler is invoked for every object change in a checkpoint.
+type ObjectChangeHandler func(ctx context.Context, change *ObjectChange) error
+
+// Option customises a Worker.
+type Option func(*Worker)
+
+// WithStartCheckpoint backfills from sequence when no progress has been recorded.
+// Without it a fresh worker starts at the current chain tip.
+func WithStartCheckpoint(sequence uint64) Option {
+	return func(w *Worker) {
+		w.start = &sequence
+	}
+}
+
+// WithEndCheckpoint stops Run after sequence has been processed, which is useful for bounded backfills.
+func WithEndCheckpoint(sequence uint64) Option {
+	return func(w *Worker) {
+		w.end = &sequence
+	}
+}
+
+// WithProgressStore sets where the last processed checkpoint is recorded.
+func WithProgressStore(store ProgressStore) Option {
+	return func(w *Worker) {
+		if store != nil {
+			w.progress = store
+		}
+	}
+}
+
+// WithPollInterval sets how long the worker waits at the chain tip before polling again.
+func WithPollInterval(interval time.Duration) Option {
+	return func(w *Worker) {
+		if interval > 0 {
+			w.interval = interval
+		}
+	}
+}
+
+// WithSubscription toggles use of the source's streaming subscription when it has one.
+func WithSubscription(enabled bool) Option {
+	return func(w *Worker) {
+		w.subscribe = enabled
+	}
+}
+
+// Worker processes checkpoints strictly in order. Progress is saved only after
+// every handler for a checkpoint succeeds, so a failed checkpoint is retried
+// from the start on the next Run.
+type Worker struct {
+	source    Source
+	progress  ProgressStore
+	start     *uint64
+	end       *uint64
+	interval  time.Duration
+	subscribe bool
+
+	checkpointHandlers   []CheckpointHandler
+	transactionHandlers  []TransactionHandler
+	eventHandlers        []EventHandler
+	objectChangeHandlers []ObjectChangeHandler
+
+	next uint64
+}
+
+// NewWorker creates a worker reading from source.
+func NewWorker(source Source, opts ...Option) (*Worker, error) {
+	if source == nil {
+		return nil, errors.New("nil source")
+	}
+
+	w := &Worker{
+		source:    source,
+		progress:  NewMemoryProgressStore(),
+		interval:  defaultPollInterval,
+		subscribe: true,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(w)
+		}
+	}
+	return w, nil
+}
+
+// OnCheckpoint registers a checkpoint handler.
+func (w *Worker) OnCheckpoint(handler CheckpointHandler) *Worker {
+	if handler != nil {
+		w.checkpointHandlers = append(w.checkpointHandlers, handler)
+	}
+	return w
+}
+
+// OnTransaction registers a transaction handler.
+func (w *Worker) OnTransaction(handler TransactionHandler) *Worker {
+	if handler != nil {
+		w.transactionHandlers = append(w.transactionHandlers, handler)
+	}
+	return w
+}
+
+// OnEvent registers an event handler.
+func (w *Worker) OnEvent(handler EventHandler) *Worker {
+	if handler != nil {
+		w.eventHandlers = append(w.eventHandlers, handler)
+	}
+	return w
+}
+
+// OnObjectChange registers an object change handler.
+func (w *Worker) OnObjectChange(handler ObjectChangeHandler) *Worker {
+	if handler != nil {
+		w.objectChangeHandlers = append(w.objectChangeHandlers, handler)
+	}
+	return w
+}
+
+// Run processes checkpoints until ctx is cancelled, a handler fails, or the
+// end checkpoint is reached. It streams from the source when possible and
+// falls back to polling, backfilling any gap from recorded progress.
+func (w *Worker) Run(ctx context.Context) error {
+	if ctx == nil {
+		return errors.New("nil context")
+	}
+
+	next, err := w.resume(ctx)
+	if err != nil {
+		return err
+	}
+	w.next = next
+
+	subscriber, canStream := w.source.(Subscriber)
+	for {
+		if w.done() {
+			return nil
+		}
+
+		if canStream && w.subscribe {
+			if err := w.stream(ctx, subscriber); err != nil {
+				var handlerErr *HandlerError
+				if errors.As(err, &handlerErr) || ctx.Err() != nil {
+					return err
+				}
+			}
+			if w.done() {
+				return nil
+			}
+		}
+
+		if err := w.catchUp(ctx); err != nil {
+			return err
+		}
+		if w.done() {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(w.interval):
+		}
+	}
+}
+
+// HandlerError wraps a handler failure with the checkpoint being processed.
+type HandlerError struct {
+	Checkpoint uint64
+	Err        error
+}
+
+// Error implements the error interface.
+func (e *HandlerError) Error() string {
+	return fmt.Sprintf("indexer: checkpoint %d: %v", e.Checkpoint, e.Err)
+}
+
+// Unwrap returns the handler error.
+func (e *HandlerError) Unwrap() error {
+	return e.Err
+}
+
+func (w *Worker) resume(ctx context.Context) (uint64, error) {
+	last, ok, err := w.progress.Load(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("indexer: load progress: %w", err)
+	}
+	if ok {
+		return last + 1, nil
+	}
+	if w.start != nil {
+		return *w.start, nil
+	}
+
+	latest, err := w.source.LatestCheckpoint(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("indexer: latest checkpoint: %w", err)
+	}
+	return latest, nil
+}
+
+func (w *Worker) done() bool {
+	return w.end != nil && w.next > *w.end
+}
+
+func (w *Worker) catchUp(ctx context.Context) error {
+	latest, err := w.source.LatestCheckpoint(ctx)
+	if err != nil {
+		return fmt.Errorf("indexer: latest checkpoint: %w", err)
+	}
+
+	for w.next <= latest && !w.done() {
+		if err := w.fetchAndProcess(ctx, w.next); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *Worker) stream(ctx context.Context, subscriber Subscriber) error {
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := subscriber.Subscribe(streamCtx)
+	if err != nil {
+		return err
+	}
+
+	for !w.done() {
+		cp, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if cp.SequenceNumber < w.next {
+			continue
+		}
+		for w.next < cp.SequenceNumber && !w.done() {
+			if err := w.fetchAndProcess(ctx, w.next); err != nil {
+				return err
+			}
+		}
+		if w.done() {
+			return nil
+		}
+		if err := w.process(ctx, cp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *Worker) fetchAndProcess(ctx context.Context, sequence uint64) error {
+	cp, err := w.source.Checkpoint(ctx, sequence)
+	if err != nil {
+		return fmt.Errorf("indexer: fetch checkpoint %d: %w", sequence, err)
+	}
+	return w.process(ctx, cp)
+}
+
+func (w *Worker) process(ctx context.Context, cp *Checkpoint) error {
+	if cp == nil {
+		return fmt.Errorf("indexer: checkpoint %d missing", w.next)
+	}
+	if cp.SequenceNumber != w.next {
+		return fmt.Errorf("indexer: expected checkpoint %d, got %d", w.next, cp.SequenceNumber)
+	}
+
+	fail := func(err error) error {
+		return &HandlerError{Checkpoint: cp.SequenceNumber, Err: err}
+	}
+
+	for _, tx := range cp.Transactions {
+		for _, handler := range w.transactionHandlers {
+			if err := handler(ctx, tx); err != nil {
+				return fail(err)
+			}
+		}
+		for _, event := range tx.Events {
+			for _, handler := range w.eventHandlers {
+				if err := handler(ctx, event); err != nil {
+					return fail(err)
+				}
+			}
+		}
+		for _, change := range tx.ObjectChanges {
+			for _, handler := range w.objectChangeHandlers {
+				if err := handler(ctx, change); err != nil {
+					return fail(err)
+				}
+			}
+		}
+	}
+	for _, handler := range w.checkpointHandlers {
+		if err := handler(ctx, cp); err != nil {
+			return fail(err)
+		}
+	}
+
+	if err := w.progress.Save(ctx, cp.SequenceNumber); err != nil {
+		return fmt.Errorf("indexer: save progress: %w", err)
+	}
+	w.next = cp.SequenceNumber + 1
+	return nil
+}
@@ -0,0 +1,144 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"testing"
+)
+
+type fakeSource struct {
+	latest  uint64
+	fetched []uint64
+}
+
+func (s *fakeSource) LatestCheckpoint(context.Context) (uint64, error) {
+	return s.latest, nil
+}
+
+func (s *fakeSource) Checkpoint(_ context.Context, sequence uint64) (*Checkpoint, error) {
+	s.fetched = append(s.fetched, sequence)
+	return fakeCheckpoint(sequence), nil
+}
+
+func fakeCheckpoint(sequence uint64) *Checkpoint {
+	digest := fmt.Sprintf("tx%d", sequence)
+	return &Checkpoint{
+		SequenceNumber: sequence,
+		Transactions: []*Transaction{{
+			Digest:        digest,
+			Checkpoint:    sequence,
+			Events:        []*Event{{TransactionDigest: digest, Checkpoint: sequence}},
+			ObjectChanges: []*ObjectChange{{TransactionDigest: digest, Checkpoint: sequence}},
+		}},
+	}
+}
+
+type fakeStreamingSource struct {
+	fakeSource
+	pushed []uint64
+}
+
+type sliceStream struct {
+	items []uint64
+}
+
+func (s *sliceStream) Recv() (*Checkpoint, error) {
+	if len(s.items) == 0 {
+		return nil, io.EOF
+	}
+	next := s.items[0]
+	s.items = s.items[1:]
+	return fakeCheckpoint(next), nil
+}
+
+func (s *fakeStreamingSource) Subscribe(context.Context) (Stream, error) {
+	return &sliceStream{items: s.pushed}, nil
+}
+
+func TestWorkerBackfillAndResume(t *testing.T) {
+	source := &fakeSource{latest: 20}
+	store := NewFileProgressStore(filepath.Join(t.TempDir(), "progress"))
+
+	var seen []uint64
+	events := 0
+	worker, err := NewWorker(source, WithStartCheckpoint(3), WithEndCheckpoint(6), WithProgressStore(store))
+	if err != nil {
+		t.Fatalf("new worker: %v", err)
+	}
+	worker.
+		OnCheckpoint(func(_ context.Context, cp *Checkpoint) error {
+			seen = append(seen, cp.SequenceNumber)
+			return nil
+		}).
+		OnEvent(func(context.Context, *Event) error {
+			events++
+			return nil
+		})
+
+	if err := worker.Run(context.Background()); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if fmt.Sprint(seen) != "[3 4 5 6]" || events != 4 {
+		t.Fatalf("unexpected checkpoints %v (events %d)", seen, events)
+	}
+
+	resumed, _ := NewWorker(source, WithStartCheckpoint(0), WithEndCheckpoint(8), WithProgressStore(store))
+	seen = nil
+	resumed.OnCheckpoint(func(_ context.Context, cp *Checkpoint) error {
+		seen = append(seen, cp.SequenceNumber)
+		return nil
+	})
+	if err := resumed.Run(context.Background()); err != nil {
+		t.Fatalf("resume: %v", err)
+	}
+	if fmt.Sprint(seen) != "[7 8]" {
+		t.Fatalf("expected resume from progress, got %v", seen)
+	}
+}
+
+func TestWorkerStreamFillsGaps(t *testing.T) {
+	source := &fakeStreamingSource{fakeSource: fakeSource{latest: 5}, pushed: []uint64{1, 4, 5}}
+
+	var seen []uint64
+	worker, _ := NewWorker(source, WithStartCheckpoint(2), WithEndCheckpoint(5))
+	worker.OnTransaction(func(_ context.Context, tx *Transaction) error {
+		seen = append(seen, tx.Checkpoint)
+		return nil
+	})
+
+	if err := worker.Run(context.Background()); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if fmt.Sprint(seen) != "[2 3 4 5]" {
+		t.Fatalf("unexpected order %v", seen)
+	}
+	if fmt.Sprint(source.fetched) != "[2 3]" {
+		t.Fatalf("expected gap backfill of 2 and 3, got %v", source.fetched)
+	}
+}
+
+func TestWorkerHandlerErrorKeepsProgress(t *testing.T) {
+	source := &fakeSource{latest: 10}
+	store := NewMemoryProgressStore()
+	boom := errors.New("boom")
+
+	worker, _ := NewWorker(source, WithStartCheckpoint(0), WithProgressStore(store))
+	worker.OnObjectChange(func(_ context.Context, change *ObjectChange) error {
+		if change.Checkpoint == 2 {
+			return boom
+		}
+		return nil
+	})
+
+	err := worker.Run(context.Background())
+	var handlerErr *HandlerError
+	if !errors.As(err, &handlerErr) || handlerErr.Checkpoint != 2 || !errors.Is(err, boom) {
+		t.Fatalf("expected handler error at checkpoint 2, got %v", err)
+	}
+	if last, ok, _ := store.Load(context.Background()); !ok || last != 1 {
+		t.Fatalf("expected progress 1, got %d (%v)", last, ok)
+	}
+}
@@ -0,0 +1,70 @@
+// Package units converts between decimal strings and integer amounts of base
+// units. It backs both utils.ParseUnits/FormatUnits and types.CoinAmount,
+// which cannot import utils.
+package units
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Format renders an integer amount of base units as a decimal string with
+// the given number of decimals, trimming trailing zeros (1234000000, 9 -> "1.234").
+func Format(amount *big.Int, decimals int) string {
+	if amount == nil {
+		return "0"
+	}
+	if decimals <= 0 {
+		return amount.String()
+	}
+
+	negative := amount.Sign() < 0
+	digits := new(big.Int).Abs(amount).String()
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+
+	whole := digits[:len(digits)-decimals]
+	fraction := strings.TrimRight(digits[len(digits)-decimals:], "0")
+
+	out := whole
+	if fraction != "" {
+		out += "." + fraction
+	}
+	if negative {
+		out = "-" + out
+	}
+	return out
+}
+
+// Parse converts a decimal string into base units with the given number of
+// decimals ("1.5", 9 -> 1500000000). It rejects values with more fractional
+// digits than decimals allows and values without any digits.
+func Parse(value string, decimals int) (*big.Int, error) {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
+		return nil, fmt.Errorf("empty amount")
+	}
+	if decimals < 0 {
+		return nil, fmt.Errorf("negative decimals")
+	}
+
+	whole, fraction, _ := strings.Cut(trimmed, ".")
+	if len(fraction) > decimals {
+		return nil, fmt.Errorf("amount %q has more than %d decimal places", value, decimals)
+	}
+	if unsigned := strings.TrimLeft(whole, "+-"); unsigned == "" {
+		if fraction == "" {
+			return nil, fmt.Errorf("invalid amount %q", value)
+		}
+		whole += "0"
+	}
+
+	combined := whole + fraction + strings.Repeat("0", decimals-len(fraction))
+	amount, ok := new(big.Int).SetString(combined, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid amount %q", value)
+	}
+	return amount, nil
+}
@@ -0,0 +1,183 @@
+package keypair
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	ed25519keys "github.com/open-move/sui-go-sdk/cryptography/ed25519"
+	secp256k1keys "github.com/open-move/sui-go-sdk/cryptography/secp256k1"
+	secp256r1keys "github.com/open-move/sui-go-sdk/cryptography/secp256r1"
+	"github.com/open-move/sui-go-sdk/keychain"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+// IndexPlaceholder marks where DeriveBatch puts each key's index in a path
+// template, as in "m/44'/784'/{index}'/0'/0'".
+const IndexPlaceholder = "{index}"
+
+// maxChildIndex is the largest index a path segment can hold; higher values
+// set the hardened bit.
+const maxChildIndex = 1<<31 - 1
+
+// DerivedKey is a keypair derived by DeriveBatch.
+type DerivedKey struct {
+	Index     uint32
+	Path      string
+	Address   string
+	PublicKey []byte
+	Keypair   Keypair
+}
+
+// Batch holds consecutive keypairs derived from one mnemonic.
+type Batch struct {
+	Keys    []DerivedKey
+	byIndex map[uint32]int
+	byAddr  map[string]int
+}
+
+// DeriveBatch derives count keypairs from a mnemonic, for indexes startIndex
+// onwards substituted for IndexPlaceholder in pathTemplate, such as deposit
+// addresses for exchange users. The seed and the key at the path segments
+// before the placeholder are derived once for the whole batch; each key only
+// derives the segments from the placeholder on. The mnemonic is used without
+// a passphrase.
+func DeriveBatch(s keychain.Scheme, mnemonic, pathTemplate string, startIndex uint32, count int) (*Batch, error) {
+	if n := strings.Count(pathTemplate, IndexPlaceholder); n != 1 {
+		return nil, fmt.Errorf("derive batch: path template %q must contain %s once, found %d", pathTemplate, IndexPlaceholder, n)
+	}
+	if count <= 0 {
+		return nil, fmt.Errorf("derive batch: count must be positive, got %d", count)
+	}
+	if uint64(startIndex)+uint64(count)-1 > maxChildIndex {
+		return nil, fmt.Errorf("derive batch: indexes %d to %d exceed %d", startIndex, uint64(startIndex)+uint64(count)-1, maxChildIndex)
+	}
+	first, err := keychain.ParseDerivationPath(strings.Replace(pathTemplate, IndexPlaceholder, strconv.FormatUint(uint64(startIndex), 10), 1))
+	if err != nil {
+		return nil, err
+	}
+	seed, err := keychain.SeedFromMnemonic(mnemonic, "")
+	if err != nil {
+		return nil, err
+	}
+	defer zero(seed)
+	// Segments are separated by "/" after the leading "m".
+	depth := strings.Count(pathTemplate[:strings.Index(pathTemplate, IndexPlaceholder)], "/") - 1
+	derive, err := parentDeriver(s, seed, first.Segments()[:depth])
+	if err != nil {
+		return nil, fmt.Errorf("derive batch: %w", err)
+	}
+
+	batch := &Batch{
+		Keys:    make([]DerivedKey, count),
+		byIndex: make(map[uint32]int, count),
+		byAddr:  make(map[string]int, count),
+	}
+	for i := range batch.Keys {
+		index := startIndex + uint32(i)
+		path := strings.Replace(pathTemplate, IndexPlaceholder, strconv.FormatUint(uint64(index), 10), 1)
+		parsed, err := keychain.ParseDerivationPath(path)
+		if err != nil {
+			return nil, err
+		}
+		kp, err := derive(parsed)
+		if err != nil {
+			return nil, fmt.Errorf("derive batch: %s: %w", path, err)
+		}
+		address, err := kp.SuiAddress()
+		if err != nil {
+			return nil, err
+		}
+		parsedAddress, err := utils.ParseAddress(address)
+		if err != nil {
+			return nil, err
+		}
+		batch.Keys[i] = DerivedKey{Index: index, Path: path, Address: address, PublicKey: kp.PublicKey(), Keypair: kp}
+		batch.byIndex[index] = i
+		batch.byAddr[parsedAddress.String()] = i
+	}
+	return batch, nil
+}
+
+// parentDeriver derives the key at prefix once and returns a function that
+// derives keypairs at paths extending it.
+func parentDeriver(s keychain.Scheme, seed []byte, prefix []keychain.PathSegment) (func(keychain.DerivationPath) (Keypair, error), error) {
+	switch s {
+	case keychain.SchemeEd25519:
+		parent, err := ed25519keys.MasterKey(seed).Derive(prefix...)
+		if err != nil {
+			return nil, err
+		}
+		return func(path keychain.DerivationPath) (Keypair, error) {
+			return ed25519keys.DeriveFrom(parent, path)
+		}, nil
+	case keychain.SchemeSecp256k1:
+		parent, err := secp256k1keys.MasterKey(seed).Derive(prefix...)
+		if err != nil {
+			return nil, err
+		}
+		return func(path keychain.DerivationPath) (Keypair, error) {
+			return secp256k1keys.DeriveFrom(parent, path)
+		}, nil
+	case keychain.SchemeSecp256r1:
+		parent, err := secp256r1keys.MasterKey(seed).Derive(prefix...)
+		if err != nil {
+			return nil, err
+		}
+		return func(path keychain.DerivationPath) (Keypair, error) {
+			return secp256r1keys.DeriveFrom(parent, path)
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported scheme %d", s)
+	}
+}
+
+// Addresses returns the batch's addresses in index order.
+func (b *Batch) Addresses() []string {
+	addresses := make([]string, len(b.Keys))
+	for i, key := range b.Keys {
+		addresses[i] = key.Address
+	}
+	return addresses
+}
+
+// PublicKeys returns the batch's public keys in index order.
+func (b *Batch) PublicKeys() [][]byte {
+	keys := make([][]byte, len(b.Keys))
+	for i, key := range b.Keys {
+		keys[i] = key.PublicKey
+	}
+	return keys
+}
+
+// AddressMap returns a new map from index to address.
+func (b *Batch) AddressMap() map[uint32]string {
+	addresses := make(map[uint32]string, len(b.Keys))
+	for _, key := range b.Keys {
+		addresses[key.Index] = key.Address
+	}
+	return addresses
+}
+
+// Key returns the key derived at index.
+func (b *Batch) Key(index uint32) (DerivedKey, bool) {
+	i, ok := b.byIndex[index]
+	if !ok {
+		return DerivedKey{}, false
+	}
+	return b.Keys[i], true
+}
+
+// Lookup returns the key whose address is address, in any accepted address
+// form, such as the recipient of an incoming deposit.
+func (b *Batch) Lookup(address string) (DerivedKey, bool) {
+	parsed, err := utils.ParseAddress(address)
+	if err != nil {
+		return DerivedKey{}, false
+	}
+	i, ok := b.byAddr[parsed.String()]
+	if !ok {
+		return DerivedKey{}, false
+	}
+	return b.Keys[i], true
+}
@@ -0,0 +1,96 @@
+package keypair
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+
+	ed25519keys "github.com/open-move/sui-go-sdk/cryptography/ed25519"
+	"github.com/open-move/sui-go-sdk/keychain"
+)
+
+func TestDeriveBatch(t *testing.T) {
+	for scheme, template := range map[keychain.Scheme]string{
+		keychain.SchemeEd25519:   "m/44'/784'/{index}'/0'/0'",
+		keychain.SchemeSecp256k1: "m/54'/784'/0'/0/{index}",
+		keychain.SchemeSecp256r1: "m/74'/784'/{index}'/0/0",
+	} {
+		batch, err := DeriveBatch(scheme, testMnemonic, template, 5, 3)
+		if err != nil {
+			t.Fatalf("DeriveBatch: %v", err)
+		}
+		if len(batch.Keys) != 3 || len(batch.Addresses()) != 3 || len(batch.PublicKeys()) != 3 {
+			t.Fatalf("unexpected batch %+v", batch.Keys)
+		}
+		addresses := batch.AddressMap()
+		for i, key := range batch.Keys {
+			index := uint32(5 + i)
+			path := strings.Replace(template, IndexPlaceholder, strconv.Itoa(int(index)), 1)
+			want, err := DeriveFromMnemonic(scheme, testMnemonic, "", path)
+			if err != nil {
+				t.Fatalf("derive %s: %v", path, err)
+			}
+			address, _ := want.SuiAddress()
+			if key.Index != index || key.Path != path || key.Address != address || !bytes.Equal(key.PublicKey, want.PublicKey()) {
+				t.Errorf("key %d = %+v, want %s %s", i, key, path, address)
+			}
+			if addresses[index] != address || batch.Addresses()[i] != address {
+				t.Errorf("address of index %d = %s, want %s", index, addresses[index], address)
+			}
+			if got, ok := batch.Key(index); !ok || got.Address != address {
+				t.Errorf("Key(%d) = %+v, %v", index, got, ok)
+			}
+			if got, ok := batch.Lookup(strings.ToUpper(address[2:])); !ok || got.Index != index {
+				t.Errorf("Lookup(%s) = %+v, %v", address, got, ok)
+			}
+		}
+		if _, ok := batch.Key(4); ok {
+			t.Error("Key found an index outside the batch")
+		}
+		if _, ok := batch.Lookup("0x1"); ok {
+			t.Error("Lookup found an address outside the batch")
+		}
+	}
+
+	for _, tt := range []struct {
+		name     string
+		template string
+		start    uint32
+		count    int
+	}{
+		{"no placeholder", "m/44'/784'/0'/0'/0'", 0, 1},
+		{"two placeholders", "m/44'/784'/{index}'/{index}'/0'", 0, 1},
+		{"zero count", "m/44'/784'/{index}'/0'/0'", 0, 0},
+		{"index overflow", "m/44'/784'/{index}'/0'/0'", maxChildIndex, 2},
+		{"invalid path", "m/44'/784'/{index}'/0'", 0, 1},
+	} {
+		if _, err := DeriveBatch(keychain.SchemeEd25519, testMnemonic, tt.template, tt.start, tt.count); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+}
+
+func TestDeriveFromParent(t *testing.T) {
+	seed, err := keychain.SeedFromMnemonic(testMnemonic, "")
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	path, _ := keychain.ParseDerivationPath("m/44'/784'/3'/0'/0'")
+	parent, err := ed25519keys.MasterKey(seed).Derive(path.Segments()[:3]...)
+	if err != nil {
+		t.Fatalf("parent: %v", err)
+	}
+	got, err := ed25519keys.DeriveFrom(parent, path)
+	if err != nil {
+		t.Fatalf("DeriveFrom: %v", err)
+	}
+	want, _ := ed25519keys.Derive(seed, path)
+	if !bytes.Equal(got.PublicKey(), want.PublicKey()) {
+		t.Error("key derived from the parent differs from Derive")
+	}
+	other, _ := keychain.ParseDerivationPath("m/44'/784'/4'/0'/0'")
+	if _, err := ed25519keys.DeriveFrom(parent, other); err == nil {
+		t.Error("DeriveFrom accepted a path that does not extend the parent")
+	}
+}
@@ -21,7 +21,10 @@ func DeriveFromMnemonic(s keychain.Scheme, mnemonic, passphrase, path string) (K
 	if err != nil {
 		return nil, err
 	}
+	return deriveFromSeed(s, seed, parsed)
+}
 
+func deriveFromSeed(s keychain.Scheme, seed []byte, parsed keychain.DerivationPath) (Keypair, error) {
 	switch s {
 	case keychain.SchemeEd25519:
 		return ed25519keys.Derive(seed, parsed)
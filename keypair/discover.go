@@ -0,0 +1,104 @@
+package keypair
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/open-move/sui-go-sdk/keychain"
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+// DefaultGapLimit is how many consecutive unused accounts DiscoverAccounts
+// scans before it stops.
+const DefaultGapLimit = 20
+
+// ActivityChecker reports whether an address has been used on chain.
+// graphql.Client implements it.
+type ActivityChecker interface {
+	HasActivity(ctx context.Context, address types.Address) (bool, error)
+}
+
+// DiscoveredAccount is an account found by DiscoverAccounts.
+type DiscoveredAccount struct {
+	Index   uint32
+	Path    string
+	Address string
+	Keypair Keypair
+}
+
+// GenerateMnemonic returns a new BIP-39 mnemonic with strength bits of
+// entropy: 128 for 12 words up to 256 for 24 words.
+func GenerateMnemonic(strength int) (string, error) {
+	return keychain.NewMnemonic(strength)
+}
+
+// AccountPath returns the derivation path Sui wallets use for the account at
+// index: m/44'/784'/index'/0'/0' for Ed25519 and m/54'/784'/index'/0/0 or
+// m/74'/784'/index'/0/0 for the ECDSA schemes.
+func AccountPath(s keychain.Scheme, index uint32) (string, error) {
+	switch s {
+	case keychain.SchemeEd25519:
+		return fmt.Sprintf("m/%d'/784'/%d'/0'/0'", s.Purpose(), index), nil
+	case keychain.SchemeSecp256k1, keychain.SchemeSecp256r1:
+		return fmt.Sprintf("m/%d'/784'/%d'/0/0", s.Purpose(), index), nil
+	default:
+		return "", fmt.Errorf("account path: unsupported scheme %d", s)
+	}
+}
+
+// DiscoverAccounts restores the accounts of a mnemonic the way wallets do:
+// it derives accounts at AccountPath indexes 0, 1, 2, ... and asks checker
+// whether each has been used, stopping after gapLimit consecutive unused
+// accounts. It returns the used accounts in index order. A gapLimit of zero
+// or less uses DefaultGapLimit. The mnemonic is used without a passphrase.
+func DiscoverAccounts(ctx context.Context, checker ActivityChecker, mnemonic string, s keychain.Scheme, gapLimit int) ([]DiscoveredAccount, error) {
+	if checker == nil {
+		return nil, errors.New("discover accounts: nil activity checker")
+	}
+	if gapLimit <= 0 {
+		gapLimit = DefaultGapLimit
+	}
+	seed, err := keychain.SeedFromMnemonic(mnemonic, "")
+	if err != nil {
+		return nil, err
+	}
+	defer zero(seed)
+
+	var accounts []DiscoveredAccount
+	for index, gap := uint32(0), 0; gap < gapLimit; index++ {
+		path, err := AccountPath(s, index)
+		if err != nil {
+			return nil, err
+		}
+		parsed, err := keychain.ParseDerivationPath(path)
+		if err != nil {
+			return nil, err
+		}
+		kp, err := deriveFromSeed(s, seed, parsed)
+		if err != nil {
+			return nil, err
+		}
+		address, err := kp.SuiAddress()
+		if err != nil {
+			return nil, err
+		}
+		parsedAddress, err := utils.ParseAddress(address)
+		if err != nil {
+			return nil, err
+		}
+
+		used, err := checker.HasActivity(ctx, parsedAddress)
+		if err != nil {
+			return nil, fmt.Errorf("discover accounts: check %s: %w", address, err)
+		}
+		if !used {
+			gap++
+			continue
+		}
+		gap = 0
+		accounts = append(accounts, DiscoveredAccount{Index: index, Path: path, Address: address, Keypair: kp})
+	}
+	return accounts, nil
+}
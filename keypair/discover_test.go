@@ -0,0 +1,66 @@
+package keypair
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/keychain"
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+type activitySet struct {
+	used    map[types.Address]bool
+	checked int
+}
+
+func (a *activitySet) HasActivity(_ context.Context, address types.Address) (bool, error) {
+	a.checked++
+	return a.used[address], nil
+}
+
+func TestGenerateMnemonic(t *testing.T) {
+	mnemonic, err := GenerateMnemonic(256)
+	if err != nil {
+		t.Fatalf("GenerateMnemonic: %v", err)
+	}
+	if words := len(strings.Fields(mnemonic)); words != 24 {
+		t.Fatalf("expected 24 words, got %d", words)
+	}
+	if _, err := GenerateMnemonic(100); err == nil {
+		t.Fatal("expected error for invalid strength")
+	}
+}
+
+func TestDiscoverAccounts(t *testing.T) {
+	for _, scheme := range []keychain.Scheme{keychain.SchemeEd25519, keychain.SchemeSecp256k1} {
+		checker := &activitySet{used: make(map[types.Address]bool)}
+		for _, index := range []uint32{0, 2} {
+			path, err := AccountPath(scheme, index)
+			if err != nil {
+				t.Fatalf("AccountPath: %v", err)
+			}
+			kp, err := DeriveFromMnemonic(scheme, testMnemonic, "", path)
+			if err != nil {
+				t.Fatalf("derive %s: %v", path, err)
+			}
+			address, _ := kp.SuiAddress()
+			checker.used[utils.MustParseAddress(address)] = true
+		}
+
+		accounts, err := DiscoverAccounts(context.Background(), checker, testMnemonic, scheme, 2)
+		if err != nil {
+			t.Fatalf("DiscoverAccounts: %v", err)
+		}
+		if len(accounts) != 2 || accounts[0].Index != 0 || accounts[1].Index != 2 {
+			t.Fatalf("unexpected accounts %+v", accounts)
+		}
+		if checker.checked != 5 {
+			t.Fatalf("expected 5 checks, got %d", checker.checked)
+		}
+		if accounts[1].Keypair == nil || accounts[1].Path == "" {
+			t.Fatalf("missing keypair or path: %+v", accounts[1])
+		}
+	}
+}
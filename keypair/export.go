@@ -0,0 +1,183 @@
+package keypair
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/open-move/sui-go-sdk/keychain"
+	"golang.org/x/crypto/argon2"
+)
+
+// ErrInvalidPassphrase indicates the passphrase could not decrypt an
+// encrypted key export.
+var ErrInvalidPassphrase = errors.New("keypair: invalid passphrase")
+
+const (
+	encryptedKeyVersion = 1
+	kdfArgon2id         = "argon2id"
+	argon2Time          = 3
+	argon2Memory        = 64 * 1024 // KiB
+	argon2Threads       = 4
+	argon2SaltSize      = 16
+	argon2KeySize       = 32
+)
+
+// ExportToBech32 encodes the keypair's secret as a SIP-15 "suiprivkey..."
+// string, the format `sui keytool export` prints and wallets import.
+// FromBech32 reverses it.
+func ExportToBech32(k Keypair) (string, error) {
+	return ToBech32FromKeypair(k)
+}
+
+// ExportToSuiKeystoreEntry encodes the keypair as one entry of a Sui CLI
+// sui.keystore file: base64 of flag || secret.
+func ExportToSuiKeystoreEntry(k Keypair) (string, error) {
+	if k == nil {
+		return "", fmt.Errorf("export: nil keypair")
+	}
+	secret, err := k.ExportSecret()
+	if err != nil {
+		return "", err
+	}
+	payload := append([]byte{k.Scheme().AddressFlag()}, secret...)
+	zero(secret)
+	encoded := base64.StdEncoding.EncodeToString(payload)
+	zero(payload)
+	return encoded, nil
+}
+
+// FromSuiKeystoreEntry parses one entry of a sui.keystore file, as written
+// by ExportToSuiKeystoreEntry or the Sui CLI.
+func FromSuiKeystoreEntry(entry string) (Keypair, error) {
+	payload, err := base64.StdEncoding.DecodeString(entry)
+	if err != nil {
+		return nil, fmt.Errorf("keystore entry: decode base64: %w", err)
+	}
+	defer zero(payload)
+	if len(payload) != 1+keychain.PrivateKeySize() {
+		return nil, fmt.Errorf("keystore entry: expected %d bytes, got %d", 1+keychain.PrivateKeySize(), len(payload))
+	}
+	scheme, err := keychain.SchemeFromFlag(payload[0])
+	if err != nil {
+		return nil, err
+	}
+	return FromSecretKey(scheme, payload[1:])
+}
+
+// encryptedKey is the JSON form of ExportEncryptedJSON: a sui.keystore
+// entry sealed with AES-256-GCM under an Argon2id-derived key. The address
+// is authenticated as additional data so it can be read without the
+// passphrase but not altered.
+type encryptedKey struct {
+	Version    int    `json:"version"`
+	Address    string `json:"address"`
+	KDF        string `json:"kdf"`
+	Time       uint32 `json:"time"`
+	Memory     uint32 `json:"memory"`
+	Threads    uint8  `json:"threads"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// ExportEncryptedJSON encrypts the keypair with a passphrase for storage or
+// transfer. The result is JSON that records the address in the clear and
+// the Argon2id parameters used; FromEncryptedJSON reverses it.
+func ExportEncryptedJSON(k Keypair, passphrase string) ([]byte, error) {
+	if k == nil {
+		return nil, fmt.Errorf("export: nil keypair")
+	}
+	if passphrase == "" {
+		return nil, errors.New("export: empty passphrase")
+	}
+	address, err := k.SuiAddress()
+	if err != nil {
+		return nil, err
+	}
+	entry, err := ExportToSuiKeystoreEntry(k)
+	if err != nil {
+		return nil, err
+	}
+	plaintext := []byte(entry)
+	defer zero(plaintext)
+
+	out := encryptedKey{
+		Version: encryptedKeyVersion,
+		Address: address,
+		KDF:     kdfArgon2id,
+		Time:    argon2Time,
+		Memory:  argon2Memory,
+		Threads: argon2Threads,
+		Salt:    make([]byte, argon2SaltSize),
+	}
+	if _, err := rand.Read(out.Salt); err != nil {
+		return nil, fmt.Errorf("export: salt: %w", err)
+	}
+	aead, err := out.cipher(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	out.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(out.Nonce); err != nil {
+		return nil, fmt.Errorf("export: nonce: %w", err)
+	}
+	out.Ciphertext = aead.Seal(nil, out.Nonce, plaintext, []byte(out.Address))
+	return json.MarshalIndent(out, "", "  ")
+}
+
+// FromEncryptedJSON decrypts a key exported with ExportEncryptedJSON. It
+// returns ErrInvalidPassphrase when the passphrase is wrong or the export
+// was tampered with.
+func FromEncryptedJSON(data []byte, passphrase string) (Keypair, error) {
+	var in encryptedKey
+	if err := json.Unmarshal(data, &in); err != nil {
+		return nil, fmt.Errorf("encrypted key: decode json: %w", err)
+	}
+	if in.Version != encryptedKeyVersion {
+		return nil, fmt.Errorf("encrypted key: unsupported version %d", in.Version)
+	}
+	if in.KDF != kdfArgon2id {
+		return nil, fmt.Errorf("encrypted key: unsupported kdf %q", in.KDF)
+	}
+	if in.Time == 0 || in.Memory == 0 || in.Threads == 0 {
+		return nil, errors.New("encrypted key: invalid kdf parameters")
+	}
+
+	aead, err := in.cipher(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	if len(in.Nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("encrypted key: invalid nonce length %d", len(in.Nonce))
+	}
+	plaintext, err := aead.Open(nil, in.Nonce, in.Ciphertext, []byte(in.Address))
+	if err != nil {
+		return nil, ErrInvalidPassphrase
+	}
+	defer zero(plaintext)
+
+	kp, err := FromSuiKeystoreEntry(string(plaintext))
+	if err != nil {
+		return nil, err
+	}
+	if address, err := kp.SuiAddress(); err != nil || address != in.Address {
+		return nil, fmt.Errorf("encrypted key: key does not match address %s", in.Address)
+	}
+	return kp, nil
+}
+
+func (e encryptedKey) cipher(passphrase string) (cipher.AEAD, error) {
+	key := argon2.IDKey([]byte(passphrase), e.Salt, e.Time, e.Memory, e.Threads, argon2KeySize)
+	defer zero(key)
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("encrypted key: cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,91 @@
+package keypair
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/keychain"
+)
+
+func TestExportFormatsRoundTrip(t *testing.T) {
+	for _, scheme := range []keychain.Scheme{keychain.SchemeEd25519, keychain.SchemeSecp256k1, keychain.SchemeSecp256r1} {
+		kp, err := Generate(scheme)
+		if err != nil {
+			t.Fatalf("generate %d: %v", scheme, err)
+		}
+		want, _ := kp.SuiAddress()
+
+		bech, err := ExportToBech32(kp)
+		if err != nil {
+			t.Fatalf("bech32: %v", err)
+		}
+		entry, err := ExportToSuiKeystoreEntry(kp)
+		if err != nil {
+			t.Fatalf("keystore entry: %v", err)
+		}
+		encrypted, err := ExportEncryptedJSON(kp, "hunter2")
+		if err != nil {
+			t.Fatalf("encrypted json: %v", err)
+		}
+
+		imports := map[string]func() (Keypair, error){
+			"bech32":    func() (Keypair, error) { return FromBech32(bech) },
+			"keystore":  func() (Keypair, error) { return FromSuiKeystoreEntry(entry) },
+			"encrypted": func() (Keypair, error) { return FromEncryptedJSON(encrypted, "hunter2") },
+		}
+		for name, fn := range imports {
+			got, err := fn()
+			if err != nil {
+				t.Fatalf("%s import: %v", name, err)
+			}
+			if addr, _ := got.SuiAddress(); addr != want {
+				t.Fatalf("%s import: address %s, want %s", name, addr, want)
+			}
+		}
+	}
+}
+
+func TestSuiKeystoreEntryVector(t *testing.T) {
+	// flag 0x00 (ed25519) followed by the secret of the legacy mnemonic vector.
+	kp, err := DeriveFromMnemonic(keychain.SchemeEd25519, testMnemonic, "", "m/44'/784'/0'/0'/0'")
+	if err != nil {
+		t.Fatalf("derive: %v", err)
+	}
+	entry, err := ExportToSuiKeystoreEntry(kp)
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	if entry != "AIhpywcXi/Z+CNfEq99FSH2/N5yaRS/OwoNoVL9KPSmw" {
+		t.Fatalf("unexpected entry %s", entry)
+	}
+	if _, err := FromSuiKeystoreEntry("AIhpyw=="); err == nil {
+		t.Fatal("expected short entry to be rejected")
+	}
+}
+
+func TestEncryptedJSONRejectsTampering(t *testing.T) {
+	kp, err := Generate(keychain.SchemeEd25519)
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	encrypted, err := ExportEncryptedJSON(kp, "correct horse")
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	if bytes.Contains(encrypted, []byte("correct horse")) {
+		t.Fatal("passphrase leaked into export")
+	}
+	if _, err := FromEncryptedJSON(encrypted, "wrong"); !errors.Is(err, ErrInvalidPassphrase) {
+		t.Fatalf("expected ErrInvalidPassphrase, got %v", err)
+	}
+
+	var fields map[string]any
+	json.Unmarshal(encrypted, &fields)
+	fields["address"] = "0x0000000000000000000000000000000000000000000000000000000000000001"
+	tampered, _ := json.Marshal(fields)
+	if _, err := FromEncryptedJSON(tampered, "correct horse"); !errors.Is(err, ErrInvalidPassphrase) {
+		t.Fatalf("expected altered address to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,47 @@
+package keypair_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/cryptography/intent"
+	"github.com/open-move/sui-go-sdk/keychain"
+	"github.com/open-move/sui-go-sdk/keypair"
+)
+
+func TestSignWithIntent(t *testing.T) {
+	kp, err := keypair.DeriveFromMnemonic(
+		keychain.SchemeEd25519,
+		"ship host undo vacant also squeeze current alarm shift blush travel supply",
+		"",
+		"m/44'/784'/0'/0'/0'",
+	)
+	if err != nil {
+		t.Fatalf("derive keypair: %v", err)
+	}
+
+	payload := []byte("checkpoint summary bytes")
+	txSig, err := kp.SignTransaction(payload)
+	if err != nil {
+		t.Fatalf("sign transaction: %v", err)
+	}
+	intentSig, err := kp.SignWithIntent(intent.IntentScopeTransactionData, payload)
+	if err != nil {
+		t.Fatalf("sign with intent: %v", err)
+	}
+	if !bytes.Equal(txSig, intentSig) {
+		t.Fatalf("transaction data intent should match SignTransaction")
+	}
+
+	checkpointSig, err := kp.SignWithIntent(intent.IntentScopeCheckpointSummary, payload)
+	if err != nil {
+		t.Fatalf("sign checkpoint summary: %v", err)
+	}
+	if bytes.Equal(checkpointSig, txSig) {
+		t.Fatalf("different scopes must produce different signatures")
+	}
+
+	if _, err := kp.SignWithIntent(intent.IntentScope(99), payload); err == nil {
+		t.Fatalf("expected error for unknown scope")
+	}
+}
@@ -1,15 +1,25 @@
 // Package keypair defines the interface for Sui keypairs and provides utilities for key generation and management.
 package keypair
 
-import "github.com/open-move/sui-go-sdk/keychain"
+import (
+	"github.com/open-move/sui-go-sdk/cryptography/intent"
+	"github.com/open-move/sui-go-sdk/keychain"
+)
 
-// Keypair defines the interface for Sui keypairs and provides utilities for key generation and management.
-type Keypair interface {
+// Signer produces Sui signatures without requiring access to private key material.
+// In-memory keypairs, hardware wallets, and remote signing services all satisfy it.
+type Signer interface {
 	PublicKey() []byte
 	Scheme() keychain.Scheme
 	SuiAddress() (string, error)
-	ExportSecret() ([]byte, error)
 	SignTransaction(txBytes []byte) ([]byte, error)
 	SignPersonalMessage(message []byte) ([]byte, error)
+}
+
+// Keypair defines the interface for Sui keypairs and provides utilities for key generation and management.
+type Keypair interface {
+	Signer
+	ExportSecret() ([]byte, error)
+	SignWithIntent(scope intent.IntentScope, payload []byte) ([]byte, error)
 	VerifyPersonalMessage(message []byte, signature []byte) error
 }
@@ -0,0 +1,149 @@
+package keystore
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// ErrInvalidPassphrase indicates the passphrase could not decrypt the keystore.
+var ErrInvalidPassphrase = errors.New("keystore: invalid passphrase")
+
+const (
+	envelopeVersion = 1
+	kdfScrypt       = "scrypt"
+	scryptN         = 1 << 15
+	scryptR         = 8
+	scryptP         = 1
+	saltSize        = 16
+	keySize         = 32
+)
+
+// envelope wraps an encrypted sui.keystore payload. The plaintext is the same
+// JSON array the Sui CLI reads, sealed with AES-256-GCM under a scrypt-derived key.
+type envelope struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	N          int    `json:"n"`
+	R          int    `json:"r"`
+	P          int    `json:"p"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// LoadEncrypted reads a keystore file written by SaveEncrypted.
+// Plaintext keystore files are accepted as well so callers can migrate transparently.
+func LoadEncrypted(path string, passphrase string) (*Keystore, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("keystore: read %s: %w", path, err)
+	}
+	if !isEncrypted(data) {
+		return Parse(data)
+	}
+	return Decrypt(data, passphrase)
+}
+
+// SaveEncrypted writes the keystore encrypted at rest with the given passphrase.
+func (k *Keystore) SaveEncrypted(path string, passphrase string) error {
+	data, err := k.Encrypt(passphrase)
+	if err != nil {
+		return err
+	}
+	return writeFile(path, data)
+}
+
+// Encrypt seals the keystore contents with a passphrase-derived key.
+func (k *Keystore) Encrypt(passphrase string) ([]byte, error) {
+	if passphrase == "" {
+		return nil, errors.New("keystore: empty passphrase")
+	}
+
+	plaintext, err := k.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	defer zero(plaintext)
+
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("keystore: salt: %w", err)
+	}
+
+	env := envelope{Version: envelopeVersion, KDF: kdfScrypt, N: scryptN, R: scryptR, P: scryptP, Salt: salt}
+	aead, err := env.cipher(passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	env.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(env.Nonce); err != nil {
+		return nil, fmt.Errorf("keystore: nonce: %w", err)
+	}
+	env.Ciphertext = aead.Seal(nil, env.Nonce, plaintext, nil)
+
+	return json.MarshalIndent(env, "", "  ")
+}
+
+// Decrypt opens an encrypted keystore payload produced by Encrypt. Payloads
+// whose scrypt parameters exceed the ones Encrypt uses are rejected.
+func Decrypt(data []byte, passphrase string) (*Keystore, error) {
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, fmt.Errorf("keystore: decode envelope: %w", err)
+	}
+	if env.Version != envelopeVersion {
+		return nil, fmt.Errorf("keystore: unsupported envelope version %d", env.Version)
+	}
+	if env.KDF != kdfScrypt {
+		return nil, fmt.Errorf("keystore: unsupported kdf %q", env.KDF)
+	}
+	// The parameters come from the file, so cap them at the ones Encrypt
+	// writes; larger ones would let a crafted file exhaust memory and CPU.
+	if env.N > scryptN || env.R > scryptR || env.P > scryptP {
+		return nil, fmt.Errorf("keystore: scrypt parameters n=%d r=%d p=%d exceed n=%d r=%d p=%d", env.N, env.R, env.P, scryptN, scryptR, scryptP)
+	}
+
+	aead, err := env.cipher(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	if len(env.Nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("keystore: invalid nonce length %d", len(env.Nonce))
+	}
+
+	plaintext, err := aead.Open(nil, env.Nonce, env.Ciphertext, nil)
+	if err != nil {
+		return nil, ErrInvalidPassphrase
+	}
+	defer zero(plaintext)
+
+	return Parse(plaintext)
+}
+
+func (e envelope) cipher(passphrase string) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), e.Salt, e.N, e.R, e.P, keySize)
+	if err != nil {
+		return nil, fmt.Errorf("keystore: derive key: %w", err)
+	}
+	defer zero(key)
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("keystore: cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+func isEncrypted(data []byte) bool {
+	trimmed := bytes.TrimSpace(data)
+	return len(trimmed) > 0 && trimmed[0] == '{'
+}
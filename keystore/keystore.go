@@ -0,0 +1,298 @@
+// Package keystore reads and writes key files compatible with the Sui CLI
+// sui.keystore format: a JSON array of base64 encoded `flag || secret` entries.
+package keystore
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/open-move/sui-go-sdk/keychain"
+	"github.com/open-move/sui-go-sdk/keypair"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+var (
+	// ErrKeyNotFound indicates no key in the keystore matches the requested address.
+	ErrKeyNotFound = errors.New("keystore: key not found")
+	// ErrPassphraseRequired indicates the keystore file is encrypted and a passphrase must be supplied.
+	ErrPassphraseRequired = errors.New("keystore: file is encrypted, passphrase required")
+)
+
+const (
+	flagSize = 1
+	fileMode = 0o600
+	dirMode  = 0o700
+)
+
+// Keystore holds a set of private keys indexed by their Sui address.
+type Keystore struct {
+	mu      sync.RWMutex
+	order   []string
+	entries map[string]entry
+}
+
+type entry struct {
+	scheme keychain.Scheme
+	secret []byte
+}
+
+// New returns an empty keystore.
+func New() *Keystore {
+	return &Keystore{entries: make(map[string]entry)}
+}
+
+// DefaultPath returns the location used by the Sui CLI, ~/.sui/sui_config/sui.keystore.
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("keystore: resolve home directory: %w", err)
+	}
+	return filepath.Join(home, ".sui", "sui_config", "sui.keystore"), nil
+}
+
+// LoadDefault loads the Sui CLI keystore from DefaultPath.
+func LoadDefault() (*Keystore, error) {
+	path, err := DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return Load(path)
+}
+
+// Load reads a plaintext sui.keystore file.
+func Load(path string) (*Keystore, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("keystore: read %s: %w", path, err)
+	}
+	if isEncrypted(data) {
+		return nil, ErrPassphraseRequired
+	}
+	return Parse(data)
+}
+
+// Parse decodes the JSON array representation of a sui.keystore file.
+func Parse(data []byte) (*Keystore, error) {
+	var encoded []string
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return nil, fmt.Errorf("keystore: decode json: %w", err)
+	}
+
+	ks := New()
+	for i, value := range encoded {
+		scheme, secret, err := decodeEntry(value)
+		if err != nil {
+			return nil, fmt.Errorf("keystore: entry %d: %w", i, err)
+		}
+		if _, err := ks.add(scheme, secret); err != nil {
+			return nil, fmt.Errorf("keystore: entry %d: %w", i, err)
+		}
+	}
+	return ks, nil
+}
+
+// Marshal encodes the keystore as a JSON array understood by the Sui CLI.
+func (k *Keystore) Marshal() ([]byte, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	encoded := make([]string, 0, len(k.order))
+	for _, addr := range k.order {
+		e := k.entries[addr]
+		encoded = append(encoded, encodeEntry(e.scheme, e.secret))
+	}
+	return json.MarshalIndent(encoded, "", "  ")
+}
+
+// Save writes the keystore in plaintext sui.keystore format with owner-only permissions.
+func (k *Keystore) Save(path string) error {
+	data, err := k.Marshal()
+	if err != nil {
+		return err
+	}
+	return writeFile(path, data)
+}
+
+// Len reports the number of keys held by the keystore.
+func (k *Keystore) Len() int {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return len(k.order)
+}
+
+// Addresses returns the Sui addresses of all keys in insertion order.
+func (k *Keystore) Addresses() []string {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return append([]string(nil), k.order...)
+}
+
+// SortedAddresses returns the Sui addresses of all keys in lexical order.
+func (k *Keystore) SortedAddresses() []string {
+	addrs := k.Addresses()
+	sort.Strings(addrs)
+	return addrs
+}
+
+// Has reports whether a key for the given address is present.
+func (k *Keystore) Has(address string) bool {
+	normalized, err := utils.NormalizeAddress(address)
+	if err != nil {
+		return false
+	}
+
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	_, ok := k.entries[normalized]
+	return ok
+}
+
+// Keypair returns the keypair for the given address.
+func (k *Keystore) Keypair(address string) (keypair.Keypair, error) {
+	normalized, err := utils.NormalizeAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	k.mu.RLock()
+	e, ok := k.entries[normalized]
+	k.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, normalized)
+	}
+
+	return keypair.FromSecretKey(e.scheme, e.secret)
+}
+
+// Keypairs returns every keypair in the keystore in insertion order.
+func (k *Keystore) Keypairs() ([]keypair.Keypair, error) {
+	addrs := k.Addresses()
+	out := make([]keypair.Keypair, 0, len(addrs))
+	for _, addr := range addrs {
+		kp, err := k.Keypair(addr)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, kp)
+	}
+	return out, nil
+}
+
+// Import adds a keypair to the keystore and returns its address.
+func (k *Keystore) Import(kp keypair.Keypair) (string, error) {
+	if kp == nil {
+		return "", errors.New("keystore: nil keypair")
+	}
+	secret, err := kp.ExportSecret()
+	if err != nil {
+		return "", err
+	}
+	defer zero(secret)
+
+	return k.add(kp.Scheme(), secret)
+}
+
+// ImportBech32 adds a suiprivkey-encoded private key and returns its address.
+func (k *Keystore) ImportBech32(encoded string) (string, error) {
+	parsed, err := keychain.DecodePrivateKey(encoded)
+	if err != nil {
+		return "", err
+	}
+	defer zero(parsed.SecretKey)
+
+	return k.add(parsed.Scheme, parsed.SecretKey)
+}
+
+// Remove deletes the key for the given address, reporting whether it existed.
+func (k *Keystore) Remove(address string) bool {
+	normalized, err := utils.NormalizeAddress(address)
+	if err != nil {
+		return false
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	e, ok := k.entries[normalized]
+	if !ok {
+		return false
+	}
+	zero(e.secret)
+	delete(k.entries, normalized)
+	for i, addr := range k.order {
+		if addr == normalized {
+			k.order = append(k.order[:i], k.order[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+func (k *Keystore) add(scheme keychain.Scheme, secret []byte) (string, error) {
+	kp, err := keypair.FromSecretKey(scheme, secret)
+	if err != nil {
+		return "", err
+	}
+	addr, err := kp.SuiAddress()
+	if err != nil {
+		return "", err
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if _, exists := k.entries[addr]; !exists {
+		k.order = append(k.order, addr)
+	}
+	k.entries[addr] = entry{scheme: scheme, secret: append([]byte(nil), secret...)}
+	return addr, nil
+}
+
+func decodeEntry(value string) (keychain.Scheme, []byte, error) {
+	raw, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return 0, nil, fmt.Errorf("decode base64: %w", err)
+	}
+	defer zero(raw)
+
+	if len(raw) != flagSize+keychain.PrivateKeySize() {
+		return 0, nil, fmt.Errorf("invalid key length %d", len(raw))
+	}
+	scheme, err := keychain.SchemeFromFlag(raw[0])
+	if err != nil {
+		return 0, nil, err
+	}
+	return scheme, append([]byte(nil), raw[flagSize:]...), nil
+}
+
+func encodeEntry(scheme keychain.Scheme, secret []byte) string {
+	raw := make([]byte, 0, flagSize+len(secret))
+	raw = append(raw, scheme.AddressFlag())
+	raw = append(raw, secret...)
+	defer zero(raw)
+	return base64.StdEncoding.EncodeToString(raw)
+}
+
+func writeFile(path string, data []byte) error {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, dirMode); err != nil {
+			return fmt.Errorf("keystore: create directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(path, data, fileMode); err != nil {
+		return fmt.Errorf("keystore: write %s: %w", path, err)
+	}
+	return nil
+}
+
+func zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
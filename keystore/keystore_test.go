@@ -0,0 +1,124 @@
+package keystore
+
+import (
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/keychain"
+	"github.com/open-move/sui-go-sdk/keypair"
+)
+
+func TestParseSuiKeystore(t *testing.T) {
+	secret := make([]byte, keychain.PrivateKeySize())
+	for i := range secret {
+		secret[i] = byte(i + 1)
+	}
+	kp, err := keypair.FromSecretKey(keychain.SchemeEd25519, secret)
+	if err != nil {
+		t.Fatalf("from secret: %v", err)
+	}
+	want, err := kp.SuiAddress()
+	if err != nil {
+		t.Fatalf("address: %v", err)
+	}
+
+	data := []byte(`["` + encodeEntry(keychain.SchemeEd25519, secret) + `"]`)
+	ks, err := Parse(data)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	addrs := ks.Addresses()
+	if len(addrs) != 1 || addrs[0] != want {
+		t.Fatalf("unexpected addresses %v, want %s", addrs, want)
+	}
+
+	loaded, err := ks.Keypair(want)
+	if err != nil {
+		t.Fatalf("keypair: %v", err)
+	}
+	if loaded.Scheme() != keychain.SchemeEd25519 {
+		t.Fatalf("unexpected scheme %v", loaded.Scheme())
+	}
+
+	if _, err := ks.Keypair("0x2"); !errors.Is(err, ErrKeyNotFound) {
+		t.Fatalf("expected ErrKeyNotFound, got %v", err)
+	}
+}
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	ks := New()
+	for _, scheme := range []keychain.Scheme{keychain.SchemeEd25519, keychain.SchemeSecp256k1, keychain.SchemeSecp256r1} {
+		kp, err := keypair.Generate(scheme)
+		if err != nil {
+			t.Fatalf("generate: %v", err)
+		}
+		if _, err := ks.Import(kp); err != nil {
+			t.Fatalf("import: %v", err)
+		}
+	}
+
+	dir := t.TempDir()
+	plainPath := filepath.Join(dir, "sui.keystore")
+	if err := ks.Save(plainPath); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	plain, err := Load(plainPath)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	assertSameAddresses(t, ks.Addresses(), plain.Addresses())
+
+	encPath := filepath.Join(dir, "sui.keystore.enc")
+	if err := ks.SaveEncrypted(encPath, "correct horse"); err != nil {
+		t.Fatalf("save encrypted: %v", err)
+	}
+	if _, err := Load(encPath); !errors.Is(err, ErrPassphraseRequired) {
+		t.Fatalf("expected ErrPassphraseRequired, got %v", err)
+	}
+	if _, err := LoadEncrypted(encPath, "wrong"); !errors.Is(err, ErrInvalidPassphrase) {
+		t.Fatalf("expected ErrInvalidPassphrase, got %v", err)
+	}
+	decrypted, err := LoadEncrypted(encPath, "correct horse")
+	if err != nil {
+		t.Fatalf("load encrypted: %v", err)
+	}
+	assertSameAddresses(t, ks.Addresses(), decrypted.Addresses())
+}
+
+func TestDecryptRejectsCostlyScryptParameters(t *testing.T) {
+	data, err := New().Encrypt("correct horse")
+	if err != nil {
+		t.Fatalf("encrypt: %v", err)
+	}
+
+	for _, field := range []string{"n", "r", "p"} {
+		var env map[string]any
+		if err := json.Unmarshal(data, &env); err != nil {
+			t.Fatalf("decode envelope: %v", err)
+		}
+		env[field] = 1 << 30
+		tampered, err := json.Marshal(env)
+		if err != nil {
+			t.Fatalf("encode envelope: %v", err)
+		}
+		if _, err := Decrypt(tampered, "correct horse"); err == nil || !strings.Contains(err.Error(), "scrypt parameters") {
+			t.Fatalf("%s: expected scrypt parameter error, got %v", field, err)
+		}
+	}
+}
+
+func assertSameAddresses(t *testing.T, want, got []string) {
+	t.Helper()
+	if len(want) != len(got) {
+		t.Fatalf("address count mismatch: got %d want %d", len(got), len(want))
+	}
+	for i := range want {
+		if want[i] != got[i] {
+			t.Fatalf("address %d mismatch: got %s want %s", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,222 @@
+// Package awskms provides a kms.Backend for asymmetric ECC_SECG_P256K1 and
+// ECC_NIST_P256 keys in AWS KMS. It talks to the KMS JSON API directly with
+// Signature Version 4, so it does not depend on the AWS SDK. Wrapper
+// encrypts secrets such as private keys with a symmetric KMS key.
+package awskms
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/open-move/sui-go-sdk/kms"
+)
+
+var _ kms.Backend = (*Backend)(nil)
+
+// Credentials are AWS access keys used to sign requests.
+type Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// CredentialsFromEnv reads AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and
+// AWS_SESSION_TOKEN from the environment.
+func CredentialsFromEnv() (Credentials, error) {
+	creds := Credentials{
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+	if creds.AccessKeyID == "" || creds.SecretAccessKey == "" {
+		return Credentials{}, errors.New("awskms: AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must be set")
+	}
+	return creds, nil
+}
+
+// Config configures a Backend.
+type Config struct {
+	// KeyID is the key ID, key ARN, alias name or alias ARN.
+	KeyID       string
+	Region      string
+	Credentials Credentials
+	// Endpoint overrides https://kms.<region>.amazonaws.com, e.g. for VPC endpoints.
+	Endpoint   string
+	HTTPClient *http.Client
+}
+
+// Backend signs with a single AWS KMS key.
+type Backend struct {
+	cfg        Config
+	endpoint   string
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// New returns a Backend for cfg.
+func New(cfg Config) (*Backend, error) {
+	if cfg.KeyID == "" {
+		return nil, errors.New("awskms: key ID is empty")
+	}
+	if cfg.Region == "" {
+		return nil, errors.New("awskms: region is empty")
+	}
+	if cfg.Credentials.AccessKeyID == "" || cfg.Credentials.SecretAccessKey == "" {
+		return nil, errors.New("awskms: credentials are incomplete")
+	}
+
+	b := &Backend{cfg: cfg, endpoint: cfg.Endpoint, httpClient: cfg.HTTPClient, now: time.Now}
+	if b.endpoint == "" {
+		b.endpoint = "https://kms." + cfg.Region + ".amazonaws.com/"
+	}
+	if b.httpClient == nil {
+		b.httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	return b, nil
+}
+
+// NewSigner is shorthand for creating a Backend and wrapping it in a kms.Signer.
+func NewSigner(ctx context.Context, cfg Config) (*kms.Signer, error) {
+	backend, err := New(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return kms.NewSigner(ctx, backend)
+}
+
+// PublicKey returns the key's DER-encoded SubjectPublicKeyInfo.
+func (b *Backend) PublicKey(ctx context.Context) ([]byte, error) {
+	var resp struct {
+		PublicKey []byte `json:"PublicKey"`
+	}
+	if err := b.call(ctx, "GetPublicKey", map[string]any{"KeyId": b.cfg.KeyID}, &resp); err != nil {
+		return nil, err
+	}
+	return resp.PublicKey, nil
+}
+
+// SignDigest signs a SHA-256 digest with ECDSA_SHA_256.
+func (b *Backend) SignDigest(ctx context.Context, digest []byte) ([]byte, error) {
+	req := map[string]any{
+		"KeyId":            b.cfg.KeyID,
+		"Message":          base64.StdEncoding.EncodeToString(digest),
+		"MessageType":      "DIGEST",
+		"SigningAlgorithm": "ECDSA_SHA_256",
+	}
+	var resp struct {
+		Signature []byte `json:"Signature"`
+	}
+	if err := b.call(ctx, "Sign", req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Signature, nil
+}
+
+// Wrapper encrypts and decrypts small secrets, such as private keys, with a
+// symmetric KMS key. It implements secrets.KeyWrapper.
+type Wrapper struct {
+	backend *Backend
+}
+
+// NewWrapper returns a Wrapper for the symmetric encryption key in cfg.
+func NewWrapper(cfg Config) (*Wrapper, error) {
+	backend, err := New(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &Wrapper{backend: backend}, nil
+}
+
+// Wrap encrypts plaintext, which must be at most 4096 bytes.
+func (w *Wrapper) Wrap(ctx context.Context, plaintext []byte) ([]byte, error) {
+	req := map[string]any{"KeyId": w.backend.cfg.KeyID, "Plaintext": plaintext}
+	var resp struct {
+		CiphertextBlob []byte `json:"CiphertextBlob"`
+	}
+	if err := w.backend.call(ctx, "Encrypt", req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.CiphertextBlob, nil
+}
+
+// Unwrap decrypts a ciphertext produced by Wrap.
+func (w *Wrapper) Unwrap(ctx context.Context, ciphertext []byte) ([]byte, error) {
+	req := map[string]any{"KeyId": w.backend.cfg.KeyID, "CiphertextBlob": ciphertext}
+	var resp struct {
+		Plaintext []byte `json:"Plaintext"`
+	}
+	if err := w.backend.call(ctx, "Decrypt", req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Plaintext, nil
+}
+
+// APIError is an error response from the KMS API.
+type APIError struct {
+	StatusCode int
+	Type       string
+	Message    string
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	return fmt.Sprintf("awskms: %s (HTTP %d): %s", e.Type, e.StatusCode, e.Message)
+}
+
+func (b *Backend) call(ctx context.Context, action string, payload any, out any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("awskms: marshal %s request: %w", action, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("awskms: create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "TrentService."+action)
+	signV4(req, body, b.cfg.Credentials, b.cfg.Region, "kms", b.now())
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("awskms: %s: %w", action, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("awskms: read %s response: %w", action, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		var errBody struct {
+			Type       string `json:"__type"`
+			Message    string `json:"message"`
+			MessageAlt string `json:"Message"`
+		}
+		if json.Unmarshal(data, &errBody) == nil {
+			apiErr.Type = errBody.Type
+			apiErr.Message = errBody.Message
+			if apiErr.Message == "" {
+				apiErr.Message = errBody.MessageAlt
+			}
+		}
+		if apiErr.Message == "" {
+			apiErr.Message = string(data)
+		}
+		return apiErr
+	}
+
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("awskms: decode %s response: %w", action, err)
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package awskms
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-move/sui-go-sdk/cryptography/secp256r1"
+)
+
+// TestSignV4 checks the "get-vanilla" case from the AWS SigV4 test suite.
+func TestSignV4(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	creds := Credentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"}
+	signV4(req, nil, creds, "us-east-1", "service", time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+	want := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, " +
+		"SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31"
+	if got := req.Header.Get("Authorization"); got != want {
+		t.Fatalf("authorization header\n got %s\nwant %s", got, want)
+	}
+}
+
+func TestBackendSigner(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	spki, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("marshal public key: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/") {
+			t.Errorf("missing SigV4 authorization: %q", r.Header.Get("Authorization"))
+		}
+		var req map[string]string
+		json.NewDecoder(r.Body).Decode(&req)
+		if req["KeyId"] != "alias/sui" {
+			t.Errorf("unexpected key ID %q", req["KeyId"])
+		}
+
+		switch r.Header.Get("X-Amz-Target") {
+		case "TrentService.GetPublicKey":
+			json.NewEncoder(w).Encode(map[string]any{"PublicKey": spki, "KeySpec": "ECC_NIST_P256"})
+		case "TrentService.Sign":
+			if req["MessageType"] != "DIGEST" || req["SigningAlgorithm"] != "ECDSA_SHA_256" {
+				t.Errorf("unexpected sign request %v", req)
+			}
+			digest, _ := base64.StdEncoding.DecodeString(req["Message"])
+			sig, _ := ecdsa.SignASN1(rand.Reader, key, digest)
+			json.NewEncoder(w).Encode(map[string]any{"Signature": sig})
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"__type":"UnknownOperationException","message":"unknown"}`))
+		}
+	}))
+	defer server.Close()
+
+	signer, err := NewSigner(context.Background(), Config{
+		KeyID:       "alias/sui",
+		Region:      "us-east-1",
+		Credentials: Credentials{AccessKeyID: "AKID", SecretAccessKey: "secret"},
+		Endpoint:    server.URL,
+	})
+	if err != nil {
+		t.Fatalf("new signer: %v", err)
+	}
+
+	message := []byte("custody")
+	sig, err := signer.SignPersonalMessage(message)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	if err := secp256r1.VerifyPersonalMessage(signer.PublicKey(), message, sig); err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+}
+
+func TestBackendAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"__type":"NotFoundException","message":"key not found"}`))
+	}))
+	defer server.Close()
+
+	backend, err := New(Config{
+		KeyID:       "missing",
+		Region:      "us-east-1",
+		Credentials: Credentials{AccessKeyID: "AKID", SecretAccessKey: "secret"},
+		Endpoint:    server.URL,
+	})
+	if err != nil {
+		t.Fatalf("new backend: %v", err)
+	}
+	_, err = backend.PublicKey(context.Background())
+	apiErr, ok := err.(*APIError)
+	if !ok || apiErr.Type != "NotFoundException" || apiErr.Message != "key not found" {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestWrapper(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string][]byte
+		json.NewDecoder(r.Body).Decode(&req)
+		flip := func(b []byte) []byte {
+			out := make([]byte, len(b))
+			for i := range b {
+				out[i] = b[i] ^ 0xff
+			}
+			return out
+		}
+		switch r.Header.Get("X-Amz-Target") {
+		case "TrentService.Encrypt":
+			json.NewEncoder(w).Encode(map[string]any{"CiphertextBlob": flip(req["Plaintext"])})
+		case "TrentService.Decrypt":
+			json.NewEncoder(w).Encode(map[string]any{"Plaintext": flip(req["CiphertextBlob"])})
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	wrapper, err := NewWrapper(Config{
+		KeyID:       "alias/secrets",
+		Region:      "us-east-1",
+		Credentials: Credentials{AccessKeyID: "AKID", SecretAccessKey: "secret"},
+		Endpoint:    server.URL,
+	})
+	if err != nil {
+		t.Fatalf("new wrapper: %v", err)
+	}
+
+	ciphertext, err := wrapper.Wrap(context.Background(), []byte("key material"))
+	if err != nil {
+		t.Fatalf("wrap: %v", err)
+	}
+	if string(ciphertext) == "key material" {
+		t.Fatal("wrap returned the plaintext")
+	}
+	plaintext, err := wrapper.Unwrap(context.Background(), ciphertext)
+	if err != nil {
+		t.Fatalf("unwrap: %v", err)
+	}
+	if string(plaintext) != "key material" {
+		t.Fatalf("unwrap returned %q", plaintext)
+	}
+}
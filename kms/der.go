@@ -0,0 +1,121 @@
+package kms
+
+import (
+	"crypto/elliptic"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/open-move/sui-go-sdk/keychain"
+)
+
+var (
+	oidPublicKeyECDSA = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	oidCurveSecp256k1 = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+	oidCurveP256      = asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7}
+)
+
+// ErrUnsupportedKey is returned for keys that are not secp256k1 or secp256r1.
+var ErrUnsupportedKey = errors.New("kms: unsupported key type")
+
+type subjectPublicKeyInfo struct {
+	Algorithm struct {
+		Algorithm  asn1.ObjectIdentifier
+		Parameters asn1.ObjectIdentifier
+	}
+	PublicKey asn1.BitString
+}
+
+type ecdsaSignature struct {
+	R, S *big.Int
+}
+
+// ParsePublicKey decodes a DER SubjectPublicKeyInfo holding a secp256k1 or
+// secp256r1 key and returns its scheme and compressed public key.
+//
+// crypto/x509 cannot be used here because it does not know secp256k1.
+func ParsePublicKey(der []byte) (keychain.Scheme, []byte, error) {
+	var info subjectPublicKeyInfo
+	rest, err := asn1.Unmarshal(der, &info)
+	if err != nil {
+		return 0, nil, fmt.Errorf("kms: parse public key: %w", err)
+	}
+	if len(rest) != 0 {
+		return 0, nil, errors.New("kms: trailing data after public key")
+	}
+	if !info.Algorithm.Algorithm.Equal(oidPublicKeyECDSA) {
+		return 0, nil, fmt.Errorf("%w: algorithm %v", ErrUnsupportedKey, info.Algorithm.Algorithm)
+	}
+
+	var scheme keychain.Scheme
+	switch {
+	case info.Algorithm.Parameters.Equal(oidCurveSecp256k1):
+		scheme = keychain.SchemeSecp256k1
+	case info.Algorithm.Parameters.Equal(oidCurveP256):
+		scheme = keychain.SchemeSecp256r1
+	default:
+		return 0, nil, fmt.Errorf("%w: curve %v", ErrUnsupportedKey, info.Algorithm.Parameters)
+	}
+
+	point := info.PublicKey.RightAlign()
+	compressed, err := compressPoint(point)
+	if err != nil {
+		return 0, nil, err
+	}
+	return scheme, compressed, nil
+}
+
+// compressPoint converts an SEC1 uncompressed point to its 33-byte compressed form.
+func compressPoint(point []byte) ([]byte, error) {
+	switch {
+	case len(point) == 33 && (point[0] == 0x02 || point[0] == 0x03):
+		return append([]byte(nil), point...), nil
+	case len(point) == 65 && point[0] == 0x04:
+		out := make([]byte, 33)
+		out[0] = 0x02 | (point[64] & 1)
+		copy(out[1:], point[1:33])
+		return out, nil
+	default:
+		return nil, fmt.Errorf("kms: invalid public key point of %d bytes", len(point))
+	}
+}
+
+// CompactSignature converts a DER-encoded ECDSA signature into the 64-byte
+// `r || s` form used by Sui, normalizing s to the lower half of the curve
+// order as Sui requires.
+func CompactSignature(scheme keychain.Scheme, der []byte) ([]byte, error) {
+	var order *big.Int
+	switch scheme {
+	case keychain.SchemeSecp256k1:
+		order = secp256k1.S256().N
+	case keychain.SchemeSecp256r1:
+		order = elliptic.P256().Params().N
+	default:
+		return nil, fmt.Errorf("%w: scheme %s", ErrUnsupportedKey, scheme.Label())
+	}
+
+	var sig ecdsaSignature
+	rest, err := asn1.Unmarshal(der, &sig)
+	if err != nil {
+		return nil, fmt.Errorf("kms: parse signature: %w", err)
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("kms: trailing data after signature")
+	}
+	if sig.R == nil || sig.S == nil || sig.R.Sign() <= 0 || sig.S.Sign() <= 0 ||
+		sig.R.Cmp(order) >= 0 || sig.S.Cmp(order) >= 0 {
+		return nil, errors.New("kms: signature out of range")
+	}
+
+	s := sig.S
+	if s.Cmp(new(big.Int).Rsh(order, 1)) > 0 {
+		s = new(big.Int).Sub(order, s)
+	}
+
+	out := make([]byte, 64)
+	sig.R.FillBytes(out[:32])
+	s.FillBytes(out[32:])
+	return out, nil
+}
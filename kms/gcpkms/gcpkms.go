@@ -0,0 +1,183 @@
+// Package gcpkms provides a kms.Backend for EC_SIGN_SECP256K1_SHA256 and
+// EC_SIGN_P256_SHA256 key versions in Google Cloud KMS. It uses the Cloud KMS
+// REST API directly, so it does not depend on the Google Cloud client
+// libraries; callers supply OAuth2 access tokens through TokenSource.
+package gcpkms
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/open-move/sui-go-sdk/kms"
+)
+
+// DefaultEndpoint is the Cloud KMS REST endpoint.
+const DefaultEndpoint = "https://cloudkms.googleapis.com/v1/"
+
+var _ kms.Backend = (*Backend)(nil)
+
+// TokenSource returns an OAuth2 access token with the cloudkms scope, e.g.
+// from golang.org/x/oauth2/google or the metadata server.
+type TokenSource func(ctx context.Context) (string, error)
+
+// StaticToken returns a TokenSource that always yields token.
+func StaticToken(token string) TokenSource {
+	return func(context.Context) (string, error) {
+		return token, nil
+	}
+}
+
+// Config configures a Backend.
+type Config struct {
+	// KeyVersion is the full resource name, e.g.
+	// projects/p/locations/l/keyRings/r/cryptoKeys/k/cryptoKeyVersions/1.
+	KeyVersion  string
+	TokenSource TokenSource
+	// Endpoint overrides DefaultEndpoint.
+	Endpoint   string
+	HTTPClient *http.Client
+}
+
+// Backend signs with a single Cloud KMS key version.
+type Backend struct {
+	cfg        Config
+	endpoint   string
+	httpClient *http.Client
+}
+
+// New returns a Backend for cfg.
+func New(cfg Config) (*Backend, error) {
+	if cfg.KeyVersion == "" {
+		return nil, errors.New("gcpkms: key version is empty")
+	}
+	if cfg.TokenSource == nil {
+		return nil, errors.New("gcpkms: token source is nil")
+	}
+
+	b := &Backend{cfg: cfg, endpoint: cfg.Endpoint, httpClient: cfg.HTTPClient}
+	if b.endpoint == "" {
+		b.endpoint = DefaultEndpoint
+	}
+	if !strings.HasSuffix(b.endpoint, "/") {
+		b.endpoint += "/"
+	}
+	if b.httpClient == nil {
+		b.httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	return b, nil
+}
+
+// NewSigner is shorthand for creating a Backend and wrapping it in a kms.Signer.
+func NewSigner(ctx context.Context, cfg Config) (*kms.Signer, error) {
+	backend, err := New(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return kms.NewSigner(ctx, backend)
+}
+
+// PublicKey returns the key version's DER-encoded SubjectPublicKeyInfo.
+func (b *Backend) PublicKey(ctx context.Context) ([]byte, error) {
+	var resp struct {
+		Pem string `json:"pem"`
+	}
+	if err := b.call(ctx, http.MethodGet, b.cfg.KeyVersion+"/publicKey", nil, &resp); err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode([]byte(resp.Pem))
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, errors.New("gcpkms: response does not contain a PEM public key")
+	}
+	return block.Bytes, nil
+}
+
+// SignDigest signs a SHA-256 digest.
+func (b *Backend) SignDigest(ctx context.Context, digest []byte) ([]byte, error) {
+	req := map[string]any{
+		"digest": map[string]string{"sha256": base64.StdEncoding.EncodeToString(digest)},
+	}
+	var resp struct {
+		Signature []byte `json:"signature"`
+	}
+	if err := b.call(ctx, http.MethodPost, b.cfg.KeyVersion+":asymmetricSign", req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Signature, nil
+}
+
+// APIError is an error response from the Cloud KMS API.
+type APIError struct {
+	StatusCode int
+	Status     string
+	Message    string
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	return fmt.Sprintf("gcpkms: %s (HTTP %d): %s", e.Status, e.StatusCode, e.Message)
+}
+
+func (b *Backend) call(ctx context.Context, method, path string, payload any, out any) error {
+	token, err := b.cfg.TokenSource(ctx)
+	if err != nil {
+		return fmt.Errorf("gcpkms: access token: %w", err)
+	}
+
+	var body io.Reader
+	if payload != nil {
+		encoded, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("gcpkms: marshal request: %w", err)
+		}
+		body = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, b.endpoint+path, body)
+	if err != nil {
+		return fmt.Errorf("gcpkms: create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("gcpkms: %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("gcpkms: read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		apiErr := &APIError{StatusCode: resp.StatusCode, Message: string(data)}
+		var errBody struct {
+			Error struct {
+				Status  string `json:"status"`
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if json.Unmarshal(data, &errBody) == nil && errBody.Error.Message != "" {
+			apiErr.Status = errBody.Error.Status
+			apiErr.Message = errBody.Error.Message
+		}
+		return apiErr
+	}
+
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("gcpkms: decode response: %w", err)
+	}
+	return nil
+}
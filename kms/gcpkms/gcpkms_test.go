@@ -0,0 +1,82 @@
+package gcpkms
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/cryptography/secp256r1"
+)
+
+const keyVersion = "projects/p/locations/global/keyRings/r/cryptoKeys/k/cryptoKeyVersions/1"
+
+func TestBackendSigner(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	spki, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("marshal public key: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			t.Errorf("unexpected authorization %q", r.Header.Get("Authorization"))
+		}
+		switch r.URL.Path {
+		case "/" + keyVersion + "/publicKey":
+			pemKey := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: spki})
+			json.NewEncoder(w).Encode(map[string]any{"pem": string(pemKey), "algorithm": "EC_SIGN_P256_SHA256"})
+		case "/" + keyVersion + ":asymmetricSign":
+			var req struct {
+				Digest struct {
+					Sha256 string `json:"sha256"`
+				} `json:"digest"`
+			}
+			json.NewDecoder(r.Body).Decode(&req)
+			digest, _ := base64.StdEncoding.DecodeString(req.Digest.Sha256)
+			sig, _ := ecdsa.SignASN1(rand.Reader, key, digest)
+			json.NewEncoder(w).Encode(map[string]any{"signature": sig})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":{"status":"NOT_FOUND","message":"no such key"}}`))
+		}
+	}))
+	defer server.Close()
+
+	signer, err := NewSigner(context.Background(), Config{
+		KeyVersion:  keyVersion,
+		TokenSource: StaticToken("token"),
+		Endpoint:    server.URL,
+	})
+	if err != nil {
+		t.Fatalf("new signer: %v", err)
+	}
+
+	message := []byte("custody")
+	sig, err := signer.SignPersonalMessage(message)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	if err := secp256r1.VerifyPersonalMessage(signer.PublicKey(), message, sig); err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+
+	missing, err := New(Config{KeyVersion: "missing", TokenSource: StaticToken("token"), Endpoint: server.URL})
+	if err != nil {
+		t.Fatalf("new backend: %v", err)
+	}
+	_, err = missing.PublicKey(context.Background())
+	if apiErr, ok := err.(*APIError); !ok || apiErr.Status != "NOT_FOUND" {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
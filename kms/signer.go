@@ -0,0 +1,115 @@
+// Package kms implements a keypair.Signer backed by a remote key management
+// service. The private key never leaves the service: the signer hashes the
+// intent message locally and asks the backend to sign the SHA-256 digest.
+//
+// Backends for AWS KMS and Google Cloud KMS live in the awskms and gcpkms
+// subpackages; any other service can be plugged in by implementing Backend.
+package kms
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/open-move/sui-go-sdk/cryptography/intent"
+	"github.com/open-move/sui-go-sdk/cryptography/personalmsg"
+	"github.com/open-move/sui-go-sdk/cryptography/transaction"
+	"github.com/open-move/sui-go-sdk/keychain"
+	"github.com/open-move/sui-go-sdk/keypair"
+)
+
+var _ keypair.Signer = (*Signer)(nil)
+
+// Backend is a secp256k1 or secp256r1 key held by a key management service.
+type Backend interface {
+	// PublicKey returns the key's DER-encoded SubjectPublicKeyInfo.
+	PublicKey(ctx context.Context) ([]byte, error)
+	// SignDigest signs a SHA-256 digest and returns a DER-encoded ECDSA signature.
+	SignDigest(ctx context.Context, digest []byte) ([]byte, error)
+}
+
+// Signer is a keypair.Signer backed by a KMS key.
+type Signer struct {
+	backend   Backend
+	scheme    keychain.Scheme
+	publicKey []byte
+	address   string
+}
+
+// NewSigner fetches the backend's public key, detects its curve and returns a
+// signer for the corresponding Sui address.
+func NewSigner(ctx context.Context, backend Backend) (*Signer, error) {
+	if backend == nil {
+		return nil, errors.New("kms: nil backend")
+	}
+
+	der, err := backend.PublicKey(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("kms: fetch public key: %w", err)
+	}
+	scheme, publicKey, err := ParsePublicKey(der)
+	if err != nil {
+		return nil, err
+	}
+	address, err := keychain.AddressFromPublicKey(scheme, publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("kms: %w", err)
+	}
+
+	return &Signer{backend: backend, scheme: scheme, publicKey: publicKey, address: address}, nil
+}
+
+// PublicKey returns a copy of the compressed public key.
+func (s *Signer) PublicKey() []byte {
+	return append([]byte(nil), s.publicKey...)
+}
+
+// Scheme returns the signature scheme of the KMS key.
+func (s *Signer) Scheme() keychain.Scheme {
+	return s.scheme
+}
+
+// SuiAddress returns the Sui address of the KMS key.
+func (s *Signer) SuiAddress() (string, error) {
+	return s.address, nil
+}
+
+// SignTransaction signs BCS-encoded TransactionData and returns the serialized
+// signature `flag || sig || pubkey`.
+func (s *Signer) SignTransaction(txBytes []byte) ([]byte, error) {
+	return s.SignTransactionContext(context.Background(), txBytes)
+}
+
+// SignTransactionContext is SignTransaction with a context for the KMS call.
+func (s *Signer) SignTransactionContext(ctx context.Context, txBytes []byte) ([]byte, error) {
+	return transaction.Sign(s.scheme, txBytes, s.publicKey, s.signFunc(ctx))
+}
+
+// SignWithIntent signs a BCS-serialized payload under an arbitrary intent scope.
+func (s *Signer) SignWithIntent(scope intent.IntentScope, payload []byte) ([]byte, error) {
+	return transaction.SignWithIntent(s.scheme, scope, payload, s.publicKey, s.signFunc(context.Background()))
+}
+
+// SignPersonalMessage signs a personal message and returns the serialized
+// signature `flag || sig || pubkey`.
+func (s *Signer) SignPersonalMessage(message []byte) ([]byte, error) {
+	return personalmsg.Sign(s.scheme, message, s.publicKey, s.signFunc(context.Background()))
+}
+
+// signFunc adapts the backend to the signing helpers, which hand over the
+// 32-byte intent digest. Sui ECDSA signatures are over SHA-256 of that digest.
+func (s *Signer) signFunc(ctx context.Context) func([]byte) ([]byte, error) {
+	return func(digest []byte) ([]byte, error) {
+		if s == nil || s.backend == nil {
+			return nil, errors.New("kms: nil signer")
+		}
+
+		hash := sha256.Sum256(digest)
+		der, err := s.backend.SignDigest(ctx, hash[:])
+		if err != nil {
+			return nil, fmt.Errorf("kms: sign: %w", err)
+		}
+		return CompactSignature(s.scheme, der)
+	}
+}
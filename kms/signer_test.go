@@ -0,0 +1,131 @@
+package kms
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/asn1"
+	"math/big"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	secp256k1ecdsa "github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	k1 "github.com/open-move/sui-go-sdk/cryptography/secp256k1"
+	r1 "github.com/open-move/sui-go-sdk/cryptography/secp256r1"
+	"github.com/open-move/sui-go-sdk/keychain"
+)
+
+// k1Backend emulates a KMS secp256k1 key. It always returns high-s
+// signatures to exercise normalization.
+type k1Backend struct {
+	key *secp256k1.PrivateKey
+}
+
+func (b k1Backend) PublicKey(context.Context) ([]byte, error) {
+	var info subjectPublicKeyInfo
+	info.Algorithm.Algorithm = oidPublicKeyECDSA
+	info.Algorithm.Parameters = oidCurveSecp256k1
+	point := b.key.PubKey().SerializeUncompressed()
+	info.PublicKey = asn1.BitString{Bytes: point, BitLength: len(point) * 8}
+	return asn1.Marshal(info)
+}
+
+func (b k1Backend) SignDigest(_ context.Context, digest []byte) ([]byte, error) {
+	der := secp256k1ecdsa.Sign(b.key, digest).Serialize()
+	var sig ecdsaSignature
+	if _, err := asn1.Unmarshal(der, &sig); err != nil {
+		return nil, err
+	}
+	n := secp256k1.S256().N
+	if sig.S.Cmp(new(big.Int).Rsh(n, 1)) <= 0 {
+		sig.S = new(big.Int).Sub(n, sig.S)
+	}
+	return asn1.Marshal(sig)
+}
+
+type p256Backend struct {
+	key *ecdsa.PrivateKey
+}
+
+func (b p256Backend) PublicKey(context.Context) ([]byte, error) {
+	return x509.MarshalPKIXPublicKey(&b.key.PublicKey)
+}
+
+func (b p256Backend) SignDigest(_ context.Context, digest []byte) ([]byte, error) {
+	return ecdsa.SignASN1(rand.Reader, b.key, digest)
+}
+
+func TestSignerSecp256k1(t *testing.T) {
+	key, err := secp256k1.GeneratePrivateKey()
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	signer, err := NewSigner(context.Background(), k1Backend{key: key})
+	if err != nil {
+		t.Fatalf("new signer: %v", err)
+	}
+
+	if signer.Scheme() != keychain.SchemeSecp256k1 {
+		t.Fatalf("unexpected scheme %v", signer.Scheme())
+	}
+	if !bytes.Equal(signer.PublicKey(), key.PubKey().SerializeCompressed()) {
+		t.Fatal("public key was not compressed correctly")
+	}
+	want, _ := keychain.AddressFromPublicKey(keychain.SchemeSecp256k1, key.PubKey().SerializeCompressed())
+	if got, _ := signer.SuiAddress(); got != want {
+		t.Fatalf("address %s, want %s", got, want)
+	}
+
+	message := []byte("hello from kms")
+	sig, err := signer.SignPersonalMessage(message)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	if err := k1.VerifyPersonalMessage(signer.PublicKey(), message, sig); err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	s := new(big.Int).SetBytes(sig[33:65])
+	if s.Cmp(new(big.Int).Rsh(secp256k1.S256().N, 1)) > 0 {
+		t.Fatal("signature s was not normalized")
+	}
+}
+
+func TestSignerSecp256r1(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	signer, err := NewSigner(context.Background(), p256Backend{key: key})
+	if err != nil {
+		t.Fatalf("new signer: %v", err)
+	}
+	if signer.Scheme() != keychain.SchemeSecp256r1 {
+		t.Fatalf("unexpected scheme %v", signer.Scheme())
+	}
+
+	message := []byte("hello from kms")
+	sig, err := signer.SignPersonalMessage(message)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	if err := r1.VerifyPersonalMessage(signer.PublicKey(), message, sig); err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+}
+
+func TestParsePublicKeyRejectsUnsupportedCurve(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if _, _, err := ParsePublicKey(der); err == nil {
+		t.Fatal("expected P-384 key to be rejected")
+	}
+}
@@ -0,0 +1,178 @@
+// Package ledger talks to the Sui application on Ledger hardware wallets.
+//
+// The package speaks the Sui app's APDU protocol but does not open USB devices
+// itself; callers supply a Transport (HID, Speculos, or a test double).
+package ledger
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/open-move/sui-go-sdk/keychain"
+)
+
+// DefaultDerivationPath is the first Ed25519 account used by Sui wallets.
+const DefaultDerivationPath = "m/44'/784'/0'/0'/0'"
+
+const (
+	claSui byte = 0x00
+
+	insGetVersion    byte = 0x00
+	insVerifyAddress byte = 0x01
+	insGetPublicKey  byte = 0x02
+	insSignTx        byte = 0x03
+)
+
+// Version describes the Sui application running on the device.
+type Version struct {
+	Major uint8
+	Minor uint8
+	Patch uint8
+}
+
+// String returns the semantic version string.
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// PublicKeyResult is the device response for public key requests.
+type PublicKeyResult struct {
+	PublicKey []byte
+	Address   string
+}
+
+// Device issues Sui app instructions over a Transport.
+type Device struct {
+	transport Transport
+}
+
+// NewDevice wraps a transport connected to a Ledger running the Sui app.
+func NewDevice(transport Transport) (*Device, error) {
+	if transport == nil {
+		return nil, errors.New("ledger: nil transport")
+	}
+	return &Device{transport: transport}, nil
+}
+
+// Close releases the underlying transport.
+func (d *Device) Close() error {
+	if d == nil || d.transport == nil {
+		return nil
+	}
+	return d.transport.Close()
+}
+
+// GetVersion returns the version of the Sui application.
+func (d *Device) GetVersion() (Version, error) {
+	resp, err := d.sendChunks(insGetVersion, []byte{0})
+	if err != nil {
+		return Version{}, err
+	}
+	if len(resp) < 3 {
+		return Version{}, fmt.Errorf("ledger: short version response (%d bytes)", len(resp))
+	}
+	return Version{Major: resp[0], Minor: resp[1], Patch: resp[2]}, nil
+}
+
+// GetPublicKey returns the Ed25519 public key and Sui address at path.
+func (d *Device) GetPublicKey(path string) (PublicKeyResult, error) {
+	return d.publicKey(insGetPublicKey, path)
+}
+
+// VerifyAddress displays the address at path on the device for confirmation
+// and returns it once the user approves.
+func (d *Device) VerifyAddress(path string) (PublicKeyResult, error) {
+	return d.publicKey(insVerifyAddress, path)
+}
+
+// SignTransaction asks the device to sign an intent message (intent prefix
+// followed by the BCS payload) with the key at path. It returns the raw
+// 64-byte Ed25519 signature.
+func (d *Device) SignTransaction(path string, intentMessage []byte) ([]byte, error) {
+	pathPayload, err := encodePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := make([]byte, 4, 4+len(intentMessage))
+	binary.LittleEndian.PutUint32(payload, uint32(len(intentMessage)))
+	payload = append(payload, intentMessage...)
+
+	sig, err := d.sendChunks(insSignTx, payload, pathPayload)
+	if err != nil {
+		return nil, err
+	}
+	if len(sig) != 64 {
+		return nil, fmt.Errorf("ledger: unexpected signature length %d", len(sig))
+	}
+	return sig, nil
+}
+
+func (d *Device) publicKey(ins byte, path string) (PublicKeyResult, error) {
+	pathPayload, err := encodePath(path)
+	if err != nil {
+		return PublicKeyResult{}, err
+	}
+
+	resp, err := d.sendChunks(ins, pathPayload)
+	if err != nil {
+		return PublicKeyResult{}, err
+	}
+
+	if len(resp) < 1 || len(resp) < 1+int(resp[0]) {
+		return PublicKeyResult{}, errors.New("ledger: malformed public key response")
+	}
+	keyLen := int(resp[0])
+	publicKey := append([]byte(nil), resp[1:1+keyLen]...)
+	if len(publicKey) != 32 {
+		return PublicKeyResult{}, fmt.Errorf("ledger: unexpected public key length %d", len(publicKey))
+	}
+
+	address, err := keychain.AddressFromPublicKey(keychain.SchemeEd25519, publicKey)
+	if err != nil {
+		return PublicKeyResult{}, err
+	}
+	return PublicKeyResult{PublicKey: publicKey, Address: address}, nil
+}
+
+func (d *Device) exchange(ins byte, data []byte) ([]byte, error) {
+	if d == nil || d.transport == nil {
+		return nil, errors.New("ledger: nil device")
+	}
+
+	apdu, err := encodeAPDU(claSui, ins, 0, 0, data)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := d.transport.Exchange(apdu)
+	if err != nil {
+		return nil, fmt.Errorf("ledger: exchange: %w", err)
+	}
+	return splitResponse(resp)
+}
+
+// encodePath serializes a BIP-32 path as a segment count followed by
+// little-endian uint32 indices.
+func encodePath(path string) ([]byte, error) {
+	if path == "" {
+		path = DefaultDerivationPath
+	}
+
+	parsed, err := keychain.ParseDerivationPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("ledger: %w", err)
+	}
+	if err := parsed.ValidateForScheme(keychain.SchemeEd25519); err != nil {
+		return nil, fmt.Errorf("ledger: %w", err)
+	}
+
+	segments := parsed.Segments()
+	out := make([]byte, 1, 1+4*len(segments))
+	out[0] = byte(len(segments))
+	for _, segment := range segments {
+		out = binary.LittleEndian.AppendUint32(out, segment.HardenedIndex())
+	}
+	return out, nil
+}
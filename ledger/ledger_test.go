@@ -0,0 +1,150 @@
+package ledger
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/open-move/sui-go-sdk/keychain"
+)
+
+// fakeDevice emulates the Sui app block protocol with an in-memory Ed25519 key.
+type fakeDevice struct {
+	key     ed25519.PrivateKey
+	reject  bool
+	pending [][32]byte
+	params  [][]byte
+	current []byte
+	ins     byte
+}
+
+func (f *fakeDevice) Close() error { return nil }
+
+func (f *fakeDevice) Exchange(apdu []byte) ([]byte, error) {
+	ins, data := apdu[1], apdu[5:]
+	switch data[0] {
+	case hostStart:
+		f.ins = ins
+		f.params = nil
+		f.pending = nil
+		for rest := data[1:]; len(rest) >= 32; rest = rest[32:] {
+			var h [32]byte
+			copy(h[:], rest[:32])
+			f.pending = append(f.pending, h)
+		}
+		return f.next()
+	case hostGetChunkResponseSuccess:
+		chunk := data[1:]
+		f.current = append(f.current, chunk[32:]...)
+		var next [32]byte
+		copy(next[:], chunk[:32])
+		if next != ([32]byte{}) {
+			return f.request(next), nil
+		}
+		f.params = append(f.params, f.current)
+		f.current = nil
+		return f.next()
+	}
+	return []byte{0x6a, 0x80}, nil
+}
+
+func (f *fakeDevice) request(hash [32]byte) []byte {
+	return append(append([]byte{deviceGetChunk}, hash[:]...), 0x90, 0x00)
+}
+
+func (f *fakeDevice) next() ([]byte, error) {
+	if len(f.pending) > 0 {
+		hash := f.pending[0]
+		f.pending = f.pending[1:]
+		return f.request(hash), nil
+	}
+
+	var result []byte
+	switch f.ins {
+	case insGetPublicKey:
+		pub := f.key.Public().(ed25519.PublicKey)
+		result = append([]byte{32}, pub...)
+		result = append(result, 0)
+	case insSignTx:
+		if f.reject {
+			return []byte{0x69, 0x85}, nil
+		}
+		msg := f.params[0][4:]
+		if int(binary.LittleEndian.Uint32(f.params[0])) != len(msg) {
+			return nil, errors.New("bad length prefix")
+		}
+		digest := blake2b.Sum256(msg)
+		result = ed25519.Sign(f.key, digest[:])
+	}
+	return append(append([]byte{deviceResultFinal}, result...), 0x90, 0x00), nil
+}
+
+func TestSignerSignTransaction(t *testing.T) {
+	seed := bytes.Repeat([]byte{7}, ed25519.SeedSize)
+	fake := &fakeDevice{key: ed25519.NewKeyFromSeed(seed)}
+	device, err := NewDevice(fake)
+	if err != nil {
+		t.Fatalf("new device: %v", err)
+	}
+
+	signer, err := NewSigner(device, "")
+	if err != nil {
+		t.Fatalf("new signer: %v", err)
+	}
+
+	pub := fake.key.Public().(ed25519.PublicKey)
+	wantAddress, _ := keychain.AddressFromPublicKey(keychain.SchemeEd25519, pub)
+	if address, _ := signer.SuiAddress(); address != wantAddress {
+		t.Fatalf("address mismatch: got %s want %s", address, wantAddress)
+	}
+
+	// Large enough to span several linked chunks.
+	txBytes := bytes.Repeat([]byte{0xab}, 3*blockChunkSize+17)
+	sig, err := signer.SignTransaction(txBytes)
+	if err != nil {
+		t.Fatalf("sign transaction: %v", err)
+	}
+	if len(sig) != 1+64+32 || sig[0] != keychain.SchemeEd25519.AddressFlag() {
+		t.Fatalf("unexpected serialized signature layout")
+	}
+
+	digest := blake2b.Sum256(append([]byte{0, 0, 0}, txBytes...))
+	if !ed25519.Verify(pub, digest[:], sig[1:65]) {
+		t.Fatalf("signature does not verify")
+	}
+	if !bytes.Equal(sig[65:], pub) {
+		t.Fatalf("public key suffix mismatch")
+	}
+}
+
+func TestSignerUserRejection(t *testing.T) {
+	fake := &fakeDevice{key: ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize)), reject: true}
+	device, _ := NewDevice(fake)
+	signer, err := NewSigner(device, DefaultDerivationPath)
+	if err != nil {
+		t.Fatalf("new signer: %v", err)
+	}
+
+	if _, err := signer.SignTransaction([]byte{1, 2, 3}); !errors.Is(err, ErrUserRejected) {
+		t.Fatalf("expected ErrUserRejected, got %v", err)
+	}
+}
+
+func TestLinkChunks(t *testing.T) {
+	chunks := map[string][]byte{}
+	data := bytes.Repeat([]byte{1}, blockChunkSize+1)
+	first := linkChunks(data, chunks)
+	if len(chunks) != 2 {
+		t.Fatalf("expected 2 chunks, got %d", len(chunks))
+	}
+	for _, chunk := range chunks {
+		if sha256.Sum256(chunk) == first && len(chunk) != 32+blockChunkSize {
+			t.Fatalf("first chunk should carry a full block")
+		}
+	}
+}
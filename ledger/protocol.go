@@ -0,0 +1,102 @@
+package ledger
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// The Sui Ledger application streams large payloads using a hash-linked block
+// protocol: each parameter is split into chunks, every chunk is prefixed with
+// the SHA-256 of the chunk that follows it, and the device pulls chunks by hash
+// as it needs them. Results may be accumulated across several round trips.
+
+const blockChunkSize = 180
+
+const (
+	hostStart                     byte = 0x00
+	hostGetChunkResponseSuccess   byte = 0x01
+	hostGetChunkResponseFailure   byte = 0x02
+	hostPutChunkResponse          byte = 0x03
+	hostResultAccumulatingRespond byte = 0x04
+)
+
+const (
+	deviceResultAccumulating byte = 0x00
+	deviceResultFinal        byte = 0x01
+	deviceGetChunk           byte = 0x02
+	devicePutChunk           byte = 0x03
+)
+
+// sendChunks runs the block protocol for an instruction and returns the final result payload.
+func (d *Device) sendChunks(ins byte, params ...[]byte) ([]byte, error) {
+	chunks := make(map[string][]byte)
+	start := []byte{hostStart}
+	for _, param := range params {
+		hash := linkChunks(param, chunks)
+		start = append(start, hash[:]...)
+	}
+
+	var result []byte
+	payload := start
+	for {
+		resp, err := d.exchange(ins, payload)
+		if err != nil {
+			return nil, err
+		}
+		if len(resp) == 0 {
+			return nil, fmt.Errorf("ledger: empty block protocol response")
+		}
+
+		switch resp[0] {
+		case deviceResultAccumulating:
+			result = append(result, resp[1:]...)
+			payload = []byte{hostResultAccumulatingRespond}
+		case deviceResultFinal:
+			result = append(result, resp[1:]...)
+			return result, nil
+		case deviceGetChunk:
+			if len(resp) < 1+sha256.Size {
+				return nil, fmt.Errorf("ledger: malformed chunk request")
+			}
+			chunk, ok := chunks[hex.EncodeToString(resp[1:1+sha256.Size])]
+			if !ok {
+				payload = []byte{hostGetChunkResponseFailure}
+				continue
+			}
+			payload = append([]byte{hostGetChunkResponseSuccess}, chunk...)
+		case devicePutChunk:
+			data := append([]byte(nil), resp[1:]...)
+			hash := sha256.Sum256(data)
+			chunks[hex.EncodeToString(hash[:])] = data
+			payload = []byte{hostPutChunkResponse}
+		default:
+			return nil, fmt.Errorf("ledger: unknown block protocol command 0x%02x", resp[0])
+		}
+	}
+}
+
+// linkChunks splits data into hash-linked chunks, records them by hash, and
+// returns the hash of the first chunk.
+func linkChunks(data []byte, chunks map[string][]byte) [sha256.Size]byte {
+	var parts [][]byte
+	for offset := 0; offset < len(data); offset += blockChunkSize {
+		end := offset + blockChunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+		parts = append(parts, data[offset:end])
+	}
+	if len(parts) == 0 {
+		parts = append(parts, nil)
+	}
+
+	var next [sha256.Size]byte
+	for i := len(parts) - 1; i >= 0; i-- {
+		linked := bytes.Join([][]byte{next[:], parts[i]}, nil)
+		next = sha256.Sum256(linked)
+		chunks[hex.EncodeToString(next[:])] = linked
+	}
+	return next
+}
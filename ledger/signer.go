@@ -0,0 +1,109 @@
+package ledger
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/open-move/sui-go-sdk/cryptography/intent"
+	"github.com/open-move/sui-go-sdk/keychain"
+	"github.com/open-move/sui-go-sdk/keypair"
+	"github.com/open-move/sui-go-sdk/types"
+)
+
+var _ keypair.Signer = (*Signer)(nil)
+
+// Signer is a keypair.Signer backed by a Ledger device. The private key never
+// leaves the device; every signature requires confirmation on the hardware.
+type Signer struct {
+	device    *Device
+	path      string
+	publicKey []byte
+	address   string
+}
+
+// NewSigner fetches the public key at path (DefaultDerivationPath when empty)
+// and returns a signer bound to that account.
+func NewSigner(device *Device, path string) (*Signer, error) {
+	if device == nil {
+		return nil, errors.New("ledger: nil device")
+	}
+	if path == "" {
+		path = DefaultDerivationPath
+	}
+
+	result, err := device.GetPublicKey(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Signer{device: device, path: path, publicKey: result.PublicKey, address: result.Address}, nil
+}
+
+// Path returns the derivation path the signer is bound to.
+func (s *Signer) Path() string {
+	return s.path
+}
+
+// PublicKey returns a copy of the Ed25519 public key.
+func (s *Signer) PublicKey() []byte {
+	return append([]byte(nil), s.publicKey...)
+}
+
+// Scheme returns the signature scheme (always Ed25519 for the Sui app).
+func (s *Signer) Scheme() keychain.Scheme {
+	return keychain.SchemeEd25519
+}
+
+// SuiAddress returns the Sui address of the device account.
+func (s *Signer) SuiAddress() (string, error) {
+	return s.address, nil
+}
+
+// SignTransaction signs BCS-encoded TransactionData on the device and returns
+// the serialized signature `flag || sig || pubkey`.
+func (s *Signer) SignTransaction(txBytes []byte) ([]byte, error) {
+	if len(txBytes) == 0 {
+		return nil, errors.New("ledger: empty transaction bytes")
+	}
+
+	prefix := intent.DefaultIntent().Bytes()
+	message := make([]byte, 0, len(prefix)+len(txBytes))
+	message = append(message, prefix[:]...)
+	message = append(message, txBytes...)
+	return s.sign(message)
+}
+
+// SignPersonalMessage signs a personal message on the device and returns the
+// serialized signature `flag || sig || pubkey`.
+func (s *Signer) SignPersonalMessage(message []byte) ([]byte, error) {
+	if len(message) == 0 {
+		return nil, errors.New("ledger: empty personal message")
+	}
+
+	intentMsg := intent.NewIntentMessage(
+		intent.DefaultIntent().WithScope(intent.IntentScopePersonalMessage),
+		types.PersonalMessage{Message: append([]byte(nil), message...)},
+	)
+	encoded, err := intentMsg.MarshalBCS()
+	if err != nil {
+		return nil, fmt.Errorf("ledger: %w", err)
+	}
+	return s.sign(encoded)
+}
+
+func (s *Signer) sign(intentMessage []byte) ([]byte, error) {
+	if s == nil || s.device == nil {
+		return nil, errors.New("ledger: nil signer")
+	}
+
+	sig, err := s.device.SignTransaction(s.path, intentMessage)
+	if err != nil {
+		return nil, err
+	}
+
+	serialized := make([]byte, 0, 1+len(sig)+len(s.publicKey))
+	serialized = append(serialized, keychain.SchemeEd25519.AddressFlag())
+	serialized = append(serialized, sig...)
+	serialized = append(serialized, s.publicKey...)
+	return serialized, nil
+}
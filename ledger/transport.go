@@ -0,0 +1,83 @@
+package ledger
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Transport exchanges raw APDU frames with a Ledger device.
+//
+// Implementations typically wrap a USB HID or Speculos TCP connection. Exchange
+// receives a fully encoded command APDU and must return the device response
+// including the trailing two-byte status word.
+type Transport interface {
+	Exchange(apdu []byte) ([]byte, error)
+	Close() error
+}
+
+// Status words returned by the Sui Ledger application.
+const (
+	StatusOK                  uint16 = 0x9000
+	StatusUserRejected        uint16 = 0x6985
+	StatusWrongLength         uint16 = 0x6700
+	StatusInvalidData         uint16 = 0x6a80
+	StatusInstructionNotFound uint16 = 0x6d00
+	StatusClaNotSupported     uint16 = 0x6e00
+	StatusAppNotOpen          uint16 = 0x6e01
+	StatusDeviceLocked        uint16 = 0x5515
+)
+
+const maxAPDUData = 255
+
+// ErrUserRejected indicates the user declined the request on the device.
+var ErrUserRejected = errors.New("ledger: request rejected on device")
+
+// StatusError reports a non-success status word returned by the device.
+type StatusError struct {
+	Code uint16
+}
+
+// Error implements the error interface.
+func (e StatusError) Error() string {
+	switch e.Code {
+	case StatusUserRejected:
+		return "ledger: request rejected on device"
+	case StatusDeviceLocked:
+		return "ledger: device is locked"
+	case StatusAppNotOpen, StatusClaNotSupported:
+		return "ledger: Sui application is not open"
+	case StatusInstructionNotFound:
+		return "ledger: instruction not supported by Sui application"
+	default:
+		return fmt.Sprintf("ledger: device returned status 0x%04x", e.Code)
+	}
+}
+
+// Is allows errors.Is(err, ErrUserRejected) for rejection status words.
+func (e StatusError) Is(target error) bool {
+	return target == ErrUserRejected && e.Code == StatusUserRejected
+}
+
+func encodeAPDU(cla, ins, p1, p2 byte, data []byte) ([]byte, error) {
+	if len(data) > maxAPDUData {
+		return nil, fmt.Errorf("ledger: apdu payload too large (%d bytes)", len(data))
+	}
+
+	apdu := make([]byte, 0, 5+len(data))
+	apdu = append(apdu, cla, ins, p1, p2, byte(len(data)))
+	apdu = append(apdu, data...)
+	return apdu, nil
+}
+
+func splitResponse(resp []byte) ([]byte, error) {
+	if len(resp) < 2 {
+		return nil, fmt.Errorf("ledger: short response (%d bytes)", len(resp))
+	}
+
+	code := binary.BigEndian.Uint16(resp[len(resp)-2:])
+	if code != StatusOK {
+		return nil, StatusError{Code: code}
+	}
+	return resp[:len(resp)-2], nil
+}
@@ -5,13 +5,12 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"os"
 
 	"github.com/joho/godotenv"
 	"github.com/open-move/sui-go-sdk/graphql"
 	"github.com/open-move/sui-go-sdk/graphql/examples/mutation"
 	"github.com/open-move/sui-go-sdk/graphql/examples/query"
-	"github.com/open-move/sui-go-sdk/keypair"
+	"github.com/open-move/sui-go-sdk/secrets"
 	"github.com/open-move/sui-go-sdk/types"
 )
 
@@ -25,15 +24,12 @@ func main() {
 	client := graphql.NewClient(graphql.WithEndpoint(graphql.TestnetEndpoint))
 	ctx := context.Background()
 
-	// Load keypair from environment variable
-	privKey := os.Getenv("SUI_PRIVATE_KEY")
-	if privKey == "" {
-		log.Fatal("SUI_PRIVATE_KEY not set in .env")
-	}
-
-	kp, err := keypair.FromBech32(privKey)
+	// Load keypair from environment variable. Prefer a value sealed with
+	// secrets.Seal, with its passphrase in SUI_PRIVATE_KEY_PASSPHRASE; a
+	// plaintext suiprivkey is accepted for local testing only.
+	kp, err := secrets.FromEnv(ctx, "SUI_PRIVATE_KEY", secrets.Options{AllowPlaintext: true})
 	if err != nil {
-		log.Fatalf("Failed to parse private key: %v", err)
+		log.Fatalf("Failed to load private key: %v", err)
 	}
 
 	sender, err := kp.SuiAddress()
@@ -0,0 +1,567 @@
+// Package movegen generates Go bindings for a published Move package from
+// its ABI (graphql.PackageABI):
+//
+//   - a Go struct for every Move struct and enum, with the field order and
+//     Go types of its BCS layout and JSON tags with the Move field names, so
+//     object contents decode with bcs.Unmarshal or bcsreg;
+//
+//   - a <Module>Module type per module whose methods add typed Move calls to
+//     a transaction, so calls are checked by the Go compiler:
+//
+//     pool := bindings.PoolModule{}
+//     pool.Swap(tx, "0x2::sui::SUI", poolID, uint64(1_000))
+//
+// Arguments of pure Move types (integers, bool, address, strings, IDs and
+// vectors and options of those) take the matching Go type. Objects and
+// values of generic type take any value MoveCall.Values accepts: an object
+// ID, an object reference, or an Argument or Result of an earlier command.
+// A trailing TxContext parameter is supplied by the runtime and omitted.
+//
+// The command cmd/sui-movegen drives the generator from a package address.
+package movegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+
+	"github.com/open-move/sui-go-sdk/graphql"
+	"github.com/open-move/sui-go-sdk/transaction"
+)
+
+// Options configure Generate.
+type Options struct {
+	// Package is the package clause of the generated file.
+	Package string
+	// Modules restricts generation to the named modules. Empty generates
+	// every module.
+	Modules []string
+}
+
+// Generate returns gofmt-ed Go source with bindings for abi. When more than
+// one module is generated, type names are prefixed with their module name
+// (pool::Pool becomes PoolPool) to keep them unique.
+//
+// Structs whose layout cannot be expressed in Go, such as those holding a
+// datatype from another package the generator does not know, are left out
+// with a comment naming the field; their module's functions are still
+// generated.
+func Generate(abi *graphql.PackageABI, opts Options) ([]byte, error) {
+	if abi == nil {
+		return nil, fmt.Errorf("movegen: nil ABI")
+	}
+	if opts.Package == "" {
+		return nil, fmt.Errorf("movegen: package name is empty")
+	}
+
+	modules := abi.Modules
+	if len(opts.Modules) > 0 {
+		modules = nil
+		for _, name := range opts.Modules {
+			m := abi.Module(name)
+			if m == nil {
+				return nil, fmt.Errorf("movegen: package %s has no module %s", abi.Address, name)
+			}
+			modules = append(modules, *m)
+		}
+	}
+	if len(modules) == 0 {
+		return nil, fmt.Errorf("movegen: package %s has no modules to generate", abi.Address)
+	}
+
+	g := &generator{
+		address:   abi.Address.String(),
+		datatypes: make(map[string]*datatype),
+		imports:   make(map[string]bool),
+		prefix:    len(modules) > 1,
+	}
+	for i := range modules {
+		if err := g.collect(&modules[i]); err != nil {
+			return nil, err
+		}
+	}
+	g.resolve()
+
+	var body bytes.Buffer
+	for i := range modules {
+		if err := g.module(&body, &modules[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	var out bytes.Buffer
+	out.WriteString("// Code generated by sui-movegen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&out, "package %s\n\n", opts.Package)
+	if len(g.imports) > 0 {
+		paths := make([]string, 0, len(g.imports))
+		for path := range g.imports {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		out.WriteString("import (\n")
+		for _, path := range paths {
+			fmt.Fprintf(&out, "\t%q\n", path)
+		}
+		out.WriteString(")\n\n")
+	}
+	fmt.Fprintf(&out, "// PackageID is the package the bindings were generated from.\nconst PackageID = %q\n\n", g.address)
+	out.WriteString("func packageOrDefault(pkg string) string {\n\tif pkg == \"\" {\n\t\treturn PackageID\n\t}\n\treturn pkg\n}\n\n")
+	out.Write(body.Bytes())
+
+	formatted, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("movegen: format generated code: %w", err)
+	}
+	return formatted, nil
+}
+
+// datatype is a struct or enum of the package being generated.
+type datatype struct {
+	module  string
+	name    string
+	goName  string
+	phantom []bool
+	fields  [][]graphql.MoveField // one entry per variant; structs have one
+	// unsupported names the first field whose type cannot be generated.
+	unsupported string
+}
+
+type generator struct {
+	address   string
+	datatypes map[string]*datatype // keyed by module::Name
+	imports   map[string]bool
+	prefix    bool
+}
+
+func (g *generator) collect(m *graphql.ModuleABI) error {
+	for _, s := range m.Structs {
+		d := &datatype{module: m.Name, name: s.Name, goName: g.typeName(m.Name, s.Name), fields: [][]graphql.MoveField{s.Fields}}
+		for _, tp := range s.TypeParameters {
+			d.phantom = append(d.phantom, tp.IsPhantom)
+		}
+		g.datatypes[m.Name+"::"+s.Name] = d
+	}
+	for _, e := range m.Enums {
+		d := &datatype{module: m.Name, name: e.Name, goName: g.typeName(m.Name, e.Name)}
+		for _, tp := range e.TypeParameters {
+			d.phantom = append(d.phantom, tp.IsPhantom)
+		}
+		for _, v := range e.Variants {
+			d.fields = append(d.fields, v.Fields)
+		}
+		g.datatypes[m.Name+"::"+e.Name] = d
+	}
+	return nil
+}
+
+// resolve marks datatypes with fields that cannot be generated, repeating
+// until datatypes holding such datatypes are marked too.
+func (g *generator) resolve() {
+	keys := make([]string, 0, len(g.datatypes))
+	for key := range g.datatypes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for changed := true; changed; {
+		changed = false
+		for _, key := range keys {
+			d := g.datatypes[key]
+			if d.unsupported != "" {
+				continue
+			}
+			for _, fields := range d.fields {
+				for _, f := range fields {
+					if _, err := g.fieldType(f, nil); err != nil {
+						d.unsupported = fmt.Sprintf("field %s: %v", f.Name, err)
+						changed = true
+						break
+					}
+				}
+				if d.unsupported != "" {
+					break
+				}
+			}
+		}
+	}
+}
+
+func (g *generator) typeName(module, name string) string {
+	if g.prefix {
+		return exportedName(module) + exportedName(name)
+	}
+	return exportedName(name)
+}
+
+func (g *generator) module(out *bytes.Buffer, m *graphql.ModuleABI) error {
+	fmt.Fprintf(out, "// =============================================================================\n// Module %s\n// =============================================================================\n\n", m.Name)
+
+	for _, s := range m.Structs {
+		d := g.datatypes[m.Name+"::"+s.Name]
+		if d.unsupported != "" {
+			fmt.Fprintf(out, "// %s (%s::%s::%s) is not generated: %s.\n\n", d.goName, g.address, m.Name, s.Name, d.unsupported)
+			continue
+		}
+		fmt.Fprintf(out, "// %s is %s::%s::%s.\n", d.goName, g.address, m.Name, s.Name)
+		fmt.Fprintf(out, "type %s%s struct {\n", d.goName, g.typeParams(d))
+		if err := g.fields(out, s.Fields); err != nil {
+			return fmt.Errorf("movegen: %s::%s: %w", m.Name, s.Name, err)
+		}
+		out.WriteString("}\n\n")
+	}
+
+	for _, e := range m.Enums {
+		d := g.datatypes[m.Name+"::"+e.Name]
+		if d.unsupported != "" {
+			fmt.Fprintf(out, "// %s (%s::%s::%s) is not generated: %s.\n\n", d.goName, g.address, m.Name, e.Name, d.unsupported)
+			continue
+		}
+		typeParams, typeArgs := g.typeParams(d), g.typeArgs(d)
+		fmt.Fprintf(out, "// %s is the enum %s::%s::%s. Exactly one variant is set.\n", d.goName, g.address, m.Name, e.Name)
+		fmt.Fprintf(out, "type %s%s struct {\n", d.goName, typeParams)
+		for _, v := range e.Variants {
+			if len(v.Fields) == 0 {
+				fmt.Fprintf(out, "\t%s *struct{} `json:%q`\n", exportedName(v.Name), v.Name+",omitempty")
+				continue
+			}
+			fmt.Fprintf(out, "\t%s *%s%s%s `json:%q`\n", exportedName(v.Name), d.goName, exportedName(v.Name), typeArgs, v.Name+",omitempty")
+		}
+		out.WriteString("}\n\n")
+		fmt.Fprintf(out, "// IsBcsEnum marks %s as a BCS enum.\nfunc (%s%s) IsBcsEnum() {}\n\n", d.goName, d.goName, typeArgs)
+		for _, v := range e.Variants {
+			if len(v.Fields) == 0 {
+				continue
+			}
+			fmt.Fprintf(out, "// %s%s holds the fields of the %s variant of %s.\n", d.goName, exportedName(v.Name), v.Name, d.goName)
+			fmt.Fprintf(out, "type %s%s%s struct {\n", d.goName, exportedName(v.Name), typeParams)
+			if err := g.fields(out, v.Fields); err != nil {
+				return fmt.Errorf("movegen: %s::%s::%s: %w", m.Name, e.Name, v.Name, err)
+			}
+			out.WriteString("}\n\n")
+		}
+	}
+
+	binding := exportedName(m.Name) + "Module"
+	fmt.Fprintf(out, "// %s adds calls to the functions of the %s module to a transaction.\n", binding, m.Name)
+	fmt.Fprintf(out, "type %s struct {\n\t// Package is the package to call; PackageID when empty. Set it to\n\t// call an upgraded version of the package.\n\tPackage string\n}\n\n", binding)
+	for _, fn := range m.Functions {
+		if fn.Visibility != graphql.MoveVisibilityPublic && !fn.IsEntry {
+			continue
+		}
+		if err := g.function(out, binding, m.Name, fn); err != nil {
+			return fmt.Errorf("movegen: %s::%s: %w", m.Name, fn.Name, err)
+		}
+	}
+	return nil
+}
+
+func (g *generator) fields(out *bytes.Buffer, fields []graphql.MoveField) error {
+	for _, f := range fields {
+		typ, err := g.fieldType(f, g.imports)
+		if err != nil {
+			return err
+		}
+		tag := fmt.Sprintf("json:%q", f.Name)
+		if strings.HasPrefix(typ, "*") {
+			tag += ` bcs:"optional"`
+		}
+		fmt.Fprintf(out, "\t%s %s `%s`\n", exportedName(f.Name), typ, tag)
+	}
+	return nil
+}
+
+// typeParams renders the Go type parameters of d. Phantom parameters do not
+// affect the layout and are left out.
+func (g *generator) typeParams(d *datatype) string {
+	var params []string
+	for i, phantom := range d.phantom {
+		if !phantom {
+			params = append(params, fmt.Sprintf("T%d", i))
+		}
+	}
+	if len(params) == 0 {
+		return ""
+	}
+	return "[" + strings.Join(params, ", ") + " any]"
+}
+
+func (g *generator) typeArgs(d *datatype) string {
+	var args []string
+	for i, phantom := range d.phantom {
+		if !phantom {
+			args = append(args, fmt.Sprintf("T%d", i))
+		}
+	}
+	if len(args) == 0 {
+		return ""
+	}
+	return "[" + strings.Join(args, ", ") + "]"
+}
+
+func (g *generator) fieldType(f graphql.MoveField, imports map[string]bool) (string, error) {
+	if f.Type == nil {
+		return "", fmt.Errorf("no type")
+	}
+	param, err := f.Type.MoveParameter()
+	if err != nil {
+		return "", err
+	}
+	sig := *param.Body
+	// A top-level option becomes a pointer the BCS encoder treats as an
+	// option; nested options have no such representation.
+	if name, _ := splitTypeName(sig); sig.Kind == transaction.MoveTypeDatatype && name == "0x1::option::Option" && len(sig.TypeArguments) == 1 {
+		inner, err := g.layoutType(sig.TypeArguments[0], imports)
+		if err != nil {
+			return "", err
+		}
+		return "*" + inner, nil
+	}
+	return g.layoutType(sig, imports)
+}
+
+// layoutType returns the Go type with the BCS layout of sig.
+func (g *generator) layoutType(sig transaction.MoveTypeSignature, imports map[string]bool) (string, error) {
+	use := func(path string) {
+		if imports != nil {
+			imports[path] = true
+		}
+	}
+	switch sig.Kind {
+	case transaction.MoveTypeBool:
+		return "bool", nil
+	case transaction.MoveTypeU8:
+		return "uint8", nil
+	case transaction.MoveTypeU16:
+		return "uint16", nil
+	case transaction.MoveTypeU32:
+		return "uint32", nil
+	case transaction.MoveTypeU64:
+		return "uint64", nil
+	case transaction.MoveTypeU128:
+		use("math/big")
+		return "big.Int", nil
+	case transaction.MoveTypeU256:
+		// Little-endian, as BCS encodes u256.
+		return "[32]byte", nil
+	case transaction.MoveTypeAddress:
+		use("github.com/open-move/sui-go-sdk/types")
+		return "types.Address", nil
+	case transaction.MoveTypeParameter:
+		return fmt.Sprintf("T%d", sig.TypeParameter), nil
+	case transaction.MoveTypeVector:
+		elem, err := g.layoutType(sig.TypeArguments[0], imports)
+		if err != nil {
+			return "", err
+		}
+		if elem == "uint8" {
+			return "[]byte", nil
+		}
+		return "[]" + elem, nil
+	case transaction.MoveTypeDatatype:
+	default:
+		return "", fmt.Errorf("unknown type")
+	}
+
+	name, local := splitTypeName(sig)
+	if d, ok := g.datatypes[local]; ok && !isFrameworkType(name) {
+		if d.unsupported != "" {
+			return "", fmt.Errorf("%s is not generated", d.name)
+		}
+		var args []string
+		for i, arg := range sig.TypeArguments {
+			if i < len(d.phantom) && d.phantom[i] {
+				continue
+			}
+			typ, err := g.layoutType(arg, imports)
+			if err != nil {
+				return "", err
+			}
+			args = append(args, typ)
+		}
+		if len(args) == 0 {
+			return d.goName, nil
+		}
+		return d.goName + "[" + strings.Join(args, ", ") + "]", nil
+	}
+
+	switch name {
+	case "0x1::string::String", "0x1::ascii::String", "0x1::type_name::TypeName", "0x2::url::Url":
+		return "string", nil
+	case "0x2::object::UID", "0x2::object::ID":
+		use("github.com/open-move/sui-go-sdk/types")
+		return "types.Address", nil
+	case "0x2::balance::Balance":
+		return "uint64", nil
+	case "0x2::coin::Coin":
+		use("github.com/open-move/sui-go-sdk/bcsreg")
+		return "bcsreg.Coin", nil
+	case "0x2::table::Table", "0x2::bag::Bag", "0x2::object_table::ObjectTable", "0x2::object_bag::ObjectBag":
+		use("github.com/open-move/sui-go-sdk/bcsreg")
+		return "bcsreg.Table", nil
+	case "0x2::vec_set::VecSet":
+		elem, err := g.layoutType(sig.TypeArguments[0], imports)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + elem, nil
+	case "0x2::vec_map::VecMap":
+		key, err := g.layoutType(sig.TypeArguments[0], imports)
+		if err != nil {
+			return "", err
+		}
+		value, err := g.layoutType(sig.TypeArguments[1], imports)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("[]struct {\nKey %s `json:\"key\"`\nValue %s `json:\"value\"`\n}", key, value), nil
+	}
+	return "", fmt.Errorf("unsupported type %s", name)
+}
+
+func (g *generator) function(out *bytes.Buffer, binding, module string, fn graphql.MoveFunction) error {
+	params := make([]transaction.MoveParameter, 0, len(fn.Parameters))
+	for i, p := range fn.Parameters {
+		param, err := p.MoveParameter()
+		if err != nil {
+			return fmt.Errorf("parameter %d: %w", i, err)
+		}
+		params = append(params, param)
+	}
+	if n := len(params); n > 0 && params[n-1].Reference != transaction.ReferenceUnknown &&
+		params[n-1].Body.Kind == transaction.MoveTypeDatatype && params[n-1].TypeName == "0x2::tx_context::TxContext" {
+		params = params[:n-1]
+	}
+
+	args := []string{"tx *transaction.Transaction"}
+	for i := range fn.TypeParameters {
+		args = append(args, fmt.Sprintf("t%d string", i))
+	}
+	values := make([]string, len(params))
+	for i, param := range params {
+		typ := "any"
+		if param.Reference == transaction.ReferenceUnknown {
+			if native, ok := g.argumentType(*param.Body); ok {
+				typ = native
+			}
+		}
+		args = append(args, fmt.Sprintf("arg%d %s", i, typ))
+		values[i] = fmt.Sprintf("arg%d", i)
+	}
+	g.imports["github.com/open-move/sui-go-sdk/transaction"] = true
+
+	fmt.Fprintf(out, "// %s calls %s::%s::%s", exportedName(fn.Name), g.address, module, fn.Name)
+	if len(fn.Return) > 0 {
+		returns := make([]string, len(fn.Return))
+		for i, r := range fn.Return {
+			returns[i] = r.Repr
+		}
+		fmt.Fprintf(out, ", which returns %s", strings.Join(returns, ", "))
+	}
+	out.WriteString(".\n")
+	if len(fn.TypeParameters) > 0 {
+		out.WriteString("// Type arguments are passed in order as t0, t1, ...\n")
+	}
+	fmt.Fprintf(out, "func (m %s) %s(%s) transaction.Result {\n", binding, exportedName(fn.Name), strings.Join(args, ", "))
+	out.WriteString("\treturn tx.MoveCall(transaction.MoveCall{\n")
+	out.WriteString("\t\tPackage: packageOrDefault(m.Package),\n")
+	fmt.Fprintf(out, "\t\tModule: %q,\n\t\tFunction: %q,\n", module, fn.Name)
+	if len(fn.TypeParameters) > 0 {
+		typeArgs := make([]string, len(fn.TypeParameters))
+		for i := range typeArgs {
+			typeArgs[i] = fmt.Sprintf("t%d", i)
+		}
+		fmt.Fprintf(out, "\t\tTypeArguments: []string{%s},\n", strings.Join(typeArgs, ", "))
+	}
+	if len(values) > 0 {
+		fmt.Fprintf(out, "\t\tValues: []any{%s},\n", strings.Join(values, ", "))
+	}
+	out.WriteString("\t})\n}\n\n")
+	return nil
+}
+
+// argumentType returns the Go type of a pure argument, as accepted by
+// MoveCall.Values.
+func (g *generator) argumentType(sig transaction.MoveTypeSignature) (string, bool) {
+	switch sig.Kind {
+	case transaction.MoveTypeBool:
+		return "bool", true
+	case transaction.MoveTypeU8:
+		return "uint8", true
+	case transaction.MoveTypeU16:
+		return "uint16", true
+	case transaction.MoveTypeU32:
+		return "uint32", true
+	case transaction.MoveTypeU64:
+		return "uint64", true
+	case transaction.MoveTypeU128, transaction.MoveTypeU256:
+		g.imports["math/big"] = true
+		return "*big.Int", true
+	case transaction.MoveTypeAddress:
+		g.imports["github.com/open-move/sui-go-sdk/types"] = true
+		return "types.Address", true
+	case transaction.MoveTypeVector:
+		elem, ok := g.argumentType(sig.TypeArguments[0])
+		if !ok {
+			return "", false
+		}
+		if elem == "uint8" {
+			return "[]byte", true
+		}
+		return "[]" + elem, true
+	case transaction.MoveTypeDatatype:
+		name, _ := splitTypeName(sig)
+		switch name {
+		case "0x1::string::String", "0x1::ascii::String":
+			return "string", true
+		case "0x2::object::ID":
+			g.imports["github.com/open-move/sui-go-sdk/types"] = true
+			return "types.Address", true
+		case "0x1::option::Option":
+			inner, ok := g.argumentType(sig.TypeArguments[0])
+			if !ok {
+				return "", false
+			}
+			if strings.HasPrefix(inner, "*") {
+				return inner, true
+			}
+			return "*" + inner, true
+		}
+	}
+	return "", false
+}
+
+// splitTypeName returns the address::module::Name of a datatype and its
+// module::Name.
+func splitTypeName(sig transaction.MoveTypeSignature) (string, string) {
+	parts := strings.SplitN(sig.TypeName, "::", 3)
+	if len(parts) != 3 {
+		return sig.TypeName, ""
+	}
+	return sig.TypeName, parts[1] + "::" + parts[2]
+}
+
+func isFrameworkType(name string) bool {
+	return strings.HasPrefix(name, "0x1::") || strings.HasPrefix(name, "0x2::") || strings.HasPrefix(name, "0x3::")
+}
+
+// exportedName converts a Move identifier such as "pool_id" to an exported
+// Go identifier such as "PoolID".
+func exportedName(name string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(name, "_") {
+		if part == "" {
+			continue
+		}
+		switch strings.ToLower(part) {
+		case "id", "uid", "url", "nft":
+			b.WriteString(strings.ToUpper(part))
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	if b.Len() == 0 {
+		return "X"
+	}
+	return b.String()
+}
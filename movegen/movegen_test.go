@@ -0,0 +1,112 @@
+package movegen
+
+import (
+	"encoding/json"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/graphql"
+)
+
+const poolABI = `{
+  "address": "0x0000000000000000000000000000000000000000000000000000000000000abc",
+  "version": 1,
+  "modules": [{
+    "name": "pool",
+    "functions": [
+      {"name": "swap", "visibility": "PUBLIC", "isEntry": false, "typeParameters": [{"constraints": []}],
+       "parameters": [
+         {"repr": "&mut 0xabc::pool::Pool<$0>", "signature": {"ref": "&mut", "body": {"datatype": {"package": "0xabc", "module": "pool", "type": "Pool", "typeParameters": [{"typeParameter": 0}]}}}},
+         {"repr": "u64", "signature": {"ref": null, "body": "u64"}},
+         {"repr": "0x1::option::Option<u128>", "signature": {"ref": null, "body": {"datatype": {"package": "0x1", "module": "option", "type": "Option", "typeParameters": ["u128"]}}}},
+         {"repr": "&mut 0x2::tx_context::TxContext", "signature": {"ref": "&mut", "body": {"datatype": {"package": "0x2", "module": "tx_context", "type": "TxContext", "typeParameters": []}}}}
+       ],
+       "return": [{"repr": "u64", "signature": {"ref": null, "body": "u64"}}]},
+      {"name": "set_name", "visibility": "PRIVATE", "isEntry": true, "typeParameters": [],
+       "parameters": [{"repr": "vector<u8>", "signature": {"ref": null, "body": {"vector": "u8"}}}],
+       "return": []},
+      {"name": "internal", "visibility": "FRIEND", "isEntry": false, "typeParameters": [], "parameters": [], "return": []}
+    ],
+    "structs": [
+      {"name": "Pool", "abilities": ["KEY"], "typeParameters": [{"constraints": [], "isPhantom": true}],
+       "fields": [
+         {"name": "id", "type": {"repr": "0x2::object::UID", "signature": {"ref": null, "body": {"datatype": {"package": "0x2", "module": "object", "type": "UID", "typeParameters": []}}}}},
+         {"name": "reserve", "type": {"repr": "0x2::balance::Balance<$0>", "signature": {"ref": null, "body": {"datatype": {"package": "0x2", "module": "balance", "type": "Balance", "typeParameters": [{"typeParameter": 0}]}}}}},
+         {"name": "fee", "type": {"repr": "0x1::option::Option<u64>", "signature": {"ref": null, "body": {"datatype": {"package": "0x1", "module": "option", "type": "Option", "typeParameters": ["u64"]}}}}},
+         {"name": "history", "type": {"repr": "vector<0xabc::pool::Entry<u64>>", "signature": {"ref": null, "body": {"vector": {"datatype": {"package": "0xabc", "module": "pool", "type": "Entry", "typeParameters": ["u64"]}}}}}}
+       ]},
+      {"name": "Entry", "abilities": ["STORE"], "typeParameters": [{"constraints": [], "isPhantom": false}],
+       "fields": [{"name": "value", "type": {"repr": "$0", "signature": {"ref": null, "body": {"typeParameter": 0}}}}]},
+      {"name": "Foreign", "abilities": ["STORE"], "typeParameters": [],
+       "fields": [{"name": "other", "type": {"repr": "0xdef::m::T", "signature": {"ref": null, "body": {"datatype": {"package": "0xdef", "module": "m", "type": "T", "typeParameters": []}}}}}]}
+    ],
+    "enums": [
+      {"name": "Side", "abilities": ["COPY", "DROP"], "typeParameters": [],
+       "variants": [
+         {"name": "Bid", "fields": []},
+         {"name": "Ask", "fields": [{"name": "price", "type": {"repr": "u64", "signature": {"ref": null, "body": "u64"}}}]}
+       ]}
+    ],
+    "constants": []
+  }]
+}`
+
+func TestGenerate(t *testing.T) {
+	var abi graphql.PackageABI
+	if err := json.Unmarshal([]byte(poolABI), &abi); err != nil {
+		t.Fatalf("decode ABI: %v", err)
+	}
+	code, err := Generate(&abi, Options{Package: "pool"})
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "pool_gen.go", code, 0); err != nil {
+		t.Fatalf("generated code does not parse: %v\n%s", err, code)
+	}
+
+	// Compare with whitespace collapsed, since gofmt aligns fields.
+	src := strings.Join(strings.Fields(string(code)), " ")
+	for _, want := range []string{
+		"type Pool struct {",
+		"ID types.Address `json:\"id\"`",
+		"Reserve uint64 `json:\"reserve\"`",
+		"Fee *uint64 `json:\"fee\" bcs:\"optional\"`",
+		"History []Entry[uint64]",
+		"type Entry[T0 any] struct {",
+		"// Foreign (0x0000000000000000000000000000000000000000000000000000000000000abc::pool::Foreign) is not generated: field other: unsupported type 0xdef::m::T.",
+		"Ask *SideAsk `json:\"Ask,omitempty\"`",
+		"func (Side) IsBcsEnum() {}",
+		"func (m PoolModule) Swap(tx *transaction.Transaction, t0 string, arg0 any, arg1 uint64, arg2 *big.Int) transaction.Result {",
+		"TypeArguments: []string{t0},",
+		"Values: []any{arg0, arg1, arg2},",
+		"func (m PoolModule) SetName(tx *transaction.Transaction, arg0 []byte) transaction.Result {",
+	} {
+		if !strings.Contains(src, strings.Join(strings.Fields(want), " ")) {
+			t.Errorf("generated code is missing %q\n%s", want, code)
+		}
+	}
+	if strings.Contains(src, "Internal(") {
+		t.Errorf("friend functions should not be generated")
+	}
+}
+
+func TestGeneratePrefixesMultipleModules(t *testing.T) {
+	var abi graphql.PackageABI
+	if err := json.Unmarshal([]byte(poolABI), &abi); err != nil {
+		t.Fatalf("decode ABI: %v", err)
+	}
+	abi.Modules = append(abi.Modules, graphql.ModuleABI{Name: "math"})
+	code, err := Generate(&abi, Options{Package: "bindings"})
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	if !strings.Contains(string(code), "type PoolPool struct {") || !strings.Contains(string(code), "type MathModule struct {") {
+		t.Fatalf("expected module-prefixed names:\n%s", code)
+	}
+
+	if _, err := Generate(&abi, Options{Package: "bindings", Modules: []string{"missing"}}); err == nil {
+		t.Fatalf("expected an error for an unknown module")
+	}
+}
@@ -0,0 +1,349 @@
+// Package mvr resolves Move Registry (MVR) names such as "@suifrens/core"
+// to package addresses, so Move calls can target "@suifrens/core::mint::mint"
+// instead of a hex address that changes with every upgrade.
+//
+// A Client implements transaction.NameResolver; pass it as
+// BuildOptions.NameResolver to resolve named targets at build time.
+package mvr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/open-move/sui-go-sdk/network"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+// Public registry endpoints. Names are registered per network, so a name
+// can resolve to different packages on mainnet and testnet.
+const (
+	MainnetURL = "https://mainnet.mvr.mystenlabs.com"
+	TestnetURL = "https://testnet.mvr.mystenlabs.com"
+)
+
+// DefaultCacheTTL is how long the address of an unversioned name is cached.
+// Versioned names always resolve to the same package and are cached for the
+// life of the client.
+const DefaultCacheTTL = 5 * time.Minute
+
+// maxBulkNames bounds the names sent in one bulk resolution request.
+const maxBulkNames = 50
+
+var (
+	// ErrInvalidName reports a string that is not a valid MVR name.
+	ErrInvalidName = errors.New("mvr: invalid name")
+	// ErrNotFound reports a name the registry does not know on this network.
+	ErrNotFound = errors.New("mvr: name not found")
+)
+
+var labelPattern = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+// Name is a parsed MVR name: "@org/app" or "@org/app/version".
+type Name struct {
+	Org string
+	App string
+	// Version pins a package version; zero means the latest.
+	Version uint64
+}
+
+// ParseName parses "@org/app", "org.sui/app" and their "/version" forms.
+func ParseName(s string) (Name, error) {
+	var org, rest string
+	switch {
+	case strings.HasPrefix(s, "@"):
+		org, rest, _ = strings.Cut(s[1:], "/")
+	case strings.Contains(s, ".sui/"):
+		org, rest, _ = strings.Cut(s, ".sui/")
+	default:
+		return Name{}, fmt.Errorf("%w: %q", ErrInvalidName, s)
+	}
+
+	app, version, versioned := strings.Cut(rest, "/")
+	name := Name{Org: org, App: app}
+	if !labelPattern.MatchString(org) || !labelPattern.MatchString(app) {
+		return Name{}, fmt.Errorf("%w: %q", ErrInvalidName, s)
+	}
+	if versioned {
+		v, err := strconv.ParseUint(version, 10, 64)
+		if err != nil || v == 0 {
+			return Name{}, fmt.Errorf("%w: bad version in %q", ErrInvalidName, s)
+		}
+		name.Version = v
+	}
+	return name, nil
+}
+
+// IsName reports whether s is an MVR name rather than an address.
+func IsName(s string) bool {
+	_, err := ParseName(s)
+	return err == nil
+}
+
+// String returns the name in its canonical "@org/app[/version]" form.
+func (n Name) String() string {
+	s := "@" + n.Org + "/" + n.App
+	if n.Version > 0 {
+		s += "/" + strconv.FormatUint(n.Version, 10)
+	}
+	return s
+}
+
+// Client resolves names against one network's registry. It is safe for
+// concurrent use.
+type Client struct {
+	endpoint   string
+	httpClient *http.Client
+	ttl        time.Duration
+	now        func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	address string
+	expires time.Time // zero for versioned names
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used to reach the registry.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Client) {
+		if client != nil {
+			c.httpClient = client
+		}
+	}
+}
+
+// WithCacheTTL sets how long unversioned names are cached. Zero disables
+// caching of unversioned names.
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(c *Client) {
+		c.ttl = ttl
+	}
+}
+
+// NewClient returns a client for the registry at endpoint, such as
+// MainnetURL.
+func NewClient(endpoint string, opts ...Option) *Client {
+	c := &Client{
+		endpoint:   strings.TrimRight(endpoint, "/"),
+		httpClient: http.DefaultClient,
+		ttl:        DefaultCacheTTL,
+		now:        time.Now,
+		cache:      make(map[string]cacheEntry),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// ForNetwork returns a client for the public registry of a network. Only
+// mainnet and testnet have one.
+func ForNetwork(name network.Name, opts ...Option) (*Client, error) {
+	switch name {
+	case network.Mainnet:
+		return NewClient(MainnetURL, opts...), nil
+	case network.Testnet:
+		return NewClient(TestnetURL, opts...), nil
+	default:
+		return nil, fmt.Errorf("mvr: no public registry for network %q", name)
+	}
+}
+
+// ResolvePackageName returns the address of the package a name refers to.
+// It implements transaction.NameResolver.
+func (c *Client) ResolvePackageName(ctx context.Context, name string) (string, error) {
+	resolved, err := c.ResolvePackageNames(ctx, []string{name})
+	if err != nil {
+		return "", err
+	}
+	return resolved[name], nil
+}
+
+// ResolvePackageNames resolves several names, fetching the uncached ones in
+// bulk. The result is keyed by the names as given.
+func (c *Client) ResolvePackageNames(ctx context.Context, names []string) (map[string]string, error) {
+	keys := make(map[string]string, len(names)) // given name -> canonical name
+	addresses := make(map[string]string, len(names))
+	var missing []string
+	for _, raw := range names {
+		name, err := ParseName(raw)
+		if err != nil {
+			return nil, err
+		}
+		key := name.String()
+		keys[raw] = key
+		if _, seen := addresses[key]; seen {
+			continue
+		}
+		if address, ok := c.cached(key); ok {
+			addresses[key] = address
+			continue
+		}
+		addresses[key] = ""
+		missing = append(missing, key)
+	}
+
+	for start := 0; start < len(missing); start += maxBulkNames {
+		batch := missing[start:min(start+maxBulkNames, len(missing))]
+		fetched, err := c.fetch(ctx, batch)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range batch {
+			address, ok := fetched[key]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+			}
+			addresses[key] = address
+			c.store(key, address)
+		}
+	}
+
+	resolved := make(map[string]string, len(keys))
+	for raw, key := range keys {
+		resolved[raw] = addresses[key]
+	}
+	return resolved, nil
+}
+
+// ResolveTarget replaces the package name of a Move call target such as
+// "@suifrens/core::mint::mint" with its address. Targets that already use
+// an address are returned unchanged.
+func (c *Client) ResolveTarget(ctx context.Context, target string) (string, error) {
+	pkg, rest, found := strings.Cut(target, "::")
+	if !found || !IsName(pkg) {
+		return target, nil
+	}
+	address, err := c.ResolvePackageName(ctx, pkg)
+	if err != nil {
+		return "", err
+	}
+	return address + "::" + rest, nil
+}
+
+func (c *Client) cached(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.cache[key]
+	if !ok || (!entry.expires.IsZero() && !c.now().Before(entry.expires)) {
+		return "", false
+	}
+	return entry.address, true
+}
+
+func (c *Client) store(key, address string) {
+	name, _ := ParseName(key)
+	entry := cacheEntry{address: address}
+	if name.Version == 0 {
+		if c.ttl <= 0 {
+			return
+		}
+		entry.expires = c.now().Add(c.ttl)
+	}
+	c.mu.Lock()
+	c.cache[key] = entry
+	c.mu.Unlock()
+}
+
+type packageResolution struct {
+	PackageID string `json:"package_id"`
+}
+
+// fetch resolves canonical names, using the single-name endpoint for one
+// name and the bulk endpoint otherwise. Unknown names are absent from the
+// result.
+func (c *Client) fetch(ctx context.Context, names []string) (map[string]string, error) {
+	if len(names) == 1 {
+		var resp packageResolution
+		status, err := c.do(ctx, http.MethodGet, "/v1/resolution/"+url.PathEscape(names[0]), nil, &resp)
+		if status == http.StatusNotFound {
+			return map[string]string{}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		address, err := normalizeAddress(resp.PackageID)
+		if err != nil {
+			return nil, fmt.Errorf("mvr: %s: %w", names[0], err)
+		}
+		return map[string]string{names[0]: address}, nil
+	}
+
+	var resp struct {
+		Resolution map[string]packageResolution `json:"resolution"`
+	}
+	if _, err := c.do(ctx, http.MethodPost, "/v1/resolution/bulk", map[string]any{"names": names}, &resp); err != nil {
+		return nil, err
+	}
+	out := make(map[string]string, len(resp.Resolution))
+	for name, resolution := range resp.Resolution {
+		if resolution.PackageID == "" {
+			continue
+		}
+		address, err := normalizeAddress(resolution.PackageID)
+		if err != nil {
+			return nil, fmt.Errorf("mvr: %s: %w", name, err)
+		}
+		out[name] = address
+	}
+	return out, nil
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body any, out any) (int, error) {
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return 0, err
+		}
+		reader = bytes.NewReader(encoded)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+path, reader)
+	if err != nil {
+		return 0, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("mvr: %w", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, fmt.Errorf("mvr: read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return resp.StatusCode, fmt.Errorf("mvr: %s %s: %s: %s", method, path, resp.Status, strings.TrimSpace(string(data)))
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return resp.StatusCode, fmt.Errorf("mvr: decode response: %w", err)
+	}
+	return resp.StatusCode, nil
+}
+
+func normalizeAddress(address string) (string, error) {
+	parsed, err := utils.ParseAddress(address)
+	if err != nil {
+		return "", err
+	}
+	return parsed.String(), nil
+}
@@ -0,0 +1,133 @@
+package mvr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var registry = map[string]string{
+	"@suifrens/core":   "0x5",
+	"@suifrens/core/2": "0x7",
+	"@mysten/kiosk":    "0x8",
+}
+
+func newRegistry(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var requests atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/resolution/{name...}", func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		address, ok := registry[r.PathValue("name")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"package_id": address})
+	})
+	mux.HandleFunc("POST /v1/resolution/bulk", func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		var req struct{ Names []string }
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		resolution := make(map[string]any)
+		for _, name := range req.Names {
+			if address, ok := registry[name]; ok {
+				resolution[name] = map[string]string{"package_id": address}
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"resolution": resolution})
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestParseName(t *testing.T) {
+	for input, want := range map[string]string{
+		"@suifrens/core":    "@suifrens/core",
+		"suifrens.sui/core": "@suifrens/core",
+		"@suifrens/core/2":  "@suifrens/core/2",
+		"@my-org/my-app/10": "@my-org/my-app/10",
+	} {
+		name, err := ParseName(input)
+		if err != nil {
+			t.Fatalf("%s: %v", input, err)
+		}
+		if name.String() != want {
+			t.Fatalf("%s: expected %s, got %s", input, want, name)
+		}
+	}
+	for _, input := range []string{"0x2", "@suifrens", "@Suifrens/core", "@suifrens/core/0", "@suifrens/core/v2"} {
+		if _, err := ParseName(input); !errors.Is(err, ErrInvalidName) {
+			t.Fatalf("%s: expected ErrInvalidName, got %v", input, err)
+		}
+	}
+}
+
+func TestResolvePackageNameCaches(t *testing.T) {
+	server, requests := newRegistry(t)
+	client := NewClient(server.URL)
+	now := time.Unix(0, 0)
+	client.now = func() time.Time { return now }
+
+	for range 2 {
+		address, err := client.ResolvePackageName(context.Background(), "suifrens.sui/core")
+		if err != nil {
+			t.Fatalf("resolve: %v", err)
+		}
+		if !strings.HasSuffix(address, "05") || len(address) != 66 {
+			t.Fatalf("unexpected address %s", address)
+		}
+	}
+	if n := requests.Load(); n != 1 {
+		t.Fatalf("expected 1 request, got %d", n)
+	}
+
+	now = now.Add(DefaultCacheTTL)
+	if _, err := client.ResolvePackageName(context.Background(), "@suifrens/core"); err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if n := requests.Load(); n != 2 {
+		t.Fatalf("expected the expired entry to be refetched, got %d requests", n)
+	}
+
+	if _, err := client.ResolvePackageName(context.Background(), "@unknown/app"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestResolvePackageNamesBulk(t *testing.T) {
+	server, requests := newRegistry(t)
+	client := NewClient(server.URL)
+
+	resolved, err := client.ResolvePackageNames(context.Background(), []string{"@suifrens/core/2", "@mysten/kiosk", "mysten.sui/kiosk"})
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if len(resolved) != 3 || resolved["@mysten/kiosk"] != resolved["mysten.sui/kiosk"] {
+		t.Fatalf("unexpected resolution %v", resolved)
+	}
+	if n := requests.Load(); n != 1 {
+		t.Fatalf("expected one bulk request, got %d", n)
+	}
+
+	target, err := client.ResolveTarget(context.Background(), "@suifrens/core/2::mint::mint")
+	if err != nil {
+		t.Fatalf("resolve target: %v", err)
+	}
+	if target != resolved["@suifrens/core/2"]+"::mint::mint" {
+		t.Fatalf("unexpected target %s", target)
+	}
+	if n := requests.Load(); n != 1 {
+		t.Fatalf("expected the versioned name to be cached, got %d requests", n)
+	}
+}
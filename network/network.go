@@ -0,0 +1,192 @@
+// Package network describes the public Sui networks: their endpoints, faucets,
+// explorers and chain identifiers.
+package network
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+// Name identifies a Sui network.
+type Name string
+
+const (
+	Mainnet  Name = "mainnet"
+	Testnet  Name = "testnet"
+	Devnet   Name = "devnet"
+	Localnet Name = "localnet"
+	// Custom is reported for networks that match none of the known profiles.
+	Custom Name = "custom"
+)
+
+// Known chain identifiers. Devnet and localnet are regenerated on every
+// reset, so they have no stable identifier.
+const (
+	MainnetChainID = "35834a8a"
+	TestnetChainID = "4c78adac"
+)
+
+// Config is the profile of a Sui network. Explorer fields are fmt templates
+// with a single %s verb; an empty template means the network has no public
+// explorer.
+type Config struct {
+	Name       Name
+	ChainID    string
+	GraphQLURL string
+	GRPCURL    string
+	FaucetURL  string
+
+	ExplorerTransactionURL string
+	ExplorerObjectURL      string
+	ExplorerAddressURL     string
+	ExplorerCheckpointURL  string
+}
+
+var (
+	MainnetConfig = Config{
+		Name:                   Mainnet,
+		ChainID:                MainnetChainID,
+		GraphQLURL:             "https://graphql.mainnet.sui.io/graphql",
+		GRPCURL:                "https://fullnode.mainnet.sui.io",
+		ExplorerTransactionURL: "https://suiscan.xyz/mainnet/tx/%s",
+		ExplorerObjectURL:      "https://suiscan.xyz/mainnet/object/%s",
+		ExplorerAddressURL:     "https://suiscan.xyz/mainnet/account/%s",
+		ExplorerCheckpointURL:  "https://suiscan.xyz/mainnet/checkpoint/%s",
+	}
+
+	TestnetConfig = Config{
+		Name:                   Testnet,
+		ChainID:                TestnetChainID,
+		GraphQLURL:             "https://graphql.testnet.sui.io/graphql",
+		GRPCURL:                "https://fullnode.testnet.sui.io",
+		FaucetURL:              "https://faucet.testnet.sui.io/v2/gas",
+		ExplorerTransactionURL: "https://suiscan.xyz/testnet/tx/%s",
+		ExplorerObjectURL:      "https://suiscan.xyz/testnet/object/%s",
+		ExplorerAddressURL:     "https://suiscan.xyz/testnet/account/%s",
+		ExplorerCheckpointURL:  "https://suiscan.xyz/testnet/checkpoint/%s",
+	}
+
+	DevnetConfig = Config{
+		Name:                   Devnet,
+		GraphQLURL:             "https://graphql.devnet.sui.io/graphql",
+		GRPCURL:                "https://fullnode.devnet.sui.io",
+		FaucetURL:              "https://faucet.devnet.sui.io/v2/gas",
+		ExplorerTransactionURL: "https://suiscan.xyz/devnet/tx/%s",
+		ExplorerObjectURL:      "https://suiscan.xyz/devnet/object/%s",
+		ExplorerAddressURL:     "https://suiscan.xyz/devnet/account/%s",
+		ExplorerCheckpointURL:  "https://suiscan.xyz/devnet/checkpoint/%s",
+	}
+
+	LocalnetConfig = Config{
+		Name:       Localnet,
+		GraphQLURL: "http://127.0.0.1:9125/graphql",
+		GRPCURL:    "http://127.0.0.1:9000",
+		FaucetURL:  "http://127.0.0.1:9123/v2/gas",
+	}
+)
+
+var known = []Config{MainnetConfig, TestnetConfig, DevnetConfig, LocalnetConfig}
+
+// Get returns the profile for a known network name.
+func Get(name Name) (Config, bool) {
+	for _, cfg := range known {
+		if cfg.Name == name {
+			return cfg, true
+		}
+	}
+	return Config{}, false
+}
+
+// FromChainIdentifier returns the profile whose chain identifier matches id.
+// Only networks with a stable identifier (mainnet and testnet) can match.
+func FromChainIdentifier(id string) (Config, bool) {
+	id = strings.ToLower(strings.TrimPrefix(id, "0x"))
+	for _, cfg := range known {
+		if cfg.ChainID != "" && cfg.ChainID == id {
+			return cfg, true
+		}
+	}
+	return Config{}, false
+}
+
+// FromEndpoint returns the profile whose GraphQL or gRPC URL matches endpoint.
+// Any loopback endpoint is treated as localnet.
+func FromEndpoint(endpoint string) (Config, bool) {
+	trimmed := strings.TrimRight(endpoint, "/")
+	for _, cfg := range known {
+		if trimmed == cfg.GraphQLURL || trimmed == cfg.GRPCURL {
+			return cfg, true
+		}
+	}
+
+	host := endpoint
+	if u, err := url.Parse(endpoint); err == nil && u.Host != "" {
+		host = u.Hostname()
+	} else if h, _, err := net.SplitHostPort(endpoint); err == nil {
+		host = h
+	}
+	if host == "localhost" {
+		return LocalnetConfig, true
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return LocalnetConfig, true
+	}
+	return Config{}, false
+}
+
+// Detect identifies the network from its chain identifier, falling back to
+// the endpoint for networks without a stable identifier. The returned config
+// always carries the observed chain identifier.
+func Detect(chainID, endpoint string) Config {
+	chainID = strings.ToLower(strings.TrimPrefix(chainID, "0x"))
+	if cfg, ok := FromChainIdentifier(chainID); ok {
+		return cfg
+	}
+	if cfg, ok := FromEndpoint(endpoint); ok && cfg.ChainID == "" {
+		cfg.ChainID = chainID
+		return cfg
+	}
+	return Config{Name: Custom, ChainID: chainID}
+}
+
+// ChainIdentifierFromDigest derives the chain identifier, the hex encoding of
+// the first four bytes of the genesis checkpoint digest, from a base58 digest.
+func ChainIdentifierFromDigest(genesisDigest string) (string, error) {
+	digest, err := utils.ParseDigest(genesisDigest)
+	if err != nil {
+		return "", fmt.Errorf("parse genesis digest: %w", err)
+	}
+	return hex.EncodeToString(digest[:4]), nil
+}
+
+// TransactionURL returns the explorer link for a transaction digest.
+func (c Config) TransactionURL(digest string) string {
+	return explorerURL(c.ExplorerTransactionURL, digest)
+}
+
+// ObjectURL returns the explorer link for an object ID.
+func (c Config) ObjectURL(objectID string) string {
+	return explorerURL(c.ExplorerObjectURL, objectID)
+}
+
+// AddressURL returns the explorer link for an account address.
+func (c Config) AddressURL(address string) string {
+	return explorerURL(c.ExplorerAddressURL, address)
+}
+
+// CheckpointURL returns the explorer link for a checkpoint sequence number.
+func (c Config) CheckpointURL(sequenceNumber uint64) string {
+	return explorerURL(c.ExplorerCheckpointURL, fmt.Sprint(sequenceNumber))
+}
+
+func explorerURL(template, value string) string {
+	if template == "" {
+		return ""
+	}
+	return fmt.Sprintf(template, value)
+}
@@ -0,0 +1,57 @@
+package network
+
+import "testing"
+
+func TestChainIdentifierFromDigest(t *testing.T) {
+	cases := map[string]string{
+		"4btiuiMPvEENsttpZC7CZ53DruC3MAgfznDbASZ7DR6S": MainnetChainID,
+		"69WiPg3DAQiq5ijkxyuaCF2Wm4LsznPL9pu4RqQ2vfpe": TestnetChainID,
+	}
+	for digest, want := range cases {
+		got, err := ChainIdentifierFromDigest(digest)
+		if err != nil {
+			t.Fatalf("digest %s: %v", digest, err)
+		}
+		if got != want {
+			t.Fatalf("digest %s: got %s, want %s", digest, got, want)
+		}
+	}
+
+	if _, err := ChainIdentifierFromDigest("not-a-digest"); err == nil {
+		t.Fatalf("expected error for invalid digest")
+	}
+}
+
+func TestDetect(t *testing.T) {
+	if cfg := Detect("0x35834A8A", "https://example.com"); cfg.Name != Mainnet {
+		t.Fatalf("expected mainnet, got %s", cfg.Name)
+	}
+
+	cfg := Detect("abcd1234", DevnetConfig.GraphQLURL+"/")
+	if cfg.Name != Devnet || cfg.ChainID != "abcd1234" {
+		t.Fatalf("expected devnet with observed chain id, got %+v", cfg)
+	}
+
+	cfg = Detect("deadbeef", "http://localhost:9000")
+	if cfg.Name != Localnet || cfg.ChainID != "deadbeef" {
+		t.Fatalf("expected localnet, got %+v", cfg)
+	}
+
+	// A mainnet endpoint reporting a different chain is not mainnet.
+	cfg = Detect("deadbeef", MainnetConfig.GraphQLURL)
+	if cfg.Name != Custom {
+		t.Fatalf("expected custom, got %+v", cfg)
+	}
+}
+
+func TestExplorerURLs(t *testing.T) {
+	if got := TestnetConfig.TransactionURL("abc"); got != "https://suiscan.xyz/testnet/tx/abc" {
+		t.Fatalf("unexpected transaction url %s", got)
+	}
+	if got := TestnetConfig.CheckpointURL(42); got != "https://suiscan.xyz/testnet/checkpoint/42" {
+		t.Fatalf("unexpected checkpoint url %s", got)
+	}
+	if got := LocalnetConfig.ObjectURL("0x1"); got != "" {
+		t.Fatalf("expected no localnet explorer, got %s", got)
+	}
+}
@@ -0,0 +1,302 @@
+// Package offline supports signing transactions on a machine without
+// network access. An online machine builds a transaction and exports a
+// SigningRequest: a JSON envelope with the BCS bytes, their digest and a
+// readable summary. The air-gapped machine validates the request, shows the
+// summary and signs it into a SignedTransaction, which the online machine
+// validates again and executes. A Store keeps envelopes on disk between the
+// steps.
+package offline
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	cryptotx "github.com/open-move/sui-go-sdk/cryptography/transaction"
+	"github.com/open-move/sui-go-sdk/grpc"
+	"github.com/open-move/sui-go-sdk/keychain"
+	"github.com/open-move/sui-go-sdk/preview"
+	"github.com/open-move/sui-go-sdk/transaction"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+// Version is the envelope format written by this package.
+const Version = 1
+
+var (
+	// ErrDigestMismatch reports an envelope whose digest does not match its
+	// transaction bytes, i.e. the bytes were altered after export.
+	ErrDigestMismatch = errors.New("offline: transaction digest does not match bytes")
+	// ErrUnauthorizedSigner reports a signature from an address that is
+	// neither the transaction's sender nor its gas owner.
+	ErrUnauthorizedSigner = errors.New("offline: signer is not the sender or gas owner")
+)
+
+// SigningRequest is a built transaction waiting for signatures.
+type SigningRequest struct {
+	Version int `json:"version"`
+	// TransactionBytes is the base64 encoded BCS TransactionData.
+	TransactionBytes string `json:"transactionBytes"`
+	// Digest is the base58 transaction digest of TransactionBytes.
+	Digest string `json:"digest"`
+	Sender string `json:"sender"`
+	// GasOwner is set when it differs from the sender, for sponsored
+	// transactions that need both signatures.
+	GasOwner string `json:"gasOwner,omitempty"`
+	// Summary describes what the transaction does, for the signer to
+	// review. It is informational; validation never trusts it.
+	Summary   string    `json:"summary,omitempty"`
+	ChainID   string    `json:"chainId,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// SignedTransaction is a transaction with its signatures, ready to submit.
+type SignedTransaction struct {
+	Version          int    `json:"version"`
+	TransactionBytes string `json:"transactionBytes"`
+	Digest           string `json:"digest"`
+	// Signatures are base64 encoded `flag || signature || publicKey`
+	// signatures, in the order they are submitted.
+	Signatures []string `json:"signatures"`
+	ChainID    string   `json:"chainId,omitempty"`
+}
+
+// RequestOptions configures NewSigningRequest.
+type RequestOptions struct {
+	// Previewer renders the summary. The default shows amounts in base
+	// units, as it has no coin metadata.
+	Previewer *preview.Previewer
+	// ChainID records the network the transaction was built for.
+	ChainID string
+}
+
+// NewSigningRequest wraps BCS TransactionData, such as
+// transaction.BuildResult.TransactionBytes, in a signing request.
+func NewSigningRequest(ctx context.Context, txBytes []byte, opts RequestOptions) (*SigningRequest, error) {
+	data, err := transaction.DecodeTransactionData(txBytes)
+	if err != nil {
+		return nil, err
+	}
+	req := &SigningRequest{
+		Version:          Version,
+		TransactionBytes: base64.StdEncoding.EncodeToString(txBytes),
+		Digest:           transaction.TransactionDigest(txBytes).String(),
+		Sender:           data.V1.Sender.String(),
+		ChainID:          opts.ChainID,
+		CreatedAt:        time.Now().UTC(),
+	}
+	if owner := data.V1.GasData.Owner; owner != data.V1.Sender {
+		req.GasOwner = owner.String()
+	}
+
+	previewer := opts.Previewer
+	if previewer == nil {
+		previewer = preview.New(preview.Options{})
+	}
+	if summary, err := previewer.FromBytes(ctx, txBytes); err == nil {
+		req.Summary = summary.String()
+	}
+	return req, nil
+}
+
+// Validate checks the request's version and that its digest, sender and
+// gas owner match the transaction bytes. It returns the bytes.
+func (r *SigningRequest) Validate() ([]byte, error) {
+	if r.Version != Version {
+		return nil, fmt.Errorf("offline: unsupported envelope version %d", r.Version)
+	}
+	txBytes, err := validateBytes(r.TransactionBytes, r.Digest)
+	if err != nil {
+		return nil, err
+	}
+	data, err := transaction.DecodeTransactionData(txBytes)
+	if err != nil {
+		return nil, err
+	}
+	if !sameAddress(r.Sender, data.V1.Sender.String()) {
+		return nil, fmt.Errorf("offline: request sender %s does not match transaction sender %s", r.Sender, data.V1.Sender)
+	}
+	if r.GasOwner != "" && !sameAddress(r.GasOwner, data.V1.GasData.Owner.String()) {
+		return nil, fmt.Errorf("offline: request gas owner %s does not match transaction gas owner %s", r.GasOwner, data.V1.GasData.Owner)
+	}
+	return txBytes, nil
+}
+
+// Sign validates the request and signs it with each signer, which must be
+// the sender or the gas owner.
+func (r *SigningRequest) Sign(signers ...transaction.TransactionSigner) (*SignedTransaction, error) {
+	if _, err := r.Validate(); err != nil {
+		return nil, err
+	}
+	signed := &SignedTransaction{
+		Version:          Version,
+		TransactionBytes: r.TransactionBytes,
+		Digest:           r.Digest,
+		ChainID:          r.ChainID,
+	}
+	return signed, signed.AddSignatures(signers...)
+}
+
+// AddSignatures signs the transaction with more signers, e.g. the sponsor
+// after the sender has signed on another machine.
+func (s *SignedTransaction) AddSignatures(signers ...transaction.TransactionSigner) error {
+	if len(signers) == 0 {
+		return errors.New("offline: no signers")
+	}
+	txBytes, err := validateBytes(s.TransactionBytes, s.Digest)
+	if err != nil {
+		return err
+	}
+	authorized, err := authorizedSigners(txBytes)
+	if err != nil {
+		return err
+	}
+	for _, signer := range signers {
+		if signer == nil {
+			return errors.New("offline: nil signer")
+		}
+		address, err := signer.SuiAddress()
+		if err != nil {
+			return err
+		}
+		if !authorized[normalize(address)] {
+			return fmt.Errorf("%w: %s", ErrUnauthorizedSigner, address)
+		}
+		signature, err := signer.SignTransaction(txBytes)
+		if err != nil {
+			return fmt.Errorf("offline: sign: %w", err)
+		}
+		s.Signatures = append(s.Signatures, base64.StdEncoding.EncodeToString(signature))
+	}
+	return nil
+}
+
+// Validate checks the envelope's digest against its bytes and that every
+// signature carries the public key of the sender or gas owner. It returns
+// the transaction bytes and raw signatures ready to submit. The signatures
+// themselves are verified by the network on execution.
+func (s *SignedTransaction) Validate() ([]byte, [][]byte, error) {
+	if s.Version != Version {
+		return nil, nil, fmt.Errorf("offline: unsupported envelope version %d", s.Version)
+	}
+	if len(s.Signatures) == 0 {
+		return nil, nil, errors.New("offline: transaction has no signatures")
+	}
+	txBytes, err := validateBytes(s.TransactionBytes, s.Digest)
+	if err != nil {
+		return nil, nil, err
+	}
+	authorized, err := authorizedSigners(txBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	signatures := make([][]byte, len(s.Signatures))
+	for i, encoded := range s.Signatures {
+		parsed, err := cryptotx.ParseSerializedSignature(encoded)
+		if err != nil {
+			return nil, nil, fmt.Errorf("offline: signature %d: %w", i, err)
+		}
+		address, err := keychain.AddressFromPublicKey(parsed.Scheme, parsed.PublicKey)
+		if err != nil {
+			return nil, nil, fmt.Errorf("offline: signature %d: %w", i, err)
+		}
+		if !authorized[normalize(address)] {
+			return nil, nil, fmt.Errorf("%w: signature %d is from %s", ErrUnauthorizedSigner, i, address)
+		}
+		signatures[i], _ = base64.StdEncoding.DecodeString(encoded)
+	}
+	return txBytes, signatures, nil
+}
+
+// Execute validates a signed transaction and submits it through client.
+func Execute(ctx context.Context, client *grpc.Client, signed *SignedTransaction, options *grpc.ExecuteOptions) (*grpc.ExecutionResult, error) {
+	if client == nil {
+		return nil, errors.New("nil client")
+	}
+	txBytes, signatures, err := signed.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return client.ExecuteTransactionBytes(ctx, txBytes, signatures, options)
+}
+
+func validateBytes(encoded, digest string) ([]byte, error) {
+	txBytes, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("offline: decode transaction bytes: %w", err)
+	}
+	if len(txBytes) == 0 {
+		return nil, errors.New("offline: empty transaction bytes")
+	}
+	if transaction.TransactionDigest(txBytes).String() != digest {
+		return nil, ErrDigestMismatch
+	}
+	return txBytes, nil
+}
+
+func authorizedSigners(txBytes []byte) (map[string]bool, error) {
+	data, err := transaction.DecodeTransactionData(txBytes)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]bool{
+		data.V1.Sender.String():        true,
+		data.V1.GasData.Owner.String(): true,
+	}, nil
+}
+
+func normalize(address string) string {
+	parsed, err := utils.ParseAddress(address)
+	if err != nil {
+		return address
+	}
+	return parsed.String()
+}
+
+func sameAddress(a, b string) bool {
+	return normalize(a) == normalize(b)
+}
+
+// WriteFile writes v, a SigningRequest or SignedTransaction, as indented
+// JSON readable only by the owner.
+func WriteFile(path string, v any) error {
+	encoded, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(encoded, '\n'), 0o600)
+}
+
+// ReadRequest reads a SigningRequest written by WriteFile.
+func ReadRequest(path string) (*SigningRequest, error) {
+	var req SigningRequest
+	if err := readJSON(path, &req); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// ReadSigned reads a SignedTransaction written by WriteFile.
+func ReadSigned(path string) (*SignedTransaction, error) {
+	var signed SignedTransaction
+	if err := readJSON(path, &signed); err != nil {
+		return nil, err
+	}
+	return &signed, nil
+}
+
+func readJSON(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("offline: %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package offline
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/cryptography/ed25519"
+	"github.com/open-move/sui-go-sdk/transaction"
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+func buildTransfer(t *testing.T, sender string) []byte {
+	t.Helper()
+	tx := transaction.New()
+	tx.SetSender(sender).SetGasBudget(5000).SetGasPrice(1).SetGasPayment([]types.ObjectRef{
+		{ObjectID: utils.MustParseAddress("0x9"), Version: 3, Digest: types.Digest(bytes.Repeat([]byte{1}, 32))},
+	})
+	tx.PayAllSui("0xb")
+	built, err := tx.Build(context.Background(), transaction.BuildOptions{})
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	return built.TransactionBytes
+}
+
+func TestOfflineRoundTrip(t *testing.T) {
+	kp, err := ed25519.Generate()
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	sender, _ := kp.SuiAddress()
+	txBytes := buildTransfer(t, sender)
+
+	req, err := NewSigningRequest(context.Background(), txBytes, RequestOptions{ChainID: "4c78adac"})
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	if req.Digest != transaction.TransactionDigest(txBytes).String() || req.GasOwner != "" || req.Summary == "" {
+		t.Fatalf("unexpected request %+v", req)
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "request.json")
+	if err := WriteFile(path, req); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	loaded, err := ReadRequest(path)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	signed, err := loaded.Sign(kp)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	gotBytes, signatures, err := signed.Validate()
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if !bytes.Equal(gotBytes, txBytes) || len(signatures) != 1 {
+		t.Fatalf("unexpected validated transaction")
+	}
+
+	store, err := NewStore(filepath.Join(dir, "signed"))
+	if err != nil {
+		t.Fatalf("store: %v", err)
+	}
+	if err := store.Save(signed); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	digests, _ := store.List()
+	if len(digests) != 1 || digests[0] != req.Digest {
+		t.Fatalf("unexpected digests %v", digests)
+	}
+	again, err := store.Load(req.Digest)
+	if err != nil || again.Signatures[0] != signed.Signatures[0] {
+		t.Fatalf("load: %v", err)
+	}
+	if err := store.Remove(req.Digest); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if _, err := store.Load(req.Digest); !errors.Is(err, ErrNotStored) {
+		t.Fatalf("expected ErrNotStored, got %v", err)
+	}
+}
+
+func TestOfflineRejectsTampering(t *testing.T) {
+	kp, _ := ed25519.Generate()
+	sender, _ := kp.SuiAddress()
+	req, err := NewSigningRequest(context.Background(), buildTransfer(t, sender), RequestOptions{})
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+
+	tampered := *req
+	tampered.TransactionBytes = base64.StdEncoding.EncodeToString(buildTransfer(t, "0xa"))
+	if _, err := tampered.Sign(kp); !errors.Is(err, ErrDigestMismatch) {
+		t.Fatalf("expected ErrDigestMismatch, got %v", err)
+	}
+
+	mislabeled := *req
+	mislabeled.Sender = "0xb"
+	if _, err := mislabeled.Sign(kp); err == nil || !strings.Contains(err.Error(), "request sender") {
+		t.Fatalf("expected a sender mismatch, got %v", err)
+	}
+
+	other, _ := ed25519.Generate()
+	if _, err := req.Sign(other); !errors.Is(err, ErrUnauthorizedSigner) {
+		t.Fatalf("expected ErrUnauthorizedSigner, got %v", err)
+	}
+
+	signed, err := req.Sign(kp)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	forged := *signed
+	forgedSig, _ := other.SignTransaction(mustDecode(t, req.TransactionBytes))
+	forged.Signatures = []string{base64.StdEncoding.EncodeToString(forgedSig)}
+	if _, _, err := forged.Validate(); !errors.Is(err, ErrUnauthorizedSigner) {
+		t.Fatalf("expected ErrUnauthorizedSigner, got %v", err)
+	}
+	if _, _, err := signed.Validate(); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+}
+
+func mustDecode(t *testing.T, encoded string) []byte {
+	t.Helper()
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	return decoded
+}
@@ -0,0 +1,76 @@
+package offline
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ErrNotStored reports a digest with no transaction in the store.
+var ErrNotStored = errors.New("offline: transaction not stored")
+
+const signedSuffix = ".signed.json"
+
+// Store keeps pre-signed transactions in a directory, one file per
+// transaction named by its digest, until they are executed.
+type Store struct {
+	dir string
+}
+
+// NewStore returns a store backed by dir, creating it if needed.
+func NewStore(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &Store{dir: dir}, nil
+}
+
+// Save validates and stores a signed transaction, replacing any earlier
+// copy with the same digest.
+func (s *Store) Save(signed *SignedTransaction) error {
+	if _, _, err := signed.Validate(); err != nil {
+		return err
+	}
+	return WriteFile(s.path(signed.Digest), signed)
+}
+
+// Load returns the stored transaction with the given digest.
+func (s *Store) Load(digest string) (*SignedTransaction, error) {
+	signed, err := ReadSigned(s.path(digest))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrNotStored, digest)
+	}
+	return signed, err
+}
+
+// List returns the digests of all stored transactions, sorted.
+func (s *Store) List() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	var digests []string
+	for _, entry := range entries {
+		if name := entry.Name(); !entry.IsDir() && strings.HasSuffix(name, signedSuffix) {
+			digests = append(digests, strings.TrimSuffix(name, signedSuffix))
+		}
+	}
+	sort.Strings(digests)
+	return digests, nil
+}
+
+// Remove deletes a stored transaction, typically after it executed.
+func (s *Store) Remove(digest string) error {
+	err := os.Remove(s.path(digest))
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w: %s", ErrNotStored, digest)
+	}
+	return err
+}
+
+func (s *Store) path(digest string) string {
+	return filepath.Join(s.dir, filepath.Base(digest)+signedSuffix)
+}
@@ -0,0 +1,83 @@
+package preview
+
+import (
+	"context"
+	"errors"
+
+	"github.com/open-move/sui-go-sdk/graphql"
+	"github.com/open-move/sui-go-sdk/types"
+)
+
+var (
+	_ ObjectTypeResolver = (*GraphQLMetadata)(nil)
+	_ GasEstimator       = (*GraphQLMetadata)(nil)
+)
+
+// GraphQLMetadata resolves object types and gas estimates through the Sui
+// GraphQL API.
+type GraphQLMetadata struct {
+	client *graphql.Client
+}
+
+// NewGraphQLMetadata returns metadata backed by client.
+func NewGraphQLMetadata(client *graphql.Client) *GraphQLMetadata {
+	return &GraphQLMetadata{client: client}
+}
+
+// NewGraphQL returns a Previewer that looks up coin metadata, object types
+// and gas estimates through client.
+func NewGraphQL(client *graphql.Client) *Previewer {
+	metadata := NewGraphQLMetadata(client)
+	return New(Options{
+		Coins:   graphql.NewCoinInfoProvider(client),
+		Objects: metadata,
+		Gas:     metadata,
+	})
+}
+
+// ObjectTypes implements ObjectTypeResolver.
+func (m *GraphQLMetadata) ObjectTypes(ctx context.Context, ids []types.Address) (map[types.Address]string, error) {
+	if m == nil || m.client == nil {
+		return nil, errors.New("nil client")
+	}
+
+	objects, err := m.client.GetMultipleObjects(ctx, ids, nil)
+	if err != nil {
+		return nil, err
+	}
+	resolved := make(map[types.Address]string, len(objects))
+	for _, obj := range objects {
+		if obj.AsMoveObject != nil && obj.AsMoveObject.Contents != nil {
+			resolved[obj.Address] = obj.AsMoveObject.Contents.Type.Repr
+		}
+	}
+	return resolved, nil
+}
+
+// EstimateGas implements GasEstimator by simulating the transaction.
+func (m *GraphQLMetadata) EstimateGas(ctx context.Context, txBytes []byte) (uint64, error) {
+	if m == nil || m.client == nil {
+		return 0, errors.New("nil client")
+	}
+
+	result, err := graphql.SimulateTransaction(m.client, ctx, txBytes, nil)
+	if err != nil {
+		return 0, err
+	}
+	if result == nil || result.Error != nil {
+		if result != nil {
+			return 0, errors.New(*result.Error)
+		}
+		return 0, errors.New("empty simulation result")
+	}
+	if result.Effects == nil || result.Effects.GasEffects == nil || result.Effects.GasEffects.GasSummary == nil {
+		return 0, errors.New("simulation result missing gas summary")
+	}
+
+	summary := result.Effects.GasEffects.GasSummary
+	charged := uint64(summary.ComputationCost) + uint64(summary.StorageCost)
+	if rebate := uint64(summary.StorageRebate); rebate < charged {
+		return charged - rebate, nil
+	}
+	return 0, nil
+}
@@ -0,0 +1,444 @@
+// Package preview turns transaction bytes into a human-readable summary for
+// wallet confirmation screens: the commands a transaction runs, the coins and
+// objects it sends and to whom, the Move functions it calls and what it may
+// cost in gas.
+package preview
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/open-move/sui-go-sdk/graphql"
+	"github.com/open-move/sui-go-sdk/transaction"
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+const suiCoinType = "0x2::sui::SUI"
+
+// ObjectTypeResolver reports the Move types of objects. Objects it does not
+// know are omitted from the result.
+type ObjectTypeResolver interface {
+	ObjectTypes(ctx context.Context, ids []types.Address) (map[types.Address]string, error)
+}
+
+// GasEstimator estimates what a transaction will cost by simulating it. The
+// returned cost is computation plus storage minus the storage rebate, in
+// MIST, or zero when the rebate exceeds the charges.
+type GasEstimator interface {
+	EstimateGas(ctx context.Context, txBytes []byte) (uint64, error)
+}
+
+// Options configures a Previewer. Every field is optional: without Coins
+// amounts are shown in base units, without Objects input objects are shown
+// by ID only, and without Gas the preview has no gas estimate.
+type Options struct {
+	Coins   graphql.CoinInfoProvider
+	Objects ObjectTypeResolver
+	Gas     GasEstimator
+}
+
+// Previewer builds transaction previews.
+type Previewer struct {
+	opts Options
+}
+
+// New returns a Previewer that looks up metadata through opts.
+func New(opts Options) *Previewer {
+	return &Previewer{opts: opts}
+}
+
+// Preview is a human-readable summary of a transaction.
+type Preview struct {
+	Sender types.Address
+	// Commands describes each command in order.
+	Commands []Command
+	// Transfers lists what each TransferObjects command sends, in order.
+	Transfers []Transfer
+	// MoveCalls lists the distinct Move functions the transaction calls.
+	MoveCalls []string
+	Gas       Gas
+}
+
+// Command describes a single programmable transaction command.
+type Command struct {
+	// Kind is the command name, such as "MoveCall" or "TransferObjects".
+	Kind    string
+	Summary string
+	// Target is the called function for MoveCall commands.
+	Target string
+}
+
+// Transfer is a set of assets sent to one recipient.
+type Transfer struct {
+	// Recipient is nil when the address is computed by an earlier command.
+	Recipient *types.Address
+	Assets    []Asset
+}
+
+// Asset is an object or coin moved by a transaction.
+type Asset struct {
+	// ObjectID is set for objects passed in as inputs.
+	ObjectID *types.Address
+	// Type is the Move type, when known.
+	Type string
+	// Amount is set for coins whose balance the transaction determines,
+	// such as coins split off with a fixed amount.
+	Amount      *Amount
+	Description string
+}
+
+// Amount is a coin amount.
+type Amount struct {
+	CoinType string
+	Value    *big.Int
+	// Formatted renders Value with the coin's decimals and symbol when its
+	// metadata is available, e.g. "1.5 SUI", or in base units otherwise.
+	Formatted string
+}
+
+// Gas summarizes the transaction's gas settings.
+type Gas struct {
+	Owner  types.Address
+	Price  uint64
+	Budget uint64
+	// MaxFee is the most the transaction can cost: its budget, in SUI.
+	MaxFee Amount
+	// Estimated is the simulated cost, when a GasEstimator is configured
+	// and the simulation succeeded.
+	Estimated *Amount
+}
+
+// FromBuildResult previews a transaction built with transaction.Build.
+func (p *Previewer) FromBuildResult(ctx context.Context, result transaction.BuildResult) (*Preview, error) {
+	return p.FromBytes(ctx, result.TransactionBytes)
+}
+
+// FromBytes previews BCS-encoded TransactionData.
+func (p *Previewer) FromBytes(ctx context.Context, txBytes []byte) (*Preview, error) {
+	data, err := transaction.DecodeTransactionData(txBytes)
+	if err != nil {
+		return nil, err
+	}
+	v1 := data.V1
+	ptb := v1.Kind.ProgrammableTransaction
+	if ptb == nil {
+		return nil, transaction.ErrMissingProgrammableKind
+	}
+
+	w := &walker{p: p, ctx: ctx, ptb: ptb, results: make(map[transaction.NestedResult]value)}
+	if err := w.resolveInputTypes(); err != nil {
+		return nil, err
+	}
+
+	preview := &Preview{Sender: v1.Sender}
+	seenCalls := make(map[string]bool)
+	for i, cmd := range ptb.Commands {
+		command, transfer := w.command(uint16(i), cmd)
+		preview.Commands = append(preview.Commands, command)
+		if transfer != nil {
+			preview.Transfers = append(preview.Transfers, *transfer)
+		}
+		if command.Target != "" && !seenCalls[command.Target] {
+			seenCalls[command.Target] = true
+			preview.MoveCalls = append(preview.MoveCalls, command.Target)
+		}
+	}
+
+	gas := v1.GasData
+	preview.Gas = Gas{
+		Owner:  gas.Owner,
+		Price:  gas.Price,
+		Budget: gas.Budget,
+		MaxFee: p.amount(ctx, suiCoinType, new(big.Int).SetUint64(gas.Budget)),
+	}
+	if p.opts.Gas != nil {
+		if cost, err := p.opts.Gas.EstimateGas(ctx, txBytes); err == nil {
+			estimated := p.amount(ctx, suiCoinType, new(big.Int).SetUint64(cost))
+			preview.Gas.Estimated = &estimated
+		}
+	}
+	return preview, nil
+}
+
+// String renders the preview as indented lines of text.
+func (p *Preview) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Sender: %s\n", p.Sender)
+	for i, cmd := range p.Commands {
+		fmt.Fprintf(&b, "  %d. %s\n", i+1, cmd.Summary)
+	}
+	fmt.Fprintf(&b, "Gas: up to %s", p.Gas.MaxFee.Formatted)
+	if p.Gas.Estimated != nil {
+		fmt.Fprintf(&b, " (estimated %s)", p.Gas.Estimated.Formatted)
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// amount formats value in coinType, falling back to base units when the
+// coin's metadata is unavailable.
+func (p *Previewer) amount(ctx context.Context, coinType string, value *big.Int) Amount {
+	amount := Amount{CoinType: coinType, Value: value, Formatted: value.String() + " " + shortType(coinType)}
+	if p.opts.Coins != nil {
+		formatter := graphql.NewCoinFormatter(p.opts.Coins, nil)
+		if formatted, err := formatter.FormatBalance(ctx, value, coinType); err == nil {
+			amount.Formatted = formatted
+		}
+	}
+	return amount
+}
+
+// value is what the walker knows about an argument.
+type value struct {
+	objectID *types.Address
+	typ      string
+	coinType string // set when the value is a coin
+	amount   *big.Int
+	desc     string
+}
+
+type walker struct {
+	p          *Previewer
+	ctx        context.Context
+	ptb        *transaction.ProgrammableTransaction
+	inputTypes map[types.Address]string
+	results    map[transaction.NestedResult]value
+}
+
+func (w *walker) resolveInputTypes() error {
+	if w.p.opts.Objects == nil {
+		return nil
+	}
+	var ids []types.Address
+	for _, in := range w.ptb.Inputs {
+		if id, ok := inputObjectID(in); ok {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	resolved, err := w.p.opts.Objects.ObjectTypes(w.ctx, ids)
+	if err != nil {
+		return fmt.Errorf("resolve input object types: %w", err)
+	}
+	w.inputTypes = resolved
+	return nil
+}
+
+func inputObjectID(in transaction.CallArg) (types.Address, bool) {
+	if in.Object == nil {
+		return types.Address{}, false
+	}
+	switch {
+	case in.Object.ImmOrOwnedObject != nil:
+		return in.Object.ImmOrOwnedObject.ObjectID, true
+	case in.Object.SharedObject != nil:
+		return in.Object.SharedObject.ObjectID, true
+	case in.Object.Receiving != nil:
+		return in.Object.Receiving.ObjectID, true
+	}
+	return types.Address{}, false
+}
+
+func (w *walker) command(index uint16, cmd transaction.Command) (Command, *Transfer) {
+	switch {
+	case cmd.MoveCall != nil:
+		call := cmd.MoveCall
+		target := fmt.Sprintf("%s::%s::%s", call.Package, call.Module, call.Function)
+		summary := "Call " + shortType(target)
+		if len(call.TypeArguments) > 0 {
+			args := make([]string, len(call.TypeArguments))
+			for i, arg := range call.TypeArguments {
+				args[i] = shortType(arg.String())
+			}
+			summary += "<" + strings.Join(args, ", ") + ">"
+		}
+		w.results[transaction.NestedResult{Index: index}] = value{desc: "result of " + shortType(target)}
+		return Command{Kind: "MoveCall", Target: target, Summary: summary}, nil
+
+	case cmd.SplitCoins != nil:
+		source := w.value(cmd.SplitCoins.Coin)
+		parts := make([]string, len(cmd.SplitCoins.Amounts))
+		for i, arg := range cmd.SplitCoins.Amounts {
+			split := value{coinType: source.coinType, typ: source.typ, desc: "coin split from " + source.desc}
+			if amount, ok := w.pureU64(arg); ok {
+				split.amount = new(big.Int).SetUint64(amount)
+			}
+			parts[i] = w.describeAmount(split)
+			if split.amount != nil {
+				split.desc = parts[i]
+			}
+			w.results[transaction.NestedResult{Index: index, ResultIndex: uint16(i)}] = split
+		}
+		return Command{Kind: "SplitCoins", Summary: fmt.Sprintf("Split %s from %s", strings.Join(parts, ", "), source.desc)}, nil
+
+	case cmd.MergeCoins != nil:
+		dest := w.value(cmd.MergeCoins.Destination)
+		return Command{Kind: "MergeCoins", Summary: fmt.Sprintf("Merge %d coin(s) into %s", len(cmd.MergeCoins.Sources), dest.desc)}, nil
+
+	case cmd.TransferObjects != nil:
+		transfer := &Transfer{}
+		recipient := "a computed address"
+		if addr, ok := w.pureAddress(cmd.TransferObjects.Address); ok {
+			transfer.Recipient = &addr
+			recipient = addr.String()
+		}
+		descs := make([]string, len(cmd.TransferObjects.Objects))
+		for i, arg := range cmd.TransferObjects.Objects {
+			asset := w.asset(w.value(arg))
+			transfer.Assets = append(transfer.Assets, asset)
+			descs[i] = asset.Description
+		}
+		return Command{Kind: "TransferObjects", Summary: fmt.Sprintf("Transfer %s to %s", strings.Join(descs, ", "), recipient)}, transfer
+
+	case cmd.MakeMoveVec != nil:
+		summary := fmt.Sprintf("Make a vector of %d element(s)", len(cmd.MakeMoveVec.Elements))
+		if !cmd.MakeMoveVec.Type.None {
+			summary = fmt.Sprintf("Make a vector<%s> of %d element(s)", shortType(cmd.MakeMoveVec.Type.Some.String()), len(cmd.MakeMoveVec.Elements))
+		}
+		w.results[transaction.NestedResult{Index: index}] = value{desc: "vector"}
+		return Command{Kind: "MakeMoveVec", Summary: summary}, nil
+
+	case cmd.Publish != nil:
+		w.results[transaction.NestedResult{Index: index}] = value{desc: "upgrade capability"}
+		return Command{Kind: "Publish", Summary: fmt.Sprintf("Publish a package with %d module(s)", len(cmd.Publish.Modules))}, nil
+
+	case cmd.Upgrade != nil:
+		w.results[transaction.NestedResult{Index: index}] = value{desc: "upgrade receipt"}
+		return Command{Kind: "Upgrade", Summary: fmt.Sprintf("Upgrade package %s with %d module(s)", cmd.Upgrade.Package, len(cmd.Upgrade.Modules))}, nil
+	}
+	return Command{Kind: "Unknown", Summary: "Unknown command"}, nil
+}
+
+func (w *walker) value(arg transaction.Argument) value {
+	switch {
+	case arg.GasCoin != nil:
+		return value{coinType: suiCoinType, typ: "0x2::coin::Coin<0x2::sui::SUI>", desc: "the gas coin"}
+	case arg.Input != nil:
+		if int(*arg.Input) >= len(w.ptb.Inputs) {
+			return value{desc: "an unknown input"}
+		}
+		id, ok := inputObjectID(w.ptb.Inputs[*arg.Input])
+		if !ok {
+			return value{desc: "a pure value"}
+		}
+		v := value{objectID: &id, desc: "object " + id.String()}
+		if typ, ok := w.inputTypes[id]; ok {
+			v.typ = typ
+			v.coinType = coinType(typ)
+			if v.coinType != "" {
+				v.desc = shortType(v.coinType) + " coin " + id.String()
+			} else {
+				v.desc = shortType(typ) + " " + id.String()
+			}
+		}
+		return v
+	case arg.Result != nil:
+		if v, ok := w.results[transaction.NestedResult{Index: *arg.Result}]; ok {
+			return v
+		}
+	case arg.NestedResult != nil:
+		if v, ok := w.results[*arg.NestedResult]; ok {
+			return v
+		}
+		if v, ok := w.results[transaction.NestedResult{Index: arg.NestedResult.Index}]; ok {
+			return v
+		}
+	}
+	return value{desc: "an unknown value"}
+}
+
+func (w *walker) asset(v value) Asset {
+	asset := Asset{ObjectID: v.objectID, Type: v.typ, Description: v.desc}
+	if v.amount != nil && v.coinType != "" {
+		amount := w.p.amount(w.ctx, v.coinType, v.amount)
+		asset.Amount = &amount
+		asset.Description = amount.Formatted
+	}
+	return asset
+}
+
+func (w *walker) describeAmount(v value) string {
+	switch {
+	case v.amount != nil && v.coinType != "":
+		return w.p.amount(w.ctx, v.coinType, v.amount).Formatted
+	case v.amount != nil:
+		return v.amount.String()
+	}
+	return "a computed amount"
+}
+
+func (w *walker) pure(arg transaction.Argument) ([]byte, bool) {
+	if arg.Input == nil || int(*arg.Input) >= len(w.ptb.Inputs) {
+		return nil, false
+	}
+	in := w.ptb.Inputs[*arg.Input]
+	if in.Pure == nil {
+		return nil, false
+	}
+	return in.Pure.Bytes, true
+}
+
+func (w *walker) pureU64(arg transaction.Argument) (uint64, bool) {
+	raw, ok := w.pure(arg)
+	if !ok || len(raw) != 8 {
+		return 0, false
+	}
+	return binary.LittleEndian.Uint64(raw), true
+}
+
+func (w *walker) pureAddress(arg transaction.Argument) (types.Address, bool) {
+	raw, ok := w.pure(arg)
+	if !ok || len(raw) != len(types.Address{}) {
+		return types.Address{}, false
+	}
+	var addr types.Address
+	copy(addr[:], raw)
+	return addr, true
+}
+
+// coinType returns T for a 0x2::coin::Coin<T> type and "" otherwise.
+func coinType(typ string) string {
+	tag, err := utils.ParseTypeTag(typ)
+	if err != nil || tag.Struct == nil {
+		return ""
+	}
+	s := tag.Struct
+	if s.Address != utils.MustParseAddress("0x2") || s.Module != "coin" || s.Name != "Coin" || len(s.TypeParams) != 1 {
+		return ""
+	}
+	return s.TypeParams[0].String()
+}
+
+// shortType abbreviates the addresses in a Move type or function name, so
+// 0x000...002::coin::Coin reads as 0x2::coin::Coin.
+func shortType(name string) string {
+	var b strings.Builder
+	for i := 0; i < len(name); {
+		if strings.HasPrefix(name[i:], "0x") {
+			j := i + 2
+			for j < len(name) && isHex(name[j]) {
+				j++
+			}
+			digits := strings.TrimLeft(name[i+2:j], "0")
+			if digits == "" {
+				digits = "0"
+			}
+			b.WriteString("0x" + digits)
+			i = j
+			continue
+		}
+		b.WriteByte(name[i])
+		i++
+	}
+	return b.String()
+}
+
+func isHex(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
@@ -0,0 +1,141 @@
+package preview
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/graphql"
+	"github.com/open-move/sui-go-sdk/transaction"
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+type stubCoins map[string]graphql.CoinInfo
+
+func normalizeType(t *testing.T, typ string) string {
+	tag, err := utils.ParseTypeTag(typ)
+	if err != nil {
+		t.Fatalf("parse type %s: %v", typ, err)
+	}
+	return tag.String()
+}
+
+func (s stubCoins) CoinInfo(_ context.Context, coinType string) (*graphql.CoinInfo, error) {
+	tag, err := utils.ParseTypeTag(coinType)
+	if err != nil {
+		return nil, err
+	}
+	info, ok := s[tag.String()]
+	if !ok {
+		return nil, graphql.ErrCoinMetadataNotFound
+	}
+	return &info, nil
+}
+
+type stubObjects map[types.Address]string
+
+func (s stubObjects) ObjectTypes(context.Context, []types.Address) (map[types.Address]string, error) {
+	return s, nil
+}
+
+type stubGas uint64
+
+func (s stubGas) EstimateGas(context.Context, []byte) (uint64, error) { return uint64(s), nil }
+
+func TestPreview(t *testing.T) {
+	digest := types.Digest(bytes.Repeat([]byte{1}, 32))
+	usdc := utils.MustParseAddress("0xdba3")
+	usdcType := usdc.String() + "::usdc::USDC"
+	coinID := utils.MustParseAddress("0xc0")
+	nftID := utils.MustParseAddress("0xa1")
+	recipient := "0x00000000000000000000000000000000000000000000000000000000000000b0"
+
+	tx := transaction.New()
+	tx.SetSender("0x1").SetGasPrice(1000).SetGasBudget(5_000_000).SetGasPayment([]types.ObjectRef{{ObjectID: utils.MustParseAddress("0x99"), Version: 1, Digest: digest}})
+	sui := tx.SplitCoins(transaction.SplitCoins{Coin: tx.Gas(), Amounts: []transaction.Argument{tx.PureU64(1_500_000_000)}})
+	usdcCoins := tx.SplitCoins(transaction.SplitCoins{
+		Coin:    tx.ObjectRef(types.ObjectRef{ObjectID: coinID, Version: 1, Digest: digest}),
+		Amounts: []transaction.Argument{tx.PureU64(2_500_000)},
+	})
+	tx.TransferObjects(transaction.TransferObjects{
+		Objects: []transaction.Argument{sui[0], usdcCoins[0], tx.ObjectRef(types.ObjectRef{ObjectID: nftID, Version: 1, Digest: digest})},
+		Address: tx.PureAddress(recipient),
+	})
+	tx.MoveCall(transaction.MoveCall{Target: "0x2::coin::zero", TypeArguments: []string{usdcType}})
+
+	built, err := tx.Build(context.Background(), transaction.BuildOptions{})
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+
+	previewer := New(Options{
+		Coins: stubCoins{
+			normalizeType(t, suiCoinType): {Decimals: 9, Symbol: "SUI"},
+			normalizeType(t, usdcType):    {Decimals: 6, Symbol: "USDC"},
+		},
+		Objects: stubObjects{
+			coinID: "0x2::coin::Coin<" + usdcType + ">",
+			nftID:  "0x5::nft::Hero",
+		},
+		Gas: stubGas(1_234_000),
+	})
+	preview, err := previewer.FromBuildResult(context.Background(), built)
+	if err != nil {
+		t.Fatalf("preview: %v", err)
+	}
+
+	wantSummaries := []string{
+		"Split 1.5 SUI from the gas coin",
+		"Split 2.5 USDC from 0xdba3::usdc::USDC coin " + coinID.String(),
+		"Transfer 1.5 SUI, 2.5 USDC, 0x5::nft::Hero " + nftID.String() + " to " + recipient,
+		"Call 0x2::coin::zero<0xdba3::usdc::USDC>",
+	}
+	if len(preview.Commands) != len(wantSummaries) {
+		t.Fatalf("got %d commands, want %d", len(preview.Commands), len(wantSummaries))
+	}
+	for i, want := range wantSummaries {
+		if got := preview.Commands[i].Summary; got != want {
+			t.Errorf("command %d: got %q, want %q", i, got, want)
+		}
+	}
+
+	if len(preview.Transfers) != 1 || preview.Transfers[0].Recipient == nil || preview.Transfers[0].Recipient.String() != recipient {
+		t.Fatalf("unexpected transfers %+v", preview.Transfers)
+	}
+	assets := preview.Transfers[0].Assets
+	if assets[1].Amount == nil || assets[1].Amount.Value.Uint64() != 2_500_000 || assets[2].ObjectID == nil || *assets[2].ObjectID != nftID {
+		t.Fatalf("unexpected assets %+v", assets)
+	}
+	if len(preview.MoveCalls) != 1 || !strings.HasSuffix(preview.MoveCalls[0], "::coin::zero") {
+		t.Fatalf("unexpected move calls %v", preview.MoveCalls)
+	}
+	if preview.Gas.MaxFee.Formatted != "0.005 SUI" || preview.Gas.Estimated == nil || preview.Gas.Estimated.Formatted != "0.001234 SUI" {
+		t.Fatalf("unexpected gas %+v", preview.Gas)
+	}
+	if !strings.Contains(preview.String(), "Gas: up to 0.005 SUI (estimated 0.001234 SUI)") {
+		t.Fatalf("unexpected rendering:\n%s", preview)
+	}
+}
+
+func TestPreviewWithoutMetadata(t *testing.T) {
+	tx := transaction.New()
+	tx.SetSender("0x1").SetGasPrice(1000).SetGasBudget(1_000_000).SetGasPayment([]types.ObjectRef{{ObjectID: utils.MustParseAddress("0x99"), Version: 1, Digest: types.Digest(bytes.Repeat([]byte{1}, 32))}})
+	tx.PaySui([]string{"0x2"}, []uint64{42})
+	built, err := tx.Build(context.Background(), transaction.BuildOptions{})
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+
+	preview, err := New(Options{}).FromBytes(context.Background(), built.TransactionBytes)
+	if err != nil {
+		t.Fatalf("preview: %v", err)
+	}
+	if got := preview.Commands[0].Summary; got != "Split 42 0x2::sui::SUI from the gas coin" {
+		t.Fatalf("unexpected summary %q", got)
+	}
+	if preview.Gas.Estimated != nil || preview.Gas.MaxFee.Formatted != "1000000 0x2::sui::SUI" {
+		t.Fatalf("unexpected gas %+v", preview.Gas)
+	}
+}
@@ -0,0 +1,273 @@
+// Package price quotes coins in SUI or USDC at the mid price of on-chain DEX
+// pools, such as DeepBook order books and Cetus CLMM pools.
+package price
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/open-move/sui-go-sdk/graphql"
+	"github.com/open-move/sui-go-sdk/types"
+)
+
+// Coin types quotes are usually given in.
+const (
+	SUI  = "0x2::sui::SUI"
+	USDC = "0xdba34672e30cb065b1f93e3ab55318768fd6fef66c15942c9f7cb846e2f900e7::usdc::USDC"
+)
+
+// ErrNoPool is returned when no venue has a pool that prices a coin.
+var ErrNoPool = errors.New("no pool")
+
+// Pool is a DEX pool trading Base against Quote.
+type Pool struct {
+	Venue string
+	ID    types.Address
+	Base  string
+	Quote string
+	// Liquidity ranks pools of the same pair within a venue. It is nil when
+	// the venue does not report it.
+	Liquidity *big.Int
+}
+
+// Quote is the price of one whole Coin in whole units of QuoteCoin.
+type Quote struct {
+	Coin      string
+	QuoteCoin string
+	Price     *big.Float
+	// Route lists the pools the price was read from; a quote through SUI
+	// has two.
+	Route []Pool
+}
+
+// Options configures a Quoter.
+type Options struct {
+	// Venues are tried in order. The default is DeepBook, then Cetus, on
+	// mainnet.
+	Venues []Venue
+	// Coins supplies the decimals of the coins. The default reads coin
+	// metadata through the client.
+	Coins graphql.CoinInfoProvider
+}
+
+// Quoter quotes coins from DEX pools. The pools found for each pair, or
+// their absence, are cached for the life of the Quoter; prices are read on
+// every call.
+type Quoter struct {
+	client *graphql.Client
+	venues []Venue
+	coins  graphql.CoinInfoProvider
+
+	mu    sync.Mutex
+	pools map[[2]string][]Pool
+}
+
+// New returns a Quoter backed by the client. opts may be nil.
+func New(client *graphql.Client, opts *Options) *Quoter {
+	q := &Quoter{client: client, pools: make(map[[2]string][]Pool)}
+	if opts != nil {
+		q.venues = opts.Venues
+		q.coins = opts.Coins
+	}
+	if len(q.venues) == 0 {
+		q.venues = []Venue{DeepBookMainnet(), CetusMainnet()}
+	}
+	if q.coins == nil {
+		q.coins = graphql.NewCoinInfoProvider(client)
+	}
+	return q
+}
+
+// QuoteSUI quotes coin in SUI.
+func (q *Quoter) QuoteSUI(ctx context.Context, coin string) (*Quote, error) {
+	return q.Quote(ctx, coin, SUI)
+}
+
+// QuoteUSDC quotes coin in USDC.
+func (q *Quoter) QuoteUSDC(ctx context.Context, coin string) (*Quote, error) {
+	return q.Quote(ctx, coin, USDC)
+}
+
+// Price quotes coinType in USDC, taken as US dollars, so a Quoter can serve
+// as the PriceFeed of a graphql.CoinFormatter.
+func (q *Quoter) Price(ctx context.Context, coinType string) (*big.Float, string, error) {
+	quote, err := q.QuoteUSDC(ctx, coinType)
+	if err != nil {
+		return nil, "", err
+	}
+	return quote.Price, "USD", nil
+}
+
+// Quote returns the mid price of coin in quoteCoin. Without a pool between
+// them, the price is routed through SUI.
+func (q *Quoter) Quote(ctx context.Context, coin, quoteCoin string) (*Quote, error) {
+	coin, err := graphql.NormalizeTypeTag(coin)
+	if err != nil {
+		return nil, fmt.Errorf("coin: %w", err)
+	}
+	quoteCoin, err = graphql.NormalizeTypeTag(quoteCoin)
+	if err != nil {
+		return nil, fmt.Errorf("quote coin: %w", err)
+	}
+	if coin == quoteCoin {
+		return &Quote{Coin: coin, QuoteCoin: quoteCoin, Price: big.NewFloat(1)}, nil
+	}
+
+	quote, err := q.direct(ctx, coin, quoteCoin)
+	sui, _ := graphql.NormalizeTypeTag(SUI)
+	if !errors.Is(err, ErrNoPool) || coin == sui || quoteCoin == sui {
+		return quote, err
+	}
+	first, err := q.direct(ctx, coin, sui)
+	if err != nil {
+		return nil, err
+	}
+	second, err := q.direct(ctx, sui, quoteCoin)
+	if err != nil {
+		return nil, err
+	}
+	return &Quote{
+		Coin:      coin,
+		QuoteCoin: quoteCoin,
+		Price:     new(big.Float).Mul(first.Price, second.Price),
+		Route:     append(first.Route, second.Route...),
+	}, nil
+}
+
+// FindPool returns the pool Quote prefers between coin types a and b: the
+// most liquid pool of the first venue that has one.
+func (q *Quoter) FindPool(ctx context.Context, a, b string) (Pool, error) {
+	a, err := graphql.NormalizeTypeTag(a)
+	if err != nil {
+		return Pool{}, err
+	}
+	b, err = graphql.NormalizeTypeTag(b)
+	if err != nil {
+		return Pool{}, err
+	}
+	pools, err := q.candidates(ctx, a, b)
+	if err != nil {
+		return Pool{}, err
+	}
+	return pools[0], nil
+}
+
+// direct quotes coin in quoteCoin from a single pool, falling back to the
+// next venue when a pool cannot be priced, such as an empty order book.
+func (q *Quoter) direct(ctx context.Context, coin, quoteCoin string) (*Quote, error) {
+	pools, err := q.candidates(ctx, coin, quoteCoin)
+	if err != nil {
+		return nil, err
+	}
+	var errs []error
+	for _, pool := range pools {
+		price, err := q.poolPrice(ctx, pool, coin)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		return &Quote{Coin: coin, QuoteCoin: quoteCoin, Price: price, Route: []Pool{pool}}, nil
+	}
+	return nil, errors.Join(errs...)
+}
+
+// poolPrice returns the price of one whole coin in whole units of the
+// pool's other coin.
+func (q *Quoter) poolPrice(ctx context.Context, pool Pool, coin string) (*big.Float, error) {
+	venue := q.venue(pool.Venue)
+	if venue == nil {
+		return nil, fmt.Errorf("unknown venue %q", pool.Venue)
+	}
+	raw, err := venue.MidPrice(ctx, q.client, pool)
+	if err != nil {
+		return nil, err
+	}
+	if raw.Sign() <= 0 {
+		return nil, fmt.Errorf("%s pool %s: non-positive price", pool.Venue, pool.ID)
+	}
+	base, err := q.coins.CoinInfo(ctx, pool.Base)
+	if err != nil {
+		return nil, err
+	}
+	quote, err := q.coins.CoinInfo(ctx, pool.Quote)
+	if err != nil {
+		return nil, err
+	}
+
+	price := new(big.Float).Mul(raw, pow10(base.Decimals-quote.Decimals))
+	if coin != pool.Base {
+		price.Quo(big.NewFloat(1), price)
+	}
+	return price, nil
+}
+
+// candidates returns the best pool of each venue that trades a against b,
+// in venue order.
+func (q *Quoter) candidates(ctx context.Context, a, b string) ([]Pool, error) {
+	key := [2]string{a, b}
+	if b < a {
+		key = [2]string{b, a}
+	}
+	q.mu.Lock()
+	cached, ok := q.pools[key]
+	q.mu.Unlock()
+	if ok {
+		if len(cached) == 0 {
+			return nil, fmt.Errorf("%w between %s and %s", ErrNoPool, a, b)
+		}
+		return cached, nil
+	}
+
+	var pools []Pool
+	for _, venue := range q.venues {
+		found, err := venue.Pools(ctx, q.client, a, b)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", venue.Name(), err)
+		}
+		if best, ok := deepest(found); ok {
+			pools = append(pools, best)
+		}
+	}
+	q.mu.Lock()
+	q.pools[key] = pools
+	q.mu.Unlock()
+	if len(pools) == 0 {
+		return nil, fmt.Errorf("%w between %s and %s", ErrNoPool, a, b)
+	}
+	return pools, nil
+}
+
+func (q *Quoter) venue(name string) Venue {
+	for _, venue := range q.venues {
+		if venue.Name() == name {
+			return venue
+		}
+	}
+	return nil
+}
+
+// deepest returns the pool with the most liquidity, or the first pool when
+// none reports it.
+func deepest(pools []Pool) (Pool, bool) {
+	if len(pools) == 0 {
+		return Pool{}, false
+	}
+	best := pools[0]
+	for _, pool := range pools[1:] {
+		if pool.Liquidity != nil && (best.Liquidity == nil || pool.Liquidity.Cmp(best.Liquidity) > 0) {
+			best = pool
+		}
+	}
+	return best, true
+}
+
+func pow10(exp int) *big.Float {
+	n := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(max(exp, -exp))), nil))
+	if exp < 0 {
+		return n.Quo(big.NewFloat(1), n)
+	}
+	return n
+}
@@ -0,0 +1,179 @@
+package price
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/graphql"
+)
+
+const token = "0x7::token::TOKEN"
+
+type staticCoins map[string]int
+
+func (s staticCoins) CoinInfo(_ context.Context, coinType string) (*graphql.CoinInfo, error) {
+	decimals, ok := s[coinType]
+	if !ok {
+		return nil, fmt.Errorf("unknown coin %s", coinType)
+	}
+	return &graphql.CoinInfo{CoinType: coinType, Decimals: decimals}, nil
+}
+
+func normalize(t *testing.T, typeTag string) string {
+	t.Helper()
+	normalized, err := graphql.NormalizeTypeTag(typeTag)
+	if err != nil {
+		t.Fatalf("normalize %s: %v", typeTag, err)
+	}
+	return normalized
+}
+
+func TestQuoter(t *testing.T) {
+	const (
+		deepBookPool = "0x0000000000000000000000000000000000000000000000000000000000000d01"
+		shallowPool  = "0x0000000000000000000000000000000000000000000000000000000000000c01"
+		deepPool     = "0x0000000000000000000000000000000000000000000000000000000000000c02"
+		clock        = "0x0000000000000000000000000000000000000000000000000000000000000006"
+	)
+	// Each Cetus pool trades SUI against TOKEN; the deeper one prices a SUI
+	// at 4 TOKEN, a square root price of 2 in Q64.64.
+	sqrtPrices := map[string]string{shallowPool: "18446744073709551616", deepPool: "36893488147419103232"}
+	pools := map[string]string{
+		normalize(t, "0xdee::pool::Pool<0x2::sui::SUI, "+USDC+">"): `[{"address":"` + deepBookPool + `","asMoveObject":{"contents":{"json":{"id":"` + deepBookPool + `"}}}}]`,
+		normalize(t, "0xce7::pool::Pool<0x2::sui::SUI, "+token+">"): `[
+			{"address":"` + shallowPool + `","asMoveObject":{"contents":{"json":{"current_sqrt_price":"` + sqrtPrices[shallowPool] + `","liquidity":"10","is_pause":false}}}},
+			{"address":"` + deepPool + `","asMoveObject":{"contents":{"json":{"current_sqrt_price":"` + sqrtPrices[deepPool] + `","liquidity":"5000","is_pause":false}}}}
+		]`,
+	}
+
+	var poolQueries atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query     string `json:"query"`
+			Variables struct {
+				ObjectID string `json:"objectId"`
+				Filter   struct {
+					Type string `json:"type"`
+				} `json:"filter"`
+			} `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(req.Query, "query FindPricePools"):
+			poolQueries.Add(1)
+			nodes, ok := pools[req.Variables.Filter.Type]
+			if !ok {
+				nodes = "[]"
+			}
+			w.Write([]byte(`{"data":{"objects":{"nodes":` + nodes + `}}}`))
+		case strings.Contains(req.Query, "query GetObject"):
+			sqrt := sqrtPrices[req.Variables.ObjectID]
+			w.Write([]byte(`{"data":{"object":{"address":"` + req.Variables.ObjectID + `","version":3,"digest":"11111111111111111111111111111111",
+				"asMoveObject":{"contents":{"type":{"repr":"0xce7::pool::Pool"},"json":{"current_sqrt_price":"` + sqrt + `","liquidity":"5000","is_pause":false}}}}}}`))
+		case strings.Contains(req.Query, "query GetReferenceGasPrice"):
+			w.Write([]byte(`{"data":{"epoch":{"referenceGasPrice":"750"}}}`))
+		case strings.Contains(req.Query, "query GetNormalizedMoveFunction"):
+			w.Write([]byte(`{"data":{"object":{"asMovePackage":{"module":{"function":{"name":"mid_price","parameters":[
+				{"repr":"&0xdee::pool::Pool<$0, $1>","signature":{"ref":"&","body":{"datatype":{"package":"0x0000000000000000000000000000000000000000000000000000000000000dee","module":"pool","type":"Pool","typeParameters":[{"typeParameter":0},{"typeParameter":1}]}}}},
+				{"repr":"&0x2::clock::Clock","signature":{"ref":"&","body":{"datatype":{"package":"0x0000000000000000000000000000000000000000000000000000000000000002","module":"clock","type":"Clock","typeParameters":[]}}}}
+			],"return":[{"repr":"u64"}]}}}}}}`))
+		case strings.Contains(req.Query, "query MultiGetObjects"):
+			w.Write([]byte(`{"data":{"multiGetObjects":[
+				{"address":"` + deepBookPool + `","version":9,"digest":"11111111111111111111111111111111","owner":{"__typename":"Shared","initialSharedVersion":4}},
+				{"address":"` + clock + `","version":9,"digest":"11111111111111111111111111111111","owner":{"__typename":"Shared","initialSharedVersion":1}}
+			]}}`))
+		case strings.Contains(req.Query, "mutation SimulateMoveCall"):
+			// 0.0035 USDC units per MIST, or 3.5 USDC per SUI.
+			w.Write([]byte(`{"data":{"simulateTransaction":{"effects":{"status":"SUCCESS"},"outputs":[{"returnValues":[
+				{"value":{"type":{"repr":"u64"},"json":"3500000"}}
+			]}]}}}`))
+		case strings.Contains(req.Query, "serviceConfig"):
+			w.Write([]byte(`{"data":{"serviceConfig":{"maxOutputNodes":1000}}}`))
+		case strings.Contains(req.Query, "query GetMoveTypeLayout"):
+			w.Write([]byte(`{"data":{"type":{"layout":"u64"}}}`))
+		default:
+			t.Errorf("unexpected query %s", req.Query)
+		}
+	}))
+	defer server.Close()
+
+	client := graphql.NewClient(graphql.WithEndpoint(server.URL), graphql.WithRetries(0))
+	q := New(client, &Options{
+		Venues: []Venue{&DeepBook{Package: "0xdee"}, &Cetus{Package: "0xce7"}},
+		Coins:  staticCoins{normalize(t, SUI): 9, normalize(t, USDC): 6, normalize(t, token): 9},
+	})
+	ctx := context.Background()
+
+	check := func(quote *Quote, err error, want string, route ...string) {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("quote: %v", err)
+		}
+		if got := quote.Price.Text('f', 6); got != want {
+			t.Errorf("price = %s, want %s", got, want)
+		}
+		if len(quote.Route) != len(route) {
+			t.Fatalf("route = %+v, want %v", quote.Route, route)
+		}
+		for i, id := range route {
+			if quote.Route[i].ID.String() != id {
+				t.Errorf("route[%d] = %s, want %s", i, quote.Route[i].ID, id)
+			}
+		}
+	}
+
+	quote, err := q.QuoteUSDC(ctx, SUI)
+	check(quote, err, "3.500000", deepBookPool)
+	quote, err = q.QuoteSUI(ctx, token)
+	check(quote, err, "0.250000", deepPool)
+	quote, err = q.Quote(ctx, SUI, token)
+	check(quote, err, "4.000000", deepPool)
+	quote, err = q.QuoteUSDC(ctx, token)
+	check(quote, err, "0.875000", deepPool, deepBookPool)
+	if quote.Coin != normalize(t, token) || quote.QuoteCoin != normalize(t, USDC) {
+		t.Errorf("quote coins = %s, %s", quote.Coin, quote.QuoteCoin)
+	}
+
+	price, currency, err := q.Price(ctx, token)
+	if err != nil || currency != "USD" || price.Text('f', 3) != "0.875" {
+		t.Errorf("Price = %v %s, %v", price, currency, err)
+	}
+	var _ graphql.PriceFeed = q
+
+	pool, err := q.FindPool(ctx, token, SUI)
+	if err != nil || pool.Venue != "cetus" || pool.Base != normalize(t, SUI) || pool.Liquidity.Cmp(big.NewInt(5000)) != 0 {
+		t.Errorf("FindPool = %+v, %v", pool, err)
+	}
+
+	// SUI/USDC, SUI/TOKEN and TOKEN/USDC, which has no pool, each took one
+	// query per venue and type order; everything after came from the cache.
+	if n := poolQueries.Load(); n != 12 {
+		t.Errorf("pool queries = %d, want 12", n)
+	}
+
+	if _, err := q.QuoteSUI(ctx, "0x8::other::OTHER"); !errors.Is(err, ErrNoPool) {
+		t.Errorf("expected ErrNoPool, got %v", err)
+	}
+}
+
+func TestDeepest(t *testing.T) {
+	pools := []Pool{{Venue: "a"}, {Venue: "b", Liquidity: big.NewInt(2)}, {Venue: "c", Liquidity: big.NewInt(1)}}
+	if best, ok := deepest(pools); !ok || best.Venue != "b" {
+		t.Errorf("deepest = %+v, %v", best, ok)
+	}
+	if best, _ := deepest(pools[:1]); best.Venue != "a" {
+		t.Errorf("deepest without liquidity = %+v", best)
+	}
+	if _, ok := deepest(nil); ok {
+		t.Error("deepest of no pools succeeded")
+	}
+}
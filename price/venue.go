@@ -0,0 +1,211 @@
+package price
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/open-move/sui-go-sdk/graphql"
+	"github.com/open-move/sui-go-sdk/types"
+)
+
+// Mainnet package IDs of the supported venues. Both are the packages that
+// define the pool types, which stay the same across upgrades.
+const (
+	DeepBookMainnetPackage = "0x2c8d603bc51326b8c13cef9dd07031a408a48dddb541963357661df5d3204809"
+	CetusMainnetPackage    = "0x1eabed72c53feb3805120a081dc15963c204dc8d091542592abaf7a35689b2fb"
+)
+
+// clockID is the shared Clock object.
+const clockID = "0x6"
+
+// deepBookScaling is the fixed-point scale of DeepBook prices.
+const deepBookScaling = 1_000_000_000
+
+// poolsPerVenue bounds how many pools of one pair a venue looks at.
+const poolsPerVenue = 50
+
+// Venue is a DEX whose pools can price a pair of coins.
+type Venue interface {
+	// Name identifies the venue in Pool.Venue.
+	Name() string
+	// Pools returns the venue's pools between coin types a and b, which are
+	// normalized, with either coin as the base.
+	Pools(ctx context.Context, client *graphql.Client, a, b string) ([]Pool, error)
+	// MidPrice returns the price of one base unit of pool.Base in base units
+	// of pool.Quote, before adjusting for the coins' decimals.
+	MidPrice(ctx context.Context, client *graphql.Client, pool Pool) (*big.Float, error)
+}
+
+// DeepBook prices coins at the mid price of a DeepBook v3 order book, read
+// by calling pool::mid_price.
+type DeepBook struct {
+	// Package defines the Pool type.
+	Package string
+	// CallPackage is the package version whose mid_price is called. DeepBook
+	// rejects calls through outdated versions, so set it to the current
+	// version when it differs from Package. It defaults to Package.
+	CallPackage string
+}
+
+// DeepBookMainnet returns the DeepBook venue on mainnet.
+func DeepBookMainnet() *DeepBook {
+	return &DeepBook{Package: DeepBookMainnetPackage}
+}
+
+// Name returns "deepbook".
+func (d *DeepBook) Name() string { return "deepbook" }
+
+// Pools returns the DeepBook pools between a and b. DeepBook keeps at most
+// one pool per pair, so their liquidity is not reported.
+func (d *DeepBook) Pools(ctx context.Context, client *graphql.Client, a, b string) ([]Pool, error) {
+	objects, err := findPools(ctx, client, d.Package+"::pool::Pool", a, b)
+	if err != nil {
+		return nil, err
+	}
+	pools := make([]Pool, len(objects))
+	for i, obj := range objects {
+		pools[i] = Pool{Venue: d.Name(), ID: obj.id, Base: obj.base, Quote: obj.quote}
+	}
+	return pools, nil
+}
+
+// MidPrice calls pool::mid_price, which fails when either side of the book
+// is empty.
+func (d *DeepBook) MidPrice(ctx context.Context, client *graphql.Client, pool Pool) (*big.Float, error) {
+	pkg := d.CallPackage
+	if pkg == "" {
+		pkg = d.Package
+	}
+	mid, err := graphql.ReadMoveValue[uint64](client, ctx, pkg+"::pool::mid_price", []string{pool.Base, pool.Quote}, []any{pool.ID.String(), clockID})
+	if err != nil {
+		return nil, fmt.Errorf("deepbook pool %s: %w", pool.ID, err)
+	}
+	if mid == 0 {
+		return nil, fmt.Errorf("deepbook pool %s: no mid price", pool.ID)
+	}
+	price := new(big.Float).SetUint64(mid)
+	return price.Quo(price, big.NewFloat(deepBookScaling)), nil
+}
+
+// Cetus prices coins at the current price of a Cetus CLMM pool, read from
+// the pool's square root price.
+type Cetus struct {
+	// Package defines the Pool type.
+	Package string
+}
+
+// CetusMainnet returns the Cetus venue on mainnet.
+func CetusMainnet() *Cetus {
+	return &Cetus{Package: CetusMainnetPackage}
+}
+
+// Name returns "cetus".
+func (c *Cetus) Name() string { return "cetus" }
+
+// cetusPool holds the fields of a Cetus pool's contents that price it.
+type cetusPool struct {
+	CurrentSqrtPrice json.Number `json:"current_sqrt_price"`
+	Liquidity        json.Number `json:"liquidity"`
+	IsPause          bool        `json:"is_pause"`
+}
+
+// Pools returns the Cetus pools between a and b that are not paused. A pair
+// usually has a pool per fee tier; Liquidity tells them apart.
+func (c *Cetus) Pools(ctx context.Context, client *graphql.Client, a, b string) ([]Pool, error) {
+	objects, err := findPools(ctx, client, c.Package+"::pool::Pool", a, b)
+	if err != nil {
+		return nil, err
+	}
+	var pools []Pool
+	for _, obj := range objects {
+		var contents cetusPool
+		if err := json.Unmarshal(obj.json, &contents); err != nil {
+			return nil, fmt.Errorf("cetus pool %s: %w", obj.id, err)
+		}
+		if contents.IsPause {
+			continue
+		}
+		liquidity, ok := new(big.Int).SetString(contents.Liquidity.String(), 10)
+		if !ok {
+			return nil, fmt.Errorf("cetus pool %s: invalid liquidity %q", obj.id, contents.Liquidity)
+		}
+		pools = append(pools, Pool{Venue: c.Name(), ID: obj.id, Base: obj.base, Quote: obj.quote, Liquidity: liquidity})
+	}
+	return pools, nil
+}
+
+// MidPrice reads the pool and squares its Q64.64 square root price.
+func (c *Cetus) MidPrice(ctx context.Context, client *graphql.Client, pool Pool) (*big.Float, error) {
+	obj, err := client.GetObject(ctx, pool.ID, &graphql.ObjectDataOptions{ShowContent: true})
+	if err != nil {
+		return nil, fmt.Errorf("cetus pool %s: %w", pool.ID, err)
+	}
+	if obj == nil || obj.AsMoveObject == nil || obj.AsMoveObject.Contents == nil {
+		return nil, fmt.Errorf("cetus pool %s: not found", pool.ID)
+	}
+	var p cetusPool
+	if err := json.Unmarshal(obj.AsMoveObject.Contents.Json, &p); err != nil {
+		return nil, fmt.Errorf("cetus pool %s: %w", pool.ID, err)
+	}
+	sqrt, ok := new(big.Float).SetPrec(256).SetString(p.CurrentSqrtPrice.String())
+	if !ok || sqrt.Sign() <= 0 {
+		return nil, fmt.Errorf("cetus pool %s: invalid sqrt price %q", pool.ID, p.CurrentSqrtPrice)
+	}
+	sqrt.SetMantExp(sqrt, -64)
+	return sqrt.Mul(sqrt, sqrt), nil
+}
+
+// poolObject is a pool found by findPools.
+type poolObject struct {
+	id          types.Address
+	base, quote string
+	json        json.RawMessage
+}
+
+// findPools lists the objects of poolType<a, b> and poolType<b, a>.
+func findPools(ctx context.Context, client *graphql.Client, poolType, a, b string) ([]poolObject, error) {
+	if client == nil {
+		return nil, errors.New("nil client")
+	}
+	query := `
+		query FindPricePools($filter: ObjectFilter!, $first: Int) {
+			objects(filter: $filter, first: $first) {
+				nodes {
+					address
+					asMoveObject {
+						contents { json }
+					}
+				}
+			}
+		}
+	`
+
+	var pools []poolObject
+	for _, pair := range [][2]string{{a, b}, {b, a}} {
+		objectType, err := graphql.NormalizeTypeTag(fmt.Sprintf("%s<%s, %s>", poolType, pair[0], pair[1]))
+		if err != nil {
+			return nil, err
+		}
+		var result struct {
+			Objects *graphql.Connection[graphql.Object] `json:"objects"`
+		}
+		vars := map[string]any{"filter": graphql.ObjectFilter{Type: &objectType}, "first": poolsPerVenue}
+		if err := client.Execute(ctx, query, vars, &result); err != nil {
+			return nil, err
+		}
+		if result.Objects == nil {
+			continue
+		}
+		for _, node := range result.Objects.Nodes {
+			pool := poolObject{id: node.Address, base: pair[0], quote: pair[1]}
+			if node.AsMoveObject != nil && node.AsMoveObject.Contents != nil {
+				pool.json = node.AsMoveObject.Contents.Json
+			}
+			pools = append(pools, pool)
+		}
+	}
+	return pools, nil
+}
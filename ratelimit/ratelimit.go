@@ -0,0 +1,162 @@
+// Package ratelimit throttles outgoing RPC requests on the client side.
+//
+// A TokenBucket holds up to burst tokens, refilled at a steady rate, and
+// every request takes one. One bucket can be handed to several clients, such
+// as the graphql and grpc clients of one process, so together they stay
+// within a provider's global quota:
+//
+//	quota := ratelimit.NewTokenBucket(50, 10)
+//	gql := graphql.NewClient(graphql.WithRateLimiter(quota))
+//	rpc, err := grpc.NewClient(ctx, endpoint, grpc.WithRateLimiter(quota))
+//
+// Clients accept any Limiter, so golang.org/x/time/rate limiters work too.
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Limiter admits requests. Wait blocks until the caller may send one
+// request, or returns the context's error when ctx ends first.
+type Limiter interface {
+	Wait(ctx context.Context) error
+}
+
+// Stats are the totals of a TokenBucket.
+type Stats struct {
+	// Acquired counts tokens handed out.
+	Acquired uint64
+	// Throttled counts acquisitions that had to wait, and Canceled those
+	// abandoned because their context ended.
+	Throttled uint64
+	Canceled  uint64
+	// TotalWait and MaxWait are the time spent waiting for tokens,
+	// including abandoned waits.
+	TotalWait time.Duration
+	MaxWait   time.Duration
+}
+
+// TokenBucket is a Limiter that allows rate requests per second on
+// average, with bursts of up to burst requests. It is safe for concurrent
+// use; a nil *TokenBucket never waits.
+type TokenBucket struct {
+	rate  float64
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+	stats  Stats
+}
+
+// NewTokenBucket returns a full bucket refilled at rate tokens per second.
+// A burst below one is raised to one. It returns nil, which never waits,
+// when rate is not positive.
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+	if rate <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &TokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+// Wait implements Limiter.
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	_, err := b.Acquire(ctx)
+	return err
+}
+
+// Acquire blocks until a token is available and takes it, returning how
+// long it waited. When ctx ends first it returns the time waited and the
+// context's error; no token is taken.
+func (b *TokenBucket) Acquire(ctx context.Context) (time.Duration, error) {
+	if b == nil {
+		return 0, ctx.Err()
+	}
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	var start time.Time
+	for {
+		delay := b.take()
+		if delay == 0 {
+			var waited time.Duration
+			if !start.IsZero() {
+				waited = time.Since(start)
+			}
+			b.record(waited, false)
+			return waited, nil
+		}
+		if start.IsZero() {
+			start = time.Now()
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			waited := time.Since(start)
+			b.record(waited, true)
+			return waited, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// TryAcquire takes a token if one is available without waiting.
+func (b *TokenBucket) TryAcquire() bool {
+	if b == nil {
+		return true
+	}
+	if b.take() != 0 {
+		return false
+	}
+	b.record(0, false)
+	return true
+}
+
+// Stats returns the bucket's totals.
+func (b *TokenBucket) Stats() Stats {
+	if b == nil {
+		return Stats{}
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.stats
+}
+
+// take takes a token and returns zero, or returns how long until one is
+// available.
+func (b *TokenBucket) take() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
+
+func (b *TokenBucket) record(waited time.Duration, canceled bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if canceled {
+		b.stats.Canceled++
+	} else {
+		b.stats.Acquired++
+	}
+	if waited > 0 {
+		if !canceled {
+			b.stats.Throttled++
+		}
+		b.stats.TotalWait += waited
+		b.stats.MaxWait = max(b.stats.MaxWait, waited)
+	}
+}
@@ -0,0 +1,55 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTokenBucket(t *testing.T) {
+	b := NewTokenBucket(50, 2)
+	start := time.Now()
+	for range 4 {
+		if err := b.Wait(context.Background()); err != nil {
+			t.Fatalf("wait: %v", err)
+		}
+	}
+	// Two tokens are available at once; the other two take 20ms each.
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Fatalf("bucket did not throttle: %v", elapsed)
+	}
+	stats := b.Stats()
+	if stats.Acquired != 4 || stats.Throttled != 2 || stats.TotalWait < 30*time.Millisecond || stats.MaxWait == 0 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+
+	empty := NewTokenBucket(0.001, 1)
+	if !empty.TryAcquire() || empty.TryAcquire() {
+		t.Fatalf("TryAcquire should take the only token and then fail")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	waited, err := empty.Acquire(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) || waited < 5*time.Millisecond {
+		t.Fatalf("expected a deadline after waiting, got %v after %v", err, waited)
+	}
+	if stats := empty.Stats(); stats.Acquired != 1 || stats.Canceled != 1 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+func TestNilTokenBucket(t *testing.T) {
+	var b *TokenBucket = NewTokenBucket(0, 1)
+	if b != nil {
+		t.Fatalf("a zero rate should disable the bucket")
+	}
+	if err := b.Wait(context.Background()); err != nil || !b.TryAcquire() {
+		t.Fatalf("a nil bucket should never wait: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := b.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the context error, got %v", err)
+	}
+}
@@ -0,0 +1,188 @@
+// Package secrets loads private keys from environment variables and config
+// values that hold them encrypted rather than as plaintext "suiprivkey..."
+// strings.
+//
+// A key is sealed once, offline, with Seal (a passphrase) or SealWithWrapper
+// (a key management service), and the resulting single-line value is stored
+// in the environment or a config file. At startup Open or FromEnv turns it
+// back into a keypair.Keypair. Plaintext keys are rejected unless
+// Options.AllowPlaintext is set, so an unencrypted key cannot slip into
+// production unnoticed.
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/open-move/sui-go-sdk/keychain"
+	"github.com/open-move/sui-go-sdk/keypair"
+)
+
+const (
+	// PassphrasePrefix starts values sealed with Seal: the key encrypted with
+	// AES-256-GCM under an Argon2id-derived key, as by
+	// keypair.ExportEncryptedJSON, in unpadded base64url.
+	PassphrasePrefix = "suienc:"
+	// WrappedPrefix starts values sealed with SealWithWrapper: the key
+	// encrypted by a KeyWrapper, in unpadded base64url.
+	WrappedPrefix = "suikms:"
+	// PlaintextPrefix starts plaintext Bech32 private keys.
+	PlaintextPrefix = "suiprivkey"
+
+	// PassphraseSuffix is appended to a variable's name to find its
+	// passphrase when FromEnv is given none.
+	PassphraseSuffix = "_PASSPHRASE"
+)
+
+var (
+	// ErrPlaintextKey is returned for a plaintext key when
+	// Options.AllowPlaintext is not set.
+	ErrPlaintextKey = errors.New("secrets: plaintext private key; seal it with secrets.Seal or set AllowPlaintext")
+	// ErrPassphraseRequired is returned for a passphrase-sealed value when no
+	// passphrase is available.
+	ErrPassphraseRequired = errors.New("secrets: passphrase required")
+	// ErrWrapperRequired is returned for a KMS-sealed value when
+	// Options.Wrapper is nil.
+	ErrWrapperRequired = errors.New("secrets: key wrapper required")
+)
+
+// KeyWrapper encrypts and decrypts key material with a key held elsewhere,
+// typically a symmetric key in a key management service. awskms.Wrapper
+// implements it.
+type KeyWrapper interface {
+	Wrap(ctx context.Context, plaintext []byte) ([]byte, error)
+	Unwrap(ctx context.Context, ciphertext []byte) ([]byte, error)
+}
+
+// Options configures Open and FromEnv.
+type Options struct {
+	// Passphrase opens values sealed with Seal.
+	Passphrase string
+	// Wrapper opens values sealed with SealWithWrapper.
+	Wrapper KeyWrapper
+	// AllowPlaintext accepts plaintext "suiprivkey..." keys, e.g. during
+	// local development.
+	AllowPlaintext bool
+}
+
+// Seal encrypts k with passphrase and returns a single-line value for an
+// environment variable or config file.
+func Seal(k keypair.Keypair, passphrase string) (string, error) {
+	data, err := keypair.ExportEncryptedJSON(k, passphrase)
+	if err != nil {
+		return "", fmt.Errorf("secrets: %w", err)
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, data); err != nil {
+		return "", fmt.Errorf("secrets: %w", err)
+	}
+	return PassphrasePrefix + base64.RawURLEncoding.EncodeToString(compact.Bytes()), nil
+}
+
+// SealWithWrapper encrypts k with w and returns a single-line value for an
+// environment variable or config file.
+func SealWithWrapper(ctx context.Context, k keypair.Keypair, w KeyWrapper) (string, error) {
+	if k == nil {
+		return "", errors.New("secrets: nil keypair")
+	}
+	if w == nil {
+		return "", ErrWrapperRequired
+	}
+	secret, err := k.ExportSecret()
+	if err != nil {
+		return "", fmt.Errorf("secrets: %w", err)
+	}
+	plaintext := append([]byte{k.Scheme().AddressFlag()}, secret...)
+	zero(secret)
+	defer zero(plaintext)
+
+	ciphertext, err := w.Wrap(ctx, plaintext)
+	if err != nil {
+		return "", fmt.Errorf("secrets: wrap key: %w", err)
+	}
+	return WrappedPrefix + base64.RawURLEncoding.EncodeToString(ciphertext), nil
+}
+
+// Open decrypts a value produced by Seal or SealWithWrapper. Plaintext
+// Bech32 keys are returned as they are when opts.AllowPlaintext is set.
+func Open(ctx context.Context, value string, opts Options) (keypair.Keypair, error) {
+	value = strings.TrimSpace(value)
+	switch {
+	case strings.HasPrefix(value, PassphrasePrefix):
+		if opts.Passphrase == "" {
+			return nil, ErrPassphraseRequired
+		}
+		data, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(value, PassphrasePrefix))
+		if err != nil {
+			return nil, fmt.Errorf("secrets: decode sealed key: %w", err)
+		}
+		kp, err := keypair.FromEncryptedJSON(data, opts.Passphrase)
+		if err != nil {
+			return nil, fmt.Errorf("secrets: %w", err)
+		}
+		return kp, nil
+
+	case strings.HasPrefix(value, WrappedPrefix):
+		if opts.Wrapper == nil {
+			return nil, ErrWrapperRequired
+		}
+		ciphertext, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(value, WrappedPrefix))
+		if err != nil {
+			return nil, fmt.Errorf("secrets: decode wrapped key: %w", err)
+		}
+		plaintext, err := opts.Wrapper.Unwrap(ctx, ciphertext)
+		if err != nil {
+			return nil, fmt.Errorf("secrets: unwrap key: %w", err)
+		}
+		defer zero(plaintext)
+		if len(plaintext) != 1+keychain.PrivateKeySize() {
+			return nil, fmt.Errorf("secrets: unwrapped key has %d bytes, want %d", len(plaintext), 1+keychain.PrivateKeySize())
+		}
+		scheme, err := keychain.SchemeFromFlag(plaintext[0])
+		if err != nil {
+			return nil, fmt.Errorf("secrets: %w", err)
+		}
+		return keypair.FromSecretKey(scheme, plaintext[1:])
+
+	case strings.HasPrefix(value, PlaintextPrefix):
+		if !opts.AllowPlaintext {
+			return nil, ErrPlaintextKey
+		}
+		return keypair.FromBech32(value)
+
+	case value == "":
+		return nil, errors.New("secrets: empty key")
+	default:
+		return nil, errors.New("secrets: unrecognized key format")
+	}
+}
+
+// FromEnv opens the key in the environment variable name. When
+// opts.Passphrase is empty, the passphrase is read from the variable named
+// name+PassphraseSuffix, e.g. SUI_PRIVATE_KEY_PASSPHRASE.
+func FromEnv(ctx context.Context, name string, opts Options) (keypair.Keypair, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok || strings.TrimSpace(value) == "" {
+		return nil, fmt.Errorf("secrets: %s is not set", name)
+	}
+	if opts.Passphrase == "" {
+		opts.Passphrase = os.Getenv(name + PassphraseSuffix)
+	}
+	kp, err := Open(ctx, value, opts)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return kp, nil
+}
+
+func zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
@@ -0,0 +1,120 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/keychain"
+	"github.com/open-move/sui-go-sdk/keypair"
+)
+
+// xorWrapper stands in for a KMS key.
+type xorWrapper struct{}
+
+func (xorWrapper) Wrap(_ context.Context, plaintext []byte) ([]byte, error) {
+	return xor(plaintext), nil
+}
+
+func (xorWrapper) Unwrap(_ context.Context, ciphertext []byte) ([]byte, error) {
+	return xor(ciphertext), nil
+}
+
+func xor(b []byte) []byte {
+	out := make([]byte, len(b))
+	for i := range b {
+		out[i] = b[i] ^ 0x5a
+	}
+	return out
+}
+
+func TestSealAndOpen(t *testing.T) {
+	ctx := context.Background()
+	kp, err := keypair.Generate(keychain.SchemeSecp256k1)
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	want, _ := kp.SuiAddress()
+
+	sealed, err := Seal(kp, "hunter2")
+	if err != nil {
+		t.Fatalf("seal: %v", err)
+	}
+	wrapped, err := SealWithWrapper(ctx, kp, xorWrapper{})
+	if err != nil {
+		t.Fatalf("seal with wrapper: %v", err)
+	}
+	for _, value := range []string{sealed, wrapped} {
+		if strings.ContainsAny(value, " \n\"") {
+			t.Fatalf("sealed value is not a single token: %q", value)
+		}
+	}
+
+	for name, value := range map[string]string{"passphrase": sealed, "wrapper": wrapped} {
+		got, err := Open(ctx, value, Options{Passphrase: "hunter2", Wrapper: xorWrapper{}})
+		if err != nil {
+			t.Fatalf("%s: open: %v", name, err)
+		}
+		if address, _ := got.SuiAddress(); address != want {
+			t.Fatalf("%s: opened %s, want %s", name, address, want)
+		}
+	}
+
+	if _, err := Open(ctx, sealed, Options{Passphrase: "wrong"}); !errors.Is(err, keypair.ErrInvalidPassphrase) {
+		t.Fatalf("expected ErrInvalidPassphrase, got %v", err)
+	}
+	if _, err := Open(ctx, sealed, Options{}); !errors.Is(err, ErrPassphraseRequired) {
+		t.Fatalf("expected ErrPassphraseRequired, got %v", err)
+	}
+	if _, err := Open(ctx, wrapped, Options{}); !errors.Is(err, ErrWrapperRequired) {
+		t.Fatalf("expected ErrWrapperRequired, got %v", err)
+	}
+}
+
+func TestOpenPlaintext(t *testing.T) {
+	kp, err := keypair.Generate(keychain.SchemeEd25519)
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	bech, err := keypair.ExportToBech32(kp)
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+
+	if _, err := Open(context.Background(), bech, Options{}); !errors.Is(err, ErrPlaintextKey) {
+		t.Fatalf("expected ErrPlaintextKey, got %v", err)
+	}
+	if _, err := Open(context.Background(), bech, Options{AllowPlaintext: true}); err != nil {
+		t.Fatalf("open allowed plaintext: %v", err)
+	}
+	if _, err := Open(context.Background(), "not a key", Options{AllowPlaintext: true}); err == nil {
+		t.Fatal("expected an unrecognized format error")
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	kp, err := keypair.Generate(keychain.SchemeEd25519)
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	sealed, err := Seal(kp, "correct horse")
+	if err != nil {
+		t.Fatalf("seal: %v", err)
+	}
+
+	t.Setenv("TEST_SUI_KEY", sealed)
+	t.Setenv("TEST_SUI_KEY"+PassphraseSuffix, "correct horse")
+	got, err := FromEnv(context.Background(), "TEST_SUI_KEY", Options{})
+	if err != nil {
+		t.Fatalf("from env: %v", err)
+	}
+	want, _ := kp.SuiAddress()
+	if address, _ := got.SuiAddress(); address != want {
+		t.Fatalf("got %s, want %s", address, want)
+	}
+
+	if _, err := FromEnv(context.Background(), "TEST_SUI_KEY_UNSET", Options{}); err == nil || !strings.Contains(err.Error(), "not set") {
+		t.Fatalf("expected an unset variable error, got %v", err)
+	}
+}
@@ -0,0 +1,366 @@
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/open-move/sui-go-sdk/graphql"
+	"github.com/open-move/sui-go-sdk/ratelimit"
+	"github.com/open-move/sui-go-sdk/types"
+)
+
+// Defaults used when ExportOptions leaves a field zero.
+const (
+	DefaultExportRetries = 3
+	DefaultRetryBackoff  = time.Second
+)
+
+// Owner kinds of a Record.
+const (
+	OwnerAddress          = "address"
+	OwnerObject           = "object"
+	OwnerShared           = "shared"
+	OwnerImmutable        = "immutable"
+	OwnerConsensusAddress = "consensus_address"
+)
+
+// Record is one exported object. Its fields are flat scalars, apart from
+// the JSON contents, so NDJSON exports load directly into columnar tools
+// such as DuckDB, BigQuery or Parquet writers.
+type Record struct {
+	ObjectID string `json:"object_id"`
+	Version  uint64 `json:"version"`
+	Digest   string `json:"digest"`
+	// Type is the object's Move type, e.g. "0x2::coin::Coin<0x2::sui::SUI>".
+	Type string `json:"type"`
+	// OwnerKind is one of the Owner constants, and Owner the owning address
+	// or object for address and object owners.
+	OwnerKind            string  `json:"owner_kind"`
+	Owner                string  `json:"owner,omitempty"`
+	InitialSharedVersion *uint64 `json:"initial_shared_version,omitempty"`
+	HasPublicTransfer    bool    `json:"has_public_transfer"`
+	StorageRebate        string  `json:"storage_rebate,omitempty"`
+	PreviousTransaction  string  `json:"previous_transaction,omitempty"`
+	// Contents are the object's fields as JSON.
+	Contents json.RawMessage `json:"contents,omitempty"`
+	// BCS holds the BCS-encoded contents when ExportOptions.IncludeBCS is
+	// set; it is base64 in the NDJSON output.
+	BCS []byte `json:"bcs,omitempty"`
+}
+
+// ExportOptions configures Export. Owner, Filter or both must be set.
+type ExportOptions struct {
+	// Owner exports the objects owned by this address.
+	Owner *types.Address
+	// Filter narrows the objects exported, e.g. to one type. Without an
+	// Owner it selects objects across the whole network, which the service
+	// only allows for some filters, such as a type.
+	Filter *graphql.ObjectFilter
+	// PageSize is the page size; it defaults to DefaultPageSize.
+	PageSize int
+	// Cursor, when set, makes the export resumable: it is loaded before the
+	// first page and saved after each page is written, so an interrupted
+	// export continues where it stopped when run again with the same store.
+	Cursor graphql.CursorStore
+	// Retries is how many times a failed page is retried, with exponential
+	// backoff from RetryBackoff. Zero means DefaultExportRetries; a negative
+	// value disables retries.
+	Retries      int
+	RetryBackoff time.Duration
+	// Limiter, when set, is waited on before every request.
+	Limiter ratelimit.Limiter
+	// IncludeBCS adds the BCS-encoded contents to every record.
+	IncludeBCS bool
+	// OnPage, when set, is called after each page is written and its cursor
+	// saved, e.g. to report progress.
+	OnPage func(progress ExportProgress)
+}
+
+// ExportProgress reports the state of an export.
+type ExportProgress struct {
+	// Objects and Pages count what this run wrote.
+	Objects int
+	Pages   int
+	// Cursor is the position after the last page written. It is empty until
+	// a page is written.
+	Cursor string
+	// Done is set once the last page is written.
+	Done bool
+}
+
+// flusher is implemented by buffered writers such as *bufio.Writer.
+type flusher interface {
+	Flush() error
+}
+
+// Export writes the objects selected by opts to w as newline-delimited JSON
+// Records, one per line, following pagination to the end.
+//
+// When w has a Flush method it is flushed before each cursor save, so a
+// saved cursor never runs ahead of the data. A page written just before an
+// interruption may be written again on resume; deduplicate by object_id and
+// version when that matters. Pages are read one after another, so with an
+// Owner the export is consistent with a single checkpoint only if it
+// finishes while the service still serves the cursor's checkpoint.
+func Export(ctx context.Context, client *graphql.Client, w io.Writer, opts ExportOptions) (ExportProgress, error) {
+	var progress ExportProgress
+	if client == nil {
+		return progress, errors.New("snapshot: nil client")
+	}
+	if opts.Owner == nil && opts.Filter == nil {
+		return progress, errors.New("snapshot: export needs an owner or a filter")
+	}
+	if opts.PageSize <= 0 {
+		opts.PageSize = DefaultPageSize
+	}
+	if opts.Retries == 0 {
+		opts.Retries = DefaultExportRetries
+	}
+	if opts.RetryBackoff <= 0 {
+		opts.RetryBackoff = DefaultRetryBackoff
+	}
+
+	var after *string
+	if opts.Cursor != nil {
+		cursor, err := opts.Cursor.Load(ctx)
+		if err != nil {
+			return progress, fmt.Errorf("snapshot: load cursor: %w", err)
+		}
+		after = cursor
+		if cursor != nil {
+			progress.Cursor = *cursor
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	for {
+		page, err := fetchExportPage(ctx, client, opts, after)
+		if err != nil {
+			return progress, err
+		}
+		if page == nil {
+			break
+		}
+		for _, node := range page.Nodes {
+			if err := enc.Encode(node.record(opts.IncludeBCS)); err != nil {
+				return progress, fmt.Errorf("snapshot: write record: %w", err)
+			}
+			progress.Objects++
+		}
+		progress.Pages++
+		if f, ok := w.(flusher); ok {
+			if err := f.Flush(); err != nil {
+				return progress, fmt.Errorf("snapshot: flush: %w", err)
+			}
+		}
+
+		next := page.PageInfo.EndCursor
+		if next != nil {
+			progress.Cursor = *next
+			if opts.Cursor != nil {
+				if err := opts.Cursor.Save(ctx, *next); err != nil {
+					return progress, fmt.Errorf("snapshot: save cursor: %w", err)
+				}
+			}
+		}
+		if !page.PageInfo.HasNextPage || next == nil {
+			break
+		}
+		if opts.OnPage != nil {
+			opts.OnPage(progress)
+		}
+		after = next
+	}
+
+	progress.Done = true
+	if opts.OnPage != nil {
+		opts.OnPage(progress)
+	}
+	return progress, nil
+}
+
+// ReadRecords decodes newline-delimited Records written by Export and calls
+// fn for each, stopping at the first error.
+func ReadRecords(r io.Reader, fn func(Record) error) error {
+	dec := json.NewDecoder(r)
+	for n := 1; ; n++ {
+		var record Record
+		if err := dec.Decode(&record); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("snapshot: record %d: %w", n, err)
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+}
+
+// exportNode decodes both owned MoveObject nodes and Object nodes of the
+// top-level objects query.
+type exportNode struct {
+	Address             types.Address           `json:"address"`
+	Version             graphql.UInt53          `json:"version"`
+	Digest              types.Digest            `json:"digest"`
+	Owner               *graphql.ObjectOwner    `json:"owner"`
+	StorageRebate       *graphql.BigInt         `json:"storageRebate"`
+	PreviousTransaction *graphql.TransactionRef `json:"previousTransaction"`
+	HasPublicTransfer   *bool                   `json:"hasPublicTransfer"`
+	Contents            *graphql.MoveValue      `json:"contents"`
+	AsMoveObject        *struct {
+		HasPublicTransfer bool               `json:"hasPublicTransfer"`
+		Contents          *graphql.MoveValue `json:"contents"`
+	} `json:"asMoveObject"`
+}
+
+func (n exportNode) record(includeBCS bool) Record {
+	record := Record{
+		ObjectID: n.Address.String(),
+		Version:  uint64(n.Version),
+		Digest:   n.Digest.String(),
+	}
+	contents := n.Contents
+	if n.HasPublicTransfer != nil {
+		record.HasPublicTransfer = *n.HasPublicTransfer
+	}
+	if n.AsMoveObject != nil {
+		contents = n.AsMoveObject.Contents
+		record.HasPublicTransfer = n.AsMoveObject.HasPublicTransfer
+	}
+	if contents != nil {
+		record.Type = contents.Type.Repr
+		record.Contents = contents.Json
+		if includeBCS {
+			record.BCS = contents.Bcs
+		}
+	}
+	if n.StorageRebate != nil {
+		record.StorageRebate = string(*n.StorageRebate)
+	}
+	if n.PreviousTransaction != nil {
+		record.PreviousTransaction = n.PreviousTransaction.Digest.String()
+	}
+	if owner := n.Owner; owner != nil {
+		switch owner.Typename {
+		case "AddressOwner":
+			record.OwnerKind = OwnerAddress
+		case "ObjectOwner":
+			record.OwnerKind = OwnerObject
+		case "Shared":
+			record.OwnerKind = OwnerShared
+		case "Immutable":
+			record.OwnerKind = OwnerImmutable
+		case "ConsensusAddressOwner":
+			record.OwnerKind = OwnerConsensusAddress
+		}
+		if owner.Address != nil {
+			record.Owner = owner.Address.Address.String()
+		}
+		if owner.InitialSharedVersion != nil {
+			version := uint64(*owner.InitialSharedVersion)
+			record.InitialSharedVersion = &version
+		}
+	}
+	return record
+}
+
+const exportOwnerSelection = `
+	__typename
+	... on AddressOwner { address { address } }
+	... on ObjectOwner { address { address } }
+	... on Shared { initialSharedVersion }
+	... on ConsensusAddressOwner { address { address } }
+`
+
+// fetchExportPage reads one page, retrying failures with backoff.
+func fetchExportPage(ctx context.Context, client *graphql.Client, opts ExportOptions, after *string) (*graphql.Connection[exportNode], error) {
+	vars := map[string]any{"first": opts.PageSize}
+	if opts.Filter != nil {
+		vars["filter"] = opts.Filter
+	}
+	if after != nil {
+		vars["after"] = *after
+	}
+
+	var query string
+	if opts.Owner != nil {
+		vars["address"] = *opts.Owner
+		query = `
+			query ExportOwnedObjects($address: SuiAddress!, $filter: ObjectFilter, $first: Int, $after: String) {
+				address(address: $address) {
+					objects(filter: $filter, first: $first, after: $after) {
+						pageInfo { hasNextPage endCursor }
+						nodes {
+							address version digest storageRebate
+							owner {` + exportOwnerSelection + `}
+							previousTransaction { digest }
+							hasPublicTransfer
+							contents { type { repr } json` + bcsField(opts.IncludeBCS) + ` }
+						}
+					}
+				}
+			}
+		`
+	} else {
+		query = `
+			query ExportObjects($filter: ObjectFilter, $first: Int, $after: String) {
+				objects(filter: $filter, first: $first, after: $after) {
+					pageInfo { hasNextPage endCursor }
+					nodes {
+						address version digest storageRebate
+						owner {` + exportOwnerSelection + `}
+						previousTransaction { digest }
+						asMoveObject {
+							hasPublicTransfer
+							contents { type { repr } json` + bcsField(opts.IncludeBCS) + ` }
+						}
+					}
+				}
+			}
+		`
+	}
+
+	backoff := opts.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		if err := wait(ctx, opts.Limiter); err != nil {
+			return nil, err
+		}
+		var result struct {
+			Address *struct {
+				Objects *graphql.Connection[exportNode] `json:"objects"`
+			} `json:"address"`
+			Objects *graphql.Connection[exportNode] `json:"objects"`
+		}
+		err := client.Execute(ctx, query, vars, &result)
+		if err == nil {
+			if result.Address != nil {
+				return result.Address.Objects, nil
+			}
+			return result.Objects, nil
+		}
+		if attempt >= opts.Retries || ctx.Err() != nil {
+			return nil, fmt.Errorf("snapshot: export page after %d attempts: %w", attempt+1, err)
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+func bcsField(include bool) string {
+	if include {
+		return " bcs"
+	}
+	return ""
+}
@@ -0,0 +1,140 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/open-move/sui-go-sdk/graphql"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+// newExportServer serves three objects, two per page. The first request for
+// the second page fails when flaky is set.
+func newExportServer(t *testing.T, flaky bool) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var requests atomic.Int32
+	var failed atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		var req struct {
+			Query     string         `json:"query"`
+			Variables map[string]any `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		after, _ := req.Variables["after"].(string)
+		if flaky && after == "c2" && !failed.Swap(true) {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"errors":[{"message":"try again"}]}`))
+			return
+		}
+
+		node := func(id string, owner map[string]any) map[string]any {
+			contents := map[string]any{"type": map[string]any{"repr": "0x2::coin::Coin<0x2::sui::SUI>"}, "json": map[string]any{"balance": "5"}, "bcs": "AQI="}
+			n := map[string]any{"address": id, "version": 3, "digest": "11111111111111111111111111111111", "owner": owner, "storageRebate": "100"}
+			if strings.Contains(req.Query, "asMoveObject") {
+				n["asMoveObject"] = map[string]any{"hasPublicTransfer": true, "contents": contents}
+			} else {
+				n["hasPublicTransfer"] = true
+				n["contents"] = contents
+			}
+			return n
+		}
+		addressOwner := map[string]any{"__typename": "AddressOwner", "address": map[string]any{"address": "0x1"}}
+		page := map[string]any{
+			"pageInfo": map[string]any{"hasNextPage": true, "endCursor": "c2"},
+			"nodes":    []any{node("0xa", addressOwner), node("0xb", map[string]any{"__typename": "Shared", "initialSharedVersion": 7})},
+		}
+		if after == "c2" {
+			page = map[string]any{
+				"pageInfo": map[string]any{"hasNextPage": false, "endCursor": "c3"},
+				"nodes":    []any{node("0xc", addressOwner)},
+			}
+		}
+		data := map[string]any{"objects": page}
+		if strings.Contains(req.Query, "ExportOwnedObjects") {
+			data = map[string]any{"address": data}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestExport(t *testing.T) {
+	server, requests := newExportServer(t, true)
+	client := graphql.NewClient(graphql.WithEndpoint(server.URL), graphql.WithRetries(0))
+	owner := utils.MustParseAddress("0x1")
+	store := graphql.NewMemoryCursorStore("")
+
+	var out bytes.Buffer
+	var pages []ExportProgress
+	progress, err := Export(context.Background(), client, &out, ExportOptions{
+		Owner:        &owner,
+		PageSize:     2,
+		Cursor:       store,
+		RetryBackoff: time.Millisecond,
+		IncludeBCS:   true,
+		OnPage:       func(p ExportProgress) { pages = append(pages, p) },
+	})
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	if progress.Objects != 3 || progress.Pages != 2 || !progress.Done || progress.Cursor != "c3" || requests.Load() != 3 {
+		t.Fatalf("unexpected progress %+v after %d requests", progress, requests.Load())
+	}
+	if len(pages) != 2 || pages[0].Cursor != "c2" || pages[0].Done || !pages[1].Done {
+		t.Fatalf("unexpected page reports %+v", pages)
+	}
+	if cursor, _ := store.Load(context.Background()); cursor == nil || *cursor != "c3" {
+		t.Fatalf("cursor not saved: %v", cursor)
+	}
+
+	var records []Record
+	if err := ReadRecords(&out, func(r Record) error {
+		records = append(records, r)
+		return nil
+	}); err != nil {
+		t.Fatalf("read records: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("expected 3 records, got %d", len(records))
+	}
+	first, shared := records[0], records[1]
+	if first.ObjectID != utils.MustParseAddress("0xa").String() || first.Version != 3 || first.Type != "0x2::coin::Coin<0x2::sui::SUI>" ||
+		first.OwnerKind != OwnerAddress || first.Owner != owner.String() || !first.HasPublicTransfer || first.StorageRebate != "100" ||
+		string(first.Contents) != `{"balance":"5"}` || !bytes.Equal(first.BCS, []byte{1, 2}) {
+		t.Fatalf("unexpected record %+v", first)
+	}
+	if shared.OwnerKind != OwnerShared || shared.InitialSharedVersion == nil || *shared.InitialSharedVersion != 7 || shared.Owner != "" {
+		t.Fatalf("unexpected shared record %+v", shared)
+	}
+}
+
+func TestExportResumes(t *testing.T) {
+	server, requests := newExportServer(t, false)
+	client := graphql.NewClient(graphql.WithEndpoint(server.URL), graphql.WithRetries(0))
+	typ := "0x2::coin::Coin<0x2::sui::SUI>"
+
+	var out bytes.Buffer
+	progress, err := Export(context.Background(), client, &out, ExportOptions{
+		Filter: &graphql.ObjectFilter{Type: &typ},
+		Cursor: graphql.NewMemoryCursorStore("c2"),
+	})
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	if progress.Objects != 1 || requests.Load() != 1 || !strings.Contains(out.String(), `"has_public_transfer":true`) {
+		t.Fatalf("expected only the last page, got %+v:\n%s", progress, out.String())
+	}
+
+	if _, err := Export(context.Background(), client, &out, ExportOptions{}); err == nil {
+		t.Fatal("expected an error without an owner or filter")
+	}
+}
@@ -0,0 +1,225 @@
+// Package snapshot collects the holdings of many addresses at once: coin
+// balances, owned objects and staked SUI, fetched concurrently under a
+// request rate limit and consolidated into one portfolio. Export writes the
+// objects of an address or type as newline-delimited JSON records for
+// analytics.
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/open-move/sui-go-sdk/graphql"
+	"github.com/open-move/sui-go-sdk/ratelimit"
+	"github.com/open-move/sui-go-sdk/types"
+)
+
+// Defaults used when Options leaves a field zero.
+const (
+	DefaultConcurrency = 8
+	DefaultPageSize    = 50
+)
+
+// Options configures Collect. The zero value fetches everything with
+// DefaultConcurrency and no rate limit.
+type Options struct {
+	// Concurrency bounds the requests in flight.
+	Concurrency int
+	// RequestsPerSecond limits the request rate with a token bucket holding
+	// Burst tokens. Zero disables the limit.
+	RequestsPerSecond float64
+	// Burst is the bucket size; it defaults to 1.
+	Burst int
+	// Limiter, when set, is used instead of RequestsPerSecond and Burst,
+	// e.g. to share a provider quota with the client's other requests.
+	Limiter ratelimit.Limiter
+	// PageSize is the page size for owned objects and stakes.
+	PageSize int
+	// ObjectFilter narrows the owned objects collected, e.g. to one type.
+	ObjectFilter *graphql.ObjectFilter
+	// SkipObjects and SkipStakes leave those holdings out.
+	SkipObjects bool
+	SkipStakes  bool
+}
+
+// Account holds the holdings of one address.
+type Account struct {
+	Address  types.Address
+	Balances []graphql.Balance
+	Objects  []graphql.Object
+	Stakes   []graphql.StakedSui
+}
+
+// StakedPrincipal returns the SUI staked by the account, excluding rewards.
+func (a *Account) StakedPrincipal() *big.Int {
+	total := new(big.Int)
+	for _, stake := range a.Stakes {
+		if principal, ok := stake.Principal.ToBigInt(); ok {
+			total.Add(total, principal)
+		}
+	}
+	return total
+}
+
+// Snapshot is the consolidated portfolio of a set of addresses.
+type Snapshot struct {
+	// Accounts are in the order of the addresses passed to Collect.
+	Accounts []Account
+	// Balances totals the coin balances of all accounts by coin type.
+	Balances map[string]*big.Int
+	// StakedPrincipal totals the staked SUI of all accounts.
+	StakedPrincipal *big.Int
+	// ObjectCount is the number of owned objects collected.
+	ObjectCount int
+	// CollectedAt is when the collection started.
+	CollectedAt time.Time
+}
+
+// Account returns the holdings of address, or nil if it was not collected.
+func (s *Snapshot) Account(address types.Address) *Account {
+	for i := range s.Accounts {
+		if s.Accounts[i].Address == address {
+			return &s.Accounts[i]
+		}
+	}
+	return nil
+}
+
+// Collect fetches the balances, owned objects and stakes of addresses
+// concurrently and returns them as one snapshot. Every page is a separate
+// request, so an address with many objects costs several; all of them share
+// the concurrency and rate limits in opts. The first error cancels the
+// remaining requests and is returned.
+func Collect(ctx context.Context, client *graphql.Client, addresses []types.Address, opts *Options) (*Snapshot, error) {
+	if client == nil {
+		return nil, errors.New("snapshot: nil client")
+	}
+	var o Options
+	if opts != nil {
+		o = *opts
+	}
+	if o.Concurrency <= 0 {
+		o.Concurrency = DefaultConcurrency
+	}
+	if o.PageSize <= 0 {
+		o.PageSize = DefaultPageSize
+	}
+
+	snap := &Snapshot{
+		Accounts:    make([]Account, len(addresses)),
+		CollectedAt: time.Now(),
+	}
+	limiter := o.Limiter
+	if limiter == nil {
+		if bucket := ratelimit.NewTokenBucket(o.RequestsPerSecond, o.Burst); bucket != nil {
+			limiter = bucket
+		}
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(o.Concurrency)
+	for i, address := range addresses {
+		account := &snap.Accounts[i]
+		account.Address = address
+
+		g.Go(func() error {
+			if err := wait(ctx, limiter); err != nil {
+				return err
+			}
+			balances, err := client.GetAllBalances(ctx, address)
+			if err != nil {
+				return fmt.Errorf("snapshot: balances of %s: %w", address, err)
+			}
+			account.Balances = balances
+			return nil
+		})
+		if !o.SkipObjects {
+			g.Go(func() error {
+				objects, err := collectPages(ctx, limiter, o.PageSize, func(page *graphql.PaginationArgs) (*graphql.Connection[graphql.Object], error) {
+					return client.GetOwnedObjects(ctx, address, o.ObjectFilter, page)
+				})
+				if err != nil {
+					return fmt.Errorf("snapshot: objects of %s: %w", address, err)
+				}
+				account.Objects = objects
+				return nil
+			})
+		}
+		if !o.SkipStakes {
+			g.Go(func() error {
+				stakes, err := collectPages(ctx, limiter, o.PageSize, func(page *graphql.PaginationArgs) (*graphql.Connection[graphql.StakedSui], error) {
+					return client.GetStakedSui(ctx, address, page)
+				})
+				if err != nil {
+					return fmt.Errorf("snapshot: stakes of %s: %w", address, err)
+				}
+				account.Stakes = stakes
+				return nil
+			})
+		}
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	snap.Balances = make(map[string]*big.Int)
+	snap.StakedPrincipal = new(big.Int)
+	for i := range snap.Accounts {
+		account := &snap.Accounts[i]
+		for _, balance := range account.Balances {
+			if balance.CoinType == nil {
+				continue
+			}
+			amount, ok := balance.TotalBalance.ToBigInt()
+			if !ok {
+				return nil, fmt.Errorf("snapshot: invalid balance %q for %s", balance.TotalBalance, balance.CoinType.Repr)
+			}
+			total, ok := snap.Balances[balance.CoinType.Repr]
+			if !ok {
+				total = new(big.Int)
+				snap.Balances[balance.CoinType.Repr] = total
+			}
+			total.Add(total, amount)
+		}
+		snap.StakedPrincipal.Add(snap.StakedPrincipal, account.StakedPrincipal())
+		snap.ObjectCount += len(account.Objects)
+	}
+	return snap, nil
+}
+
+// collectPages reads every page of a connection, waiting on limiter before
+// each request.
+func collectPages[T any](ctx context.Context, limiter ratelimit.Limiter, pageSize int, fetch func(*graphql.PaginationArgs) (*graphql.Connection[T], error)) ([]T, error) {
+	var nodes []T
+	page := &graphql.PaginationArgs{First: &pageSize}
+	for {
+		if err := wait(ctx, limiter); err != nil {
+			return nil, err
+		}
+		conn, err := fetch(page)
+		if err != nil {
+			return nil, err
+		}
+		if conn == nil {
+			return nodes, nil
+		}
+		nodes = append(nodes, conn.Nodes...)
+		if !conn.PageInfo.HasNextPage || conn.PageInfo.EndCursor == nil {
+			return nodes, nil
+		}
+		page = &graphql.PaginationArgs{First: &pageSize, After: conn.PageInfo.EndCursor}
+	}
+}
+
+// wait waits on limiter, if any.
+func wait(ctx context.Context, limiter ratelimit.Limiter) error {
+	if limiter == nil {
+		return ctx.Err()
+	}
+	return limiter.Wait(ctx)
+}
@@ -0,0 +1,103 @@
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/graphql"
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+func TestCollect(t *testing.T) {
+	first := utils.MustParseAddress("0x1")
+	second := utils.MustParseAddress("0x2")
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		var req struct {
+			Query     string         `json:"query"`
+			Variables map[string]any `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		owner := req.Variables["address"].(string)
+		after, _ := req.Variables["after"].(string)
+
+		var address map[string]any
+		switch {
+		case strings.Contains(req.Query, "GetAllBalances"):
+			address = map[string]any{"balances": map[string]any{"nodes": []any{
+				map[string]any{"coinType": map[string]any{"repr": "0x2::sui::SUI"}, "totalBalance": "100"},
+			}}}
+		case strings.Contains(req.Query, "GetOwnedObjects"):
+			// The first address owns two pages of objects.
+			page := map[string]any{
+				"pageInfo": map[string]any{"hasNextPage": false},
+				"nodes":    []any{map[string]any{"address": "0x" + strings.Repeat("a", 64)}},
+			}
+			if owner == first.String() && after == "" {
+				page["pageInfo"] = map[string]any{"hasNextPage": true, "endCursor": "c1"}
+			}
+			address = map[string]any{"objects": page}
+		case strings.Contains(req.Query, "GetStakedSui"):
+			nodes := []any{}
+			if owner == second.String() {
+				nodes = append(nodes, map[string]any{"principal": "1000"})
+			}
+			address = map[string]any{"stakedSuis": map[string]any{"nodes": nodes}}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"address": address}})
+	}))
+	defer server.Close()
+
+	client := graphql.NewClient(graphql.WithEndpoint(server.URL), graphql.WithRetries(0))
+	snap, err := Collect(context.Background(), client, []types.Address{first, second}, &Options{Concurrency: 2})
+	if err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+
+	if requests.Load() != 7 {
+		t.Fatalf("expected 7 requests, got %d", requests.Load())
+	}
+	if len(snap.Accounts) != 2 || snap.Accounts[0].Address != first || snap.Accounts[1].Address != second {
+		t.Fatalf("accounts out of order: %+v", snap.Accounts)
+	}
+	if got := snap.Balances["0x2::sui::SUI"]; got == nil || got.Int64() != 200 {
+		t.Fatalf("unexpected SUI total %v", got)
+	}
+	if snap.StakedPrincipal.Int64() != 1000 || snap.Account(second).StakedPrincipal().Int64() != 1000 {
+		t.Fatalf("unexpected staked principal %v", snap.StakedPrincipal)
+	}
+	if snap.ObjectCount != 3 || len(snap.Account(first).Objects) != 2 {
+		t.Fatalf("unexpected object count %d", snap.ObjectCount)
+	}
+}
+
+func TestCollectSkipsHoldings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query string `json:"query"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if !strings.Contains(req.Query, "GetAllBalances") {
+			t.Errorf("unexpected query %q", req.Query)
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"address": nil}})
+	}))
+	defer server.Close()
+
+	client := graphql.NewClient(graphql.WithEndpoint(server.URL), graphql.WithRetries(0))
+	snap, err := Collect(context.Background(), client, []types.Address{utils.MustParseAddress("0x1")}, &Options{SkipObjects: true, SkipStakes: true})
+	if err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	if len(snap.Balances) != 0 || snap.ObjectCount != 0 {
+		t.Fatalf("unexpected snapshot %+v", snap)
+	}
+}
@@ -0,0 +1,202 @@
+package suitest
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/graphql"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+// ExpectedEffects describes the parts of a transaction's effects a test
+// cares about. Zero fields are not checked, and only the types, owners and
+// coins listed are compared, so a test names just what its Move code should
+// do. Types and addresses may be written in short form ("0x2::sui::SUI").
+type ExpectedEffects struct {
+	// Failure expects the transaction to abort; by default it must succeed.
+	Failure bool
+	// ErrorContains must appear in the execution error. It implies Failure.
+	ErrorContains string
+	// Created is the number of objects the transaction creates.
+	Created *int
+	// CreatedTypes maps object types to the number of objects of that type
+	// the transaction creates.
+	CreatedTypes map[string]int
+	// BalanceChanges maps owner addresses to coin types to balance deltas.
+	// A zero delta expects no change.
+	BalanceChanges map[string]map[string]int64
+	// Events maps event types to the number emitted.
+	Events map[string]int
+	// MinGas and MaxGas bound the net gas cost (computation plus storage
+	// minus rebate) in MIST. MaxGas zero is unbounded.
+	MinGas int64
+	MaxGas int64
+}
+
+// AssertEffects fails the test with one error per difference between
+// effects and want. Effects must select status, execution error, object
+// changes (with output types), balance changes, events (with types) and the
+// gas summary for the corresponding checks.
+func AssertEffects(t testing.TB, effects *graphql.TransactionEffects, want ExpectedEffects) {
+	t.Helper()
+	diffs := DiffEffects(effects, want)
+	if len(diffs) == 0 {
+		return
+	}
+	t.Errorf("effects mismatch:\n  %s", strings.Join(diffs, "\n  "))
+}
+
+// DiffEffects returns the differences between effects and want, one line
+// each, sorted within each section. It is empty when the effects match.
+func DiffEffects(effects *graphql.TransactionEffects, want ExpectedEffects) []string {
+	if effects == nil {
+		return []string{"no effects"}
+	}
+
+	var diffs []string
+	diffs = append(diffs, diffStatus(effects, want)...)
+	diffs = append(diffs, diffCreated(effects, want)...)
+	diffs = append(diffs, diffBalances(effects, want.BalanceChanges)...)
+	diffs = append(diffs, diffCounts("events", eventTypes(effects), want.Events)...)
+	diffs = append(diffs, diffGas(effects, want)...)
+	return diffs
+}
+
+func diffStatus(effects *graphql.TransactionEffects, want ExpectedEffects) []string {
+	failure := want.Failure || want.ErrorContains != ""
+	message := ""
+	if effects.ExecutionError != nil {
+		message = effects.ExecutionError.Message
+	}
+
+	switch got := effects.Status; {
+	case failure && got != graphql.ExecutionStatusFailure:
+		return []string{fmt.Sprintf("status: got %s, want FAILURE", got)}
+	case !failure && got != graphql.ExecutionStatusSuccess:
+		return []string{fmt.Sprintf("status: got %s (%s), want SUCCESS", got, message)}
+	case want.ErrorContains != "" && !strings.Contains(message, want.ErrorContains):
+		return []string{fmt.Sprintf("execution error: got %q, want it to contain %q", message, want.ErrorContains)}
+	}
+	return nil
+}
+
+func diffCreated(effects *graphql.TransactionEffects, want ExpectedEffects) []string {
+	created := make(map[string]int)
+	total := 0
+	if effects.ObjectChanges != nil {
+		for _, change := range effects.ObjectChanges.Nodes {
+			if change.IDCreated == nil || !*change.IDCreated {
+				continue
+			}
+			total++
+			if out := change.OutputState; out != nil && out.AsMoveObject != nil && out.AsMoveObject.Type != nil {
+				created[normalizeType(out.AsMoveObject.Type.Repr)]++
+			}
+		}
+	}
+
+	var diffs []string
+	if want.Created != nil && total != *want.Created {
+		diffs = append(diffs, fmt.Sprintf("created objects: got %d, want %d", total, *want.Created))
+	}
+	return append(diffs, diffCounts("created", created, want.CreatedTypes)...)
+}
+
+func diffBalances(effects *graphql.TransactionEffects, want map[string]map[string]int64) []string {
+	if len(want) == 0 {
+		return nil
+	}
+	got := make(map[string]*big.Int)
+	if effects.BalanceChanges != nil {
+		for _, change := range effects.BalanceChanges.Nodes {
+			if change.Owner == nil || change.CoinType == nil {
+				continue
+			}
+			amount, ok := change.Amount.ToBigInt()
+			if !ok {
+				continue
+			}
+			key := change.Owner.Address.String() + " " + normalizeType(change.CoinType.Repr)
+			if prev := got[key]; prev != nil {
+				amount.Add(amount, prev)
+			}
+			got[key] = amount
+		}
+	}
+
+	var diffs []string
+	for owner, coins := range want {
+		for coinType, delta := range coins {
+			key := normalizeAddress(owner) + " " + normalizeType(coinType)
+			actual := got[key]
+			if actual == nil {
+				actual = new(big.Int)
+			}
+			if actual.Cmp(big.NewInt(delta)) != 0 {
+				diffs = append(diffs, fmt.Sprintf("balance change %s %s: got %s, want %d", owner, coinType, actual, delta))
+			}
+		}
+	}
+	sort.Strings(diffs)
+	return diffs
+}
+
+func diffGas(effects *graphql.TransactionEffects, want ExpectedEffects) []string {
+	if want.MinGas == 0 && want.MaxGas == 0 {
+		return nil
+	}
+	if effects.GasEffects == nil || effects.GasEffects.GasSummary == nil {
+		return []string{"gas: no gas summary selected"}
+	}
+	summary := effects.GasEffects.GasSummary
+	net := int64(summary.ComputationCost) + int64(summary.StorageCost) - int64(summary.StorageRebate)
+	switch {
+	case want.MaxGas == 0 && net < want.MinGas:
+		return []string{fmt.Sprintf("gas: got %d, want at least %d", net, want.MinGas)}
+	case want.MaxGas != 0 && (net < want.MinGas || net > want.MaxGas):
+		return []string{fmt.Sprintf("gas: got %d, want between %d and %d", net, want.MinGas, want.MaxGas)}
+	}
+	return nil
+}
+
+func eventTypes(effects *graphql.TransactionEffects) map[string]int {
+	counts := make(map[string]int)
+	if effects.Events == nil {
+		return counts
+	}
+	for _, event := range effects.Events.Nodes {
+		if event.Contents != nil {
+			counts[normalizeType(event.Contents.Type.Repr)]++
+		}
+	}
+	return counts
+}
+
+// diffCounts compares the wanted counts per type against got.
+func diffCounts(label string, got map[string]int, want map[string]int) []string {
+	var diffs []string
+	for typ, n := range want {
+		if actual := got[normalizeType(typ)]; actual != n {
+			diffs = append(diffs, fmt.Sprintf("%s %s: got %d, want %d", label, typ, actual, n))
+		}
+	}
+	sort.Strings(diffs)
+	return diffs
+}
+
+func normalizeType(typ string) string {
+	if normalized, err := graphql.NormalizeTypeTag(typ); err == nil {
+		return normalized
+	}
+	return typ
+}
+
+func normalizeAddress(address string) string {
+	if parsed, err := utils.ParseAddress(address); err == nil {
+		return parsed.String()
+	}
+	return address
+}
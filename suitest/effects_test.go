@@ -0,0 +1,76 @@
+package suitest
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/graphql"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+const mintEffects = `{
+	"status": "SUCCESS",
+	"objectChanges": {"nodes": [
+		{"address": "0xa1", "idCreated": true, "outputState": {"address": "0xa1", "version": 5, "digest": "11111111111111111111111111111111",
+			"asMoveObject": {"type": {"repr": "0x0000000000000000000000000000000000000000000000000000000000000002::coin::Coin<0x0000000000000000000000000000000000000000000000000000000000000002::sui::SUI>"}}}},
+		{"address": "0xa2", "idCreated": true, "outputState": {"address": "0xa2", "version": 5, "digest": "11111111111111111111111111111111",
+			"asMoveObject": {"type": {"repr": "0x000000000000000000000000000000000000000000000000000000000000000c::nft::Nft"}}}},
+		{"address": "0xa3", "idCreated": false}
+	]},
+	"balanceChanges": {"nodes": [
+		{"owner": {"address": "0xb"}, "coinType": {"repr": "0x0000000000000000000000000000000000000000000000000000000000000002::sui::SUI"}, "amount": "-1500"},
+		{"owner": {"address": "0xc"}, "coinType": {"repr": "0x0000000000000000000000000000000000000000000000000000000000000002::sui::SUI"}, "amount": "1000"}
+	]},
+	"events": {"nodes": [
+		{"contents": {"type": {"repr": "0x000000000000000000000000000000000000000000000000000000000000000c::nft::Minted"}}}
+	]},
+	"gasEffects": {"gasSummary": {"computationCost": 1000, "storageCost": 2000, "storageRebate": 2500, "nonRefundableStorageFee": 0}}
+}`
+
+func decodeEffects(t *testing.T, data string) *graphql.TransactionEffects {
+	t.Helper()
+	var effects graphql.TransactionEffects
+	if err := json.Unmarshal([]byte(data), &effects); err != nil {
+		t.Fatalf("decode effects: %v", err)
+	}
+	return &effects
+}
+
+func TestAssertEffects(t *testing.T) {
+	effects := decodeEffects(t, mintEffects)
+	AssertEffects(t, effects, ExpectedEffects{
+		Created:      utils.Ptr(2),
+		CreatedTypes: map[string]int{"0x2::coin::Coin<0x2::sui::SUI>": 1, "0xc::nft::Nft": 1},
+		BalanceChanges: map[string]map[string]int64{
+			"0xb": {"0x2::sui::SUI": -1500},
+			"0xc": {"0x2::sui::SUI": 1000},
+			"0xd": {"0x2::sui::SUI": 0},
+		},
+		Events: map[string]int{"0xc::nft::Minted": 1},
+		MaxGas: 1000,
+	})
+}
+
+func TestDiffEffects(t *testing.T) {
+	effects := decodeEffects(t, mintEffects)
+	diffs := DiffEffects(effects, ExpectedEffects{
+		ErrorContains:  "EInsufficient",
+		Created:        utils.Ptr(1),
+		CreatedTypes:   map[string]int{"0xc::nft::Nft": 2},
+		BalanceChanges: map[string]map[string]int64{"0xb": {"0x2::sui::SUI": -1000}},
+		Events:         map[string]int{"0xc::nft::Burned": 1},
+		MinGas:         1000,
+	})
+	want := []string{
+		"status: got SUCCESS, want FAILURE",
+		"created objects: got 2, want 1",
+		"created 0xc::nft::Nft: got 1, want 2",
+		"balance change 0xb 0x2::sui::SUI: got -1500, want -1000",
+		"events 0xc::nft::Burned: got 0, want 1",
+		"gas: got 500, want at least 1000",
+	}
+	if strings.Join(diffs, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected diffs:\n%s", strings.Join(diffs, "\n"))
+	}
+}
@@ -0,0 +1,775 @@
+package suitest
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/open-move/sui-go-sdk/graphql"
+	"github.com/open-move/sui-go-sdk/transaction"
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+// Defaults for a new LocalChain.
+const (
+	DefaultLocalGasPrice = 1000
+	DefaultLocalGasCost  = 1_000_000
+)
+
+var (
+	// ErrUnsupportedCommand is returned for transactions LocalChain cannot
+	// execute, such as Move calls and publishes.
+	ErrUnsupportedCommand = errors.New("suitest: command not supported by LocalChain")
+	// ErrUnknownObject is returned for objects that are not fixtures.
+	ErrUnknownObject = errors.New("suitest: unknown object")
+)
+
+var (
+	_ transaction.Resolver      = (*LocalChain)(nil)
+	_ transaction.GasResolver   = (*LocalChain)(nil)
+	_ transaction.EpochResolver = (*LocalChain)(nil)
+)
+
+// LocalObject is an object held by a LocalChain.
+type LocalObject struct {
+	ID      types.Address
+	Version uint64
+	Digest  types.Digest
+	// Type is the object's Move type in long form.
+	Type  string
+	Owner types.Address
+	// Balance is the value of a coin.
+	Balance uint64
+}
+
+// Ref returns the object's reference.
+func (o LocalObject) Ref() types.ObjectRef {
+	return types.ObjectRef{ObjectID: o.ID, Version: o.Version, Digest: o.Digest}
+}
+
+// CoinType returns the coin type of a 0x2::coin::Coin object.
+func (o LocalObject) CoinType() (string, bool) {
+	if !strings.HasPrefix(o.Type, coinTypePrefix) || !strings.HasSuffix(o.Type, ">") {
+		return "", false
+	}
+	return o.Type[len(coinTypePrefix) : len(o.Type)-1], true
+}
+
+var (
+	coinTypePrefix = normalizeType("0x2::coin::Coin") + "<"
+	suiCoinType    = normalizeType("0x2::sui::SUI")
+)
+
+// LocalChain is an in-memory ledger of object fixtures for hermetic tests of
+// transaction-building code. It resolves its objects and gas for Build, and
+// executes programmable transactions made of SplitCoins, MergeCoins and
+// TransferObjects commands locally, returning effects synthesized the way
+// the network reports them: object changes, balance changes and a fixed gas
+// charge. Results are deterministic: created object IDs derive from the
+// transaction digest.
+//
+// Register it on a Server with Serve to answer the SimulateTransaction and
+// ExecuteTransaction operations, so graphql.SimulateBuiltTransaction runs
+// against it without a network.
+type LocalChain struct {
+	// GasPrice is the reference gas price.
+	GasPrice uint64
+	// GasCost is the computation cost in MIST charged to every transaction.
+	GasCost uint64
+	// Epoch is the current epoch.
+	Epoch uint64
+
+	mu      sync.Mutex
+	objects map[types.Address]*LocalObject
+	lamport uint64
+	nextID  uint64
+}
+
+// NewLocalChain returns an empty chain with the default gas price and cost.
+func NewLocalChain() *LocalChain {
+	return &LocalChain{
+		GasPrice: DefaultLocalGasPrice,
+		GasCost:  DefaultLocalGasCost,
+		objects:  make(map[types.Address]*LocalObject),
+		lamport:  1,
+	}
+}
+
+// AddCoin adds a coin of coinType ("0x2::sui::SUI") holding balance and
+// owned by owner, and returns its reference.
+func (c *LocalChain) AddCoin(owner, coinType string, balance uint64) types.ObjectRef {
+	return c.add(owner, coinTypePrefix+normalizeType(coinType)+">", balance)
+}
+
+// AddObject adds an object of objectType owned by owner and returns its
+// reference.
+func (c *LocalChain) AddObject(owner, objectType string) types.ObjectRef {
+	return c.add(owner, normalizeType(objectType), 0)
+}
+
+func (c *LocalChain) add(owner, objectType string, balance uint64) types.ObjectRef {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.nextID++
+	var seed [8]byte
+	binary.BigEndian.PutUint64(seed[:], c.nextID)
+	obj := &LocalObject{
+		ID:      types.Address(sha256.Sum256(append([]byte("fixture"), seed[:]...))),
+		Version: c.lamport,
+		Type:    objectType,
+		Owner:   utils.MustParseAddress(owner),
+		Balance: balance,
+	}
+	obj.Digest = objectDigest(obj.ID, obj.Version)
+	c.objects[obj.ID] = obj
+	return obj.Ref()
+}
+
+// Object returns the current state of an object.
+func (c *LocalChain) Object(id types.Address) (LocalObject, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	obj, ok := c.objects[id]
+	if !ok {
+		return LocalObject{}, false
+	}
+	return *obj, true
+}
+
+// Balance returns the total balance of owner's coins of coinType.
+func (c *LocalChain) Balance(owner, coinType string) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	address := utils.MustParseAddress(owner)
+	want := normalizeType(coinType)
+	var total uint64
+	for _, obj := range c.objects {
+		if typ, ok := obj.CoinType(); ok && typ == want && obj.Owner == address {
+			total += obj.Balance
+		}
+	}
+	return total
+}
+
+// ResolveObjects implements transaction.Resolver.
+func (c *LocalChain) ResolveObjects(_ context.Context, objectIDs []string) ([]transaction.ObjectMetadata, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make([]transaction.ObjectMetadata, len(objectIDs))
+	for i, id := range objectIDs {
+		address, err := utils.ParseAddress(id)
+		if err != nil {
+			return nil, err
+		}
+		obj, ok := c.objects[address]
+		if !ok {
+			return nil, fmt.Errorf("%w %s", ErrUnknownObject, id)
+		}
+		out[i] = transaction.ObjectMetadata{ID: obj.ID, Version: obj.Version, Digest: obj.Digest, OwnerKind: transaction.OwnerAddress}
+	}
+	return out, nil
+}
+
+// ResolveMoveFunction implements transaction.Resolver. LocalChain has no
+// packages, so it always fails.
+func (c *LocalChain) ResolveMoveFunction(_ context.Context, packageID, module, function string) (*transaction.MoveFunction, error) {
+	return nil, fmt.Errorf("%w: %s::%s::%s", ErrUnsupportedCommand, packageID, module, function)
+}
+
+// ResolveGasPrice implements transaction.GasResolver.
+func (c *LocalChain) ResolveGasPrice(context.Context) (uint64, error) {
+	return c.GasPrice, nil
+}
+
+// ResolveGasBudget implements transaction.GasResolver with the fixed gas
+// cost.
+func (c *LocalChain) ResolveGasBudget(context.Context, transaction.GasBudgetInput) (uint64, error) {
+	return c.GasCost, nil
+}
+
+// ResolveGasPayment implements transaction.GasResolver, paying with owner's
+// SUI coins in order of object ID.
+func (c *LocalChain) ResolveGasPayment(_ context.Context, owner types.Address, budget uint64) ([]types.ObjectRef, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var coins []*LocalObject
+	for _, obj := range c.objects {
+		if typ, ok := obj.CoinType(); ok && typ == suiCoinType && obj.Owner == owner {
+			coins = append(coins, obj)
+		}
+	}
+	sort.Slice(coins, func(i, j int) bool { return bytes.Compare(coins[i].ID[:], coins[j].ID[:]) < 0 })
+
+	var refs []types.ObjectRef
+	var total uint64
+	for _, coin := range coins {
+		if total >= budget {
+			break
+		}
+		refs = append(refs, coin.Ref())
+		total += coin.Balance
+	}
+	if total < budget {
+		return nil, fmt.Errorf("suitest: %s has %d MIST, below the gas budget %d", owner, total, budget)
+	}
+	return refs, nil
+}
+
+// ResolveCurrentEpoch implements transaction.EpochResolver.
+func (c *LocalChain) ResolveCurrentEpoch(context.Context) (uint64, error) {
+	return c.Epoch, nil
+}
+
+// Simulate executes the transaction without changing the chain. With checks
+// disabled, inputs need not belong to the sender or be at their current
+// version, as with dev-inspect. A transaction the network would reject
+// before execution returns a result with Error set.
+func (c *LocalChain) Simulate(txBytes []byte, checks bool) (*graphql.SimulationResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out, err := c.run(txBytes, checks)
+	if err != nil {
+		return nil, err
+	}
+	if out.rejection != "" {
+		return &graphql.SimulationResult{Error: &out.rejection}, nil
+	}
+	return &graphql.SimulationResult{Effects: out.effects}, nil
+}
+
+// Execute executes the transaction and applies its effects. A failed
+// transaction still pays for gas. Signatures are not checked.
+func (c *LocalChain) Execute(txBytes []byte) (*graphql.TransactionEffects, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out, err := c.run(txBytes, true)
+	if err != nil {
+		return nil, err
+	}
+	if out.rejection != "" {
+		return nil, fmt.Errorf("suitest: transaction rejected: %s", out.rejection)
+	}
+	for id, obj := range out.writes {
+		if obj == nil {
+			delete(c.objects, id)
+		} else {
+			c.objects[id] = obj
+		}
+	}
+	c.lamport = out.lamport
+	return out.effects, nil
+}
+
+// Serve answers the SimulateTransaction and ExecuteTransaction operations
+// on s from the chain.
+func (c *LocalChain) Serve(s *Server) {
+	s.HandleFunc("SimulateTransaction", func(req Request) Response {
+		txBytes, err := bytesVariable(req, "txBytes")
+		if err != nil {
+			return errorResponse(err)
+		}
+		skip, _ := req.Variables["skipChecks"].(bool)
+		result, err := c.Simulate(txBytes, !skip)
+		if err != nil {
+			return errorResponse(err)
+		}
+		return dataResponse(s, map[string]any{"simulateTransaction": result})
+	})
+	s.HandleFunc("ExecuteTransaction", func(req Request) Response {
+		txBytes, err := bytesVariable(req, "tx")
+		if err != nil {
+			return errorResponse(err)
+		}
+		result := &graphql.ExecuteTransactionResult{}
+		if effects, err := c.Execute(txBytes); err != nil {
+			result.Errors = []string{err.Error()}
+		} else {
+			result.Effects = effects
+		}
+		return dataResponse(s, map[string]any{"executeTransaction": result})
+	})
+}
+
+func bytesVariable(req Request, name string) ([]byte, error) {
+	encoded, _ := req.Variables[name].(string)
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("suitest: decode %s: %w", name, err)
+	}
+	return decoded, nil
+}
+
+func errorResponse(err error) Response {
+	return Response{Errors: []graphql.GraphQLError{{Message: err.Error()}}}
+}
+
+func dataResponse(s *Server, data any) Response {
+	raw, err := toRawJSON(data)
+	if err != nil {
+		s.t.Errorf("suitest: marshal response: %v", err)
+		return errorResponse(err)
+	}
+	return Response{Data: raw}
+}
+
+// =============================================================================
+// Execution
+// =============================================================================
+
+// outcome is the result of running a transaction against the chain.
+type outcome struct {
+	effects   *graphql.TransactionEffects
+	rejection string
+	// writes holds the new state of every changed object; nil deletes it.
+	writes  map[types.Address]*LocalObject
+	lamport uint64
+}
+
+// execution is the working state of a transaction.
+type execution struct {
+	chain   *LocalChain
+	digest  types.Digest
+	objects map[types.Address]*LocalObject
+	// placed records, for each object the transaction created, whether it
+	// was transferred; an unplaced coin cannot be dropped.
+	placed  map[types.Address]bool
+	created []types.Address
+	inputs  []value
+	results [][]value
+	gasCoin types.Address
+}
+
+// value is an argument: an object, by ID, or pure bytes.
+type value struct {
+	object *types.Address
+	pure   []byte
+}
+
+// executionError is a failure the network reports in effects.
+type executionError struct {
+	message string
+}
+
+func (e *executionError) Error() string { return e.message }
+
+func failf(format string, args ...any) error {
+	return &executionError{message: fmt.Sprintf(format, args...)}
+}
+
+func (c *LocalChain) run(txBytes []byte, checks bool) (*outcome, error) {
+	data, err := transaction.DecodeTransactionData(txBytes)
+	if err != nil {
+		return nil, err
+	}
+	v1 := data.V1
+	ptb := v1.Kind.ProgrammableTransaction
+	if ptb == nil {
+		return nil, fmt.Errorf("%w: %s transaction", ErrUnsupportedCommand, v1.Kind.Name())
+	}
+
+	x := &execution{
+		chain:   c,
+		digest:  transaction.TransactionDigest(txBytes),
+		objects: make(map[types.Address]*LocalObject),
+		placed:  make(map[types.Address]bool),
+	}
+	reject := func(format string, args ...any) (*outcome, error) {
+		return &outcome{rejection: fmt.Sprintf(format, args...)}, nil
+	}
+
+	// Load the gas coins and smash them into the first.
+	if len(v1.GasData.Payment) == 0 {
+		return reject("no gas payment")
+	}
+	for i, ref := range v1.GasData.Payment {
+		obj, err := x.load(ref, v1.GasData.Owner, checks)
+		if err != nil {
+			return reject("gas payment %d: %v", i, err)
+		}
+		if typ, ok := obj.CoinType(); !ok || typ != suiCoinType {
+			return reject("gas payment %s is not a SUI coin", obj.ID)
+		}
+		if i == 0 {
+			x.gasCoin = obj.ID
+			continue
+		}
+		x.objects[x.gasCoin].Balance += obj.Balance
+		x.objects[obj.ID] = nil
+	}
+	if v1.GasData.Budget < c.GasCost {
+		return reject("gas budget %d is below the gas cost %d", v1.GasData.Budget, c.GasCost)
+	}
+	if balance := x.objects[x.gasCoin].Balance; balance < v1.GasData.Budget {
+		return reject("gas balance %d is below the gas budget %d", balance, v1.GasData.Budget)
+	}
+
+	x.inputs = make([]value, len(ptb.Inputs))
+	for i, input := range ptb.Inputs {
+		switch {
+		case input.Pure != nil:
+			x.inputs[i] = value{pure: input.Pure.Bytes}
+		case input.Object != nil && input.Object.ImmOrOwnedObject != nil:
+			obj, err := x.load(*input.Object.ImmOrOwnedObject, v1.Sender, checks)
+			if err != nil {
+				return reject("input %d: %v", i, err)
+			}
+			x.inputs[i] = value{object: &obj.ID}
+		default:
+			return nil, fmt.Errorf("%w: input %d is not a pure value or owned object", ErrUnsupportedCommand, i)
+		}
+	}
+
+	// Commands run against a copy so a failure leaves only the gas charge.
+	start := x.snapshot()
+	var failure error
+	for i, cmd := range ptb.Commands {
+		results, err := x.command(cmd)
+		if err != nil {
+			var failed *executionError
+			if !errors.As(err, &failed) {
+				return nil, fmt.Errorf("command %d: %w", i, err)
+			}
+			failure = failf("command %d: %s", i, failed.message)
+			break
+		}
+		x.results = append(x.results, results)
+	}
+	if failure == nil {
+		for _, id := range x.created {
+			if x.objects[id] != nil && !x.placed[id] {
+				failure = failf("UnusedValueWithoutDrop: created object %s was not transferred", id)
+				break
+			}
+		}
+	}
+	if failure != nil {
+		x.objects, x.created = start, nil
+	}
+
+	gas := x.objects[x.gasCoin]
+	if gas == nil {
+		return nil, fmt.Errorf("%w: the gas coin was merged away", ErrUnsupportedCommand)
+	}
+	gas.Balance -= c.GasCost
+
+	lamport := c.lamport + 1
+	for _, obj := range x.objects {
+		if obj != nil {
+			obj.Version = lamport
+			obj.Digest = objectDigest(obj.ID, lamport)
+		}
+	}
+	return &outcome{
+		effects: x.effects(failure, lamport),
+		writes:  x.objects,
+		lamport: lamport,
+	}, nil
+}
+
+// load copies an input object into the execution, checking that owner owns
+// it at ref's version when checks are enabled.
+func (x *execution) load(ref types.ObjectRef, owner types.Address, checks bool) (*LocalObject, error) {
+	if _, ok := x.objects[ref.ObjectID]; ok {
+		return nil, fmt.Errorf("object %s is used twice", ref.ObjectID)
+	}
+	obj, ok := x.chain.objects[ref.ObjectID]
+	if !ok {
+		return nil, fmt.Errorf("%w %s", ErrUnknownObject, ref.ObjectID)
+	}
+	if checks {
+		if obj.Version != ref.Version {
+			return nil, fmt.Errorf("object %s is at version %d, not %d", obj.ID, obj.Version, ref.Version)
+		}
+		if obj.Owner != owner {
+			return nil, fmt.Errorf("object %s is owned by %s, not %s", obj.ID, obj.Owner, owner)
+		}
+	}
+	copied := *obj
+	x.objects[obj.ID] = &copied
+	return &copied, nil
+}
+
+func (x *execution) snapshot() map[types.Address]*LocalObject {
+	out := make(map[types.Address]*LocalObject, len(x.objects))
+	for id, obj := range x.objects {
+		if obj != nil {
+			copied := *obj
+			obj = &copied
+		}
+		out[id] = obj
+	}
+	return out
+}
+
+func (x *execution) command(cmd transaction.Command) ([]value, error) {
+	switch {
+	case cmd.SplitCoins != nil:
+		coin, err := x.coin(cmd.SplitCoins.Coin)
+		if err != nil {
+			return nil, err
+		}
+		results := make([]value, len(cmd.SplitCoins.Amounts))
+		for i, arg := range cmd.SplitCoins.Amounts {
+			amount, err := x.u64(arg)
+			if err != nil {
+				return nil, err
+			}
+			if amount > coin.Balance {
+				return nil, failf("InsufficientCoinBalance: cannot split %d from %d", amount, coin.Balance)
+			}
+			coin.Balance -= amount
+			created := x.create(coin.Type, amount)
+			results[i] = value{object: &created.ID}
+		}
+		return results, nil
+
+	case cmd.MergeCoins != nil:
+		target, err := x.coin(cmd.MergeCoins.Destination)
+		if err != nil {
+			return nil, err
+		}
+		for _, arg := range cmd.MergeCoins.Sources {
+			source, err := x.coin(arg)
+			if err != nil {
+				return nil, err
+			}
+			if source.ID == target.ID {
+				return nil, failf("cannot merge coin %s into itself", source.ID)
+			}
+			if source.Type != target.Type {
+				return nil, failf("cannot merge %s into %s", source.Type, target.Type)
+			}
+			target.Balance += source.Balance
+			x.objects[source.ID] = nil
+		}
+		return nil, nil
+
+	case cmd.TransferObjects != nil:
+		recipient, err := x.address(cmd.TransferObjects.Address)
+		if err != nil {
+			return nil, err
+		}
+		for _, arg := range cmd.TransferObjects.Objects {
+			obj, err := x.object(arg)
+			if err != nil {
+				return nil, err
+			}
+			obj.Owner = recipient
+			x.placed[obj.ID] = true
+		}
+		return nil, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedCommand, commandName(cmd))
+}
+
+// create adds a new object, with an ID derived from the transaction digest.
+func (x *execution) create(objectType string, balance uint64) *LocalObject {
+	var seed [8]byte
+	binary.BigEndian.PutUint64(seed[:], uint64(len(x.created)))
+	obj := &LocalObject{
+		ID:      types.Address(sha256.Sum256(append(append([]byte(nil), x.digest...), seed[:]...))),
+		Type:    objectType,
+		Balance: balance,
+	}
+	x.objects[obj.ID] = obj
+	x.created = append(x.created, obj.ID)
+	return obj
+}
+
+func (x *execution) value(arg transaction.Argument) (value, error) {
+	switch {
+	case arg.GasCoin != nil:
+		return value{object: &x.gasCoin}, nil
+	case arg.Input != nil:
+		if int(*arg.Input) >= len(x.inputs) {
+			return value{}, failf("input %d out of range", *arg.Input)
+		}
+		return x.inputs[*arg.Input], nil
+	case arg.Result != nil:
+		if int(*arg.Result) >= len(x.results) || len(x.results[*arg.Result]) != 1 {
+			return value{}, failf("result %d is not a single value", *arg.Result)
+		}
+		return x.results[*arg.Result][0], nil
+	case arg.NestedResult != nil:
+		nested := arg.NestedResult
+		if int(nested.Index) >= len(x.results) || int(nested.ResultIndex) >= len(x.results[nested.Index]) {
+			return value{}, failf("nested result %d.%d out of range", nested.Index, nested.ResultIndex)
+		}
+		return x.results[nested.Index][nested.ResultIndex], nil
+	}
+	return value{}, failf("empty argument")
+}
+
+func (x *execution) object(arg transaction.Argument) (*LocalObject, error) {
+	v, err := x.value(arg)
+	if err != nil {
+		return nil, err
+	}
+	if v.object == nil {
+		return nil, failf("expected an object, got a pure value")
+	}
+	obj := x.objects[*v.object]
+	if obj == nil {
+		return nil, failf("object %s was already consumed", *v.object)
+	}
+	return obj, nil
+}
+
+func (x *execution) coin(arg transaction.Argument) (*LocalObject, error) {
+	obj, err := x.object(arg)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := obj.CoinType(); !ok {
+		return nil, failf("object %s of type %s is not a coin", obj.ID, obj.Type)
+	}
+	return obj, nil
+}
+
+func (x *execution) u64(arg transaction.Argument) (uint64, error) {
+	v, err := x.value(arg)
+	if err != nil {
+		return 0, err
+	}
+	if len(v.pure) != 8 {
+		return 0, failf("expected a u64 pure value")
+	}
+	return binary.LittleEndian.Uint64(v.pure), nil
+}
+
+func (x *execution) address(arg transaction.Argument) (types.Address, error) {
+	v, err := x.value(arg)
+	if err != nil {
+		return types.Address{}, err
+	}
+	if len(v.pure) != len(types.Address{}) {
+		return types.Address{}, failf("expected an address pure value")
+	}
+	return types.Address(v.pure), nil
+}
+
+// effects synthesizes the effects of the execution.
+func (x *execution) effects(failure error, lamport uint64) *graphql.TransactionEffects {
+	effects := &graphql.TransactionEffects{
+		Digest:  x.digest,
+		Status:  graphql.ExecutionStatusSuccess,
+		Lamport: graphql.UInt53(lamport),
+		GasEffects: &graphql.GasEffects{GasSummary: &graphql.GasCostSummary{
+			ComputationCost: graphql.UInt53(x.chain.GasCost),
+		}},
+		Epoch: &graphql.Epoch{EpochID: graphql.UInt53(x.chain.Epoch)},
+	}
+	if failure != nil {
+		effects.Status = graphql.ExecutionStatusFailure
+		effects.ExecutionError = &graphql.ExecutionError{Message: failure.Error()}
+	}
+
+	ids := make([]types.Address, 0, len(x.objects))
+	for id := range x.objects {
+		if _, existed := x.chain.objects[id]; existed {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return bytes.Compare(ids[i][:], ids[j][:]) < 0 })
+	ids = append(ids, x.created...)
+
+	balances := make(map[[2]string]*big.Int)
+	addBalance := func(obj *LocalObject, sign int64) {
+		if typ, ok := obj.CoinType(); ok {
+			key := [2]string{obj.Owner.String(), typ}
+			if balances[key] == nil {
+				balances[key] = new(big.Int)
+			}
+			balances[key].Add(balances[key], new(big.Int).Mul(big.NewInt(sign), new(big.Int).SetUint64(obj.Balance)))
+		}
+	}
+
+	changes := &graphql.Connection[graphql.ObjectChange]{}
+	for _, id := range ids {
+		change := graphql.ObjectChange{Address: id}
+		before, existed := x.chain.objects[id]
+		after := x.objects[id]
+		change.IDCreated = utils.Ptr(!existed)
+		change.IDDeleted = utils.Ptr(after == nil)
+		if existed {
+			change.InputState = objectState(before)
+			addBalance(before, -1)
+		}
+		if after != nil {
+			change.OutputState = objectState(after)
+			addBalance(after, 1)
+		}
+		changes.Nodes = append(changes.Nodes, change)
+	}
+	effects.ObjectChanges = changes
+
+	keys := make([][2]string, 0, len(balances))
+	for key, amount := range balances {
+		if amount.Sign() != 0 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i][0] < keys[j][0] || (keys[i][0] == keys[j][0] && keys[i][1] < keys[j][1])
+	})
+	effects.BalanceChanges = &graphql.Connection[graphql.BalanceChange]{}
+	for _, key := range keys {
+		effects.BalanceChanges.Nodes = append(effects.BalanceChanges.Nodes, graphql.BalanceChange{
+			Owner:    &graphql.Address{Address: utils.MustParseAddress(key[0])},
+			CoinType: &graphql.MoveType{Repr: key[1]},
+			Amount:   graphql.BigInt(balances[key].String()),
+		})
+	}
+	return effects
+}
+
+func objectState(obj *LocalObject) *graphql.Object {
+	return &graphql.Object{
+		Address: obj.ID,
+		Version: graphql.UInt53(obj.Version),
+		Digest:  obj.Digest,
+		Owner: &graphql.ObjectOwner{
+			Typename: "AddressOwner",
+			Address:  &graphql.OwnerAddress{Address: obj.Owner},
+		},
+		AsMoveObject: &graphql.MoveObject{
+			Address: obj.ID,
+			Version: graphql.UInt53(obj.Version),
+			Digest:  obj.Digest,
+			Type:    &graphql.MoveType{Repr: obj.Type},
+		},
+	}
+}
+
+func objectDigest(id types.Address, version uint64) types.Digest {
+	var seed [8]byte
+	binary.BigEndian.PutUint64(seed[:], version)
+	sum := sha256.Sum256(append(id[:], seed[:]...))
+	return types.Digest(sum[:])
+}
+
+func commandName(cmd transaction.Command) string {
+	switch {
+	case cmd.MoveCall != nil:
+		return "MoveCall"
+	case cmd.MakeMoveVec != nil:
+		return "MakeMoveVec"
+	case cmd.Publish != nil:
+		return "Publish"
+	case cmd.Upgrade != nil:
+		return "Upgrade"
+	}
+	return "unknown command"
+}
@@ -0,0 +1,118 @@
+package suitest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/graphql"
+	"github.com/open-move/sui-go-sdk/transaction"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+const usdcType = "0xdead::usdc::USDC"
+
+func TestLocalChainSimulateBuiltTransaction(t *testing.T) {
+	chain := NewLocalChain()
+	chain.AddCoin("0xa", "0x2::sui::SUI", 10_000_000_000)
+	usdc := chain.AddCoin("0xa", usdcType, 500)
+
+	server := NewServer(t)
+	chain.Serve(server)
+
+	tx := transaction.New()
+	tx.SetSender("0xa")
+	coins := tx.SplitCoins(transaction.SplitCoins{Coin: tx.Gas(), Amounts: []transaction.Argument{tx.PureU64(100)}})
+	tx.TransferObjects(transaction.TransferObjects{Objects: coins, Address: tx.PureAddress("0xb")})
+	tx.TransferObjects(transaction.TransferObjects{Objects: []transaction.Argument{tx.Object(usdc.ObjectID.String())}, Address: tx.PureAddress("0xc")})
+
+	opts := transaction.BuildOptions{Resolver: chain, GasResolver: chain}
+	result, err := graphql.SimulateBuiltTransaction(server.Client(), context.Background(), tx, opts, nil)
+	if err != nil {
+		t.Fatalf("simulate: %v", err)
+	}
+	AssertEffects(t, result.Effects, ExpectedEffects{
+		Created:      utils.Ptr(1),
+		CreatedTypes: map[string]int{"0x2::coin::Coin<0x2::sui::SUI>": 1},
+		BalanceChanges: map[string]map[string]int64{
+			"0xa": {"0x2::sui::SUI": -100 - DefaultLocalGasCost, usdcType: -500},
+			"0xb": {"0x2::sui::SUI": 100},
+			"0xc": {usdcType: 500},
+		},
+		MinGas: DefaultLocalGasCost,
+		MaxGas: DefaultLocalGasCost,
+	})
+	if chain.Balance("0xb", "0x2::sui::SUI") != 0 {
+		t.Fatalf("simulation changed the chain")
+	}
+
+	built, err := graphql.BuildTransaction(context.Background(), tx, opts)
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	if _, err := chain.Execute(built); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if got := chain.Balance("0xb", "0x2::sui::SUI"); got != 100 {
+		t.Fatalf("recipient balance = %d, want 100", got)
+	}
+	if obj, ok := chain.Object(usdc.ObjectID); !ok || obj.Owner != utils.MustParseAddress("0xc") || obj.Version <= usdc.Version {
+		t.Fatalf("unexpected USDC coin %+v", obj)
+	}
+
+	// The inputs moved on, so executing the same bytes again is rejected.
+	if _, err := chain.Execute(built); err == nil {
+		t.Fatalf("expected stale inputs to be rejected")
+	}
+}
+
+func TestLocalChainFailures(t *testing.T) {
+	chain := NewLocalChain()
+	chain.AddCoin("0xa", "0x2::sui::SUI", 5_000_000)
+	opts := transaction.BuildOptions{Resolver: chain, GasResolver: chain}
+
+	build := func(fn func(tx *transaction.Transaction)) []byte {
+		t.Helper()
+		tx := transaction.New()
+		tx.SetSender("0xa")
+		fn(tx)
+		built, err := graphql.BuildTransaction(context.Background(), tx, opts)
+		if err != nil {
+			t.Fatalf("build: %v", err)
+		}
+		return built
+	}
+
+	overdraw := build(func(tx *transaction.Transaction) {
+		coins := tx.SplitCoins(transaction.SplitCoins{Coin: tx.Gas(), Amounts: []transaction.Argument{tx.PureU64(10_000_000)}})
+		tx.TransferObjects(transaction.TransferObjects{Objects: coins, Address: tx.PureAddress("0xb")})
+	})
+	effects, err := chain.Execute(overdraw)
+	if err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	AssertEffects(t, effects, ExpectedEffects{
+		ErrorContains:  "InsufficientCoinBalance",
+		Created:        utils.Ptr(0),
+		BalanceChanges: map[string]map[string]int64{"0xa": {"0x2::sui::SUI": -DefaultLocalGasCost}},
+	})
+	if got := chain.Balance("0xa", "0x2::sui::SUI"); got != 4_000_000 {
+		t.Fatalf("balance after failure = %d, want only gas charged", got)
+	}
+
+	unused := build(func(tx *transaction.Transaction) {
+		tx.SplitCoins(transaction.SplitCoins{Coin: tx.Gas(), Amounts: []transaction.Argument{tx.PureU64(1)}})
+	})
+	result, err := chain.Simulate(unused, true)
+	if err != nil {
+		t.Fatalf("simulate: %v", err)
+	}
+	AssertEffects(t, result.Effects, ExpectedEffects{ErrorContains: "UnusedValueWithoutDrop"})
+
+	publish := build(func(tx *transaction.Transaction) {
+		tx.Publish(transaction.PublishInput{Modules: [][]byte{{0}}, Dependencies: []string{"0x1", "0x2"}})
+	})
+	if _, err := chain.Simulate(publish, true); !errors.Is(err, ErrUnsupportedCommand) {
+		t.Fatalf("expected ErrUnsupportedCommand, got %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package suitest
+
+import (
+	"strings"
+	"testing"
+)
+
+// punctuators are GraphQL tokens that need no surrounding whitespace.
+const punctuators = "{}()[]:!=@$,|&"
+
+// NormalizeQuery collapses insignificant whitespace and commas in a GraphQL
+// document so that queries can be compared regardless of formatting. String
+// literals and comments are handled; comments are dropped.
+func NormalizeQuery(query string) string {
+	var b strings.Builder
+	var last rune
+	pendingSpace := false
+
+	emit := func(r rune) {
+		if pendingSpace && b.Len() > 0 && !strings.ContainsRune(punctuators, last) && !strings.ContainsRune(punctuators, r) {
+			b.WriteByte(' ')
+		}
+		pendingSpace = false
+		b.WriteRune(r)
+		last = r
+	}
+
+	runes := []rune(query)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '#':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+			pendingSpace = true
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r' || r == ',':
+			pendingSpace = true
+		case r == '"':
+			emit(r)
+			for i++; i < len(runes); i++ {
+				b.WriteRune(runes[i])
+				last = runes[i]
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+					b.WriteRune(runes[i])
+					continue
+				}
+				if runes[i] == '"' {
+					break
+				}
+			}
+		default:
+			emit(r)
+		}
+	}
+	return b.String()
+}
+
+// AssertQuery fails the test if got and want differ after NormalizeQuery.
+func AssertQuery(t testing.TB, got, want string) {
+	t.Helper()
+	if g, w := NormalizeQuery(got), NormalizeQuery(want); g != w {
+		t.Errorf("query mismatch\n got: %s\nwant: %s", g, w)
+	}
+}
+
+// AssertOperation fails the test unless the server received operation
+// exactly times times.
+func AssertOperation(t testing.TB, s *Server, operation string, times int) {
+	t.Helper()
+	if got := len(s.RequestsFor(operation)); got != times {
+		t.Errorf("operation %q called %d times, want %d", operation, got, times)
+	}
+}
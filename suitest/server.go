@@ -0,0 +1,176 @@
+// Package suitest provides deterministic test doubles for code built on the
+// SDK's GraphQL client: a mock server that answers by operation name, a
+// recorder that captures live traffic into transcript fixtures, helpers
+// for asserting generated query strings and transaction effects, and an
+// in-memory chain that executes simple transactions without a network.
+package suitest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/graphql"
+)
+
+// Request is a GraphQL request received by the mock server.
+type Request struct {
+	OperationName string         `json:"operationName,omitempty"`
+	Query         string         `json:"query"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// Response is the GraphQL response body the mock server returns.
+type Response struct {
+	Data   json.RawMessage        `json:"data,omitempty"`
+	Errors []graphql.GraphQLError `json:"errors,omitempty"`
+}
+
+// HandlerFunc answers a single request.
+type HandlerFunc func(req Request) Response
+
+// Server is a mock Sui GraphQL endpoint. Responses are registered per
+// operation name; requests for unregistered operations fail the test.
+type Server struct {
+	*httptest.Server
+
+	t        testing.TB
+	mu       sync.Mutex
+	handlers map[string][]HandlerFunc
+	requests []Request
+}
+
+// NewServer starts a mock server that is closed when the test finishes.
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+	s := &Server{t: t, handlers: make(map[string][]HandlerFunc)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Client returns a graphql.Client pointed at the server.
+func (s *Server) Client(opts ...graphql.ClientOption) *graphql.Client {
+	opts = append([]graphql.ClientOption{graphql.WithEndpoint(s.URL), graphql.WithRetries(0)}, opts...)
+	return graphql.NewClient(opts...)
+}
+
+// Handle answers operation with data, which is marshaled to JSON unless it is
+// already a json.RawMessage or string. Registering the same operation several
+// times queues the responses in order; the last one is repeated once the
+// queue is drained.
+func (s *Server) Handle(operation string, data any) {
+	raw, err := toRawJSON(data)
+	if err != nil {
+		s.t.Fatalf("suitest: marshal response for %q: %v", operation, err)
+	}
+	s.HandleFunc(operation, func(Request) Response {
+		return Response{Data: raw}
+	})
+}
+
+// HandleError answers operation with a GraphQL error.
+func (s *Server) HandleError(operation string, message string) {
+	s.HandleFunc(operation, func(Request) Response {
+		return Response{Errors: []graphql.GraphQLError{{Message: message}}}
+	})
+}
+
+// HandleFunc answers operation with fn.
+func (s *Server) HandleFunc(operation string, fn HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[operation] = append(s.handlers[operation], fn)
+}
+
+// Requests returns every request received so far, in order.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// RequestsFor returns the requests received for operation.
+func (s *Server) RequestsFor(operation string) []Request {
+	var out []Request
+	for _, req := range s.Requests() {
+		if req.OperationName == operation {
+			out = append(out, req)
+		}
+	}
+	return out
+}
+
+// LastRequest returns the most recent request, failing the test if there is none.
+func (s *Server) LastRequest() Request {
+	s.t.Helper()
+	reqs := s.Requests()
+	if len(reqs) == 0 {
+		s.t.Fatalf("suitest: no requests received")
+	}
+	return reqs[len(reqs)-1]
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	var req Request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("suitest: decode request: %v", err), http.StatusBadRequest)
+		return
+	}
+	if req.OperationName == "" {
+		req.OperationName = OperationName(req.Query)
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, req)
+	var handler HandlerFunc
+	if queue := s.handlers[req.OperationName]; len(queue) > 0 {
+		handler = queue[0]
+		if len(queue) > 1 {
+			s.handlers[req.OperationName] = queue[1:]
+		}
+	}
+	s.mu.Unlock()
+
+	resp := Response{Errors: []graphql.GraphQLError{{
+		Message: fmt.Sprintf("suitest: no response registered for operation %q", req.OperationName),
+	}}}
+	if handler == nil {
+		s.t.Errorf("suitest: unexpected operation %q", req.OperationName)
+	} else {
+		resp = handler(req)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		s.t.Errorf("suitest: encode response: %v", err)
+	}
+}
+
+var operationNamePattern = regexp.MustCompile(`^\s*(?:query|mutation|subscription)\s+([_A-Za-z][_0-9A-Za-z]*)`)
+
+// OperationName extracts the operation name from a GraphQL document, or
+// returns "" for anonymous operations.
+func OperationName(query string) string {
+	if m := operationNamePattern.FindStringSubmatch(query); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+func toRawJSON(data any) (json.RawMessage, error) {
+	switch v := data.(type) {
+	case json.RawMessage:
+		return v, nil
+	case []byte:
+		return json.RawMessage(v), nil
+	case string:
+		return json.RawMessage(v), nil
+	default:
+		return json.Marshal(v)
+	}
+}
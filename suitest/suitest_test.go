@@ -0,0 +1,83 @@
+package suitest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/graphql"
+)
+
+func TestServerAnswersByOperation(t *testing.T) {
+	server := NewServer(t)
+	server.Handle("GetChainIdentifier", map[string]any{"chainIdentifier": "4c78adac"})
+	server.Handle("GetReferenceGasPrice", `{"epoch":{"referenceGasPrice":"750"}}`)
+	server.Handle("GetReferenceGasPrice", `{"epoch":{"referenceGasPrice":"800"}}`)
+
+	client := server.Client()
+	ctx := context.Background()
+
+	chainID, err := client.GetChainIdentifier(ctx)
+	if err != nil || chainID != "4c78adac" {
+		t.Fatalf("GetChainIdentifier = %q, %v", chainID, err)
+	}
+	for _, want := range []string{"750", "800", "800"} {
+		price, err := client.GetReferenceGasPrice(ctx)
+		if err != nil || price == nil || string(*price) != want {
+			t.Fatalf("GetReferenceGasPrice = %v, %v; want %s", price, err, want)
+		}
+	}
+
+	AssertOperation(t, server, "GetReferenceGasPrice", 3)
+	AssertQuery(t, server.LastRequest().Query, `query GetReferenceGasPrice { epoch { referenceGasPrice } }`)
+}
+
+func TestServerHandleError(t *testing.T) {
+	server := NewServer(t)
+	server.HandleError("GetChainIdentifier", "boom")
+
+	_, err := server.Client().GetChainIdentifier(context.Background())
+	if errs, ok := err.(graphql.GraphQLErrors); !ok || errs[0].Message != "boom" {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestRecorderAndReplay(t *testing.T) {
+	live := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"chainIdentifier":"35834a8a"}}`))
+	}))
+	defer live.Close()
+
+	rec := NewRecorder(live.URL)
+	if _, err := rec.Client().GetChainIdentifier(context.Background()); err != nil {
+		t.Fatalf("recorded call: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "chain.json")
+	if err := rec.Transcript().Save(path); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	live.Close()
+
+	client := Replay(t, path, live.URL)
+	chainID, err := client.GetChainIdentifier(context.Background())
+	if err != nil || chainID != "35834a8a" {
+		t.Fatalf("replayed call = %q, %v", chainID, err)
+	}
+}
+
+func TestNormalizeQuery(t *testing.T) {
+	got := NormalizeQuery(`
+		query Q($id: SuiAddress!, $n: Int) {
+			# comment
+			object(address: $id) { address, version }
+			search(text: "a  b, c")
+		}
+	`)
+	want := `query Q($id:SuiAddress!$n:Int){object(address:$id){address version}search(text:"a  b, c")}`
+	if got != want {
+		t.Fatalf("got  %s\nwant %s", got, want)
+	}
+}
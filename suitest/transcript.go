@@ -0,0 +1,168 @@
+package suitest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/graphql"
+)
+
+// RecordEnv is the environment variable that switches Replay into recording mode.
+const RecordEnv = "SUITEST_RECORD"
+
+// Entry is one recorded request and the response the endpoint returned.
+type Entry struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Transcript is an ordered list of recorded GraphQL exchanges.
+type Transcript struct {
+	Endpoint string  `json:"endpoint,omitempty"`
+	Entries  []Entry `json:"entries"`
+}
+
+// LoadTranscript reads a transcript fixture from path.
+func LoadTranscript(path string) (*Transcript, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var transcript Transcript
+	if err := json.Unmarshal(data, &transcript); err != nil {
+		return nil, fmt.Errorf("suitest: parse transcript %s: %w", path, err)
+	}
+	return &transcript, nil
+}
+
+// Save writes the transcript to path as indented JSON, creating parent
+// directories as needed.
+func (tr *Transcript) Save(path string) error {
+	data, err := json.MarshalIndent(tr, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Load registers every entry of the transcript on the server. Entries for the
+// same operation are replayed in recorded order.
+func (s *Server) Load(transcript *Transcript) {
+	for _, entry := range transcript.Entries {
+		resp := entry.Response
+		s.HandleFunc(entry.Request.OperationName, func(Request) Response {
+			return resp
+		})
+	}
+}
+
+// Recorder is an http.RoundTripper that forwards GraphQL requests to a live
+// endpoint and captures each exchange into a Transcript.
+type Recorder struct {
+	// Transport performs the live requests; http.DefaultTransport when nil.
+	Transport http.RoundTripper
+
+	mu         sync.Mutex
+	transcript Transcript
+}
+
+// NewRecorder returns a Recorder that captures traffic sent to endpoint.
+func NewRecorder(endpoint string) *Recorder {
+	return &Recorder{transcript: Transcript{Endpoint: endpoint}}
+}
+
+// Client returns a graphql.Client that sends its traffic through the recorder.
+func (r *Recorder) Client(opts ...graphql.ClientOption) *graphql.Client {
+	opts = append([]graphql.ClientOption{
+		graphql.WithEndpoint(r.transcript.Endpoint),
+		graphql.WithHTTPClient(&http.Client{Transport: r}),
+	}, opts...)
+	return graphql.NewClient(opts...)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	var entry Entry
+	if json.Unmarshal(body, &entry.Request) == nil && json.Unmarshal(respBody, &entry.Response) == nil {
+		if entry.Request.OperationName == "" {
+			entry.Request.OperationName = OperationName(entry.Request.Query)
+		}
+		r.mu.Lock()
+		r.transcript.Entries = append(r.transcript.Entries, entry)
+		r.mu.Unlock()
+	}
+	return resp, nil
+}
+
+// Transcript returns a copy of everything recorded so far.
+func (r *Recorder) Transcript() *Transcript {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return &Transcript{
+		Endpoint: r.transcript.Endpoint,
+		Entries:  append([]Entry(nil), r.transcript.Entries...),
+	}
+}
+
+// Replay returns a client for a fixture-backed test. Normally it serves the
+// transcript at path from a mock server. When the SUITEST_RECORD environment
+// variable is set, it instead talks to endpoint and rewrites the fixture with
+// the recorded traffic when the test finishes.
+func Replay(t testing.TB, path string, endpoint string, opts ...graphql.ClientOption) *graphql.Client {
+	t.Helper()
+
+	if os.Getenv(RecordEnv) != "" {
+		rec := NewRecorder(endpoint)
+		t.Cleanup(func() {
+			if err := rec.Transcript().Save(path); err != nil {
+				t.Errorf("suitest: save transcript: %v", err)
+			}
+		})
+		return rec.Client(opts...)
+	}
+
+	transcript, err := LoadTranscript(path)
+	if err != nil {
+		t.Fatalf("suitest: load transcript (set %s=1 to record it): %v", RecordEnv, err)
+	}
+	server := NewServer(t)
+	server.Load(transcript)
+	return server.Client(opts...)
+}
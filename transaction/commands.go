@@ -1,6 +1,8 @@
 package transaction
 
 import (
+	"strings"
+
 	bcs "github.com/iotaledger/bcs-go"
 	"github.com/open-move/sui-go-sdk/types"
 	"github.com/open-move/sui-go-sdk/typetag"
@@ -15,6 +17,11 @@ type MoveCall struct {
 	Function      string
 	TypeArguments []string
 	Arguments     []Argument
+	// Values holds native Go arguments (uint64, bool, string, types.Address,
+	// types.ObjectRef, []byte, slices, ...) that are encoded at build time
+	// against the resolved function signature. Argument and Result elements
+	// are passed through unchanged. Values cannot be combined with Arguments.
+	Values []any
 }
 
 // MakeMoveVecInput represents the input for a MakeMoveVec command.
@@ -36,14 +43,17 @@ type UpgradeInput struct {
 	Ticket       Argument
 }
 
-func (m MoveCall) toProgrammableMoveCall() (ProgrammableMoveCall, error) {
+// toProgrammableMoveCall converts the call. When the package is a Move
+// Registry name, the call's package is left zero and the name is returned
+// for Build to resolve.
+func (m MoveCall) toProgrammableMoveCall() (ProgrammableMoveCall, string, error) {
 	pkg := m.Package
 	mod := m.Module
 	fn := m.Function
 	if m.Target != "" {
 		parsedPkg, parsedMod, parsedFn, err := utils.ParseMoveCallTarget(m.Target)
 		if err != nil {
-			return ProgrammableMoveCall{}, err
+			return ProgrammableMoveCall{}, "", err
 		}
 
 		pkg = parsedPkg
@@ -52,19 +62,25 @@ func (m MoveCall) toProgrammableMoveCall() (ProgrammableMoveCall, error) {
 	}
 
 	if pkg == "" || mod == "" || fn == "" {
-		return ProgrammableMoveCall{}, ErrMissingMoveCallTarget
+		return ProgrammableMoveCall{}, "", ErrMissingMoveCallTarget
 	}
 
-	address, err := utils.ParseAddress(pkg)
-	if err != nil {
-		return ProgrammableMoveCall{}, err
+	var (
+		address types.Address
+		name    string
+		err     error
+	)
+	if isPackageName(pkg) {
+		name = pkg
+	} else if address, err = utils.ParseAddress(pkg); err != nil {
+		return ProgrammableMoveCall{}, "", err
 	}
 
 	parsedTypeArgs := make([]typetag.TypeTag, len(m.TypeArguments))
 	for i, arg := range m.TypeArguments {
 		parsed, err := utils.ParseTypeTag(arg)
 		if err != nil {
-			return ProgrammableMoveCall{}, err
+			return ProgrammableMoveCall{}, "", err
 		}
 		parsedTypeArgs[i] = parsed
 	}
@@ -75,7 +91,13 @@ func (m MoveCall) toProgrammableMoveCall() (ProgrammableMoveCall, error) {
 		Function:      fn,
 		TypeArguments: parsedTypeArgs,
 		Arguments:     append([]Argument(nil), m.Arguments...),
-	}, nil
+	}, name, nil
+}
+
+// isPackageName reports whether pkg is a Move Registry name ("@org/app" or
+// "org.sui/app") rather than an address.
+func isPackageName(pkg string) bool {
+	return strings.HasPrefix(pkg, "@") || strings.Contains(pkg, ".sui/")
 }
 
 func (m MakeMoveVecInput) toCommand() (MakeMoveVec, error) {
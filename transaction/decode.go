@@ -0,0 +1,80 @@
+package transaction
+
+import (
+	"fmt"
+
+	bcs "github.com/iotaledger/bcs-go"
+	"github.com/open-move/sui-go-sdk/types"
+	"golang.org/x/crypto/blake2b"
+)
+
+// transactionDigestSalt prefixes TransactionData when hashing it into a
+// transaction digest.
+const transactionDigestSalt = "TransactionData::"
+
+// TransactionDigest returns the digest identifying BCS-encoded
+// TransactionData on chain: the Blake2b-256 hash of the data prefixed with
+// "TransactionData::". Its String form is the base58 digest explorers show.
+func TransactionDigest(txBytes []byte) types.Digest {
+	hasher, _ := blake2b.New256(nil)
+	hasher.Write([]byte(transactionDigestSalt))
+	hasher.Write(txBytes)
+	return types.Digest(hasher.Sum(nil))
+}
+
+// DecodeTransactionData decodes BCS-encoded TransactionData, as returned in
+// transactionBcs fields or produced by Build.
+func DecodeTransactionData(data []byte) (*TransactionData, error) {
+	decoded, err := bcs.Unmarshal[TransactionData](data)
+	if err != nil {
+		return nil, fmt.Errorf("decode transaction data: %w", err)
+	}
+	if decoded.V1 == nil {
+		return nil, fmt.Errorf("decode transaction data: unsupported version")
+	}
+	return &decoded, nil
+}
+
+// FromTransactionData rebuilds a Transaction builder from decoded transaction
+// data so it can be modified and built again. Only programmable transactions
+// are supported.
+func FromTransactionData(data *TransactionData) (*Transaction, error) {
+	if data == nil || data.V1 == nil {
+		return nil, fmt.Errorf("transaction data missing V1 payload")
+	}
+	v1 := data.V1
+	programmable := v1.Kind.ProgrammableTransaction
+	if programmable == nil {
+		return nil, ErrMissingProgrammableKind
+	}
+
+	tx := New()
+	for _, arg := range programmable.Inputs {
+		switch {
+		case arg.Pure != nil:
+			tx.inputs = append(tx.inputs, input{Pure: &Pure{Bytes: append([]byte(nil), arg.Pure.Bytes...)}})
+		case arg.Object != nil:
+			obj := *arg.Object
+			tx.inputs = append(tx.inputs, input{Object: &obj})
+		default:
+			return nil, fmt.Errorf("unsupported call arg")
+		}
+	}
+	tx.commands = append([]Command(nil), programmable.Commands...)
+
+	sender := v1.Sender
+	tx.sender = &sender
+	expiration := v1.Expiration
+	tx.expiration = &expiration
+
+	owner := v1.GasData.Owner
+	price := v1.GasData.Price
+	budget := v1.GasData.Budget
+	tx.gas = gasConfig{
+		Payment: append([]types.ObjectRef(nil), v1.GasData.Payment...),
+		Owner:   &owner,
+		Price:   &price,
+		Budget:  &budget,
+	}
+	return tx, nil
+}
@@ -0,0 +1,44 @@
+package transaction
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/types"
+)
+
+func TestDecodeTransactionDataRoundTrip(t *testing.T) {
+	digest := types.Digest(bytes.Repeat([]byte{3}, 32))
+	tx := New()
+	tx.SetSender("0xa").SetGasBudget(1000).SetGasPrice(1).SetGasPayment([]types.ObjectRef{
+		{ObjectID: mustAddress(t, "0x9"), Version: 4, Digest: digest},
+	})
+	coins := tx.SplitCoins(SplitCoins{Coin: tx.Gas(), Amounts: []Argument{tx.PureU64(5)}})
+	tx.TransferObjects(TransferObjects{Objects: coins, Address: tx.PureAddress("0xb")})
+
+	built, err := tx.Build(context.Background(), BuildOptions{})
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+
+	decoded, err := DecodeTransactionData(built.TransactionBytes)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if decoded.V1.GasData.Budget != 1000 || len(decoded.V1.Kind.ProgrammableTransaction.Commands) != 2 {
+		t.Fatalf("unexpected decoded data")
+	}
+
+	rebuilt, err := FromTransactionData(decoded)
+	if err != nil {
+		t.Fatalf("from data: %v", err)
+	}
+	again, err := rebuilt.Build(context.Background(), BuildOptions{})
+	if err != nil {
+		t.Fatalf("rebuild: %v", err)
+	}
+	if !bytes.Equal(again.TransactionBytes, built.TransactionBytes) {
+		t.Fatalf("rebuilt bytes differ")
+	}
+}
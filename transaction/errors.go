@@ -1,14 +1,26 @@
 package transaction
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/open-move/sui-go-sdk/utils"
+)
 
 var (
 	ErrNilTransaction          = errors.New("transaction is nil")
-	ErrInvalidAddress          = errors.New("invalid sui address")
+	ErrInvalidAddress          = utils.ErrInvalidAddress
 	ErrInvalidDigest           = errors.New("invalid object digest")
 	ErrInvalidSerializedSig    = errors.New("invalid serialized signature")
 	ErrMissingMoveCallTarget   = errors.New("move call target or package/module/function required")
 	ErrUnresolvedInput         = errors.New("transaction input unresolved")
 	ErrResolverRequired        = errors.New("resolver required to resolve object inputs")
 	ErrMissingProgrammableKind = errors.New("programmable transaction required")
+	ErrPayLengthMismatch       = errors.New("recipients and amounts must have the same length")
+	ErrNoRecipients            = errors.New("at least one recipient required")
+	ErrNoCoins                 = errors.New("at least one coin required")
+	ErrMixedMoveCallArguments  = errors.New("move call accepts either Arguments or Values, not both")
+	ErrMoveArgumentMismatch    = errors.New("move call argument does not match parameter type")
+	ErrTransactionExpired      = errors.New("transaction expired")
+	ErrNameResolverRequired    = errors.New("name resolver required to resolve package names")
+	ErrInvalidTransaction      = errors.New("invalid transaction")
 )
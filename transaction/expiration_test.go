@@ -0,0 +1,65 @@
+package transaction
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/types"
+)
+
+type stubEpochResolver uint64
+
+func (s stubEpochResolver) ResolveCurrentEpoch(context.Context) (uint64, error) {
+	return uint64(s), nil
+}
+
+func newExpiringTransaction(t *testing.T) *Transaction {
+	t.Helper()
+	tx := New()
+	tx.SetSender("0xa").SetGasBudget(1000).SetGasPrice(1).SetGasPayment([]types.ObjectRef{
+		{ObjectID: mustAddress(t, "0x9"), Version: 4, Digest: types.Digest(bytes.Repeat([]byte{3}, 32))},
+	})
+	tx.PayAllSui("0xb")
+	return tx
+}
+
+func TestSetExpirationInEpochs(t *testing.T) {
+	tx := newExpiringTransaction(t)
+	tx.SetExpirationInEpochs(context.Background(), stubEpochResolver(10), 2)
+
+	built, err := tx.Build(context.Background(), BuildOptions{EpochResolver: stubEpochResolver(12)})
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	decoded, err := DecodeTransactionData(built.TransactionBytes)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if epoch := decoded.V1.Expiration.Epoch; epoch == nil || *epoch != 12 {
+		t.Fatalf("unexpected expiration %+v", decoded.V1.Expiration)
+	}
+}
+
+func TestBuildRejectsExpiredTransaction(t *testing.T) {
+	tx := newExpiringTransaction(t)
+	tx.SetExpiration(ExpirationEpoch(5))
+
+	_, err := tx.Build(context.Background(), BuildOptions{EpochResolver: stubEpochResolver(6)})
+	if !errors.Is(err, ErrTransactionExpired) {
+		t.Fatalf("expected ErrTransactionExpired, got %v", err)
+	}
+
+	if _, err := tx.Build(context.Background(), BuildOptions{}); err != nil {
+		t.Fatalf("build without epoch resolver should skip the check: %v", err)
+	}
+}
+
+func TestSetExpirationInEpochsRequiresResolver(t *testing.T) {
+	tx := newExpiringTransaction(t)
+	tx.SetExpirationInEpochs(context.Background(), nil, 1)
+	if tx.Err() == nil {
+		t.Fatal("expected error without epoch resolver")
+	}
+}
@@ -0,0 +1,176 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/open-move/sui-go-sdk/types"
+)
+
+// DefaultEpochCheckInterval is how often a GasPriceOracle asks the network
+// whether the epoch has changed.
+const DefaultEpochCheckInterval = 30 * time.Second
+
+// GasPriceSource reports the reference gas price and the current epoch. Both
+// the gRPC resolver and the GraphQL client implement it.
+type GasPriceSource interface {
+	ResolveGasPrice(ctx context.Context) (uint64, error)
+	EpochResolver
+}
+
+// GasPriceOracleOptions configures a GasPriceOracle.
+type GasPriceOracleOptions struct {
+	// CheckInterval bounds how often the current epoch is polled. Zero means
+	// DefaultEpochCheckInterval.
+	CheckInterval time.Duration
+	// Multiplier scales the reference gas price returned by GetGasPrice, e.g.
+	// 1.5 during congestion. Zero means 1.
+	Multiplier float64
+}
+
+// GasPriceOracle caches the reference gas price for the current epoch so that
+// many concurrent transaction submissions share a single lookup. The price is
+// refetched only when the epoch changes; the epoch itself is checked at most
+// once per CheckInterval. Concurrent callers wait for the same refresh.
+type GasPriceOracle struct {
+	source   GasPriceSource
+	interval time.Duration
+	now      func() time.Time
+
+	mu         sync.Mutex
+	multiplier float64
+	cached     bool
+	epoch      uint64
+	price      uint64
+	checkedAt  time.Time
+}
+
+// NewGasPriceOracle returns an oracle that reads prices from source.
+func NewGasPriceOracle(source GasPriceSource, opts GasPriceOracleOptions) (*GasPriceOracle, error) {
+	if source == nil {
+		return nil, fmt.Errorf("gas price source required")
+	}
+	o := &GasPriceOracle{source: source, interval: opts.CheckInterval, now: time.Now, multiplier: 1}
+	if o.interval <= 0 {
+		o.interval = DefaultEpochCheckInterval
+	}
+	if opts.Multiplier != 0 {
+		if err := o.SetMultiplier(opts.Multiplier); err != nil {
+			return nil, err
+		}
+	}
+	return o, nil
+}
+
+// SetMultiplier changes the priority multiplier applied by GetGasPrice.
+// Multipliers below 1 are rejected since validators refuse transactions
+// priced under the reference gas price.
+func (o *GasPriceOracle) SetMultiplier(multiplier float64) error {
+	if math.IsNaN(multiplier) || math.IsInf(multiplier, 0) || multiplier < 1 {
+		return fmt.Errorf("gas price multiplier must be at least 1, got %v", multiplier)
+	}
+	o.mu.Lock()
+	o.multiplier = multiplier
+	o.mu.Unlock()
+	return nil
+}
+
+// GetGasPrice returns the current reference gas price scaled by the oracle's
+// multiplier.
+func (o *GasPriceOracle) GetGasPrice(ctx context.Context) (uint64, error) {
+	o.mu.Lock()
+	multiplier := o.multiplier
+	o.mu.Unlock()
+	return o.GetPriorityGasPrice(ctx, multiplier)
+}
+
+// GetPriorityGasPrice returns the current reference gas price scaled by
+// multiplier, rounding up.
+func (o *GasPriceOracle) GetPriorityGasPrice(ctx context.Context, multiplier float64) (uint64, error) {
+	if math.IsNaN(multiplier) || math.IsInf(multiplier, 0) || multiplier < 1 {
+		return 0, fmt.Errorf("gas price multiplier must be at least 1, got %v", multiplier)
+	}
+	price, _, err := o.ReferenceGasPrice(ctx)
+	if err != nil {
+		return 0, err
+	}
+	scaled := math.Ceil(float64(price) * multiplier)
+	if scaled >= math.MaxUint64 {
+		return 0, fmt.Errorf("gas price %d overflows with multiplier %v", price, multiplier)
+	}
+	return uint64(scaled), nil
+}
+
+// ReferenceGasPrice returns the unscaled reference gas price and the epoch it
+// belongs to, refreshing the cache if the epoch has rolled over.
+func (o *GasPriceOracle) ReferenceGasPrice(ctx context.Context) (uint64, uint64, error) {
+	if ctx == nil {
+		return 0, 0, fmt.Errorf("nil context")
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	now := o.now()
+	if o.cached && now.Sub(o.checkedAt) < o.interval {
+		return o.price, o.epoch, nil
+	}
+
+	epoch, err := o.source.ResolveCurrentEpoch(ctx)
+	if err != nil {
+		return 0, 0, fmt.Errorf("resolve current epoch: %w", err)
+	}
+	if !o.cached || epoch != o.epoch {
+		price, err := o.source.ResolveGasPrice(ctx)
+		if err != nil {
+			return 0, 0, fmt.Errorf("resolve gas price: %w", err)
+		}
+		o.price, o.epoch, o.cached = price, epoch, true
+	}
+	o.checkedAt = now
+	return o.price, o.epoch, nil
+}
+
+// Invalidate drops the cached price so the next call refetches it.
+func (o *GasPriceOracle) Invalidate() {
+	o.mu.Lock()
+	o.cached = false
+	o.mu.Unlock()
+}
+
+// ResolveGasPrice implements the gas price half of GasResolver, so the oracle
+// can be dropped into a resolver with GasResolver.
+func (o *GasPriceOracle) ResolveGasPrice(ctx context.Context) (uint64, error) {
+	return o.GetGasPrice(ctx)
+}
+
+// GasResolver wraps inner so that gas prices come from the oracle while
+// budgets and payment are still resolved by inner.
+func (o *GasPriceOracle) GasResolver(inner GasResolver) GasResolver {
+	return &oracleGasResolver{oracle: o, inner: inner}
+}
+
+type oracleGasResolver struct {
+	oracle *GasPriceOracle
+	inner  GasResolver
+}
+
+func (r *oracleGasResolver) ResolveGasPrice(ctx context.Context) (uint64, error) {
+	return r.oracle.GetGasPrice(ctx)
+}
+
+func (r *oracleGasResolver) ResolveGasBudget(ctx context.Context, input GasBudgetInput) (uint64, error) {
+	return r.inner.ResolveGasBudget(ctx, input)
+}
+
+func (r *oracleGasResolver) ResolveGasPayment(ctx context.Context, owner types.Address, budget uint64) ([]types.ObjectRef, error) {
+	return r.inner.ResolveGasPayment(ctx, owner, budget)
+}
+
+func (r *oracleGasResolver) ResolveCurrentEpoch(ctx context.Context) (uint64, error) {
+	_, epoch, err := r.oracle.ReferenceGasPrice(ctx)
+	return epoch, err
+}
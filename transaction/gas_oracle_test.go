@@ -0,0 +1,122 @@
+package transaction
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type stubGasPriceSource struct {
+	epoch      atomic.Uint64
+	price      atomic.Uint64
+	priceCalls atomic.Int32
+	epochCalls atomic.Int32
+}
+
+func (s *stubGasPriceSource) ResolveGasPrice(context.Context) (uint64, error) {
+	s.priceCalls.Add(1)
+	return s.price.Load(), nil
+}
+
+func (s *stubGasPriceSource) ResolveCurrentEpoch(context.Context) (uint64, error) {
+	s.epochCalls.Add(1)
+	return s.epoch.Load(), nil
+}
+
+func TestGasPriceOracleRefreshesAtEpochRollover(t *testing.T) {
+	source := &stubGasPriceSource{}
+	source.epoch.Store(10)
+	source.price.Store(1000)
+
+	oracle, err := NewGasPriceOracle(source, GasPriceOracleOptions{CheckInterval: time.Minute})
+	if err != nil {
+		t.Fatalf("NewGasPriceOracle: %v", err)
+	}
+	now := time.Unix(0, 0)
+	oracle.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if price, err := oracle.GetGasPrice(ctx); err != nil || price != 1000 {
+				t.Errorf("GetGasPrice = %d, %v", price, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := source.priceCalls.Load(); got != 1 {
+		t.Fatalf("price fetched %d times, want 1", got)
+	}
+
+	// Within the check interval the epoch is not polled again.
+	source.epoch.Store(11)
+	source.price.Store(1200)
+	now = now.Add(30 * time.Second)
+	if price, _ := oracle.GetGasPrice(ctx); price != 1000 {
+		t.Fatalf("price before interval = %d, want cached 1000", price)
+	}
+
+	now = now.Add(time.Minute)
+	price, epoch, err := oracle.ReferenceGasPrice(ctx)
+	if err != nil || price != 1200 || epoch != 11 {
+		t.Fatalf("ReferenceGasPrice = %d, %d, %v; want 1200, 11", price, epoch, err)
+	}
+
+	// Same epoch: only the epoch is rechecked.
+	now = now.Add(time.Minute)
+	if _, err := oracle.GetGasPrice(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := source.priceCalls.Load(); got != 2 {
+		t.Fatalf("price fetched %d times, want 2", got)
+	}
+	if got := source.epochCalls.Load(); got != 3 {
+		t.Fatalf("epoch checked %d times, want 3", got)
+	}
+}
+
+func TestGasPriceOracleMultiplier(t *testing.T) {
+	source := &stubGasPriceSource{}
+	source.price.Store(751)
+
+	oracle, err := NewGasPriceOracle(source, GasPriceOracleOptions{Multiplier: 1.5})
+	if err != nil {
+		t.Fatalf("NewGasPriceOracle: %v", err)
+	}
+	ctx := context.Background()
+	if price, _ := oracle.GetGasPrice(ctx); price != 1127 {
+		t.Fatalf("GetGasPrice = %d, want 1127", price)
+	}
+	if price, _ := oracle.GetPriorityGasPrice(ctx, 2); price != 1502 {
+		t.Fatalf("GetPriorityGasPrice = %d, want 1502", price)
+	}
+	if err := oracle.SetMultiplier(0.5); err == nil {
+		t.Fatal("expected error for multiplier below 1")
+	}
+	if err := oracle.SetMultiplier(1); err != nil {
+		t.Fatal(err)
+	}
+	if price, _ := oracle.ResolveGasPrice(ctx); price != 751 {
+		t.Fatalf("ResolveGasPrice = %d, want 751", price)
+	}
+}
+
+func TestGasPriceOracleGasResolver(t *testing.T) {
+	source := &stubGasPriceSource{}
+	source.price.Store(900)
+	oracle, err := NewGasPriceOracle(source, GasPriceOracleOptions{Multiplier: 2})
+	if err != nil {
+		t.Fatalf("NewGasPriceOracle: %v", err)
+	}
+
+	resolver := oracle.GasResolver(nil)
+	price, err := resolver.ResolveGasPrice(context.Background())
+	if err != nil || price != 1800 {
+		t.Fatalf("ResolveGasPrice = %d, %v; want 1800", price, err)
+	}
+}
@@ -0,0 +1,481 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	bcs "github.com/iotaledger/bcs-go"
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/typetag"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+// UnresolvedValue is a native Go value passed to MoveCall.Values. It is encoded
+// as a pure or object input once the Move function signature is known.
+type UnresolvedValue struct {
+	Value any
+}
+
+var (
+	u128Max = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+	u256Max = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+)
+
+// MoveArgumentError reports a Go value that does not fit the Move parameter it was passed for.
+type MoveArgumentError struct {
+	Function string
+	Index    int
+	Expected string
+	Value    any
+	Reason   string
+}
+
+// Error implements the error interface.
+func (e *MoveArgumentError) Error() string {
+	msg := fmt.Sprintf("move call %s: argument %d: expected %s, got %T", e.Function, e.Index, e.Expected, e.Value)
+	if e.Reason != "" {
+		msg += ": " + e.Reason
+	}
+	return msg
+}
+
+// Unwrap allows errors.Is(err, ErrMoveArgumentMismatch).
+func (e *MoveArgumentError) Unwrap() error {
+	return ErrMoveArgumentMismatch
+}
+
+// moveCallValues converts MoveCall.Values into arguments, adding an unresolved
+// value input for every element that is not already an Argument.
+func (b *Transaction) moveCallValues(values []any) []Argument {
+	args := make([]Argument, len(values))
+	for i, value := range values {
+		switch v := value.(type) {
+		case Argument:
+			args[i] = v
+		case Result:
+			args[i] = v.Arg()
+		default:
+			args[i] = b.addInput(input{UnresolvedValue: &UnresolvedValue{Value: value}})
+		}
+	}
+	return args
+}
+
+// resolveValues encodes every UnresolvedValue input against the signature of
+// the Move call that consumes it.
+func (b *Transaction) resolveValues(ctx context.Context, resolver Resolver) error {
+	for _, cmd := range b.commands {
+		call := cmd.MoveCall
+		if call == nil || !b.hasUnresolvedValues(call.Arguments) {
+			continue
+		}
+		if resolver == nil {
+			return ErrResolverRequired
+		}
+		if ctx == nil {
+			return fmt.Errorf("nil context")
+		}
+
+		name := fmt.Sprintf("%s::%s::%s", call.Package.String(), call.Module, call.Function)
+		sig, err := resolver.ResolveMoveFunction(ctx, call.Package.String(), call.Module, call.Function)
+		if err != nil {
+			return err
+		}
+		params := trimTxContext(sig.Parameters)
+		if len(params) != len(call.Arguments) {
+			return fmt.Errorf("move call %s expects %d args, got %d", name, len(params), len(call.Arguments))
+		}
+
+		for i, arg := range call.Arguments {
+			if arg.Input == nil || int(*arg.Input) >= len(b.inputs) {
+				continue
+			}
+			idx := int(*arg.Input)
+			pending := b.inputs[idx].UnresolvedValue
+			if pending == nil {
+				continue
+			}
+
+			resolved, err := encodeMoveArgument(params[i], call.TypeArguments, pending.Value)
+			if err != nil {
+				if argErr, ok := err.(*MoveArgumentError); ok {
+					argErr.Function = name
+					argErr.Index = i
+				}
+				return err
+			}
+			b.inputs[idx] = resolved
+		}
+	}
+
+	for _, in := range b.inputs {
+		if in.UnresolvedValue != nil {
+			return fmt.Errorf("%w: native values are only supported as move call arguments", ErrUnresolvedInput)
+		}
+	}
+	return nil
+}
+
+func (b *Transaction) hasUnresolvedValues(args []Argument) bool {
+	for _, arg := range args {
+		if arg.Input != nil && int(*arg.Input) < len(b.inputs) && b.inputs[*arg.Input].UnresolvedValue != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func encodeMoveArgument(param MoveParameter, typeArgs []typetag.TypeTag, value any) (input, error) {
+	if param.Body == nil {
+		return input{}, &MoveArgumentError{Expected: "a resolvable parameter type", Value: value, Reason: "resolver did not provide the parameter signature"}
+	}
+
+	sig, err := substituteTypeParameters(*param.Body, typeArgs)
+	if err != nil {
+		return input{}, &MoveArgumentError{Expected: "a concrete type", Value: value, Reason: err.Error()}
+	}
+
+	if param.Reference == ReferenceUnknown && isPureSignature(sig) {
+		encoded, err := encodePureValue(sig, value)
+		if err != nil {
+			return input{}, &MoveArgumentError{Expected: describeSignature(sig), Value: value, Reason: err.Error()}
+		}
+		return input{Pure: &Pure{Bytes: encoded}}, nil
+	}
+
+	return encodeObjectValue(param, sig, value)
+}
+
+func encodeObjectValue(param MoveParameter, sig MoveTypeSignature, value any) (input, error) {
+	expected := "object " + describeSignature(sig)
+	switch v := value.(type) {
+	case string:
+		if _, err := utils.ParseAddress(v); err != nil {
+			return input{}, &MoveArgumentError{Expected: expected, Value: value, Reason: "invalid object id"}
+		}
+		return input{UnresolvedObject: &UnresolvedObject{ObjectID: v}}, nil
+	case types.Address:
+		return input{UnresolvedObject: &UnresolvedObject{ObjectID: v.String()}}, nil
+	case types.ObjectRef:
+		ref := v
+		if isReceivingType(param) || isReceivingSignature(sig) {
+			return input{Object: &ObjectArg{Receiving: &ref}}, nil
+		}
+		return input{Object: &ObjectArg{ImmOrOwnedObject: &ref}}, nil
+	case *types.ObjectRef:
+		if v == nil {
+			break
+		}
+		return encodeObjectValue(param, sig, *v)
+	case types.SharedObjectRef:
+		ref := v
+		if param.Reference == ReferenceMutable {
+			ref.Mutable = true
+		}
+		return input{Object: &ObjectArg{SharedObject: &ref}}, nil
+	}
+
+	return input{}, &MoveArgumentError{Expected: expected, Value: value, Reason: "use an object id, types.ObjectRef or types.SharedObjectRef"}
+}
+
+func substituteTypeParameters(sig MoveTypeSignature, typeArgs []typetag.TypeTag) (MoveTypeSignature, error) {
+	if sig.Kind == MoveTypeParameter {
+		if int(sig.TypeParameter) >= len(typeArgs) {
+			return MoveTypeSignature{}, fmt.Errorf("type parameter T%d not supplied", sig.TypeParameter)
+		}
+		return signatureFromTypeTag(typeArgs[sig.TypeParameter]), nil
+	}
+
+	if len(sig.TypeArguments) == 0 {
+		return sig, nil
+	}
+	out := sig
+	out.TypeArguments = make([]MoveTypeSignature, len(sig.TypeArguments))
+	for i, arg := range sig.TypeArguments {
+		resolved, err := substituteTypeParameters(arg, typeArgs)
+		if err != nil {
+			return MoveTypeSignature{}, err
+		}
+		out.TypeArguments[i] = resolved
+	}
+	return out, nil
+}
+
+func signatureFromTypeTag(tag typetag.TypeTag) MoveTypeSignature {
+	switch {
+	case tag.Bool != nil:
+		return MoveTypeSignature{Kind: MoveTypeBool}
+	case tag.U8 != nil:
+		return MoveTypeSignature{Kind: MoveTypeU8}
+	case tag.U16 != nil:
+		return MoveTypeSignature{Kind: MoveTypeU16}
+	case tag.U32 != nil:
+		return MoveTypeSignature{Kind: MoveTypeU32}
+	case tag.U64 != nil:
+		return MoveTypeSignature{Kind: MoveTypeU64}
+	case tag.U128 != nil:
+		return MoveTypeSignature{Kind: MoveTypeU128}
+	case tag.U256 != nil:
+		return MoveTypeSignature{Kind: MoveTypeU256}
+	case tag.Address != nil:
+		return MoveTypeSignature{Kind: MoveTypeAddress}
+	case tag.Vector != nil:
+		return MoveTypeSignature{Kind: MoveTypeVector, TypeArguments: []MoveTypeSignature{signatureFromTypeTag(*tag.Vector)}}
+	case tag.Struct != nil:
+		out := MoveTypeSignature{
+			Kind:     MoveTypeDatatype,
+			TypeName: fmt.Sprintf("%s::%s::%s", tag.Struct.Address.String(), tag.Struct.Module, tag.Struct.Name),
+		}
+		for _, param := range tag.Struct.TypeParams {
+			out.TypeArguments = append(out.TypeArguments, signatureFromTypeTag(param))
+		}
+		return out
+	default:
+		return MoveTypeSignature{Kind: MoveTypeUnknown}
+	}
+}
+
+func isPureSignature(sig MoveTypeSignature) bool {
+	switch sig.Kind {
+	case MoveTypeAddress, MoveTypeBool, MoveTypeU8, MoveTypeU16, MoveTypeU32, MoveTypeU64, MoveTypeU128, MoveTypeU256:
+		return true
+	case MoveTypeVector:
+		return len(sig.TypeArguments) == 1 && isPureSignature(sig.TypeArguments[0])
+	case MoveTypeDatatype:
+		switch {
+		case isDatatype(sig, "0x1", "string", "String"), isDatatype(sig, "0x1", "ascii", "String"), isDatatype(sig, "0x2", "object", "ID"):
+			return true
+		case isDatatype(sig, "0x1", "option", "Option"):
+			return len(sig.TypeArguments) == 1 && isPureSignature(sig.TypeArguments[0])
+		}
+	}
+	return false
+}
+
+func isReceivingSignature(sig MoveTypeSignature) bool {
+	return isDatatype(sig, "0x2", "transfer", "Receiving")
+}
+
+func isDatatype(sig MoveTypeSignature, address, module, name string) bool {
+	if sig.Kind != MoveTypeDatatype {
+		return false
+	}
+	parts := strings.Split(sig.TypeName, "::")
+	if len(parts) != 3 || parts[1] != module || parts[2] != name {
+		return false
+	}
+	got, err := utils.NormalizeAddress(parts[0])
+	if err != nil {
+		return false
+	}
+	want, _ := utils.NormalizeAddress(address)
+	return got == want
+}
+
+func describeSignature(sig MoveTypeSignature) string {
+	switch sig.Kind {
+	case MoveTypeAddress:
+		return "address"
+	case MoveTypeBool:
+		return "bool"
+	case MoveTypeU8:
+		return "u8"
+	case MoveTypeU16:
+		return "u16"
+	case MoveTypeU32:
+		return "u32"
+	case MoveTypeU64:
+		return "u64"
+	case MoveTypeU128:
+		return "u128"
+	case MoveTypeU256:
+		return "u256"
+	case MoveTypeVector:
+		if len(sig.TypeArguments) == 1 {
+			return "vector<" + describeSignature(sig.TypeArguments[0]) + ">"
+		}
+		return "vector"
+	case MoveTypeDatatype:
+		if len(sig.TypeArguments) == 0 {
+			return sig.TypeName
+		}
+		args := make([]string, len(sig.TypeArguments))
+		for i, arg := range sig.TypeArguments {
+			args[i] = describeSignature(arg)
+		}
+		return sig.TypeName + "<" + strings.Join(args, ", ") + ">"
+	case MoveTypeParameter:
+		return fmt.Sprintf("T%d", sig.TypeParameter)
+	default:
+		return "unknown"
+	}
+}
+
+func encodePureValue(sig MoveTypeSignature, value any) ([]byte, error) {
+	switch sig.Kind {
+	case MoveTypeBool:
+		v, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("unsupported value")
+		}
+		return bcs.Marshal(&v)
+	case MoveTypeU8:
+		return encodeUnsigned(value, 1)
+	case MoveTypeU16:
+		return encodeUnsigned(value, 2)
+	case MoveTypeU32:
+		return encodeUnsigned(value, 4)
+	case MoveTypeU64:
+		return encodeUnsigned(value, 8)
+	case MoveTypeU128:
+		return encodeUnsigned(value, 16)
+	case MoveTypeU256:
+		return encodeUnsigned(value, 32)
+	case MoveTypeAddress:
+		return encodeAddressValue(value)
+	case MoveTypeVector:
+		return encodeVectorValue(sig.TypeArguments[0], value)
+	case MoveTypeDatatype:
+		switch {
+		case isDatatype(sig, "0x1", "string", "String"), isDatatype(sig, "0x1", "ascii", "String"):
+			s, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("unsupported value")
+			}
+			return bcs.Marshal(&s)
+		case isDatatype(sig, "0x2", "object", "ID"):
+			return encodeAddressValue(value)
+		case isDatatype(sig, "0x1", "option", "Option"):
+			return encodeOptionValue(sig.TypeArguments[0], value)
+		}
+	}
+	return nil, fmt.Errorf("type cannot be encoded as a pure value")
+}
+
+func encodeOptionValue(inner MoveTypeSignature, value any) ([]byte, error) {
+	if value == nil {
+		return []byte{0}, nil
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return []byte{0}, nil
+		}
+		if _, isBig := value.(*big.Int); !isBig {
+			value = rv.Elem().Interface()
+		}
+	}
+
+	encoded, err := encodePureValue(inner, value)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{1}, encoded...), nil
+}
+
+func encodeVectorValue(elem MoveTypeSignature, value any) ([]byte, error) {
+	if elem.Kind == MoveTypeU8 {
+		switch v := value.(type) {
+		case []byte:
+			return bcs.Marshal(&v)
+		case string:
+			return bcs.Marshal(&v)
+		}
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, fmt.Errorf("unsupported value")
+	}
+
+	enc := bcs.NewBytesEncoder()
+	enc.WriteLen(rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		encoded, err := encodePureValue(elem, rv.Index(i).Interface())
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		enc.Write(encoded)
+	}
+	return enc.Bytes(), enc.Err()
+}
+
+func encodeAddressValue(value any) ([]byte, error) {
+	switch v := value.(type) {
+	case string:
+		addr, err := utils.ParseAddress(v)
+		if err != nil {
+			return nil, err
+		}
+		return addr[:], nil
+	case types.Address:
+		return v[:], nil
+	case [32]byte:
+		return v[:], nil
+	}
+	return nil, fmt.Errorf("unsupported value")
+}
+
+// encodeUnsigned encodes any Go integer, *big.Int, types.CoinAmount or
+// decimal string as a BCS unsigned integer of size bytes, rejecting
+// out-of-range values.
+func encodeUnsigned(value any, size int) ([]byte, error) {
+	n := new(big.Int)
+	switch v := value.(type) {
+	case *big.Int:
+		if v == nil {
+			return nil, fmt.Errorf("nil integer")
+		}
+		n.Set(v)
+	case big.Int:
+		n.Set(&v)
+	case types.CoinAmount:
+		n.SetUint64(v.Raw())
+	case string:
+		if _, ok := n.SetString(v, 10); !ok {
+			return nil, fmt.Errorf("invalid integer %q", v)
+		}
+	default:
+		rv := reflect.ValueOf(value)
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			n.SetInt64(rv.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			n.SetUint64(rv.Uint())
+		default:
+			return nil, fmt.Errorf("unsupported value")
+		}
+	}
+
+	limit := u256Max
+	switch size {
+	case 16:
+		limit = u128Max
+	case 1, 2, 4, 8:
+		limit = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(size*8)), big.NewInt(1))
+	}
+	if n.Sign() < 0 || n.Cmp(limit) > 0 {
+		return nil, fmt.Errorf("value %s out of range", n.String())
+	}
+
+	switch size {
+	case 1:
+		v := uint8(n.Uint64())
+		return bcs.Marshal(&v)
+	case 2:
+		v := uint16(n.Uint64())
+		return bcs.Marshal(&v)
+	case 4:
+		v := uint32(n.Uint64())
+		return bcs.Marshal(&v)
+	case 8:
+		v := n.Uint64()
+		return bcs.Marshal(&v)
+	case 16:
+		return bcs.Marshal(n)
+	}
+	return utils.EncodeU256(n)
+}
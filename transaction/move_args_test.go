@@ -0,0 +1,140 @@
+package transaction
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	bcs "github.com/iotaledger/bcs-go"
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+func TestMoveCallValuesEncoding(t *testing.T) {
+	objectID := mustNormalize(t, "0x5")
+	digest := types.Digest(bytes.Repeat([]byte{2}, 32))
+
+	resolver := stubResolver{
+		objects: map[string]ObjectMetadata{
+			objectID: {ID: mustAddress(t, objectID), Version: 7, Digest: digest, OwnerKind: OwnerAddress},
+		},
+		move: &MoveFunction{Parameters: []MoveParameter{
+			{Reference: ReferenceMutable, TypeName: "0x2::example::Pool", Body: &MoveTypeSignature{Kind: MoveTypeDatatype, TypeName: "0x2::example::Pool"}},
+			{Body: &MoveTypeSignature{Kind: MoveTypeU64}},
+			{Body: &MoveTypeSignature{Kind: MoveTypeBool}},
+			{Body: &MoveTypeSignature{Kind: MoveTypeDatatype, TypeName: "0x1::string::String"}},
+			{Body: &MoveTypeSignature{Kind: MoveTypeVector, TypeArguments: []MoveTypeSignature{{Kind: MoveTypeAddress}}}},
+			{Body: &MoveTypeSignature{Kind: MoveTypeDatatype, TypeName: "0x1::option::Option", TypeArguments: []MoveTypeSignature{{Kind: MoveTypeParameter}}}},
+			{Body: &MoveTypeSignature{Kind: MoveTypeDatatype, TypeName: "0x2::tx_context::TxContext"}, TypeName: "0x2::tx_context::TxContext", Reference: ReferenceMutable},
+		}},
+	}
+
+	tx := New()
+	tx.MoveCall(MoveCall{
+		Target:        "0x2::example::deposit",
+		TypeArguments: []string{"u128"},
+		Values:        []any{"0x5", 42, true, "hello", []string{"0x1", "0x2"}, big.NewInt(9)},
+	})
+
+	result, err := tx.Build(context.Background(), BuildOptions{Resolver: resolver})
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+
+	inputs := result.ResolvedInputArgs
+	if len(inputs) != 6 {
+		t.Fatalf("expected 6 inputs, got %d", len(inputs))
+	}
+	if inputs[0].Object == nil || inputs[0].Object.ImmOrOwnedObject == nil || inputs[0].Object.ImmOrOwnedObject.Version != 7 {
+		t.Fatalf("expected owned object input")
+	}
+
+	u64 := uint64(42)
+	str := "hello"
+	addrs := []types.Address{mustAddress(t, "0x1"), mustAddress(t, "0x2")}
+	want := [][]byte{
+		mustBCS(t, &u64),
+		{1},
+		mustBCS(t, &str),
+		mustBCS(t, &addrs),
+		append([]byte{1}, append([]byte{9}, make([]byte, 15)...)...),
+	}
+	for i, expected := range want {
+		got := inputs[i+1].Pure
+		if got == nil || !bytes.Equal(got.Bytes, expected) {
+			t.Fatalf("input %d: got %v want %v", i+1, got, expected)
+		}
+	}
+}
+
+func TestMoveCallValuesMismatch(t *testing.T) {
+	resolver := stubResolver{move: &MoveFunction{Parameters: []MoveParameter{
+		{Body: &MoveTypeSignature{Kind: MoveTypeU8}},
+	}}}
+
+	tx := New()
+	tx.MoveCall(MoveCall{Target: "0x2::example::f", Values: []any{300}})
+
+	_, err := tx.Build(context.Background(), BuildOptions{Resolver: resolver})
+	var argErr *MoveArgumentError
+	if !errors.As(err, &argErr) || !errors.Is(err, ErrMoveArgumentMismatch) || argErr.Expected != "u8" {
+		t.Fatalf("expected u8 mismatch error, got %v", err)
+	}
+
+	tx = New()
+	tx.MoveCall(MoveCall{Target: "0x2::example::f", Values: []any{"not a number"}})
+	if _, err := tx.Build(context.Background(), BuildOptions{Resolver: resolver}); !errors.Is(err, ErrMoveArgumentMismatch) {
+		t.Fatalf("expected mismatch for string, got %v", err)
+	}
+}
+
+func TestMoveCallValuesRequireResolver(t *testing.T) {
+	tx := New()
+	tx.MoveCall(MoveCall{Target: "0x2::example::f", Values: []any{uint64(1)}})
+	if _, err := tx.Build(context.Background(), BuildOptions{}); !errors.Is(err, ErrResolverRequired) {
+		t.Fatalf("expected ErrResolverRequired, got %v", err)
+	}
+}
+
+func TestEncodeUnsignedWidths(t *testing.T) {
+	u8, u16, u32 := uint8(200), uint16(0x1234), uint32(0xdeadbeef)
+	u256 := new(big.Int).Lsh(big.NewInt(1), 200)
+	u256Bytes, err := utils.EncodeU256(u256)
+	if err != nil {
+		t.Fatalf("encode u256: %v", err)
+	}
+
+	for _, tc := range []struct {
+		kind  MoveTypeKind
+		value any
+		want  []byte
+	}{
+		{MoveTypeU8, 200, mustBCS(t, &u8)},
+		{MoveTypeU16, "4660", mustBCS(t, &u16)},
+		{MoveTypeU32, uint64(0xdeadbeef), mustBCS(t, &u32)},
+		{MoveTypeU256, u256, u256Bytes},
+	} {
+		got, err := encodePureValue(MoveTypeSignature{Kind: tc.kind}, tc.value)
+		if err != nil {
+			t.Fatalf("encode %v: %v", tc.value, err)
+		}
+		if !bytes.Equal(got, tc.want) {
+			t.Fatalf("encode %v: got %x want %x", tc.value, got, tc.want)
+		}
+	}
+
+	if _, err := encodePureValue(MoveTypeSignature{Kind: MoveTypeU8}, 256); err == nil {
+		t.Fatal("expected out of range u8 to fail")
+	}
+}
+
+func mustBCS[T any](t *testing.T, value *T) []byte {
+	t.Helper()
+	encoded, err := bcs.Marshal(value)
+	if err != nil {
+		t.Fatalf("bcs marshal: %v", err)
+	}
+	return encoded
+}
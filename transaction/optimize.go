@@ -0,0 +1,232 @@
+package transaction
+
+import (
+	"bytes"
+	"slices"
+
+	"github.com/open-move/sui-go-sdk/types"
+)
+
+// Optimize shrinks the transaction before it is built. It removes duplicate
+// inputs, pointing every use at the first copy, and merges consecutive
+// SplitCoins commands on the same coin into one. Sui rejects transactions
+// that list the same object twice, so deduplication is also required when a
+// helper adds an object the caller already added.
+//
+// Identical pure inputs share one input. Avoid Optimize when a Move call
+// takes a pure input by mutable reference and another command reads the same
+// value.
+//
+// Optimize renumbers inputs and commands, so it must be called after the last
+// command is added: Arguments and Results obtained earlier are not valid
+// afterwards.
+func (b *Transaction) Optimize() *Transaction {
+	if b == nil || b.err != nil {
+		return b
+	}
+	b.dedupeInputs()
+	b.mergeSplitCoins()
+	return b
+}
+
+// dedupeInputs drops inputs equal to an earlier input and remaps arguments.
+func (b *Transaction) dedupeInputs() {
+	remap := make([]uint16, len(b.inputs))
+	kept := b.inputs[:0:0]
+	for i, in := range b.inputs {
+		remap[i] = uint16(len(kept))
+		for j, existing := range kept {
+			if merged, ok := mergeInputs(existing, in); ok {
+				kept[j] = merged
+				remap[i] = uint16(j)
+				break
+			}
+		}
+		if int(remap[i]) == len(kept) {
+			kept = append(kept, in)
+		}
+	}
+	if len(kept) == len(b.inputs) {
+		return
+	}
+
+	b.inputs = kept
+	b.mapArguments(func(arg Argument) Argument {
+		if arg.Input == nil {
+			return arg
+		}
+		idx := remap[*arg.Input]
+		return Argument{Input: &idx}
+	})
+}
+
+// mergeInputs returns the input to use for both a and b when they refer to the
+// same value.
+func mergeInputs(a, b input) (input, bool) {
+	switch {
+	case a.Pure != nil && b.Pure != nil:
+		return a, bytes.Equal(a.Pure.Bytes, b.Pure.Bytes)
+	case a.UnresolvedObject != nil && b.UnresolvedObject != nil:
+		return a, a.UnresolvedObject.ObjectID == b.UnresolvedObject.ObjectID
+	case a.Object != nil && b.Object != nil:
+		return mergeObjectArgs(a, *a.Object, *b.Object)
+	}
+	// Unresolved values are encoded per use, so equal Go values may still
+	// need different encodings.
+	return input{}, false
+}
+
+func mergeObjectArgs(a input, x, y ObjectArg) (input, bool) {
+	switch {
+	case x.ImmOrOwnedObject != nil && y.ImmOrOwnedObject != nil:
+		return a, objectRefsEqual(*x.ImmOrOwnedObject, *y.ImmOrOwnedObject)
+	case x.Receiving != nil && y.Receiving != nil:
+		return a, objectRefsEqual(*x.Receiving, *y.Receiving)
+	case x.SharedObject != nil && y.SharedObject != nil:
+		s, t := *x.SharedObject, *y.SharedObject
+		if s.ObjectID != t.ObjectID || s.InitialSharedVersion != t.InitialSharedVersion {
+			return input{}, false
+		}
+		// A shared object used mutably anywhere must be declared mutable.
+		s.Mutable = s.Mutable || t.Mutable
+		return input{Object: &ObjectArg{SharedObject: &s}}, true
+	}
+	return input{}, false
+}
+
+func objectRefsEqual(x, y types.ObjectRef) bool {
+	return x.ObjectID == y.ObjectID && x.Version == y.Version && bytes.Equal(x.Digest, y.Digest)
+}
+
+// mergeSplitCoins folds each SplitCoins command into the one before it when
+// both split the same coin. The merge is skipped when either command's result
+// is used as a whole, since that requires a single-result command.
+func (b *Transaction) mergeSplitCoins() {
+	for i := 0; i+1 < len(b.commands); {
+		first, second := b.commands[i].SplitCoins, b.commands[i+1].SplitCoins
+		if first == nil || second == nil || !argumentsEqual(first.Coin, second.Coin) ||
+			b.usesWholeResult(i) || b.usesWholeResult(i+1) ||
+			slices.ContainsFunc(second.Amounts, func(arg Argument) bool { return b.referencesCommand(arg, i) }) {
+			i++
+			continue
+		}
+
+		offset := uint16(len(first.Amounts))
+		merged := SplitCoins{
+			Coin:    first.Coin,
+			Amounts: append(slices.Clone(first.Amounts), second.Amounts...),
+		}
+		b.commands[i] = Command{SplitCoins: &merged}
+		b.commands = slices.Delete(b.commands, i+1, i+2)
+		b.shiftPackageNames(i + 1)
+
+		removed := uint16(i + 1)
+		b.mapArguments(func(arg Argument) Argument {
+			if arg.NestedResult == nil {
+				if arg.Result != nil && *arg.Result > removed {
+					idx := *arg.Result - 1
+					return Argument{Result: &idx}
+				}
+				return arg
+			}
+			nested := *arg.NestedResult
+			switch {
+			case nested.Index == removed:
+				nested = NestedResult{Index: uint16(i), ResultIndex: offset + nested.ResultIndex}
+			case nested.Index > removed:
+				nested.Index--
+			}
+			return Argument{NestedResult: &nested}
+		})
+	}
+}
+
+// shiftPackageNames renumbers pending package names after command removed
+// is deleted.
+func (b *Transaction) shiftPackageNames(removed int) {
+	if len(b.packageNames) == 0 {
+		return
+	}
+	shifted := make(map[int]string, len(b.packageNames))
+	for idx, name := range b.packageNames {
+		if idx > removed {
+			idx--
+		}
+		shifted[idx] = name
+	}
+	b.packageNames = shifted
+}
+
+// referencesCommand reports whether arg is a result of command index.
+func (b *Transaction) referencesCommand(arg Argument, index int) bool {
+	return (arg.Result != nil && int(*arg.Result) == index) ||
+		(arg.NestedResult != nil && int(arg.NestedResult.Index) == index)
+}
+
+func (b *Transaction) usesWholeResult(index int) bool {
+	used := false
+	b.visitArguments(func(arg Argument) {
+		if arg.Result != nil && int(*arg.Result) == index {
+			used = true
+		}
+	})
+	return used
+}
+
+func (b *Transaction) visitArguments(fn func(Argument)) {
+	b.mapArguments(func(arg Argument) Argument {
+		fn(arg)
+		return arg
+	})
+}
+
+// mapArguments replaces every argument of every command with fn(arg).
+func (b *Transaction) mapArguments(fn func(Argument) Argument) {
+	mapAll := func(args []Argument) []Argument {
+		out := make([]Argument, len(args))
+		for i, arg := range args {
+			out[i] = fn(arg)
+		}
+		return out
+	}
+
+	for i, cmd := range b.commands {
+		switch {
+		case cmd.MoveCall != nil:
+			call := *cmd.MoveCall
+			call.Arguments = mapAll(call.Arguments)
+			b.commands[i] = Command{MoveCall: &call}
+		case cmd.TransferObjects != nil:
+			transfer := TransferObjects{Objects: mapAll(cmd.TransferObjects.Objects), Address: fn(cmd.TransferObjects.Address)}
+			b.commands[i] = Command{TransferObjects: &transfer}
+		case cmd.SplitCoins != nil:
+			split := SplitCoins{Coin: fn(cmd.SplitCoins.Coin), Amounts: mapAll(cmd.SplitCoins.Amounts)}
+			b.commands[i] = Command{SplitCoins: &split}
+		case cmd.MergeCoins != nil:
+			merge := MergeCoins{Destination: fn(cmd.MergeCoins.Destination), Sources: mapAll(cmd.MergeCoins.Sources)}
+			b.commands[i] = Command{MergeCoins: &merge}
+		case cmd.MakeMoveVec != nil:
+			vec := *cmd.MakeMoveVec
+			vec.Elements = mapAll(vec.Elements)
+			b.commands[i] = Command{MakeMoveVec: &vec}
+		case cmd.Upgrade != nil:
+			upgrade := *cmd.Upgrade
+			upgrade.Ticket = fn(upgrade.Ticket)
+			b.commands[i] = Command{Upgrade: &upgrade}
+		}
+	}
+}
+
+func argumentsEqual(x, y Argument) bool {
+	switch {
+	case x.GasCoin != nil || y.GasCoin != nil:
+		return x.GasCoin != nil && y.GasCoin != nil
+	case x.Input != nil || y.Input != nil:
+		return x.Input != nil && y.Input != nil && *x.Input == *y.Input
+	case x.Result != nil || y.Result != nil:
+		return x.Result != nil && y.Result != nil && *x.Result == *y.Result
+	case x.NestedResult != nil || y.NestedResult != nil:
+		return x.NestedResult != nil && y.NestedResult != nil && *x.NestedResult == *y.NestedResult
+	}
+	return true
+}
@@ -0,0 +1,89 @@
+package transaction
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/types"
+)
+
+func TestOptimizeDedupesInputs(t *testing.T) {
+	digest := types.Digest(bytes.Repeat([]byte{1}, 32))
+	coin := types.ObjectRef{ObjectID: mustAddress(t, "0x1"), Version: 1, Digest: digest}
+	pool := types.SharedObjectRef{ObjectID: mustAddress(t, "0x2"), InitialSharedVersion: 5}
+
+	tx := New()
+	tx.MergeCoins(MergeCoins{Destination: tx.Gas(), Sources: []Argument{tx.ObjectRef(coin)}})
+	tx.TransferObjects(TransferObjects{Objects: []Argument{tx.ObjectRef(coin)}, Address: tx.PureAddress("0xa")})
+	tx.TransferObjects(TransferObjects{Objects: []Argument{tx.Gas()}, Address: tx.PureAddress("0xa")})
+
+	writable := pool
+	writable.Mutable = true
+	tx.MergeCoins(MergeCoins{Destination: tx.SharedObject(pool), Sources: []Argument{tx.SharedObject(writable)}})
+	tx.Optimize()
+
+	result, err := tx.Build(context.Background(), BuildOptions{})
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	inputs := result.ProgrammableKind.Inputs
+	if len(inputs) != 3 {
+		t.Fatalf("expected 3 inputs, got %d", len(inputs))
+	}
+	if shared := inputs[2].Object.SharedObject; shared == nil || !shared.Mutable {
+		t.Fatalf("expected merged shared input to be mutable, got %+v", inputs[2])
+	}
+
+	commands := result.ProgrammableKind.Commands
+	if got := *commands[1].TransferObjects.Objects[0].Input; got != 0 {
+		t.Fatalf("expected coin to be input 0, got %d", got)
+	}
+	if got := *commands[2].TransferObjects.Address.Input; got != 1 {
+		t.Fatalf("expected recipient to be input 1, got %d", got)
+	}
+	if *commands[3].MergeCoins.Destination.Input != 2 || *commands[3].MergeCoins.Sources[0].Input != 2 {
+		t.Fatal("expected both shared uses to point at input 2")
+	}
+}
+
+func TestOptimizeMergesSplitCoins(t *testing.T) {
+	tx := New()
+	first := tx.SplitCoins(SplitCoins{Coin: tx.Gas(), Amounts: []Argument{tx.PureU64(10)}})
+	second := tx.SplitCoins(SplitCoins{Coin: tx.Gas(), Amounts: []Argument{tx.PureU64(20), tx.PureU64(10)}})
+	tx.TransferObjects(TransferObjects{Objects: []Argument{first[0], second[0], second[1]}, Address: tx.PureAddress("0xa")})
+	tx.Optimize()
+
+	result, err := tx.Build(context.Background(), BuildOptions{})
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	if n := len(result.ProgrammableKind.Inputs); n != 3 {
+		t.Fatalf("expected 3 inputs, got %d", n)
+	}
+	commands := result.ProgrammableKind.Commands
+	if len(commands) != 2 {
+		t.Fatalf("expected 2 commands, got %d", len(commands))
+	}
+	if n := len(commands[0].SplitCoins.Amounts); n != 3 {
+		t.Fatalf("expected 3 amounts, got %d", n)
+	}
+	for i, obj := range commands[1].TransferObjects.Objects {
+		if obj.NestedResult == nil || obj.NestedResult.Index != 0 || obj.NestedResult.ResultIndex != uint16(i) {
+			t.Fatalf("object %d: unexpected argument %+v", i, obj)
+		}
+	}
+}
+
+func TestOptimizeKeepsWholeResultSplits(t *testing.T) {
+	tx := New()
+	tx.SplitCoins(SplitCoins{Coin: tx.Gas(), Amounts: []Argument{tx.PureU64(10)}})
+	idx := uint16(0)
+	tx.SplitCoins(SplitCoins{Coin: tx.Gas(), Amounts: []Argument{tx.PureU64(20)}})
+	tx.TransferObjects(TransferObjects{Objects: []Argument{{Result: &idx}}, Address: tx.PureAddress("0xa")})
+	tx.Optimize()
+
+	if n := len(tx.commands); n != 3 {
+		t.Fatalf("expected splits to stay separate, got %d commands", n)
+	}
+}
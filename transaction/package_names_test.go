@@ -0,0 +1,61 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type stubNameResolver map[string]string
+
+func (s stubNameResolver) ResolvePackageName(_ context.Context, name string) (string, error) {
+	address, ok := s[name]
+	if !ok {
+		return "", errors.New("unknown name")
+	}
+	return address, nil
+}
+
+func TestBuildResolvesPackageNames(t *testing.T) {
+	tx := New()
+	coin := tx.SplitCoins(SplitCoins{Coin: tx.Gas(), Amounts: []Argument{tx.PureU64(1)}})
+	tx.SplitCoins(SplitCoins{Coin: tx.Gas(), Amounts: []Argument{tx.PureU64(2)}})
+	tx.MoveCall(MoveCall{Target: "@suifrens/core::mint::mint", Arguments: []Argument{coin[0]}})
+	tx.MoveCall(MoveCall{Target: "0x2::coin::zero", TypeArguments: []string{"0x2::sui::SUI"}})
+	tx.MoveCall(MoveCall{Target: "suifrens.sui/core::mint::burn"})
+	tx.Optimize()
+
+	if _, err := tx.Build(context.Background(), BuildOptions{}); !errors.Is(err, ErrNameResolverRequired) {
+		t.Fatalf("expected ErrNameResolverRequired, got %v", err)
+	}
+
+	resolver := stubNameResolver{"@suifrens/core": "0x5", "suifrens.sui/core": "0x6"}
+	result, err := tx.Build(context.Background(), BuildOptions{NameResolver: resolver})
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	commands := result.ProgrammableKind.Commands
+	if len(commands) != 4 {
+		t.Fatalf("expected the split coins to merge, got %d commands", len(commands))
+	}
+	for i, want := range map[int]string{1: "0x5", 2: "0x2", 3: "0x6"} {
+		if got := commands[i].MoveCall.Package; got != mustAddress(t, want) {
+			t.Fatalf("command %d: expected package %s, got %s", i, want, got)
+		}
+	}
+}
+
+func TestResolveMoveCallTarget(t *testing.T) {
+	opts := BuildOptions{NameResolver: stubNameResolver{"@suifrens/core": "0x5"}}
+
+	got, err := ResolveMoveCallTarget(context.Background(), opts, "@suifrens/core::mint::mint")
+	if err != nil || got != "0x5::mint::mint" {
+		t.Fatalf("unexpected target %q (%v)", got, err)
+	}
+	if got, _ := ResolveMoveCallTarget(context.Background(), BuildOptions{}, "0x2::coin::zero"); got != "0x2::coin::zero" {
+		t.Fatalf("address target changed to %q", got)
+	}
+	if _, err := ResolveMoveCallTarget(context.Background(), opts, "@other/app::m::f"); err == nil {
+		t.Fatal("expected an error for an unknown name")
+	}
+}
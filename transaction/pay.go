@@ -0,0 +1,99 @@
+package transaction
+
+import (
+	"fmt"
+
+	"github.com/open-move/sui-go-sdk/types"
+)
+
+// PaySui splits each amount off the gas coin and transfers it to the matching
+// recipient, mirroring `sui client pay-sui`. The coins paying for the transfer
+// are the gas payment, so callers should set it (or let a GasResolver pick it)
+// to cover the sum of amounts plus the gas budget.
+func (b *Transaction) PaySui(recipients []string, amounts []uint64) {
+	if b == nil || b.err != nil {
+		return
+	}
+	if len(recipients) == 0 {
+		b.setErr(ErrNoRecipients)
+		return
+	}
+	if len(recipients) != len(amounts) {
+		b.setErr(ErrPayLengthMismatch)
+		return
+	}
+
+	amountArgs := make([]Argument, len(amounts))
+	for i, amount := range amounts {
+		amountArgs[i] = b.PureU64(amount)
+	}
+
+	coins := b.SplitCoins(SplitCoins{Coin: b.Gas(), Amounts: amountArgs})
+	if b.err != nil {
+		return
+	}
+
+	for i, recipient := range recipients {
+		b.TransferObjects(TransferObjects{
+			Objects: []Argument{coins[i]},
+			Address: b.PureAddress(recipient),
+		})
+	}
+}
+
+// PaySuiAmounts is PaySui with typed amounts. Every amount must be a SUI
+// amount (9 decimals), so a value parsed for another coin cannot be sent as
+// MIST by mistake.
+func (b *Transaction) PaySuiAmounts(recipients []string, amounts []types.CoinAmount) {
+	if b == nil || b.err != nil {
+		return
+	}
+	mist := make([]uint64, len(amounts))
+	for i, amount := range amounts {
+		if amount.Decimals() != types.SUIDecimals {
+			b.setErr(fmt.Errorf("amount %d: %w: SUI has %d, got %d", i, types.ErrDecimalMismatch, types.SUIDecimals, amount.Decimals()))
+			return
+		}
+		mist[i] = amount.MIST()
+	}
+	b.PaySui(recipients, mist)
+}
+
+// PayAllSui transfers the entire gas coin to recipient, mirroring
+// `sui client pay-all-sui`. All gas payment coins are merged into the gas coin
+// before execution, so the recipient receives their combined balance minus gas.
+func (b *Transaction) PayAllSui(recipient string) {
+	if b == nil || b.err != nil {
+		return
+	}
+
+	b.TransferObjects(TransferObjects{
+		Objects: []Argument{b.Gas()},
+		Address: b.PureAddress(recipient),
+	})
+}
+
+// ConsolidateCoins merges every coin into the first one and returns the argument
+// referencing the surviving coin. Use it for non-SUI coin types; SUI coins are
+// consolidated by listing them as gas payment.
+func (b *Transaction) ConsolidateCoins(coins []types.ObjectRef) Argument {
+	if b == nil || b.err != nil {
+		return Argument{}
+	}
+	if len(coins) == 0 {
+		b.setErr(ErrNoCoins)
+		return Argument{}
+	}
+
+	primary := b.ObjectRef(coins[0])
+	if len(coins) == 1 {
+		return primary
+	}
+
+	sources := make([]Argument, 0, len(coins)-1)
+	for _, coin := range coins[1:] {
+		sources = append(sources, b.ObjectRef(coin))
+	}
+	b.MergeCoins(MergeCoins{Destination: primary, Sources: sources})
+	return primary
+}
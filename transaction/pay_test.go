@@ -0,0 +1,94 @@
+package transaction
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/types"
+)
+
+func TestPaySuiCommands(t *testing.T) {
+	tx := New()
+	tx.PaySui([]string{"0x1", "0x2"}, []uint64{10, 20})
+
+	result, err := tx.Build(context.Background(), BuildOptions{})
+	if err != nil {
+		t.Fatalf("build pay sui: %v", err)
+	}
+
+	commands := result.ProgrammableKind.Commands
+	if len(commands) != 3 {
+		t.Fatalf("expected 3 commands, got %d", len(commands))
+	}
+	if commands[0].SplitCoins == nil || commands[0].SplitCoins.Coin.GasCoin == nil {
+		t.Fatalf("expected split from gas coin")
+	}
+	for i, cmd := range commands[1:] {
+		if cmd.TransferObjects == nil {
+			t.Fatalf("command %d: expected transfer", i+1)
+		}
+		nested := cmd.TransferObjects.Objects[0].NestedResult
+		if nested == nil || nested.Index != 0 || nested.ResultIndex != uint16(i) {
+			t.Fatalf("command %d: unexpected transferred argument", i+1)
+		}
+	}
+}
+
+func TestPaySuiLengthMismatch(t *testing.T) {
+	tx := New()
+	tx.PaySui([]string{"0x1"}, []uint64{1, 2})
+	if !errors.Is(tx.Err(), ErrPayLengthMismatch) {
+		t.Fatalf("expected ErrPayLengthMismatch, got %v", tx.Err())
+	}
+}
+
+func TestPaySuiAmounts(t *testing.T) {
+	tx := New()
+	tx.PaySuiAmounts([]string{"0x1"}, []types.CoinAmount{types.MustFromSUI("1.5")})
+
+	result, err := tx.Build(context.Background(), BuildOptions{})
+	if err != nil {
+		t.Fatalf("build pay sui: %v", err)
+	}
+	want := types.FromMIST(1_500_000_000).MIST()
+	var encoded [8]byte
+	for i := range encoded {
+		encoded[i] = byte(want >> (8 * i))
+	}
+	if pure := result.ProgrammableKind.Inputs[0].Pure; pure == nil || !bytes.Equal(pure.Bytes, encoded[:]) {
+		t.Fatalf("unexpected amount input %+v", result.ProgrammableKind.Inputs[0])
+	}
+
+	tx = New()
+	tx.PaySuiAmounts([]string{"0x1"}, []types.CoinAmount{types.NewCoinAmount(1_000_000, 6)})
+	if !errors.Is(tx.Err(), types.ErrDecimalMismatch) {
+		t.Fatalf("expected ErrDecimalMismatch, got %v", tx.Err())
+	}
+}
+
+func TestConsolidateCoins(t *testing.T) {
+	digest := types.Digest(bytes.Repeat([]byte{1}, 32))
+	coins := []types.ObjectRef{
+		{ObjectID: mustAddress(t, "0x1"), Version: 1, Digest: digest},
+		{ObjectID: mustAddress(t, "0x2"), Version: 1, Digest: digest},
+		{ObjectID: mustAddress(t, "0x3"), Version: 1, Digest: digest},
+	}
+
+	tx := New()
+	primary := tx.ConsolidateCoins(coins)
+
+	result, err := tx.Build(context.Background(), BuildOptions{})
+	if err != nil {
+		t.Fatalf("build consolidate: %v", err)
+	}
+	if primary.Input == nil || *primary.Input != 0 {
+		t.Fatalf("expected primary coin to be input 0")
+	}
+
+	commands := result.ProgrammableKind.Commands
+	if len(commands) != 1 || commands[0].MergeCoins == nil || len(commands[0].MergeCoins.Sources) != 2 {
+		t.Fatalf("expected a single merge of two sources")
+	}
+}
@@ -0,0 +1,30 @@
+package transaction
+
+import "github.com/open-move/sui-go-sdk/types"
+
+// ReceiveObject calls target with parent and a Receiving ticket for an object
+// that was sent to parent with transfer::transfer, following the
+// transfer::receive pattern. target ("package::module::function") must take
+// the parent object and a 0x2::transfer::Receiving<T> as its first two
+// parameters and call transfer::receive (or public_receive) on them; extra
+// arguments follow. typeArguments are passed to target, usually the type of
+// the received object. The returned Result is target's result.
+//
+// parent is usually tx.Object(id) for an owned or shared parent. The
+// receiving reference must name the object's current version and digest.
+func (b *Transaction) ReceiveObject(parent Argument, receiving types.ObjectRef, target string, typeArguments []string, extra ...Argument) Result {
+	if b == nil || b.err != nil {
+		return Result{}
+	}
+	if len(receiving.Digest) != 32 {
+		b.setErr(ErrInvalidDigest)
+		return Result{}
+	}
+
+	ticket := b.ReceivingObject(receiving)
+	return b.MoveCall(MoveCall{
+		Target:        target,
+		TypeArguments: typeArguments,
+		Arguments:     append([]Argument{parent, ticket}, extra...),
+	})
+}
@@ -0,0 +1,64 @@
+package transaction
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/types"
+)
+
+func TestReceiveObject(t *testing.T) {
+	digest := types.Digest(bytes.Repeat([]byte{1}, 32))
+	parent := types.ObjectRef{ObjectID: mustAddress(t, "0x10"), Version: 3, Digest: digest}
+	received := types.ObjectRef{ObjectID: mustAddress(t, "0x20"), Version: 7, Digest: digest}
+
+	tx := New()
+	coin := tx.ReceiveObject(tx.ObjectRef(parent), received, "0x5::wallet::accept", []string{"0x2::coin::Coin<0x2::sui::SUI>"})
+	tx.TransferObjects(TransferObjects{Objects: []Argument{coin.Arg()}, Address: tx.PureAddress("0x2")})
+
+	result, err := tx.Build(context.Background(), BuildOptions{})
+	if err != nil {
+		t.Fatalf("build receive: %v", err)
+	}
+
+	inputs := result.ProgrammableKind.Inputs
+	if len(inputs) != 3 || inputs[1].Object == nil || inputs[1].Object.Receiving == nil {
+		t.Fatalf("expected receiving input at index 1, got %+v", inputs)
+	}
+	if got := *inputs[1].Object.Receiving; got.ObjectID != received.ObjectID || got.Version != 7 {
+		t.Fatalf("unexpected receiving ref %+v", got)
+	}
+
+	call := result.ProgrammableKind.Commands[0].MoveCall
+	if call == nil || call.Function != "accept" || len(call.TypeArguments) != 1 || len(call.Arguments) != 2 {
+		t.Fatalf("unexpected move call %+v", call)
+	}
+	if *call.Arguments[0].Input != 0 || *call.Arguments[1].Input != 1 {
+		t.Fatalf("expected parent then receiving ticket, got %+v", call.Arguments)
+	}
+}
+
+func TestReceiveObjectInvalidDigest(t *testing.T) {
+	tx := New()
+	tx.ReceiveObject(tx.Object("0x10"), types.ObjectRef{ObjectID: mustAddress(t, "0x20"), Version: 1}, "0x5::wallet::accept", nil)
+	if !errors.Is(tx.Err(), ErrInvalidDigest) {
+		t.Fatalf("expected ErrInvalidDigest, got %v", tx.Err())
+	}
+}
+
+func TestIsReceivingType(t *testing.T) {
+	for name, want := range map[string]bool{
+		"0x2::transfer::Receiving":                                 true,
+		"0x2::transfer::Receiving<0x2::coin::Coin<0x2::sui::SUI>>": true,
+		"0x0000000000000000000000000000000000000000000000000000000000000002::transfer::Receiving<0x3::staking::Stake>": true,
+		"0x3::transfer::Receiving": false,
+		"0x2::coin::Coin":          false,
+		"":                         false,
+	} {
+		if got := isReceivingType(MoveParameter{TypeName: name}); got != want {
+			t.Errorf("isReceivingType(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
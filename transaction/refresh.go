@@ -0,0 +1,93 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/open-move/sui-go-sdk/types"
+)
+
+// versionConflictMarkers are fragments of the error messages validators
+// return when an owned object version is stale or locked by another
+// transaction.
+var versionConflictMarkers = []string{
+	"ObjectVersionUnavailableForConsumption",
+	"not available for consumption",
+	"ObjectLockConflict",
+	"already locked",
+	"equivocat",
+}
+
+// IsStaleObjectError reports whether err looks like a rejection caused by an
+// outdated or locked object reference, which RefreshObjectRefs can fix once
+// the other transaction has settled.
+func IsStaleObjectError(err error) bool {
+	if err == nil {
+		return false
+	}
+	message := err.Error()
+	for _, marker := range versionConflictMarkers {
+		if strings.Contains(message, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// RefreshObjectRefs re-fetches the version and digest of every owned,
+// immutable and receiving object input and of every gas payment coin, so
+// references captured earlier do not fail execution once the objects have
+// moved on. Call it right before Build. Resolvers that cache objects must be
+// fresh for the refresh to have an effect.
+func (b *Transaction) RefreshObjectRefs(ctx context.Context, resolver Resolver) error {
+	if b == nil {
+		return ErrNilTransaction
+	}
+	if b.err != nil {
+		return b.err
+	}
+	if resolver == nil {
+		return ErrResolverRequired
+	}
+
+	var refs []*types.ObjectRef
+	for i := range b.inputs {
+		obj := b.inputs[i].Object
+		switch {
+		case obj == nil:
+		case obj.ImmOrOwnedObject != nil:
+			refs = append(refs, obj.ImmOrOwnedObject)
+		case obj.Receiving != nil:
+			refs = append(refs, obj.Receiving)
+		}
+	}
+	for i := range b.gas.Payment {
+		refs = append(refs, &b.gas.Payment[i])
+	}
+	if len(refs) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(refs))
+	for i, ref := range refs {
+		ids[i] = ref.ObjectID.String()
+	}
+	metadata, err := resolver.ResolveObjects(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("refresh object refs: %w", err)
+	}
+	if len(metadata) != len(refs) {
+		return fmt.Errorf("refresh object refs: resolver returned %d objects for %d ids", len(metadata), len(refs))
+	}
+
+	for i, ref := range refs {
+		meta := metadata[i]
+		if meta.ID != ref.ObjectID {
+			return fmt.Errorf("refresh object refs: resolver returned %s for %s", meta.ID, ref.ObjectID)
+		}
+		ref.Version = meta.Version
+		ref.Digest = meta.Digest
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package transaction
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/types"
+)
+
+func TestRefreshObjectRefs(t *testing.T) {
+	digest := func(b byte) types.Digest { return types.Digest(bytes.Repeat([]byte{b}, 32)) }
+
+	resolver := stubResolver{objects: map[string]ObjectMetadata{
+		mustNormalize(t, "0x1"): {ID: mustAddress(t, "0x1"), Version: 10, Digest: digest(10)},
+		mustNormalize(t, "0x2"): {ID: mustAddress(t, "0x2"), Version: 20, Digest: digest(20)},
+		mustNormalize(t, "0x3"): {ID: mustAddress(t, "0x3"), Version: 30, Digest: digest(30)},
+	}}
+
+	tx := New()
+	owned := tx.ObjectRef(types.ObjectRef{ObjectID: mustAddress(t, "0x1"), Version: 1, Digest: digest(1)})
+	tx.ReceivingObject(types.ObjectRef{ObjectID: mustAddress(t, "0x2"), Version: 2, Digest: digest(2)})
+	tx.PureU64(5)
+	tx.SetGasPayment([]types.ObjectRef{{ObjectID: mustAddress(t, "0x3"), Version: 3, Digest: digest(3)}})
+	tx.TransferObjects(TransferObjects{Objects: []Argument{owned}, Address: tx.PureAddress("0x9")})
+
+	if err := tx.RefreshObjectRefs(context.Background(), resolver); err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+
+	if ref := tx.inputs[0].Object.ImmOrOwnedObject; ref.Version != 10 || !bytes.Equal(ref.Digest, digest(10)) {
+		t.Fatalf("owned input not refreshed: %+v", ref)
+	}
+	if ref := tx.inputs[1].Object.Receiving; ref.Version != 20 {
+		t.Fatalf("receiving input not refreshed: %+v", ref)
+	}
+	if ref := tx.gas.Payment[0]; ref.Version != 30 {
+		t.Fatalf("gas payment not refreshed: %+v", ref)
+	}
+
+	if err := tx.RefreshObjectRefs(context.Background(), nil); !errors.Is(err, ErrResolverRequired) {
+		t.Fatalf("expected ErrResolverRequired, got %v", err)
+	}
+}
+
+func TestIsStaleObjectError(t *testing.T) {
+	stale := fmt.Errorf("execute: %w", errors.New("Object ID 0x1 Version 0x3 Digest abc is not available for consumption, current version: 0x4"))
+	if !IsStaleObjectError(stale) {
+		t.Fatal("expected stale object error")
+	}
+	if !IsStaleObjectError(errors.New("ObjectLockConflict: object 0x1 is already locked")) {
+		t.Fatal("expected a lock conflict to count as stale")
+	}
+	if IsStaleObjectError(errors.New("insufficient gas")) || IsStaleObjectError(nil) {
+		t.Fatal("unexpected stale object error")
+	}
+}
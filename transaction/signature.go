@@ -14,7 +14,7 @@ const (
 // UserSignatureFromSerialized parses a serialized signature in the form
 // `flag || signature || publicKey` and produces a gRPC UserSignature.
 func UserSignatureFromSerialized(serialized []byte) (*v2.UserSignature, error) {
-	scheme, sig, pub, err := parseSerializedSignature(serialized)
+	scheme, sig, pub, err := ParseSerializedSignature(serialized)
 	if err != nil {
 		return nil, err
 	}
@@ -35,7 +35,10 @@ func UserSignatureFromSerialized(serialized []byte) (*v2.UserSignature, error) {
 	}, nil
 }
 
-func parseSerializedSignature(serialized []byte) (keychain.Scheme, []byte, []byte, error) {
+// ParseSerializedSignature splits a serialized `flag || signature || publicKey`
+// signature into its scheme, signature and public key, checking each length
+// against the scheme.
+func ParseSerializedSignature(serialized []byte) (keychain.Scheme, []byte, []byte, error) {
 	if len(serialized) < 1+signatureLength {
 		return 0, nil, nil, ErrInvalidSerializedSig
 	}
@@ -0,0 +1,612 @@
+package transaction
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+
+	bcs "github.com/iotaledger/bcs-go"
+
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/typetag"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+// SnapshotVersion is the version of the JSON format written by
+// Transaction.MarshalJSON.
+const SnapshotVersion = 1
+
+// ErrInvalidSnapshot is returned when a transaction snapshot cannot be
+// loaded.
+var ErrInvalidSnapshot = errors.New("invalid transaction snapshot")
+
+// snapshot is the JSON form of a Transaction. Inputs and commands keep
+// their order, so the Argument values handed out before the snapshot was
+// taken stay valid after it is loaded.
+type snapshot struct {
+	Version      int               `json:"version"`
+	Sender       *types.Address    `json:"sender,omitempty"`
+	Expiration   *snapshotExpiry   `json:"expiration,omitempty"`
+	Gas          snapshotGas       `json:"gas"`
+	Inputs       []snapshotInput   `json:"inputs"`
+	Commands     []snapshotCommand `json:"commands"`
+	PackageNames map[int]string    `json:"packageNames,omitempty"`
+}
+
+type snapshotExpiry struct {
+	None  bool    `json:"none,omitempty"`
+	Epoch *uint64 `json:"epoch,omitempty"`
+}
+
+type snapshotGas struct {
+	Payment []types.ObjectRef `json:"payment,omitempty"`
+	Owner   *types.Address    `json:"owner,omitempty"`
+	Price   *uint64           `json:"price,omitempty"`
+	Budget  *uint64           `json:"budget,omitempty"`
+}
+
+// Input kinds of a snapshot.
+const (
+	snapshotPure             = "Pure"
+	snapshotImmOrOwned       = "ImmOrOwnedObject"
+	snapshotShared           = "SharedObject"
+	snapshotReceiving        = "Receiving"
+	snapshotUnresolvedObject = "UnresolvedObject"
+	snapshotUnresolvedValue  = "UnresolvedValue"
+)
+
+type snapshotInput struct {
+	Kind     string                 `json:"kind"`
+	Bytes    []byte                 `json:"bytes,omitempty"`
+	Ref      *types.ObjectRef       `json:"ref,omitempty"`
+	Shared   *types.SharedObjectRef `json:"shared,omitempty"`
+	ObjectID string                 `json:"objectId,omitempty"`
+	Value    *snapshotValue         `json:"value,omitempty"`
+}
+
+// snapshotValue is a MoveCall.Values element waiting for its function
+// signature. Exactly one field is set; None stands for a nil value.
+type snapshotValue struct {
+	None      bool                   `json:"none,omitempty"`
+	Bool      *bool                  `json:"bool,omitempty"`
+	Int       *string                `json:"int,omitempty"`
+	String    *string                `json:"string,omitempty"`
+	Address   *types.Address         `json:"address,omitempty"`
+	Bytes     *[]byte                `json:"bytes,omitempty"`
+	ObjectRef *types.ObjectRef       `json:"objectRef,omitempty"`
+	Shared    *types.SharedObjectRef `json:"sharedObjectRef,omitempty"`
+	Vector    *[]snapshotValue       `json:"vector,omitempty"`
+}
+
+type snapshotArgument struct {
+	Kind   string  `json:"kind"`
+	Index  *uint16 `json:"index,omitempty"`
+	Result *uint16 `json:"result,omitempty"`
+}
+
+type snapshotCommand struct {
+	Kind string `json:"kind"`
+
+	Package       *types.Address     `json:"package,omitempty"`
+	Module        string             `json:"module,omitempty"`
+	Function      string             `json:"function,omitempty"`
+	TypeArguments []string           `json:"typeArguments,omitempty"`
+	Arguments     []snapshotArgument `json:"arguments,omitempty"`
+
+	Objects     []snapshotArgument `json:"objects,omitempty"`
+	Address     *snapshotArgument  `json:"address,omitempty"`
+	Coin        *snapshotArgument  `json:"coin,omitempty"`
+	Amounts     []snapshotArgument `json:"amounts,omitempty"`
+	Destination *snapshotArgument  `json:"destination,omitempty"`
+	Sources     []snapshotArgument `json:"sources,omitempty"`
+	Type        *string            `json:"type,omitempty"`
+	Elements    []snapshotArgument `json:"elements,omitempty"`
+	Ticket      *snapshotArgument  `json:"ticket,omitempty"`
+
+	Modules      [][]byte        `json:"modules,omitempty"`
+	Dependencies []types.Address `json:"dependencies,omitempty"`
+}
+
+// MarshalJSON encodes the transaction as built so far, including inputs and
+// Move call values that are not resolved yet, so that it can be queued and
+// completed, built and signed elsewhere. Values passed to MoveCall.Values
+// must be of the types it documents; pointers are stored as the value they
+// point to. A transaction holding a build error cannot be encoded.
+func (b *Transaction) MarshalJSON() ([]byte, error) {
+	if b == nil {
+		return nil, ErrNilTransaction
+	}
+	if b.err != nil {
+		return nil, fmt.Errorf("snapshot transaction: %w", b.err)
+	}
+
+	s := snapshot{
+		Version:      SnapshotVersion,
+		Sender:       b.sender,
+		Inputs:       make([]snapshotInput, len(b.inputs)),
+		Commands:     make([]snapshotCommand, len(b.commands)),
+		PackageNames: b.packageNames,
+		Gas: snapshotGas{
+			Payment: b.gas.Payment,
+			Owner:   b.gas.Owner,
+			Price:   b.gas.Price,
+			Budget:  b.gas.Budget,
+		},
+	}
+	if e := b.expiration; e != nil {
+		s.Expiration = &snapshotExpiry{None: e.None != nil, Epoch: e.Epoch}
+	}
+	for i, in := range b.inputs {
+		encoded, err := snapshotInputOf(in)
+		if err != nil {
+			return nil, fmt.Errorf("snapshot transaction: input %d: %w", i, err)
+		}
+		s.Inputs[i] = encoded
+	}
+	for i, cmd := range b.commands {
+		s.Commands[i] = snapshotCommandOf(cmd)
+	}
+	return json.Marshal(s)
+}
+
+// UnmarshalJSON loads a transaction written by MarshalJSON, replacing the
+// receiver's contents. The loaded transaction is checked as by Validate
+// without a resolver; an invalid snapshot matches ErrInvalidSnapshot.
+func (b *Transaction) UnmarshalJSON(data []byte) error {
+	if b == nil {
+		return ErrNilTransaction
+	}
+	var s snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+	}
+	loaded, err := s.transaction()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+	}
+	if err := loaded.Validate(context.Background(), nil); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+	}
+	*b = *loaded
+	return nil
+}
+
+func (s snapshot) transaction() (*Transaction, error) {
+	if s.Version != SnapshotVersion {
+		return nil, fmt.Errorf("unsupported version %d", s.Version)
+	}
+	if len(s.Inputs) > maxIndex+1 || len(s.Commands) > maxIndex+1 {
+		return nil, fmt.Errorf("transaction index exceeds %d", maxIndex)
+	}
+
+	b := &Transaction{
+		sender: s.Sender,
+		gas: gasConfig{
+			Payment: s.Gas.Payment,
+			Owner:   s.Gas.Owner,
+			Price:   s.Gas.Price,
+			Budget:  s.Gas.Budget,
+		},
+		inputs:   make([]input, len(s.Inputs)),
+		commands: make([]Command, len(s.Commands)),
+	}
+	if e := s.Expiration; e != nil {
+		switch {
+		case e.None && e.Epoch == nil:
+			b.expiration = &TransactionExpiration{None: &struct{}{}}
+		case !e.None && e.Epoch != nil:
+			b.expiration = &TransactionExpiration{Epoch: e.Epoch}
+		default:
+			return nil, errors.New("expiration must be none or an epoch")
+		}
+	}
+	for i, in := range s.Inputs {
+		decoded, err := in.input()
+		if err != nil {
+			return nil, fmt.Errorf("input %d: %w", i, err)
+		}
+		b.inputs[i] = decoded
+	}
+	for i, cmd := range s.Commands {
+		decoded, err := cmd.command()
+		if err != nil {
+			return nil, fmt.Errorf("command %d: %w", i, err)
+		}
+		b.commands[i] = decoded
+	}
+	for index, name := range s.PackageNames {
+		if index < 0 || index >= len(b.commands) || b.commands[index].MoveCall == nil {
+			return nil, fmt.Errorf("package name %q refers to command %d, which is not a Move call", name, index)
+		}
+		if !isPackageName(name) {
+			return nil, fmt.Errorf("invalid package name %q", name)
+		}
+		if b.packageNames == nil {
+			b.packageNames = make(map[int]string, len(s.PackageNames))
+		}
+		b.packageNames[index] = name
+	}
+	return b, nil
+}
+
+func snapshotInputOf(in input) (snapshotInput, error) {
+	switch {
+	case in.Pure != nil:
+		return snapshotInput{Kind: snapshotPure, Bytes: in.Pure.Bytes}, nil
+	case in.Object != nil && in.Object.ImmOrOwnedObject != nil:
+		return snapshotInput{Kind: snapshotImmOrOwned, Ref: in.Object.ImmOrOwnedObject}, nil
+	case in.Object != nil && in.Object.SharedObject != nil:
+		return snapshotInput{Kind: snapshotShared, Shared: in.Object.SharedObject}, nil
+	case in.Object != nil && in.Object.Receiving != nil:
+		return snapshotInput{Kind: snapshotReceiving, Ref: in.Object.Receiving}, nil
+	case in.UnresolvedObject != nil:
+		return snapshotInput{Kind: snapshotUnresolvedObject, ObjectID: in.UnresolvedObject.ObjectID}, nil
+	case in.UnresolvedValue != nil:
+		value, err := snapshotValueOf(in.UnresolvedValue.Value)
+		if err != nil {
+			return snapshotInput{}, err
+		}
+		return snapshotInput{Kind: snapshotUnresolvedValue, Value: &value}, nil
+	}
+	return snapshotInput{}, errors.New("empty input")
+}
+
+func (in snapshotInput) input() (input, error) {
+	switch in.Kind {
+	case snapshotPure:
+		return input{Pure: &Pure{Bytes: in.Bytes}}, nil
+	case snapshotImmOrOwned, snapshotReceiving:
+		if in.Ref == nil {
+			return input{}, fmt.Errorf("%s input has no ref", in.Kind)
+		}
+		if in.Kind == snapshotReceiving {
+			return input{Object: &ObjectArg{Receiving: in.Ref}}, nil
+		}
+		return input{Object: &ObjectArg{ImmOrOwnedObject: in.Ref}}, nil
+	case snapshotShared:
+		if in.Shared == nil {
+			return input{}, errors.New("SharedObject input has no shared ref")
+		}
+		return input{Object: &ObjectArg{SharedObject: in.Shared}}, nil
+	case snapshotUnresolvedObject:
+		if _, err := utils.ParseAddress(in.ObjectID); err != nil {
+			return input{}, fmt.Errorf("object id: %w", err)
+		}
+		return input{UnresolvedObject: &UnresolvedObject{ObjectID: in.ObjectID}}, nil
+	case snapshotUnresolvedValue:
+		if in.Value == nil {
+			return input{}, errors.New("UnresolvedValue input has no value")
+		}
+		value, err := in.Value.value()
+		if err != nil {
+			return input{}, err
+		}
+		return input{UnresolvedValue: &UnresolvedValue{Value: value}}, nil
+	}
+	return input{}, fmt.Errorf("unknown input kind %q", in.Kind)
+}
+
+func snapshotValueOf(v any) (snapshotValue, error) {
+	switch x := v.(type) {
+	case nil:
+		return snapshotValue{None: true}, nil
+	case bool:
+		return snapshotValue{Bool: &x}, nil
+	case string:
+		return snapshotValue{String: &x}, nil
+	case types.Address:
+		return snapshotValue{Address: &x}, nil
+	case [32]byte:
+		address := types.Address(x)
+		return snapshotValue{Address: &address}, nil
+	case []byte:
+		return snapshotValue{Bytes: &x}, nil
+	case *big.Int:
+		if x == nil {
+			return snapshotValue{None: true}, nil
+		}
+		n := x.String()
+		return snapshotValue{Int: &n}, nil
+	case big.Int:
+		n := x.String()
+		return snapshotValue{Int: &n}, nil
+	case types.CoinAmount:
+		n := new(big.Int).SetUint64(x.Raw()).String()
+		return snapshotValue{Int: &n}, nil
+	case types.ObjectRef:
+		return snapshotValue{ObjectRef: &x}, nil
+	case types.SharedObjectRef:
+		return snapshotValue{Shared: &x}, nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n := big.NewInt(rv.Int()).String()
+		return snapshotValue{Int: &n}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n := new(big.Int).SetUint64(rv.Uint()).String()
+		return snapshotValue{Int: &n}, nil
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return snapshotValue{None: true}, nil
+		}
+		return snapshotValueOf(rv.Elem().Interface())
+	case reflect.Slice, reflect.Array:
+		elems := make([]snapshotValue, rv.Len())
+		for i := range elems {
+			elem, err := snapshotValueOf(rv.Index(i).Interface())
+			if err != nil {
+				return snapshotValue{}, fmt.Errorf("element %d: %w", i, err)
+			}
+			elems[i] = elem
+		}
+		return snapshotValue{Vector: &elems}, nil
+	}
+	return snapshotValue{}, fmt.Errorf("cannot snapshot a Move call value of type %T", v)
+}
+
+// value returns the Go value the snapshot holds, in a form the Move call
+// value encoder accepts: integers come back as *big.Int and vectors as
+// []any.
+func (v snapshotValue) value() (any, error) {
+	set := 0
+	for _, ok := range []bool{v.None, v.Bool != nil, v.Int != nil, v.String != nil, v.Address != nil, v.Bytes != nil, v.ObjectRef != nil, v.Shared != nil, v.Vector != nil} {
+		if ok {
+			set++
+		}
+	}
+	if set != 1 {
+		return nil, errors.New("value must have exactly one kind")
+	}
+
+	switch {
+	case v.None:
+		return nil, nil
+	case v.Bool != nil:
+		return *v.Bool, nil
+	case v.Int != nil:
+		n, ok := new(big.Int).SetString(*v.Int, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer %q", *v.Int)
+		}
+		return n, nil
+	case v.String != nil:
+		return *v.String, nil
+	case v.Address != nil:
+		return *v.Address, nil
+	case v.Bytes != nil:
+		return *v.Bytes, nil
+	case v.ObjectRef != nil:
+		return *v.ObjectRef, nil
+	case v.Shared != nil:
+		return *v.Shared, nil
+	}
+	elems := make([]any, len(*v.Vector))
+	for i, elem := range *v.Vector {
+		decoded, err := elem.value()
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		elems[i] = decoded
+	}
+	return elems, nil
+}
+
+func snapshotArgumentOf(arg Argument) snapshotArgument {
+	switch {
+	case arg.GasCoin != nil:
+		return snapshotArgument{Kind: "GasCoin"}
+	case arg.Input != nil:
+		return snapshotArgument{Kind: "Input", Index: arg.Input}
+	case arg.Result != nil:
+		return snapshotArgument{Kind: "Result", Index: arg.Result}
+	case arg.NestedResult != nil:
+		return snapshotArgument{Kind: "NestedResult", Index: &arg.NestedResult.Index, Result: &arg.NestedResult.ResultIndex}
+	}
+	return snapshotArgument{}
+}
+
+func snapshotArgumentsOf(args []Argument) []snapshotArgument {
+	out := make([]snapshotArgument, len(args))
+	for i, arg := range args {
+		out[i] = snapshotArgumentOf(arg)
+	}
+	return out
+}
+
+func snapshotArgumentRef(arg Argument) *snapshotArgument {
+	encoded := snapshotArgumentOf(arg)
+	return &encoded
+}
+
+func (a *snapshotArgument) argument() (Argument, error) {
+	if a == nil {
+		return Argument{}, errors.New("missing argument")
+	}
+	switch a.Kind {
+	case "GasCoin":
+		return Argument{GasCoin: &struct{}{}}, nil
+	case "Input", "Result":
+		if a.Index == nil {
+			return Argument{}, fmt.Errorf("%s argument has no index", a.Kind)
+		}
+		index := *a.Index
+		if a.Kind == "Input" {
+			return Argument{Input: &index}, nil
+		}
+		return Argument{Result: &index}, nil
+	case "NestedResult":
+		if a.Index == nil || a.Result == nil {
+			return Argument{}, errors.New("NestedResult argument needs an index and a result")
+		}
+		return nestedResultArg(*a.Index, *a.Result), nil
+	}
+	return Argument{}, fmt.Errorf("unknown argument kind %q", a.Kind)
+}
+
+func snapshotArguments(args []snapshotArgument) ([]Argument, error) {
+	out := make([]Argument, len(args))
+	for i := range args {
+		arg, err := args[i].argument()
+		if err != nil {
+			return nil, fmt.Errorf("argument %d: %w", i, err)
+		}
+		out[i] = arg
+	}
+	return out, nil
+}
+
+func snapshotCommandOf(cmd Command) snapshotCommand {
+	switch {
+	case cmd.MoveCall != nil:
+		call := cmd.MoveCall
+		out := snapshotCommand{
+			Kind:      "MoveCall",
+			Package:   &call.Package,
+			Module:    call.Module,
+			Function:  call.Function,
+			Arguments: snapshotArgumentsOf(call.Arguments),
+		}
+		for _, tag := range call.TypeArguments {
+			out.TypeArguments = append(out.TypeArguments, tag.String())
+		}
+		return out
+	case cmd.TransferObjects != nil:
+		return snapshotCommand{
+			Kind:    "TransferObjects",
+			Objects: snapshotArgumentsOf(cmd.TransferObjects.Objects),
+			Address: snapshotArgumentRef(cmd.TransferObjects.Address),
+		}
+	case cmd.SplitCoins != nil:
+		return snapshotCommand{
+			Kind:    "SplitCoins",
+			Coin:    snapshotArgumentRef(cmd.SplitCoins.Coin),
+			Amounts: snapshotArgumentsOf(cmd.SplitCoins.Amounts),
+		}
+	case cmd.MergeCoins != nil:
+		return snapshotCommand{
+			Kind:        "MergeCoins",
+			Destination: snapshotArgumentRef(cmd.MergeCoins.Destination),
+			Sources:     snapshotArgumentsOf(cmd.MergeCoins.Sources),
+		}
+	case cmd.Publish != nil:
+		return snapshotCommand{
+			Kind:         "Publish",
+			Modules:      cmd.Publish.Modules,
+			Dependencies: cmd.Publish.Dependencies,
+		}
+	case cmd.MakeMoveVec != nil:
+		out := snapshotCommand{Kind: "MakeMoveVec", Elements: snapshotArgumentsOf(cmd.MakeMoveVec.Elements)}
+		if !cmd.MakeMoveVec.Type.None {
+			tag := cmd.MakeMoveVec.Type.Some.String()
+			out.Type = &tag
+		}
+		return out
+	case cmd.Upgrade != nil:
+		return snapshotCommand{
+			Kind:         "Upgrade",
+			Modules:      cmd.Upgrade.Modules,
+			Dependencies: cmd.Upgrade.Dependencies,
+			Package:      &cmd.Upgrade.Package,
+			Ticket:       snapshotArgumentRef(cmd.Upgrade.Ticket),
+		}
+	}
+	return snapshotCommand{}
+}
+
+func (c snapshotCommand) command() (Command, error) {
+	switch c.Kind {
+	case "MoveCall":
+		if c.Package == nil || c.Module == "" || c.Function == "" {
+			return Command{}, ErrMissingMoveCallTarget
+		}
+		args, err := snapshotArguments(c.Arguments)
+		if err != nil {
+			return Command{}, err
+		}
+		typeArgs := make([]typetag.TypeTag, len(c.TypeArguments))
+		for i, arg := range c.TypeArguments {
+			if typeArgs[i], err = utils.ParseTypeTag(arg); err != nil {
+				return Command{}, fmt.Errorf("type argument %d: %w", i, err)
+			}
+		}
+		return Command{MoveCall: &ProgrammableMoveCall{
+			Package:       *c.Package,
+			Module:        c.Module,
+			Function:      c.Function,
+			TypeArguments: typeArgs,
+			Arguments:     args,
+		}}, nil
+
+	case "TransferObjects":
+		objects, err := snapshotArguments(c.Objects)
+		if err != nil {
+			return Command{}, err
+		}
+		address, err := c.Address.argument()
+		if err != nil {
+			return Command{}, fmt.Errorf("address: %w", err)
+		}
+		return Command{TransferObjects: &TransferObjects{Objects: objects, Address: address}}, nil
+
+	case "SplitCoins":
+		coin, err := c.Coin.argument()
+		if err != nil {
+			return Command{}, fmt.Errorf("coin: %w", err)
+		}
+		amounts, err := snapshotArguments(c.Amounts)
+		if err != nil {
+			return Command{}, err
+		}
+		return Command{SplitCoins: &SplitCoins{Coin: coin, Amounts: amounts}}, nil
+
+	case "MergeCoins":
+		destination, err := c.Destination.argument()
+		if err != nil {
+			return Command{}, fmt.Errorf("destination: %w", err)
+		}
+		sources, err := snapshotArguments(c.Sources)
+		if err != nil {
+			return Command{}, err
+		}
+		return Command{MergeCoins: &MergeCoins{Destination: destination, Sources: sources}}, nil
+
+	case "Publish":
+		return Command{Publish: &Publish{Modules: c.Modules, Dependencies: c.Dependencies}}, nil
+
+	case "MakeMoveVec":
+		elements, err := snapshotArguments(c.Elements)
+		if err != nil {
+			return Command{}, err
+		}
+		elemType := bcs.Option[typetag.TypeTag]{None: true}
+		if c.Type != nil {
+			tag, err := utils.ParseTypeTag(*c.Type)
+			if err != nil {
+				return Command{}, fmt.Errorf("type: %w", err)
+			}
+			elemType = optionTypeTag(&tag)
+		}
+		return Command{MakeMoveVec: &MakeMoveVec{Type: elemType, Elements: elements}}, nil
+
+	case "Upgrade":
+		if c.Package == nil {
+			return Command{}, errors.New("upgrade has no package")
+		}
+		ticket, err := c.Ticket.argument()
+		if err != nil {
+			return Command{}, fmt.Errorf("ticket: %w", err)
+		}
+		return Command{Upgrade: &Upgrade{
+			Modules:      c.Modules,
+			Dependencies: c.Dependencies,
+			Package:      *c.Package,
+			Ticket:       ticket,
+		}}, nil
+	}
+	return Command{}, fmt.Errorf("unknown command kind %q", c.Kind)
+}
@@ -0,0 +1,143 @@
+package transaction
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/types"
+)
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	digest := types.Digest(bytes.Repeat([]byte{1}, 32))
+	coin := types.ObjectRef{ObjectID: mustAddress(t, "0x1"), Version: 1, Digest: digest}
+	pool := types.SharedObjectRef{ObjectID: mustAddress(t, "0x2"), InitialSharedVersion: 5, Mutable: true}
+	elemType := "0x2::sui::SUI"
+
+	tx := New()
+	tx.SetSender("0xa").SetGasBudget(5_000_000).SetGasPrice(1_000).SetGasPayment([]types.ObjectRef{coin})
+	tx.SetExpiration(TransactionExpiration{Epoch: &[]uint64{9}[0]})
+	split := tx.SplitCoins(SplitCoins{Coin: tx.Gas(), Amounts: []Argument{tx.PureU64(10), tx.PureU64(20)}})
+	tx.MergeCoins(MergeCoins{Destination: split[0], Sources: []Argument{split[1]}})
+	tx.MoveCall(MoveCall{Target: "0x2::coin::join", TypeArguments: []string{elemType}, Arguments: []Argument{split[0], tx.SharedObject(pool)}})
+	vec := tx.MakeMoveVec(MakeMoveVecInput{Type: &elemType, Elements: []Argument{split[0]}})
+	tx.TransferObjects(TransferObjects{Objects: []Argument{vec.Arg(), tx.ReceivingObject(coin)}, Address: tx.PureAddress("0xb")})
+
+	data, err := json.Marshal(tx)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	loaded := New()
+	if err := json.Unmarshal(data, loaded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	again, err := json.Marshal(loaded)
+	if err != nil {
+		t.Fatalf("marshal loaded: %v", err)
+	}
+	if !bytes.Equal(data, again) {
+		t.Fatalf("snapshot changed after a round trip:\n%s\n%s", data, again)
+	}
+
+	want, err := tx.Build(context.Background(), BuildOptions{})
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	got, err := loaded.Build(context.Background(), BuildOptions{})
+	if err != nil {
+		t.Fatalf("build loaded: %v", err)
+	}
+	if !bytes.Equal(want.TransactionBytes, got.TransactionBytes) {
+		t.Fatal("loaded transaction builds to different bytes")
+	}
+
+	// Arguments handed out before the snapshot stay valid, and new inputs
+	// are appended after the loaded ones.
+	next := loaded.PureU64(30)
+	if *next.Input != uint16(len(tx.inputs)) {
+		t.Fatalf("next input index = %d, want %d", *next.Input, len(tx.inputs))
+	}
+	loaded.MergeCoins(MergeCoins{Destination: split[0], Sources: []Argument{split[1]}})
+	if err := loaded.Validate(context.Background(), nil); err != nil {
+		t.Fatalf("validate extended transaction: %v", err)
+	}
+}
+
+func TestSnapshotUnresolvedValues(t *testing.T) {
+	n := uint64(7)
+	values := []any{
+		uint8(1), &n, nil, true, "name", mustAddress(t, "0xc"), []byte{1, 2},
+		[]uint16{3, 4}, big.NewInt(5), types.SharedObjectRef{ObjectID: mustAddress(t, "0x2"), InitialSharedVersion: 1},
+	}
+	tx := New()
+	tx.MoveCall(MoveCall{Target: "0x2::example::call", Values: values})
+	tx.MoveCall(MoveCall{Target: "@suifrens/core::suifren::mint", Values: []any{tx.Object("0xd")}})
+
+	data, err := json.Marshal(tx)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	loaded := New()
+	if err := json.Unmarshal(data, loaded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	want := []any{
+		big.NewInt(1), big.NewInt(7), nil, true, "name", mustAddress(t, "0xc"), []byte{1, 2},
+		[]any{big.NewInt(3), big.NewInt(4)}, big.NewInt(5), values[9],
+	}
+	for i, w := range want {
+		in := loaded.inputs[i]
+		if in.UnresolvedValue == nil || !reflect.DeepEqual(in.UnresolvedValue.Value, w) {
+			t.Errorf("value %d = %#v, want %#v", i, in.UnresolvedValue, w)
+		}
+	}
+	if obj := loaded.inputs[len(values)].UnresolvedObject; obj == nil || obj.ObjectID != tx.inputs[len(values)].UnresolvedObject.ObjectID {
+		t.Errorf("unresolved object = %+v", obj)
+	}
+	if !reflect.DeepEqual(loaded.packageNames, tx.packageNames) {
+		t.Errorf("package names = %v, want %v", loaded.packageNames, tx.packageNames)
+	}
+
+	bad := New()
+	bad.MoveCall(MoveCall{Target: "0x2::example::call", Values: []any{func() {}}})
+	if _, err := json.Marshal(bad); err == nil {
+		t.Error("expected an error for a value that cannot be stored")
+	}
+}
+
+func TestSnapshotValidation(t *testing.T) {
+	tx := New()
+	tx.TransferObjects(TransferObjects{Objects: []Argument{tx.Gas()}, Address: tx.PureAddress("0xb")})
+	data, err := json.Marshal(tx)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	for _, tt := range []struct{ name, old, new string }{
+		{"version", `"version":1`, `"version":2`},
+		{"input index", `"kind":"Input","index":0`, `"kind":"Input","index":3`},
+		{"argument kind", `"kind":"GasCoin"`, `"kind":"Gas"`},
+		{"command kind", `"kind":"TransferObjects"`, `"kind":"Transfer"`},
+		{"input kind", `"kind":"Pure"`, `"kind":"Object"`},
+	} {
+		mutated := strings.Replace(string(data), tt.old, tt.new, 1)
+		if mutated == string(data) {
+			t.Fatalf("%s: %s not found in %s", tt.name, tt.old, data)
+		}
+		if err := json.Unmarshal([]byte(mutated), New()); !errors.Is(err, ErrInvalidSnapshot) {
+			t.Errorf("%s: expected ErrInvalidSnapshot, got %v", tt.name, err)
+		}
+	}
+
+	broken := New()
+	broken.SetSender("not an address")
+	if _, err := json.Marshal(broken); err == nil {
+		t.Error("expected an error for a transaction holding a build error")
+	}
+}
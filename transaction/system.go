@@ -0,0 +1,327 @@
+package transaction
+
+import (
+	"time"
+
+	"github.com/iotaledger/bcs-go"
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/typetag"
+)
+
+// System transactions are created by validators rather than users: epoch
+// changes, consensus commit prologues, JWK and randomness updates. They
+// appear in checkpoints alongside programmable transactions, so the types
+// below model them in full for decoding; none of them can be built.
+
+// ChangeEpoch advances the system to a new epoch.
+type ChangeEpoch struct {
+	Epoch                   uint64
+	ProtocolVersion         uint64
+	StorageCharge           uint64
+	ComputationCharge       uint64
+	StorageRebate           uint64
+	NonRefundableStorageFee uint64
+	EpochStartTimestampMs   uint64
+	SystemPackages          []SystemPackage
+}
+
+// SystemPackage is a framework package written at an epoch change.
+type SystemPackage struct {
+	Version      uint64
+	Modules      [][]byte
+	Dependencies []types.Address
+}
+
+// GenesisTransaction creates the objects of the chain's first checkpoint.
+type GenesisTransaction struct {
+	Objects []GenesisObject
+}
+
+// GenesisObject is an object written by the genesis transaction.
+type GenesisObject struct {
+	RawObject *GenesisRawObject
+}
+
+// IsBcsEnum marks GenesisObject as a BCS enum.
+func (GenesisObject) IsBcsEnum() {}
+
+// GenesisRawObject is the data and owner of a genesis object.
+type GenesisRawObject struct {
+	Data  ObjectData
+	Owner Owner
+}
+
+// ObjectData is the contents of an object: a Move object or a package.
+type ObjectData struct {
+	Move    *MoveObject
+	Package *MovePackage
+}
+
+// IsBcsEnum marks ObjectData as a BCS enum.
+func (ObjectData) IsBcsEnum() {}
+
+// MoveObject is a Move object with its BCS contents.
+type MoveObject struct {
+	Type              MoveObjectType
+	HasPublicTransfer bool
+	Version           uint64
+	Contents          []byte
+}
+
+// MoveObjectType is the type of a Move object, with the common coin types
+// abbreviated.
+type MoveObjectType struct {
+	Other     *typetag.StructTag
+	GasCoin   *struct{}
+	StakedSui *struct{}
+	Coin      *typetag.TypeTag
+}
+
+// IsBcsEnum marks MoveObjectType as a BCS enum.
+func (MoveObjectType) IsBcsEnum() {}
+
+// MovePackage is a published Move package.
+type MovePackage struct {
+	ID      types.Address
+	Version uint64
+	// Modules are sorted by name.
+	Modules         []PackageModule
+	TypeOriginTable []TypeOrigin
+	// LinkageTable is sorted by original package ID.
+	LinkageTable []PackageLinkage
+}
+
+// PackageModule is a compiled module of a package.
+type PackageModule struct {
+	Name     string
+	Bytecode []byte
+}
+
+// TypeOrigin records the package that first defined a type.
+type TypeOrigin struct {
+	ModuleName   string
+	DatatypeName string
+	Package      types.Address
+}
+
+// PackageLinkage maps a dependency to the version of it the package uses.
+type PackageLinkage struct {
+	OriginalID      types.Address
+	UpgradedID      types.Address
+	UpgradedVersion uint64
+}
+
+// Owner is the owner of an object.
+type Owner struct {
+	AddressOwner          *types.Address
+	ObjectOwner           *types.Address
+	Shared                *SharedOwner
+	Immutable             *struct{}
+	ConsensusAddressOwner *ConsensusAddressOwner
+}
+
+// IsBcsEnum marks Owner as a BCS enum.
+func (Owner) IsBcsEnum() {}
+
+// SharedOwner describes a shared object.
+type SharedOwner struct {
+	InitialSharedVersion uint64
+}
+
+// ConsensusAddressOwner describes an address-owned object sequenced by
+// consensus.
+type ConsensusAddressOwner struct {
+	StartVersion uint64
+	Owner        types.Address
+}
+
+// ConsensusCommitPrologue starts the transactions of a consensus commit and
+// sets the clock.
+type ConsensusCommitPrologue struct {
+	Epoch             uint64
+	Round             uint64
+	CommitTimestampMs uint64
+}
+
+// ConsensusCommitPrologueV2 adds the commit digest.
+type ConsensusCommitPrologueV2 struct {
+	Epoch                 uint64
+	Round                 uint64
+	CommitTimestampMs     uint64
+	ConsensusCommitDigest types.Digest
+}
+
+// ConsensusCommitPrologueV3 adds the sub-DAG index and the version
+// assignments of cancelled transactions.
+type ConsensusCommitPrologueV3 struct {
+	Epoch                                 uint64
+	Round                                 uint64
+	SubDagIndex                           bcs.Option[uint64]
+	CommitTimestampMs                     uint64
+	ConsensusCommitDigest                 types.Digest
+	ConsensusDeterminedVersionAssignments ConsensusDeterminedVersionAssignments
+}
+
+// ConsensusCommitPrologueV4 adds the digest of additional consensus state.
+type ConsensusCommitPrologueV4 struct {
+	Epoch                                 uint64
+	Round                                 uint64
+	SubDagIndex                           bcs.Option[uint64]
+	CommitTimestampMs                     uint64
+	ConsensusCommitDigest                 types.Digest
+	ConsensusDeterminedVersionAssignments ConsensusDeterminedVersionAssignments
+	AdditionalStateDigest                 types.Digest
+}
+
+// ConsensusDeterminedVersionAssignments lists the shared object versions
+// assigned to transactions that consensus cancelled.
+type ConsensusDeterminedVersionAssignments struct {
+	CancelledTransactions   *[]CancelledTransaction
+	CancelledTransactionsV2 *[]CancelledTransactionV2
+}
+
+// IsBcsEnum marks ConsensusDeterminedVersionAssignments as a BCS enum.
+func (ConsensusDeterminedVersionAssignments) IsBcsEnum() {}
+
+// CancelledTransaction is a cancelled transaction and its object versions.
+type CancelledTransaction struct {
+	Digest      types.Digest
+	Assignments []VersionAssignment
+}
+
+// VersionAssignment is the version assigned to a shared object.
+type VersionAssignment struct {
+	ObjectID types.Address
+	Version  uint64
+}
+
+// CancelledTransactionV2 is a cancelled transaction and its object versions,
+// with objects identified by their initial shared version as well.
+type CancelledTransactionV2 struct {
+	Digest      types.Digest
+	Assignments []VersionAssignmentV2
+}
+
+// VersionAssignmentV2 is the version assigned to a consensus object.
+type VersionAssignmentV2 struct {
+	ObjectID             types.Address
+	InitialSharedVersion uint64
+	Version              uint64
+}
+
+// AuthenticatorStateUpdate records the JWKs that zkLogin accepts.
+type AuthenticatorStateUpdate struct {
+	Epoch                                uint64
+	Round                                uint64
+	NewActiveJwks                        []ActiveJwk
+	AuthenticatorObjInitialSharedVersion uint64
+}
+
+// ActiveJwk is a JWK and the epoch it was last seen in.
+type ActiveJwk struct {
+	JwkID JwkID
+	Jwk   Jwk
+	Epoch uint64
+}
+
+// JwkID identifies a JWK by issuer and key ID.
+type JwkID struct {
+	Iss string
+	Kid string
+}
+
+// Jwk is an RSA JSON web key.
+type Jwk struct {
+	Kty string
+	E   string
+	N   string
+	Alg string
+}
+
+// RandomnessStateUpdate publishes the randomness of a round for
+// 0x8::random.
+type RandomnessStateUpdate struct {
+	Epoch                             uint64
+	RandomnessRound                   uint64
+	RandomBytes                       []byte
+	RandomnessObjInitialSharedVersion uint64
+}
+
+// EndOfEpochTransactionKind is one step of the end-of-epoch transaction.
+type EndOfEpochTransactionKind struct {
+	ChangeEpoch                    *ChangeEpoch
+	AuthenticatorStateCreate       *struct{}
+	AuthenticatorStateExpire       *AuthenticatorStateExpire
+	RandomnessStateCreate          *struct{}
+	DenyListStateCreate            *struct{}
+	BridgeStateCreate              *types.Digest
+	BridgeCommitteeInit            *uint64
+	StoreExecutionTimeObservations *StoredExecutionTimeObservations
+	AccumulatorRootCreate          *struct{}
+	CoinRegistryCreate             *struct{}
+	DisplayRegistryCreate          *struct{}
+}
+
+// IsBcsEnum marks EndOfEpochTransactionKind as a BCS enum.
+func (EndOfEpochTransactionKind) IsBcsEnum() {}
+
+// AuthenticatorStateExpire removes JWKs older than MinEpoch.
+type AuthenticatorStateExpire struct {
+	MinEpoch                             uint64
+	AuthenticatorObjInitialSharedVersion uint64
+}
+
+// StoredExecutionTimeObservations are the validators' measurements of how
+// long commands take, used for congestion control.
+type StoredExecutionTimeObservations struct {
+	V1 *[]ExecutionTimeObservations
+}
+
+// IsBcsEnum marks StoredExecutionTimeObservations as a BCS enum.
+func (StoredExecutionTimeObservations) IsBcsEnum() {}
+
+// ExecutionTimeObservations are the measurements for one kind of command.
+type ExecutionTimeObservations struct {
+	Key          ExecutionTimeObservationKey
+	Observations []ExecutionTimeObservation
+}
+
+// ExecutionTimeObservation is one validator's measurement.
+type ExecutionTimeObservation struct {
+	Authority []byte
+	Duration  Duration
+}
+
+// Duration is a Rust std::time::Duration.
+type Duration struct {
+	Secs  uint64
+	Nanos uint32
+}
+
+// Std returns d as a time.Duration.
+func (d Duration) Std() time.Duration {
+	return time.Duration(d.Secs)*time.Second + time.Duration(d.Nanos)
+}
+
+// ExecutionTimeObservationKey identifies the command an observation
+// measures.
+type ExecutionTimeObservationKey struct {
+	MoveEntryPoint  *MoveEntryPoint
+	TransferObjects *struct{}
+	SplitCoins      *struct{}
+	MergeCoins      *struct{}
+	Publish         *struct{}
+	MakeMoveVec     *struct{}
+	Upgrade         *struct{}
+}
+
+// IsBcsEnum marks ExecutionTimeObservationKey as a BCS enum.
+func (ExecutionTimeObservationKey) IsBcsEnum() {}
+
+// MoveEntryPoint is a Move function with its type arguments.
+type MoveEntryPoint struct {
+	Package       types.Address
+	Module        string
+	Function      string
+	TypeArguments []typetag.TypeTag
+}
@@ -0,0 +1,102 @@
+package transaction
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/iotaledger/bcs-go"
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/typetag"
+)
+
+func TestDecodeConsensusCommitPrologueV3(t *testing.T) {
+	u64 := func(v uint64) []byte { return binary.LittleEndian.AppendUint64(nil, v) }
+
+	var raw []byte
+	raw = append(raw, 0, 8) // TransactionData::V1, ConsensusCommitPrologueV3
+	raw = append(raw, u64(7)...)
+	raw = append(raw, u64(42)...)
+	raw = append(raw, 1)
+	raw = append(raw, u64(9)...) // sub_dag_index: Some(9)
+	raw = append(raw, u64(1_700_000_000_000)...)
+	raw = append(raw, 32)
+	raw = append(raw, bytes.Repeat([]byte{5}, 32)...)
+	raw = append(raw, 1, 1) // CancelledTransactionsV2 with one transaction
+	raw = append(raw, 32)
+	raw = append(raw, bytes.Repeat([]byte{6}, 32)...)
+	raw = append(raw, 1)
+	raw = append(raw, bytes.Repeat([]byte{0}, 31)...)
+	raw = append(raw, 6) // object 0x6
+	raw = append(raw, u64(1)...)
+	raw = append(raw, u64(100)...)
+	raw = append(raw, make([]byte, 32)...) // sender 0x0
+	raw = append(raw, 0)                   // no gas payment
+	raw = append(raw, make([]byte, 32)...) // gas owner
+	raw = append(raw, u64(1)...)
+	raw = append(raw, u64(0)...)
+	raw = append(raw, 0) // TransactionExpiration::None
+
+	data, err := DecodeTransactionData(raw)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	kind := data.V1.Kind
+	if kind.Name() != "ConsensusCommitPrologueV3" || !kind.IsSystem() {
+		t.Fatalf("unexpected kind %q", kind.Name())
+	}
+	prologue := kind.ConsensusCommitPrologueV3
+	if prologue.Epoch != 7 || prologue.Round != 42 || prologue.SubDagIndex.None || prologue.SubDagIndex.Some != 9 {
+		t.Fatalf("unexpected prologue %+v", prologue)
+	}
+	cancelled := *prologue.ConsensusDeterminedVersionAssignments.CancelledTransactionsV2
+	if len(cancelled) != 1 || len(cancelled[0].Assignments) != 1 {
+		t.Fatalf("unexpected cancelled transactions %+v", cancelled)
+	}
+	if got := cancelled[0].Assignments[0]; got.ObjectID != mustAddress(t, "0x6") || got.InitialSharedVersion != 1 || got.Version != 100 {
+		t.Fatalf("unexpected assignment %+v", got)
+	}
+}
+
+func TestSystemTransactionKindsRoundTrip(t *testing.T) {
+	coinType := typetag.TypeTagStruct(typetag.NewStructTag(mustAddress(t, "0x2"), "sui", "SUI", nil))
+	kinds := []TransactionKind{
+		{ChangeEpoch: &ChangeEpoch{Epoch: 3, SystemPackages: []SystemPackage{
+			{Version: 2, Modules: [][]byte{{1, 2}}, Dependencies: []types.Address{mustAddress(t, "0x1")}},
+		}}},
+		{Genesis: &GenesisTransaction{Objects: []GenesisObject{{RawObject: &GenesisRawObject{
+			Data:  ObjectData{Move: &MoveObject{Type: MoveObjectType{Coin: &coinType}, Version: 1, Contents: []byte{9}}},
+			Owner: Owner{Shared: &SharedOwner{InitialSharedVersion: 1}},
+		}}}}},
+		{AuthenticatorStateUpdate: &AuthenticatorStateUpdate{Epoch: 1, NewActiveJwks: []ActiveJwk{
+			{JwkID: JwkID{Iss: "https://accounts.google.com", Kid: "k"}, Jwk: Jwk{Kty: "RSA", E: "AQAB", N: "n", Alg: "RS256"}},
+		}}},
+		{EndOfEpochTransaction: &[]EndOfEpochTransactionKind{
+			{ChangeEpoch: &ChangeEpoch{Epoch: 4}},
+			{StoreExecutionTimeObservations: &StoredExecutionTimeObservations{V1: &[]ExecutionTimeObservations{{
+				Key:          ExecutionTimeObservationKey{MoveEntryPoint: &MoveEntryPoint{Package: mustAddress(t, "0x2"), Module: "coin", Function: "join", TypeArguments: []typetag.TypeTag{coinType}}},
+				Observations: []ExecutionTimeObservation{{Authority: bytes.Repeat([]byte{1}, 96), Duration: Duration{Secs: 1, Nanos: 5}}},
+			}}}},
+			{CoinRegistryCreate: &struct{}{}},
+		}},
+		{RandomnessStateUpdate: &RandomnessStateUpdate{Epoch: 1, RandomnessRound: 2, RandomBytes: []byte{1, 2, 3}}},
+	}
+
+	for _, kind := range kinds {
+		encoded, err := bcs.Marshal(&kind)
+		if err != nil {
+			t.Fatalf("marshal %s: %v", kind.Name(), err)
+		}
+		decoded, err := bcs.Unmarshal[TransactionKind](encoded)
+		if err != nil {
+			t.Fatalf("unmarshal %s: %v", kind.Name(), err)
+		}
+		if decoded.Name() != kind.Name() || !decoded.IsSystem() {
+			t.Fatalf("decoded %q as %q", kind.Name(), decoded.Name())
+		}
+		again, err := bcs.Marshal(&decoded)
+		if err != nil || !bytes.Equal(again, encoded) {
+			t.Fatalf("%s did not round trip", kind.Name())
+		}
+	}
+}
@@ -3,6 +3,7 @@ package transaction
 import (
 	"context"
 	"fmt"
+	"math"
 	"math/big"
 	"strings"
 
@@ -15,6 +16,14 @@ import (
 type BuildOptions struct {
 	Resolver    Resolver
 	GasResolver GasResolver
+	// EpochResolver, when set, makes Build reject transactions whose epoch
+	// expiration has already passed. If nil, Resolver or GasResolver is used
+	// when it also implements EpochResolver.
+	EpochResolver EpochResolver
+	// NameResolver resolves Move calls that name their package, as in
+	// "@suifrens/core::mint::mint". If nil, Resolver is used when it also
+	// implements NameResolver.
+	NameResolver NameResolver
 }
 
 type BuildResult struct {
@@ -32,12 +41,16 @@ type Transaction struct {
 	expiration *TransactionExpiration
 	gas        gasConfig
 	err        error
+	// packageNames maps the index of a Move call command to the package
+	// name it targets, until Build resolves it.
+	packageNames map[int]string
 }
 
 type input struct {
 	Pure             *Pure
 	Object           *ObjectArg
 	UnresolvedObject *UnresolvedObject
+	UnresolvedValue  *UnresolvedValue
 }
 
 type UnresolvedObject struct {
@@ -70,6 +83,15 @@ func (b *Transaction) HasSender() bool {
 	return b != nil && b.sender != nil
 }
 
+// Sender returns the normalized sender address, or an empty string when unset.
+func (b *Transaction) Sender() string {
+	if b == nil || b.sender == nil {
+		return ""
+	}
+
+	return b.sender.String()
+}
+
 // SetSender sets the transaction sender address.
 func (b *Transaction) SetSender(address string) *Transaction {
 	if b == nil {
@@ -96,6 +118,31 @@ func (b *Transaction) SetExpiration(expiration TransactionExpiration) *Transacti
 	return b
 }
 
+// SetExpirationInEpochs makes the transaction expire epochs after the current
+// epoch reported by resolver. A transaction expiring at epoch e can still be
+// executed during epoch e.
+func (b *Transaction) SetExpirationInEpochs(ctx context.Context, resolver EpochResolver, epochs uint64) *Transaction {
+	if b == nil {
+		return b
+	}
+	if resolver == nil {
+		b.setErr(fmt.Errorf("epoch resolver required to set expiration"))
+		return b
+	}
+
+	current, err := resolver.ResolveCurrentEpoch(ctx)
+	if err != nil {
+		b.setErr(fmt.Errorf("resolve current epoch: %w", err))
+		return b
+	}
+	if current > math.MaxUint64-epochs {
+		b.setErr(fmt.Errorf("expiration epoch overflows: current %d + %d", current, epochs))
+		return b
+	}
+
+	return b.SetExpiration(ExpirationEpoch(current + epochs))
+}
+
 // SetGasBudget sets the gas budget for the transaction.
 func (b *Transaction) SetGasBudget(budget uint64) *Transaction {
 	if b == nil {
@@ -190,6 +237,11 @@ func (b *Transaction) PureU64(value uint64) Argument {
 	return b.pureEncoded(bytes, err)
 }
 
+// PureAmount adds a coin amount as a pure u64 input in base units.
+func (b *Transaction) PureAmount(amount types.CoinAmount) Argument {
+	return b.PureU64(amount.Raw())
+}
+
 // PureU128 adds a pure u128 input.
 func (b *Transaction) PureU128(value *big.Int) Argument {
 	if value == nil {
@@ -307,6 +359,15 @@ func (b *Transaction) SplitCoins(args SplitCoins) []Argument {
 	return results
 }
 
+// SplitCoinAmounts splits each amount off coin and returns the new coins.
+func (b *Transaction) SplitCoinAmounts(coin Argument, amounts ...types.CoinAmount) []Argument {
+	args := make([]Argument, len(amounts))
+	for i, amount := range amounts {
+		args[i] = b.PureAmount(amount)
+	}
+	return b.SplitCoins(SplitCoins{Coin: coin, Amounts: args})
+}
+
 // MergeCoins adds a merge-coins command.
 func (b *Transaction) MergeCoins(args MergeCoins) {
 	b.addCommand(Command{MergeCoins: &args})
@@ -319,7 +380,15 @@ func (b *Transaction) TransferObjects(args TransferObjects) {
 
 // MoveCall adds a Move call command and returns its result.
 func (b *Transaction) MoveCall(args MoveCall) Result {
-	call, err := args.toProgrammableMoveCall()
+	if len(args.Values) > 0 {
+		if len(args.Arguments) > 0 {
+			b.setErr(ErrMixedMoveCallArguments)
+			return Result{}
+		}
+		args.Arguments = b.moveCallValues(args.Values)
+	}
+
+	call, name, err := args.toProgrammableMoveCall()
 	if err != nil {
 		b.setErr(err)
 		return Result{}
@@ -329,6 +398,12 @@ func (b *Transaction) MoveCall(args MoveCall) Result {
 	if idx == nil {
 		return Result{}
 	}
+	if name != "" {
+		if b.packageNames == nil {
+			b.packageNames = make(map[int]string)
+		}
+		b.packageNames[int(*idx)] = name
+	}
 
 	return Result{Index: *idx}
 }
@@ -390,6 +465,18 @@ func (b *Transaction) Build(ctx context.Context, opts BuildOptions) (BuildResult
 		return BuildResult{}, b.err
 	}
 
+	if err := b.resolvePackageNames(ctx, opts); err != nil {
+		return BuildResult{}, err
+	}
+
+	if err := b.Validate(ctx, opts.Resolver); err != nil {
+		return BuildResult{}, err
+	}
+
+	if err := b.resolveValues(ctx, opts.Resolver); err != nil {
+		return BuildResult{}, err
+	}
+
 	resolvedInputs, err := b.resolveInputs(ctx, opts.Resolver)
 	if err != nil {
 		return BuildResult{}, err
@@ -417,6 +504,9 @@ func (b *Transaction) Build(ctx context.Context, opts BuildOptions) (BuildResult
 		fallback := ExpirationNone()
 		expiration = &fallback
 	}
+	if err := checkExpiration(ctx, opts, *expiration); err != nil {
+		return BuildResult{}, err
+	}
 
 	if !b.hasFullTransaction() && opts.GasResolver != nil {
 		if err = b.resolveGas(ctx, opts.GasResolver, kind, *expiration); err != nil {
@@ -448,6 +538,98 @@ func (b *Transaction) Build(ctx context.Context, opts BuildOptions) (BuildResult
 	return result, nil
 }
 
+// resolvePackageNames fills in the package of every Move call that named
+// its package, resolving each distinct name once.
+func (b *Transaction) resolvePackageNames(ctx context.Context, opts BuildOptions) error {
+	if len(b.packageNames) == 0 {
+		return nil
+	}
+
+	resolver := nameResolver(opts)
+	if resolver == nil {
+		return ErrNameResolverRequired
+	}
+
+	addresses := make(map[string]types.Address)
+	for idx, name := range b.packageNames {
+		address, ok := addresses[name]
+		if !ok {
+			resolved, err := resolver.ResolvePackageName(ctx, name)
+			if err != nil {
+				return fmt.Errorf("resolve package %s: %w", name, err)
+			}
+			if address, err = utils.ParseAddress(resolved); err != nil {
+				return fmt.Errorf("resolve package %s: %w", name, err)
+			}
+			addresses[name] = address
+		}
+
+		call := *b.commands[idx].MoveCall
+		call.Package = address
+		b.commands[idx] = Command{MoveCall: &call}
+	}
+	b.packageNames = nil
+	return nil
+}
+
+// ResolveMoveCallTarget replaces the package name of a Move call target such
+// as "@suifrens/core::mint::mint" with its address, using the name resolver
+// of opts. Targets that use an address are returned unchanged.
+func ResolveMoveCallTarget(ctx context.Context, opts BuildOptions, target string) (string, error) {
+	pkg, rest, found := strings.Cut(target, "::")
+	if !found || !isPackageName(pkg) {
+		return target, nil
+	}
+
+	resolver := nameResolver(opts)
+	if resolver == nil {
+		return "", ErrNameResolverRequired
+	}
+	address, err := resolver.ResolvePackageName(ctx, pkg)
+	if err != nil {
+		return "", fmt.Errorf("resolve package %s: %w", pkg, err)
+	}
+	return address + "::" + rest, nil
+}
+
+func nameResolver(opts BuildOptions) NameResolver {
+	if opts.NameResolver != nil {
+		return opts.NameResolver
+	}
+	if r, ok := opts.Resolver.(NameResolver); ok {
+		return r
+	}
+	return nil
+}
+
+// checkExpiration rejects an epoch expiration that is already in the past.
+func checkExpiration(ctx context.Context, opts BuildOptions, expiration TransactionExpiration) error {
+	if expiration.Epoch == nil {
+		return nil
+	}
+
+	resolver := opts.EpochResolver
+	if resolver == nil {
+		if r, ok := opts.Resolver.(EpochResolver); ok {
+			resolver = r
+		} else if r, ok := opts.GasResolver.(EpochResolver); ok {
+			resolver = r
+		}
+	}
+	if resolver == nil {
+		return nil
+	}
+
+	current, err := resolver.ResolveCurrentEpoch(ctx)
+	if err != nil {
+		return fmt.Errorf("resolve current epoch: %w", err)
+	}
+	if current > *expiration.Epoch {
+		return fmt.Errorf("%w: expired at epoch %d, current epoch is %d", ErrTransactionExpired, *expiration.Epoch, current)
+	}
+	return nil
+}
+
 func (b *Transaction) addInput(in input) Argument {
 	if b.err != nil {
 		return Argument{}
@@ -607,19 +789,23 @@ func trimTxContext(params []MoveParameter) []MoveParameter {
 	if len(params) == 0 {
 		return params
 	}
-	last := params[len(params)-1]
-	if last.TypeName == "0x2::tx_context::TxContext" {
+	if isTxContext(params[len(params)-1]) {
 		return params[:len(params)-1]
 	}
 	return params
 }
 
-func isReceivingType(param MoveParameter) bool {
-	if param.TypeName == "" {
-		return false
-	}
+// isTxContext reports whether param is 0x2::tx_context::TxContext, with
+// the framework address in short or full form.
+func isTxContext(param MoveParameter) bool {
+	return isDatatype(MoveTypeSignature{Kind: MoveTypeDatatype, TypeName: param.TypeName}, "0x2", "tx_context", "TxContext")
+}
 
-	return param.TypeName == "0x2::transfer::Receiving" || strings.HasPrefix(param.TypeName, "0x2::transfer::Receiving<")
+// isReceivingType reports whether param is 0x2::transfer::Receiving, with
+// the framework address in short or full form.
+func isReceivingType(param MoveParameter) bool {
+	name, _, _ := strings.Cut(param.TypeName, "<")
+	return isDatatype(MoveTypeSignature{Kind: MoveTypeDatatype, TypeName: name}, "0x2", "transfer", "Receiving")
 }
 
 func (b *Transaction) resolveInputs(ctx context.Context, resolver Resolver) ([]CallArg, error) {
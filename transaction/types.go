@@ -134,17 +134,61 @@ type ProgrammableTransaction struct {
 	Commands []Command
 }
 
-// TransactionKind represents the kind of transaction.
+// TransactionKind represents the kind of transaction. Users submit
+// programmable transactions; every other kind is a system transaction.
 type TransactionKind struct {
-	ProgrammableTransaction *ProgrammableTransaction
-	ChangeEpoch             *struct{}
-	Genesis                 *struct{}
-	ConsensusCommitPrologue *struct{}
+	ProgrammableTransaction       *ProgrammableTransaction
+	ChangeEpoch                   *ChangeEpoch
+	Genesis                       *GenesisTransaction
+	ConsensusCommitPrologue       *ConsensusCommitPrologue
+	AuthenticatorStateUpdate      *AuthenticatorStateUpdate
+	EndOfEpochTransaction         *[]EndOfEpochTransactionKind
+	RandomnessStateUpdate         *RandomnessStateUpdate
+	ConsensusCommitPrologueV2     *ConsensusCommitPrologueV2
+	ConsensusCommitPrologueV3     *ConsensusCommitPrologueV3
+	ConsensusCommitPrologueV4     *ConsensusCommitPrologueV4
+	ProgrammableSystemTransaction *ProgrammableTransaction
 }
 
 // IsBcsEnum marks TransactionKind as a BCS enum.
 func (TransactionKind) IsBcsEnum() {}
 
+// Name returns the name of the kind, such as "ProgrammableTransaction" or
+// "ConsensusCommitPrologueV3", or "" if no variant is set.
+func (k TransactionKind) Name() string {
+	switch {
+	case k.ProgrammableTransaction != nil:
+		return "ProgrammableTransaction"
+	case k.ChangeEpoch != nil:
+		return "ChangeEpoch"
+	case k.Genesis != nil:
+		return "Genesis"
+	case k.ConsensusCommitPrologue != nil:
+		return "ConsensusCommitPrologue"
+	case k.AuthenticatorStateUpdate != nil:
+		return "AuthenticatorStateUpdate"
+	case k.EndOfEpochTransaction != nil:
+		return "EndOfEpochTransaction"
+	case k.RandomnessStateUpdate != nil:
+		return "RandomnessStateUpdate"
+	case k.ConsensusCommitPrologueV2 != nil:
+		return "ConsensusCommitPrologueV2"
+	case k.ConsensusCommitPrologueV3 != nil:
+		return "ConsensusCommitPrologueV3"
+	case k.ConsensusCommitPrologueV4 != nil:
+		return "ConsensusCommitPrologueV4"
+	case k.ProgrammableSystemTransaction != nil:
+		return "ProgrammableSystemTransaction"
+	}
+	return ""
+}
+
+// IsSystem reports whether the kind is a system transaction, created by
+// validators rather than submitted by a user.
+func (k TransactionKind) IsSystem() bool {
+	return k.ProgrammableTransaction == nil && k.Name() != ""
+}
+
 // TransactionExpiration represents the transaction expiration.
 type TransactionExpiration struct {
 	None  *struct{}
@@ -212,6 +256,37 @@ type MoveFunction struct {
 type MoveParameter struct {
 	Reference ReferenceKind
 	TypeName  string
+	// Body is the full parameter type, used to encode native Go values. It is
+	// optional; resolvers that leave it nil only support pre-built arguments.
+	Body *MoveTypeSignature
+}
+
+// MoveTypeKind identifies the shape of a type in a Move function signature.
+type MoveTypeKind int
+
+const (
+	MoveTypeUnknown MoveTypeKind = iota
+	MoveTypeAddress
+	MoveTypeBool
+	MoveTypeU8
+	MoveTypeU16
+	MoveTypeU32
+	MoveTypeU64
+	MoveTypeU128
+	MoveTypeU256
+	MoveTypeVector
+	MoveTypeDatatype
+	MoveTypeParameter
+)
+
+// MoveTypeSignature describes a (possibly generic) Move type. TypeName is set
+// for datatypes, TypeArguments for vectors and generic datatypes, and
+// TypeParameter for references to the function's type parameters.
+type MoveTypeSignature struct {
+	Kind          MoveTypeKind
+	TypeName      string
+	TypeArguments []MoveTypeSignature
+	TypeParameter uint32
 }
 
 type PackageMetadata struct {
@@ -235,6 +310,20 @@ type GasResolver interface {
 	ResolveGasPayment(ctx context.Context, owner types.Address, budget uint64) ([]types.ObjectRef, error)
 }
 
+// EpochResolver reports the network's current epoch. It is used to set
+// epoch-relative expirations and to reject already-expired transactions at
+// build time.
+type EpochResolver interface {
+	ResolveCurrentEpoch(ctx context.Context) (uint64, error)
+}
+
+// NameResolver maps Move Registry names such as "@suifrens/core" to package
+// addresses. Build uses it for Move calls whose target names a package
+// instead of giving its address; mvr.Client implements it.
+type NameResolver interface {
+	ResolvePackageName(ctx context.Context, name string) (string, error)
+}
+
 type TransactionSigner interface {
 	SignTransaction(txBytes []byte) ([]byte, error)
 	SuiAddress() (string, error)
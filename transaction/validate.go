@@ -0,0 +1,212 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const (
+	// BaseTransactionCost is the fixed computation cost of a transaction in
+	// gas units. The network rejects budgets below this cost at the
+	// transaction's gas price.
+	BaseTransactionCost = 1_000
+	// MaxGasBudget is the largest gas budget the network accepts, in MIST.
+	MaxGasBudget = 50_000_000_000
+)
+
+// Violation is one problem found by Validate.
+type Violation struct {
+	// Command is the index of the offending command, or -1 for problems
+	// with the transaction as a whole, such as its gas budget.
+	Command int
+	Message string
+}
+
+func (v Violation) String() string {
+	if v.Command < 0 {
+		return v.Message
+	}
+	return fmt.Sprintf("command %d: %s", v.Command, v.Message)
+}
+
+// ValidationError lists every problem Validate found. It matches
+// ErrInvalidTransaction with errors.Is.
+type ValidationError struct {
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		parts[i] = v.String()
+	}
+	return fmt.Sprintf("%s: %s", ErrInvalidTransaction, strings.Join(parts, "; "))
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidTransaction
+}
+
+// Validate checks the transaction for mistakes the network would reject
+// with a less helpful error: arguments that refer to missing inputs or to
+// results of the same or later commands, commands without the values they
+// need, and gas budgets outside the accepted range. When resolver is not
+// nil, Move calls are also checked against the number of parameters of the
+// on-chain function; an error from the resolver is returned, wrapped with the
+// command's index, since the check could not run.
+//
+// Build runs Validate with its Resolver. A *ValidationError is returned
+// when any check fails.
+func (b *Transaction) Validate(ctx context.Context, resolver Resolver) error {
+	if b == nil {
+		return ErrNilTransaction
+	}
+	if b.err != nil {
+		return b.err
+	}
+
+	var violations []Violation
+	report := func(command int, format string, args ...any) {
+		violations = append(violations, Violation{Command: command, Message: fmt.Sprintf(format, args...)})
+	}
+
+	for i, cmd := range b.commands {
+		check := func(what string, arg Argument) {
+			if msg := b.checkArgument(i, arg); msg != "" {
+				report(i, "%s %s", what, msg)
+			}
+		}
+		checkAll := func(what string, args []Argument) {
+			for j, arg := range args {
+				check(fmt.Sprintf("%s %d", what, j), arg)
+			}
+		}
+
+		switch {
+		case cmd.MoveCall != nil:
+			checkAll("argument", cmd.MoveCall.Arguments)
+		case cmd.TransferObjects != nil:
+			if len(cmd.TransferObjects.Objects) == 0 {
+				report(i, "TransferObjects has no objects to transfer")
+			}
+			checkAll("object", cmd.TransferObjects.Objects)
+			check("recipient", cmd.TransferObjects.Address)
+		case cmd.SplitCoins != nil:
+			if len(cmd.SplitCoins.Amounts) == 0 {
+				report(i, "SplitCoins has no amounts")
+			}
+			check("coin", cmd.SplitCoins.Coin)
+			checkAll("amount", cmd.SplitCoins.Amounts)
+		case cmd.MergeCoins != nil:
+			if len(cmd.MergeCoins.Sources) == 0 {
+				report(i, "MergeCoins has no coins to merge")
+			}
+			check("destination", cmd.MergeCoins.Destination)
+			checkAll("source", cmd.MergeCoins.Sources)
+		case cmd.MakeMoveVec != nil:
+			if len(cmd.MakeMoveVec.Elements) == 0 && cmd.MakeMoveVec.Type.None {
+				report(i, "MakeMoveVec needs an element type when it has no elements")
+			}
+			checkAll("element", cmd.MakeMoveVec.Elements)
+		case cmd.Publish != nil:
+			if len(cmd.Publish.Modules) == 0 {
+				report(i, "Publish has no modules")
+			}
+		case cmd.Upgrade != nil:
+			if len(cmd.Upgrade.Modules) == 0 {
+				report(i, "Upgrade has no modules")
+			}
+			check("ticket", cmd.Upgrade.Ticket)
+		}
+	}
+
+	if resolver != nil {
+		if err := b.checkMoveCallArity(ctx, resolver, report); err != nil {
+			return err
+		}
+	}
+
+	if budget := b.gas.Budget; budget != nil {
+		price := uint64(1)
+		if b.gas.Price != nil {
+			price = max(*b.gas.Price, 1)
+		}
+		if minimum := BaseTransactionCost * price; *budget < minimum {
+			report(-1, "gas budget %d is below the minimum of %d at gas price %d", *budget, minimum, price)
+		}
+		if *budget > MaxGasBudget {
+			report(-1, "gas budget %d is above the maximum of %d", *budget, uint64(MaxGasBudget))
+		}
+	}
+
+	if len(violations) > 0 {
+		return &ValidationError{Violations: violations}
+	}
+	return nil
+}
+
+// checkArgument describes what is wrong with arg used by command index, or
+// returns "" when it is valid.
+func (b *Transaction) checkArgument(index int, arg Argument) string {
+	switch {
+	case arg.Input != nil:
+		if int(*arg.Input) >= len(b.inputs) {
+			return fmt.Sprintf("refers to input %d, but the transaction has %d inputs", *arg.Input, len(b.inputs))
+		}
+	case arg.Result != nil:
+		return b.checkResult(index, int(*arg.Result))
+	case arg.NestedResult != nil:
+		if msg := b.checkResult(index, int(arg.NestedResult.Index)); msg != "" {
+			return msg
+		}
+		if split := b.commands[arg.NestedResult.Index].SplitCoins; split != nil && int(arg.NestedResult.ResultIndex) >= len(split.Amounts) {
+			return fmt.Sprintf("refers to coin %d of command %d, which splits %d coins", arg.NestedResult.ResultIndex, arg.NestedResult.Index, len(split.Amounts))
+		}
+	case arg.GasCoin == nil:
+		return "is empty"
+	}
+	return ""
+}
+
+func (b *Transaction) checkResult(index, result int) string {
+	if result >= index {
+		return fmt.Sprintf("refers to the result of command %d, which does not run before it", result)
+	}
+	if cmd := b.commands[result]; cmd.TransferObjects != nil || cmd.MergeCoins != nil {
+		return fmt.Sprintf("refers to the result of command %d, which has no result", result)
+	}
+	return ""
+}
+
+// checkMoveCallArity compares the arguments of every Move call with its
+// function's parameters, resolving each function once.
+func (b *Transaction) checkMoveCallArity(ctx context.Context, resolver Resolver, report func(int, string, ...any)) error {
+	signatures := make(map[string]*MoveFunction)
+	for i, cmd := range b.commands {
+		call := cmd.MoveCall
+		if call == nil {
+			continue
+		}
+		if _, named := b.packageNames[i]; named {
+			continue
+		}
+		target := fmt.Sprintf("%s::%s::%s", call.Package.String(), call.Module, call.Function)
+		sig, ok := signatures[target]
+		if !ok {
+			resolved, err := resolver.ResolveMoveFunction(ctx, call.Package.String(), call.Module, call.Function)
+			if err != nil {
+				return fmt.Errorf("command %d: resolve %s: %w", i, target, err)
+			}
+			sig = resolved
+			signatures[target] = sig
+		}
+		if sig == nil {
+			continue
+		}
+		if params := trimTxContext(sig.Parameters); len(params) != len(call.Arguments) {
+			report(i, "Move call %s expects %d arguments, got %d", target, len(params), len(call.Arguments))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateReportsEveryViolation(t *testing.T) {
+	tx := New()
+	coins := tx.SplitCoins(SplitCoins{Coin: tx.Gas(), Amounts: []Argument{tx.PureU64(1)}})
+	missing := uint16(9)
+	tx.TransferObjects(TransferObjects{Address: tx.PureAddress("0x1")})
+	tx.MergeCoins(MergeCoins{Destination: coins[0], Sources: []Argument{Result{Index: 1}.Arg(), {Input: &missing}}})
+	tx.TransferObjects(TransferObjects{Objects: []Argument{Result{Index: 0}.At(3), Result{Index: 5}.Arg()}, Address: tx.PureAddress("0x1")})
+	tx.SetGasPrice(1000).SetGasBudget(5000)
+
+	_, err := tx.Build(context.Background(), BuildOptions{})
+	var validation *ValidationError
+	if !errors.As(err, &validation) || !errors.Is(err, ErrInvalidTransaction) {
+		t.Fatalf("expected a ValidationError, got %v", err)
+	}
+	want := []string{
+		"command 1: TransferObjects has no objects to transfer",
+		"command 2: source 0 refers to the result of command 1, which has no result",
+		"command 2: source 1 refers to input 9, but the transaction has 3 inputs",
+		"command 3: object 0 refers to coin 3 of command 0, which splits 1 coins",
+		"command 3: object 1 refers to the result of command 5, which does not run before it",
+		"gas budget 5000 is below the minimum of 1000000 at gas price 1000",
+	}
+	if len(validation.Violations) != len(want) {
+		t.Fatalf("unexpected violations:\n%v", err)
+	}
+	for i, v := range validation.Violations {
+		if v.String() != want[i] {
+			t.Errorf("violation %d = %q, want %q", i, v, want[i])
+		}
+	}
+}
+
+func TestValidateMoveCallArity(t *testing.T) {
+	resolver := stubResolver{move: &MoveFunction{Parameters: []MoveParameter{
+		{TypeName: "0x2::coin::Coin"},
+		{},
+		{Reference: ReferenceMutable, TypeName: "0x0000000000000000000000000000000000000000000000000000000000000002::tx_context::TxContext"},
+	}}}
+
+	tx := New()
+	tx.MoveCall(MoveCall{Target: "0x2::pay::split", Arguments: []Argument{tx.PureU64(1)}})
+	err := tx.Validate(context.Background(), resolver)
+	if err == nil || !strings.Contains(err.Error(), "command 0: Move call 0x0000000000000000000000000000000000000000000000000000000000000002::pay::split expects 2 arguments, got 1") {
+		t.Fatalf("expected an arity violation, got %v", err)
+	}
+
+	tx = New()
+	tx.MoveCall(MoveCall{Target: "0x2::pay::split", Arguments: []Argument{tx.PureU64(1), tx.PureU64(2)}})
+	if err := tx.Validate(context.Background(), resolver); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	// A resolver failure means the check did not run.
+	if err := tx.Validate(context.Background(), stubResolver{}); err == nil || !strings.Contains(err.Error(), "command 0: resolve") {
+		t.Fatalf("expected the resolver error, got %v", err)
+	}
+	if err := tx.Validate(context.Background(), nil); err != nil {
+		t.Fatalf("validate without resolver: %v", err)
+	}
+}
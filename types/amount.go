@@ -0,0 +1,207 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+
+	"github.com/open-move/sui-go-sdk/internal/units"
+)
+
+const (
+	// SUIDecimals is the number of decimals of the SUI coin.
+	SUIDecimals = 9
+	// MISTPerSUI is the number of MIST in one SUI.
+	MISTPerSUI = 1_000_000_000
+
+	// maxDecimals keeps 10^decimals within uint64.
+	maxDecimals = 19
+)
+
+var (
+	ErrAmountOverflow  = errors.New("coin amount overflows uint64")
+	ErrAmountUnderflow = errors.New("coin amount would be negative")
+	ErrDecimalMismatch = errors.New("coin amounts have different decimals")
+)
+
+// CoinAmount is an amount of a coin in its smallest unit (MIST for SUI)
+// together with the coin's number of decimals, so it can be parsed from and
+// rendered as a human readable value without floating point. The zero
+// value is zero MIST.
+type CoinAmount struct {
+	raw      uint64
+	decimals uint8
+}
+
+// NewCoinAmount returns raw base units of a coin with the given decimals.
+func NewCoinAmount(raw uint64, decimals uint8) CoinAmount {
+	return CoinAmount{raw: raw, decimals: min(decimals, maxDecimals)}
+}
+
+// FromMIST returns an amount of SUI given in MIST.
+func FromMIST(mist uint64) CoinAmount {
+	return CoinAmount{raw: mist, decimals: SUIDecimals}
+}
+
+// FromSUI parses a decimal amount of SUI, such as "1.5" or "0.000000001".
+func FromSUI(value string) (CoinAmount, error) {
+	return ParseCoinAmount(value, SUIDecimals)
+}
+
+// MustFromSUI is FromSUI for constants; it panics on invalid input.
+func MustFromSUI(value string) CoinAmount {
+	amount, err := FromSUI(value)
+	if err != nil {
+		panic(err)
+	}
+	return amount
+}
+
+// ParseCoinAmount parses a decimal amount of a coin with the given
+// decimals. It rejects negative values, more fractional digits than the coin
+// has, and amounts that do not fit in a u64.
+func ParseCoinAmount(value string, decimals uint8) (CoinAmount, error) {
+	if decimals > maxDecimals {
+		return CoinAmount{}, fmt.Errorf("coin amount: %d decimals not supported", decimals)
+	}
+	amount, err := units.Parse(value, int(decimals))
+	if err != nil {
+		return CoinAmount{}, fmt.Errorf("coin amount: %w", err)
+	}
+	if amount.Sign() < 0 {
+		return CoinAmount{}, fmt.Errorf("coin amount: invalid value %q", value)
+	}
+	if !amount.IsUint64() {
+		return CoinAmount{}, fmt.Errorf("coin amount: %q: %w", value, ErrAmountOverflow)
+	}
+	return CoinAmount{raw: amount.Uint64(), decimals: decimals}, nil
+}
+
+// Raw returns the amount in base units.
+func (a CoinAmount) Raw() uint64 {
+	return a.raw
+}
+
+// MIST returns the amount in base units; it is Raw named for SUI amounts.
+func (a CoinAmount) MIST() uint64 {
+	return a.raw
+}
+
+// Decimals returns the coin's number of decimals.
+func (a CoinAmount) Decimals() uint8 {
+	return a.decimals
+}
+
+// IsZero reports whether the amount is zero.
+func (a CoinAmount) IsZero() bool {
+	return a.raw == 0
+}
+
+// Add returns a + b.
+func (a CoinAmount) Add(b CoinAmount) (CoinAmount, error) {
+	if err := a.sameCoin(b); err != nil {
+		return CoinAmount{}, err
+	}
+	sum, carry := bits.Add64(a.raw, b.raw, 0)
+	if carry != 0 {
+		return CoinAmount{}, ErrAmountOverflow
+	}
+	return CoinAmount{raw: sum, decimals: a.decimals}, nil
+}
+
+// Sub returns a - b.
+func (a CoinAmount) Sub(b CoinAmount) (CoinAmount, error) {
+	if err := a.sameCoin(b); err != nil {
+		return CoinAmount{}, err
+	}
+	if b.raw > a.raw {
+		return CoinAmount{}, ErrAmountUnderflow
+	}
+	return CoinAmount{raw: a.raw - b.raw, decimals: a.decimals}, nil
+}
+
+// Mul returns the amount multiplied by n.
+func (a CoinAmount) Mul(n uint64) (CoinAmount, error) {
+	hi, lo := bits.Mul64(a.raw, n)
+	if hi != 0 {
+		return CoinAmount{}, ErrAmountOverflow
+	}
+	return CoinAmount{raw: lo, decimals: a.decimals}, nil
+}
+
+// Div returns the amount divided by n, rounded down, and the remainder in
+// base units.
+func (a CoinAmount) Div(n uint64) (CoinAmount, uint64, error) {
+	if n == 0 {
+		return CoinAmount{}, 0, errors.New("coin amount: division by zero")
+	}
+	return CoinAmount{raw: a.raw / n, decimals: a.decimals}, a.raw % n, nil
+}
+
+// Cmp compares two amounts of the same coin, returning -1, 0 or +1.
+// Amounts with different decimals are compared by value.
+func (a CoinAmount) Cmp(b CoinAmount) int {
+	if a.decimals != b.decimals {
+		// Scale the amount with fewer decimals; if that overflows it is
+		// the larger one.
+		if a.decimals < b.decimals {
+			return -b.Cmp(a)
+		}
+		scaled, ok := scale(b.raw, a.decimals-b.decimals)
+		if !ok {
+			return -1
+		}
+		b = CoinAmount{raw: scaled, decimals: a.decimals}
+	}
+	switch {
+	case a.raw < b.raw:
+		return -1
+	case a.raw > b.raw:
+		return 1
+	}
+	return 0
+}
+
+// LessThan reports whether a < b.
+func (a CoinAmount) LessThan(b CoinAmount) bool {
+	return a.Cmp(b) < 0
+}
+
+// GreaterThan reports whether a > b.
+func (a CoinAmount) GreaterThan(b CoinAmount) bool {
+	return a.Cmp(b) > 0
+}
+
+// String renders the amount in whole units with trailing zeros trimmed,
+// e.g. "1.5" for 1_500_000_000 MIST.
+func (a CoinAmount) String() string {
+	return units.Format(new(big.Int).SetUint64(a.raw), int(a.decimals))
+}
+
+// Format renders the amount followed by symbol, e.g. "1.5 SUI".
+func (a CoinAmount) Format(symbol string) string {
+	if symbol == "" {
+		return a.String()
+	}
+	return a.String() + " " + symbol
+}
+
+func (a CoinAmount) sameCoin(b CoinAmount) error {
+	if a.decimals != b.decimals {
+		return fmt.Errorf("%w: %d and %d", ErrDecimalMismatch, a.decimals, b.decimals)
+	}
+	return nil
+}
+
+// scale multiplies v by 10^by, reporting false on overflow.
+func scale(v uint64, by uint8) (uint64, bool) {
+	for range by {
+		if v > math.MaxUint64/10 {
+			return 0, false
+		}
+		v *= 10
+	}
+	return v, true
+}
@@ -0,0 +1,78 @@
+package types
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseCoinAmount(t *testing.T) {
+	cases := []struct {
+		value    string
+		decimals uint8
+		raw      uint64
+		text     string
+	}{
+		{"1.5", SUIDecimals, 1_500_000_000, "1.5"},
+		{"0.000000001", SUIDecimals, 1, "0.000000001"},
+		{".25", 6, 250_000, "0.25"},
+		{"42", 0, 42, "42"},
+		{"0", SUIDecimals, 0, "0"},
+		{"18446744073.709551615", SUIDecimals, 18446744073709551615, "18446744073.709551615"},
+	}
+	for _, tc := range cases {
+		amount, err := ParseCoinAmount(tc.value, tc.decimals)
+		if err != nil {
+			t.Fatalf("parse %q: %v", tc.value, err)
+		}
+		if amount.Raw() != tc.raw || amount.String() != tc.text {
+			t.Fatalf("parse %q: got %d %q, want %d %q", tc.value, amount.Raw(), amount.String(), tc.raw, tc.text)
+		}
+	}
+
+	for _, bad := range []string{"", ".", "-1", "1.0000000001", "1e9", "18446744073.709551616"} {
+		if _, err := FromSUI(bad); err == nil {
+			t.Fatalf("expected %q to be rejected", bad)
+		}
+	}
+	if _, err := FromSUI("18446744074"); !errors.Is(err, ErrAmountOverflow) {
+		t.Fatalf("expected ErrAmountOverflow, got %v", err)
+	}
+}
+
+func TestCoinAmountArithmetic(t *testing.T) {
+	a := MustFromSUI("1.5")
+	b := FromMIST(500_000_000)
+
+	sum, err := a.Add(b)
+	if err != nil || sum.String() != "2" || sum.Format("SUI") != "2 SUI" {
+		t.Fatalf("add: %v %v", sum, err)
+	}
+	diff, err := a.Sub(b)
+	if err != nil || diff.MIST() != MISTPerSUI {
+		t.Fatalf("sub: %v %v", diff, err)
+	}
+	if _, err := b.Sub(a); !errors.Is(err, ErrAmountUnderflow) {
+		t.Fatalf("expected ErrAmountUnderflow, got %v", err)
+	}
+	if _, err := FromMIST(1 << 63).Mul(2); !errors.Is(err, ErrAmountOverflow) {
+		t.Fatalf("expected ErrAmountOverflow, got %v", err)
+	}
+	if _, err := FromMIST(^uint64(0)).Add(FromMIST(1)); !errors.Is(err, ErrAmountOverflow) {
+		t.Fatalf("expected ErrAmountOverflow, got %v", err)
+	}
+	if _, err := a.Add(NewCoinAmount(1, 6)); !errors.Is(err, ErrDecimalMismatch) {
+		t.Fatalf("expected ErrDecimalMismatch, got %v", err)
+	}
+	third, rem, err := FromMIST(10).Div(3)
+	if err != nil || third.MIST() != 3 || rem != 1 {
+		t.Fatalf("div: %v %d %v", third, rem, err)
+	}
+
+	if !b.LessThan(a) || !a.GreaterThan(b) || a.Cmp(MustFromSUI("1.50")) != 0 {
+		t.Fatal("unexpected comparison")
+	}
+	// 1.5 with 6 decimals equals 1.5 SUI by value.
+	if NewCoinAmount(1_500_000, 6).Cmp(a) != 0 {
+		t.Fatal("expected equal values across decimals")
+	}
+}
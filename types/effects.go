@@ -0,0 +1,86 @@
+package types
+
+// TransactionEffects is a backend-independent summary of a transaction's
+// effects.
+type TransactionEffects struct {
+	TransactionDigest Digest
+	Success           bool
+	// Error describes the failure when Success is false.
+	Error          string
+	Epoch          uint64
+	LamportVersion uint64
+	GasUsed        GasCostSummary
+	// GasObject is the gas coin after execution.
+	GasObject    *ObjectRef
+	Dependencies []Digest
+	Changes      []ObjectChange
+}
+
+// GasCostSummary breaks down the gas a transaction was charged, in MIST.
+type GasCostSummary struct {
+	ComputationCost         uint64
+	StorageCost             uint64
+	StorageRebate           uint64
+	NonRefundableStorageFee uint64
+}
+
+// NetCost returns computation plus storage minus the storage rebate. It is
+// negative when the transaction freed more storage than it used.
+func (g GasCostSummary) NetCost() int64 {
+	return int64(g.ComputationCost) + int64(g.StorageCost) - int64(g.StorageRebate)
+}
+
+// ObjectChangeKind classifies how a transaction changed an object.
+type ObjectChangeKind int
+
+const (
+	ObjectChangeUnknown ObjectChangeKind = iota
+	ObjectCreated
+	ObjectMutated
+	ObjectDeleted
+	ObjectWrapped
+	ObjectUnwrapped
+	PackagePublished
+)
+
+// String returns the change kind's name.
+func (k ObjectChangeKind) String() string {
+	switch k {
+	case ObjectCreated:
+		return "created"
+	case ObjectMutated:
+		return "mutated"
+	case ObjectDeleted:
+		return "deleted"
+	case ObjectWrapped:
+		return "wrapped"
+	case ObjectUnwrapped:
+		return "unwrapped"
+	case PackagePublished:
+		return "published"
+	}
+	return "unknown"
+}
+
+// ObjectChange describes one object touched by a transaction.
+type ObjectChange struct {
+	ObjectID   ObjectID
+	Kind       ObjectChangeKind
+	ObjectType string
+	// Input is the object before the transaction; nil for new objects.
+	Input *ObjectRef
+	// Output is the object after the transaction; nil when it no longer
+	// exists at the top level (deleted or wrapped).
+	Output *ObjectRef
+}
+
+// ChangesOfKind returns the changes of the given kind, in order.
+func (e *TransactionEffects) ChangesOfKind(kind ObjectChangeKind) []ObjectChange {
+	var out []ObjectChange
+	for _, change := range e.Changes {
+		if change.Kind == kind {
+			out = append(out, change)
+		}
+	}
+	return out
+}
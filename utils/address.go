@@ -3,6 +3,7 @@ package utils
 import (
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/open-move/sui-go-sdk/types"
@@ -10,6 +11,13 @@ import (
 
 var ErrInvalidAddress = errors.New("invalid sui address")
 
+const (
+	addressHexLength = 64
+	shortFormChars   = 4
+)
+
+// NormalizeAddress lowercases the input and left-pads short hex (e.g. "0x2")
+// to the canonical 0x-prefixed 64 character form.
 func NormalizeAddress(input string) (string, error) {
 	trimmed := strings.TrimPrefix(strings.ToLower(strings.TrimSpace(input)), "0x")
 
@@ -17,11 +25,11 @@ func NormalizeAddress(input string) (string, error) {
 		return "", ErrInvalidAddress
 	}
 
-	if len(trimmed) > 64 {
+	if len(trimmed) > addressHexLength {
 		return "", ErrInvalidAddress
 	}
 
-	padded := strings.Repeat("0", 64-len(trimmed)) + trimmed
+	padded := strings.Repeat("0", addressHexLength-len(trimmed)) + trimmed
 	if _, err := hex.DecodeString(padded); err != nil {
 		return "", ErrInvalidAddress
 	}
@@ -29,6 +37,12 @@ func NormalizeAddress(input string) (string, error) {
 	return "0x" + padded, nil
 }
 
+// IsValidSuiAddress reports whether input can be normalized into a Sui address.
+func IsValidSuiAddress(input string) bool {
+	_, err := NormalizeAddress(input)
+	return err == nil
+}
+
 // ParseAddress parses a hex string into a Sui Address type.
 func ParseAddress(input string) (types.Address, error) {
 	normalized, err := NormalizeAddress(input)
@@ -45,3 +59,36 @@ func ParseAddress(input string) (types.Address, error) {
 	copy(addr[:], decoded)
 	return addr, nil
 }
+
+// MustParseAddress is like ParseAddress but panics if the input is invalid.
+// It is intended for package-level constants such as framework addresses.
+func MustParseAddress(input string) types.Address {
+	addr, err := ParseAddress(input)
+	if err != nil {
+		panic(fmt.Sprintf("utils: parse address %q: %v", input, err))
+	}
+	return addr
+}
+
+// AddressFromBytes converts a 32-byte slice into a Sui Address.
+func AddressFromBytes(b []byte) (types.Address, error) {
+	if len(b) != len(types.Address{}) {
+		return types.Address{}, fmt.Errorf("%w: expected %d bytes, got %d", ErrInvalidAddress, len(types.Address{}), len(b))
+	}
+
+	var addr types.Address
+	copy(addr[:], b)
+	return addr, nil
+}
+
+// FormatShort renders an address as "0x1234…abcd" for display purposes.
+// Inputs that are not valid addresses are returned unchanged.
+func FormatShort(input string) string {
+	normalized, err := NormalizeAddress(input)
+	if err != nil {
+		return input
+	}
+
+	body := normalized[2:]
+	return "0x" + body[:shortFormChars] + "…" + body[len(body)-shortFormChars:]
+}
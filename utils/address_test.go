@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeAddressPadsShortForm(t *testing.T) {
+	got, err := NormalizeAddress("0x2")
+	if err != nil {
+		t.Fatalf("normalize: %v", err)
+	}
+
+	want := "0x0000000000000000000000000000000000000000000000000000000000000002"
+	if got != want {
+		t.Fatalf("got %s want %s", got, want)
+	}
+}
+
+func TestIsValidSuiAddress(t *testing.T) {
+	cases := map[string]bool{
+		"0x2":  true,
+		"0xAB": true,
+		"":     false,
+		"0x":   false,
+		"0xzz": false,
+		"0x" + "11111111111111111111111111111111111111111111111111111111111111111": false,
+	}
+
+	for input, want := range cases {
+		if got := IsValidSuiAddress(input); got != want {
+			t.Fatalf("IsValidSuiAddress(%q) = %v, want %v", input, got, want)
+		}
+	}
+}
+
+func TestFormatShort(t *testing.T) {
+	got := FormatShort("0x1234" + strings.Repeat("0", 56) + "abcd")
+	if got != "0x1234…abcd" {
+		t.Fatalf("unexpected short form %q", got)
+	}
+
+	if got := FormatShort("not-an-address"); got != "not-an-address" {
+		t.Fatalf("expected invalid input to be returned unchanged, got %q", got)
+	}
+}
+
+func TestAddressFromBytes(t *testing.T) {
+	raw := make([]byte, 32)
+	raw[31] = 2
+
+	addr, err := AddressFromBytes(raw)
+	if err != nil {
+		t.Fatalf("from bytes: %v", err)
+	}
+	if addr != MustParseAddress("0x2") {
+		t.Fatalf("unexpected address %s", addr)
+	}
+
+	if _, err := AddressFromBytes(raw[:31]); !errors.Is(err, ErrInvalidAddress) {
+		t.Fatalf("expected ErrInvalidAddress, got %v", err)
+	}
+}
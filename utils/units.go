@@ -0,0 +1,20 @@
+package utils
+
+import (
+	"math/big"
+
+	"github.com/open-move/sui-go-sdk/internal/units"
+)
+
+// FormatUnits renders an integer amount of base units as a decimal string with
+// the given number of decimals, trimming trailing zeros (1234000000, 9 -> "1.234").
+func FormatUnits(amount *big.Int, decimals int) string {
+	return units.Format(amount, decimals)
+}
+
+// ParseUnits converts a decimal string into base units with the given number
+// of decimals ("1.5", 9 -> 1500000000). It rejects values with more fractional
+// digits than decimals allows.
+func ParseUnits(value string, decimals int) (*big.Int, error) {
+	return units.Parse(value, decimals)
+}
@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestFormatUnits(t *testing.T) {
+	cases := []struct {
+		amount   int64
+		decimals int
+		want     string
+	}{
+		{1234000000, 9, "1.234"},
+		{1000000000, 9, "1"},
+		{5, 9, "0.000000005"},
+		{0, 9, "0"},
+		{-1500, 3, "-1.5"},
+		{42, 0, "42"},
+	}
+
+	for _, tc := range cases {
+		if got := FormatUnits(big.NewInt(tc.amount), tc.decimals); got != tc.want {
+			t.Fatalf("FormatUnits(%d, %d) = %q, want %q", tc.amount, tc.decimals, got, tc.want)
+		}
+	}
+}
+
+func TestParseUnits(t *testing.T) {
+	got, err := ParseUnits("1.5", 9)
+	if err != nil || got.Int64() != 1500000000 {
+		t.Fatalf("ParseUnits(1.5) = %v, %v", got, err)
+	}
+	if got, err := ParseUnits(".25", 2); err != nil || got.Int64() != 25 {
+		t.Fatalf("ParseUnits(.25) = %v, %v", got, err)
+	}
+	if _, err := ParseUnits("0.001", 2); err == nil {
+		t.Fatalf("expected precision error")
+	}
+	for _, bad := range []string{"abc", ".", "-"} {
+		if _, err := ParseUnits(bad, 2); err == nil {
+			t.Fatalf("expected %q to be rejected", bad)
+		}
+	}
+}
@@ -0,0 +1,350 @@
+// Package zksend creates and claims link-based transfers in the style of
+// zkSend. A link holds the secret key of a fresh Ed25519 address; creating
+// a link funds that address with SUI, coins and objects in one transaction,
+// and anyone holding the link can claim the assets to their own address.
+//
+// Links are bearer secrets: whoever has the URL can claim its contents.
+package zksend
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/open-move/sui-go-sdk/account"
+	"github.com/open-move/sui-go-sdk/cryptography/ed25519"
+	"github.com/open-move/sui-go-sdk/grpc"
+	"github.com/open-move/sui-go-sdk/keychain"
+	"github.com/open-move/sui-go-sdk/keypair"
+	v2 "github.com/open-move/sui-go-sdk/proto/sui/rpc/v2"
+	"github.com/open-move/sui-go-sdk/transaction"
+	"github.com/open-move/sui-go-sdk/types"
+	"github.com/open-move/sui-go-sdk/utils"
+)
+
+const (
+	// DefaultHost is the claim site links point to.
+	DefaultHost = "https://zksend.com"
+	// DefaultClaimGas is the MIST left on the link to pay for the claim
+	// transaction, so the recipient needs no SUI of their own.
+	DefaultClaimGas = 10_000_000
+
+	claimPath   = "/claim"
+	suiCoinType = "0x2::coin::Coin<0x2::sui::SUI>"
+)
+
+// ErrEmptyLink is returned when claiming a link that holds nothing, usually
+// because it was already claimed.
+var ErrEmptyLink = errors.New("zksend: link has no assets")
+
+// Link is a claimable transfer link.
+type Link struct {
+	keypair *ed25519.Keypair
+	address string
+	host    string
+}
+
+// NewLink generates a link with a fresh keypair pointing at DefaultHost.
+func NewLink() (*Link, error) {
+	kp, err := ed25519.Generate()
+	if err != nil {
+		return nil, err
+	}
+	return newLink(kp, DefaultHost)
+}
+
+// ParseLink reads a link from its URL, or from its bare secret as returned
+// by Secret. A "suiprivkey" encoded Ed25519 key is accepted as well.
+func ParseLink(link string) (*Link, error) {
+	link = strings.TrimSpace(link)
+	host := DefaultHost
+	secret := link
+	if u, err := url.Parse(link); err == nil && u.Scheme != "" && u.Host != "" {
+		host = u.Scheme + "://" + u.Host
+		secret = u.Fragment
+	}
+	secret = strings.TrimPrefix(secret, "$")
+	if secret == "" {
+		return nil, errors.New("zksend: link has no secret")
+	}
+
+	var seed []byte
+	if strings.HasPrefix(secret, "suiprivkey") {
+		parsed, err := keychain.DecodePrivateKey(secret)
+		if err != nil {
+			return nil, fmt.Errorf("zksend: %w", err)
+		}
+		if parsed.Scheme != keychain.SchemeEd25519 {
+			return nil, fmt.Errorf("zksend: link keys must be Ed25519, got scheme %d", parsed.Scheme)
+		}
+		seed = parsed.SecretKey
+	} else {
+		decoded, err := decodeSecret(secret)
+		if err != nil {
+			return nil, fmt.Errorf("zksend: decode secret: %w", err)
+		}
+		seed = decoded
+	}
+
+	kp, err := ed25519.FromSecretKey(seed)
+	if err != nil {
+		return nil, fmt.Errorf("zksend: %w", err)
+	}
+	return newLink(kp, host)
+}
+
+// decodeSecret accepts standard and URL-safe base64, padded or not, since
+// links are often re-encoded when shared.
+func decodeSecret(secret string) ([]byte, error) {
+	var lastErr error
+	for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding} {
+		decoded, err := enc.DecodeString(secret)
+		if err == nil {
+			return decoded, nil
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+func newLink(kp *ed25519.Keypair, host string) (*Link, error) {
+	address, err := kp.SuiAddress()
+	if err != nil {
+		return nil, err
+	}
+	return &Link{keypair: kp, address: address, host: strings.TrimSuffix(host, "/")}, nil
+}
+
+// Address returns the address that holds the link's assets.
+func (l *Link) Address() string {
+	return l.address
+}
+
+// Signer returns the signer of the link's address.
+func (l *Link) Signer() keypair.Signer {
+	return l.keypair
+}
+
+// Secret returns the base64 encoded secret key carried in the link URL.
+func (l *Link) Secret() string {
+	seed, _ := l.keypair.ExportSecret()
+	return base64.StdEncoding.EncodeToString(seed)
+}
+
+// URL returns the shareable link, with the secret in the URL fragment so it
+// is never sent to the claim site's server.
+func (l *Link) URL() string {
+	return l.host + claimPath + "#$" + l.Secret()
+}
+
+// WithHost returns a copy of the link pointing at another claim site.
+func (l *Link) WithHost(host string) *Link {
+	copied := *l
+	copied.host = strings.TrimSuffix(host, "/")
+	return &copied
+}
+
+// Assets lists what a new link is funded with.
+type Assets struct {
+	// Amount is the MIST sent to the link, on top of the claim gas.
+	Amount uint64
+	// Coins maps coin types to amounts for coins other than SUI.
+	Coins map[string]uint64
+	// Objects are IDs of objects owned by the sender to put in the link.
+	Objects []string
+}
+
+// Contents are the assets currently held by a link.
+type Contents struct {
+	// Balance is the link's SUI balance in MIST, including claim gas.
+	Balance uint64
+	// Objects are every object the link owns, SUI coins excluded.
+	Objects []*v2.Object
+}
+
+// Empty reports whether the link holds nothing worth claiming.
+func (c *Contents) Empty() bool {
+	return len(c.Objects) == 0 && c.Balance == 0
+}
+
+// Client creates and claims links over a gRPC client.
+type Client struct {
+	client   *grpc.Client
+	host     string
+	claimGas uint64
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHost sets the claim site for links created by the client.
+func WithHost(host string) Option {
+	return func(c *Client) {
+		c.host = host
+	}
+}
+
+// WithClaimGas sets the MIST reserved on new links for the claim
+// transaction.
+func WithClaimGas(amount uint64) Option {
+	return func(c *Client) {
+		c.claimGas = amount
+	}
+}
+
+// NewClient returns a Client using client to reach the network.
+func NewClient(client *grpc.Client, opts ...Option) *Client {
+	c := &Client{client: client, host: DefaultHost, claimGas: DefaultClaimGas}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// CreateLink generates a link and funds it from sender in a single
+// transaction: SUI is split from the sender's gas coins, other coins are
+// merged and split, and objects are transferred whole. The link always
+// receives the claim gas in addition to assets.Amount.
+func (c *Client) CreateLink(ctx context.Context, sender keypair.Signer, assets Assets) (*Link, *grpc.ExecutionResult, error) {
+	if c == nil || c.client == nil {
+		return nil, nil, errors.New("nil client")
+	}
+	if assets.Amount > ^uint64(0)-c.claimGas {
+		return nil, nil, errors.New("zksend: amount plus claim gas overflows uint64")
+	}
+	owner, err := account.New(sender, c.client)
+	if err != nil {
+		return nil, nil, err
+	}
+	link, err := NewLink()
+	if err != nil {
+		return nil, nil, err
+	}
+	link = link.WithHost(c.host)
+
+	tx := transaction.New()
+	tx.SetSender(owner.Address())
+	recipient := tx.PureAddress(link.Address())
+	for coinType, amount := range assets.Coins {
+		coins, err := c.client.SelectCoins(ctx, owner.Address(), coinType, amount)
+		if err != nil {
+			return nil, nil, fmt.Errorf("select %s: %w", coinType, err)
+		}
+		refs := make([]types.ObjectRef, len(coins))
+		for i, coin := range coins {
+			if refs[i], err = utils.ParseObjectRef(coin.GetObjectId(), coin.GetVersion(), coin.GetDigest()); err != nil {
+				return nil, nil, err
+			}
+		}
+		merged := tx.ConsolidateCoins(refs)
+		split := tx.SplitCoins(transaction.SplitCoins{Coin: merged, Amounts: []transaction.Argument{tx.PureU64(amount)}})
+		tx.TransferObjects(transaction.TransferObjects{Objects: split, Address: recipient})
+	}
+	if len(assets.Objects) > 0 {
+		objects := make([]transaction.Argument, len(assets.Objects))
+		for i, id := range assets.Objects {
+			objects[i] = tx.Object(id)
+		}
+		tx.TransferObjects(transaction.TransferObjects{Objects: objects, Address: recipient})
+	}
+	gas, err := c.client.PaySui(ctx, tx, []string{link.Address()}, []uint64{assets.Amount + c.claimGas}, grpc.WithCoinExclusions(assets.Objects...))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	built, err := tx.Build(ctx, transaction.BuildOptions{Resolver: grpc.NewResolver(c.client), GasResolver: gas})
+	if err != nil {
+		return nil, nil, err
+	}
+	signature, err := sender.SignTransaction(built.TransactionBytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("sign transaction: %w", err)
+	}
+	result, err := c.client.ExecuteTransactionBytes(ctx, built.TransactionBytes, [][]byte{signature}, &grpc.ExecuteOptions{WaitForCheckpoint: true})
+	if err != nil {
+		return nil, nil, err
+	}
+	return link, result, nil
+}
+
+// Contents returns what the link currently holds.
+func (c *Client) Contents(ctx context.Context, link *Link) (*Contents, error) {
+	if c == nil || c.client == nil {
+		return nil, errors.New("nil client")
+	}
+	gasCoins, err := c.client.ListOwnedObjects(ctx, link.Address(), suiCoinType, 0)
+	if err != nil {
+		return nil, err
+	}
+	owned, err := c.client.ListOwnedObjects(ctx, link.Address(), "", 0)
+	if err != nil {
+		return nil, err
+	}
+	balance, err := c.client.GetBalance(ctx, link.Address(), "0x2::sui::SUI")
+	if err != nil {
+		return nil, err
+	}
+
+	isGas := make(map[string]bool, len(gasCoins))
+	for _, coin := range gasCoins {
+		isGas[coin.GetObjectId()] = true
+	}
+	contents := &Contents{Balance: balance.GetBalance()}
+	for _, obj := range owned {
+		if !isGas[obj.GetObjectId()] {
+			contents.Objects = append(contents.Objects, obj)
+		}
+	}
+	return contents, nil
+}
+
+// Claim sweeps everything held by the link identified by secret (a link URL
+// or bare secret) to recipient. The link's own SUI pays for the claim and
+// the remainder is sent to recipient along with every other object.
+func (c *Client) Claim(ctx context.Context, secret string, recipient string) (*grpc.ExecutionResult, error) {
+	link, err := ParseLink(secret)
+	if err != nil {
+		return nil, err
+	}
+	return c.ClaimLink(ctx, link, recipient)
+}
+
+// ClaimLink is Claim for a parsed link.
+func (c *Client) ClaimLink(ctx context.Context, link *Link, recipient string) (*grpc.ExecutionResult, error) {
+	if recipient == "" {
+		return nil, errors.New("zksend: recipient is empty")
+	}
+	contents, err := c.Contents(ctx, link)
+	if err != nil {
+		return nil, err
+	}
+	if contents.Empty() {
+		return nil, ErrEmptyLink
+	}
+	if contents.Balance == 0 {
+		return nil, fmt.Errorf("zksend: link %s has no SUI to pay for the claim", link.Address())
+	}
+
+	holder, err := account.New(link.Signer(), c.client)
+	if err != nil {
+		return nil, err
+	}
+	tx := transaction.New()
+	tx.SetSender(holder.Address())
+	if len(contents.Objects) > 0 {
+		objects := make([]transaction.Argument, len(contents.Objects))
+		for i, obj := range contents.Objects {
+			objects[i] = tx.Object(obj.GetObjectId())
+		}
+		tx.TransferObjects(transaction.TransferObjects{Objects: objects, Address: tx.PureAddress(recipient)})
+	}
+	// Merges every SUI coin into the gas coin and sends what is left after
+	// gas to the recipient.
+	if err := c.client.PayAllSui(ctx, tx, recipient); err != nil {
+		return nil, err
+	}
+	return holder.Execute(ctx, tx)
+}
@@ -0,0 +1,110 @@
+package zksend
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/open-move/sui-go-sdk/cryptography/ed25519"
+	"github.com/open-move/sui-go-sdk/grpc"
+	"github.com/open-move/sui-go-sdk/keychain"
+	v2 "github.com/open-move/sui-go-sdk/proto/sui/rpc/v2"
+	"github.com/open-move/sui-go-sdk/utils"
+	googlegrpc "google.golang.org/grpc"
+)
+
+func TestLinkRoundTrip(t *testing.T) {
+	link, err := NewLink()
+	if err != nil {
+		t.Fatalf("new link: %v", err)
+	}
+	link = link.WithHost("https://gifts.example/")
+	if !strings.HasPrefix(link.URL(), "https://gifts.example/claim#$") {
+		t.Fatalf("unexpected url %s", link.URL())
+	}
+
+	seed, _ := link.keypair.ExportSecret()
+	bech, err := keychain.EncodePrivateKey(keychain.SchemeEd25519, seed)
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	for _, input := range []string{link.URL(), link.Secret(), "$" + link.Secret(), bech} {
+		parsed, err := ParseLink(input)
+		if err != nil {
+			t.Fatalf("parse %q: %v", input, err)
+		}
+		if parsed.Address() != link.Address() {
+			t.Fatalf("parse %q: address %s, want %s", input, parsed.Address(), link.Address())
+		}
+	}
+	if parsed, _ := ParseLink(link.URL()); parsed.URL() != link.URL() {
+		t.Fatalf("host not kept: %s", parsed.URL())
+	}
+
+	if _, err := ParseLink("https://zksend.com/claim"); err == nil {
+		t.Fatal("expected error for link without secret")
+	}
+	if _, err := ParseLink("c2hvcnQ="); err == nil {
+		t.Fatal("expected error for short secret")
+	}
+}
+
+type stubStateServer struct {
+	v2.UnimplementedStateServiceServer
+	gas     []*v2.Object
+	objects []*v2.Object
+	balance uint64
+}
+
+func (s *stubStateServer) ListOwnedObjects(_ context.Context, req *v2.ListOwnedObjectsRequest) (*v2.ListOwnedObjectsResponse, error) {
+	if req.GetObjectType() != "" {
+		return &v2.ListOwnedObjectsResponse{Objects: s.gas}, nil
+	}
+	return &v2.ListOwnedObjectsResponse{Objects: append(append([]*v2.Object(nil), s.gas...), s.objects...)}, nil
+}
+
+func (s *stubStateServer) GetBalance(_ context.Context, req *v2.GetBalanceRequest) (*v2.GetBalanceResponse, error) {
+	return &v2.GetBalanceResponse{Balance: &v2.Balance{CoinType: req.CoinType, Balance: utils.Ptr(s.balance)}}, nil
+}
+
+func TestContents(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	server := googlegrpc.NewServer()
+	state := &stubStateServer{}
+	v2.RegisterStateServiceServer(server, state)
+	go server.Serve(lis)
+	defer server.Stop()
+
+	client, err := grpc.NewClient(context.Background(), lis.Addr().String())
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	defer client.Close()
+	zk := NewClient(client)
+
+	kp, _ := ed25519.Generate()
+	link, err := newLink(kp, DefaultHost)
+	if err != nil {
+		t.Fatalf("link: %v", err)
+	}
+
+	if _, err := zk.ClaimLink(context.Background(), link, "0x1"); !errors.Is(err, ErrEmptyLink) {
+		t.Fatalf("expected ErrEmptyLink, got %v", err)
+	}
+
+	state.balance = 25_000_000
+	state.gas = []*v2.Object{{ObjectId: utils.Ptr("0xa")}}
+	state.objects = []*v2.Object{{ObjectId: utils.Ptr("0xb"), ObjectType: utils.Ptr("0x2::coin::Coin<0xc::usdc::USDC>")}}
+	contents, err := zk.Contents(context.Background(), link)
+	if err != nil {
+		t.Fatalf("contents: %v", err)
+	}
+	if contents.Empty() || contents.Balance != 25_000_000 || len(contents.Objects) != 1 || contents.Objects[0].GetObjectId() != "0xb" {
+		t.Fatalf("unexpected contents %+v", contents)
+	}
+}